@@ -139,12 +139,8 @@ func LoadOrCreateTrustKey(trustKeyPath string) (libtrust.PrivateKey, error) {
 		if err != nil {
 			return nil, fmt.Errorf("Error generating key: %s", err)
 		}
-		encodedKey, err := serializePrivateKey(trustKey, filepath.Ext(trustKeyPath))
-		if err != nil {
-			return nil, fmt.Errorf("Error serializing key: %s", err)
-		}
-		if err := ioutils.AtomicWriteFile(trustKeyPath, encodedKey, os.FileMode(0600)); err != nil {
-			return nil, fmt.Errorf("Error saving key file: %s", err)
+		if err := SaveTrustKey(trustKeyPath, trustKey); err != nil {
+			return nil, err
 		}
 	} else if err != nil {
 		return nil, fmt.Errorf("Error loading key file %s: %s", trustKeyPath, err)
@@ -152,6 +148,19 @@ func LoadOrCreateTrustKey(trustKeyPath string) (libtrust.PrivateKey, error) {
 	return trustKey, nil
 }
 
+// SaveTrustKey saves the libtrust key at the given path, as a JWK if the
+// extension of the path is .json or .jwk, or in PEM format otherwise.
+func SaveTrustKey(trustKeyPath string, trustKey libtrust.PrivateKey) error {
+	encodedKey, err := serializePrivateKey(trustKey, filepath.Ext(trustKeyPath))
+	if err != nil {
+		return fmt.Errorf("Error serializing key: %s", err)
+	}
+	if err := ioutils.AtomicWriteFile(trustKeyPath, encodedKey, os.FileMode(0600)); err != nil {
+		return fmt.Errorf("Error saving key file: %s", err)
+	}
+	return nil
+}
+
 func serializePrivateKey(key libtrust.PrivateKey, ext string) (encoded []byte, err error) {
 	if ext == ".json" || ext == ".jwk" {
 		encoded, err = json.Marshal(key)
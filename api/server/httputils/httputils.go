@@ -10,6 +10,8 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types/versions"
 )
 
 // APIVersionKey is the client's requested API version.
@@ -98,3 +100,12 @@ func VersionFromContext(ctx context.Context) (ver string) {
 	}
 	return val.(string)
 }
+
+// CheckMinVersion returns a bad request error if the API version of the
+// request is older than version, for the features added in version.
+func CheckMinVersion(ctx context.Context, version, feature string) error {
+	if v := VersionFromContext(ctx); v != "" && versions.LessThan(v, version) {
+		return errors.NewBadRequestError(fmt.Errorf("%s requires API version %s or newer", feature, version))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package httputils
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestCheckMinVersion(t *testing.T) {
+	for _, version := range []string{"", "1.25", "1.26"} {
+		ctx := context.WithValue(context.Background(), APIVersionKey, version)
+		if err := CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+			t.Fatalf("Unexpected error for API version %q: %v", version, err)
+		}
+	}
+	ctx := context.WithValue(context.Background(), APIVersionKey, "1.24")
+	if err := CheckMinVersion(ctx, "1.25", "pods"); err == nil {
+		t.Fatal("Expected an error for API version 1.24")
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/server/httputils"
+)
+
+// connStateTracker returns a hook of the connection states which limits
+// the time a client has to send the headers of its first request, and the
+// time a connection stays idle between two requests. The limits are
+// removed once the headers of a request are read, so that neither the
+// bodies of the requests nor the hijacked connections are limited.
+func connStateTracker(readTimeout, idleTimeout time.Duration) func(net.Conn, http.ConnState) {
+	deadline := func(timeout time.Duration) time.Time {
+		if timeout > 0 {
+			return time.Now().Add(timeout)
+		}
+		return time.Time{}
+	}
+	return func(conn net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			conn.SetReadDeadline(deadline(readTimeout))
+		case http.StateActive:
+			conn.SetReadDeadline(time.Time{})
+		case http.StateIdle:
+			conn.SetReadDeadline(deadline(idleTimeout))
+		}
+	}
+}
+
+// unlimitedPaths matches the paths of the requests which are not limited by
+// the concurrencyLimiter: the pings, so that the daemon can be checked while
+// it is loaded, and the waits for a container to exit, which only write
+// their response once it exits.
+var unlimitedPaths = regexp.MustCompile(`^(/v[0-9.]+)?/(_ping|containers/[^/]+/wait)$`)
+
+// concurrencyLimiter rejects the requests beyond the maximum number of
+// requests being handled at once, with a 503 status code. A request counts
+// until the headers of its response are written, so that the streaming
+// requests, such as docker events, docker logs -f and docker stats, and the
+// hijacked ones, such as attach and exec, do not hold a slot while they
+// stream.
+type concurrencyLimiter struct {
+	handler http.Handler
+	slots   chan struct{}
+}
+
+func newConcurrencyLimiter(handler http.Handler, max int) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		handler: handler,
+		slots:   make(chan struct{}, max),
+	}
+}
+
+func (l *concurrencyLimiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if unlimitedPaths.MatchString(r.URL.Path) {
+		l.handler.ServeHTTP(w, r)
+		return
+	}
+	select {
+	case l.slots <- struct{}{}:
+		lw := &slotReleaseWriter{ResponseWriter: w, release: func() { <-l.slots }}
+		defer lw.releaseSlot()
+		l.handler.ServeHTTP(lw, r)
+	default:
+		w.Header().Set("Retry-After", "1")
+		err := errors.NewErrorWithStatusCode(fmt.Errorf("too many concurrent requests, the maximum is %d", cap(l.slots)), http.StatusServiceUnavailable)
+		httputils.MakeErrorHandler(err)(w, r)
+	}
+}
+
+// slotReleaseWriter releases the slot of a request in the concurrencyLimiter
+// once the headers of its response are written, or its connection hijacked.
+type slotReleaseWriter struct {
+	http.ResponseWriter
+	once    sync.Once
+	release func()
+}
+
+func (w *slotReleaseWriter) releaseSlot() {
+	w.once.Do(w.release)
+}
+
+func (w *slotReleaseWriter) WriteHeader(code int) {
+	w.ResponseWriter.WriteHeader(code)
+	w.releaseSlot()
+}
+
+func (w *slotReleaseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.releaseSlot()
+	return n, err
+}
+
+// Flush uses the internal flush API of the wrapped http.ResponseWriter
+func (w *slotReleaseWriter) Flush() {
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		logrus.Error("Internal response writer doesn't support the Flusher interface")
+		return
+	}
+	flusher.Flush()
+	w.releaseSlot()
+}
+
+// Hijack returns the internal connection of the wrapped http.ResponseWriter
+func (w *slotReleaseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("Internal response writer doesn't support the Hijacker interface")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.releaseSlot()
+	}
+	return conn, rw, err
+}
+
+// CloseNotify uses the internal close notify API of the wrapped http.ResponseWriter
+func (w *slotReleaseWriter) CloseNotify() <-chan bool {
+	closeNotifier, ok := w.ResponseWriter.(http.CloseNotifier)
+	if !ok {
+		logrus.Error("Internal response writer doesn't support the CloseNotifier interface")
+		return nil
+	}
+	return closeNotifier.CloseNotify()
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type deadlineConn struct {
+	net.Conn
+	readDeadline time.Time
+}
+
+func (c *deadlineConn) SetReadDeadline(t time.Time) error {
+	c.readDeadline = t
+	return nil
+}
+
+func TestConnStateTracker(t *testing.T) {
+	track := connStateTracker(10*time.Second, 0)
+	conn := &deadlineConn{}
+
+	track(conn, http.StateNew)
+	if d := conn.readDeadline.Sub(time.Now()); d <= 0 || d > 10*time.Second {
+		t.Fatalf("expected the headers to be read within 10s, got a deadline in %v", d)
+	}
+	track(conn, http.StateActive)
+	if !conn.readDeadline.IsZero() {
+		t.Fatalf("expected no deadline once the headers are read, got %v", conn.readDeadline)
+	}
+	track(conn, http.StateIdle)
+	if !conn.readDeadline.IsZero() {
+		t.Fatalf("expected no idle timeout, got %v", conn.readDeadline)
+	}
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	})
+	l := newConcurrencyLimiter(handler, 1)
+
+	done := make(chan struct{})
+	go func() {
+		req, _ := http.NewRequest("GET", "/events", nil)
+		l.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+	<-started
+
+	req, _ := http.NewRequest("GET", "/info", nil)
+	resp := httptest.NewRecorder()
+	l.ServeHTTP(resp, req)
+	if resp.Code != http.StatusServiceUnavailable || resp.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected a 503 with a Retry-After header, got %d %v", resp.Code, resp.Header())
+	}
+
+	close(release)
+	<-done
+	go func() { <-started }()
+	resp = httptest.NewRecorder()
+	l.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected the request to be handled once the first one is done, got %d", resp.Code)
+	}
+}
+
+func TestConcurrencyLimiterStreaming(t *testing.T) {
+	streaming := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/events" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			streaming <- struct{}{}
+			<-release
+		}
+	})
+	l := newConcurrencyLimiter(handler, 1)
+
+	done := make(chan struct{})
+	go func() {
+		req, _ := http.NewRequest("GET", "/events", nil)
+		l.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+	<-streaming
+
+	// The slot of a streaming request is released once its headers are
+	// written.
+	req, _ := http.NewRequest("GET", "/info", nil)
+	resp := httptest.NewRecorder()
+	l.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected the request to be handled while the other one streams, got %d", resp.Code)
+	}
+	close(release)
+	<-done
+}
+
+func TestConcurrencyLimiterUnlimitedPaths(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/info" {
+			started <- struct{}{}
+			<-release
+		}
+	})
+	l := newConcurrencyLimiter(handler, 1)
+
+	done := make(chan struct{})
+	go func() {
+		req, _ := http.NewRequest("GET", "/info", nil)
+		l.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+	<-started
+	defer func() {
+		close(release)
+		<-done
+	}()
+
+	for _, path := range []string{"/_ping", "/v1.25/_ping", "/containers/web/wait", "/v1.25/containers/web/wait"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		resp := httptest.NewRecorder()
+		l.ServeHTTP(resp, req)
+		if resp.Code != http.StatusOK {
+			t.Fatalf("%s: expected the request not to be limited, got %d", path, resp.Code)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// Verbosity levels of the audit log
+const (
+	// AuditLevelMetadata logs who called which endpoint, and the outcome.
+	AuditLevelMetadata = "metadata"
+	// AuditLevelRequest also logs the bodies of the requests, with the
+	// secrets and the environment variables redacted.
+	AuditLevelRequest = "request"
+)
+
+// AuditRecord is the record of a state-changing API request.
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+	// User is the common name of the TLS client certificate, if any.
+	User string `json:"user,omitempty"`
+	// Peer is the remote address of the request, which holds the
+	// credentials of the peer process for the unix sockets.
+	Peer   string                 `json:"peer,omitempty"`
+	Method string                 `json:"method"`
+	URI    string                 `json:"uri"`
+	Body   map[string]interface{} `json:"body,omitempty"`
+	// BodyOmitted is set when the body of the request is too large to be
+	// logged.
+	BodyOmitted bool `json:"body_omitted,omitempty"`
+	// Error is the error the request failed with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLogger writes the records of the audit log.
+type AuditLogger interface {
+	LogAudit(record AuditRecord)
+}
+
+// AuditMiddleware logs the state-changing API requests, the requests
+// which are not GET, HEAD or OPTIONS, once they are handled.
+type AuditMiddleware struct {
+	logger   AuditLogger
+	level    string
+	redacted []string
+}
+
+// NewAuditMiddleware creates a new AuditMiddleware writing to logger, with
+// the verbosity level. The values of the redacted keys of the bodies of the
+// requests are not logged, nor are the passwords, secrets and join tokens.
+func NewAuditMiddleware(logger AuditLogger, level string, redacted []string) AuditMiddleware {
+	return AuditMiddleware{
+		logger:   logger,
+		level:    level,
+		redacted: redacted,
+	}
+}
+
+// WrapHandler returns a new handler function wrapping the previous one in the request chain.
+func (a AuditMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		switch r.Method {
+		case "GET", "HEAD", "OPTIONS":
+			return handler(ctx, w, r, vars)
+		}
+
+		record := AuditRecord{
+			Time:   time.Now().UTC(),
+			Peer:   r.RemoteAddr,
+			Method: r.Method,
+			URI:    r.RequestURI,
+		}
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			record.User = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		if a.level == AuditLevelRequest {
+			body, err := peekJSONBody(r)
+			switch {
+			case err == errBodyTooLarge:
+				record.BodyOmitted = true
+			case err == nil && body != nil:
+				maskSecretKeys(body, append(requestSecretKeys(r), a.redacted...))
+				record.Body = body
+			}
+		}
+
+		err := handler(ctx, w, r, vars)
+		if err != nil {
+			record.Error = err.Error()
+		}
+		a.logger.LogAudit(record)
+		return err
+	}
+}
+
+// AuditFileLogger writes the records of the audit log to a file, one JSON
+// object per line.
+type AuditFileLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewAuditFileLogger opens the file at path to append the records of the
+// audit log to it.
+func NewAuditFileLogger(path string) (*AuditFileLogger, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditFileLogger{file: f}, nil
+}
+
+// LogAudit appends the record to the file.
+func (l *AuditFileLogger) LogAudit(record AuditRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		logrus.Errorf("Error encoding the audit record of %s %s: %v", record.Method, record.URI, err)
+		return
+	}
+	b = append(b, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(b); err != nil {
+		logrus.Errorf("Error writing the audit record of %s %s: %v", record.Method, record.URI, err)
+	}
+}
+
+// Close closes the file.
+func (l *AuditFileLogger) Close() error {
+	return l.file.Close()
+}
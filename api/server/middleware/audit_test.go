@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+type recordingAuditLogger struct {
+	records []AuditRecord
+}
+
+func (l *recordingAuditLogger) LogAudit(record AuditRecord) {
+	l.records = append(l.records, record)
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		if r.Method != "POST" {
+			return nil
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), "hunter2") {
+			t.Fatalf("expected the handler to read the whole body, got %s", b)
+		}
+		return nil
+	}
+
+	logger := &recordingAuditLogger{}
+	m := NewAuditMiddleware(logger, AuditLevelRequest, []string{"Labels"})
+	h := m.WrapHandler(handler)
+
+	body := `{"Image":"busybox","Env":["TOKEN=hunter2"],"Labels":{"token":"hunter2"},"AuthConfig":{"Password":"hunter2"}}`
+	req, _ := http.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = int64(len(body))
+	req.RequestURI = "/containers/create"
+	req.RemoteAddr = "pid=42,uid=1000,gid=1000"
+	if err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(logger.records))
+	}
+	record := logger.records[0]
+	if record.Method != "POST" || record.URI != "/containers/create" || record.Peer != "pid=42,uid=1000,gid=1000" {
+		t.Fatalf("unexpected record %+v", record)
+	}
+	if record.Body["Image"] != "busybox" || record.Body["Env"] != "*****" || record.Body["Labels"] != "*****" {
+		t.Fatalf("expected the environment and the labels to be redacted, got %v", record.Body)
+	}
+	if auth, ok := record.Body["AuthConfig"].(map[string]interface{}); !ok || auth["Password"] != "*****" {
+		t.Fatalf("expected the password to be redacted, got %v", record.Body)
+	}
+
+	// The variables of the environment files are always redacted.
+	m = NewAuditMiddleware(logger, AuditLevelRequest, nil)
+	body = `{"Name":"db","Env":["PASSWORD=hunter2"]}`
+	req, _ = http.NewRequest("POST", "/v1.25/envfiles/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = int64(len(body))
+	if err := m.WrapHandler(handler)(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.records) != 2 || logger.records[1].Body["Env"] != "*****" {
+		t.Fatalf("expected the variables of the environment file to be redacted, got %+v", logger.records)
+	}
+
+	// The environment of the execs is always redacted.
+	body = `{"Cmd":["sh"],"Env":["PASSWORD=hunter2"]}`
+	req, _ = http.NewRequest("POST", "/v1.25/containers/web/exec", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = int64(len(body))
+	if err := m.WrapHandler(handler)(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.records) != 3 || logger.records[2].Body["Env"] != "*****" {
+		t.Fatalf("expected the environment of the exec to be redacted, got %+v", logger.records)
+	}
+
+	// The bodies too large to be logged are recorded as omitted.
+	body = `{"Image":"busybox","Env":["PASSWORD=hunter2"],"Cmd":["` + strings.Repeat("x", maxPeekBodySize) + `"]}`
+	req, _ = http.NewRequest("POST", "/v1.25/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err := m.WrapHandler(handler)(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.records) != 4 || logger.records[3].Body != nil || !logger.records[3].BodyOmitted {
+		t.Fatalf("expected the body to be recorded as omitted, got %+v", logger.records)
+	}
+
+	req, _ = http.NewRequest("GET", "/containers/json", nil)
+	if err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.records) != 4 {
+		t.Fatalf("expected the GET request not to be logged, got %d records", len(logger.records))
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -21,27 +22,9 @@ func DebugRequestMiddleware(handler func(ctx context.Context, w http.ResponseWri
 		if r.Method != "POST" {
 			return handler(ctx, w, r, vars)
 		}
-		if err := httputils.CheckForJSON(r); err != nil {
-			return handler(ctx, w, r, vars)
-		}
-		maxBodySize := 4096 // 4KB
-		if r.ContentLength > int64(maxBodySize) {
-			return handler(ctx, w, r, vars)
-		}
 
-		body := r.Body
-		bufReader := bufio.NewReaderSize(body, maxBodySize)
-		r.Body = ioutils.NewReadCloserWrapper(bufReader, func() error { return body.Close() })
-
-		b, err := bufReader.Peek(maxBodySize)
-		if err != io.EOF {
-			// either there was an error reading, or the buffer is full (in which case the request is too large)
-			return handler(ctx, w, r, vars)
-		}
-
-		var postForm map[string]interface{}
-		if err := json.Unmarshal(b, &postForm); err == nil {
-			maskSecretKeys(postForm)
+		if postForm, err := peekJSONBody(r); err == nil && postForm != nil {
+			maskSecretKeys(postForm, requestSecretKeys(r))
 			formStr, errMarshal := json.Marshal(postForm)
 			if errMarshal == nil {
 				logrus.Debugf("form data: %s", string(formStr))
@@ -54,23 +37,87 @@ func DebugRequestMiddleware(handler func(ctx context.Context, w http.ResponseWri
 	}
 }
 
-func maskSecretKeys(inp interface{}) {
+// errBodyTooLarge is returned by peekJSONBody for the bodies larger than
+// maxPeekBodySize.
+var errBodyTooLarge = errors.New("request body too large")
+
+// maxPeekBodySize is the size of the largest body decoded by peekJSONBody.
+const maxPeekBodySize = 4096 // 4KB
+
+// peekJSONBody decodes the JSON body of the request, if it is small enough,
+// and leaves it to be read by the handler. It returns nil if the request
+// has no JSON body, and errBodyTooLarge if the body is too large.
+func peekJSONBody(r *http.Request) (map[string]interface{}, error) {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return nil, nil
+	}
+	maxBodySize := maxPeekBodySize
+	if r.ContentLength > int64(maxBodySize) {
+		return nil, errBodyTooLarge
+	}
+
+	body := r.Body
+	bufReader := bufio.NewReaderSize(body, maxBodySize)
+	r.Body = ioutils.NewReadCloserWrapper(bufReader, func() error { return body.Close() })
+
+	b, err := bufReader.Peek(maxBodySize)
+	if err == nil {
+		// the buffer is full, the request is too large
+		return nil, errBodyTooLarge
+	}
+	if err != io.EOF {
+		return nil, err
+	}
+
+	var postForm map[string]interface{}
+	if err := json.Unmarshal(b, &postForm); err != nil {
+		return nil, err
+	}
+	return postForm, nil
+}
+
+// envEndpoints are the endpoints whose requests set environment variables,
+// which often hold credentials.
+var envEndpoints = []string{
+	"/containers/create",
+	"/exec",
+	"/envfiles/create",
+	"/pods/create",
+	"/services/create",
+	"/update",
+}
+
+// requestSecretKeys returns the keys of the body of the request which may
+// hold secrets, such as the environment variables of the containers, the
+// execs and the environment files.
+func requestSecretKeys(r *http.Request) []string {
+	for _, e := range envEndpoints {
+		if strings.HasSuffix(r.URL.Path, e) {
+			return []string{"Env"}
+		}
+	}
+	return nil
+}
+
+// maskSecretKeys masks the values of the passwords, secrets and join tokens,
+// and of the extra keys, at any depth.
+func maskSecretKeys(inp interface{}, extra []string) {
 	if arr, ok := inp.([]interface{}); ok {
 		for _, f := range arr {
-			maskSecretKeys(f)
+			maskSecretKeys(f, extra)
 		}
 		return
 	}
 	if form, ok := inp.(map[string]interface{}); ok {
 	loop0:
 		for k, v := range form {
-			for _, m := range []string{"password", "secret", "jointoken"} {
+			for _, m := range append([]string{"password", "secret", "jointoken"}, extra...) {
 				if strings.EqualFold(m, k) {
 					form[k] = "*****"
 					continue loop0
 				}
 			}
-			maskSecretKeys(v)
+			maskSecretKeys(v, extra)
 		}
 	}
 }
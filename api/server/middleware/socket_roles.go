@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// socketRule allows the requests of a method to the endpoints matching a
+// path.
+type socketRule struct {
+	method string
+	path   string
+}
+
+func parseSocketRule(rule string) (socketRule, error) {
+	fields := strings.Fields(rule)
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "/") {
+		return socketRule{}, fmt.Errorf("invalid rule '%s', must be in the form '<method> /<path>'", rule)
+	}
+	if _, err := path.Match(fields[1], ""); err != nil {
+		return socketRule{}, fmt.Errorf("invalid path in rule '%s': %v", rule, err)
+	}
+	return socketRule{method: strings.ToUpper(fields[0]), path: fields[1]}, nil
+}
+
+// allows returns whether the rule allows the requests of method to the
+// endpoint p. The path of the rule must match the whole path of the
+// endpoint, and not only one of its parents, so that the roles only give
+// access to the endpoints they list.
+func (r socketRule) allows(method, p string) bool {
+	if r.method != "*" && r.method != method && !(r.method == "GET" && method == "HEAD") {
+		return false
+	}
+	ok, _ := path.Match(r.path, p)
+	return ok
+}
+
+type socketRole struct {
+	name  string
+	uids  map[uint32]bool
+	gids  map[uint32]bool
+	rules []socketRule
+}
+
+// matches returns whether the role applies to a client with the user id uid
+// and the group ids gids, which hold its primary and supplementary groups.
+func (r socketRole) matches(uid uint32, gids []uint32) bool {
+	if r.uids[uid] {
+		return true
+	}
+	for _, gid := range gids {
+		if r.gids[gid] {
+			return true
+		}
+	}
+	return false
+}
+
+// SocketRolesMiddleware restricts the endpoints the clients of the unix
+// sockets can access, by the user and group ids of the client processes.
+// It relies on the remote addresses of the connections to hold the
+// credentials of the peers, see listeners.WithPeerCredentials.
+type SocketRolesMiddleware struct {
+	roles []socketRole
+}
+
+// NewSocketRolesMiddleware creates a new SocketRolesMiddleware with the
+// roles, or returns an error if one of their rules is invalid.
+func NewSocketRolesMiddleware(roles map[string]types.SocketRole) (SocketRolesMiddleware, error) {
+	var names []string
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m := SocketRolesMiddleware{}
+	for _, name := range names {
+		role := socketRole{
+			name: name,
+			uids: make(map[uint32]bool),
+			gids: make(map[uint32]bool),
+		}
+		for _, uid := range roles[name].UIDs {
+			role.uids[uid] = true
+		}
+		for _, gid := range roles[name].GIDs {
+			role.gids[gid] = true
+		}
+		for _, rule := range roles[name].Allow {
+			r, err := parseSocketRule(rule)
+			if err != nil {
+				return SocketRolesMiddleware{}, fmt.Errorf("socket role %s: %v", name, err)
+			}
+			role.rules = append(role.rules, r)
+		}
+		m.roles = append(m.roles, role)
+	}
+	return m, nil
+}
+
+// WrapHandler returns a new handler function wrapping the previous one in the request chain.
+func (m SocketRolesMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		uid, gids, ok := peerCredentials(r.RemoteAddr)
+		if !ok {
+			return handler(ctx, w, r, vars)
+		}
+
+		// The clients without a role are only restricted by the
+		// permissions of the socket.
+		var names []string
+		for _, role := range m.roles {
+			if role.matches(uid, gids) {
+				names = append(names, role.name)
+			}
+		}
+		if len(names) == 0 {
+			return handler(ctx, w, r, vars)
+		}
+
+		p := path.Clean(r.URL.Path)
+		if v := vars["version"]; v != "" {
+			p = path.Clean("/" + strings.TrimPrefix(p, "/v"+v))
+		}
+		for _, role := range m.roles {
+			if !role.matches(uid, gids) {
+				continue
+			}
+			for _, rule := range role.rules {
+				if rule.allows(r.Method, p) {
+					return handler(ctx, w, r, vars)
+				}
+			}
+		}
+		return errors.NewRequestForbiddenError(fmt.Errorf("access denied: the socket roles %s do not allow %s %s", strings.Join(names, ", "), r.Method, p))
+	}
+}
+
+// peerCredentials parses the user id and the group ids of a remote address
+// such as "pid=1234,uid=1000,gid=1000,groups=4:24:999", where groups lists
+// the supplementary groups of the peer, if it has any. The primary group
+// comes first in the group ids.
+func peerCredentials(addr string) (uid uint32, gids []uint32, ok bool) {
+	var (
+		hasUID, hasGID bool
+		gid            uint32
+		groups         []uint32
+	)
+	for _, field := range strings.Split(addr, ",") {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return 0, nil, false
+		}
+		var values []string
+		if parts[0] == "groups" {
+			values = strings.Split(parts[1], ":")
+		} else {
+			values = []string{parts[1]}
+		}
+		var ids []uint32
+		for _, v := range values {
+			id, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return 0, nil, false
+			}
+			ids = append(ids, uint32(id))
+		}
+		switch parts[0] {
+		case "uid":
+			uid, hasUID = ids[0], true
+		case "gid":
+			gid, hasGID = ids[0], true
+		case "groups":
+			groups = ids
+		}
+	}
+	if !hasUID || !hasGID {
+		return 0, nil, false
+	}
+	return uid, append([]uint32{gid}, groups...), true
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestSocketRolesMiddleware(t *testing.T) {
+	m, err := NewSocketRolesMiddleware(map[string]types.SocketRole{
+		"monitoring": {GIDs: []uint32{1001}, Allow: []string{"GET /info", "GET /_ping", "GET /containers/*/json", "GET /images/*/json"}},
+		"ops":        {UIDs: []uint32{1002}, Allow: []string{"get /containers/*/json", "POST /containers/*/restart", "POST /containers/*/attach"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		return nil
+	}
+	h := m.WrapHandler(handler)
+
+	cases := []struct {
+		peer, method, path, version string
+		upgrade                     bool
+		allowed                     bool
+	}{
+		{"pid=1,uid=1000,gid=1001", "GET", "/v1.25/info", "1.25", false, true},
+		{"pid=1,uid=1000,gid=1001", "HEAD", "/_ping", "", false, true},
+		{"pid=1,uid=1000,gid=1001", "GET", "/info/extra", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "POST", "/v1.25/containers/c1/stop", "1.25", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/v1.25/containers/c1/json", "1.25", false, true},
+		{"pid=1,uid=1000,gid=1001", "GET", "/v1.25/containers/c1/attach/ws", "1.25", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/v1.25/events", "1.25", true, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/containers/c1/archive", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/containers/c1/logs", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/containers/json", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/images/get", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/images/busybox/json", "", false, true},
+		{"pid=1,uid=1000,gid=1001", "GET", "/images/library/busybox/json", "", false, false},
+		{"pid=1,uid=1000,gid=1001", "GET", "/images/busybox/layers/sha256:abc", "", false, false},
+		{"pid=1,uid=1000,gid=1000,groups=4:1001", "GET", "/info", "", false, true},
+		{"pid=1,uid=1000,gid=1000,groups=4:1001", "POST", "/containers/c1/stop", "", false, false},
+		{"pid=1,uid=1002,gid=1002", "GET", "/containers/c1/json", "", false, true},
+		{"pid=1,uid=1002,gid=1002", "GET", "/images/json", "", false, false},
+		{"pid=1,uid=1002,gid=1002", "POST", "/v1.25/containers/c1/restart", "1.25", false, true},
+		{"pid=1,uid=1002,gid=1002", "POST", "/containers/c1/restart/../kill", "", false, false},
+		{"pid=1,uid=1002,gid=1002", "POST", "/containers/c1/exec", "", false, false},
+		{"pid=1,uid=1002,gid=1002", "POST", "/containers/c1/attach", "", true, true},
+		{"pid=1,uid=1002,gid=1002", "POST", "/exec/e1/start", "", false, false},
+		{"pid=1,uid=1003,gid=1003", "POST", "/containers/c1/kill", "", false, true},
+		{"127.0.0.1:2375", "DELETE", "/containers/c1", "", false, true},
+	}
+	for _, c := range cases {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		req.RemoteAddr = c.peer
+		if c.upgrade {
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Upgrade", "tcp")
+		}
+		err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{"version": c.version})
+		if c.allowed && err != nil {
+			t.Fatalf("%s %s by %s: unexpected error: %v", c.method, c.path, c.peer, err)
+		}
+		if !c.allowed && (err == nil || !strings.Contains(err.Error(), "access denied")) {
+			t.Fatalf("%s %s by %s: expected the request to be denied, got %v", c.method, c.path, c.peer, err)
+		}
+	}
+}
+
+func TestNewSocketRolesMiddlewareInvalidRule(t *testing.T) {
+	for _, rule := range []string{"GET", "GET containers", "GET /containers [", "GET /containers /images"} {
+		_, err := NewSocketRolesMiddleware(map[string]types.SocketRole{
+			"invalid": {GIDs: []uint32{1001}, Allow: []string{rule}},
+		})
+		if err == nil {
+			t.Fatalf("expected an error for the rule %q", rule)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
@@ -41,6 +42,7 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	options.Dockerfile = r.FormValue("dockerfile")
 	options.SuppressOutput = httputils.BoolValue(r, "q")
 	options.NoCache = httputils.BoolValue(r, "nocache")
+	options.Validate = httputils.BoolValue(r, "validate")
 	options.ForceRemove = httputils.BoolValue(r, "forcerm")
 	options.MemorySwap = httputils.Int64ValueOrZero(r, "memswap")
 	options.Memory = httputils.Int64ValueOrZero(r, "memory")
@@ -52,6 +54,13 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	options.CgroupParent = r.FormValue("cgroupparent")
 	options.Tags = r.Form["t"]
 
+	switch policy := r.FormValue("frompolicy"); policy {
+	case "", types.BuildFromPolicyAny, types.BuildFromPolicyPinned:
+		options.FromPolicy = policy
+	default:
+		return nil, fmt.Errorf("Unsupported FROM policy: %q", policy)
+	}
+
 	if r.Form.Get("shmsize") != "" {
 		shmSize, err := strconv.ParseInt(r.Form.Get("shmsize"), 10, 64)
 		if err != nil {
@@ -93,6 +102,15 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 		options.Labels = labels
 	}
 
+	var annotations = map[string]string{}
+	annotationsJSON := r.FormValue("annotations")
+	if annotationsJSON != "" {
+		if err := json.Unmarshal([]byte(annotationsJSON), &annotations); err != nil {
+			return nil, err
+		}
+		options.Annotations = annotations
+	}
+
 	var cacheFrom = []string{}
 	cacheFromJSON := r.FormValue("cachefrom")
 	if cacheFromJSON != "" {
@@ -133,10 +151,18 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	contentType := "application/json"
+	if acceptsJSONSeq(r) && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.25") {
+		contentType = jsonSeqMediaType
+	}
+	w.Header().Set("Content-Type", contentType)
 
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
+	var structured *progressWriter
+	if contentType == jsonSeqMediaType {
+		structured = newProgressWriter(output)
+	}
 	sf := streamformatter.NewJSONStreamFormatter()
 	errf := func(err error) error {
 		if httputils.BoolValue(r, "q") && notVerboseBuffer.Len() > 0 {
@@ -147,7 +173,11 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 		if !output.Flushed() {
 			return err
 		}
-		_, err = w.Write(sf.FormatError(err))
+		if structured != nil {
+			err = structured.writeError(err)
+		} else {
+			_, err = w.Write(sf.FormatError(err))
+		}
 		if err != nil {
 			logrus.Warnf("could not write error response: %v", err)
 		}
@@ -160,23 +190,42 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 	}
 	buildOptions.AuthConfigs = authConfigs
 
+	if secretsEncoded := r.Header.Get("X-Build-Secrets"); secretsEncoded != "" {
+		secretsJSON := base64.NewDecoder(base64.URLEncoding, strings.NewReader(secretsEncoded))
+		if err := json.NewDecoder(secretsJSON).Decode(&buildOptions.Secrets); err != nil {
+			return errf(fmt.Errorf("invalid build secrets: %v", err))
+		}
+	}
+
+	if gitAuthEncoded := r.Header.Get("X-Build-Git-Auth"); gitAuthEncoded != "" {
+		gitAuthJSON := base64.NewDecoder(base64.URLEncoding, strings.NewReader(gitAuthEncoded))
+		buildOptions.GitAuth = &types.GitAuthConfig{}
+		if err := json.NewDecoder(gitAuthJSON).Decode(buildOptions.GitAuth); err != nil {
+			return errf(fmt.Errorf("invalid git credentials: %v", err))
+		}
+	}
+
 	remoteURL := r.FormValue("remote")
 
 	// Currently, only used if context is from a remote url.
 	// Look at code in DetectContextFromRemoteURL for more information.
-	createProgressReader := func(in io.ReadCloser) io.ReadCloser {
-		progressOutput := sf.NewProgressOutput(output, true)
+	out := io.Writer(output)
+	if structured != nil {
+		// The structured progress reports the steps even if the output is
+		// suppressed.
+		out = structured
 		if buildOptions.SuppressOutput {
-			progressOutput = sf.NewProgressOutput(notVerboseBuffer, true)
+			out = ioutil.Discard
 		}
-		return progress.NewProgressReader(in, progressOutput, r.ContentLength, "Downloading context", remoteURL)
-	}
-
-	out := io.Writer(output)
-	if buildOptions.SuppressOutput {
+	} else if buildOptions.SuppressOutput {
 		out = notVerboseBuffer
 	}
 	out = &syncWriter{w: out}
+
+	createProgressReader := func(in io.ReadCloser) io.ReadCloser {
+		progressOutput := sf.NewProgressOutput(out, true)
+		return progress.NewProgressReader(in, progressOutput, r.ContentLength, "Downloading context", remoteURL)
+	}
 	stdout := &streamformatter.StdoutFormatter{Writer: out, StreamFormatter: sf}
 	stderr := &streamformatter.StderrFormatter{Writer: out, StreamFormatter: sf}
 
@@ -186,12 +235,25 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 		StderrFormatter:    stderr,
 		ProgressReaderFunc: createProgressReader,
 	}
+	if structured != nil {
+		pg.StepFunc = structured.writeStep
+	}
 
 	imgID, err := br.backend.BuildFromContext(ctx, r.Body, remoteURL, buildOptions, pg)
 	if err != nil {
 		return errf(err)
 	}
 
+	// No image is built when the Dockerfile is only validated.
+	if imgID == "" {
+		return nil
+	}
+
+	if structured != nil {
+		structured.writeImage(string(imgID))
+		return nil
+	}
+
 	// Everything worked so if -q was provided the output from the daemon
 	// should be just the image ID and we'll print that to stdout.
 	if buildOptions.SuppressOutput {
@@ -0,0 +1,129 @@
+package build
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// jsonSeqMediaType is the media type of the structured build progress, a
+// sequence of BuildProgress records as described in RFC 7464.
+const jsonSeqMediaType = "application/json-seq"
+
+// acceptsJSONSeq returns whether the client accepts the structured build
+// progress.
+func acceptsJSONSeq(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept)); err == nil && mediaType == jsonSeqMediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// The color codes added by the StderrFormatter to the output of the steps.
+const (
+	stderrColorStart = "\033[91m"
+	stderrColorEnd   = "\033[0m"
+)
+
+// progressWriter writes the structured build progress. The output of a step
+// is written in records with the index of the step.
+type progressWriter struct {
+	mu   sync.Mutex
+	out  io.Writer
+	step types.BuildProgress // the current step
+	// failed is set once a failed step reported the error ending the build.
+	failed bool
+}
+
+func newProgressWriter(out io.Writer) *progressWriter {
+	return &progressWriter{out: out}
+}
+
+// write writes the record prefixed with a record separator.
+func (pw *progressWriter) write(p types.BuildProgress) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 0, len(b)+2)
+	buf = append(buf, 0x1e)
+	buf = append(buf, b...)
+	buf = append(buf, '\n')
+	_, err = pw.out.Write(buf)
+	return err
+}
+
+// writeStep writes the record of the start or the end of a step.
+func (pw *progressWriter) writeStep(p types.BuildProgress) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	if p.Status == types.BuildStepStarted {
+		pw.step = p
+	} else {
+		pw.step = types.BuildProgress{}
+	}
+	if p.Status == types.BuildStepFailed && p.Error != "" {
+		pw.failed = true
+	}
+	pw.write(p)
+}
+
+// writeError writes the record of the error ending the build, unless the
+// failed step already reported it.
+func (pw *progressWriter) writeError(err error) error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	if pw.failed {
+		return nil
+	}
+	return pw.write(types.BuildProgress{Error: err.Error()})
+}
+
+// Write implements io.Writer. It converts the JSON messages of the build
+// output to records of the current step; the progress bars are dropped.
+func (pw *progressWriter) Write(b []byte) (int, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+
+		p := types.BuildProgress{Step: pw.step.Step, Total: pw.step.Total}
+		switch {
+		case jm.Error != nil:
+			p = types.BuildProgress{Error: jm.Error.Message}
+		case jm.Stream != "":
+			p.Output = strings.TrimSuffix(strings.TrimPrefix(jm.Stream, stderrColorStart), stderrColorEnd)
+		case jm.Status != "" && jm.Progress == nil:
+			p.Output = jm.Status + "\n"
+		default:
+			continue
+		}
+		if err := pw.write(p); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// writeImage writes the last record of a successful build.
+func (pw *progressWriter) writeImage(imgID string) error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	return pw.write(types.BuildProgress{ImageID: imgID})
+}
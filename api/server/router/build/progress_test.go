@@ -0,0 +1,131 @@
+package build
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/streamformatter"
+)
+
+// readRecords parses the records written by a progressWriter.
+func readRecords(t *testing.T, b []byte) []types.BuildProgress {
+	var records []types.BuildProgress
+	for _, rec := range strings.Split(string(b), "\x1e")[1:] {
+		if !strings.HasSuffix(rec, "\n") {
+			t.Fatalf("expected the record %q to end with a newline", rec)
+		}
+		var p types.BuildProgress
+		if err := json.Unmarshal([]byte(rec), &p); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, p)
+	}
+	return records
+}
+
+func TestProgressWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pw := newProgressWriter(buf)
+	sf := streamformatter.NewJSONStreamFormatter()
+	stderr := &streamformatter.StderrFormatter{Writer: pw, StreamFormatter: sf}
+
+	pw.writeStep(types.BuildProgress{Step: 1, Total: 2, Instruction: "RUN echo hello", Status: types.BuildStepStarted})
+	if _, err := pw.Write(sf.FormatStream("hello\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stderr.Write([]byte("warning\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pw.Write(sf.FormatStatus("", "Downloading")); err != nil {
+		t.Fatal(err)
+	}
+	// The progress bars are dropped.
+	if _, err := pw.Write(sf.FormatProgress("", "Downloading", &jsonmessage.JSONProgress{Current: 1, Total: 2}, nil)); err != nil {
+		t.Fatal(err)
+	}
+	pw.writeStep(types.BuildProgress{Step: 1, Total: 2, Status: types.BuildStepCompleted, ImageID: "sha256:1"})
+	// The output written between the steps has no step index.
+	if _, err := pw.Write(sf.FormatStream("between\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := pw.writeImage("sha256:2"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []types.BuildProgress{
+		{Step: 1, Total: 2, Instruction: "RUN echo hello", Status: types.BuildStepStarted},
+		{Step: 1, Total: 2, Output: "hello\n"},
+		{Step: 1, Total: 2, Output: "warning\n"},
+		{Step: 1, Total: 2, Output: "Downloading\n"},
+		{Step: 1, Total: 2, Status: types.BuildStepCompleted, ImageID: "sha256:1"},
+		{Output: "between\n"},
+		{ImageID: "sha256:2"},
+	}
+	if records := readRecords(t, buf.Bytes()); !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected records %+v, got %+v", expected, records)
+	}
+}
+
+func TestProgressWriterError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pw := newProgressWriter(buf)
+	sf := streamformatter.NewJSONStreamFormatter()
+
+	errFailed := errors.New("The command '/bin/sh -c false' returned a non-zero code: 1")
+	pw.writeStep(types.BuildProgress{Step: 1, Total: 1, Instruction: "RUN false", Status: types.BuildStepStarted})
+	if _, err := pw.Write(sf.FormatError(errFailed)); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readRecords(t, buf.Bytes())
+	if len(records) != 2 || !reflect.DeepEqual(records[1], types.BuildProgress{Error: errFailed.Error()}) {
+		t.Fatalf("expected an error record, got %+v", records)
+	}
+}
+
+func TestProgressWriterFailedStep(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pw := newProgressWriter(buf)
+
+	errFailed := errors.New("The command '/bin/sh -c false' returned a non-zero code: 1")
+	pw.writeStep(types.BuildProgress{Step: 1, Total: 1, Instruction: "RUN false", Status: types.BuildStepStarted})
+	pw.writeStep(types.BuildProgress{Step: 1, Total: 1, Instruction: "RUN false", Status: types.BuildStepFailed, Error: errFailed.Error()})
+	// The error ending the build is only reported by the failed step.
+	if err := pw.writeError(errFailed); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []types.BuildProgress{
+		{Step: 1, Total: 1, Instruction: "RUN false", Status: types.BuildStepStarted},
+		{Step: 1, Total: 1, Instruction: "RUN false", Status: types.BuildStepFailed, Error: errFailed.Error()},
+	}
+	if records := readRecords(t, buf.Bytes()); !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected records %+v, got %+v", expected, records)
+	}
+}
+
+func TestAcceptsJSONSeq(t *testing.T) {
+	cases := map[string]bool{
+		"":                     false,
+		"application/json":     false,
+		"application/json-seq": true,
+		"application/json, application/json-seq; q=0.9": true,
+	}
+	for accept, expected := range cases {
+		r, err := http.NewRequest("POST", "/build", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Accept", accept)
+		if acceptsJSONSeq(r) != expected {
+			t.Fatalf("expected %v for %q", expected, accept)
+		}
+	}
+}
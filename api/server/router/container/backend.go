@@ -2,7 +2,6 @@ package container
 
 import (
 	"io"
-	"time"
 
 	"golang.org/x/net/context"
 
@@ -10,6 +9,8 @@ import (
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/go-connections/nat"
+	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 // execBackend includes functions to implement to provide exec functionality.
@@ -24,15 +25,20 @@ type execBackend interface {
 // copyBackend includes functions to implement to provide container copy functionality.
 type copyBackend interface {
 	ContainerArchivePath(name string, path string) (content io.ReadCloser, stat *types.ContainerPathStat, err error)
+	ContainerBackup(name, since string) (content io.ReadCloser, snapshot string, err error)
 	ContainerCopy(name string, res string) (io.ReadCloser, error)
-	ContainerExport(name string, out io.Writer) error
+	ContainerCopyBetween(srcName, srcPath, dstName, dstPath string, followLink, noOverwriteDirNonDir bool) error
+	ContainerExport(name, format string, out io.Writer) error
 	ContainerExtractToDir(name, path string, noOverwriteDirNonDir bool, content io.Reader) error
+	ContainerRestore(name string, content io.Reader) error
 	ContainerStatPath(name string, path string) (stat *types.ContainerPathStat, err error)
 }
 
 // stateBackend includes functions to implement to provide container state lifecycle functionality.
 type stateBackend interface {
 	ContainerCreate(config types.ContainerCreateConfig, validateHostname bool) (types.ContainerCreateResponse, error)
+	ContainerClone(name, newName string, config *types.ContainerCloneConfig) (types.ContainerCreateResponse, error)
+	ContainerHandoff(name, from string, seconds *int) error
 	ContainerKill(name string, sig uint64) error
 	ContainerPause(name string) error
 	ContainerRename(oldName, newName string) error
@@ -42,17 +48,25 @@ type stateBackend interface {
 	ContainerStart(name string, hostConfig *container.HostConfig, validateHostname bool, checkpoint string) error
 	ContainerStop(name string, seconds int) error
 	ContainerUnpause(name string) error
-	ContainerUpdate(name string, hostConfig *container.HostConfig, validateHostname bool) (types.ContainerUpdateResponse, error)
-	ContainerWait(name string, timeout time.Duration) (int, error)
+	ContainerUpdate(name string, hostConfig *container.HostConfig, forceOomKillDisable, validateHostname bool) (types.ContainerUpdateResponse, error)
+	ContainerUpdateLabels(name string, add map[string]string, remove []string) error
+	ContainerUpdateExtraHosts(name string, add, remove []string) error
+	ContainerWaitExit(name string) (*types.ContainerWaitResponse, error)
 }
 
 // monitorBackend includes functions to implement to provide containers monitoring functionality.
 type monitorBackend interface {
 	ContainerChanges(name string) ([]archive.Change, error)
+	ContainerCoreDump(name, core string, out io.Writer) error
+	ContainerCoreDumps(name string) ([]types.CoreDump, error)
+	ContainerFilesystemUsage(name string, refresh bool) (*types.ContainerFilesystemUsage, error)
 	ContainerInspect(name string, size bool, version string) (interface{}, error)
 	ContainerLogs(ctx context.Context, name string, config *backend.ContainerLogsConfig, started chan struct{}) error
+	ContainerResolve(name, host string) (*types.ContainerResolveResponse, error)
+	ContainerSpec(name string) (*specs.Spec, error)
 	ContainerStats(ctx context.Context, name string, config *backend.ContainerStatsConfig) error
 	ContainerTop(name string, psArgs string) (*types.ContainerProcessList, error)
+	ContainerVerify(name string) (*types.ContainerVerifyReport, error)
 
 	Containers(config *types.ContainerListOptions) ([]*types.Container, error)
 }
@@ -65,6 +79,7 @@ type attachBackend interface {
 // systemBackend includes functions to implement to provide system wide containers functionality
 type systemBackend interface {
 	ContainersPrune(config *types.ContainersPruneConfig) (*types.ContainersPruneReport, error)
+	ContainerPortsCheck(bindings nat.PortMap) (*types.PortsCheckResponse, error)
 }
 
 // Backend is all the methods that need to be implemented to provide container specific functionality.
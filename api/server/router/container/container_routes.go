@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 	"syscall"
-	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/server/httputils"
@@ -16,6 +17,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/pkg/flowstream"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/signal"
 	"golang.org/x/net/context"
@@ -47,6 +49,10 @@ func (s *containerRouter) getContainersJSON(ctx context.Context, w http.Response
 		config.Limit = limit
 	}
 
+	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.25") {
+		config.Resources = httputils.BoolValue(r, "resources")
+	}
+
 	containers, err := s.backend.Containers(config)
 	if err != nil {
 		return err
@@ -65,10 +71,22 @@ func (s *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 		w.Header().Set("Content-Type", "application/json")
 	}
 
+	version := httputils.VersionFromContext(ctx)
+	summary := httputils.BoolValue(r, "summary")
+	if summary {
+		if versions.LessThan(version, "1.25") {
+			return fmt.Errorf("the summary option requires API version 1.25 or newer")
+		}
+		if stream {
+			return fmt.Errorf("the summary option cannot be used with stream")
+		}
+	}
+
 	config := &backend.ContainerStatsConfig{
 		Stream:    stream,
+		Summary:   summary,
 		OutStream: w,
-		Version:   string(httputils.VersionFromContext(ctx)),
+		Version:   string(version),
 	}
 
 	return s.backend.ContainerStats(ctx, vars["name"], config)
@@ -120,7 +138,15 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 }
 
 func (s *containerRouter) getContainersExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-	return s.backend.ContainerExport(vars["name"], w)
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	// The format was added in API 1.25; older clients always get a tar.
+	var format string
+	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.25") {
+		format = r.Form.Get("format")
+	}
+	return s.backend.ContainerExport(vars["name"], format, w)
 }
 
 func (s *containerRouter) postContainersStart(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -179,6 +205,35 @@ func (s *containerRouter) postContainersStop(ctx context.Context, w http.Respons
 	return nil
 }
 
+func (s *containerRouter) postContainersHandoff(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "container handoff"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	from := r.Form.Get("from")
+	if from == "" {
+		return validationError{fmt.Errorf("the container to hand off the ports from is missing")}
+	}
+	var seconds *int
+	if t := r.Form.Get("t"); t != "" {
+		v, err := strconv.Atoi(t)
+		if err != nil {
+			return validationError{fmt.Errorf("invalid value for t: %s", t)}
+		}
+		seconds = &v
+	}
+
+	if err := s.backend.ContainerHandoff(vars["name"], from, seconds); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
 type errContainerIsRunning interface {
 	ContainerIsRunning() bool
 }
@@ -263,14 +318,12 @@ func (s *containerRouter) postContainersUnpause(ctx context.Context, w http.Resp
 }
 
 func (s *containerRouter) postContainersWait(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-	status, err := s.backend.ContainerWait(vars["name"], -1*time.Second)
+	resp, err := s.backend.ContainerWaitExit(vars["name"])
 	if err != nil {
 		return err
 	}
 
-	return httputils.WriteJSON(w, http.StatusOK, &types.ContainerWaitResponse{
-		StatusCode: status,
-	})
+	return httputils.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (s *containerRouter) getContainersChanges(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -282,6 +335,73 @@ func (s *containerRouter) getContainersChanges(ctx context.Context, w http.Respo
 	return httputils.WriteJSON(w, http.StatusOK, changes)
 }
 
+func (s *containerRouter) getContainersCores(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	cores, err := s.backend.ContainerCoreDumps(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, cores)
+}
+
+func (s *containerRouter) getContainersCore(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	return s.backend.ContainerCoreDump(vars["name"], vars["core"], w)
+}
+
+func (s *containerRouter) getContainersSpec(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "container spec"); err != nil {
+		return err
+	}
+	spec, err := s.backend.ContainerSpec(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, spec)
+}
+
+func (s *containerRouter) getContainersVerify(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "container verify"); err != nil {
+		return err
+	}
+	report, err := s.backend.ContainerVerify(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
+func (s *containerRouter) getContainersResolve(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "resolving names in containers"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	resp, err := s.backend.ContainerResolve(vars["name"], r.Form.Get("name"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (s *containerRouter) getContainersFilesystemUsage(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	usage, err := s.backend.ContainerFilesystemUsage(vars["name"], httputils.BoolValue(r, "refresh"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, usage)
+}
+
 func (s *containerRouter) getContainersTop(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -331,8 +451,28 @@ func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 	}
 
 	name := vars["name"]
+
+	updateLabels := versions.GreaterThanOrEqualTo(version, "1.25") &&
+		(len(updateConfig.LabelsAdd) > 0 || len(updateConfig.LabelsRemove) > 0)
+	if updateLabels {
+		if err := s.backend.ContainerUpdateLabels(name, updateConfig.LabelsAdd, updateConfig.LabelsRemove); err != nil {
+			return err
+		}
+	}
+	updateHosts := versions.GreaterThanOrEqualTo(version, "1.25") &&
+		(len(updateConfig.ExtraHostsAdd) > 0 || len(updateConfig.ExtraHostsRemove) > 0)
+	if updateHosts {
+		if err := s.backend.ContainerUpdateExtraHosts(name, updateConfig.ExtraHostsAdd, updateConfig.ExtraHostsRemove); err != nil {
+			return err
+		}
+	}
+	// Only labels or extra hosts were given, there is nothing else to update.
+	if (updateLabels || updateHosts) && updateConfig.RestartPolicy.Name == "" && reflect.DeepEqual(updateConfig.Resources, container.Resources{}) {
+		return httputils.WriteJSON(w, http.StatusOK, types.ContainerUpdateResponse{})
+	}
+
 	validateHostname := versions.GreaterThanOrEqualTo(version, "1.24")
-	resp, err := s.backend.ContainerUpdate(name, hostConfig, validateHostname)
+	resp, err := s.backend.ContainerUpdate(name, hostConfig, updateConfig.ForceOomKillDisable, validateHostname)
 	if err != nil {
 		return err
 	}
@@ -357,13 +497,17 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 	version := httputils.VersionFromContext(ctx)
 	adjustCPUShares := versions.LessThan(version, "1.19")
 
+	// The older clients could disable the OOM killer without a memory limit.
+	forceOomKillDisable := versions.LessThan(version, "1.25") || httputils.BoolValue(r, "forceoomkilldisable")
+
 	validateHostname := versions.GreaterThanOrEqualTo(version, "1.24")
 	ccr, err := s.backend.ContainerCreate(types.ContainerCreateConfig{
-		Name:             name,
-		Config:           config,
-		HostConfig:       hostConfig,
-		NetworkingConfig: networkingConfig,
-		AdjustCPUShares:  adjustCPUShares,
+		Name:                name,
+		Config:              config,
+		HostConfig:          hostConfig,
+		NetworkingConfig:    networkingConfig,
+		AdjustCPUShares:     adjustCPUShares,
+		ForceOomKillDisable: forceOomKillDisable,
 	}, validateHostname)
 	if err != nil {
 		return err
@@ -372,6 +516,27 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 	return httputils.WriteJSON(w, http.StatusCreated, ccr)
 }
 
+func (s *containerRouter) postContainersClone(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var cfg types.ContainerCloneConfig
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		return err
+	}
+
+	ccr, err := s.backend.ContainerClone(vars["name"], r.Form.Get("name"), &cfg)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, ccr)
+}
+
 func (s *containerRouter) deleteContainers(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -418,6 +583,8 @@ func (s *containerRouter) postContainersAttach(ctx context.Context, w http.Respo
 	containerName := vars["name"]
 
 	_, upgrade := r.Header["Upgrade"]
+	flowControl := upgrade && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.25") &&
+		hasUpgradeProtocol(r, flowstream.Protocol)
 	detachKeys := r.FormValue("detachKeys")
 
 	hijacker, ok := w.(http.Hijacker)
@@ -434,6 +601,20 @@ func (s *containerRouter) postContainersAttach(ctx context.Context, w http.Respo
 		// set raw mode
 		conn.Write([]byte{})
 
+		if flowControl {
+			fmt.Fprintf(conn, "HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.flow-stream\r\nConnection: Upgrade\r\nUpgrade: %s\r\n\r\n", flowstream.Protocol)
+
+			// The output is multiplexed on a single stream, as with the
+			// raw stream, and the input is read from another stream.
+			session := flowstream.NewSession(conn)
+			stdin, stdout := session.Stream(flowstream.AttachStdinStream), session.Stream(flowstream.AttachOutputStream)
+			closer := func() error {
+				stdout.CloseWrite()
+				return session.Close()
+			}
+			return ioutils.NewReadCloserWrapper(stdin, closer), stdout, stdout, nil
+		}
+
 		if upgrade {
 			fmt.Fprintf(conn, "HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
 		} else {
@@ -457,6 +638,10 @@ func (s *containerRouter) postContainersAttach(ctx context.Context, w http.Respo
 		DetachKeys: detachKeys,
 		MuxStreams: true,
 	}
+	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.25") {
+		attachConfig.Scrollback = httputils.BoolValue(r, "scrollback")
+		attachConfig.SinceDetach = httputils.BoolValue(r, "sinceDetach")
+	}
 
 	if err = s.backend.ContainerAttach(containerName, attachConfig); err != nil {
 		logrus.Errorf("Handler for %s %s returned error: %v", r.Method, r.URL.Path, err)
@@ -474,6 +659,19 @@ func (s *containerRouter) postContainersAttach(ctx context.Context, w http.Respo
 	return nil
 }
 
+// hasUpgradeProtocol returns whether protocol is one of the protocols of
+// the Upgrade header of r.
+func hasUpgradeProtocol(r *http.Request, protocol string) bool {
+	for _, v := range r.Header["Upgrade"] {
+		for _, p := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(p), protocol) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (s *containerRouter) wsContainersAttach(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -545,3 +743,27 @@ func (s *containerRouter) postContainersPrune(ctx context.Context, w http.Respon
 	}
 	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
 }
+
+func (s *containerRouter) postContainersPortsCheck(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "ports check"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req types.PortsCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return err
+	}
+
+	resp, err := s.backend.ContainerPortsCheck(req.PortBindings)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, resp)
+}
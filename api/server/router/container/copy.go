@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/api/server/httputils"
@@ -115,5 +116,41 @@ func (s *containerRouter) putContainersArchive(ctx context.Context, w http.Respo
 	}
 
 	noOverwriteDirNonDir := httputils.BoolValue(r, "noOverwriteDirNonDir")
+
+	if fromContainer := r.Form.Get("fromContainer"); fromContainer != "" && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.25") {
+		fromPath := filepath.FromSlash(r.Form.Get("fromPath"))
+		if fromPath == "" {
+			return fmt.Errorf("bad parameter: 'fromPath' cannot be empty")
+		}
+		followLink := httputils.BoolValue(r, "followLink")
+		return s.backend.ContainerCopyBetween(fromContainer, fromPath, v.Name, v.Path, followLink, noOverwriteDirNonDir)
+	}
+
 	return s.backend.ContainerExtractToDir(v.Name, v.Path, noOverwriteDirNonDir, r.Body)
 }
+
+func (s *containerRouter) getContainersBackup(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	tarArchive, snapshot, err := s.backend.ContainerBackup(vars["name"], r.Form.Get("since"))
+	if err != nil {
+		return err
+	}
+	defer tarArchive.Close()
+
+	w.Header().Set("X-Docker-Container-Snapshot", snapshot)
+	w.Header().Set("Content-Type", "application/x-tar")
+	_, err = io.Copy(w, tarArchive)
+
+	return err
+}
+
+func (s *containerRouter) postContainersRestore(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.ContainerRestore(vars["name"], r.Body); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,12 @@
+package envfile
+
+import "github.com/docker/docker/api/types"
+
+// Backend is the methods that need to be implemented to provide
+// environment file specific functionality
+type Backend interface {
+	EnvFiles() []*types.EnvFile
+	EnvFileInspect(name string, reveal bool) (*types.EnvFile, error)
+	EnvFileCreate(req *types.EnvFileCreateRequest) (*types.EnvFile, error)
+	EnvFileRm(name string) error
+}
@@ -0,0 +1,36 @@
+package envfile
+
+import "github.com/docker/docker/api/server/router"
+
+// envFileRouter is a router to talk with the environment files controller
+type envFileRouter struct {
+	backend Backend
+	routes  []router.Route
+}
+
+// NewRouter initializes a new environment file router
+func NewRouter(b Backend) router.Router {
+	r := &envFileRouter{
+		backend: b,
+	}
+	r.initRoutes()
+	return r
+}
+
+// Routes returns the available routes to the environment files controller
+func (r *envFileRouter) Routes() []router.Route {
+	return r.routes
+}
+
+func (r *envFileRouter) initRoutes() {
+	r.routes = []router.Route{
+		// GET
+		router.NewGetRoute("/envfiles", r.getEnvFilesList),
+		router.NewGetRoute("/envfiles/{name:.*}", r.getEnvFileByName),
+		// POST
+		router.NewPostRoute("/envfiles/create", r.postEnvFilesCreate),
+		router.NewPostRoute("/envfiles/{name:.*}/reveal", r.postEnvFileReveal),
+		// DELETE
+		router.NewDeleteRoute("/envfiles/{name:.*}", r.deleteEnvFiles),
+	}
+}
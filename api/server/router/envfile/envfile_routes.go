@@ -0,0 +1,77 @@
+package envfile
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func (e *envFileRouter) getEnvFilesList(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "environment file list"); err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, e.backend.EnvFiles())
+}
+
+func (e *envFileRouter) getEnvFileByName(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "environment file inspect"); err != nil {
+		return err
+	}
+	f, err := e.backend.EnvFileInspect(vars["name"], false)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, f)
+}
+
+// postEnvFileReveal returns the environment file with the values of its
+// variables. It is a POST so that the clients only allowed to read the state
+// of the daemon cannot read the values.
+func (e *envFileRouter) postEnvFileReveal(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "environment file inspect"); err != nil {
+		return err
+	}
+	f, err := e.backend.EnvFileInspect(vars["name"], true)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, f)
+}
+
+func (e *envFileRouter) postEnvFilesCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "environment file create"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req types.EnvFileCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return err
+	}
+
+	f, err := e.backend.EnvFileCreate(&req)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, f)
+}
+
+func (e *envFileRouter) deleteEnvFiles(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "environment file remove"); err != nil {
+		return err
+	}
+	if err := e.backend.EnvFileRm(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -20,6 +20,7 @@ type Backend interface {
 
 type containerBackend interface {
 	Commit(name string, config *backend.ContainerCommitConfig) (imageID string, err error)
+	ConsistentCommit(name string, config *backend.ContainerCommitConfig) (imageID string, timing *types.ContainerCommitTiming, err error)
 }
 
 type imageBackend interface {
@@ -29,16 +30,25 @@ type imageBackend interface {
 	LookupImage(name string) (*types.ImageInspect, error)
 	TagImage(imageName, repository, tag string) error
 	ImagesPrune(config *types.ImagesPruneConfig) (*types.ImagesPruneReport, error)
+	ImageVerify(name string) (*types.ImageVerifyResponse, error)
+	ImagesAudit() (*types.ImagesAuditReport, error)
+	FlattenImage(name, repository, tag string) (string, error)
 }
 
 type importExportBackend interface {
 	LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool) error
 	ImportImage(src string, repository, tag string, msg string, inConfig io.ReadCloser, outStream io.Writer, changes []string) error
-	ExportImage(names []string, outStream io.Writer) error
+	ExportImage(names []string, compression backend.LayerCompression, outStream io.Writer) error
+	ImageLayerExport(name, diffID string, outStream io.Writer) error
+	ImageLayerImport(name string, layerData io.Reader, repository, tag, comment string) (string, error)
 }
 
 type registryBackend interface {
 	PullImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error
-	PushImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error
+	PushImage(ctx context.Context, image, tag string, compression backend.LayerCompression, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error
 	SearchRegistryForImages(ctx context.Context, filtersArgs string, term string, limit int, authConfig *types.AuthConfig, metaHeaders map[string][]string) (*registry.SearchResults, error)
+	InspectManifest(ctx context.Context, name string, metaHeaders map[string][]string, authConfig *types.AuthConfig) (*types.ManifestDescriptor, error)
+	PushManifestList(ctx context.Context, name string, manifests []types.ManifestDescriptor, metaHeaders map[string][]string, authConfig *types.AuthConfig) (string, error)
+	RegistryRepositories(ctx context.Context, hostname, last string, limit int, metaHeaders map[string][]string, authConfig *types.AuthConfig) (*types.RegistryRepositories, error)
+	RepositoryTags(ctx context.Context, name string, metaHeaders map[string][]string, authConfig *types.AuthConfig) (*types.RepositoryTags, error)
 }
@@ -33,10 +33,16 @@ func (r *imageRouter) initRoutes() {
 		// GET
 		router.NewGetRoute("/images/json", r.getImagesJSON),
 		router.NewGetRoute("/images/search", r.getImagesSearch),
+		router.NewGetRoute("/images/audit", r.getImagesAudit),
 		router.NewGetRoute("/images/get", r.getImagesGet),
 		router.NewGetRoute("/images/{name:.*}/get", r.getImagesGet),
 		router.NewGetRoute("/images/{name:.*}/history", r.getImagesHistory),
 		router.NewGetRoute("/images/{name:.*}/json", r.getImagesByName),
+		router.NewGetRoute("/images/{name:.*}/layers/{diffid:.*}", r.getImagesLayer),
+		router.NewGetRoute("/images/{name:.*}/verify", r.getImagesVerify),
+		router.NewGetRoute("/manifests/{name:.*}/json", r.getManifestsJSON),
+		router.NewGetRoute("/registries/{name}/repositories", r.getRegistriesRepositories),
+		router.NewGetRoute("/repositories/{name:.*}/tags", r.getRepositoriesTags),
 		// POST
 		router.NewPostRoute("/commit", r.postCommit),
 		router.NewPostRoute("/images/load", r.postImagesLoad),
@@ -44,6 +50,9 @@ func (r *imageRouter) initRoutes() {
 		router.Cancellable(router.NewPostRoute("/images/{name:.*}/push", r.postImagesPush)),
 		router.NewPostRoute("/images/{name:.*}/tag", r.postImagesTag),
 		router.NewPostRoute("/images/prune", r.postImagesPrune),
+		router.NewPostRoute("/images/{name:.*}/layers", r.postImagesLayers),
+		router.NewPostRoute("/images/{name:.*}/flatten", r.postImagesFlatten),
+		router.NewPostRoute("/manifests/{name:.*}/push", r.postManifestsPush),
 		// DELETE
 		router.NewDeleteRoute("/images/{name:.*}", r.deleteImages),
 	}
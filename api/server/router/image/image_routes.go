@@ -9,11 +9,14 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/registry"
@@ -36,6 +39,10 @@ func (s *imageRouter) postCommit(ctx context.Context, w http.ResponseWriter, r *
 	if r.FormValue("pause") == "" && versions.GreaterThanOrEqualTo(version, "1.13") {
 		pause = true
 	}
+	consistent := httputils.BoolValue(r, "consistent")
+	if consistent && !pause {
+		return errors.NewBadRequestError(fmt.Errorf("a consistent commit cannot be done without pausing the container"))
+	}
 
 	c, _, _, err := s.decoder.DecodeConfig(r.Body)
 	if err != nil && err != io.EOF { //Do not fail if body is empty.
@@ -58,6 +65,17 @@ func (s *imageRouter) postCommit(ctx context.Context, w http.ResponseWriter, r *
 		Changes: r.Form["changes"],
 	}
 
+	if consistent {
+		imgID, timing, err := s.backend.ConsistentCommit(cname, commitCfg)
+		if err != nil {
+			return err
+		}
+		return httputils.WriteJSON(w, http.StatusCreated, &types.ContainerCommitResponse{
+			ID:     imgID,
+			Timing: timing,
+		})
+	}
+
 	imgID, err := s.backend.Commit(cname, commitCfg)
 	if err != nil {
 		return err
@@ -153,13 +171,17 @@ func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter,
 
 	image := vars["name"]
 	tag := r.Form.Get("tag")
+	compression, err := layerCompressionFormValues(r)
+	if err != nil {
+		return err
+	}
 
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
 
 	w.Header().Set("Content-Type", "application/json")
 
-	if err := s.backend.PushImage(ctx, image, tag, metaHeaders, authConfig, output); err != nil {
+	if err := s.backend.PushImage(ctx, image, tag, compression, metaHeaders, authConfig, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -169,10 +191,41 @@ func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter,
 	return nil
 }
 
+// layerCompressionFormValues returns the compression of the layers of the
+// images pushed or saved, from the compression and compressionLevel query
+// parameters.
+func layerCompressionFormValues(r *http.Request) (backend.LayerCompression, error) {
+	compression := backend.LayerCompression{Algorithm: r.Form.Get("compression")}
+	if level := r.Form.Get("compressionLevel"); level != "" {
+		l, err := strconv.Atoi(level)
+		if err != nil {
+			return compression, errors.NewBadRequestError(fmt.Errorf("invalid compressionLevel %q", level))
+		}
+		compression.Level = l
+	}
+	if compression.Algorithm == "" && compression.Level != 0 {
+		return compression, errors.NewBadRequestError(fmt.Errorf("compressionLevel requires a compression"))
+	}
+	if compression.Algorithm != "" {
+		c, err := archive.ParseCompression(compression.Algorithm)
+		if err != nil {
+			return compression, errors.NewBadRequestError(err)
+		}
+		if err := archive.ValidateCompressionLevel(c, compression.Level); err != nil {
+			return compression, errors.NewBadRequestError(err)
+		}
+	}
+	return compression, nil
+}
+
 func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
 	}
+	compression, err := layerCompressionFormValues(r)
+	if err != nil {
+		return err
+	}
 
 	w.Header().Set("Content-Type", "application/x-tar")
 
@@ -185,7 +238,7 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		names = r.Form["names"]
 	}
 
-	if err := s.backend.ExportImage(names, output); err != nil {
+	if err := s.backend.ExportImage(names, compression, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -211,6 +264,54 @@ func (s *imageRouter) postImagesLoad(ctx context.Context, w http.ResponseWriter,
 	return nil
 }
 
+func (s *imageRouter) getImagesLayer(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	w.Header().Set("Content-Type", "application/x-tar")
+
+	output := ioutils.NewWriteFlusher(w)
+	defer output.Close()
+	if err := s.backend.ImageLayerExport(vars["name"], vars["diffid"], output); err != nil {
+		if !output.Flushed() {
+			return err
+		}
+		sf := streamformatter.NewJSONStreamFormatter()
+		output.Write(sf.FormatError(err))
+	}
+	return nil
+}
+
+func (s *imageRouter) postImagesLayers(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	imgID, err := s.backend.ImageLayerImport(vars["name"], r.Body, r.Form.Get("repo"), r.Form.Get("tag"), r.Form.Get("comment"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, &types.ImageLayerImportResponse{
+		ID: imgID,
+	})
+}
+
+func (s *imageRouter) postImagesFlatten(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "image flatten"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	imgID, err := s.backend.FlattenImage(vars["name"], r.Form.Get("repo"), r.Form.Get("tag"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, &types.ImageFlattenResponse{
+		ID: imgID,
+	})
+}
+
 func (s *imageRouter) deleteImages(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -266,6 +367,15 @@ func (s *imageRouter) getImagesHistory(ctx context.Context, w http.ResponseWrite
 	return httputils.WriteJSON(w, http.StatusOK, history)
 }
 
+func (s *imageRouter) getImagesVerify(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	resp, err := s.backend.ImageVerify(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, resp)
+}
+
 func (s *imageRouter) postImagesTag(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -315,6 +425,17 @@ func (s *imageRouter) getImagesSearch(ctx context.Context, w http.ResponseWriter
 	return httputils.WriteJSON(w, http.StatusOK, query.Results)
 }
 
+func (s *imageRouter) getImagesAudit(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "image audit"); err != nil {
+		return err
+	}
+	report, err := s.backend.ImagesAudit()
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
 func (s *imageRouter) postImagesPrune(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -329,9 +450,91 @@ func (s *imageRouter) postImagesPrune(ctx context.Context, w http.ResponseWriter
 		return err
 	}
 
+	pruneFilters, err := filters.FromParam(r.Form.Get("filters"))
+	if err != nil {
+		return err
+	}
+	cfg.Filters = pruneFilters
+
 	pruneReport, err := s.backend.ImagesPrune(&cfg)
 	if err != nil {
 		return err
 	}
 	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
 }
+
+// registryAuth returns the credentials and the metadata headers for the
+// registry sent with a request.
+func registryAuth(r *http.Request) (*types.AuthConfig, map[string][]string) {
+	authConfig := &types.AuthConfig{}
+	if authEncoded := r.Header.Get("X-Registry-Auth"); authEncoded != "" {
+		authJSON := base64.NewDecoder(base64.URLEncoding, strings.NewReader(authEncoded))
+		if err := json.NewDecoder(authJSON).Decode(authConfig); err != nil {
+			authConfig = &types.AuthConfig{}
+		}
+	}
+	metaHeaders := map[string][]string{}
+	for k, v := range r.Header {
+		if strings.HasPrefix(k, "X-Meta-") {
+			metaHeaders[k] = v
+		}
+	}
+	return authConfig, metaHeaders
+}
+
+func (s *imageRouter) getManifestsJSON(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	authConfig, metaHeaders := registryAuth(r)
+	descriptor, err := s.backend.InspectManifest(ctx, vars["name"], metaHeaders, authConfig)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, descriptor)
+}
+
+func (s *imageRouter) postManifestsPush(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var manifests []types.ManifestDescriptor
+	if err := json.NewDecoder(r.Body).Decode(&manifests); err != nil {
+		return err
+	}
+
+	authConfig, metaHeaders := registryAuth(r)
+	dgst, err := s.backend.PushManifestList(ctx, vars["name"], manifests, metaHeaders, authConfig)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, &types.ManifestListPushResponse{Digest: dgst})
+}
+
+func (s *imageRouter) getRegistriesRepositories(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	limit := registry.DefaultCatalogLimit
+	if r.Form.Get("limit") != "" {
+		limitValue, err := strconv.Atoi(r.Form.Get("limit"))
+		if err != nil {
+			return err
+		}
+		limit = limitValue
+	}
+
+	authConfig, metaHeaders := registryAuth(r)
+	repositories, err := s.backend.RegistryRepositories(ctx, vars["name"], r.Form.Get("last"), limit, metaHeaders, authConfig)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, repositories)
+}
+
+func (s *imageRouter) getRepositoriesTags(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	authConfig, metaHeaders := registryAuth(r)
+	tags, err := s.backend.RepositoryTags(ctx, vars["name"], metaHeaders, authConfig)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, tags)
+}
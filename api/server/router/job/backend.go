@@ -0,0 +1,12 @@
+package job
+
+import "github.com/docker/docker/api/types"
+
+// Backend is the methods that need to be implemented to provide
+// job specific functionality
+type Backend interface {
+	Jobs() []*types.Job
+	JobInspect(name string) (*types.Job, error)
+	JobCreate(req *types.JobCreateRequest) (*types.Job, error)
+	JobRm(name string, force bool) error
+}
@@ -0,0 +1,35 @@
+package job
+
+import "github.com/docker/docker/api/server/router"
+
+// jobRouter is a router to talk with the jobs controller
+type jobRouter struct {
+	backend Backend
+	routes  []router.Route
+}
+
+// NewRouter initializes a new job router
+func NewRouter(b Backend) router.Router {
+	r := &jobRouter{
+		backend: b,
+	}
+	r.initRoutes()
+	return r
+}
+
+// Routes returns the available routes to the jobs controller
+func (r *jobRouter) Routes() []router.Route {
+	return r.routes
+}
+
+func (r *jobRouter) initRoutes() {
+	r.routes = []router.Route{
+		// GET
+		router.NewGetRoute("/jobs", r.getJobsList),
+		router.NewGetRoute("/jobs/{name:.*}", r.getJobByName),
+		// POST
+		router.NewPostRoute("/jobs/create", r.postJobsCreate),
+		// DELETE
+		router.NewDeleteRoute("/jobs/{name:.*}", r.deleteJobs),
+	}
+}
@@ -1,6 +1,8 @@
 package network
 
 import (
+	"time"
+
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/libnetwork"
@@ -17,4 +19,9 @@ type Backend interface {
 	ConnectContainerToNetwork(containerName, networkName string, endpointConfig *network.EndpointSettings) error
 	DisconnectContainerFromNetwork(containerName string, networkName string, force bool) error
 	DeleteNetwork(name string) error
+	NetworksPrune(config *types.NetworksPruneConfig) (*types.NetworksPruneReport, error)
+	NetworksReconcile() (*types.NetworksReconcileReport, error)
+	NetworkPorts() ([]types.AllocatedPort, error)
+	NetworksLastUsed(ids []string) map[string]time.Time
+	NetworkDiagnostics(nw libnetwork.Network) (*types.NetworkDiagnostics, error)
 }
@@ -33,10 +33,13 @@ func (r *networkRouter) initRoutes() {
 		router.NewGetRoute("/networks", r.getNetworksList),
 		router.NewGetRoute("/networks/", r.getNetworksList),
 		router.NewGetRoute("/networks/{id:.+}", r.getNetwork),
+		router.NewGetRoute("/ports", r.getPorts),
 		// POST
 		router.NewPostRoute("/networks/create", r.postNetworkCreate),
 		router.NewPostRoute("/networks/{id:.*}/connect", r.postNetworkConnect),
 		router.NewPostRoute("/networks/{id:.*}/disconnect", r.postNetworkDisconnect),
+		router.NewPostRoute("/networks/prune", r.postNetworksPrune),
+		router.NewPostRoute("/networks/reconcile", r.postNetworksReconcile),
 		// DELETE
 		router.NewDeleteRoute("/networks/{id:.*}", r.deleteNetwork),
 	}
@@ -34,6 +34,7 @@ func (n *networkRouter) getNetworksList(ctx context.Context, w http.ResponseWrit
 
 	// Combine the network list returned by Docker daemon if it is not already
 	// returned by the cluster manager
+	var local []*types.NetworkResource
 SKIP:
 	for _, nw := range n.backend.GetNetworks() {
 		for _, nl := range list {
@@ -41,7 +42,11 @@ SKIP:
 				continue SKIP
 			}
 		}
-		list = append(list, *n.buildNetworkResource(nw))
+		local = append(local, n.buildNetworkResource(nw))
+	}
+	n.setLastUsed(local...)
+	for _, nr := range local {
+		list = append(list, *nr)
 	}
 
 	list, err = filterNetworks(list, netFilters)
@@ -63,7 +68,17 @@ func (n *networkRouter) getNetwork(ctx context.Context, w http.ResponseWriter, r
 		}
 		return err
 	}
-	return httputils.WriteJSON(w, http.StatusOK, n.buildNetworkResource(nw))
+	nr := n.buildNetworkResource(nw)
+	n.setLastUsed(nr)
+	if httputils.BoolValue(r, "verbose") {
+		if err := httputils.CheckMinVersion(ctx, "1.25", "verbose network inspect"); err != nil {
+			return err
+		}
+		if nr.Diagnostics, err = n.backend.NetworkDiagnostics(nw); err != nil {
+			return err
+		}
+	}
+	return httputils.WriteJSON(w, http.StatusOK, nr)
 }
 
 func (n *networkRouter) postNetworkCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -148,6 +163,55 @@ func (n *networkRouter) deleteNetwork(ctx context.Context, w http.ResponseWriter
 	return nil
 }
 
+func (n *networkRouter) postNetworksPrune(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "network prune"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var cfg types.NetworksPruneConfig
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		return err
+	}
+
+	pruneFilters, err := filters.FromParam(r.Form.Get("filters"))
+	if err != nil {
+		return err
+	}
+	cfg.Filters = pruneFilters
+
+	pruneReport, err := n.backend.NetworksPrune(&cfg)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
+}
+
+func (n *networkRouter) getPorts(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "network ports"); err != nil {
+		return err
+	}
+	ports, err := n.backend.NetworkPorts()
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, ports)
+}
+
+func (n *networkRouter) postNetworksReconcile(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	report, err := n.backend.NetworksReconcile()
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
 func (n *networkRouter) buildNetworkResource(nw libnetwork.Network) *types.NetworkResource {
 	r := &types.NetworkResource{}
 	if nw == nil {
@@ -189,9 +253,23 @@ func (n *networkRouter) buildNetworkResource(nw libnetwork.Network) *types.Netwo
 
 		r.Containers[key] = buildEndpointResource(tmpID, e.Name(), ei)
 	}
+	r.ContainerCount = len(epl)
 	return r
 }
 
+// setLastUsed sets when the networks were last used, with a single call to
+// the backend.
+func (n *networkRouter) setLastUsed(nrs ...*types.NetworkResource) {
+	ids := make([]string, 0, len(nrs))
+	for _, nr := range nrs {
+		ids = append(ids, nr.ID)
+	}
+	lastUsed := n.backend.NetworksLastUsed(ids)
+	for _, nr := range nrs {
+		nr.LastUsed = lastUsed[nr.ID]
+	}
+}
+
 func buildIpamResources(r *types.NetworkResource, nwInfo libnetwork.NetworkInfo) {
 	id, opts, ipv4conf, ipv6conf := nwInfo.IpamConfig()
 
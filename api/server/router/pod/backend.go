@@ -0,0 +1,14 @@
+package pod
+
+import "github.com/docker/docker/api/types"
+
+// Backend is the methods that need to be implemented to provide
+// pod specific functionality
+type Backend interface {
+	Pods() ([]*types.Pod, error)
+	PodInspect(name string) (*types.Pod, error)
+	PodCreate(req *types.PodCreateRequest) (*types.Pod, error)
+	PodStart(name string) error
+	PodStop(name string, seconds *int) error
+	PodRm(name string, force, removeVolumes bool) error
+}
@@ -0,0 +1,108 @@
+package pod
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func (p *podRouter) getPodsList(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+		return err
+	}
+	pods, err := p.backend.Pods()
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, pods)
+}
+
+func (p *podRouter) getPodByName(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+		return err
+	}
+	pod, err := p.backend.PodInspect(vars["name"])
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, pod)
+}
+
+func (p *podRouter) postPodsCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req types.PodCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return err
+	}
+
+	pod, err := p.backend.PodCreate(&req)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, pod)
+}
+
+func (p *podRouter) postPodsStart(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+		return err
+	}
+	if err := p.backend.PodStart(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (p *podRouter) postPodsStop(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var seconds *int
+	if t := r.Form.Get("t"); t != "" {
+		s, err := strconv.Atoi(t)
+		if err != nil {
+			return err
+		}
+		seconds = &s
+	}
+
+	if err := p.backend.PodStop(vars["name"], seconds); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (p *podRouter) deletePods(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckMinVersion(ctx, "1.25", "pods"); err != nil {
+		return err
+	}
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	force := httputils.BoolValue(r, "force")
+	removeVolumes := httputils.BoolValue(r, "v")
+	if err := p.backend.PodRm(vars["name"], force, removeVolumes); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
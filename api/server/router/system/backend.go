@@ -15,7 +15,13 @@ type Backend interface {
 	SystemInfo() (*types.Info, error)
 	SystemVersion() types.Version
 	SystemDiskUsage() (*types.DiskUsage, error)
+	SystemMaintenance(config types.MaintenanceConfig) error
+	SystemLeakedMounts() ([]types.LeakedMount, error)
+	SystemUnmountLeakedMounts() ([]types.LeakedMount, error)
+	SystemLogDrivers() []types.LogDriver
 	SubscribeToEvents(since, until time.Time, ef filters.Args) ([]events.Message, chan interface{})
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(ctx context.Context, authConfig *types.AuthConfig) (string, string, error)
+	TrustKeyExport(passphrase string) ([]byte, error)
+	TrustKeyImport(key []byte, passphrase string) error
 }
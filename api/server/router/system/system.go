@@ -25,9 +25,15 @@ func NewRouter(b Backend, c *cluster.Cluster) router.Router {
 		router.NewGetRoute("/_ping", pingHandler),
 		router.Cancellable(router.NewGetRoute("/events", r.getEvents)),
 		router.NewGetRoute("/info", r.getInfo),
+		router.NewGetRoute("/info/log-drivers", r.getLogDrivers),
 		router.NewGetRoute("/version", r.getVersion),
 		router.NewGetRoute("/system/df", r.getDiskUsage),
+		router.NewPostRoute("/system/maintenance", r.postMaintenance),
+		router.NewGetRoute("/system/mounts/leaked", r.getLeakedMounts),
+		router.NewPostRoute("/system/mounts/leaked/unmount", r.postUnmountLeakedMounts),
 		router.NewPostRoute("/auth", r.postAuth),
+		router.NewPostRoute("/trust/key/export", r.postTrustKeyExport),
+		router.NewPostRoute("/trust/key/import", r.postTrustKeyImport),
 	}
 
 	return r
@@ -49,6 +49,10 @@ func (s *systemRouter) getInfo(ctx context.Context, w http.ResponseWriter, r *ht
 	return httputils.WriteJSON(w, http.StatusOK, info)
 }
 
+func (s *systemRouter) getLogDrivers(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return httputils.WriteJSON(w, http.StatusOK, s.backend.SystemLogDrivers())
+}
+
 func (s *systemRouter) getVersion(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	info := s.backend.SystemVersion()
 	info.APIVersion = api.DefaultVersion
@@ -65,6 +69,41 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 	return httputils.WriteJSON(w, http.StatusOK, du)
 }
 
+func (s *systemRouter) postMaintenance(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var config types.MaintenanceConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		return err
+	}
+	if err := s.backend.SystemMaintenance(config); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *systemRouter) getLeakedMounts(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	leaks, err := s.backend.SystemLeakedMounts()
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, leaks)
+}
+
+func (s *systemRouter) postUnmountLeakedMounts(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	unmounted, err := s.backend.SystemUnmountLeakedMounts()
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, unmounted)
+}
+
 func (s *systemRouter) getEvents(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -160,6 +199,42 @@ func (s *systemRouter) postAuth(ctx context.Context, w http.ResponseWriter, r *h
 	})
 }
 
+func (s *systemRouter) postTrustKeyExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var options types.TrustKeyExportOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return err
+	}
+	key, err := s.backend.TrustKeyExport(options.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	_, err = w.Write(key)
+	return err
+}
+
+func (s *systemRouter) postTrustKeyImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var options types.TrustKeyImportOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return err
+	}
+	if err := s.backend.TrustKeyImport(options.Key, options.Passphrase); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func eventTime(formTime string) (time.Time, error) {
 	t, tNano, err := timetypes.ParseTimestamps(formTime, -1)
 	if err != nil {
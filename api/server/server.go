@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/errors"
@@ -28,6 +29,16 @@ type Config struct {
 	Version     string
 	SocketGroup string
 	TLSConfig   *tls.Config
+
+	// ReadTimeout is the time a client has to send the headers of its
+	// first request, and IdleTimeout the time a connection is kept open
+	// between two requests. They are not limited if they are 0.
+	ReadTimeout time.Duration
+	IdleTimeout time.Duration
+	// MaxConcurrentRequests is the maximum number of requests handled at
+	// once, beyond which the requests are rejected. It is not limited if
+	// it is 0.
+	MaxConcurrentRequests int
 }
 
 // Server contains instance details for the server
@@ -62,6 +73,9 @@ func (s *Server) Accept(addr string, listeners ...net.Listener) {
 			},
 			l: listener,
 		}
+		if s.cfg.ReadTimeout > 0 || s.cfg.IdleTimeout > 0 {
+			httpServer.srv.ConnState = connStateTracker(s.cfg.ReadTimeout, s.cfg.IdleTimeout)
+		}
 		s.servers = append(s.servers, httpServer)
 	}
 }
@@ -78,9 +92,14 @@ func (s *Server) Close() {
 // serveAPI loops through all initialized servers and spawns goroutine
 // with Server method for each. It sets createMux() as Handler also.
 func (s *Server) serveAPI() error {
+	var handler http.Handler = s.routerSwapper
+	if s.cfg.MaxConcurrentRequests > 0 {
+		handler = newConcurrencyLimiter(handler, s.cfg.MaxConcurrentRequests)
+	}
+
 	var chErrors = make(chan error, len(s.servers))
 	for _, srv := range s.servers {
-		srv.srv.Handler = s.routerSwapper
+		srv.srv.Handler = handler
 		go func(srv *HTTPServer) {
 			var err error
 			logrus.Infof("API listen on %s", srv.l.Addr())
@@ -18,6 +18,12 @@ type ContainerAttachConfig struct {
 	Stream     bool
 	DetachKeys string
 
+	// Scrollback replays the recent output of a TTY kept by the daemon,
+	// or only the output written since the last detach if SinceDetach is
+	// set.
+	Scrollback  bool
+	SinceDetach bool
+
 	// Used to signify that streams are multiplexed and therefore need a StdWriter to encode stdout/sderr messages accordingly.
 	// TODO @cpuguy83: This shouldn't be needed. It was only added so that http and websocket endpoints can use the same function, and the websocket function was not using a stdwriter prior to this change...
 	// HOWEVER, the websocket endpoint is using a single stream and SHOULD be encoded with stdout/stderr as is done for HTTP since it is still just a single stream.
@@ -36,6 +42,7 @@ type ContainerLogsConfig struct {
 // behavior of a backend.ContainerStats() call.
 type ContainerStatsConfig struct {
 	Stream    bool
+	Summary   bool
 	OutStream io.Writer
 	Version   string
 }
@@ -71,6 +78,9 @@ type ExecProcessConfig struct {
 type ContainerCommitConfig struct {
 	types.ContainerCommitConfig
 	Changes []string
+	// Holder, if set, holds the new image for the holder from its creation,
+	// see image.Store.CreateHeld.
+	Holder string
 }
 
 // ProgressWriter is an interface
@@ -80,4 +90,17 @@ type ProgressWriter struct {
 	StdoutFormatter    *streamformatter.StdoutFormatter
 	StderrFormatter    *streamformatter.StderrFormatter
 	ProgressReaderFunc func(io.ReadCloser) io.ReadCloser
+	// StepFunc, if set, is called at the start and the end of each step of
+	// a build, for the structured build progress.
+	StepFunc func(types.BuildProgress)
+}
+
+// LayerCompression is the compression of the layers of the images pushed or
+// saved.
+type LayerCompression struct {
+	// Algorithm is gzip or zstd, or empty for the default.
+	Algorithm string
+	// Level is the compression level, 0 being the default level of the
+	// algorithm.
+	Level int
 }
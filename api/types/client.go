@@ -23,6 +23,13 @@ type ContainerAttachOptions struct {
 	Stdout     bool
 	Stderr     bool
 	DetachKeys string
+	// FlowControl requests the use of flow control on the hijacked
+	// connection, if the daemon supports it.
+	FlowControl bool
+	// Scrollback replays the recent output of a TTY, or only the output
+	// since the last detach if SinceDetach is set.
+	Scrollback  bool
+	SinceDetach bool
 }
 
 // ContainerCommitOptions holds parameters to commit changes into a container.
@@ -32,7 +39,10 @@ type ContainerCommitOptions struct {
 	Author    string
 	Changes   []string
 	Pause     bool
-	Config    *container.Config
+	// Consistent pauses a running container, flushes the writes to its
+	// filesystem, commits it and resumes it.
+	Consistent bool
+	Config     *container.Config
 }
 
 // ContainerExecInspect holds information returned by exec inspect.
@@ -45,14 +55,23 @@ type ContainerExecInspect struct {
 
 // ContainerListOptions holds parameters to list containers with.
 type ContainerListOptions struct {
-	Quiet  bool
-	Size   bool
-	All    bool
-	Latest bool
-	Since  string
-	Before string
-	Limit  int
-	Filter filters.Args
+	Quiet     bool
+	Size      bool
+	Resources bool
+	All       bool
+	Latest    bool
+	Since     string
+	Before    string
+	Limit     int
+	Filter    filters.Args
+}
+
+// ContainerExportOptions holds parameters to export a container.
+type ContainerExportOptions struct {
+	// Format is the format of the export: "tar", the default, for a tar
+	// archive of the filesystem of the container, or "oci-bundle" for an
+	// OCI runtime bundle.
+	Format string
 }
 
 // ContainerLogsOptions holds parameters to filter logs with.
@@ -73,6 +92,19 @@ type ContainerRemoveOptions struct {
 	Force         bool
 }
 
+// PodRemoveOptions holds parameters to remove pods.
+type PodRemoveOptions struct {
+	RemoveVolumes bool
+	Force         bool
+}
+
+// ContainerCreateOptions holds parameters to create containers.
+type ContainerCreateOptions struct {
+	// ForceOomKillDisable allows disabling the OOM killer of the container
+	// without a memory limit. It is not stored with the container.
+	ForceOomKillDisable bool
+}
+
 // ContainerStartOptions holds parameters to start containers.
 type ContainerStartOptions struct {
 	CheckpointID string
@@ -84,6 +116,13 @@ type CopyToContainerOptions struct {
 	AllowOverwriteDirWithFile bool
 }
 
+// CopyBetweenContainersOptions holds information
+// about files to copy from a container into another one
+type CopyBetweenContainersOptions struct {
+	AllowOverwriteDirWithFile bool
+	FollowLink                bool
+}
+
 // EventsOptions holds parameters to filter events with.
 type EventsOptions struct {
 	Since   string
@@ -96,6 +135,12 @@ type NetworkListOptions struct {
 	Filters filters.Args
 }
 
+// NetworkInspectOptions holds parameters to inspect a network with.
+type NetworkInspectOptions struct {
+	// Verbose includes the diagnostics of the network on the docker host.
+	Verbose bool
+}
+
 // HijackedResponse holds connection information for a hijacked request.
 type HijackedResponse struct {
 	Conn   net.Conn
@@ -147,6 +192,9 @@ type ImageBuildOptions struct {
 	AuthConfigs    map[string]AuthConfig
 	Context        io.Reader
 	Labels         map[string]string
+	// Annotations are set as labels of the image under the reserved
+	// BuildAnnotationPrefix namespace, along with the build metadata.
+	Annotations map[string]string
 	// squash the resulting image's layers to the parent
 	// preserves the original image and creates a new one from the parent with all
 	// the changes applied to a single layer
@@ -154,7 +202,56 @@ type ImageBuildOptions struct {
 	// CacheFrom specifies images that are used for matching cache. Images
 	// specified here do not need to have a valid parent chain to match cache.
 	CacheFrom []string
-}
+	// Secrets holds the content of the secrets, by id, that RUN instructions
+	// can mount with --mount=type=secret. The secrets are not stored in the
+	// image.
+	Secrets map[string][]byte
+	// StructuredProgress requests the build progress as a sequence of
+	// BuildProgress records instead of a stream of JSON messages.
+	StructuredProgress bool
+	// Validate only parses and validates the Dockerfile against the build
+	// context, without running any of its steps.
+	Validate bool
+	// GitAuth holds the credentials of the clone of the RemoteContext, if it
+	// is a private git repository.
+	GitAuth *GitAuthConfig
+	// FromPolicy is the resolution policy of the FROM references, one of
+	// BuildFromPolicyAny and BuildFromPolicyPinned. The policy of the daemon
+	// applies if it is stricter.
+	FromPolicy string
+}
+
+// GitAuthConfig holds the credentials of the clone of a private git
+// repository. The token authenticates a clone over HTTP(S), with the username
+// x-access-token if Username is empty, and the private key a clone over SSH.
+type GitAuthConfig struct {
+	Username      string `json:",omitempty"`
+	Token         string `json:",omitempty"`
+	SSHKey        string `json:",omitempty"`
+	SSHKnownHosts string `json:",omitempty"` // SSHKnownHosts holds the trusted host keys, in the known_hosts format
+}
+
+// BuildAnnotationPrefix is the reserved label namespace of the build
+// annotations and build metadata.
+const BuildAnnotationPrefix = "com.docker.build."
+
+// BuildGitCommitAnnotation is the annotation recording the commit checked out
+// when the build context is a git repository.
+const BuildGitCommitAnnotation = "git-commit"
+
+// BuildBaseImagesAnnotation is the annotation recording the base images of
+// an image by digest, from the nearest, so that its build can be reproduced
+// from the same base images.
+const BuildBaseImagesAnnotation = "base-images"
+
+// Resolution policies of the FROM references of the builds.
+const (
+	// BuildFromPolicyAny accepts any reference.
+	BuildFromPolicyAny = "any"
+	// BuildFromPolicyPinned only accepts the references by digest, such as
+	// the references resolved through content trust, and the image IDs.
+	BuildFromPolicyPinned = "pinned"
+)
 
 // ImageBuildResponse holds information
 // returned by a server after building
@@ -182,6 +279,18 @@ type ImageImportOptions struct {
 	Changes []string // Changes are the raw changes to apply to this image
 }
 
+// ImageLayerImportOptions holds information to import a layer on top of an
+// image.
+type ImageLayerImportOptions struct {
+	Ref     string // Ref is the name to tag the new image with
+	Message string // Message is the comment of the new image
+}
+
+// ImageFlattenOptions holds information to flatten an image.
+type ImageFlattenOptions struct {
+	Ref string // Ref is the name to tag the new image with
+}
+
 // ImageListOptions holds parameters to filter the list of images with.
 type ImageListOptions struct {
 	MatchName string
@@ -212,7 +321,27 @@ type ImagePullOptions struct {
 type RequestPrivilegeFunc func() (string, error)
 
 //ImagePushOptions holds information to push images.
-type ImagePushOptions ImagePullOptions
+type ImagePushOptions struct {
+	All           bool
+	RegistryAuth  string // RegistryAuth is the base64 encoded credentials for the registry
+	PrivilegeFunc RequestPrivilegeFunc
+	// Compression is the compression of the layers pushed, gzip or zstd,
+	// the default of the daemon being used if it is empty.
+	Compression string
+	// CompressionLevel is the compression level of the layers pushed, 0
+	// being the default level of the compression.
+	CompressionLevel int
+}
+
+// ImageSaveOptions holds parameters to save images.
+type ImageSaveOptions struct {
+	// Compression is the compression of the layers saved, gzip or zstd,
+	// the layers being saved uncompressed if it is empty.
+	Compression string
+	// CompressionLevel is the compression level, 0 being the default level
+	// of the compression.
+	CompressionLevel int
+}
 
 // ImageRemoveOptions holds parameters to remove images.
 type ImageRemoveOptions struct {
@@ -228,6 +357,26 @@ type ImageSearchOptions struct {
 	Limit         int
 }
 
+// ManifestOptions holds parameters to inspect manifests and to push manifest
+// lists.
+type ManifestOptions struct {
+	RegistryAuth string // RegistryAuth is the base64 encoded credentials for the registry
+}
+
+// RegistryRepositoriesOptions holds parameters to list the repositories of
+// a registry.
+type RegistryRepositoriesOptions struct {
+	RegistryAuth string // RegistryAuth is the base64 encoded credentials for the registry
+	Last         string
+	Limit        int
+}
+
+// RepositoryTagsOptions holds parameters to list the tags of a repository
+// in its registry.
+type RepositoryTagsOptions struct {
+	RegistryAuth string // RegistryAuth is the base64 encoded credentials for the registry
+}
+
 // ResizeOptions holds parameters to resize a tty.
 // It can be used to resize container ttys and
 // exec process ttys too.
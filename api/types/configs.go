@@ -16,6 +16,10 @@ type ContainerCreateConfig struct {
 	HostConfig       *container.HostConfig
 	NetworkingConfig *network.NetworkingConfig
 	AdjustCPUShares  bool
+
+	// ForceOomKillDisable allows disabling the OOM killer of the container
+	// without a memory limit. It only applies to this request.
+	ForceOomKillDisable bool
 }
 
 // ContainerRmConfig holds arguments for the container remove
@@ -2,6 +2,7 @@ package container
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/mount"
@@ -31,6 +32,30 @@ func (n IpcMode) IsPrivate() bool {
 	return !(n.IsHost() || n.IsContainer())
 }
 
+// IsPrivateMode indicates whether the container uses its own ipc stack in
+// the private mode, which cannot be shared with other containers. Unlike
+// IsPrivate, it is false for the other modes with their own ipc stack.
+func (n IpcMode) IsPrivateMode() bool {
+	return n == "private"
+}
+
+// IsShareable indicates whether the container uses its own ipc stack, which
+// can be shared with other containers.
+func (n IpcMode) IsShareable() bool {
+	return n == "shareable"
+}
+
+// IsNone indicates whether the container uses its own private ipc stack,
+// with an empty /dev/shm that is not mounted from the host.
+func (n IpcMode) IsNone() bool {
+	return n == "none"
+}
+
+// IsEmpty indicates whether the container ipc mode is unset.
+func (n IpcMode) IsEmpty() bool {
+	return n == ""
+}
+
 // IsHost indicates whether the container uses the host's ipc stack.
 func (n IpcMode) IsHost() bool {
 	return n == "host"
@@ -46,7 +71,7 @@ func (n IpcMode) IsContainer() bool {
 func (n IpcMode) Valid() bool {
 	parts := strings.Split(string(n), ":")
 	switch mode := parts[0]; mode {
-	case "", "host":
+	case "", "host", "private", "shareable", "none":
 	case "container":
 		if len(parts) != 2 || parts[1] == "" {
 			return false
@@ -90,6 +115,61 @@ func (n UsernsMode) Valid() bool {
 	return true
 }
 
+// CgroupnsMode represents the cgroup namespace mode of the container.
+type CgroupnsMode string
+
+// IsPrivate indicates whether the container uses its own private cgroup namespace.
+func (c CgroupnsMode) IsPrivate() bool {
+	return c == "private"
+}
+
+// IsHost indicates whether the container uses the host's cgroup namespace.
+func (c CgroupnsMode) IsHost() bool {
+	return c == "host"
+}
+
+// IsEmpty indicates whether the container cgroup namespace mode is unset.
+func (c CgroupnsMode) IsEmpty() bool {
+	return c == ""
+}
+
+// Valid indicates whether the cgroup namespace mode is valid.
+func (c CgroupnsMode) Valid() bool {
+	return c.IsEmpty() || c.IsPrivate() || c.IsHost()
+}
+
+// CoreDumpMode represents the handling of the core dumps of the processes of
+// the container.
+type CoreDumpMode string
+
+// IsKernel indicates whether the core dumps are written where the
+// kernel.core_pattern of the host says, usually in the container.
+func (c CoreDumpMode) IsKernel() bool {
+	return c == "kernel"
+}
+
+// IsCapture indicates whether the core dumps are captured in a directory
+// managed by the daemon.
+func (c CoreDumpMode) IsCapture() bool {
+	return c == "capture"
+}
+
+// IsDiscard indicates whether the processes of the container do not dump
+// core.
+func (c CoreDumpMode) IsDiscard() bool {
+	return c == "discard"
+}
+
+// IsEmpty indicates whether the core dump mode is unset.
+func (c CoreDumpMode) IsEmpty() bool {
+	return c == ""
+}
+
+// Valid indicates whether the core dump mode is valid.
+func (c CoreDumpMode) Valid() bool {
+	return c.IsEmpty() || c.IsKernel() || c.IsCapture() || c.IsDiscard()
+}
+
 // CgroupSpec represents the cgroup to use for the container.
 type CgroupSpec string
 
@@ -187,6 +267,15 @@ type DeviceMapping struct {
 	CgroupPermissions string
 }
 
+// DeviceRequest represents a request for devices from a device driver
+// plugin, which are injected into the container when it starts.
+type DeviceRequest struct {
+	Driver    string            // Name of the device driver plugin
+	Count     int               // Number of devices to request, -1 for all the devices
+	DeviceIDs []string          // IDs of the devices to request
+	Options   map[string]string // Options of the device driver
+}
+
 // RestartPolicy represents the restart policies of the container.
 type RestartPolicy struct {
 	Name              string
@@ -234,6 +323,7 @@ type Resources struct {
 	// Applicable to all platforms
 	CPUShares int64 `json:"CpuShares"` // CPU shares (relative weight vs. other containers)
 	Memory    int64 // Memory limit (in bytes)
+	NanoCPUs  int64 `json:"NanoCpus"` // CPU quota in units of 10<sup>-9</sup> CPUs.
 
 	// Applicable to UNIX platforms
 	CgroupParent         string // Parent cgroup.
@@ -248,6 +338,7 @@ type Resources struct {
 	CpusetCpus           string          // CpusetCpus 0-2, 0,1
 	CpusetMems           string          // CpusetMems 0-2, 0,1
 	Devices              []DeviceMapping // List of devices to map inside the container
+	DeviceRequests       []DeviceRequest // List of devices to request from device drivers
 	DiskQuota            int64           // Disk limit (in bytes)
 	KernelMemory         int64           // Kernel memory limit (in bytes)
 	MemoryReservation    int64           // Memory soft limit (in bytes)
@@ -270,6 +361,21 @@ type UpdateConfig struct {
 	// Contains container's resources (cgroups, ulimits)
 	Resources
 	RestartPolicy RestartPolicy
+
+	// ForceOomKillDisable allows disabling the OOM killer of a container
+	// without a memory limit, which is refused otherwise.
+	ForceOomKillDisable bool `json:",omitempty"`
+
+	// Labels to add to the container, overwriting existing keys
+	LabelsAdd map[string]string `json:",omitempty"`
+	// Label keys to remove from the container
+	LabelsRemove []string `json:",omitempty"`
+
+	// Extra hosts to add to the container, in the host:ip form, replacing the
+	// existing entries of the same hosts
+	ExtraHostsAdd []string `json:",omitempty"`
+	// Hosts whose extra hosts entries are removed from the container
+	ExtraHostsRemove []string `json:",omitempty"`
 }
 
 // HostConfig the non-portable Config structure of a container.
@@ -284,6 +390,8 @@ type HostConfig struct {
 	PortBindings    nat.PortMap   // Port mapping between the exposed port (container) and the host
 	RestartPolicy   RestartPolicy // Restart policy to be used for the container
 	AutoRemove      bool          // Automatically remove container when it exits
+	MaxRuntime      time.Duration `json:",omitempty"` // Time after which the container is stopped, counted from its start
+	EnvFileIDs      []string      `json:",omitempty"` // IDs of the environment files stored by the daemon, of which the variables are set in the container
 	VolumeDriver    string        // Name of the volume driver used to mount volumes
 	VolumesFrom     []string      // List of volumes to take from other container
 
@@ -297,12 +405,15 @@ type HostConfig struct {
 	GroupAdd        []string          // List of additional groups that the container process will run as
 	IpcMode         IpcMode           // IPC namespace to use for the container
 	Cgroup          CgroupSpec        // Cgroup to use for the container
+	CgroupnsMode    CgroupnsMode      `json:",omitempty"` // Cgroup namespace mode to use for the container
 	Links           []string          // List of links (in the name:alias form)
+	DependsOn       []string          `json:",omitempty"` // List of containers to start before the container (in the name[:condition] form)
 	OomScoreAdj     int               // Container preference for OOM-killing
 	PidMode         PidMode           // PID namespace to use for the container
 	Privileged      bool              // Is the container in privileged mode
 	PublishAllPorts bool              // Should docker publish all exposed port for the container
 	ReadonlyRootfs  bool              // Is the container root filesystem in read-only
+	RwPaths         []string          `json:",omitempty"` // List of writable paths over the read-only root filesystem
 	SecurityOpt     []string          // List of string values to customize labels for MLS systems, such as SELinux.
 	StorageOpt      map[string]string `json:",omitempty"` // Storage driver options per container.
 	Tmpfs           map[string]string `json:",omitempty"` // List of tmpfs (mounts) used for the container
@@ -311,6 +422,8 @@ type HostConfig struct {
 	ShmSize         int64             // Total shm memory usage
 	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
 	Runtime         string            `json:",omitempty"` // Runtime to use with this container
+	CoreDumps       CoreDumpMode      `json:",omitempty"` // Handling of the core dumps of the processes of the container
+	CoreDumpMaxSize int64             `json:",omitempty"` // Maximum size in bytes of a core dump, 0 for no limit
 
 	// Applicable to Windows
 	ConsoleSize [2]uint   // Initial console size (height,width)
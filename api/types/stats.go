@@ -173,3 +173,30 @@ type StatsJSON struct {
 	// Networks request version >=1.21
 	Networks map[string]NetworkStats `json:"networks,omitempty"`
 }
+
+// StatsSummary is a point-in-time summary of the resource usage of one
+// container. Rates such as the CPU percentage are computed by the daemon
+// from two consecutive samples.
+type StatsSummary struct {
+	ID     string    `json:"id"`
+	Name   string    `json:"name"`
+	OSType string    `json:"ostype"`
+	Read   time.Time `json:"read"`
+
+	// CPU usage between the two samples, as a percentage of one CPU.
+	CPUPercentage float64 `json:"cpu_percent"`
+	// Memory usage in bytes (private working set on Windows).
+	MemoryUsage uint64 `json:"memory_usage"`
+	// Memory limit in bytes. Not used on Windows.
+	MemoryLimit uint64 `json:"memory_limit,omitempty"`
+	// Memory usage as a percentage of the limit. Not used on Windows.
+	MemoryPercentage float64 `json:"memory_percent,omitempty"`
+	// Bytes received and sent over all networks since the container started.
+	NetworkRx uint64 `json:"network_rx_bytes"`
+	NetworkTx uint64 `json:"network_tx_bytes"`
+	// Bytes read from and written to block devices since the container started.
+	BlockRead  uint64 `json:"blkio_read_bytes"`
+	BlockWrite uint64 `json:"blkio_write_bytes"`
+	// Number of pids in the container. Not used on Windows.
+	PidsCurrent uint64 `json:"pids_current,omitempty"`
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/registry"
@@ -13,6 +14,24 @@ import (
 	"github.com/docker/go-connections/nat"
 )
 
+const (
+	// ContainerGroupLabel is the label of the replicas created by
+	// `docker run --replicas`. Its value is the name of their group.
+	ContainerGroupLabel = "com.docker.group"
+	// ContainerReplicaLabel is the label holding the index of a replica in
+	// its group, starting at 1.
+	ContainerReplicaLabel = "com.docker.group.replica"
+	// ContainerPodLabel is the label of the containers of a pod. Its value
+	// is the name of the pod.
+	ContainerPodLabel = "com.docker.pod"
+	// ContainerPodIndexLabel holds the index of a container in its pod,
+	// starting at 0 for the holder of the namespaces of the pod.
+	ContainerPodIndexLabel = "com.docker.pod.index"
+	// ContainerJobLabel is the label of the containers run by a job. Its
+	// value is the name of the job.
+	ContainerJobLabel = "com.docker.job"
+)
+
 // ContainerCreateResponse contains the information returned to a client on the
 // creation of a new container.
 type ContainerCreateResponse struct {
@@ -53,14 +72,191 @@ type AuthResponse struct {
 type ContainerWaitResponse struct {
 	// StatusCode is the status code of the wait job
 	StatusCode int `json:"StatusCode"`
+	// Exit describes how the container exited
+	Exit *ContainerExitStatus `json:",omitempty"`
+}
+
+// ContainerExitStatus describes how a container exited
+type ContainerExitStatus struct {
+	// Signal is the name of the signal which killed the process of the
+	// container, as in "SIGKILL", if any
+	Signal string `json:",omitempty"`
+	// OOMKilled is whether the process was killed for running out of memory
+	OOMKilled bool
+	// Error is the error of the daemon when starting the container, as in
+	// "exec format error", if any
+	Error      string `json:",omitempty"`
+	StartedAt  string
+	FinishedAt string
 }
 
 // ContainerCommitResponse contains response of Remote API:
 // POST "/commit?container="+containerID
 type ContainerCommitResponse struct {
+	ID     string                 `json:"Id"`
+	Timing *ContainerCommitTiming `json:",omitempty"`
+}
+
+// ContainerCommitTiming holds the duration of the steps of a crash-consistent
+// commit of a container, as in POST "/commit?consistent=1".
+type ContainerCommitTiming struct {
+	Pause  time.Duration
+	Sync   time.Duration
+	Commit time.Duration
+	Resume time.Duration
+	// Frozen is the time the processes of the container were paused.
+	Frozen time.Duration
+}
+
+// ImageLayerImportResponse contains response of Remote API:
+// POST "/images/{name:.*}/layers"
+type ImageLayerImportResponse struct {
 	ID string `json:"Id"`
 }
 
+// ImageFlattenResponse contains response of Remote API:
+// POST "/images/{name:.*}/flatten"
+type ImageFlattenResponse struct {
+	ID string `json:"Id"`
+}
+
+// Statuses of a blob verified by ImageVerifyResponse
+const (
+	BlobStatusOK      = "ok"
+	BlobStatusCorrupt = "corrupt"
+	BlobStatusMissing = "missing"
+)
+
+// ImageVerifyResponse contains response of Remote API:
+// GET "/images/{name:.*}/verify"
+type ImageVerifyResponse struct {
+	ID          string `json:"Id"`
+	RepoTags    []string
+	RepoDigests []string
+	Config      BlobVerification
+	Layers      []BlobVerification
+}
+
+// Damaged returns whether the configuration or a layer of the image is not
+// intact.
+func (r ImageVerifyResponse) Damaged() bool {
+	if r.Config.Status != BlobStatusOK {
+		return true
+	}
+	for _, l := range r.Layers {
+		if l.Status != BlobStatusOK {
+			return true
+		}
+	}
+	return false
+}
+
+// BlobVerification is the result of the verification of the configuration
+// or of a layer of an image against its digest.
+type BlobVerification struct {
+	Digest  string // Digest is the ID of the image, or the DiffID of the layer
+	ChainID string `json:",omitempty"`
+	Status  string
+	Message string `json:",omitempty"`
+}
+
+// ManifestDescriptor contains response of Remote API:
+// GET "/manifests/{name:.*}/json"
+// It describes a manifest in a registry, as referenced by a manifest list.
+type ManifestDescriptor struct {
+	MediaType string
+	Digest    string
+	Size      int64
+	Platform  ManifestPlatform
+}
+
+// ManifestPlatform is the platform of the image of a manifest referenced by a
+// manifest list.
+type ManifestPlatform struct {
+	Architecture string
+	OS           string
+	OSVersion    string   `json:",omitempty"`
+	OSFeatures   []string `json:",omitempty"`
+	Variant      string   `json:",omitempty"`
+	Features     []string `json:",omitempty"`
+}
+
+// ManifestListPushResponse contains response of Remote API:
+// POST "/manifests/{name:.*}/push"
+type ManifestListPushResponse struct {
+	Digest string
+}
+
+// Types of the dangling references reported by an audit of the images
+const (
+	AuditReference = "reference"
+	AuditParent    = "parent"
+	AuditLayer     = "layer"
+)
+
+// ImageAuditEntry is a dangling reference found by an audit of the images.
+type ImageAuditEntry struct {
+	// Type is reference for a tag or a digest, parent for the parent of an
+	// image and layer for the reference count of a layer.
+	Type string
+	// ID is the tag, digest, image or layer holding the reference.
+	ID      string
+	Message string
+}
+
+// ImagesAuditReport contains response of Remote API:
+// GET "/images/audit"
+type ImagesAuditReport struct {
+	DanglingReferences []ImageAuditEntry
+}
+
+// RegistryRepositories contains response of Remote API:
+// GET "/registries/{name}/repositories"
+type RegistryRepositories struct {
+	Repositories []string
+	// Next is the repository to list the next repositories after, or empty
+	// if all of them are listed
+	Next string `json:",omitempty"`
+}
+
+// RepositoryTags contains response of Remote API:
+// GET "/repositories/{name:.*}/tags"
+type RepositoryTags struct {
+	Name string
+	Tags []string
+}
+
+// Build step statuses of BuildProgress
+const (
+	BuildStepStarted   = "started"
+	BuildStepCompleted = "completed"
+	BuildStepFailed    = "failed"
+)
+
+// BuildProgress is a record of the structured progress of a build, sent by
+// POST "/build" when the client accepts "application/json-seq". The records
+// of a step have its index set; the last record of a successful build only
+// has the ImageID of the built image set.
+type BuildProgress struct {
+	Step        int    `json:",omitempty"` // Step is the index of the step, starting at 1
+	Total       int    `json:",omitempty"` // Total is the number of steps of the build
+	Instruction string `json:",omitempty"`
+	Status      string `json:",omitempty"`
+	Cached      bool   `json:",omitempty"` // Cached is set if the step used the build cache
+	ImageID     string `json:",omitempty"` // ImageID is the image resulting from the step
+	LayerID     string `json:",omitempty"` // LayerID is the DiffID of the top layer of the image
+	Output      string `json:",omitempty"`
+	Error       string `json:",omitempty"`
+}
+
+// BuildValidationError is an error found in the Dockerfile when the build is
+// only validated. It is sent as the auxiliary data of the build output.
+type BuildValidationError struct {
+	Line        int    // Line is the line of the instruction in the Dockerfile
+	Instruction string // Instruction is the name of the instruction
+	Message     string
+}
+
 // ContainerChange contains response of Remote API:
 // GET "/containers/{name:.*}/changes"
 type ContainerChange struct {
@@ -68,6 +264,184 @@ type ContainerChange struct {
 	Path string
 }
 
+// ContainerFilesystemUsage contains response of Remote API:
+// GET "/containers/{name:.*}/filesystem-usage"
+type ContainerFilesystemUsage struct {
+	SizeRw int64 // SizeRw is the size of the read-write layer of the container
+	Inodes int64 // Inodes is the number of files and directories of the read-write layer
+	// Directories is the usage of the read-write layer by top-level
+	// directory, the largest first.
+	Directories []ContainerDirectoryUsage
+	// Computed is when the usage was computed; it is cached by the daemon.
+	Computed time.Time
+}
+
+// ContainerDirectoryUsage is the usage of a top-level directory of the
+// read-write layer of a container. The files at the root are reported under
+// "/".
+type ContainerDirectoryUsage struct {
+	Path   string
+	Size   int64
+	Inodes int64
+}
+
+// Types of the drifts reported by a verification of a container
+const (
+	DriftCgroup   = "cgroup"
+	DriftMount    = "mount"
+	DriftSysctl   = "sysctl"
+	DriftIptables = "iptables"
+)
+
+// ContainerDrift is a difference between the configuration of a container
+// and the state of the kernel.
+type ContainerDrift struct {
+	// Type is cgroup for a cgroup limit, mount for a mount point, sysctl
+	// for a namespaced kernel parameter and iptables for the rule of a
+	// published port.
+	Type string
+	// Resource is the cgroup file, mount point, kernel parameter or port
+	// which drifted.
+	Resource string
+	Expected string
+	Actual   string
+}
+
+// ContainerVerifyReport contains response of Remote API:
+// GET "/containers/{name:.*}/verify"
+type ContainerVerifyReport struct {
+	Drifts []ContainerDrift
+}
+
+// ContainerResolveAttempt is a lookup made by the resolution of a name in a
+// container
+type ContainerResolveAttempt struct {
+	// Name is the name looked up, with a search domain appended if any.
+	Name string
+	// Source is "hosts" for the /etc/hosts file of the container, "embedded"
+	// for the embedded DNS server of its networks, or the address of the
+	// nameserver queried.
+	Source    string
+	Addresses []string `json:",omitempty"`
+	Error     string   `json:",omitempty"`
+}
+
+// ContainerResolveResponse contains response of Remote API:
+// GET "/containers/{name:.*}/resolve"
+type ContainerResolveResponse struct {
+	Name string
+	// Nameservers, Search and Ndots are the configuration of the resolver of
+	// the container, from its /etc/resolv.conf file.
+	Nameservers []string
+	Search      []string
+	Ndots       int
+	// Attempts are the lookups made, in order, until the name resolved.
+	Attempts []ContainerResolveAttempt
+	// Addresses are the addresses the name resolves to, if any.
+	Addresses []string
+}
+
+// PodMember is the configuration of a container of a pod
+type PodMember struct {
+	Name       string
+	Config     *container.Config
+	HostConfig *container.HostConfig
+	// NetworkingConfig is only allowed for the holder of the pod, as the
+	// other containers share its network namespace.
+	NetworkingConfig *network.NetworkingConfig
+}
+
+// PodCreateRequest is the request of Remote API:
+// POST "/pods/create"
+// The first member is the holder of the network and IPC namespaces of the
+// pod.
+type PodCreateRequest struct {
+	Name    string
+	Members []PodMember
+}
+
+// PodContainer is a container of a pod
+type PodContainer struct {
+	ID     string `json:"Id"`
+	Name   string
+	Holder bool
+	State  string
+}
+
+// Pod contains response of Remote API:
+// GET "/pods/{name:.*}"
+// A pod is a group of containers which share the network and IPC
+// namespaces of its holder, and are started and stopped together.
+type Pod struct {
+	Name       string
+	Containers []PodContainer
+}
+
+// JobCreateRequest is the request of Remote API:
+// POST "/jobs/create"
+type JobCreateRequest struct {
+	Name string
+	// Schedule is the cron schedule of the job, in the time zone of the
+	// daemon.
+	Schedule         string
+	Config           *container.Config
+	HostConfig       *container.HostConfig
+	NetworkingConfig *network.NetworkingConfig
+	// History is the number of runs of which the containers are kept. It
+	// defaults to 5.
+	History int
+}
+
+// JobRun is a run of a job, the container it created
+type JobRun struct {
+	ID         string `json:"Id"`
+	Name       string
+	State      string
+	ExitCode   int
+	StartedAt  string `json:",omitempty"`
+	FinishedAt string `json:",omitempty"`
+}
+
+// Job contains response of Remote API:
+// GET "/jobs/{name:.*}"
+// A job creates and starts a container on a cron schedule.
+type Job struct {
+	Name             string
+	Schedule         string
+	Config           *container.Config
+	HostConfig       *container.HostConfig
+	NetworkingConfig *network.NetworkingConfig `json:",omitempty"`
+	History          int
+	Created          time.Time
+	// NextRun is the time of the next run of the job.
+	NextRun time.Time `json:",omitempty"`
+	// Runs are the runs of the job which are kept, the latest first.
+	Runs []JobRun `json:",omitempty"`
+}
+
+// EnvFileCreateRequest is the request of Remote API:
+// POST "/envfiles/create"
+type EnvFileCreateRequest struct {
+	Name string
+	// Env are the variables of the environment file, in the KEY=VALUE form.
+	Env []string
+}
+
+// EnvFile contains response of Remote API:
+// GET "/envfiles/{name:.*}"
+// An environment file is a set of environment variables stored by the
+// daemon, and given to the containers which reference it.
+type EnvFile struct {
+	ID      string `json:"Id"`
+	Name    string
+	Created time.Time
+	// Keys are the names of the variables.
+	Keys []string `json:",omitempty"`
+	// Env are the variables, in the KEY=VALUE form. They are only returned
+	// when their values are revealed.
+	Env []string `json:",omitempty"`
+}
+
 // ImageHistory contains response of Remote API:
 // GET "/images/{name:.*}/history"
 type ImageHistory struct {
@@ -166,6 +540,24 @@ type Container struct {
 	}
 	NetworkSettings *SummaryNetworkSettings
 	Mounts          []MountPoint
+	Resources       *ContainerResourceUsage `json:",omitempty"`
+}
+
+// ContainerResourceUsage contains the cumulative I/O counters of a running
+// container, as reported by GET "/containers/json" when resources are requested.
+type ContainerResourceUsage struct {
+	BlkioReadBytes  uint64
+	BlkioWriteBytes uint64
+	NetworkRxBytes  uint64
+	NetworkTxBytes  uint64
+}
+
+// CoreDump is a core dump captured for a container, as listed by
+// GET "/containers/{name:.*}/cores"
+type CoreDump struct {
+	Name    string
+	Size    int64
+	Created time.Time
 }
 
 // CopyConfig contains request body of Remote API:
@@ -271,6 +663,44 @@ type Info struct {
 	// running containers are detected
 	LiveRestoreEnabled bool
 	Isolation          container.Isolation
+	Maintenance        MaintenanceInfo
+	// PublishedPortRange is the range of the host ports allocated to the
+	// ports published without a host port, such as 40000-45000.
+	PublishedPortRange string `json:",omitempty"`
+	// DefaultSeccompProfile and DefaultAppArmorProfile are the profiles
+	// the containers run with, unless they set their own.
+	DefaultSeccompProfile  string `json:",omitempty"`
+	DefaultAppArmorProfile string `json:",omitempty"`
+	Security               SecurityInfo
+	StorageDriver          StorageDriverInfo
+	// ContainerdVersion and RuncVersion are the versions of the components
+	// the containers run with, if they are known.
+	ContainerdVersion ComponentVersion
+	RuncVersion       ComponentVersion
+}
+
+// SecurityInfo contains the status of the security features of the daemon.
+type SecurityInfo struct {
+	Seccomp  bool
+	AppArmor bool
+	SELinux  bool
+	UserNS   bool
+}
+
+// StorageDriverInfo contains the storage driver of the daemon, and its
+// status as key/values.
+type StorageDriverInfo struct {
+	Name   string
+	Status map[string]string
+	// LeakedMounts is the number of mounts of container filesystems which
+	// are not expected to exist, usually left behind by a crash.
+	LeakedMounts int `json:",omitempty"`
+}
+
+// ComponentVersion contains the version of a component of the daemon.
+type ComponentVersion struct {
+	Version string `json:",omitempty"`
+	Commit  string `json:",omitempty"`
 }
 
 // PluginsInfo is a temp struct holding Plugins name
@@ -326,10 +756,30 @@ type ContainerState struct {
 	Dead       bool
 	Pid        int
 	ExitCode   int
+	Signal     string `json:",omitempty"`
 	Error      string
 	StartedAt  string
 	FinishedAt string
 	Health     *Health `json:",omitempty"`
+	// ResourceAccounting is the resource usage of the last run of the
+	// container, recorded when it exited.
+	ResourceAccounting *ContainerResourceAccounting `json:",omitempty"`
+	// LogMessagesDropped is the number of log messages dropped by the
+	// logging driver of the running container, for the drivers which drop
+	// messages rather than block the container.
+	LogMessagesDropped uint64 `json:",omitempty"`
+}
+
+// ContainerResourceAccounting contains the cumulative resource usage of the
+// processes of a container, as last sampled before the container exited.
+type ContainerResourceAccounting struct {
+	PeakMemoryBytes uint64
+	CPUTimeNanos    uint64
+	BlkioReadBytes  uint64
+	BlkioWriteBytes uint64
+	// SampledAt is the time of the sample, which may be well before the
+	// exit of the container if it was not sampled periodically.
+	SampledAt string
 }
 
 // ContainerNode stores information about the node that a container
@@ -364,6 +814,8 @@ type ContainerJSONBase struct {
 	MountLabel      string
 	ProcessLabel    string
 	AppArmorProfile string
+	SeccompProfile  string `json:",omitempty"`
+	Pod             string `json:",omitempty"`
 	ExecIDs         []string
 	HostConfig      *container.HostConfig
 	GraphDriver     GraphDriverData
@@ -430,6 +882,14 @@ type MountPoint struct {
 	Mode        string
 	RW          bool
 	Propagation mount.Propagation
+	// SELinuxLabel is the SELinux label applied to the source by the z or
+	// Z mode of the mount point.
+	SELinuxLabel string `json:",omitempty"`
+	// CopyResult is the result of the copy of the image data into a volume
+	// when the container was created: "copied", "disabled" by the nocopy
+	// option, or "skipped" because the volume was not empty or the image has
+	// no data at the destination.
+	CopyResult string `json:",omitempty"`
 }
 
 // Volume represents the configuration of a volume for the remote API
@@ -473,6 +933,50 @@ type NetworkResource struct {
 	Containers map[string]EndpointResource // Containers contains endpoints belonging to the network
 	Options    map[string]string           // Options holds the network specific options to use for when creating the network
 	Labels     map[string]string           // Labels holds metadata specific to the network being created
+	// ContainerCount is the number of endpoints of the network on the
+	// daemon, which is 0 for a network that is not used.
+	ContainerCount int
+	// LastUsed is the time at which a container was last connected to,
+	// or disconnected from, the network on the daemon.
+	LastUsed time.Time
+	// Diagnostics holds the state of the network on the host. It is only
+	// set by a verbose inspect.
+	Diagnostics *NetworkDiagnostics `json:",omitempty"`
+}
+
+// NetworkDiagnostics holds the state of a network on the host of the daemon,
+// to debug the connectivity of its containers.
+type NetworkDiagnostics struct {
+	Endpoints []EndpointDiagnostics
+	// IptablesRules holds the iptables rules of the filter and nat tables
+	// which apply to the bridge or the subnets of the network.
+	IptablesRules []string
+	// DNSRecords holds the records of the embedded DNS server for the
+	// containers connected to the network.
+	DNSRecords []DNSRecord
+}
+
+// EndpointDiagnostics holds the network interfaces of an endpoint.
+type EndpointDiagnostics struct {
+	EndpointID string
+	Name       string
+	Container  string
+	// InterfaceName and InterfaceIndex identify the interface of the
+	// endpoint in the network namespace of the container.
+	InterfaceName  string
+	InterfaceIndex int
+	// PeerName and PeerIndex identify the peer of the interface of the
+	// endpoint in the network namespace of the host, for the drivers
+	// which connect the containers with veth pairs.
+	PeerName  string
+	PeerIndex int
+}
+
+// DNSRecord is a record of the embedded DNS server of a network.
+type DNSRecord struct {
+	Name        string
+	IPv4Address string
+	IPv6Address string
 }
 
 // EndpointResource contains network resources allocated and used for a container in a network
@@ -531,6 +1035,19 @@ type Runtime struct {
 	Args []string `json:"runtimeArgs,omitempty"`
 }
 
+// SocketRole is a role of the clients of the unix sockets of the daemon,
+// which restricts the endpoints of the API they can access.
+type SocketRole struct {
+	// UIDs and GIDs are the user and group ids of the client processes
+	// the role applies to.
+	UIDs []uint32 `json:"uids,omitempty"`
+	GIDs []uint32 `json:"gids,omitempty"`
+	// Allow are the rules of the endpoints the role can access, such as
+	// "GET /containers" for the GET requests of /containers and of the
+	// endpoints under it.
+	Allow []string `json:"allow,omitempty"`
+}
+
 // DiskUsage contains response of Remote API:
 // GET "/system/df"
 type DiskUsage struct {
@@ -540,10 +1057,63 @@ type DiskUsage struct {
 	Volumes    []*Volume
 }
 
+// Maintenance modes of the daemon, the handling of the container creates and
+// starts while the daemon is in maintenance.
+const (
+	// MaintenanceReject rejects the container creates and starts.
+	MaintenanceReject = "reject"
+	// MaintenanceQueue queues the container starts until the maintenance
+	// ends. Containers can still be created.
+	MaintenanceQueue = "queue"
+)
+
+// MaintenanceConfig contains the configuration for Remote API:
+// POST "/system/maintenance"
+type MaintenanceConfig struct {
+	Enabled bool
+	// Mode is the maintenance mode, MaintenanceReject if empty.
+	Mode string `json:",omitempty"`
+	// StopContainers stops the running containers when the maintenance is
+	// enabled. They are started again when the maintenance ends.
+	StopContainers bool `json:",omitempty"`
+	// StopTimeout is the number of seconds to wait for the containers to
+	// stop before killing them. The stop timeout of each container is used
+	// if it is nil.
+	StopTimeout *int `json:",omitempty"`
+}
+
+// MaintenanceInfo contains the maintenance state of the daemon reported by
+// Remote API: GET "/info"
+type MaintenanceInfo struct {
+	Enabled bool
+	Mode    string `json:",omitempty"`
+	// QueuedContainers is the number of containers started when the
+	// maintenance ends.
+	QueuedContainers int `json:",omitempty"`
+}
+
+// LeakedMount is a mount of the filesystem of a container which is not
+// expected to exist, as the container is neither running nor mounted by the
+// daemon. It is returned by Remote API: GET "/system/mounts/leaked" and
+// POST "/system/mounts/leaked/unmount"
+type LeakedMount struct {
+	// Path is the mount point.
+	Path string
+	// MountID is the ID of the layer in the storage driver.
+	MountID string
+	// ContainerID is the ID of the container of the layer, empty if the
+	// container was removed.
+	ContainerID string `json:",omitempty"`
+}
+
 // ImagesPruneConfig contains the configuration for Remote API:
 // POST "/image/prune"
 type ImagesPruneConfig struct {
 	DanglingOnly bool
+	// Filters selects the images to prune. It is sent in the query string.
+	Filters filters.Args `json:"-"`
+	// DryRun reports what would be pruned without removing anything.
+	DryRun bool
 }
 
 // ContainersPruneConfig contains the configuration for Remote API:
@@ -551,11 +1121,89 @@ type ImagesPruneConfig struct {
 type ContainersPruneConfig struct {
 }
 
+// TrustKeyExportOptions contains the options for Remote API:
+// POST "/trust/key/export"
+type TrustKeyExportOptions struct {
+	// Passphrase is the passphrase the exported key is encrypted with.
+	Passphrase string
+}
+
+// TrustKeyImportOptions contains the options for Remote API:
+// POST "/trust/key/import"
+type TrustKeyImportOptions struct {
+	// Key is the PEM encoded private key.
+	Key []byte
+	// Passphrase is the passphrase the key is encrypted with, if any.
+	Passphrase string
+}
+
+// LogDriver contains the response for Remote API:
+// GET "/info/log-drivers"
+type LogDriver struct {
+	Name string
+	// Options holds the names of the log options supported by the driver.
+	Options []string
+}
+
+// ContainerCloneConfig contains the configuration for Remote API:
+// POST "/containers/{name:.*}/clone"
+type ContainerCloneConfig struct {
+	// Image replaces the image of the source container. A value of the
+	// form ":tag" only replaces the tag of the source image reference.
+	Image string
+	// Env holds variables that are added to, or replace, the environment
+	// of the source container.
+	Env []string
+	// CopyVolumes copies the content of the anonymous volumes of the
+	// source container to the new container.
+	CopyVolumes bool
+	// PortBindings replaces the port bindings of the source container.
+	// Otherwise, the ports the source container publishes on fixed host
+	// ports, which it holds while it runs, are published on ephemeral host
+	// ports.
+	PortBindings nat.PortMap `json:",omitempty"`
+}
+
+// PortsCheckRequest contains the configuration for Remote API:
+// POST "/containers/ports/check"
+type PortsCheckRequest struct {
+	PortBindings nat.PortMap
+}
+
+// PortsCheckResponse contains the response for Remote API:
+// POST "/containers/ports/check"
+type PortsCheckResponse struct {
+	// Conflicts holds the port bindings which cannot be allocated. It is
+	// empty if all the port bindings can be allocated.
+	Conflicts []PortConflict
+}
+
+// PortConflict is a port binding which cannot be allocated.
+type PortConflict struct {
+	// Port is the port of the container, such as 80/tcp.
+	Port     string
+	HostIP   string
+	HostPort string
+	// ContainerID and ContainerName identify the running container which
+	// holds the host port. They are empty if the host port is held by
+	// another process of the host.
+	ContainerID   string
+	ContainerName string
+	Message       string
+}
+
 // VolumesPruneConfig contains the configuration for Remote API:
 // POST "/images/prune"
 type VolumesPruneConfig struct {
 }
 
+// NetworksPruneConfig contains the configuration for Remote API:
+// POST "/networks/prune"
+type NetworksPruneConfig struct {
+	// Filters selects the networks to prune. It is sent in the query string.
+	Filters filters.Args `json:"-"`
+}
+
 // ContainersPruneReport contains the response for Remote API:
 // POST "/containers/prune"
 type ContainersPruneReport struct {
@@ -570,6 +1218,49 @@ type VolumesPruneReport struct {
 	SpaceReclaimed uint64
 }
 
+// NetworksPruneReport contains the response for Remote API:
+// POST "/networks/prune"
+type NetworksPruneReport struct {
+	NetworksDeleted []string
+}
+
+// AllocatedPort is a host port allocated by the daemon for a published port
+// of a container.
+type AllocatedPort struct {
+	Proto    string
+	HostIP   string
+	HostPort uint16
+	// ContainerID and ContainerName identify the container the port is
+	// published by, and Network and EndpointID its endpoint.
+	ContainerID   string
+	ContainerName string
+	Network       string
+	EndpointID    string
+	ContainerIP   string
+	ContainerPort uint16
+	// UserlandProxy is set if a docker-proxy process forwards the port.
+	UserlandProxy bool
+}
+
+// NetworkRuleRepair is an iptables rule of a published port of a container
+// which was missing, and was re-created. Container is empty for the DOCKER
+// chains of the network and the rules jumping to them.
+type NetworkRuleRepair struct {
+	Container string
+	Network   string
+	Table     string
+	// Rule is the rule, as listed by iptables -S.
+	Rule string
+	// Error is set if the rule could not be re-created.
+	Error string `json:",omitempty"`
+}
+
+// NetworksReconcileReport contains the response for Remote API:
+// POST "/networks/reconcile"
+type NetworksReconcileReport struct {
+	Repairs []NetworkRuleRepair
+}
+
 // ImagesPruneReport contains the response for Remote API:
 // POST "/image/prune"
 type ImagesPruneReport struct {
@@ -50,6 +50,13 @@ type ModifiableContext interface {
 	Remove(path string) error
 }
 
+// GitContext is a Context cloned from a git repository.
+type GitContext interface {
+	ModifiableContext
+	// GitCommit returns the SHA of the commit checked out in the context.
+	GitCommit() string
+}
+
 // FileInfo extends os.FileInfo to allow retrieving an absolute path to the file.
 // TODO: remove this interface once pkg/archive exposes a walk function that Context can use.
 type FileInfo interface {
@@ -111,8 +118,19 @@ type Backend interface {
 	GetImageOnBuild(name string) (Image, error)
 	// TagImage tags an image with newTag
 	TagImageWithReference(image.ID, reference.Named) error
-	// PullOnBuild tells Docker to pull image referenced by `name`.
-	PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (Image, error)
+	// PullOnBuild tells Docker to pull image referenced by `name`. The image
+	// is held for the holder, which releases it with UnholdImage.
+	PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, holder string, output io.Writer) (Image, error)
+	// RepoDigestOnBuild returns the reference by digest, in the repository
+	// of `name`, of the image imageID, or nil if it has none.
+	RepoDigestOnBuild(name, imageID string) reference.Canonical
+	// BuildFromPolicy returns the resolution policy of the FROM references
+	// of the builds set on the daemon.
+	BuildFromPolicy() string
+	// HoldImage prevents the deletion of an image until the holder releases it.
+	HoldImage(imageID, holder string) error
+	// UnholdImage releases a hold of the holder on an image.
+	UnholdImage(imageID, holder string)
 	// ContainerAttachRaw attaches to container.
 	ContainerAttachRaw(cID string, stdin io.ReadCloser, stdout, stderr io.Writer, stream bool) error
 	// ContainerCreate creates a new Docker container and returns potential warnings
@@ -121,6 +139,9 @@ type Backend interface {
 	ContainerRm(name string, config *types.ContainerRmConfig) error
 	// Commit creates a new Docker image from an existing Docker container.
 	Commit(string, *backend.ContainerCommitConfig) (string, error)
+	// LayerDepthWarning returns a warning if the image has more layers than
+	// the daemon warns about, or an empty string.
+	LayerDepthWarning(imageID string) string
 	// ContainerKill stops the container execution abruptly.
 	ContainerKill(containerID string, sig uint64) error
 	// ContainerStart starts a new container
@@ -129,6 +150,9 @@ type Backend interface {
 	ContainerWait(containerID string, timeout time.Duration) (int, error)
 	// ContainerUpdateCmdOnBuild updates container.Path and container.Args
 	ContainerUpdateCmdOnBuild(containerID string, cmd []string) error
+	// RemoveMountPointsOnBuild removes the files and the directories
+	// created in the container as the mount points of the paths
+	RemoveMountPointsOnBuild(containerID string, paths []string) error
 
 	// CheckpointCreate checkpoints a running container
 	CheckpointCreate(container string, config types.CheckpointCreateOptions) error
@@ -151,6 +175,7 @@ type Backend interface {
 type Image interface {
 	ImageID() string
 	RunConfig() *container.Config
+	TopLayer() string
 }
 
 // ImageCacheBuilder represents a generator for stateful image cache.
@@ -121,18 +121,20 @@ func GetContextFromReader(r io.ReadCloser, dockerfileName string) (out io.ReadCl
 
 // GetContextFromGitURL uses a Git URL as context for a `docker build`. The
 // git repo is cloned into a temporary directory used as the context directory.
+// The clone is authenticated with the credentials of opts.
 // Returns the absolute path to the temporary context directory, the relative
-// path of the dockerfile in that context directory, and a non-nil error on
-// success.
-func GetContextFromGitURL(gitURL, dockerfileName string) (absContextDir, relDockerfile string, err error) {
+// path of the dockerfile in that context directory, the SHA of the commit
+// checked out, and a non-nil error on success.
+func GetContextFromGitURL(gitURL, dockerfileName string, opts gitutils.CloneOptions) (absContextDir, relDockerfile, commit string, err error) {
 	if _, err := exec.LookPath("git"); err != nil {
-		return "", "", fmt.Errorf("unable to find 'git': %v", err)
+		return "", "", "", fmt.Errorf("unable to find 'git': %v", err)
 	}
-	if absContextDir, err = gitutils.Clone(gitURL); err != nil {
-		return "", "", fmt.Errorf("unable to 'git clone' to temporary context directory: %v", err)
+	if absContextDir, commit, err = gitutils.CloneWithOptions(gitURL, opts); err != nil {
+		return "", "", "", fmt.Errorf("unable to 'git clone' to temporary context directory: %v", err)
 	}
 
-	return getDockerfileRelPath(absContextDir, dockerfileName)
+	absContextDir, relDockerfile, err = getDockerfileRelPath(absContextDir, dockerfileName)
+	return absContextDir, relDockerfile, commit, err
 }
 
 // GetContextFromURL uses a remote URL as context for a `docker build`. The
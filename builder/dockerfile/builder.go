@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -15,7 +16,9 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
+	"github.com/docker/docker/pkg/gitutils"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/reference"
 	"golang.org/x/net/context"
@@ -76,7 +79,19 @@ type Builder struct {
 	// TODO: remove once docker.Commit can receive a tag
 	id string
 
+	// stepFunc reports the structured progress of the steps, if set.
+	stepFunc   func(types.BuildProgress)
+	stepCached bool // the current step used the build cache
+
+	// gitCommit is the commit checked out in the context, if it is a git
+	// repository cloned by the daemon.
+	gitCommit string
+
 	imageCache builder.ImageCache
+
+	// heldImages are the images the build holds, so that they are not
+	// deleted before it ends.
+	heldImages map[string]struct{}
 }
 
 // BuildManager implements builder.Backend and is shared across all Builder objects.
@@ -91,7 +106,7 @@ func NewBuildManager(b builder.Backend) (bm *BuildManager) {
 
 // BuildFromContext builds a new image from a given context.
 func (bm *BuildManager) BuildFromContext(ctx context.Context, src io.ReadCloser, remote string, buildOptions *types.ImageBuildOptions, pg backend.ProgressWriter) (string, error) {
-	buildContext, dockerfileName, err := builder.DetectContextFromRemoteURL(src, remote, pg.ProgressReaderFunc)
+	buildContext, dockerfileName, err := builder.DetectContextFromRemoteURL(src, remote, gitCloneOptions(buildOptions.GitAuth), pg.ProgressReaderFunc)
 	if err != nil {
 		return "", err
 	}
@@ -107,6 +122,10 @@ func (bm *BuildManager) BuildFromContext(ctx context.Context, src io.ReadCloser,
 	if err != nil {
 		return "", err
 	}
+	b.stepFunc = pg.StepFunc
+	if gitContext, ok := buildContext.(builder.GitContext); ok {
+		b.gitCommit = gitContext.GitCommit()
+	}
 	return b.build(pg.StdoutFormatter, pg.StderrFormatter, pg.Output)
 }
 
@@ -213,6 +232,7 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 	b.Stdout = stdout
 	b.Stderr = stderr
 	b.Output = out
+	defer b.unholdImages()
 
 	// If Dockerfile was not parsed yet, extract it from the Context
 	if b.dockerfile == nil {
@@ -226,10 +246,20 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		return "", err
 	}
 
-	if len(b.options.Labels) > 0 {
+	labels, err := b.buildLabels()
+	if err != nil {
+		return "", err
+	}
+	if len(labels) > 0 {
+		// The labels are sorted so that the instruction matches the cache.
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
 		line := "LABEL "
-		for k, v := range b.options.Labels {
-			line += fmt.Sprintf("%q=%q ", k, v)
+		for _, k := range keys {
+			line += fmt.Sprintf("%q=%q ", k, labels[k])
 		}
 		_, node, err := parser.ParseLine(line, &b.directive)
 		if err != nil {
@@ -238,6 +268,10 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		b.dockerfile.Children = append(b.dockerfile.Children, node)
 	}
 
+	if b.options.Validate {
+		return "", b.validate()
+	}
+
 	var shortImgID string
 	total := len(b.dockerfile.Children)
 	for _, n := range b.dockerfile.Children {
@@ -256,7 +290,13 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 			// Not cancelled yet, keep going...
 		}
 
+		step := types.BuildProgress{Step: i + 1, Total: total, Instruction: n.Original}
+		b.reportStep(step, types.BuildStepStarted)
+		b.stepCached = false
+
 		if err := b.dispatch(i, total, n); err != nil {
+			step.Error = err.Error()
+			b.reportStep(step, types.BuildStepFailed)
 			if b.options.ForceRemove {
 				b.clearTmp()
 			}
@@ -265,6 +305,10 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 
 		shortImgID = stringid.TruncateID(b.image)
 		fmt.Fprintf(b.Stdout, " ---> %s\n", shortImgID)
+		step.Cached = b.stepCached
+		step.ImageID = b.image
+		step.LayerID = b.topLayer()
+		b.reportStep(step, types.BuildStepCompleted)
 		if b.options.Remove {
 			b.clearTmp()
 		}
@@ -293,10 +337,100 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		}
 	}
 
+	if warning := b.docker.LayerDepthWarning(b.image); warning != "" {
+		fmt.Fprintf(b.Stdout, "[Warning] %s\n", warning)
+	}
 	fmt.Fprintf(b.Stdout, "Successfully built %s\n", shortImgID)
 	return b.image, nil
 }
 
+// Build metadata recorded under the reserved annotation namespace.
+const (
+	buildVersionAnnotation = "version"
+	buildHostAnnotation    = "host"
+)
+
+// buildLabels returns the labels set on the image after all the Dockerfile
+// instructions: the labels of the build options, and the annotations along
+// with the build metadata under the reserved namespace. The commit of a git
+// context is recorded as an annotation too, without the rest of the build
+// metadata unless other annotations are set. It is the commit of the clone of
+// the daemon, or the one passed by the client for the contexts it cloned.
+func (b *Builder) buildLabels() (map[string]string, error) {
+	labels := make(map[string]string, len(b.options.Labels)+len(b.options.Annotations))
+	for k, v := range b.options.Labels {
+		labels[k] = v
+	}
+	metadata := map[string]string{}
+	if b.gitCommit != "" {
+		metadata[types.BuildGitCommitAnnotation] = b.gitCommit
+	}
+
+	annotated := false
+	for k, v := range b.options.Annotations {
+		switch k {
+		case types.BuildGitCommitAnnotation:
+			if b.gitCommit == "" {
+				metadata[k] = v
+				continue
+			}
+			return nil, fmt.Errorf("annotation %s is reserved for the build metadata", k)
+		case buildVersionAnnotation, buildHostAnnotation, types.BuildBaseImagesAnnotation:
+			return nil, fmt.Errorf("annotation %s is reserved for the build metadata", k)
+		}
+		labels[types.BuildAnnotationPrefix+k] = v
+		annotated = true
+	}
+	if annotated {
+		metadata[buildVersionAnnotation] = dockerversion.Version
+		if hostname, err := os.Hostname(); err == nil {
+			metadata[buildHostAnnotation] = hostname
+		}
+	}
+	for k, v := range metadata {
+		labels[types.BuildAnnotationPrefix+k] = v
+	}
+	return labels, nil
+}
+
+// gitCloneOptions returns the options of the clone of a git context
+// authenticated with the credentials of auth.
+func gitCloneOptions(auth *types.GitAuthConfig) gitutils.CloneOptions {
+	if auth == nil {
+		return gitutils.CloneOptions{}
+	}
+	return gitutils.CloneOptions{
+		Username:      auth.Username,
+		Token:         auth.Token,
+		SSHKey:        []byte(auth.SSHKey),
+		SSHKnownHosts: []byte(auth.SSHKnownHosts),
+	}
+}
+
+// reportStep reports the progress of a step with the given status, if the
+// structured build progress was requested.
+func (b *Builder) reportStep(step types.BuildProgress, status string) {
+	if b.stepFunc == nil {
+		return
+	}
+	step.Status = status
+	b.stepFunc(step)
+}
+
+// topLayer returns the top layer of the current image, if the structured
+// build progress was requested.
+func (b *Builder) topLayer() string {
+	if b.stepFunc == nil || b.image == "" {
+		return ""
+	}
+	img, err := b.docker.GetImageOnBuild(b.image)
+	if err != nil {
+		logrus.Debugf("[BUILDER] failed to get the layers of image %s: %v", b.image, err)
+		return ""
+	}
+	return img.TopLayer()
+}
+
 // Cancel cancels an ongoing Dockerfile build.
 func (b *Builder) Cancel() {
 	b.cancel()
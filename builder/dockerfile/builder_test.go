@@ -0,0 +1,70 @@
+package dockerfile
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/dockerversion"
+)
+
+func TestBuildLabels(t *testing.T) {
+	b := &Builder{options: &types.ImageBuildOptions{
+		Labels: map[string]string{"foo": "bar"},
+	}}
+	labels, err := b.buildLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 1 || labels["foo"] != "bar" {
+		t.Fatalf("expected only the build labels without annotations, got %v", labels)
+	}
+
+	b.options.Annotations = map[string]string{"vcs-ref": "abc123"}
+	labels, err = b.buildLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels["foo"] != "bar" || labels["com.docker.build.vcs-ref"] != "abc123" {
+		t.Fatalf("expected the labels and the annotations, got %v", labels)
+	}
+	if labels["com.docker.build.version"] != dockerversion.Version {
+		t.Fatalf("expected the builder version to be recorded, got %v", labels)
+	}
+	if _, ok := labels["com.docker.build.host"]; !ok {
+		t.Fatalf("expected the build host to be recorded, got %v", labels)
+	}
+
+	b.options.Annotations = map[string]string{"host": "example.com"}
+	if _, err := b.buildLabels(); err == nil {
+		t.Fatal("expected an error for a reserved annotation")
+	}
+
+	b.options.Annotations = map[string]string{"base-images": "busybox"}
+	if _, err := b.buildLabels(); err == nil {
+		t.Fatal("expected an error for the annotation of the base images")
+	}
+
+	b.options.Annotations = map[string]string{"git-commit": "abc123"}
+	labels, err = b.buildLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 || labels["com.docker.build.git-commit"] != "abc123" {
+		t.Fatalf("expected only the commit of the git context cloned by the client to be recorded, got %v", labels)
+	}
+
+	b.options.Annotations = nil
+	b.gitCommit = "8f8d9e3da3d0ba6c0a0a2ca4f5d5e9b6b4e3a1c2"
+	labels, err = b.buildLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 2 || labels["com.docker.build.git-commit"] != b.gitCommit {
+		t.Fatalf("expected only the commit of the git context to be recorded, got %v", labels)
+	}
+
+	b.options.Annotations = map[string]string{"git-commit": "abc123"}
+	if _, err := b.buildLabels(); err == nil {
+		t.Fatal("expected an error for the annotation of the commit of the git context")
+	}
+}
@@ -0,0 +1,110 @@
+package dockerfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/mount"
+)
+
+const (
+	// cacheVolumePrefix is the prefix of the names of the volumes holding
+	// the cache mounts of the builds.
+	cacheVolumePrefix = "buildcache_"
+	// cacheVolumeLabel is the label of the volumes holding the cache mounts,
+	// set to the id of the cache.
+	cacheVolumeLabel = "com.docker.build.cache"
+)
+
+// cacheMount is a persistent directory mounted in the container of a RUN
+// instruction with --mount=type=cache. The directory is shared by all the
+// builds using the same cache id, until it is removed.
+type cacheMount struct {
+	id       string
+	target   string
+	readOnly bool
+}
+
+// parseCacheMount parses the value of a --mount flag of the form
+// type=cache,target=PATH[,id=ID][,readonly=BOOL].
+func parseCacheMount(value string) (*cacheMount, error) {
+	m := &cacheMount{}
+	for _, field := range strings.Split(value, ",") {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+		if len(parts) != 2 {
+			if key == "readonly" || key == "ro" {
+				m.readOnly = true
+				continue
+			}
+			return nil, fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		val := parts[1]
+
+		switch key {
+		case "type":
+			if val != "cache" {
+				return nil, fmt.Errorf("unsupported mount type %q", val)
+			}
+		case "id":
+			m.id = val
+		case "target", "dst", "destination":
+			m.target = val
+		case "readonly", "ro":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, val)
+			}
+			m.readOnly = b
+		default:
+			return nil, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+
+	if m.target == "" {
+		return nil, fmt.Errorf("invalid mount %q: target is required", value)
+	}
+	if !path.IsAbs(m.target) {
+		return nil, fmt.Errorf("invalid mount %q: target must be an absolute path", value)
+	}
+	if m.id == "" {
+		m.id = m.target
+	}
+	return m, nil
+}
+
+// cacheArg returns the mount as it is added to the cache key of the RUN
+// instruction.
+func (m *cacheMount) cacheArg() string {
+	arg := fmt.Sprintf("--mount=type=cache,id=%s,target=%s", m.id, m.target)
+	if m.readOnly {
+		arg += ",readonly"
+	}
+	return arg
+}
+
+// volumeName returns the name of the volume holding the cache. The id is
+// hashed as it may contain characters that are not valid in a volume name.
+func (m *cacheMount) volumeName() string {
+	sum := sha256.Sum256([]byte(m.id))
+	return cacheVolumePrefix + hex.EncodeToString(sum[:])
+}
+
+// mount returns the mount of the volume holding the cache. The volume is
+// created on first use, and is not populated with the content of the image.
+func (m *cacheMount) mount() mount.Mount {
+	return mount.Mount{
+		Type:     mount.TypeVolume,
+		Source:   m.volumeName(),
+		Target:   m.target,
+		ReadOnly: m.readOnly,
+		VolumeOptions: &mount.VolumeOptions{
+			NoCopy: true,
+			Labels: map[string]string{cacheVolumeLabel: m.id},
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package dockerfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+func TestParseCacheMount(t *testing.T) {
+	valid := map[string]cacheMount{
+		"type=cache,target=/root/.cache/pip":       {id: "/root/.cache/pip", target: "/root/.cache/pip"},
+		"type=cache,id=pip,dst=/root/.cache/pip":   {id: "pip", target: "/root/.cache/pip"},
+		"type=cache,target=/var/cache/apt,ro":      {id: "/var/cache/apt", target: "/var/cache/apt", readOnly: true},
+		"type=cache,target=/go/pkg,readonly=false": {id: "/go/pkg", target: "/go/pkg"},
+	}
+	for value, expected := range valid {
+		m, err := parseCacheMount(value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", value, err)
+		}
+		if *m != expected {
+			t.Fatalf("%s: expected %+v, got %+v", value, expected, *m)
+		}
+	}
+
+	invalid := map[string]string{
+		"type=cache,id=pip":                 "target is required",
+		"type=cache,target=cache":           "target must be an absolute path",
+		"type=cache,target=/cache,ro=maybe": "invalid value for ro",
+		"type=cache,target=/cache,size=1g":  "unexpected key",
+		"type=cache,target=/cache,id":       "must be a key=value pair",
+	}
+	for value, expected := range invalid {
+		if _, err := parseCacheMount(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected error containing %q, got %v", value, expected, err)
+		}
+	}
+}
+
+func TestCacheMountVolume(t *testing.T) {
+	b := &Builder{options: &types.ImageBuildOptions{}}
+	rm, err := b.parseRunMounts([]string{
+		"type=cache,id=pip,target=/root/.cache/pip",
+		"type=cache,id=pip,target=/tmp/pip,ro",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mounts, cleanup, err := b.setupRunMounts(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	if len(mounts) != 2 {
+		t.Fatalf("expected 2 mounts, got %v", mounts)
+	}
+	m := mounts[0]
+	if m.Type != mount.TypeVolume || m.Target != "/root/.cache/pip" || m.ReadOnly {
+		t.Fatalf("unexpected mount: %+v", m)
+	}
+	if !strings.HasPrefix(m.Source, cacheVolumePrefix) || mounts[1].Source != m.Source {
+		t.Fatalf("expected the mounts of the same cache to share a volume, got %s and %s", m.Source, mounts[1].Source)
+	}
+	if m.VolumeOptions == nil || !m.VolumeOptions.NoCopy || m.VolumeOptions.Labels[cacheVolumeLabel] != "pip" {
+		t.Fatalf("unexpected volume options: %+v", m.VolumeOptions)
+	}
+	if !mounts[1].ReadOnly {
+		t.Fatalf("expected the second mount to be read-only")
+	}
+
+	args := b.runMountsCacheArgs(rm)
+	if len(args) != 2 || args[1] != "--mount=type=cache,id=pip,target=/tmp/pip,readonly" {
+		t.Fatalf("unexpected cache arguments: %v", args)
+	}
+}
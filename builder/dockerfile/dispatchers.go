@@ -22,6 +22,7 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/reference"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/go-connections/nat"
 )
@@ -209,20 +210,27 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		b.image = ""
 		b.noBaseImage = true
 	} else {
-		// TODO: don't use `name`, instead resolve it to a digest
+		if err := checkFromPolicy(b.fromPolicy(), name); err != nil {
+			return err
+		}
 		if !b.options.PullParent {
 			image, err = b.docker.GetImageOnBuild(name)
 			// TODO: shouldn't we error out if error is different from "not found" ?
 		}
 		if image == nil {
-			image, err = b.docker.PullOnBuild(b.clientCtx, name, b.options.AuthConfigs, b.Output)
+			image, err = b.docker.PullOnBuild(b.clientCtx, name, b.options.AuthConfigs, b.holder(), b.Output)
 			if err != nil {
 				return err
 			}
+			b.addHeldImage(image.ImageID())
 		}
 	}
 
-	return b.processImageFrom(image)
+	var ref reference.Canonical
+	if image != nil {
+		ref = b.docker.RepoDigestOnBuild(name, image.ImageID())
+	}
+	return b.processImageFrom(image, ref)
 }
 
 // ONBUILD RUN echo yo
@@ -296,10 +304,17 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 		return fmt.Errorf("Please provide a source image with `from` prior to run")
 	}
 
+	flMounts := b.flags.AddStrings("mount")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
+	runMounts, err := b.parseRunMounts(flMounts.StringValues)
+	if err != nil {
+		return err
+	}
+
 	args = handleJSONArgs(args, attributes)
 
 	if !attributes["json"] {
@@ -364,6 +379,12 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 		tmpEnv := append([]string{fmt.Sprintf("|%d", len(cmdBuildEnv))}, cmdBuildEnv...)
 		saveCmd = strslice.StrSlice(append(tmpEnv, saveCmd...))
 	}
+	// Likewise, the mounts are added to the command with the special
+	// argument "|m#", without the content of the secrets.
+	if cacheArgs := b.runMountsCacheArgs(runMounts); len(cacheArgs) > 0 {
+		tmpMounts := append([]string{fmt.Sprintf("|m%d", len(cacheArgs))}, cacheArgs...)
+		saveCmd = strslice.StrSlice(append(tmpMounts, saveCmd...))
+	}
 
 	b.runConfig.Cmd = saveCmd
 	hit, err := b.probeCache()
@@ -383,7 +404,13 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 
 	logrus.Debugf("[BUILDER] Command to be executed: %v", b.runConfig.Cmd)
 
-	cID, err := b.create()
+	mounts, cleanup, err := b.setupRunMounts(runMounts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cID, err := b.create(mounts)
 	if err != nil {
 		return err
 	}
@@ -391,6 +418,9 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 	if err := b.run(cID); err != nil {
 		return err
 	}
+	if err := b.removeSecretMountPoints(cID, runMounts.secrets); err != nil {
+		return err
+	}
 
 	// revert to original config environment and set the command string to
 	// have the build-time env vars in it (if any) so that future cache look-ups
@@ -592,9 +622,27 @@ func expose(b *Builder, args []string, attributes map[string]bool, original stri
 		b.runConfig.ExposedPorts = make(nat.PortSet)
 	}
 
-	ports, _, err := nat.ParsePortSpecs(portsTab)
-	if err != nil {
-		return err
+	ports := make(nat.PortSet)
+	for _, spec := range portsTab {
+		if strings.Contains(spec, ":") {
+			// Port mappings are accepted for compatibility, only the
+			// container port is exposed.
+			exposed, _, err := nat.ParsePortSpecs([]string{spec})
+			if err != nil {
+				return err
+			}
+			for port := range exposed {
+				ports[port] = struct{}{}
+			}
+			continue
+		}
+		exposed, err := runconfigopts.ParseExposedPorts(spec)
+		if err != nil {
+			return err
+		}
+		for _, port := range exposed {
+			ports[port] = struct{}{}
+		}
 	}
 
 	// instead of using ports directly, we build a list of ports and sort it so
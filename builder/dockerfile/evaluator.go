@@ -194,6 +194,9 @@ func (b *Builder) dispatch(stepN int, stepTotal int, ast *parser.Node) error {
 	// XXX yes, we skip any cmds that are not valid; the parser should have
 	// picked these out already.
 	if f, ok := evaluateTable[cmd]; ok {
+		if vf, ok := validateTable[cmd]; ok && b.options.Validate {
+			f = vf
+		}
 		b.flags = NewBFlags()
 		b.flags.Args = flags
 		return f(b, strList, attrs, original)
@@ -0,0 +1,63 @@
+package dockerfile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/reference"
+)
+
+// fromPolicy returns the resolution policy of the FROM references of the
+// build: the policy of the build options, or the policy of the daemon if it
+// is stricter.
+func (b *Builder) fromPolicy() string {
+	if b.options.FromPolicy == types.BuildFromPolicyPinned || b.docker.BuildFromPolicy() == types.BuildFromPolicyPinned {
+		return types.BuildFromPolicyPinned
+	}
+	return types.BuildFromPolicyAny
+}
+
+// checkFromPolicy returns an error if the policy does not accept name as the
+// reference of a base image. The pinned policy only accepts the references
+// by digest and the image IDs, which cannot be moved to another image; the
+// builds with content trust enabled resolve their FROM references to
+// references by digest.
+func checkFromPolicy(policy, name string) error {
+	if policy != types.BuildFromPolicyPinned {
+		return nil
+	}
+	id, ref, err := reference.ParseIDOrReference(name)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		return nil
+	}
+	if _, isCanonical := ref.(reference.Canonical); isCanonical {
+		return nil
+	}
+	return fmt.Errorf("%s is not pinned by digest, as required by the %s FROM policy: reference the image by digest, such as %s@sha256:<digest>, or enable content trust", name, policy, ref.Name())
+}
+
+// recordBaseImage records base, the reference by digest or the ID of the
+// base image, in the base images annotation of the configuration, before the
+// base images of the base image.
+func (b *Builder) recordBaseImage(base string) {
+	key := types.BuildAnnotationPrefix + types.BuildBaseImagesAnnotation
+	labels := make(map[string]string, len(b.runConfig.Labels)+1)
+	for k, v := range b.runConfig.Labels {
+		labels[k] = v
+	}
+	labels[key] = baseImagesAnnotation(base, labels[key])
+	b.runConfig.Labels = labels
+}
+
+// baseImagesAnnotation returns the base images annotation listing ref before
+// the base images of the annotation of the base image, if any.
+func baseImagesAnnotation(ref, inherited string) string {
+	if inherited == "" {
+		return ref
+	}
+	return strings.Join([]string{ref, inherited}, ",")
+}
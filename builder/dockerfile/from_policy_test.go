@@ -0,0 +1,66 @@
+package dockerfile
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/reference"
+)
+
+const testFromDigest = "sha256:ecda3bd9a8a1a5c7bea8eee0b5f2d9ab1b8b0ea6ae9e1b4e7e9a2bbaf1a2c13d"
+
+func TestCheckFromPolicy(t *testing.T) {
+	pinned := []string{
+		"busybox@" + testFromDigest,
+		"example.com:5000/app@" + testFromDigest,
+		testFromDigest,
+		"ecda3bd9a8a1a5c7bea8eee0b5f2d9ab1b8b0ea6ae9e1b4e7e9a2bbaf1a2c13d",
+	}
+	for _, name := range pinned {
+		if err := checkFromPolicy(types.BuildFromPolicyPinned, name); err != nil {
+			t.Fatalf("expected %s to be accepted by the pinned policy, got %v", name, err)
+		}
+	}
+
+	mutable := []string{"busybox", "busybox:latest", "example.com:5000/app:v1"}
+	for _, name := range mutable {
+		if err := checkFromPolicy(types.BuildFromPolicyPinned, name); err == nil {
+			t.Fatalf("expected %s to be rejected by the pinned policy", name)
+		}
+		if err := checkFromPolicy(types.BuildFromPolicyAny, name); err != nil {
+			t.Fatalf("expected %s to be accepted by the any policy, got %v", name, err)
+		}
+	}
+}
+
+func TestRecordBaseImage(t *testing.T) {
+	baseLabels := map[string]string{"foo": "bar"}
+	b := &Builder{runConfig: &container.Config{Labels: baseLabels}}
+
+	named, err := reference.ParseNamed("busybox@" + testFromDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := named.(reference.Canonical)
+	b.recordBaseImage(ref.String())
+	key := types.BuildAnnotationPrefix + types.BuildBaseImagesAnnotation
+	if b.runConfig.Labels[key] != ref.String() || b.runConfig.Labels["foo"] != "bar" {
+		t.Fatalf("expected the base image to be recorded along with the labels, got %v", b.runConfig.Labels)
+	}
+	if _, ok := baseLabels[key]; ok {
+		t.Fatal("expected the labels of the base image not to be modified")
+	}
+
+	// The base images of the base image are recorded after it.
+	b.recordBaseImage(ref.String())
+	if expected := ref.String() + "," + ref.String(); b.runConfig.Labels[key] != expected {
+		t.Fatalf("expected the base images to be %s, got %s", expected, b.runConfig.Labels[key])
+	}
+
+	// A base image without a reference by digest is recorded by its ID.
+	b.recordBaseImage(testFromDigest)
+	if expected := testFromDigest + "," + ref.String() + "," + ref.String(); b.runConfig.Labels[key] != expected {
+		t.Fatalf("expected the base images to be %s, got %s", expected, b.runConfig.Labels[key])
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/builder/dockerfile/parser"
@@ -36,6 +37,7 @@ import (
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/tarsum"
 	"github.com/docker/docker/pkg/urlutil"
+	"github.com/docker/docker/reference"
 	"github.com/docker/docker/runconfig/opts"
 )
 
@@ -59,7 +61,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 		} else if hit {
 			return nil
 		}
-		id, err = b.create()
+		id, err = b.create(nil)
 		if err != nil {
 			return err
 		}
@@ -75,6 +77,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 			Pause:  true,
 			Config: &autoConfig,
 		},
+		Holder: b.holder(),
 	}
 
 	// Commit the container
@@ -82,11 +85,51 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 	if err != nil {
 		return err
 	}
+	b.addHeldImage(imageID)
 
 	b.image = imageID
 	return nil
 }
 
+// holdImage prevents the deletion of an image used by the build until the
+// build ends.
+func (b *Builder) holdImage(imageID string) error {
+	if _, held := b.heldImages[imageID]; held {
+		return nil
+	}
+	if err := b.docker.HoldImage(imageID, b.holder()); err != nil {
+		return err
+	}
+	b.addHeldImage(imageID)
+	return nil
+}
+
+// addHeldImage records an image held for the build, by holdImage or as it
+// was created. The build keeps a single hold on an image it already held.
+func (b *Builder) addHeldImage(imageID string) {
+	if _, held := b.heldImages[imageID]; held {
+		b.docker.UnholdImage(imageID, b.holder())
+		return
+	}
+	if b.heldImages == nil {
+		b.heldImages = make(map[string]struct{})
+	}
+	b.heldImages[imageID] = struct{}{}
+}
+
+// unholdImages releases the images held by the build.
+func (b *Builder) unholdImages() {
+	for imageID := range b.heldImages {
+		b.docker.UnholdImage(imageID, b.holder())
+	}
+	b.heldImages = nil
+}
+
+// holder identifies the build as the holder of its images.
+func (b *Builder) holder() string {
+	return "build " + stringid.TruncateID(b.id)
+}
+
 type copyInfo struct {
 	builder.FileInfo
 	decompress bool
@@ -375,13 +418,26 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	return copyInfos, nil
 }
 
-func (b *Builder) processImageFrom(img builder.Image) error {
+// processImageFrom starts the build from the base image img, or from
+// scratch if img is nil. The reference by digest of the base image, or its
+// ID if it has none, is recorded in the configuration.
+func (b *Builder) processImageFrom(img builder.Image, ref reference.Canonical) error {
 	if img != nil {
+		if err := b.holdImage(img.ImageID()); err != nil {
+			return err
+		}
 		b.image = img.ImageID()
 
 		if img.RunConfig() != nil {
 			b.runConfig = img.RunConfig()
 		}
+		// The base images annotation inherited from the base image must
+		// not be left without the base image itself.
+		base := img.ImageID()
+		if ref != nil {
+			base = ref.String()
+		}
+		b.recordBaseImage(base)
 	}
 
 	// Check to see if we have a default PATH, note that windows won't
@@ -457,14 +513,19 @@ func (b *Builder) probeCache() (bool, error) {
 		return false, nil
 	}
 
+	if err := b.holdImage(string(cache)); err != nil {
+		return false, err
+	}
+
 	fmt.Fprintf(b.Stdout, " ---> Using cache\n")
+	b.stepCached = true
 	logrus.Debugf("[BUILDER] Use cached version: %s", b.runConfig.Cmd)
 	b.image = string(cache)
 
 	return true, nil
 }
 
-func (b *Builder) create() (string, error) {
+func (b *Builder) create(mounts []mount.Mount) (string, error) {
 	if b.image == "" && !b.noBaseImage {
 		return "", fmt.Errorf("Please provide a source image with `from` prior to run")
 	}
@@ -487,6 +548,7 @@ func (b *Builder) create() (string, error) {
 		Isolation: b.options.Isolation,
 		ShmSize:   b.options.ShmSize,
 		Resources: resources,
+		Mounts:    mounts,
 	}
 
 	config := *b.runConfig
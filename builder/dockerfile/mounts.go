@@ -0,0 +1,83 @@
+package dockerfile
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/docker/docker/api/types/mount"
+)
+
+// runMounts are the mounts of the --mount flags of a RUN instruction.
+type runMounts struct {
+	secrets []*secretMount
+	caches  []*cacheMount
+}
+
+// parseRunMounts parses the --mount flags of a RUN instruction, skipping the
+// secrets that are not required and were not provided by the client.
+func (b *Builder) parseRunMounts(values []string) (*runMounts, error) {
+	rm := &runMounts{}
+	for _, v := range values {
+		switch mountType(v) {
+		case "cache":
+			m, err := parseCacheMount(v)
+			if err != nil {
+				return nil, err
+			}
+			rm.caches = append(rm.caches, m)
+		default:
+			if runtime.GOOS == "windows" {
+				return nil, fmt.Errorf("secret mounts are not supported on Windows")
+			}
+			m, err := parseSecretMount(v)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := b.options.Secrets[m.id]; !ok {
+				if m.required {
+					return nil, fmt.Errorf("secret %s is required but was not provided", m.id)
+				}
+				continue
+			}
+			rm.secrets = append(rm.secrets, m)
+		}
+	}
+	return rm, nil
+}
+
+// mountType returns the type of the mount of a --mount flag, which must be
+// its first field.
+func mountType(value string) string {
+	field := strings.SplitN(value, ",", 2)[0]
+	if !strings.HasPrefix(field, "type=") {
+		return ""
+	}
+	return strings.TrimPrefix(field, "type=")
+}
+
+// runMountsCacheArgs returns the arguments to add to the cache key of the RUN
+// instruction for its mounts.
+func (b *Builder) runMountsCacheArgs(rm *runMounts) []string {
+	var args []string
+	for _, m := range rm.secrets {
+		args = append(args, m.cacheArg(b.options.Secrets[m.id], secretHashKey))
+	}
+	for _, m := range rm.caches {
+		args = append(args, m.cacheArg())
+	}
+	return args
+}
+
+// setupRunMounts returns the mounts of the container of the RUN instruction.
+// The returned cleanup function must be called once the container exited.
+func (b *Builder) setupRunMounts(rm *runMounts) ([]mount.Mount, func(), error) {
+	mounts, cleanup, err := b.writeSecrets(rm.secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, m := range rm.caches {
+		mounts = append(mounts, m.mount())
+	}
+	return mounts, cleanup, nil
+}
@@ -0,0 +1,165 @@
+package dockerfile
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/mount"
+)
+
+// defaultSecretDir is the directory in which a secret is mounted when the
+// mount has no target.
+const defaultSecretDir = "/run/secrets"
+
+// secretHashKey is the key of the digests of the secrets added to the cache
+// keys of the RUN instructions. The cache keys are recorded in the history of
+// the images, and a digest without a key would let the secrets be guessed
+// offline. The key is not persisted, so the instructions hashing a secret
+// miss the cache once the daemon is restarted.
+var secretHashKey = newSecretHashKey()
+
+func newSecretHashKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate the key of the digests of the build secrets: %v", err))
+	}
+	return key
+}
+
+// secretMount is a secret mounted in the container of a RUN instruction with
+// --mount=type=secret.
+type secretMount struct {
+	id       string
+	target   string
+	required bool
+	// hash adds the digest of the content of the secret, keyed with
+	// secretHashKey, to the cache key of the RUN instruction, so that a
+	// change of the secret busts the cache.
+	hash bool
+}
+
+// parseSecretMount parses the value of a --mount flag of the form
+// type=secret,id=ID[,target=PATH][,required=BOOL][,hash=BOOL].
+func parseSecretMount(value string) (*secretMount, error) {
+	m := &secretMount{}
+	for _, field := range strings.Split(value, ",") {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		val := parts[1]
+
+		switch key {
+		case "type":
+			if val != "secret" {
+				return nil, fmt.Errorf("unsupported mount type %q", val)
+			}
+		case "id":
+			m.id = val
+		case "target", "dst", "destination":
+			m.target = val
+		case "required", "hash":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, val)
+			}
+			if key == "required" {
+				m.required = b
+			} else {
+				m.hash = b
+			}
+		default:
+			return nil, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+
+	if !strings.HasPrefix(value, "type=") {
+		return nil, fmt.Errorf("invalid mount %q: type=secret must be specified first", value)
+	}
+	if m.id == "" && m.target == "" {
+		return nil, fmt.Errorf("invalid mount %q: id or target is required", value)
+	}
+	if m.target == "" {
+		m.target = path.Join(defaultSecretDir, m.id)
+	}
+	if !path.IsAbs(m.target) {
+		return nil, fmt.Errorf("invalid mount %q: target must be an absolute path", value)
+	}
+	if m.id == "" {
+		m.id = path.Base(m.target)
+	}
+	return m, nil
+}
+
+// cacheArg returns the mount as it is added to the cache key of the RUN
+// instruction. It only contains the digest of the secret keyed with key, if
+// hash is set.
+func (m *secretMount) cacheArg(content, key []byte) string {
+	arg := fmt.Sprintf("--mount=type=secret,id=%s,target=%s", m.id, m.target)
+	if m.hash {
+		h := hmac.New(sha256.New, key)
+		h.Write(content)
+		arg += ",hmac-sha256=" + hex.EncodeToString(h.Sum(nil))
+	}
+	return arg
+}
+
+// writeSecrets returns the bind mounts of the secrets of a RUN instruction.
+// The secrets are written to a temporary directory on the host, which is
+// removed by the returned cleanup function once the container of the
+// instruction has exited, so that the secrets never land in a layer of the
+// image. The mount points of the secrets are removed from the container
+// before it is committed, see removeSecretMountPoints.
+func (b *Builder) writeSecrets(secrets []*secretMount) ([]mount.Mount, func(), error) {
+	if len(secrets) == 0 {
+		return nil, func() {}, nil
+	}
+
+	dir, err := ioutil.TempDir("", "docker-build-secrets-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	var mounts []mount.Mount
+	for i, m := range secrets {
+		src := filepath.Join(dir, strconv.Itoa(i))
+		// The file is readable by any user, as the user of the RUN
+		// instruction may not be root; the directory protects it on the host.
+		if err := ioutil.WriteFile(src, b.options.Secrets[m.id], 0444); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   src,
+			Target:   m.target,
+			ReadOnly: true,
+		})
+	}
+	return mounts, cleanup, nil
+}
+
+// removeSecretMountPoints removes the files and the directories created in
+// the container of a RUN instruction as the mount points of its secrets, so
+// that they are not left in the layer of the instruction.
+func (b *Builder) removeSecretMountPoints(cID string, secrets []*secretMount) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	var targets []string
+	for _, m := range secrets {
+		targets = append(targets, m.target)
+	}
+	return b.docker.RemoveMountPointsOnBuild(cID, targets)
+}
@@ -0,0 +1,105 @@
+package dockerfile
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestParseSecretMount(t *testing.T) {
+	valid := map[string]secretMount{
+		"type=secret,id=foo":                            {id: "foo", target: "/run/secrets/foo"},
+		"type=secret,target=/etc/foo.conf":              {id: "foo.conf", target: "/etc/foo.conf"},
+		"type=secret,id=foo,dst=/etc/bar":               {id: "foo", target: "/etc/bar"},
+		"type=secret,id=foo,required=true":              {id: "foo", target: "/run/secrets/foo", required: true},
+		"type=secret,id=foo,hash=1,required=0":          {id: "foo", target: "/run/secrets/foo", hash: true},
+		"type=secret,destination=/a/b,id=foo,Hash=true": {id: "foo", target: "/a/b", hash: true},
+	}
+	for value, expected := range valid {
+		m, err := parseSecretMount(value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", value, err)
+		}
+		if *m != expected {
+			t.Fatalf("%s: expected %+v, got %+v", value, expected, *m)
+		}
+	}
+
+	invalid := map[string]string{
+		"id=foo":                          "type=secret must be specified first",
+		"type=bind,id=foo":                "unsupported mount type",
+		"type=secret":                     "id or target is required",
+		"type=secret,id=foo,target=bar":   "target must be an absolute path",
+		"type=secret,id=foo,required=yes": "invalid value for required",
+		"type=secret,id=foo,mode=0400":    "unexpected key",
+		"type=secret,id":                  "must be a key=value pair",
+	}
+	for value, expected := range invalid {
+		if _, err := parseSecretMount(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected error containing %q, got %v", value, expected, err)
+		}
+	}
+}
+
+func TestSecretMountCacheArg(t *testing.T) {
+	m := &secretMount{id: "foo", target: "/run/secrets/foo"}
+	if arg := m.cacheArg([]byte("secret"), []byte("key")); arg != "--mount=type=secret,id=foo,target=/run/secrets/foo" {
+		t.Fatalf("unexpected cache argument: %s", arg)
+	}
+
+	m.hash = true
+	expected := "--mount=type=secret,id=foo,target=/run/secrets/foo,hmac-sha256=25cf3c44c8f39313e8cbf7c23e22fe8b2ee8b288ee5206b0a6397583a1f7f0ef"
+	if arg := m.cacheArg([]byte("secret"), []byte("key")); arg != expected {
+		t.Fatalf("expected %s, got %s", expected, arg)
+	}
+	// The digest depends on the key, and not only on the secret.
+	if arg := m.cacheArg([]byte("secret"), []byte("other key")); arg == expected {
+		t.Fatal("expected the digest of the secret to depend on the key")
+	}
+}
+
+func TestSecretMounts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("secret mounts are not supported on Windows")
+	}
+
+	b := &Builder{options: &types.ImageBuildOptions{
+		Secrets: map[string][]byte{"foo": []byte("secret")},
+	}}
+
+	if _, err := b.parseRunMounts([]string{"type=secret,id=bar,required=true"}); err == nil {
+		t.Fatal("expected an error for a required secret that was not provided")
+	}
+
+	rm, err := b.parseRunMounts([]string{"type=secret,id=foo", "type=secret,id=bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rm.secrets) != 1 || rm.secrets[0].id != "foo" {
+		t.Fatalf("expected only the provided secret to be mounted, got %v", rm.secrets)
+	}
+
+	mounts, cleanup, err := b.writeSecrets(rm.secrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mounts) != 1 || mounts[0].Target != "/run/secrets/foo" || !mounts[0].ReadOnly {
+		t.Fatalf("unexpected mounts: %v", mounts)
+	}
+	content, err := ioutil.ReadFile(mounts[0].Source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "secret" {
+		t.Fatalf("expected the secret to be written, got %q", content)
+	}
+
+	cleanup()
+	if _, err := os.Stat(mounts[0].Source); !os.IsNotExist(err) {
+		t.Fatalf("expected the secret to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package dockerfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/urlutil"
+	"github.com/docker/docker/reference"
+)
+
+// validateTable replaces the dispatchers of evaluateTable that pull images,
+// run containers or copy files when the Dockerfile is only validated. The
+// other dispatchers only change the configuration of the build and are run
+// as is, with the commits disabled, so that the substitution of the
+// variables in the following instructions is validated too.
+var validateTable map[string]func(*Builder, []string, map[string]bool, string) error
+
+func init() {
+	validateTable = map[string]func(*Builder, []string, map[string]bool, string) error{
+		"from": validateFrom,
+		"run":  validateRun,
+		"add":  validateAdd,
+		"copy": validateCopy,
+	}
+}
+
+// validate validates the instructions of the Dockerfile without running
+// them. All the errors are reported, with the line of their instruction.
+func (b *Builder) validate() error {
+	b.disableCommit = true
+
+	var errs []types.BuildValidationError
+	total := len(b.dockerfile.Children)
+	for i, n := range b.dockerfile.Children {
+		err := b.checkDispatch(n, false)
+		if err == nil {
+			err = b.dispatch(i, total, n)
+		}
+		if err != nil {
+			errs = append(errs, b.validationError(n.StartLine, strings.ToUpper(n.Value), err))
+		}
+	}
+
+	leftoverArgs := []string{}
+	for arg := range b.options.BuildArgs {
+		if !b.isBuildArgAllowed(arg) {
+			leftoverArgs = append(leftoverArgs, arg)
+		}
+	}
+	if len(leftoverArgs) > 0 {
+		errs = append(errs, b.validationError(0, "", fmt.Errorf("One or more build-args %v were not consumed", leftoverArgs)))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Dockerfile validation failed with %d error(s)", len(errs))
+	}
+	fmt.Fprintf(b.Stdout, "Dockerfile is valid\n")
+	return nil
+}
+
+// validationError reports the error of an instruction in the build output,
+// and as auxiliary data for the clients to process.
+func (b *Builder) validationError(line int, instruction string, err error) types.BuildValidationError {
+	e := types.BuildValidationError{Line: line, Instruction: instruction, Message: err.Error()}
+	if line > 0 {
+		fmt.Fprintf(b.Stdout, "Dockerfile:%d: %s: %s\n", line, instruction, e.Message)
+	} else {
+		fmt.Fprintf(b.Stdout, "Dockerfile: %s\n", e.Message)
+	}
+	if stdoutFormatter, ok := b.Stdout.(*streamformatter.StdoutFormatter); ok {
+		progress.Aux(stdoutFormatter.StreamFormatter.NewProgressOutput(stdoutFormatter.Writer, false), e)
+	}
+	return e
+}
+
+// validateFrom validates the reference of the base image. The configuration
+// of the image is used for the following instructions if it is available
+// locally; it is never pulled.
+func validateFrom(b *Builder, args []string, attributes map[string]bool, original string) error {
+	if len(args) != 1 {
+		return errExactlyOneArgument("FROM")
+	}
+
+	if err := b.flags.Parse(); err != nil {
+		return err
+	}
+
+	name := args[0]
+	if name == api.NoBaseImageSpecifier {
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("Windows does not support FROM scratch")
+		}
+		b.image = ""
+		b.noBaseImage = true
+		return b.processImageFrom(nil, nil)
+	}
+
+	// The following instructions are validated against the name of the
+	// image, even if it is invalid, not to report the missing base image.
+	b.image = name
+	if _, err := reference.ParseNamed(name); err != nil {
+		return err
+	}
+	if err := checkFromPolicy(b.fromPolicy(), name); err != nil {
+		return err
+	}
+	if img, err := b.docker.GetImageOnBuild(name); err == nil && img != nil && img.RunConfig() != nil {
+		config := *img.RunConfig()
+		config.Env = append([]string{}, config.Env...)
+		b.runConfig = &config
+	}
+	return nil
+}
+
+// validateRun validates the flags of a RUN instruction.
+func validateRun(b *Builder, args []string, attributes map[string]bool, original string) error {
+	if b.image == "" && !b.noBaseImage {
+		return fmt.Errorf("Please provide a source image with `from` prior to run")
+	}
+
+	flMounts := b.flags.AddStrings("mount")
+
+	if err := b.flags.Parse(); err != nil {
+		return err
+	}
+
+	_, err := b.parseRunMounts(flMounts.StringValues)
+	return err
+}
+
+// validateAdd validates the sources of an ADD instruction.
+func validateAdd(b *Builder, args []string, attributes map[string]bool, original string) error {
+	if len(args) < 2 {
+		return errAtLeastTwoArguments("ADD")
+	}
+
+	if err := b.flags.Parse(); err != nil {
+		return err
+	}
+
+	return b.validateContextCommand(args, true, "ADD")
+}
+
+// validateCopy validates the sources of a COPY instruction.
+func validateCopy(b *Builder, args []string, attributes map[string]bool, original string) error {
+	if len(args) < 2 {
+		return errAtLeastTwoArguments("COPY")
+	}
+
+	if err := b.flags.Parse(); err != nil {
+		return err
+	}
+
+	return b.validateContextCommand(args, false, "COPY")
+}
+
+// validateContextCommand checks that the sources of an ADD or COPY
+// instruction are available in the build context, as runContextCommand
+// does. The remote sources are not downloaded.
+func (b *Builder) validateContextCommand(args []string, allowRemote bool, cmdName string) error {
+	if b.context == nil {
+		return fmt.Errorf("No context given. Impossible to use %s", cmdName)
+	}
+
+	dest := filepath.FromSlash(args[len(args)-1])
+
+	var sources int
+	for _, orig := range args[0 : len(args)-1] {
+		if urlutil.IsURL(orig) {
+			if !allowRemote {
+				return fmt.Errorf("Source can't be a URL for %s", cmdName)
+			}
+			sources++
+			continue
+		}
+		infos, err := b.calcCopyInfo(cmdName, orig, false, true)
+		if err != nil {
+			return err
+		}
+		if len(infos) == 0 {
+			return fmt.Errorf("No source files were specified for %s", orig)
+		}
+		sources += len(infos)
+	}
+
+	if sources > 1 && !strings.HasSuffix(dest, string(os.PathSeparator)) {
+		return fmt.Errorf("When using %s with more than one source file, the destination must be a directory and end with a /", cmdName)
+	}
+	return nil
+}
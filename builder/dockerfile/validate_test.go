@@ -0,0 +1,79 @@
+package dockerfile
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/pkg/archive"
+)
+
+func TestValidate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("FROM scratch is not supported on Windows")
+	}
+
+	contextDir, cleanup := createTestTempDir(t, "", "builder-dockerfile-test")
+	defer cleanup()
+	createTestTempFile(t, contextDir, "foo.txt", "foo", 0777)
+
+	tarStream, err := archive.Tar(contextDir, archive.Uncompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tarStream.Close()
+	context, err := builder.MakeTarSumContext(tarStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer context.Close()
+
+	dockerfile := `FROM scratch
+ENV SRC foo.txt
+COPY $SRC /dst
+COPY missing.txt /dst
+RUN --mount=type=bogus true
+COPY foo.txt $SRC /dst
+ADD http://example.com/foo.txt foo.txt /dst/
+COPY http://example.com/foo.txt /dst
+`
+	d := parser.Directive{}
+	parser.SetEscapeToken(parser.DefaultEscapeToken, &d)
+	n, err := parser.Parse(strings.NewReader(dockerfile), &d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := &bytes.Buffer{}
+	b := &Builder{
+		runConfig:        &container.Config{},
+		options:          &types.ImageBuildOptions{Validate: true},
+		Stdout:           stdout,
+		context:          context,
+		dockerfile:       n,
+		allowedBuildArgs: make(map[string]bool),
+	}
+
+	err = b.validate()
+	if err == nil || !strings.Contains(err.Error(), "4 error(s)") {
+		t.Fatalf("expected 4 validation errors, got %v\n%s", err, stdout)
+	}
+	for _, expected := range []string{
+		"Dockerfile:4: COPY: ",
+		"Dockerfile:5: RUN: unsupported mount type",
+		"Dockerfile:6: COPY: When using COPY with more than one source file",
+		"Dockerfile:8: COPY: Source can't be a URL for COPY",
+	} {
+		if !strings.Contains(stdout.String(), expected) {
+			t.Fatalf("expected %q in the output:\n%s", expected, stdout)
+		}
+	}
+	if strings.Contains(stdout.String(), "Dockerfile:3:") || strings.Contains(stdout.String(), "Dockerfile:7:") {
+		t.Fatalf("unexpected error for a valid instruction:\n%s", stdout)
+	}
+}
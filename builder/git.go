@@ -7,9 +7,20 @@ import (
 	"github.com/docker/docker/pkg/gitutils"
 )
 
-// MakeGitContext returns a Context from gitURL that is cloned in a temporary directory.
-func MakeGitContext(gitURL string) (ModifiableContext, error) {
-	root, err := gitutils.Clone(gitURL)
+// gitContext is a GitContext.
+type gitContext struct {
+	ModifiableContext
+	commit string
+}
+
+func (c *gitContext) GitCommit() string {
+	return c.commit
+}
+
+// MakeGitContext returns a GitContext from gitURL that is cloned in a
+// temporary directory, authenticating with the credentials of opts.
+func MakeGitContext(gitURL string, opts gitutils.CloneOptions) (GitContext, error) {
+	root, commit, err := gitutils.CloneWithOptions(gitURL, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -24,5 +35,9 @@ func MakeGitContext(gitURL string) (ModifiableContext, error) {
 		c.Close()
 		os.RemoveAll(root)
 	}()
-	return MakeTarSumContext(c)
+	ctx, err := MakeTarSumContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return &gitContext{ModifiableContext: ctx, commit: commit}, nil
 }
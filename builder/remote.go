@@ -9,6 +9,7 @@ import (
 	"regexp"
 
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/gitutils"
 	"github.com/docker/docker/pkg/httputils"
 	"github.com/docker/docker/pkg/urlutil"
 )
@@ -70,12 +71,13 @@ func MakeRemoteContext(remoteURL string, contentTypeHandlers map[string]func(io.
 // DetectContextFromRemoteURL returns a context and in certain cases the name of the dockerfile to be used
 // irrespective of user input.
 // progressReader is only used if remoteURL is actually a URL (not empty, and not a Git endpoint).
-func DetectContextFromRemoteURL(r io.ReadCloser, remoteURL string, createProgressReader func(in io.ReadCloser) io.ReadCloser) (context ModifiableContext, dockerfileName string, err error) {
+// gitOptions are only used if remoteURL is a Git endpoint, in which case the context is a GitContext.
+func DetectContextFromRemoteURL(r io.ReadCloser, remoteURL string, gitOptions gitutils.CloneOptions, createProgressReader func(in io.ReadCloser) io.ReadCloser) (context ModifiableContext, dockerfileName string, err error) {
 	switch {
 	case remoteURL == "":
 		context, err = MakeTarSumContext(r)
 	case urlutil.IsGitURL(remoteURL):
-		context, err = MakeGitContext(remoteURL)
+		context, err = MakeGitContext(remoteURL, gitOptions)
 	case urlutil.IsURL(remoteURL):
 		context, err = MakeRemoteContext(remoteURL, map[string]func(io.ReadCloser) (io.ReadCloser, error){
 			httputils.MimeTypes.TextPlain: func(rc io.ReadCloser) (io.ReadCloser, error) {
@@ -6,15 +6,20 @@ import (
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/checkpoint"
 	"github.com/docker/docker/cli/command/container"
+	"github.com/docker/docker/cli/command/envfile"
 	"github.com/docker/docker/cli/command/image"
+	"github.com/docker/docker/cli/command/job"
+	"github.com/docker/docker/cli/command/manifest"
 	"github.com/docker/docker/cli/command/network"
 	"github.com/docker/docker/cli/command/node"
 	"github.com/docker/docker/cli/command/plugin"
+	"github.com/docker/docker/cli/command/pod"
 	"github.com/docker/docker/cli/command/registry"
 	"github.com/docker/docker/cli/command/service"
 	"github.com/docker/docker/cli/command/stack"
 	"github.com/docker/docker/cli/command/swarm"
 	"github.com/docker/docker/cli/command/system"
+	"github.com/docker/docker/cli/command/trust"
 	"github.com/docker/docker/cli/command/volume"
 	"github.com/spf13/cobra"
 )
@@ -28,16 +33,22 @@ func AddCommands(cmd *cobra.Command, dockerCli *command.DockerCli) {
 		stack.NewTopLevelDeployCommand(dockerCli),
 		swarm.NewSwarmCommand(dockerCli),
 		container.NewContainerCommand(dockerCli),
+		envfile.NewEnvFileCommand(dockerCli),
 		image.NewImageCommand(dockerCli),
 		system.NewSystemCommand(dockerCli),
 		container.NewRunCommand(dockerCli),
 		image.NewBuildCommand(dockerCli),
+		manifest.NewManifestCommand(dockerCli),
 		network.NewNetworkCommand(dockerCli),
+		job.NewJobCommand(dockerCli),
+		pod.NewPodCommand(dockerCli),
+		registry.NewRegistryCommand(dockerCli),
 		hide(system.NewEventsCommand(dockerCli)),
 		registry.NewLoginCommand(dockerCli),
 		registry.NewLogoutCommand(dockerCli),
 		registry.NewSearchCommand(dockerCli),
 		system.NewVersionCommand(dockerCli),
+		trust.NewTrustCommand(dockerCli),
 		volume.NewVolumeCommand(dockerCli),
 		hide(system.NewInfoCommand(dockerCli)),
 		hide(container.NewAttachCommand(dockerCli)),
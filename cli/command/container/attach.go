@@ -16,9 +16,12 @@ import (
 )
 
 type attachOptions struct {
-	noStdin    bool
-	proxy      bool
-	detachKeys string
+	noStdin     bool
+	proxy       bool
+	detachKeys  string
+	scrollback  bool
+	sinceDetach bool
+	flowControl bool
 
 	container string
 }
@@ -41,6 +44,9 @@ func NewAttachCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVar(&opts.noStdin, "no-stdin", false, "Do not attach STDIN")
 	flags.BoolVar(&opts.proxy, "sig-proxy", true, "Proxy all received signals to the process")
 	flags.StringVar(&opts.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flags.BoolVar(&opts.scrollback, "scrollback", false, "Replay the recent output of a TTY")
+	flags.BoolVar(&opts.sinceDetach, "since-detach", false, "Replay the output of a TTY since the last detach")
+	flags.BoolVar(&opts.flowControl, "flow-control", false, "Use flow control on the connection to the daemon")
 	return cmd
 }
 
@@ -70,11 +76,14 @@ func runAttach(dockerCli *command.DockerCli, opts *attachOptions) error {
 	}
 
 	options := types.ContainerAttachOptions{
-		Stream:     true,
-		Stdin:      !opts.noStdin && c.Config.OpenStdin,
-		Stdout:     true,
-		Stderr:     true,
-		DetachKeys: dockerCli.ConfigFile().DetachKeys,
+		Stream:      true,
+		Stdin:       !opts.noStdin && c.Config.OpenStdin,
+		Stdout:      true,
+		Stderr:      true,
+		DetachKeys:  dockerCli.ConfigFile().DetachKeys,
+		Scrollback:  opts.scrollback || opts.sinceDetach,
+		SinceDetach: opts.sinceDetach,
+		FlowControl: opts.flowControl,
 	}
 
 	var in io.ReadCloser
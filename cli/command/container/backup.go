@@ -0,0 +1,65 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type backupOptions struct {
+	container string
+	output    string
+	since     string
+}
+
+// NewBackupCommand creates a new `docker container backup` command
+func NewBackupCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts backupOptions
+
+	cmd := &cobra.Command{
+		Use:   "backup [OPTIONS] CONTAINER",
+		Short: "Back up the changes of a container's filesystem as a tar archive",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			return runBackup(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.since, "since", "", "Only back up the changes since the snapshot of an earlier backup")
+
+	return cmd
+}
+
+func runBackup(dockerCli *command.DockerCli, opts backupOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
+	}
+
+	responseBody, snapshot, err := dockerCli.Client().ContainerBackup(context.Background(), opts.container, opts.since)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	if opts.output == "" {
+		fmt.Fprintln(dockerCli.Err(), snapshot)
+		_, err := io.Copy(dockerCli.Out(), responseBody)
+		return err
+	}
+
+	if err := command.CopyToFile(opts.output, responseBody); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), snapshot)
+	return nil
+}
@@ -0,0 +1,76 @@
+package container
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/docker/go-connections/nat"
+	"github.com/spf13/cobra"
+)
+
+type cloneOptions struct {
+	container   string
+	name        string
+	image       string
+	env         opts.ListOpts
+	copyVolumes bool
+	publish     opts.ListOpts
+}
+
+// NewCloneCommand creates a new cobra.Command for `docker container clone`
+func NewCloneCommand(dockerCli *command.DockerCli) *cobra.Command {
+	opts := cloneOptions{
+		env:     opts.NewListOpts(runconfigopts.ValidateEnv),
+		publish: opts.NewListOpts(nil),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [OPTIONS] CONTAINER",
+		Short: "Create a new container from the configuration of a container",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			return runClone(dockerCli, &opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.name, "name", "", "Assign a name to the new container")
+	flags.StringVar(&opts.image, "image", "", "Use another image, or another tag of the image with \":TAG\"")
+	flags.VarP(&opts.env, "env", "e", "Set or override environment variables")
+	flags.BoolVar(&opts.copyVolumes, "copy-volumes", false, "Copy the content of the anonymous volumes")
+	flags.VarP(&opts.publish, "publish", "p", "Publish the container's port(s) to the host, instead of the ports of the container")
+
+	return cmd
+}
+
+func runClone(dockerCli *command.DockerCli, opts *cloneOptions) error {
+	config := types.ContainerCloneConfig{
+		Image:       opts.image,
+		Env:         opts.env.GetAll(),
+		CopyVolumes: opts.copyVolumes,
+	}
+	if opts.publish.Len() > 0 {
+		_, portBindings, err := nat.ParsePortSpecs(opts.publish.GetAll())
+		if err != nil {
+			return err
+		}
+		config.PortBindings = portBindings
+	}
+
+	response, err := dockerCli.Client().ContainerClone(context.Background(), opts.container, config, opts.name)
+	if err != nil {
+		return err
+	}
+	for _, warning := range response.Warnings {
+		fmt.Fprintf(dockerCli.Err(), "WARNING: %s\n", warning)
+	}
+	fmt.Fprintln(dockerCli.Out(), response.ID)
+	return nil
+}
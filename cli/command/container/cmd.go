@@ -21,18 +21,24 @@ func NewContainerCommand(dockerCli *command.DockerCli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewAttachCommand(dockerCli),
+		NewBackupCommand(dockerCli),
+		NewCloneCommand(dockerCli),
 		NewCommitCommand(dockerCli),
 		NewCopyCommand(dockerCli),
+		NewCoresCommand(dockerCli),
 		NewCreateCommand(dockerCli),
 		NewDiffCommand(dockerCli),
 		NewExecCommand(dockerCli),
 		NewExportCommand(dockerCli),
+		NewHandoffCommand(dockerCli),
 		NewKillCommand(dockerCli),
 		NewLogsCommand(dockerCli),
 		NewPauseCommand(dockerCli),
 		NewPortCommand(dockerCli),
 		NewRenameCommand(dockerCli),
+		NewResolveCommand(dockerCli),
 		NewRestartCommand(dockerCli),
+		NewRestoreCommand(dockerCli),
 		NewRmCommand(dockerCli),
 		NewRunCommand(dockerCli),
 		NewStartCommand(dockerCli),
@@ -41,6 +47,7 @@ func NewContainerCommand(dockerCli *command.DockerCli) *cobra.Command {
 		NewTopCommand(dockerCli),
 		NewUnpauseCommand(dockerCli),
 		NewUpdateCommand(dockerCli),
+		NewVerifyCommand(dockerCli),
 		NewWaitCommand(dockerCli),
 		newListCommand(dockerCli),
 		newInspectCommand(dockerCli),
@@ -16,10 +16,11 @@ type commitOptions struct {
 	container string
 	reference string
 
-	pause   bool
-	comment string
-	author  string
-	changes dockeropts.ListOpts
+	pause      bool
+	consistent bool
+	comment    string
+	author     string
+	changes    dockeropts.ListOpts
 }
 
 // NewCommitCommand creates a new cobra.Command for `docker commit`
@@ -43,6 +44,7 @@ func NewCommitCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.SetInterspersed(false)
 
 	flags.BoolVarP(&opts.pause, "pause", "p", true, "Pause container during commit")
+	flags.BoolVar(&opts.consistent, "pause-consistent", false, "Flush the filesystem of the paused container before commit, and report the timing")
 	flags.StringVarP(&opts.comment, "message", "m", "", "Commit message")
 	flags.StringVarP(&opts.author, "author", "a", "", "Author (e.g., \"John Hannibal Smith <hannibal@a-team.com>\")")
 
@@ -58,12 +60,17 @@ func runCommit(dockerCli *command.DockerCli, opts *commitOptions) error {
 	name := opts.container
 	reference := opts.reference
 
+	if opts.consistent && !opts.pause {
+		return fmt.Errorf("Conflicting options: --pause-consistent and --pause=false")
+	}
+
 	options := types.ContainerCommitOptions{
-		Reference: reference,
-		Comment:   opts.comment,
-		Author:    opts.author,
-		Changes:   opts.changes.GetAll(),
-		Pause:     opts.pause,
+		Reference:  reference,
+		Comment:    opts.comment,
+		Author:     opts.author,
+		Changes:    opts.changes.GetAll(),
+		Pause:      opts.pause,
+		Consistent: opts.consistent,
 	}
 
 	response, err := dockerCli.Client().ContainerCommit(ctx, name, options)
@@ -72,5 +79,8 @@ func runCommit(dockerCli *command.DockerCli, opts *commitOptions) error {
 	}
 
 	fmt.Fprintln(dockerCli.Out(), response.ID)
+	if t := response.Timing; t != nil {
+		fmt.Fprintf(dockerCli.Err(), "Paused for %s (pause %s, sync %s, commit %s, resume %s)\n", t.Frozen, t.Pause, t.Sync, t.Commit, t.Resume)
+	}
 	return nil
 }
@@ -0,0 +1,106 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type coresGetOptions struct {
+	container string
+	core      string
+	output    string
+}
+
+// NewCoresCommand creates a new `docker container cores` command
+func NewCoresCommand(dockerCli *command.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cores",
+		Short: "Manage the core dumps captured for containers",
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(dockerCli.Err(), "\n%s", cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		newCoresListCommand(dockerCli),
+		newCoresGetCommand(dockerCli),
+	)
+	return cmd
+}
+
+func newCoresListCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls CONTAINER",
+		Aliases: []string{"list"},
+		Short:   "List the core dumps captured for a container",
+		Args:    cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCoresList(dockerCli, args[0])
+		},
+	}
+}
+
+func runCoresList(dockerCli *command.DockerCli, container string) error {
+	cores, err := dockerCli.Client().ContainerCoreDumps(context.Background(), container)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSIZE\tCREATED")
+	for _, c := range cores {
+		created := units.HumanDuration(time.Now().UTC().Sub(c.Created)) + " ago"
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.Name, units.HumanSize(float64(c.Size)), created)
+	}
+	w.Flush()
+	return nil
+}
+
+func newCoresGetCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts coresGetOptions
+
+	cmd := &cobra.Command{
+		Use:   "get [OPTIONS] CONTAINER CORE",
+		Short: "Retrieve a core dump captured for a container",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			opts.core = args[1]
+			return runCoresGet(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+
+	return cmd
+}
+
+func runCoresGet(dockerCli *command.DockerCli, opts coresGetOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("Cowardly refusing to write a core dump to a terminal. Use the -o flag or redirect.")
+	}
+
+	responseBody, err := dockerCli.Client().ContainerCoreDump(context.Background(), opts.container, opts.core)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	if opts.output == "" {
+		_, err := io.Copy(dockerCli.Out(), responseBody)
+		return err
+	}
+
+	return command.CopyToFile(opts.output, responseBody)
+}
@@ -41,7 +41,8 @@ func NewCopyCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use: `cp [OPTIONS] CONTAINER:SRC_PATH DEST_PATH|-
-	docker cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH`,
+	docker cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH
+	docker cp [OPTIONS] CONTAINER:SRC_PATH CONTAINER:DEST_PATH`,
 		Short: "Copy files/folders between a container and the local filesystem",
 		Long: strings.Join([]string{
 			"Copy files/folders between a container and the local filesystem\n",
@@ -95,8 +96,7 @@ func runCopy(dockerCli *command.DockerCli, opts copyOptions) error {
 	case toContainer:
 		return copyToContainer(ctx, dockerCli, srcPath, dstContainer, dstPath, cpParam)
 	case acrossContainers:
-		// Copying between containers isn't supported.
-		return fmt.Errorf("copying between containers is not supported")
+		return copyAcrossContainers(ctx, dockerCli, srcContainer, srcPath, dstContainer, dstPath, cpParam)
 	default:
 		// User didn't specify any container.
 		return fmt.Errorf("must specify at least one container source")
@@ -271,6 +271,21 @@ func copyToContainer(ctx context.Context, dockerCli *command.DockerCli, srcPath,
 	return dockerCli.Client().CopyToContainer(ctx, dstContainer, resolvedDstPath, content, options)
 }
 
+func copyAcrossContainers(ctx context.Context, dockerCli *command.DockerCli, srcContainer, srcPath, dstContainer, dstPath string, cpParam *cpConfig) error {
+	if srcPath == "-" || dstPath == "-" {
+		return fmt.Errorf("cannot use '-' when copying between containers")
+	}
+
+	// The daemon streams the content from one container to the other, so
+	// that it does not go through the client.
+	options := types.CopyBetweenContainersOptions{
+		AllowOverwriteDirWithFile: false,
+		FollowLink:                cpParam.followLink,
+	}
+
+	return dockerCli.Client().CopyBetweenContainers(ctx, srcContainer, srcPath, dstContainer, dstPath, options)
+}
+
 // We use `:` as a delimiter between CONTAINER and PATH, but `:` could also be
 // in a valid LOCALPATH, like `file:name.txt`. We can resolve this ambiguity by
 // requiring a LOCALPATH with a `:` to be made explicit with a relative or
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -24,7 +25,8 @@ import (
 )
 
 type createOptions struct {
-	name string
+	name      string
+	printSpec bool
 }
 
 // NewCreateCommand creates a new cobra.Command for `docker create`
@@ -49,6 +51,7 @@ func NewCreateCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.SetInterspersed(false)
 
 	flags.StringVar(&opts.name, "name", "", "Assign a name to the container")
+	flags.BoolVar(&opts.printSpec, "print-spec", false, "Print the OCI runtime spec of the container, without keeping the container")
 
 	// Add an explicit help that doesn't have a `-h` to prevent the conflict
 	// with hostname
@@ -65,14 +68,41 @@ func runCreate(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *createO
 		reportError(dockerCli.Err(), "create", err.Error(), true)
 		return cli.StatusError{StatusCode: 125}
 	}
-	response, err := createContainer(context.Background(), dockerCli, config, hostConfig, networkingConfig, hostConfig.ContainerIDFile, opts.name)
+	if opts.printSpec && hostConfig.ContainerIDFile != "" {
+		reportError(dockerCli.Err(), "create", "Conflicting options: --print-spec and --cidfile", true)
+		return cli.StatusError{StatusCode: 125}
+	}
+	ctx := context.Background()
+	response, err := createContainer(ctx, dockerCli, config, hostConfig, networkingConfig, copts.CreateOptions(), hostConfig.ContainerIDFile, opts.name)
 	if err != nil {
 		return err
 	}
+	if opts.printSpec {
+		return printSpec(ctx, dockerCli, response.ID)
+	}
 	fmt.Fprintf(dockerCli.Out(), "%s\n", response.ID)
 	return nil
 }
 
+// printSpec prints the OCI runtime spec of the created container, and
+// removes the container, which is only created to generate its spec.
+func printSpec(ctx context.Context, dockerCli *command.DockerCli, containerID string) error {
+	spec, err := dockerCli.Client().ContainerSpec(ctx, containerID)
+	options := types.ContainerRemoveOptions{RemoveVolumes: true, Force: true}
+	if rmErr := dockerCli.Client().ContainerRemove(ctx, containerID, options); rmErr != nil {
+		fmt.Fprintf(dockerCli.Err(), "Failed to remove container %s: %v\n", containerID, rmErr)
+	}
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(spec, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "%s\n", b)
+	return nil
+}
+
 func pullImage(ctx context.Context, dockerCli *command.DockerCli, image string, out io.Writer) error {
 	ref, err := reference.ParseNamed(image)
 	if err != nil {
@@ -148,7 +178,7 @@ func newCIDFile(path string) (*cidFile, error) {
 	return &cidFile{path: path, file: f}, nil
 }
 
-func createContainer(ctx context.Context, dockerCli *command.DockerCli, config *container.Config, hostConfig *container.HostConfig, networkingConfig *networktypes.NetworkingConfig, cidfile, name string) (*types.ContainerCreateResponse, error) {
+func createContainer(ctx context.Context, dockerCli *command.DockerCli, config *container.Config, hostConfig *container.HostConfig, networkingConfig *networktypes.NetworkingConfig, createOpts types.ContainerCreateOptions, cidfile, name string) (*types.ContainerCreateResponse, error) {
 	stderr := dockerCli.Err()
 
 	var containerIDFile *cidFile
@@ -179,7 +209,7 @@ func createContainer(ctx context.Context, dockerCli *command.DockerCli, config *
 	}
 
 	//create the container
-	response, err := dockerCli.Client().ContainerCreate(ctx, config, hostConfig, networkingConfig, name)
+	response, err := dockerCli.Client().ContainerCreate(ctx, config, hostConfig, networkingConfig, name, createOpts)
 
 	//if image not found try to pull it
 	if err != nil {
@@ -197,7 +227,7 @@ func createContainer(ctx context.Context, dockerCli *command.DockerCli, config *
 			}
 			// Retry
 			var retryErr error
-			response, retryErr = dockerCli.Client().ContainerCreate(ctx, config, hostConfig, networkingConfig, name)
+			response, retryErr = dockerCli.Client().ContainerCreate(ctx, config, hostConfig, networkingConfig, name, createOpts)
 			if retryErr != nil {
 				return nil, retryErr
 			}
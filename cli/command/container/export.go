@@ -6,6 +6,7 @@ import (
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/spf13/cobra"
@@ -14,6 +15,7 @@ import (
 type exportOptions struct {
 	container string
 	output    string
+	format    string
 }
 
 // NewExportCommand creates a new `docker export` command
@@ -33,6 +35,7 @@ func NewExportCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.format, "format", "tar", "Format of the export (tar or oci-bundle)")
 
 	return cmd
 }
@@ -44,7 +47,7 @@ func runExport(dockerCli *command.DockerCli, opts exportOptions) error {
 
 	clnt := dockerCli.Client()
 
-	responseBody, err := clnt.ContainerExport(context.Background(), opts.container)
+	responseBody, err := clnt.ContainerExportWithOptions(context.Background(), opts.container, types.ContainerExportOptions{Format: opts.format})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,52 @@
+package container
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type handoffOptions struct {
+	time int
+
+	from      string
+	container string
+}
+
+// NewHandoffCommand creates a new cobra.Command for `docker container handoff`
+func NewHandoffCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts handoffOptions
+
+	cmd := &cobra.Command{
+		Use:   "handoff [OPTIONS] CONTAINER NEW_CONTAINER",
+		Short: "Replace a running container, handing off its published ports",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.from = args[0]
+			opts.container = args[1]
+			var timeout *time.Duration
+			if cmd.Flags().Changed("time") {
+				t := time.Duration(opts.time) * time.Second
+				timeout = &t
+			}
+			return runHandoff(dockerCli, &opts, timeout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVarP(&opts.time, "time", "t", 0, "Seconds to wait for the container to stop before killing it, instead of its stop timeout")
+	return cmd
+}
+
+func runHandoff(dockerCli *command.DockerCli, opts *handoffOptions, timeout *time.Duration) error {
+	if err := dockerCli.Client().ContainerHandoff(context.Background(), opts.container, opts.from, timeout); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), opts.container)
+	return nil
+}
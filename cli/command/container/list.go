@@ -70,6 +70,18 @@ func (p *preProcessor) Size() bool {
 	return true
 }
 
+// NetIO sets the resources option when called by a template execution.
+func (p *preProcessor) NetIO() bool {
+	p.opts.Resources = true
+	return true
+}
+
+// BlockIO sets the resources option when called by a template execution.
+func (p *preProcessor) BlockIO() bool {
+	p.opts.Resources = true
+	return true
+}
+
 func buildContainerListOptions(opts *psOptions) (*types.ContainerListOptions, error) {
 	options := &types.ContainerListOptions{
 		All:    opts.all,
@@ -82,8 +94,8 @@ func buildContainerListOptions(opts *psOptions) (*types.ContainerListOptions, er
 		options.Limit = 1
 	}
 
-	// Currently only used with Size, so we can determine if the user
-	// put {{.Size}} in their format.
+	// Used with Size and the resource usage fields, so we can determine if
+	// the user put {{.Size}}, {{.NetIO}} or {{.BlockIO}} in their format.
 	pre := &preProcessor{opts: options}
 	tmpl, err := templates.Parse(opts.format)
 
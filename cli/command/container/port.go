@@ -8,6 +8,7 @@ import (
 
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/utils/templates"
 	"github.com/docker/go-connections/nat"
 	"github.com/spf13/cobra"
 )
@@ -15,9 +16,13 @@ import (
 type portOptions struct {
 	container string
 
-	port string
+	port   string
+	format string
 }
 
+// jsonFormat is a shortcut for the template printing the mappings as JSON.
+const jsonFormat = "json"
+
 // NewPortCommand creates a new cobra.Command for `docker port`
 func NewPortCommand(dockerCli *command.DockerCli) *cobra.Command {
 	var opts portOptions
@@ -34,6 +39,9 @@ func NewPortCommand(dockerCli *command.DockerCli) *cobra.Command {
 			return runPort(dockerCli, &opts)
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given go template, or 'json' to print the mappings as JSON")
 	return cmd
 }
 
@@ -45,27 +53,19 @@ func runPort(dockerCli *command.DockerCli, opts *portOptions) error {
 		return err
 	}
 
-	if opts.port != "" {
-		port := opts.port
-		proto := "tcp"
-		parts := strings.SplitN(port, "/", 2)
+	if opts.format != "" {
+		return formatPort(dockerCli, opts, c.NetworkSettings.Ports)
+	}
 
-		if len(parts) == 2 && len(parts[1]) != 0 {
-			port = parts[0]
-			proto = parts[1]
-		}
-		natPort := port + "/" + proto
-		newP, err := nat.NewPort(proto, port)
+	if opts.port != "" {
+		frontends, err := lookupPort(c.NetworkSettings.Ports, opts)
 		if err != nil {
 			return err
 		}
-		if frontends, exists := c.NetworkSettings.Ports[newP]; exists && frontends != nil {
-			for _, frontend := range frontends {
-				fmt.Fprintf(dockerCli.Out(), "%s:%s\n", frontend.HostIP, frontend.HostPort)
-			}
-			return nil
+		for _, frontend := range frontends {
+			fmt.Fprintf(dockerCli.Out(), "%s:%s\n", frontend.HostIP, frontend.HostPort)
 		}
-		return fmt.Errorf("Error: No public port '%s' published for %s", natPort, opts.container)
+		return nil
 	}
 
 	for from, frontends := range c.NetworkSettings.Ports {
@@ -76,3 +76,53 @@ func runPort(dockerCli *command.DockerCli, opts *portOptions) error {
 
 	return nil
 }
+
+// lookupPort returns the bindings of the port given in opts.
+func lookupPort(ports nat.PortMap, opts *portOptions) ([]nat.PortBinding, error) {
+	port := opts.port
+	proto := "tcp"
+	parts := strings.SplitN(port, "/", 2)
+
+	if len(parts) == 2 && len(parts[1]) != 0 {
+		port = parts[0]
+		proto = parts[1]
+	}
+	natPort := port + "/" + proto
+	newP, err := nat.NewPort(proto, port)
+	if err != nil {
+		return nil, err
+	}
+	if frontends, exists := ports[newP]; exists && frontends != nil {
+		return frontends, nil
+	}
+	return nil, fmt.Errorf("Error: No public port '%s' published for %s", natPort, opts.container)
+}
+
+// formatPort prints the whole port map of the container, or the bindings of
+// the port given in opts, using the format given in opts.
+func formatPort(dockerCli *command.DockerCli, opts *portOptions, ports nat.PortMap) error {
+	format := opts.format
+	if format == jsonFormat {
+		format = "{{json .}}"
+	}
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return cli.StatusError{StatusCode: 64,
+			Status: "Template parsing error: " + err.Error()}
+	}
+
+	var v interface{} = ports
+	if opts.port != "" {
+		frontends, err := lookupPort(ports, opts)
+		if err != nil {
+			return err
+		}
+		v = frontends
+	}
+
+	if err := tmpl.Execute(dockerCli.Out(), v); err != nil {
+		return err
+	}
+	dockerCli.Out().Write([]byte{'\n'})
+	return nil
+}
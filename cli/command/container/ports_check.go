@@ -0,0 +1,32 @@
+package container
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+)
+
+// runPortsCheck checks that the published ports of the container can be
+// allocated, without creating it. The conflicts are printed on the standard
+// error, and make the command exit with status 1.
+func runPortsCheck(dockerCli *command.DockerCli, hostConfig *container.HostConfig) error {
+	req := types.PortsCheckRequest{PortBindings: hostConfig.PortBindings}
+	resp, err := dockerCli.Client().ContainerPortsCheck(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Conflicts) == 0 {
+		fmt.Fprintln(dockerCli.Out(), "The published ports can be allocated")
+		return nil
+	}
+	for _, conflict := range resp.Conflicts {
+		fmt.Fprintf(dockerCli.Err(), "%s: %s\n", conflict.Port, conflict.Message)
+	}
+	return cli.StatusError{StatusCode: 1}
+}
@@ -65,3 +65,15 @@ func TestBuildContainerListOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildContainerListOptionsResources(t *testing.T) {
+	for _, format := range []string{"{{.NetIO}}", "table {{.ID}}\t{{.BlockIO}}"} {
+		options, err := buildContainerListOptions(&psOptions{last: -1, format: format, filter: opts.NewFilterOpt()})
+		assert.NilError(t, err)
+		assert.Equal(t, options.Resources, true)
+	}
+
+	options, err := buildContainerListOptions(&psOptions{last: -1, format: "{{.ID}}", filter: opts.NewFilterOpt()})
+	assert.NilError(t, err)
+	assert.Equal(t, options.Resources, false)
+}
@@ -0,0 +1,93 @@
+package container
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// waitReady waits for the container to be ready: until its healthcheck
+// reports it healthy or, if pattern is set, until a line of its logs
+// matches pattern. It fails if the container exits or becomes unhealthy
+// first, or if it is not ready after timeout, unless timeout is 0. The
+// errors refer to the container as desc.
+func waitReady(ctx context.Context, dockerCli *command.DockerCli, containerID, desc string, pattern *regexp.Regexp, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var err error
+	if pattern != nil {
+		err = waitLogPattern(ctx, dockerCli, containerID, desc, pattern)
+	} else {
+		err = waitHealthy(ctx, dockerCli, containerID, desc)
+	}
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("Error: %s is not ready after %s", desc, timeout)
+	}
+	return err
+}
+
+// waitLogPattern waits for a line of the logs of the container to match
+// pattern.
+func waitLogPattern(ctx context.Context, dockerCli *command.DockerCli, containerID, desc string, pattern *regexp.Regexp) error {
+	client := dockerCli.Client()
+
+	c, err := client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	}
+	responseBody, err := client.ContainerLogs(ctx, containerID, options)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	logs := io.Reader(responseBody)
+	if !c.Config.Tty {
+		r, w := io.Pipe()
+		defer r.Close()
+		go func() {
+			_, err := stdcopy.StdCopy(w, w, responseBody)
+			w.CloseWithError(err)
+		}()
+		logs = r
+	}
+
+	matched, err := matchLine(logs, pattern)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return fmt.Errorf("Error: %s exited before being ready", desc)
+	}
+	return nil
+}
+
+// matchLine reads r until one of its lines matches pattern, and reports
+// whether one did.
+func matchLine(r io.Reader, pattern *regexp.Regexp) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if pattern.Match(scanner.Bytes()) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,26 @@
+package container
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestMatchLine(t *testing.T) {
+	pattern := regexp.MustCompile(`^ready to accept connections on port \d+$`)
+
+	matched, err := matchLine(strings.NewReader("starting\nready to accept connections on port 5432\nmore\n"), pattern)
+	assert.NilError(t, err)
+	assert.Equal(t, matched, true)
+
+	matched, err = matchLine(strings.NewReader("starting\nnot ready to accept connections on port 5432\n"), pattern)
+	assert.NilError(t, err)
+	assert.Equal(t, matched, false)
+
+	// The last line may not be terminated.
+	matched, err = matchLine(strings.NewReader("starting\nready to accept connections on port 5432"), pattern)
+	assert.NilError(t, err)
+	assert.Equal(t, matched, true)
+}
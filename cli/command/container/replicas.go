@@ -0,0 +1,114 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/cli/command"
+	opttypes "github.com/docker/docker/opts"
+	"github.com/spf13/pflag"
+)
+
+// runReplicas runs opts.replicas containers in the background, named
+// <name>-1 to <name>-<replicas>, from the same configuration. The replicas
+// are labelled with the name of their group, so that they can be listed with
+// the group filter and removed together with `docker rm --group`. If one of
+// them fails to be created or started, the replicas already created are
+// removed.
+func runReplicas(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *runOptions, config *container.Config, hostConfig *container.HostConfig, networkingConfig *networktypes.NetworkingConfig, createOpts types.ContainerCreateOptions) error {
+	stderr := dockerCli.Err()
+	cmdPath := "run"
+
+	if opts.replicas < 1 {
+		return fmt.Errorf("Invalid number of replicas: %d", opts.replicas)
+	}
+	if opts.name == "" {
+		return fmt.Errorf("--replicas requires --name to name the replicas")
+	}
+	if fl := flags.Lookup("attach"); fl != nil && fl.Value.(*opttypes.ListOpts).Len() != 0 {
+		return fmt.Errorf("Conflicting options: -a and --replicas")
+	}
+	if hostConfig.ContainerIDFile != "" {
+		return fmt.Errorf("Conflicting options: --cidfile and --replicas")
+	}
+
+	// The replicas run in the background.
+	config.AttachStdin = false
+	config.AttachStdout = false
+	config.AttachStderr = false
+	config.StdinOnce = false
+
+	ctx := context.Background()
+	client := dockerCli.Client()
+
+	var ids []string
+	removeReplicas := func() {
+		for _, id := range ids {
+			if err := client.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
+				fmt.Fprintf(stderr, "Error removing replica %s: %v\n", id, err)
+			}
+		}
+	}
+
+	for i := 1; i <= opts.replicas; i++ {
+		replicaConfig := *config
+		replicaConfig.Labels = make(map[string]string, len(config.Labels)+2)
+		for k, v := range config.Labels {
+			replicaConfig.Labels[k] = v
+		}
+		replicaConfig.Labels[types.ContainerGroupLabel] = opts.name
+		replicaConfig.Labels[types.ContainerReplicaLabel] = strconv.Itoa(i)
+
+		createResponse, err := createContainer(ctx, dockerCli, &replicaConfig, hostConfig, networkingConfig, createOpts, "", replicaName(opts.name, i))
+		if err != nil {
+			removeReplicas()
+			reportError(stderr, cmdPath, err.Error(), true)
+			return runStartContainerErr(err)
+		}
+		ids = append(ids, createResponse.ID)
+
+		if err := client.ContainerStart(ctx, createResponse.ID, types.ContainerStartOptions{}); err != nil {
+			removeReplicas()
+			reportError(stderr, cmdPath, err.Error(), false)
+			return runStartContainerErr(err)
+		}
+	}
+
+	for _, id := range ids {
+		fmt.Fprintf(dockerCli.Out(), "%s\n", id)
+	}
+	return nil
+}
+
+// replicaName returns the name of the replica of the given index.
+func replicaName(group string, index int) string {
+	return fmt.Sprintf("%s-%d", group, index)
+}
+
+// groupContainers returns the IDs of the replicas of the group.
+func groupContainers(ctx context.Context, dockerCli *command.DockerCli, group string) ([]string, error) {
+	groupFilter := filters.NewArgs()
+	groupFilter.Add("group", group)
+	containers, err := dockerCli.Client().ContainerList(ctx, types.ContainerListOptions{
+		All:    true,
+		Filter: groupFilter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("No such group: %s", group)
+	}
+
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
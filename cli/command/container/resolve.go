@@ -0,0 +1,54 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewResolveCommand creates a new cobra.Command for `docker container resolve`
+func NewResolveCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resolve CONTAINER NAME",
+		Short: "Resolve a host name as a running container would",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResolve(dockerCli, args[0], args[1])
+		},
+	}
+}
+
+// runResolve prints the lookups made to resolve the name in the container.
+// A name which does not resolve makes the command exit with status 1.
+func runResolve(dockerCli *command.DockerCli, container, name string) error {
+	resp, err := dockerCli.Client().ContainerResolve(context.Background(), container, name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSOURCE\tADDRESSES")
+	for _, attempt := range resp.Attempts {
+		result := strings.Join(attempt.Addresses, ", ")
+		switch {
+		case attempt.Error != "":
+			result = "error: " + attempt.Error
+		case result == "":
+			result = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", attempt.Name, attempt.Source, result)
+	}
+	w.Flush()
+
+	if len(resp.Addresses) == 0 {
+		fmt.Fprintf(dockerCli.Err(), "%s does not resolve in %s\n", name, container)
+		return cli.StatusError{StatusCode: 1}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package container
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type restoreOptions struct {
+	container string
+	input     string
+}
+
+// NewRestoreCommand creates a new `docker container restore` command
+func NewRestoreCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts restoreOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore [OPTIONS] CONTAINER",
+		Short: "Replay a backup onto a stopped container's filesystem",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			return runRestore(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+
+	return cmd
+}
+
+func runRestore(dockerCli *command.DockerCli, opts restoreOptions) error {
+	var input io.Reader = dockerCli.In()
+	if opts.input != "" {
+		file, err := os.Open(opts.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	return dockerCli.Client().ContainerRestore(context.Background(), opts.container, input)
+}
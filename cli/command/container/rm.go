@@ -16,6 +16,7 @@ type rmOptions struct {
 	rmVolumes bool
 	rmLink    bool
 	force     bool
+	group     string
 
 	containers []string
 }
@@ -27,8 +28,12 @@ func NewRmCommand(dockerCli *command.DockerCli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rm [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Remove one or more containers",
-		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.group == "" {
+				if err := cli.RequiresMinArgs(1)(cmd, args); err != nil {
+					return err
+				}
+			}
 			opts.containers = args
 			return runRm(dockerCli, &opts)
 		},
@@ -38,12 +43,21 @@ func NewRmCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVarP(&opts.rmVolumes, "volumes", "v", false, "Remove the volumes associated with the container")
 	flags.BoolVarP(&opts.rmLink, "link", "l", false, "Remove the specified link")
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force the removal of a running container (uses SIGKILL)")
+	flags.StringVar(&opts.group, "group", "", "Remove the replicas of a group created with run --replicas")
 	return cmd
 }
 
 func runRm(dockerCli *command.DockerCli, opts *rmOptions) error {
 	ctx := context.Background()
 
+	if opts.group != "" {
+		ids, err := groupContainers(ctx, dockerCli, opts.group)
+		if err != nil {
+			return err
+		}
+		opts.containers = append(opts.containers, ids...)
+	}
+
 	var errs []string
 	for _, name := range opts.containers {
 		if name == "" {
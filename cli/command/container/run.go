@@ -5,9 +5,11 @@ import (
 	"io"
 	"net/http/httputil"
 	"os"
+	"regexp"
 	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -18,6 +20,7 @@ import (
 	opttypes "github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/promise"
 	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/stringid"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/libnetwork/resolvconf/dns"
 	"github.com/spf13/cobra"
@@ -29,6 +32,12 @@ type runOptions struct {
 	sigProxy   bool
 	name       string
 	detachKeys string
+	replicas   int
+	checkPorts bool
+
+	waitReady        bool
+	waitReadyPattern string
+	waitReadyTimeout time.Duration
 }
 
 // NewRunCommand create a new `docker run` command
@@ -57,6 +66,11 @@ func NewRunCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVar(&opts.sigProxy, "sig-proxy", true, "Proxy received signals to the process")
 	flags.StringVar(&opts.name, "name", "", "Assign a name to the container")
 	flags.StringVar(&opts.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flags.IntVar(&opts.replicas, "replicas", 1, "Number of containers to run in the background, named after --name")
+	flags.BoolVar(&opts.checkPorts, "check-ports", false, "Only check that the published ports can be allocated, without running the container")
+	flags.BoolVar(&opts.waitReady, "wait-ready", false, "Wait for the detached container to be healthy before returning")
+	flags.StringVar(&opts.waitReadyPattern, "wait-ready-pattern", "", "Wait for a line of the logs of the detached container to match a regular expression before returning")
+	flags.DurationVar(&opts.waitReadyTimeout, "wait-ready-timeout", time.Minute, "Maximum time to wait for the container to be ready (0 to wait indefinitely)")
 
 	// Add an explicit help that doesn't have a `-h` to prevent the conflict
 	// with hostname
@@ -77,6 +91,8 @@ func runRun(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *runOptions
 		flAttach                              *opttypes.ListOpts
 		ErrConflictAttachDetach               = fmt.Errorf("Conflicting options: -a and -d")
 		ErrConflictRestartPolicyAndAutoRemove = fmt.Errorf("Conflicting options: --restart and --rm")
+		ErrConflictWaitReadyAndAttach         = fmt.Errorf("Conflicting options: --wait-ready requires -d")
+		ErrConflictWaitReadyAndReplicas       = fmt.Errorf("Conflicting options: --wait-ready and --replicas")
 	)
 
 	config, hostConfig, networkingConfig, err := runconfigopts.Parse(flags, copts)
@@ -90,9 +106,6 @@ func runRun(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *runOptions
 	if hostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
 		return ErrConflictRestartPolicyAndAutoRemove
 	}
-	if hostConfig.OomKillDisable != nil && *hostConfig.OomKillDisable && hostConfig.Memory == 0 {
-		fmt.Fprintf(stderr, "WARNING: Disabling the OOM killer on containers without setting a '-m/--memory' limit may be dangerous.\n")
-	}
 
 	if len(hostConfig.DNS) > 0 {
 		// check the DNS settings passed via --dns against
@@ -108,6 +121,31 @@ func runRun(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *runOptions
 
 	config.ArgsEscaped = false
 
+	var readyPattern *regexp.Regexp
+	if opts.waitReadyPattern != "" {
+		readyPattern, err = regexp.Compile(opts.waitReadyPattern)
+		if err != nil {
+			return fmt.Errorf("invalid --wait-ready-pattern: %v", err)
+		}
+		opts.waitReady = true
+	}
+	if opts.waitReady {
+		if !opts.detach {
+			return ErrConflictWaitReadyAndAttach
+		}
+		if flags.Changed("replicas") {
+			return ErrConflictWaitReadyAndReplicas
+		}
+	}
+
+	if opts.checkPorts {
+		return runPortsCheck(dockerCli, hostConfig)
+	}
+
+	if flags.Changed("replicas") {
+		return runReplicas(dockerCli, flags, opts, config, hostConfig, networkingConfig, copts.CreateOptions())
+	}
+
 	if !opts.detach {
 		if err := dockerCli.In().CheckTty(config.AttachStdin, config.Tty); err != nil {
 			return err
@@ -140,7 +178,7 @@ func runRun(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *runOptions
 
 	ctx, cancelFun := context.WithCancel(context.Background())
 
-	createResponse, err := createContainer(ctx, dockerCli, config, hostConfig, networkingConfig, hostConfig.ContainerIDFile, opts.name)
+	createResponse, err := createContainer(ctx, dockerCli, config, hostConfig, networkingConfig, copts.CreateOptions(), hostConfig.ContainerIDFile, opts.name)
 	if err != nil {
 		reportError(stderr, cmdPath, err.Error(), true)
 		return runStartContainerErr(err)
@@ -248,6 +286,12 @@ func runRun(dockerCli *command.DockerCli, flags *pflag.FlagSet, opts *runOptions
 	if !config.AttachStdout && !config.AttachStderr {
 		// Detached mode
 		<-waitDisplayID
+		if opts.waitReady {
+			desc := "container " + stringid.TruncateID(createResponse.ID)
+			if err := waitReady(context.Background(), dockerCli, createResponse.ID, desc, readyPattern, opts.waitReadyTimeout); err != nil {
+				return cli.StatusError{Status: err.Error(), StatusCode: 1}
+			}
+		}
 		return nil
 	}
 
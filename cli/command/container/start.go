@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http/httputil"
 	"strings"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/pkg/promise"
 	"github.com/docker/docker/pkg/signal"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +23,7 @@ type startOptions struct {
 	openStdin  bool
 	detachKeys string
 	checkpoint string
+	withDeps   bool
 
 	containers []string
 }
@@ -43,6 +46,7 @@ func NewStartCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVarP(&opts.attach, "attach", "a", false, "Attach STDOUT/STDERR and forward signals")
 	flags.BoolVarP(&opts.openStdin, "interactive", "i", false, "Attach container's STDIN")
 	flags.StringVar(&opts.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flags.BoolVar(&opts.withDeps, "with-deps", false, "Start the containers the container depends on first")
 
 	addExperimentalStartFlags(flags, &opts)
 
@@ -52,6 +56,15 @@ func NewStartCommand(dockerCli *command.DockerCli) *cobra.Command {
 func runStart(dockerCli *command.DockerCli, opts *startOptions) error {
 	ctx, cancelFun := context.WithCancel(context.Background())
 
+	if opts.withDeps {
+		started := make(map[string]bool)
+		for _, container := range opts.containers {
+			if err := startDependencies(ctx, dockerCli, container, started, nil); err != nil {
+				return err
+			}
+		}
+	}
+
 	if opts.attach || opts.openStdin {
 		// We're going to attach to a container.
 		// 1. Ensure we only have one container.
@@ -172,3 +185,76 @@ func startContainersWithoutAttachments(dockerCli *command.DockerCli, ctx context
 	}
 	return nil
 }
+
+// startDependencies starts the dependencies of the container, after their own
+// dependencies, and waits for each of them to meet its condition. The path
+// holds the names of the containers that depend on the container, to detect
+// dependency cycles.
+func startDependencies(ctx context.Context, dockerCli *command.DockerCli, container string, started map[string]bool, path []string) error {
+	c, err := dockerCli.Client().ContainerInspect(ctx, container)
+	if err != nil {
+		return err
+	}
+	path = append(path, strings.TrimPrefix(c.Name, "/"))
+
+	for _, d := range c.HostConfig.DependsOn {
+		name, condition, err := runconfigopts.ParseDependsOn(d)
+		if err != nil {
+			return err
+		}
+		dep, err := dockerCli.Client().ContainerInspect(ctx, name)
+		if err != nil {
+			return err
+		}
+		depName := strings.TrimPrefix(dep.Name, "/")
+		for _, p := range path {
+			if p == depName {
+				return fmt.Errorf("Dependency cycle: %s -> %s", strings.Join(path, " -> "), depName)
+			}
+		}
+		if started[dep.ID] {
+			continue
+		}
+
+		if err := startDependencies(ctx, dockerCli, dep.ID, started, path); err != nil {
+			return err
+		}
+		if !dep.State.Running {
+			if err := dockerCli.Client().ContainerStart(ctx, dep.ID, types.ContainerStartOptions{}); err != nil {
+				return err
+			}
+			fmt.Fprintf(dockerCli.Out(), "%s\n", depName)
+		}
+		if condition == runconfigopts.DependencyHealthy {
+			if err := waitHealthy(ctx, dockerCli, dep.ID, "dependency "+depName); err != nil {
+				return err
+			}
+		}
+		started[dep.ID] = true
+	}
+	return nil
+}
+
+// waitHealthy waits for the container to be healthy. The errors refer to
+// the container as desc.
+func waitHealthy(ctx context.Context, dockerCli *command.DockerCli, id, desc string) error {
+	for {
+		c, err := dockerCli.Client().ContainerInspect(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !c.State.Running {
+			return fmt.Errorf("Error: %s is not running", desc)
+		}
+		if c.State.Health == nil {
+			return fmt.Errorf("Error: %s has no health check", desc)
+		}
+		switch c.State.Health.Status {
+		case types.Healthy:
+			return nil
+		case types.Unhealthy:
+			return fmt.Errorf("Error: %s is unhealthy", desc)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
@@ -70,6 +70,11 @@ func collect(s *formatter.ContainerStats, ctx context.Context, cli client.APICli
 		}
 	}()
 
+	if !streamStats {
+		collectSummary(s, ctx, cli)
+		return
+	}
+
 	response, err := cli.ContainerStats(ctx, s.Name, streamStats)
 	if err != nil {
 		s.Mu.Lock()
@@ -181,6 +186,33 @@ func collect(s *formatter.ContainerStats, ctx context.Context, cli client.APICli
 	}
 }
 
+// collectSummary fetches a single stats sample for which the daemon already
+// computed the CPU percentage.
+func collectSummary(s *formatter.ContainerStats, ctx context.Context, cli client.APIClient) {
+	summary, err := cli.ContainerStatsSummary(ctx, s.Name)
+
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	if err != nil {
+		s.Err = err
+		return
+	}
+	s.Err = nil
+
+	daemonOSType = summary.OSType
+	s.CPUPercentage = summary.CPUPercentage
+	s.Memory = float64(summary.MemoryUsage)
+	s.NetworkRx = float64(summary.NetworkRx)
+	s.NetworkTx = float64(summary.NetworkTx)
+	s.BlockRead = float64(summary.BlockRead)
+	s.BlockWrite = float64(summary.BlockWrite)
+	if daemonOSType != "windows" {
+		s.MemoryLimit = float64(summary.MemoryLimit)
+		s.MemoryPercentage = summary.MemoryPercentage
+		s.PidsCurrent = summary.PidsCurrent
+	}
+}
+
 func calculateCPUPercentUnix(previousCPU, previousSystem uint64, v *types.StatsJSON) float64 {
 	var (
 		cpuPercent = 0.0
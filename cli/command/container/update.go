@@ -9,6 +9,7 @@ import (
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
@@ -21,11 +22,16 @@ type updateOptions struct {
 	cpusetCpus        string
 	cpusetMems        string
 	cpuShares         int64
+	cpus              opts.NanoCPUs
 	memoryString      string
 	memoryReservation string
 	memorySwap        string
 	kernelMemory      string
 	restartPolicy     string
+	labelsAdd         opts.ListOpts
+	labelsRemove      opts.ListOpts
+	hostsAdd          opts.ListOpts
+	hostsRemove       opts.ListOpts
 
 	nFlag int
 
@@ -34,7 +40,12 @@ type updateOptions struct {
 
 // NewUpdateCommand creates a new cobra.Command for `docker update`
 func NewUpdateCommand(dockerCli *command.DockerCli) *cobra.Command {
-	var opts updateOptions
+	opts := updateOptions{
+		labelsAdd:    opts.NewListOpts(runconfigopts.ValidateEnv),
+		labelsRemove: opts.NewListOpts(nil),
+		hostsAdd:     opts.NewListOpts(runconfigopts.ValidateExtraHost),
+		hostsRemove:  opts.NewListOpts(nil),
+	}
 
 	cmd := &cobra.Command{
 		Use:   "update [OPTIONS] CONTAINER [CONTAINER...]",
@@ -54,11 +65,16 @@ func NewUpdateCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.StringVar(&opts.cpusetCpus, "cpuset-cpus", "", "CPUs in which to allow execution (0-3, 0,1)")
 	flags.StringVar(&opts.cpusetMems, "cpuset-mems", "", "MEMs in which to allow execution (0-3, 0,1)")
 	flags.Int64VarP(&opts.cpuShares, "cpu-shares", "c", 0, "CPU shares (relative weight)")
+	flags.Var(&opts.cpus, "cpus", "Number of CPUs")
 	flags.StringVarP(&opts.memoryString, "memory", "m", "", "Memory limit")
 	flags.StringVar(&opts.memoryReservation, "memory-reservation", "", "Memory soft limit")
 	flags.StringVar(&opts.memorySwap, "memory-swap", "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flags.StringVar(&opts.kernelMemory, "kernel-memory", "", "Kernel memory limit")
 	flags.StringVar(&opts.restartPolicy, "restart", "", "Restart policy to apply when a container exits")
+	flags.Var(&opts.labelsAdd, "label-add", "Add or update a label")
+	flags.Var(&opts.labelsRemove, "label-rm", "Remove a label by its key")
+	flags.Var(&opts.hostsAdd, "add-host", "Add or replace a custom host-to-IP mapping (host:ip)")
+	flags.Var(&opts.hostsRemove, "rm-host", "Remove the custom host-to-IP mappings of a host")
 
 	return cmd
 }
@@ -125,11 +141,18 @@ func runUpdate(dockerCli *command.DockerCli, opts *updateOptions) error {
 		KernelMemory:      kernelMemory,
 		CPUPeriod:         opts.cpuPeriod,
 		CPUQuota:          opts.cpuQuota,
+		NanoCPUs:          opts.cpus.Value(),
 	}
 
 	updateConfig := containertypes.UpdateConfig{
-		Resources:     resources,
-		RestartPolicy: restartPolicy,
+		Resources:        resources,
+		RestartPolicy:    restartPolicy,
+		LabelsRemove:     opts.labelsRemove.GetAll(),
+		ExtraHostsAdd:    opts.hostsAdd.GetAll(),
+		ExtraHostsRemove: opts.hostsRemove.GetAll(),
+	}
+	if opts.labelsAdd.Len() > 0 {
+		updateConfig.LabelsAdd = runconfigopts.ConvertKVStringsToMap(opts.labelsAdd.GetAll())
 	}
 
 	ctx := context.Background()
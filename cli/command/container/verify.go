@@ -0,0 +1,45 @@
+package container
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewVerifyCommand creates a new cobra.Command for `docker container verify`
+func NewVerifyCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify CONTAINER",
+		Short: "Compare the configuration of a running container with its state",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(dockerCli, args[0])
+		},
+	}
+}
+
+// runVerify prints the drifts between the configuration of the container
+// and the state of the kernel. They make the command exit with status 1.
+func runVerify(dockerCli *command.DockerCli, container string) error {
+	report, err := dockerCli.Client().ContainerVerify(context.Background(), container)
+	if err != nil {
+		return err
+	}
+	if len(report.Drifts) == 0 {
+		fmt.Fprintln(dockerCli.Out(), "No drift found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tRESOURCE\tEXPECTED\tACTUAL")
+	for _, drift := range report.Drifts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", drift.Type, drift.Resource, drift.Expected, drift.Actual)
+	}
+	w.Flush()
+	return cli.StatusError{StatusCode: 1}
+}
@@ -3,15 +3,19 @@ package container
 import (
 	"fmt"
 	"strings"
+	"text/template"
 
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/utils/templates"
 	"github.com/spf13/cobra"
 )
 
 type waitOptions struct {
+	format string
+
 	containers []string
 }
 
@@ -20,7 +24,7 @@ func NewWaitCommand(dockerCli *command.DockerCli) *cobra.Command {
 	var opts waitOptions
 
 	cmd := &cobra.Command{
-		Use:   "wait CONTAINER [CONTAINER...]",
+		Use:   "wait [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Block until one or more containers stop, then print their exit codes",
 		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -28,20 +32,41 @@ func NewWaitCommand(dockerCli *command.DockerCli) *cobra.Command {
 			return runWait(dockerCli, &opts)
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format how the containers exited using the given Go template")
 	return cmd
 }
 
 func runWait(dockerCli *command.DockerCli, opts *waitOptions) error {
 	ctx := context.Background()
 
+	var tmpl *template.Template
+	if opts.format != "" {
+		var err error
+		tmpl, err = templates.Parse(opts.format)
+		if err != nil {
+			return cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+	}
+
 	var errs []string
 	for _, container := range opts.containers {
-		status, err := dockerCli.Client().ContainerWait(ctx, container)
+		res, err := dockerCli.Client().ContainerWaitExit(ctx, container)
 		if err != nil {
 			errs = append(errs, err.Error())
-		} else {
-			fmt.Fprintf(dockerCli.Out(), "%d\n", status)
+			continue
+		}
+		if tmpl == nil {
+			fmt.Fprintf(dockerCli.Out(), "%d\n", res.StatusCode)
+			continue
+		}
+		if err := tmpl.Execute(dockerCli.Out(), res); err != nil {
+			errs = append(errs, err.Error())
+			continue
 		}
+		fmt.Fprintln(dockerCli.Out())
 	}
 	if len(errs) > 0 {
 		return fmt.Errorf("%s", strings.Join(errs, "\n"))
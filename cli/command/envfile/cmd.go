@@ -0,0 +1,43 @@
+package envfile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+)
+
+// NewEnvFileCommand returns a cobra command for `envfile` subcommands
+func NewEnvFileCommand(dockerCli *command.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "envfile COMMAND",
+		Short: "Manage environment files",
+		Long:  envFileDescription,
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(dockerCli.Err(), "\n%s", cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		newCreateCommand(dockerCli),
+		newInspectCommand(dockerCli),
+		newListCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+	)
+	return cmd
+}
+
+var envFileDescription = `
+The **docker envfile** command has subcommands for managing environment
+files. An environment file is a set of environment variables stored by the
+daemon, apart from the configuration of the containers. The containers created
+with **--env-file-id** get its variables, without their values being part of
+their configuration.
+
+To see help for a subcommand, use:
+
+    docker envfile CMD help
+
+`
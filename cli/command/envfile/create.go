@@ -0,0 +1,64 @@
+package envfile
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	name string
+	file string
+}
+
+func newCreateCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts createOptions
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] FILE",
+		Short: "Store an environment file in the daemon",
+		Long:  createDescription,
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.file = args[0]
+			return runCreate(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.name, "name", "", "Assign a name to the environment file")
+
+	return cmd
+}
+
+func runCreate(dockerCli *command.DockerCli, opts createOptions) error {
+	env, err := runconfigopts.ParseEnvFile(opts.file)
+	if err != nil {
+		return err
+	}
+
+	f, err := dockerCli.Client().EnvFileCreate(context.Background(), types.EnvFileCreateRequest{
+		Name: opts.name,
+		Env:  env,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "%s\n", f.ID)
+	return nil
+}
+
+var createDescription = `
+Reads the environment variables of FILE, in the format of --env-file, and
+stores them in the daemon. The containers created with --env-file-id get the
+variables, which are not written in their configuration.
+
+The values of the variables are only returned by "docker envfile inspect
+--reveal".
+`
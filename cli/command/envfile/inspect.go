@@ -0,0 +1,48 @@
+package envfile
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/inspect"
+	"github.com/spf13/cobra"
+)
+
+type inspectOptions struct {
+	format string
+	reveal bool
+	names  []string
+}
+
+func newInspectCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts inspectOptions
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] ENVFILE [ENVFILE...]",
+		Short: "Display detailed information on one or more environment files",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.names = args
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given go template")
+	flags.BoolVar(&opts.reveal, "reveal", false, "Show the values of the variables")
+
+	return cmd
+}
+
+func runInspect(dockerCli *command.DockerCli, opts inspectOptions) error {
+	client := dockerCli.Client()
+
+	ctx := context.Background()
+
+	getEnvFileFunc := func(name string) (interface{}, []byte, error) {
+		return client.EnvFileInspectWithRaw(ctx, name, opts.reveal)
+	}
+
+	return inspect.Inspect(dockerCli.Out(), opts.names, opts.format, getEnvFileFunc)
+}
@@ -0,0 +1,62 @@
+package envfile
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	quiet bool
+}
+
+func newListCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List environment files",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display environment file IDs")
+
+	return cmd
+}
+
+func runList(dockerCli *command.DockerCli, opts listOptions) error {
+	files, err := dockerCli.Client().EnvFileList(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if opts.quiet {
+		for _, f := range files {
+			fmt.Fprintf(dockerCli.Out(), "%s\n", f.ID)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "ID\tNAME\tCREATED\tKEYS\n")
+	for _, f := range files {
+		created := units.HumanDuration(time.Now().UTC().Sub(f.Created)) + " ago"
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", stringid.TruncateID(f.ID), f.Name, created, strings.Join(f.Keys, ","))
+	}
+	w.Flush()
+	return nil
+}
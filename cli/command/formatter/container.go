@@ -156,6 +156,26 @@ func (c *containerContext) Size() string {
 	return sf
 }
 
+func (c *containerContext) NetIO() string {
+	c.AddHeader(netIOHeader)
+	if c.c.Resources == nil {
+		return "--"
+	}
+	rx := units.HumanSizeWithPrecision(float64(c.c.Resources.NetworkRxBytes), 3)
+	tx := units.HumanSizeWithPrecision(float64(c.c.Resources.NetworkTxBytes), 3)
+	return fmt.Sprintf("%s / %s", rx, tx)
+}
+
+func (c *containerContext) BlockIO() string {
+	c.AddHeader(blockIOHeader)
+	if c.c.Resources == nil {
+		return "--"
+	}
+	read := units.HumanSizeWithPrecision(float64(c.c.Resources.BlkioReadBytes), 3)
+	write := units.HumanSizeWithPrecision(float64(c.c.Resources.BlkioWriteBytes), 3)
+	return fmt.Sprintf("%s / %s", read, write)
+}
+
 func (c *containerContext) Labels() string {
 	c.AddHeader(labelsHeader)
 	if c.c.Labels == nil {
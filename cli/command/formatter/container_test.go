@@ -55,6 +55,10 @@ func TestContainerPsContext(t *testing.T) {
 		{types.Container{Status: "RUNNING"}, true, "RUNNING", statusHeader, ctx.Status},
 		{types.Container{SizeRw: 10}, true, "10 B", sizeHeader, ctx.Size},
 		{types.Container{SizeRw: 10, SizeRootFs: 20}, true, "10 B (virtual 20 B)", sizeHeader, ctx.Size},
+		{types.Container{}, true, "--", netIOHeader, ctx.NetIO},
+		{types.Container{Resources: &types.ContainerResourceUsage{NetworkRxBytes: 10, NetworkTxBytes: 20}}, true, "10 B / 20 B", netIOHeader, ctx.NetIO},
+		{types.Container{}, true, "--", blockIOHeader, ctx.BlockIO},
+		{types.Container{Resources: &types.ContainerResourceUsage{BlkioReadBytes: 30, BlkioWriteBytes: 40}}, true, "30 B / 40 B", blockIOHeader, ctx.BlockIO},
 		{types.Container{}, true, "", labelsHeader, ctx.Labels},
 		{types.Container{Labels: map[string]string{"cpu": "6", "storage": "ssd"}}, true, "cpu=6,storage=ssd", labelsHeader, ctx.Labels},
 		{types.Container{Created: unix}, true, "About a minute", runningForHeader, ctx.RunningFor},
@@ -18,12 +18,30 @@ const (
 	repositoryHeader = "REPOSITORY"
 	tagHeader        = "TAG"
 	digestHeader     = "DIGEST"
+
+	imageTrustTableColumns = "\t{{.Signed}}\t{{.SignedBy}}\t{{.SignedDigest}}"
+
+	signedHeader       = "SIGNED"
+	signedByHeader     = "SIGNER ROLE"
+	signedDigestHeader = "SIGNED DIGEST"
 )
 
 // ImageContext contains image specific information required by the formater, encapsulate a Context struct.
 type ImageContext struct {
 	Context
 	Digest bool
+	// TrustData holds the trust data of the tags, keyed by repository:tag.
+	TrustData map[string]ImageTrust
+}
+
+// ImageTrust contains the trust data of a tag.
+type ImageTrust struct {
+	// Signed is set if the tag has valid trust data.
+	Signed bool
+	// Role is the role which signed the tag.
+	Role string
+	// Digest is the signed digest of the tag.
+	Digest string
 }
 
 func isDangling(image types.Image) bool {
@@ -71,6 +89,25 @@ virtual_size: {{.Size}}
 	return format
 }
 
+// NewImageTrustFormat returns a format for rendering an ImageContext with the
+// trust data of the tags.
+func NewImageTrustFormat(source string, quiet bool, digest bool) Format {
+	format := NewImageFormat(source, quiet, digest)
+	if quiet && (source == TableFormatKey || source == RawFormatKey) {
+		return format
+	}
+	switch {
+	case source == RawFormatKey:
+		format += `signed: {{.Signed}}
+signer_role: {{.SignedBy}}
+signed_digest: {{.SignedDigest}}
+`
+	case format.IsTable() && !format.Contains("{{.Signed}}"):
+		format += imageTrustTableColumns
+	}
+	return format
+}
+
 // ImageWrite writes the formatter images using the ImageContext
 func ImageWrite(ctx ImageContext, images []types.Image) error {
 	render := func(format func(subContext subContext) error) error {
@@ -173,6 +210,7 @@ func imageFormat(ctx ImageContext, images []types.Image, format func(subContext
 			}
 		}
 		for _, imageCtx := range images {
+			imageCtx.trust = ctx.TrustData[imageCtx.repo+":"+imageCtx.tag]
 			if err := format(imageCtx); err != nil {
 				return err
 			}
@@ -188,6 +226,7 @@ type imageContext struct {
 	repo   string
 	tag    string
 	digest string
+	trust  ImageTrust
 }
 
 func (c *imageContext) ID() string {
@@ -213,6 +252,34 @@ func (c *imageContext) Digest() string {
 	return c.digest
 }
 
+func (c *imageContext) Signed() string {
+	c.AddHeader(signedHeader)
+	switch {
+	case c.tag == "<none>":
+		return "N/A"
+	case c.trust.Signed:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+func (c *imageContext) SignedBy() string {
+	c.AddHeader(signedByHeader)
+	if !c.trust.Signed {
+		return "<none>"
+	}
+	return c.trust.Role
+}
+
+func (c *imageContext) SignedDigest() string {
+	c.AddHeader(signedDigestHeader)
+	if !c.trust.Signed {
+		return "<none>"
+	}
+	return c.trust.Digest
+}
+
 func (c *imageContext) CreatedSince() string {
 	c.AddHeader(createdSinceHeader)
 	createdAt := time.Unix(int64(c.i.Created), 0)
@@ -56,6 +56,30 @@ func TestImageContext(t *testing.T) {
 			i:      types.Image{},
 			digest: "sha256:d149ab53f8718e987c3a3024bb8aa0e2caadf6c0328f1d9d850b2a2a67f2819a",
 		}, "sha256:d149ab53f8718e987c3a3024bb8aa0e2caadf6c0328f1d9d850b2a2a67f2819a", digestHeader, ctx.Digest},
+		{imageContext{
+			i:     types.Image{},
+			tag:   "latest",
+			trust: ImageTrust{Signed: true, Role: "targets/releases"},
+		}, "yes", signedHeader, ctx.Signed},
+		{imageContext{
+			i:   types.Image{},
+			tag: "latest",
+		}, "no", signedHeader, ctx.Signed},
+		{imageContext{
+			i:   types.Image{},
+			tag: "<none>",
+		}, "N/A", signedHeader, ctx.Signed},
+		{imageContext{
+			i:     types.Image{},
+			trust: ImageTrust{Signed: true, Role: "targets/releases"},
+		}, "targets/releases", signedByHeader, ctx.SignedBy},
+		{imageContext{
+			i:     types.Image{},
+			trust: ImageTrust{Signed: true, Digest: "sha256:d149ab53f8718e987c3a3024bb8aa0e2caadf6c0328f1d9d850b2a2a67f2819a"},
+		}, "sha256:d149ab53f8718e987c3a3024bb8aa0e2caadf6c0328f1d9d850b2a2a67f2819a", signedDigestHeader, ctx.SignedDigest},
+		{imageContext{
+			i: types.Image{},
+		}, "<none>", signedDigestHeader, ctx.SignedDigest},
 	}
 
 	for _, c := range cases {
@@ -173,6 +197,29 @@ image               tag2                <none>
 			},
 			"imageID1\nimageID2\nimageID3\n",
 		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: NewImageTrustFormat("table {{.Repository}}\t{{.Tag}}", false, false),
+				},
+				TrustData: map[string]ImageTrust{
+					"image:tag1": {Signed: true, Role: "targets", Digest: "sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf"},
+				},
+			},
+			`REPOSITORY          TAG                 SIGNED              SIGNER ROLE         SIGNED DIGEST
+image               tag1                yes                 targets             sha256:cbbf2f9a99b47fc460d422812b6a5adff7dfee951d8fa2e4a98caa0382cfbdbf
+image               tag2                no                  <none>              <none>
+<none>              <none>              N/A                 <none>              <none>
+`,
+		},
+		{
+			ImageContext{
+				Context: Context{
+					Format: NewImageTrustFormat("table", true, false),
+				},
+			},
+			"imageID1\nimageID2\nimageID3\n",
+		},
 		// Raw Format
 		{
 			ImageContext{
@@ -2,10 +2,13 @@ package formatter
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/stringid"
+	units "github.com/docker/go-units"
 )
 
 const (
@@ -14,6 +17,7 @@ const (
 	networkIDHeader = "NETWORK ID"
 	ipv6Header      = "IPV6"
 	internalHeader  = "INTERNAL"
+	lastUsedHeader  = "LAST USED"
 )
 
 // NewNetworkFormat returns a Format for rendering using a network Context
@@ -86,6 +90,25 @@ func (c *networkContext) Internal() string {
 	return fmt.Sprintf("%v", c.n.Internal)
 }
 
+// Containers returns the number of containers connected to the network.
+func (c *networkContext) Containers() string {
+	c.AddHeader(containersHeader)
+	return strconv.Itoa(c.n.ContainerCount)
+}
+
+// LastUsed returns how long ago the network was last used, or "in use" if
+// containers are connected to it.
+func (c *networkContext) LastUsed() string {
+	c.AddHeader(lastUsedHeader)
+	if c.n.ContainerCount > 0 {
+		return "in use"
+	}
+	if c.n.LastUsed.IsZero() {
+		return ""
+	}
+	return units.HumanDuration(time.Now().UTC().Sub(c.n.LastUsed)) + " ago"
+}
+
 func (c *networkContext) Labels() string {
 	c.AddHeader(labelsHeader)
 	if c.n.Labels == nil {
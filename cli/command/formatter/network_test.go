@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/stringid"
@@ -46,6 +47,18 @@ func TestNetworkContext(t *testing.T) {
 		{networkContext{
 			n: types.NetworkResource{Internal: false},
 		}, "false", internalHeader, ctx.Internal},
+		{networkContext{
+			n: types.NetworkResource{ContainerCount: 2},
+		}, "2", containersHeader, ctx.Containers},
+		{networkContext{
+			n: types.NetworkResource{ContainerCount: 1, LastUsed: time.Now().UTC()},
+		}, "in use", lastUsedHeader, ctx.LastUsed},
+		{networkContext{
+			n: types.NetworkResource{LastUsed: time.Now().UTC().Add(-2 * time.Hour)},
+		}, "2 hours ago", lastUsedHeader, ctx.LastUsed},
+		{networkContext{
+			n: types.NetworkResource{},
+		}, "", lastUsedHeader, ctx.LastUsed},
 		{networkContext{
 			n: types.NetworkResource{},
 		}, "", labelsHeader, ctx.Labels},
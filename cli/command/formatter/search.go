@@ -0,0 +1,85 @@
+package formatter
+
+import (
+	"strconv"
+	"strings"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/stringutils"
+)
+
+const (
+	defaultSearchTableFormat = "table {{.Name}}\t{{.Description}}\t{{.StarCount}}\t{{.IsOfficial}}\t{{.IsAutomated}}"
+
+	descriptionHeader = "DESCRIPTION"
+	starsHeader       = "STARS"
+	officialHeader    = "OFFICIAL"
+	automatedHeader   = "AUTOMATED"
+)
+
+// NewSearchFormat returns a format for use with a search result Context
+func NewSearchFormat(source string) Format {
+	switch source {
+	case "", TableFormatKey:
+		return defaultSearchTableFormat
+	case RawFormatKey:
+		return `name: {{.Name}}\ndescription: {{.Description}}\nstars: {{.StarCount}}\nofficial: {{.IsOfficial}}\nautomated: {{.IsAutomated}}\n`
+	}
+	return Format(source)
+}
+
+// SearchWrite writes formatted search results using the Context
+func SearchWrite(ctx Context, results []registrytypes.SearchResult) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, result := range results {
+			if err := format(&searchContext{trunc: ctx.Trunc, s: result}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ctx.Write(&searchContext{}, render)
+}
+
+type searchContext struct {
+	HeaderContext
+	trunc bool
+	s     registrytypes.SearchResult
+}
+
+func (c *searchContext) Name() string {
+	c.AddHeader(nameHeader)
+	return c.s.Name
+}
+
+func (c *searchContext) Description() string {
+	c.AddHeader(descriptionHeader)
+	desc := strings.Replace(c.s.Description, "\n", " ", -1)
+	desc = strings.Replace(desc, "\r", " ", -1)
+	if c.trunc {
+		desc = stringutils.Ellipsis(desc, 45)
+	}
+	return desc
+}
+
+func (c *searchContext) StarCount() string {
+	c.AddHeader(starsHeader)
+	return strconv.Itoa(c.s.StarCount)
+}
+
+func (c *searchContext) formatBool(value bool) string {
+	if value {
+		return "[OK]"
+	}
+	return ""
+}
+
+func (c *searchContext) IsOfficial() string {
+	c.AddHeader(officialHeader)
+	return c.formatBool(c.s.IsOfficial)
+}
+
+func (c *searchContext) IsAutomated() string {
+	c.AddHeader(automatedHeader)
+	return c.formatBool(c.s.IsAutomated)
+}
@@ -0,0 +1,126 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestSearchContext(t *testing.T) {
+	longDescription := strings.Repeat("a very long description ", 3)
+
+	var ctx searchContext
+	cases := []struct {
+		searchCtx searchContext
+		expValue  string
+		expHeader string
+		call      func() string
+	}{
+		{searchContext{
+			s: registrytypes.SearchResult{Name: "busybox"},
+		}, "busybox", nameHeader, ctx.Name},
+		{searchContext{
+			s: registrytypes.SearchResult{Description: "line1\nline2"},
+		}, "line1 line2", descriptionHeader, ctx.Description},
+		{searchContext{
+			trunc: true,
+			s:     registrytypes.SearchResult{Description: longDescription},
+		}, longDescription[:42] + "...", descriptionHeader, ctx.Description},
+		{searchContext{
+			s: registrytypes.SearchResult{Description: longDescription},
+		}, longDescription, descriptionHeader, ctx.Description},
+		{searchContext{
+			s: registrytypes.SearchResult{StarCount: 12},
+		}, "12", starsHeader, ctx.StarCount},
+		{searchContext{
+			s: registrytypes.SearchResult{IsOfficial: true},
+		}, "[OK]", officialHeader, ctx.IsOfficial},
+		{searchContext{
+			s: registrytypes.SearchResult{},
+		}, "", automatedHeader, ctx.IsAutomated},
+	}
+
+	for _, c := range cases {
+		ctx = c.searchCtx
+		v := c.call()
+		if v != c.expValue {
+			t.Fatalf("Expected %s, was %s\n", c.expValue, v)
+		}
+
+		h := ctx.FullHeader()
+		if h != c.expHeader {
+			t.Fatalf("Expected %s, was %s\n", c.expHeader, h)
+		}
+	}
+}
+
+func TestSearchContextWrite(t *testing.T) {
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		// Errors
+		{
+			Context{Format: "{{InvalidFunction}}"},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Table format
+		{
+			Context{Format: NewSearchFormat("table")},
+			`NAME                DESCRIPTION          STARS               OFFICIAL            AUTOMATED
+busybox             Busybox base image   823                 [OK]                
+tutum/busybox       Busybox on tutum     12                                      [OK]
+`,
+		},
+		{
+			Context{Format: NewSearchFormat("table {{.Name}}\t{{.StarCount}}")},
+			`NAME                STARS
+busybox             823
+tutum/busybox       12
+`,
+		},
+		// Raw Format
+		{
+			Context{Format: NewSearchFormat("raw")},
+			`name: busybox
+description: Busybox base image
+stars: 823
+official: [OK]
+automated: 
+
+name: tutum/busybox
+description: Busybox on tutum
+stars: 12
+official: 
+automated: [OK]
+
+`,
+		},
+		// Custom Format
+		{
+			Context{Format: NewSearchFormat("{{.Name}}")},
+			`busybox
+tutum/busybox
+`,
+		},
+	}
+
+	for _, testcase := range cases {
+		results := []registrytypes.SearchResult{
+			{Name: "busybox", Description: "Busybox base image", StarCount: 823, IsOfficial: true},
+			{Name: "tutum/busybox", Description: "Busybox on tutum", StarCount: 12, IsAutomated: true},
+		}
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := SearchWrite(testcase.context, results)
+		if err != nil {
+			assert.Error(t, err, testcase.expected)
+		} else {
+			assert.Equal(t, out.String(), testcase.expected)
+		}
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 
 	"golang.org/x/net/context"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/gitutils"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/streamformatter"
@@ -38,6 +41,7 @@ type buildOptions struct {
 	dockerfileName string
 	tags           opts.ListOpts
 	labels         opts.ListOpts
+	annotations    opts.ListOpts
 	buildArgs      opts.ListOpts
 	ulimits        *runconfigopts.UlimitOpt
 	memory         string
@@ -56,16 +60,23 @@ type buildOptions struct {
 	forceRm        bool
 	pull           bool
 	cacheFrom      []string
+	secrets        opts.ListOpts
+	validate       bool
+	fromPolicy     string
+	gitTokenFile   string
+	gitSSHKey      string
 }
 
 // NewBuildCommand creates a new `docker build` command
 func NewBuildCommand(dockerCli *command.DockerCli) *cobra.Command {
 	ulimits := make(map[string]*units.Ulimit)
 	options := buildOptions{
-		tags:      opts.NewListOpts(validateTag),
-		buildArgs: opts.NewListOpts(runconfigopts.ValidateArg),
-		ulimits:   runconfigopts.NewUlimitOpt(&ulimits),
-		labels:    opts.NewListOpts(runconfigopts.ValidateEnv),
+		tags:        opts.NewListOpts(validateTag),
+		buildArgs:   opts.NewListOpts(runconfigopts.ValidateArg),
+		ulimits:     runconfigopts.NewUlimitOpt(&ulimits),
+		labels:      opts.NewListOpts(runconfigopts.ValidateEnv),
+		annotations: opts.NewListOpts(runconfigopts.ValidateEnv),
+		secrets:     opts.NewListOpts(nil),
 	}
 
 	cmd := &cobra.Command{
@@ -95,12 +106,18 @@ func NewBuildCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.StringVar(&options.cgroupParent, "cgroup-parent", "", "Optional parent cgroup for the container")
 	flags.StringVar(&options.isolation, "isolation", "", "Container isolation technology")
 	flags.Var(&options.labels, "label", "Set metadata for an image")
+	flags.Var(&options.annotations, "annotation", "Set an annotation, recorded with the build metadata, for an image")
 	flags.BoolVar(&options.noCache, "no-cache", false, "Do not use cache when building the image")
 	flags.BoolVar(&options.rm, "rm", true, "Remove intermediate containers after a successful build")
 	flags.BoolVar(&options.forceRm, "force-rm", false, "Always remove intermediate containers")
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress the build output and print image ID on success")
 	flags.BoolVar(&options.pull, "pull", false, "Always attempt to pull a newer version of the image")
 	flags.StringSliceVar(&options.cacheFrom, "cache-from", []string{}, "Images to consider as cache sources")
+	flags.Var(&options.secrets, "secret", "Secret file to expose to RUN --mount=type=secret (id=ID,src=PATH)")
+	flags.BoolVar(&options.validate, "validate", false, "Validate the Dockerfile without running the build")
+	flags.StringVar(&options.fromPolicy, "from-policy", "", "Resolution policy of the FROM references (any or pinned)")
+	flags.StringVar(&options.gitTokenFile, "git-token-file", "", "File containing the token to clone a private git repository over HTTPS")
+	flags.StringVar(&options.gitSSHKey, "git-ssh-key", "", "Private key file to clone a private git repository over SSH")
 
 	command.AddTrustedFlags(flags, true)
 
@@ -136,6 +153,7 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		contextDir    string
 		tempDir       string
 		relDockerfile string
+		gitCommit     string
 		progBuff      io.Writer
 		buildBuff     io.Writer
 	)
@@ -151,7 +169,11 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 	case specifiedContext == "-":
 		buildCtx, relDockerfile, err = builder.GetContextFromReader(dockerCli.In(), options.dockerfileName)
 	case urlutil.IsGitURL(specifiedContext):
-		tempDir, relDockerfile, err = builder.GetContextFromGitURL(specifiedContext, options.dockerfileName)
+		var gitOptions gitutils.CloneOptions
+		if gitOptions, err = parseGitCloneOptions(options); err != nil {
+			return err
+		}
+		tempDir, relDockerfile, gitCommit, err = builder.GetContextFromGitURL(specifiedContext, options.dockerfileName, gitOptions)
 	case urlutil.IsURL(specifiedContext):
 		buildCtx, relDockerfile, err = builder.GetContextFromURL(progBuff, specifiedContext, options.dockerfileName)
 	default:
@@ -269,6 +291,16 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		}
 	}
 
+	secrets, err := parseBuildSecrets(options.secrets.GetAll())
+	if err != nil {
+		return err
+	}
+
+	annotations := runconfigopts.ConvertKVStringsToMap(options.annotations.GetAll())
+	if _, ok := annotations[types.BuildGitCommitAnnotation]; !ok && gitCommit != "" {
+		annotations[types.BuildGitCommitAnnotation] = gitCommit
+	}
+
 	authConfig, _ := dockerCli.CredentialsStore().GetAll()
 	buildOptions := types.ImageBuildOptions{
 		Memory:         memory,
@@ -292,7 +324,11 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		BuildArgs:      runconfigopts.ConvertKVStringsToMap(options.buildArgs.GetAll()),
 		AuthConfigs:    authConfig,
 		Labels:         runconfigopts.ConvertKVStringsToMap(options.labels.GetAll()),
+		Annotations:    annotations,
 		CacheFrom:      options.cacheFrom,
+		Secrets:        secrets,
+		Validate:       options.validate,
+		FromPolicy:     options.fromPolicy,
 	}
 
 	response, err := dockerCli.Client().ImageBuild(ctx, body, buildOptions)
@@ -461,3 +497,75 @@ func replaceDockerfileTarWrapper(ctx context.Context, inputTarStream io.ReadClos
 
 	return pipeReader
 }
+
+// maxBuildSecretsSize is the maximum total size of the secrets of a build,
+// which are sent to the daemon in a request header.
+const maxBuildSecretsSize = 500 * 1024
+
+// parseGitCloneOptions reads the credentials of the --git-token-file and
+// --git-ssh-key flags.
+func parseGitCloneOptions(options buildOptions) (gitutils.CloneOptions, error) {
+	var opts gitutils.CloneOptions
+	if options.gitTokenFile != "" {
+		token, err := ioutil.ReadFile(options.gitTokenFile)
+		if err != nil {
+			return opts, fmt.Errorf("unable to read the git token: %v", err)
+		}
+		opts.Token = strings.TrimSpace(string(token))
+	}
+	if options.gitSSHKey != "" {
+		key, err := ioutil.ReadFile(options.gitSSHKey)
+		if err != nil {
+			return opts, fmt.Errorf("unable to read the git SSH key: %v", err)
+		}
+		opts.SSHKey = key
+	}
+	return opts, nil
+}
+
+// parseBuildSecrets reads the secrets of the --secret flags, of the form
+// id=ID,src=PATH. The id defaults to the base name of the source.
+func parseBuildSecrets(specs []string) (map[string][]byte, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	secrets := make(map[string][]byte, len(specs))
+	size := 0
+	for _, spec := range specs {
+		var id, src string
+		for _, field := range strings.Split(spec, ",") {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid secret %q: field '%s' must be a key=value pair", spec, field)
+			}
+			switch strings.ToLower(parts[0]) {
+			case "id":
+				id = parts[1]
+			case "src", "source":
+				src = parts[1]
+			default:
+				return nil, fmt.Errorf("invalid secret %q: unexpected key '%s'", spec, parts[0])
+			}
+		}
+		if src == "" {
+			return nil, fmt.Errorf("invalid secret %q: src is required", spec)
+		}
+		if id == "" {
+			id = filepath.Base(src)
+		}
+		if _, exists := secrets[id]; exists {
+			return nil, fmt.Errorf("duplicate secret id: %s", id)
+		}
+
+		content, err := ioutil.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read secret %s: %v", id, err)
+		}
+		size += len(content)
+		if size > maxBuildSecretsSize {
+			return nil, fmt.Errorf("build secrets exceed the maximum size of %s", units.BytesSize(maxBuildSecretsSize))
+		}
+		secrets[id] = content
+	}
+	return secrets, nil
+}
@@ -32,6 +32,10 @@ func NewImageCommand(dockerCli *command.DockerCli) *cobra.Command {
 		newRemoveCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		NewPruneCommand(dockerCli),
+		newExportLayerCommand(dockerCli),
+		newImportLayerCommand(dockerCli),
+		newFlattenCommand(dockerCli),
+		newVerifyCommand(dockerCli),
 	)
 
 	return cmd
@@ -0,0 +1,59 @@
+package image
+
+import (
+	"errors"
+	"io"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type exportLayerOptions struct {
+	image  string
+	diffID string
+	output string
+}
+
+// newExportLayerCommand creates a new `docker image export-layer` command
+func newExportLayerCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts exportLayerOptions
+
+	cmd := &cobra.Command{
+		Use:   "export-layer [OPTIONS] IMAGE DIFFID",
+		Short: "Export a layer of an image to a tar archive (streamed to STDOUT by default)",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.image = args[0]
+			opts.diffID = args[1]
+			return runExportLayer(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+
+	return cmd
+}
+
+func runExportLayer(dockerCli *command.DockerCli, opts exportLayerOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
+	}
+
+	responseBody, err := dockerCli.Client().ImageLayerExport(context.Background(), opts.image, opts.diffID)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	if opts.output == "" {
+		_, err := io.Copy(dockerCli.Out(), responseBody)
+		return err
+	}
+
+	return command.CopyToFile(opts.output, responseBody)
+}
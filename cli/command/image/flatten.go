@@ -0,0 +1,51 @@
+package image
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type flattenOptions struct {
+	image     string
+	reference string
+}
+
+// newFlattenCommand creates a new `docker image flatten` command
+func newFlattenCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts flattenOptions
+
+	cmd := &cobra.Command{
+		Use:   "flatten IMAGE [REPOSITORY[:TAG]]",
+		Short: "Create a new image with a single layer from the filesystem of an image",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.image = args[0]
+			if len(args) > 1 {
+				opts.reference = args[1]
+			}
+			return runFlatten(dockerCli, opts)
+		},
+	}
+
+	return cmd
+}
+
+func runFlatten(dockerCli *command.DockerCli, opts flattenOptions) error {
+	options := types.ImageFlattenOptions{
+		Ref: opts.reference,
+	}
+
+	response, err := dockerCli.Client().ImageFlatten(context.Background(), opts.image, options)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(dockerCli.Out(), response.ID)
+	return nil
+}
@@ -0,0 +1,73 @@
+package image
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type importLayerOptions struct {
+	image     string
+	source    string
+	reference string
+	message   string
+}
+
+// newImportLayerCommand creates a new `docker image import-layer` command
+func newImportLayerCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts importLayerOptions
+
+	cmd := &cobra.Command{
+		Use:   "import-layer [OPTIONS] IMAGE file|- [REPOSITORY[:TAG]]",
+		Short: "Create a new image by adding a layer from a tarball on top of an image",
+		Args:  cli.RequiresRangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.image = args[0]
+			opts.source = args[1]
+			if len(args) > 2 {
+				opts.reference = args[2]
+			}
+			return runImportLayer(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVarP(&opts.message, "message", "m", "", "Set commit message for the new image")
+
+	return cmd
+}
+
+func runImportLayer(dockerCli *command.DockerCli, opts importLayerOptions) error {
+	var in io.Reader
+	if opts.source == "-" {
+		in = dockerCli.In()
+	} else {
+		file, err := os.Open(opts.source)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	options := types.ImageLayerImportOptions{
+		Ref:     opts.reference,
+		Message: opts.message,
+	}
+
+	response, err := dockerCli.Client().ImageLayerImport(context.Background(), opts.image, in, options)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(dockerCli.Out(), response.ID)
+	return nil
+}
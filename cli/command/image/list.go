@@ -3,11 +3,14 @@ package image
 import (
 	"golang.org/x/net/context"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/formatter"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +21,7 @@ type imagesOptions struct {
 	all         bool
 	noTrunc     bool
 	showDigests bool
+	showTrust   bool
 	format      string
 	filter      opts.FilterOpt
 }
@@ -44,6 +48,7 @@ func NewImagesCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVarP(&opts.all, "all", "a", false, "Show all images (default hides intermediate images)")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Don't truncate output")
 	flags.BoolVar(&opts.showDigests, "digests", false, "Show digests")
+	flags.BoolVar(&opts.showTrust, "trust", false, "Show the trust data of the tags from the local trust cache")
 	flags.StringVar(&opts.format, "format", "", "Pretty-print images using a Go template")
 	flags.VarP(&opts.filter, "filter", "f", "Filter output based on conditions provided")
 
@@ -80,13 +85,57 @@ func runImages(dockerCli *command.DockerCli, opts imagesOptions) error {
 		}
 	}
 
+	imageFormat := formatter.NewImageFormat(format, opts.quiet, opts.showDigests)
+	var trustData map[string]formatter.ImageTrust
+	if opts.showTrust {
+		imageFormat = formatter.NewImageTrustFormat(format, opts.quiet, opts.showDigests)
+		trustData = imagesTrustData(dockerCli, images)
+	}
+
 	imageCtx := formatter.ImageContext{
 		Context: formatter.Context{
 			Output: dockerCli.Out(),
-			Format: formatter.NewImageFormat(format, opts.quiet, opts.showDigests),
+			Format: imageFormat,
 			Trunc:  !opts.noTrunc,
 		},
-		Digest: opts.showDigests,
+		Digest:    opts.showDigests,
+		TrustData: trustData,
 	}
 	return formatter.ImageWrite(imageCtx, images)
 }
+
+// imagesTrustData returns the trust data of the tags of the images, keyed by
+// repository:tag. The trust data of each repository is read once from the
+// local trust cache; the tags of the repositories without valid trust data
+// are not signed.
+func imagesTrustData(dockerCli *command.DockerCli, images []types.Image) map[string]formatter.ImageTrust {
+	repos := make(map[string]reference.Named)
+	for _, image := range images {
+		for _, refString := range image.RepoTags {
+			ref, err := reference.ParseNamed(refString)
+			if err != nil {
+				continue
+			}
+			if _, ok := ref.(reference.NamedTagged); ok {
+				repos[ref.Name()] = ref
+			}
+		}
+	}
+
+	trustData := make(map[string]formatter.ImageTrust)
+	for name, ref := range repos {
+		repoInfo, err := registry.ParseRepositoryInfo(ref)
+		if err != nil {
+			continue
+		}
+		tags, err := cachedTrustData(dockerCli, repoInfo)
+		if err != nil {
+			logrus.Debugf("no trust data for %s: %v", name, err)
+			continue
+		}
+		for tag, trust := range tags {
+			trustData[name+":"+tag] = trust
+		}
+	}
+	return trustData
+}
@@ -8,18 +8,21 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
 	units "github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
 
 type pruneOptions struct {
-	force bool
-	all   bool
+	force  bool
+	all    bool
+	dryRun bool
+	filter opts.FilterOpt
 }
 
 // NewPruneCommand returns a new cobra prune command for images
 func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
-	var opts pruneOptions
+	opts := pruneOptions{filter: opts.NewFilterOpt()}
 
 	cmd := &cobra.Command{
 		Use:   "prune",
@@ -33,7 +36,11 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 			if output != "" {
 				fmt.Fprintln(dockerCli.Out(), output)
 			}
-			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			if opts.dryRun {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+			} else {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			}
 			return nil
 		},
 	}
@@ -41,6 +48,8 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
 	flags.BoolVarP(&opts.all, "all", "a", false, "Remove all unused images, not just dangling ones")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Show the images that would be removed without removing them")
+	flags.Var(&opts.filter, "filter", "Provide filter values (e.g. 'until=<timestamp>')")
 
 	return cmd
 }
@@ -57,24 +66,31 @@ func runPrune(dockerCli *command.DockerCli, opts pruneOptions) (spaceReclaimed u
 	if opts.all {
 		warning = allImageWarning
 	}
-	if !opts.force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+	if !opts.force && !opts.dryRun && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
 		return
 	}
 
 	report, err := dockerCli.Client().ImagesPrune(context.Background(), types.ImagesPruneConfig{
 		DanglingOnly: !opts.all,
+		Filters:      opts.filter.Value(),
+		DryRun:       opts.dryRun,
 	})
 	if err != nil {
 		return
 	}
 
 	if len(report.ImagesDeleted) > 0 {
+		untagged, deleted := "untagged:", "deleted:"
 		output = "Deleted Images:\n"
+		if opts.dryRun {
+			untagged, deleted = "would untag:", "would delete:"
+			output = "Images that would be deleted:\n"
+		}
 		for _, st := range report.ImagesDeleted {
 			if st.Untagged != "" {
-				output += fmt.Sprintln("untagged:", st.Untagged)
+				output += fmt.Sprintln(untagged, st.Untagged)
 			} else {
-				output += fmt.Sprintln("deleted:", st.Deleted)
+				output += fmt.Sprintln(deleted, st.Deleted)
 			}
 		}
 		spaceReclaimed = report.SpaceReclaimed
@@ -3,6 +3,7 @@ package image
 import (
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -11,26 +12,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+type pushOptions struct {
+	remote           string
+	compression      string
+	compressionLevel int
+}
+
 // NewPushCommand creates a new `docker push` command
 func NewPushCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts pushOptions
+
 	cmd := &cobra.Command{
 		Use:   "push [OPTIONS] NAME[:TAG]",
 		Short: "Push an image or a repository to a registry",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPush(dockerCli, args[0])
+			opts.remote = args[0]
+			return runPush(dockerCli, opts)
 		},
 	}
 
 	flags := cmd.Flags()
 
+	flags.StringVar(&opts.compression, "compression", "", "Compression of the layers pushed (gzip or zstd), the default of the daemon if not set")
+	flags.IntVar(&opts.compressionLevel, "compression-level", 0, "Compression level of the layers pushed, 0 for the default level of the compression")
 	command.AddTrustedFlags(flags, true)
 
 	return cmd
 }
 
-func runPush(dockerCli *command.DockerCli, remote string) error {
-	ref, err := reference.ParseNamed(remote)
+func runPush(dockerCli *command.DockerCli, opts pushOptions) error {
+	ref, err := reference.ParseNamed(opts.remote)
 	if err != nil {
 		return err
 	}
@@ -47,11 +59,16 @@ func runPush(dockerCli *command.DockerCli, remote string) error {
 	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "push")
 
+	options := types.ImagePushOptions{
+		Compression:      opts.compression,
+		CompressionLevel: opts.compressionLevel,
+	}
+
 	if command.IsTrusted() {
-		return trustedPush(ctx, dockerCli, repoInfo, ref, authConfig, requestPrivilege)
+		return trustedPush(ctx, dockerCli, repoInfo, ref, authConfig, requestPrivilege, options)
 	}
 
-	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref.String(), requestPrivilege)
+	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref.String(), requestPrivilege, options)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@ package image
 import (
 	"fmt"
 	"strings"
+	"text/tabwriter"
 
 	"golang.org/x/net/context"
 
@@ -15,6 +16,7 @@ import (
 type removeOptions struct {
 	force   bool
 	noPrune bool
+	audit   bool
 }
 
 // NewRemoveCommand creates a new `docker remove` command
@@ -24,8 +26,16 @@ func NewRemoveCommand(dockerCli *command.DockerCli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rmi [OPTIONS] IMAGE [IMAGE...]",
 		Short: "Remove one or more images",
-		Args:  cli.RequiresMinArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.audit {
+				return cli.NoArgs(cmd, args)
+			}
+			return cli.RequiresMinArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.audit {
+				return runAudit(dockerCli)
+			}
 			return runRemove(dockerCli, opts, args)
 		},
 	}
@@ -34,6 +44,7 @@ func NewRemoveCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force removal of the image")
 	flags.BoolVar(&opts.noPrune, "no-prune", false, "Do not delete untagged parents")
+	flags.BoolVar(&opts.audit, "audit", false, "Report the dangling references of the images and their layers, without removing anything")
 
 	return cmd
 }
@@ -75,3 +86,22 @@ func runRemove(dockerCli *command.DockerCli, opts removeOptions, images []string
 	}
 	return nil
 }
+
+func runAudit(dockerCli *command.DockerCli) error {
+	report, err := dockerCli.Client().ImagesAudit(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(report.DanglingReferences) == 0 {
+		fmt.Fprintln(dockerCli.Out(), "No dangling references found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tID\tPROBLEM")
+	for _, entry := range report.DanglingReferences {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Type, entry.ID, entry.Message)
+	}
+	w.Flush()
+	return nil
+}
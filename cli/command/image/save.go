@@ -6,14 +6,19 @@ import (
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/spf13/cobra"
 )
 
 type saveOptions struct {
-	images []string
-	output string
+	images           []string
+	output           string
+	compression      string
+	compressionLevel int
 }
 
 // NewSaveCommand creates a new `docker save` command
@@ -33,6 +38,8 @@ func NewSaveCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.compression, "compression", "", "Compress the layers saved (gzip or zstd)")
+	flags.IntVar(&opts.compressionLevel, "compression-level", 0, "Compression level of the layers saved, 0 for the default level of the compression")
 
 	return cmd
 }
@@ -42,7 +49,11 @@ func runSave(dockerCli *command.DockerCli, opts saveOptions) error {
 		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
 	}
 
-	responseBody, err := dockerCli.Client().ImageSave(context.Background(), opts.images)
+	options := types.ImageSaveOptions{
+		Compression:      opts.compression,
+		CompressionLevel: opts.compressionLevel,
+	}
+	responseBody, err := dockerCli.Client().ImageSaveWithOptions(context.Background(), opts.images, options)
 	if err != nil {
 		return err
 	}
@@ -53,5 +64,11 @@ func runSave(dockerCli *command.DockerCli, opts saveOptions) error {
 		return err
 	}
 
-	return command.CopyToFile(opts.output, responseBody)
+	var body io.Reader = responseBody
+	if dockerCli.Out().IsTerminal() {
+		// The layers are streamed as soon as the daemon has written them.
+		progressOutput := streamformatter.NewStreamFormatter().NewProgressOutput(dockerCli.Out(), true)
+		body = progress.NewProgressReader(responseBody, progressOutput, 0, "", "Receiving images from Docker daemon")
+	}
+	return command.CopyToFile(opts.output, body)
 }
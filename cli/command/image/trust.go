@@ -24,6 +24,7 @@ import (
 	"github.com/docker/docker/api/types"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/formatter"
 	"github.com/docker/docker/cliconfig"
 	"github.com/docker/docker/distribution"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -50,8 +51,8 @@ type target struct {
 }
 
 // trustedPush handles content trust pushing of an image
-func trustedPush(ctx context.Context, cli *command.DockerCli, repoInfo *registry.RepositoryInfo, ref reference.Named, authConfig types.AuthConfig, requestPrivilege types.RequestPrivilegeFunc) error {
-	responseBody, err := imagePushPrivileged(ctx, cli, authConfig, ref.String(), requestPrivilege)
+func trustedPush(ctx context.Context, cli *command.DockerCli, repoInfo *registry.RepositoryInfo, ref reference.Named, authConfig types.AuthConfig, requestPrivilege types.RequestPrivilegeFunc, options types.ImagePushOptions) error {
+	responseBody, err := imagePushPrivileged(ctx, cli, authConfig, ref.String(), requestPrivilege, options)
 	if err != nil {
 		return err
 	}
@@ -217,16 +218,15 @@ func addTargetToAllSignableRoles(repo *client.NotaryRepository, target *client.T
 	return repo.AddTarget(target, signableRoles...)
 }
 
-// imagePushPrivileged push the image
-func imagePushPrivileged(ctx context.Context, cli *command.DockerCli, authConfig types.AuthConfig, ref string, requestPrivilege types.RequestPrivilegeFunc) (io.ReadCloser, error) {
+// imagePushPrivileged push the image with the given options, authenticated
+// with authConfig
+func imagePushPrivileged(ctx context.Context, cli *command.DockerCli, authConfig types.AuthConfig, ref string, requestPrivilege types.RequestPrivilegeFunc, options types.ImagePushOptions) (io.ReadCloser, error) {
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
 		return nil, err
 	}
-	options := types.ImagePushOptions{
-		RegistryAuth:  encodedAuth,
-		PrivilegeFunc: requestPrivilege,
-	}
+	options.RegistryAuth = encodedAuth
+	options.PrivilegeFunc = requestPrivilege
 
 	return cli.Client().ImagePush(ctx, ref, options)
 }
@@ -338,7 +338,9 @@ func imagePullPrivileged(ctx context.Context, cli *command.DockerCli, authConfig
 	return jsonmessage.DisplayJSONMessagesToStream(responseBody, cli.Out(), nil)
 }
 
-func trustDirectory() string {
+// TrustDirectory returns the directory of the trust data and the signing keys
+// of the client.
+func TrustDirectory() string {
 	return filepath.Join(cliconfig.ConfigDir(), "trust")
 }
 
@@ -456,15 +458,17 @@ func GetNotaryRepository(streams command.Streams, repoInfo *registry.RepositoryI
 	tr := transport.NewTransport(base, modifiers...)
 
 	return client.NewNotaryRepository(
-		trustDirectory(),
+		TrustDirectory(),
 		repoInfo.FullName(),
 		server,
 		tr,
-		getPassphraseRetriever(streams),
+		GetPassphraseRetriever(streams),
 		trustpinning.TrustPinConfig{})
 }
 
-func getPassphraseRetriever(streams command.Streams) passphrase.Retriever {
+// GetPassphraseRetriever returns a retriever which prompts for the passphrases
+// of the signing keys, unless they are set in the environment.
+func GetPassphraseRetriever(streams command.Streams) passphrase.Retriever {
 	aliasMap := map[string]string{
 		"root":     "root",
 		"snapshot": "repository",
@@ -525,6 +529,53 @@ func TrustedReference(ctx context.Context, cli *command.DockerCli, ref reference
 	return reference.WithDigest(ref, r.digest)
 }
 
+// cachedTrustData returns the trust data of the tags of a repository, keyed
+// by tag. It is read from the local cache of the trust metadata, which is
+// populated by the trusted pulls and pushes, and the notary server is not
+// contacted. The cached metadata is still verified, so that expired or
+// invalid trust data is reported as an error.
+func cachedTrustData(streams command.Streams, repoInfo *registry.RepositoryInfo) (map[string]formatter.ImageTrust, error) {
+	server, err := trustServer(repoInfo.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a round tripper, the notary repository only uses its cache.
+	notaryRepo, err := client.NewNotaryRepository(
+		TrustDirectory(),
+		repoInfo.FullName(),
+		server,
+		nil,
+		GetPassphraseRetriever(streams),
+		trustpinning.TrustPinConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := notaryRepo.ListTargets(releasesRole, data.CanonicalTargetsRole)
+	if err != nil {
+		return nil, notaryError(repoInfo.FullName(), err)
+	}
+	trustData := make(map[string]formatter.ImageTrust)
+	for _, tgt := range targets {
+		// Only list tags in the top level targets role or the releases delegation role - ignore
+		// all other delegation roles
+		if tgt.Role != releasesRole && tgt.Role != data.CanonicalTargetsRole {
+			continue
+		}
+		t, err := convertTarget(tgt.Target)
+		if err != nil {
+			continue
+		}
+		trustData[t.reference.String()] = formatter.ImageTrust{
+			Signed: true,
+			Role:   tgt.Role,
+			Digest: t.digest.String(),
+		}
+	}
+	return trustData, nil
+}
+
 func convertTarget(t client.Target) (target, error) {
 	h, ok := t.Hashes["sha256"]
 	if !ok {
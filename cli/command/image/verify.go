@@ -0,0 +1,142 @@
+package image
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"github.com/spf13/cobra"
+)
+
+type verifyOptions struct {
+	image string
+
+	repair  bool
+	noTrunc bool
+}
+
+// newVerifyCommand creates a new `docker image verify` command
+func newVerifyCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts verifyOptions
+
+	cmd := &cobra.Command{
+		Use:   "verify [OPTIONS] IMAGE",
+		Short: "Verify the configuration and the layers of an image against their digests",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.image = args[0]
+			return runVerify(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+
+	flags.BoolVar(&opts.repair, "repair", false, "Remove a damaged image and pull it again from its registry")
+	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Don't truncate output")
+
+	return cmd
+}
+
+func runVerify(dockerCli *command.DockerCli, opts verifyOptions) error {
+	ctx := context.Background()
+
+	resp, err := dockerCli.Client().ImageVerify(ctx, opts.image)
+	if err != nil {
+		return err
+	}
+	printVerification(dockerCli, resp, opts.noTrunc)
+	if !resp.Damaged() {
+		return nil
+	}
+	if !opts.repair {
+		return fmt.Errorf("Image %s is damaged, use --repair to pull it again from its registry", opts.image)
+	}
+
+	if err := repairImage(ctx, dockerCli, resp); err != nil {
+		return err
+	}
+	resp, err = dockerCli.Client().ImageVerify(ctx, resp.ID)
+	if err != nil {
+		return err
+	}
+	printVerification(dockerCli, resp, opts.noTrunc)
+	if resp.Damaged() {
+		return fmt.Errorf("Image %s is still damaged, its damaged layers are used by other images or containers", opts.image)
+	}
+	return nil
+}
+
+func printVerification(dockerCli *command.DockerCli, resp types.ImageVerifyResponse, noTrunc bool) {
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "BLOB\tDIGEST\tSTATUS")
+	printBlob(w, "config", resp.Config, noTrunc)
+	for _, l := range resp.Layers {
+		printBlob(w, "layer", l, noTrunc)
+	}
+	w.Flush()
+}
+
+func printBlob(w *tabwriter.Writer, kind string, b types.BlobVerification, noTrunc bool) {
+	dgst := b.Digest
+	if !noTrunc {
+		dgst = stringid.TruncateID(dgst)
+	}
+	status := b.Status
+	if b.Message != "" {
+		status += ": " + b.Message
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\n", kind, dgst, status)
+}
+
+// repairImage removes the damaged image, so that its layers which are not
+// used by other images or containers are removed, and pulls it again by
+// digest from its registries. The tags of the image are restored once it
+// is pulled.
+func repairImage(ctx context.Context, dockerCli *command.DockerCli, resp types.ImageVerifyResponse) error {
+	if len(resp.RepoDigests) == 0 {
+		return fmt.Errorf("Image %s cannot be repaired, it was not pulled from a registry", stringid.TruncateID(resp.ID))
+	}
+
+	if _, err := dockerCli.Client().ImageRemove(ctx, resp.ID, types.ImageRemoveOptions{Force: true}); err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "Removed %s\n", resp.ID)
+
+	for _, ref := range resp.RepoDigests {
+		if err := pullDigest(ctx, dockerCli, ref); err != nil {
+			return fmt.Errorf("%v, the image can be pulled again with `docker pull %s`", err, ref)
+		}
+	}
+	for _, tag := range resp.RepoTags {
+		if err := dockerCli.Client().ImageTag(ctx, resp.RepoDigests[0], tag); err != nil {
+			return err
+		}
+	}
+	if len(resp.RepoTags) > 0 {
+		fmt.Fprintf(dockerCli.Out(), "Restored the tags %s\n", strings.Join(resp.RepoTags, ", "))
+	}
+	return nil
+}
+
+func pullDigest(ctx context.Context, dockerCli *command.DockerCli, ref string) error {
+	distributionRef, err := reference.ParseNamed(ref)
+	if err != nil {
+		return err
+	}
+	repoInfo, err := registry.ParseRepositoryInfo(distributionRef)
+	if err != nil {
+		return err
+	}
+
+	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
+	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "pull")
+	return imagePullPrivileged(ctx, dockerCli, authConfig, distributionRef.String(), requestPrivilege, false)
+}
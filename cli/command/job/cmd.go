@@ -0,0 +1,42 @@
+package job
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+)
+
+// NewJobCommand returns a cobra command for `job` subcommands
+func NewJobCommand(dockerCli *command.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "job COMMAND",
+		Short: "Manage jobs",
+		Long:  jobDescription,
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(dockerCli.Err(), "\n%s", cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		newCreateCommand(dockerCli),
+		newInspectCommand(dockerCli),
+		newListCommand(dockerCli),
+		newLogsCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+	)
+	return cmd
+}
+
+var jobDescription = `
+The **docker job** command has subcommands for managing jobs. A job runs a
+container on a cron schedule: the daemon creates and starts a new container
+for each run, and keeps the containers of the last runs for their logs.
+
+To see help for a subcommand, use:
+
+    docker job CMD help
+
+`
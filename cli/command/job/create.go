@@ -0,0 +1,105 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	name     string
+	schedule string
+	image    string
+	env      opts.ListOpts
+	labels   []string
+	history  int
+	timeout  time.Duration
+	command  []string
+}
+
+func newCreateCommand(dockerCli *command.DockerCli) *cobra.Command {
+	opts := createOptions{
+		env: opts.NewListOpts(runconfigopts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] --schedule SCHEDULE --image IMAGE [COMMAND] [ARG...]",
+		Short: "Create a job",
+		Long:  createDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.command = args
+			return runCreate(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SetInterspersed(false)
+	flags.StringVar(&opts.name, "name", "", "Assign a name to the job")
+	flags.StringVar(&opts.schedule, "schedule", "", "Cron schedule of the job")
+	flags.StringVar(&opts.image, "image", "", "Image of the containers of the job")
+	flags.VarP(&opts.env, "env", "e", "Set environment variables")
+	flags.StringSliceVarP(&opts.labels, "label", "l", []string{}, "Set metadata on the containers of the job")
+	flags.IntVar(&opts.history, "history", 5, "Number of runs of which the containers are kept")
+	flags.DurationVar(&opts.timeout, "timeout", 0, "Stop the containers of the job after a maximum runtime (ns|us|ms|s|m|h)")
+
+	return cmd
+}
+
+func runCreate(dockerCli *command.DockerCli, opts createOptions) error {
+	if opts.schedule == "" {
+		return fmt.Errorf("--schedule is required")
+	}
+	if opts.image == "" {
+		return fmt.Errorf("--image is required")
+	}
+	if opts.history <= 0 {
+		return fmt.Errorf("--history must be positive")
+	}
+	if opts.timeout < 0 {
+		return fmt.Errorf("--timeout cannot be negative")
+	}
+
+	req := types.JobCreateRequest{
+		Name:     opts.name,
+		Schedule: opts.schedule,
+		Config: &container.Config{
+			Image:  opts.image,
+			Env:    opts.env.GetAll(),
+			Labels: runconfigopts.ConvertKVStringsToMap(opts.labels),
+		},
+		HostConfig: &container.HostConfig{
+			MaxRuntime: opts.timeout,
+		},
+		History: opts.history,
+	}
+	if len(opts.command) > 0 {
+		req.Config.Cmd = opts.command
+	}
+
+	job, err := dockerCli.Client().JobCreate(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "%s\n", job.Name)
+	return nil
+}
+
+var createDescription = `
+Creates a job running a container of the image on a cron schedule. The
+schedule has five fields, the minute, hour, day of month, month and day of
+week, in the time zone of the daemon. For example, "*/5 * * * *" runs the job
+every five minutes. The shorthands @hourly, @daily, @weekly, @monthly and
+@yearly are accepted.
+
+Each run is a new container, named after the job and the time of the run. A
+run is skipped if the previous one is still running. The containers of the
+last runs are kept, so that their logs can be read with "docker job logs".
+`
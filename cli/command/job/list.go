@@ -0,0 +1,85 @@
+package job
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	quiet bool
+}
+
+func newListCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List jobs",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display job names")
+
+	return cmd
+}
+
+func runList(dockerCli *command.DockerCli, opts listOptions) error {
+	jobs, err := dockerCli.Client().JobList(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if opts.quiet {
+		for _, job := range jobs {
+			fmt.Fprintf(dockerCli.Out(), "%s\n", job.Name)
+		}
+		return nil
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NAME\tSCHEDULE\tIMAGE\tNEXT RUN\tLAST RUN\n")
+	for _, job := range jobs {
+		image := ""
+		if job.Config != nil {
+			image = job.Config.Image
+		}
+		next := "never"
+		if !job.NextRun.IsZero() {
+			next = "in " + units.HumanDuration(job.NextRun.Sub(now))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", job.Name, job.Schedule, image, next, lastRun(job, now))
+	}
+	w.Flush()
+	return nil
+}
+
+// lastRun describes the latest run of the job.
+func lastRun(job types.Job, now time.Time) string {
+	if len(job.Runs) == 0 {
+		return ""
+	}
+	run := job.Runs[0]
+	if run.State == "running" {
+		return "running"
+	}
+	finishedAt, err := time.Parse(time.RFC3339Nano, run.FinishedAt)
+	if err != nil {
+		return run.State
+	}
+	return fmt.Sprintf("exited (%d) %s ago", run.ExitCode, units.HumanDuration(now.Sub(finishedAt)))
+}
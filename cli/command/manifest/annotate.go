@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type annotateOptions struct {
+	list     string
+	manifest string
+
+	arch       string
+	os         string
+	osVersion  string
+	osFeatures []string
+	variant    string
+}
+
+func newAnnotateCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts annotateOptions
+
+	cmd := &cobra.Command{
+		Use:   "annotate [OPTIONS] MANIFEST_LIST MANIFEST",
+		Short: "Set the platform of a manifest in a local manifest list",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.list = args[0]
+			opts.manifest = args[1]
+			return runAnnotate(dockerCli, cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.arch, "arch", "", "Set the architecture")
+	flags.StringVar(&opts.os, "os", "", "Set the operating system")
+	flags.StringVar(&opts.osVersion, "os-version", "", "Set the operating system version")
+	flags.StringSliceVar(&opts.osFeatures, "os-features", nil, "Set the operating system features")
+	flags.StringVar(&opts.variant, "variant", "", "Set the architecture variant, such as v7 for arm")
+
+	return cmd
+}
+
+func runAnnotate(dockerCli *command.DockerCli, cmd *cobra.Command, opts annotateOptions) error {
+	listRef, err := normalizeListName(opts.list)
+	if err != nil {
+		return err
+	}
+	ref, err := normalizeName(opts.manifest)
+	if err != nil {
+		return err
+	}
+
+	l, err := loadList(listRef.String())
+	if os.IsNotExist(err) {
+		return noSuchList(listRef.String())
+	}
+	if err != nil {
+		return err
+	}
+	e := l.find(ref.String())
+	if e == nil {
+		return fmt.Errorf("The manifest list %s does not reference %s", l.Name, ref.String())
+	}
+
+	flags := cmd.Flags()
+	platform := &e.Descriptor.Platform
+	if flags.Changed("arch") {
+		platform.Architecture = opts.arch
+	}
+	if flags.Changed("os") {
+		platform.OS = opts.os
+	}
+	if flags.Changed("os-version") {
+		platform.OSVersion = opts.osVersion
+	}
+	if flags.Changed("os-features") {
+		platform.OSFeatures = opts.osFeatures
+	}
+	if flags.Changed("variant") {
+		platform.Variant = opts.variant
+	}
+	if platform.Architecture == "" || platform.OS == "" {
+		return fmt.Errorf("The architecture and the operating system of %s cannot be empty", ref.String())
+	}
+
+	return saveList(l)
+}
@@ -0,0 +1,93 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	list      string
+	manifests []string
+
+	amend bool
+}
+
+func newCreateCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts createOptions
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] MANIFEST_LIST MANIFEST [MANIFEST...]",
+		Short: "Create a local manifest list referencing images pushed to its repository",
+		Args:  cli.RequiresMinArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.list = args[0]
+			opts.manifests = args[1:]
+			return runCreate(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.amend, "amend", "a", false, "Add the manifests to an existing manifest list")
+
+	return cmd
+}
+
+func runCreate(dockerCli *command.DockerCli, opts createOptions) error {
+	listRef, err := normalizeListName(opts.list)
+	if err != nil {
+		return err
+	}
+
+	l, err := loadList(listRef.String())
+	switch {
+	case err == nil:
+		if !opts.amend {
+			return fmt.Errorf("The manifest list %s already exists, use --amend to add manifests to it", listRef.String())
+		}
+	case os.IsNotExist(err):
+		l = &manifestList{Name: listRef.String()}
+	default:
+		return err
+	}
+
+	ctx := context.Background()
+	client := dockerCli.Client()
+
+	for _, name := range opts.manifests {
+		ref, err := normalizeName(name)
+		if err != nil {
+			return err
+		}
+		if ref.Name() != listRef.Name() {
+			return fmt.Errorf("%s is not in the repository of the manifest list %s, push it there first", ref.String(), listRef.Name())
+		}
+
+		encodedAuth, err := command.RetrieveAuthTokenFromImage(ctx, dockerCli, ref.String())
+		if err != nil {
+			return err
+		}
+		descriptor, err := client.ManifestInspect(ctx, ref.String(), types.ManifestOptions{RegistryAuth: encodedAuth})
+		if err != nil {
+			return err
+		}
+
+		if e := l.find(ref.String()); e != nil {
+			e.Descriptor = descriptor
+		} else {
+			l.Manifests = append(l.Manifests, manifestEntry{Image: ref.String(), Descriptor: descriptor})
+		}
+	}
+
+	if err := saveList(l); err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "Created manifest list %s\n", l.Name)
+	return nil
+}
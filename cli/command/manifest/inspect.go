@@ -0,0 +1,49 @@
+package manifest
+
+import (
+	"os"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/inspect"
+	"github.com/spf13/cobra"
+)
+
+type inspectOptions struct {
+	format string
+	names  []string
+}
+
+func newInspectCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts inspectOptions
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] MANIFEST_LIST [MANIFEST_LIST...]",
+		Short: "Display the manifests referenced by one or more local manifest lists",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.names = args
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "", "Format the output using the given go template")
+
+	return cmd
+}
+
+func runInspect(dockerCli *command.DockerCli, opts inspectOptions) error {
+	getListFunc := func(name string) (interface{}, []byte, error) {
+		ref, err := normalizeListName(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		l, err := loadList(ref.String())
+		if os.IsNotExist(err) {
+			return nil, nil, noSuchList(ref.String())
+		}
+		return l, nil, err
+	}
+
+	return inspect.Inspect(dockerCli.Out(), opts.names, opts.format, getListFunc)
+}
@@ -0,0 +1,73 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type pushOptions struct {
+	list string
+
+	purge bool
+}
+
+func newPushCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts pushOptions
+
+	cmd := &cobra.Command{
+		Use:   "push [OPTIONS] MANIFEST_LIST",
+		Short: "Push a local manifest list to its repository",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.list = args[0]
+			return runPush(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.purge, "purge", "p", false, "Remove the local manifest list once it is pushed")
+
+	return cmd
+}
+
+func runPush(dockerCli *command.DockerCli, opts pushOptions) error {
+	listRef, err := normalizeListName(opts.list)
+	if err != nil {
+		return err
+	}
+	l, err := loadList(listRef.String())
+	if os.IsNotExist(err) {
+		return noSuchList(listRef.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	manifests := make([]types.ManifestDescriptor, 0, len(l.Manifests))
+	for _, e := range l.Manifests {
+		manifests = append(manifests, e.Descriptor)
+	}
+
+	ctx := context.Background()
+	encodedAuth, err := command.RetrieveAuthTokenFromImage(ctx, dockerCli, l.Name)
+	if err != nil {
+		return err
+	}
+	dgst, err := dockerCli.Client().ManifestListPush(ctx, l.Name, manifests, types.ManifestOptions{RegistryAuth: encodedAuth})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), dgst)
+
+	if opts.purge {
+		return removeList(l.Name)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cliconfig"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/reference"
+)
+
+// manifestList is a manifest list being composed. It is stored in the
+// configuration directory of the client until it is pushed.
+type manifestList struct {
+	Name      string
+	Manifests []manifestEntry
+}
+
+// manifestEntry is a manifest referenced by a manifest list, with the name
+// of its image.
+type manifestEntry struct {
+	Image      string
+	Descriptor types.ManifestDescriptor
+}
+
+// find returns the entry of the image in the list, or nil if the list does
+// not reference it.
+func (l *manifestList) find(image string) *manifestEntry {
+	for i := range l.Manifests {
+		if l.Manifests[i].Image == image {
+			return &l.Manifests[i]
+		}
+	}
+	return nil
+}
+
+// normalizeName returns the name of an image with its default tag, if it
+// has neither a tag nor a digest.
+func normalizeName(name string) (reference.Named, error) {
+	ref, err := reference.ParseNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	return reference.WithDefaultTag(ref), nil
+}
+
+// normalizeListName returns the name of a manifest list, which must not
+// have a digest.
+func normalizeListName(name string) (reference.Named, error) {
+	ref, err := normalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ref.(reference.Canonical); ok {
+		return nil, fmt.Errorf("invalid manifest list name %s: a manifest list is named by a tag", name)
+	}
+	return ref, nil
+}
+
+func storeDir() string {
+	return filepath.Join(cliconfig.ConfigDir(), "manifests")
+}
+
+func listPath(name string) string {
+	return filepath.Join(storeDir(), url.QueryEscape(name)+".json")
+}
+
+// loadList loads the manifest list named name. The error satisfies
+// os.IsNotExist if the list was not created.
+func loadList(name string) (*manifestList, error) {
+	b, err := ioutil.ReadFile(listPath(name))
+	if err != nil {
+		return nil, err
+	}
+	var l manifestList
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("invalid manifest list %s: %v", name, err)
+	}
+	return &l, nil
+}
+
+func saveList(l *manifestList) error {
+	if err := os.MkdirAll(storeDir(), 0700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(listPath(l.Name), b, 0600)
+}
+
+func removeList(name string) error {
+	if err := os.Remove(listPath(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// noSuchList returns the error of a manifest list which was not created.
+func noSuchList(name string) error {
+	return fmt.Errorf("No such manifest list: %s, create it with docker manifest create", name)
+}
@@ -0,0 +1,73 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cliconfig"
+)
+
+func TestListStore(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "manifest-store-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	configDir := cliconfig.ConfigDir()
+	cliconfig.SetConfigDir(tmp)
+	defer cliconfig.SetConfigDir(configDir)
+
+	name := "example.com:5000/busybox:latest"
+	if _, err := loadList(name); !os.IsNotExist(err) {
+		t.Fatalf("expected a missing manifest list, got %v", err)
+	}
+
+	l := &manifestList{
+		Name: name,
+		Manifests: []manifestEntry{
+			{
+				Image: "example.com:5000/busybox:amd64",
+				Descriptor: types.ManifestDescriptor{
+					Digest:   "sha256:amd64",
+					Platform: types.ManifestPlatform{Architecture: "amd64", OS: "linux"},
+				},
+			},
+		},
+	}
+	if err := saveList(l); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadList(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := loaded.find("example.com:5000/busybox:amd64")
+	if e == nil || e.Descriptor.Digest != "sha256:amd64" {
+		t.Fatalf("expected the manifest to be stored, got %v", loaded)
+	}
+	if loaded.find("example.com:5000/busybox:arm") != nil {
+		t.Fatal("expected no entry for an image which is not in the list")
+	}
+
+	if err := removeList(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadList(name); !os.IsNotExist(err) {
+		t.Fatalf("expected the manifest list to be removed, got %v", err)
+	}
+}
+
+func TestNormalizeListName(t *testing.T) {
+	ref, err := normalizeListName("busybox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.String() != "busybox:latest" {
+		t.Fatalf("expected the default tag, got %s", ref.String())
+	}
+	if _, err := normalizeListName("busybox@sha256:9f1003c480699be56815db0f8146ad2e22efea85129b5b5983d0e0fb52d9ab70"); err == nil {
+		t.Fatal("expected an error for a manifest list named by digest")
+	}
+}
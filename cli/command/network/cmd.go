@@ -25,7 +25,10 @@ func NewNetworkCommand(dockerCli *command.DockerCli) *cobra.Command {
 		newDisconnectCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
+		newPortsCommand(dockerCli),
+		newReconcileCommand(dockerCli),
 		newRemoveCommand(dockerCli),
+		NewPruneCommand(dockerCli),
 	)
 	return cmd
 }
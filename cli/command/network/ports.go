@@ -0,0 +1,52 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+func newPortsCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ports",
+		Short: "List the host ports allocated for the published ports",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPorts(dockerCli)
+		},
+	}
+}
+
+func runPorts(dockerCli *command.DockerCli) error {
+	ports, err := dockerCli.Client().NetworkPorts(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "HOST PORT\tCONTAINER\tNETWORK\tDESTINATION\tPROXY")
+	for _, p := range ports {
+		proxy := "no"
+		if p.UserlandProxy {
+			proxy = "yes"
+		}
+		// The ports reserved without a running container have no
+		// destination.
+		destination := ""
+		if p.ContainerID != "" {
+			destination = net.JoinHostPort(p.ContainerIP, strconv.Itoa(int(p.ContainerPort)))
+		}
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\t%s\t%s\n",
+			net.JoinHostPort(p.HostIP, strconv.Itoa(int(p.HostPort))), p.Proto,
+			p.ContainerName, p.Network, destination, proxy)
+	}
+	w.Flush()
+	return nil
+}
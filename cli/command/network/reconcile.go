@@ -0,0 +1,54 @@
+package network
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/spf13/cobra"
+)
+
+func newReconcileCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reconcile",
+		Short: "Re-create the missing iptables rules of the published ports",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReconcile(dockerCli)
+		},
+	}
+}
+
+// runReconcile prints the rules which were re-created. The rules which could
+// not be re-created make the command exit with status 1.
+func runReconcile(dockerCli *command.DockerCli) error {
+	report, err := dockerCli.Client().NetworksReconcile(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(report.Repairs) == 0 {
+		fmt.Fprintln(dockerCli.Out(), "No missing rule found")
+		return nil
+	}
+
+	failed := false
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "CONTAINER\tNETWORK\tTABLE\tRULE\tSTATUS")
+	for _, repair := range report.Repairs {
+		status := "re-created"
+		if repair.Error != "" {
+			status = "error: " + repair.Error
+			failed = true
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", stringid.TruncateID(repair.Container), repair.Network, repair.Table, repair.Rule, status)
+	}
+	w.Flush()
+	if failed {
+		return cli.StatusError{StatusCode: 1}
+	}
+	return nil
+}
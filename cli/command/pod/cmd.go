@@ -0,0 +1,44 @@
+package pod
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+)
+
+// NewPodCommand returns a cobra command for `pod` subcommands
+func NewPodCommand(dockerCli *command.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pod COMMAND",
+		Short: "Manage pods",
+		Long:  podDescription,
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(dockerCli.Err(), "\n%s", cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		newCreateCommand(dockerCli),
+		newInspectCommand(dockerCli),
+		newListCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+		newStartCommand(dockerCli),
+		newStopCommand(dockerCli),
+	)
+	return cmd
+}
+
+var podDescription = `
+The **docker pod** command has subcommands for managing pods. A pod is a group
+of containers created, started, stopped and removed together. Its first
+container, the holder, owns the network and IPC namespaces, which the other
+containers of the pod join.
+
+To see help for a subcommand, use:
+
+    docker pod CMD help
+
+`
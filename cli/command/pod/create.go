@@ -0,0 +1,100 @@
+package pod
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/docker/go-connections/nat"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	name    string
+	images  []string
+	labels  []string
+	publish opts.ListOpts
+	start   bool
+}
+
+func newCreateCommand(dockerCli *command.DockerCli) *cobra.Command {
+	opts := createOptions{
+		publish: opts.NewListOpts(nil),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] POD IMAGE [IMAGE...]",
+		Short: "Create a pod",
+		Long:  createDescription,
+		Args:  cli.RequiresMinArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			opts.images = args[1:]
+			return runCreate(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&opts.labels, "label", "l", []string{}, "Set metadata on the containers of the pod")
+	flags.VarP(&opts.publish, "publish", "p", "Publish a port of the pod to the host")
+	flags.BoolVar(&opts.start, "start", false, "Start the pod once created")
+
+	return cmd
+}
+
+func runCreate(dockerCli *command.DockerCli, opts createOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(opts.publish.GetAll())
+	if err != nil {
+		return err
+	}
+
+	req := types.PodCreateRequest{Name: opts.name}
+	for i, image := range opts.images {
+		member := types.PodMember{
+			Config: &container.Config{
+				Image:  image,
+				Labels: runconfigopts.ConvertKVStringsToMap(opts.labels),
+			},
+			HostConfig: &container.HostConfig{},
+		}
+		// The ports are published by the holder, which owns the network
+		// namespace of the pod.
+		if i == 0 {
+			member.Config.ExposedPorts = exposedPorts
+			member.HostConfig.PortBindings = portBindings
+		}
+		req.Members = append(req.Members, member)
+	}
+
+	pod, err := client.PodCreate(ctx, req)
+	if err != nil {
+		return err
+	}
+	if opts.start {
+		if err := client.PodStart(ctx, pod.Name); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", pod.Name)
+	return nil
+}
+
+var createDescription = `
+Creates a pod running one container per image. The container of the first
+image is the holder of the pod: the other containers join its network and IPC
+namespaces, and so share its interfaces, ports and shared memory. The ports
+are published by the holder.
+
+The containers are named after the pod, POD-0 being the holder. If a
+container fails to be created, none of them are.
+`
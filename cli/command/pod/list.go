@@ -0,0 +1,66 @@
+package pod
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	quiet bool
+}
+
+func newListCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List pods",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display pod names")
+
+	return cmd
+}
+
+func runList(dockerCli *command.DockerCli, opts listOptions) error {
+	pods, err := dockerCli.Client().PodList(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if opts.quiet {
+		for _, pod := range pods {
+			fmt.Fprintf(dockerCli.Out(), "%s\n", pod.Name)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "NAME\tRUNNING\tCONTAINERS\n")
+	for _, pod := range pods {
+		running := 0
+		var names []string
+		for _, c := range pod.Containers {
+			if c.State == "running" {
+				running++
+			}
+			names = append(names, c.Name)
+		}
+		fmt.Fprintf(w, "%s\t%d/%d\t%s\n", pod.Name, running, len(pod.Containers), strings.Join(names, ","))
+	}
+	w.Flush()
+	return nil
+}
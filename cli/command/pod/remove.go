@@ -0,0 +1,64 @@
+package pod
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	force   bool
+	volumes bool
+
+	pods []string
+}
+
+func newRemoveCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts removeOptions
+
+	cmd := &cobra.Command{
+		Use:     "rm [OPTIONS] POD [POD...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more pods",
+		Args:    cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.pods = args
+			return runRemove(dockerCli, &opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.force, "force", "f", false, "Force the removal of running pods")
+	flags.BoolVarP(&opts.volumes, "volumes", "v", false, "Remove the volumes associated with the containers of the pods")
+
+	return cmd
+}
+
+func runRemove(dockerCli *command.DockerCli, opts *removeOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+	status := 0
+
+	options := types.PodRemoveOptions{
+		RemoveVolumes: opts.volumes,
+		Force:         opts.force,
+	}
+	for _, name := range opts.pods {
+		if err := client.PodRemove(ctx, name, options); err != nil {
+			fmt.Fprintf(dockerCli.Err(), "%s\n", err)
+			status = 1
+			continue
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s\n", name)
+	}
+
+	if status != 0 {
+		return cli.StatusError{StatusCode: status}
+	}
+	return nil
+}
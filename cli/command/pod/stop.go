@@ -0,0 +1,64 @@
+package pod
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+type stopOptions struct {
+	time        int
+	timeChanged bool
+
+	pods []string
+}
+
+func newStopCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts stopOptions
+
+	cmd := &cobra.Command{
+		Use:   "stop [OPTIONS] POD [POD...]",
+		Short: "Stop one or more pods",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.pods = args
+			opts.timeChanged = cmd.Flags().Changed("time")
+			return runStop(dockerCli, &opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVarP(&opts.time, "time", "t", 10, "Seconds to wait for the containers to stop before killing them")
+	return cmd
+}
+
+func runStop(dockerCli *command.DockerCli, opts *stopOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+	status := 0
+
+	var timeout *time.Duration
+	if opts.timeChanged {
+		t := time.Duration(opts.time) * time.Second
+		timeout = &t
+	}
+
+	for _, name := range opts.pods {
+		if err := client.PodStop(ctx, name, timeout); err != nil {
+			fmt.Fprintf(dockerCli.Err(), "%s\n", err)
+			status = 1
+			continue
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s\n", name)
+	}
+
+	if status != 0 {
+		return cli.StatusError{StatusCode: status}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/container"
 	"github.com/docker/docker/cli/command/image"
+	"github.com/docker/docker/cli/command/network"
 	"github.com/docker/docker/cli/command/volume"
 	"github.com/spf13/cobra"
 )
@@ -23,6 +24,11 @@ func NewImagePruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 	return image.NewPruneCommand(dockerCli)
 }
 
+// NewNetworkPruneCommand return a cobra prune command for networks
+func NewNetworkPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return network.NewPruneCommand(dockerCli)
+}
+
 // RunContainerPrune execute a prune command for containers
 func RunContainerPrune(dockerCli *command.DockerCli) (uint64, string, error) {
 	return container.RunPrune(dockerCli)
@@ -33,6 +39,11 @@ func RunVolumePrune(dockerCli *command.DockerCli) (uint64, string, error) {
 	return volume.RunPrune(dockerCli)
 }
 
+// RunNetworkPrune execute a prune command for networks
+func RunNetworkPrune(dockerCli *command.DockerCli) (uint64, string, error) {
+	return network.RunPrune(dockerCli)
+}
+
 // RunImagePrune execute a prune command for images
 func RunImagePrune(dockerCli *command.DockerCli, all bool) (uint64, string, error) {
 	return image.RunPrune(dockerCli, all)
@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+)
+
+// NewRegistryCommand returns a cobra command for `registry` subcommands
+func NewRegistryCommand(dockerCli *command.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry COMMAND",
+		Short: "Browse registries",
+		Long:  registryDescription,
+		Args:  cli.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(dockerCli.Err(), "\n%s", cmd.UsageString())
+		},
+	}
+	cmd.AddCommand(
+		newListCommand(dockerCli),
+		newTagsCommand(dockerCli),
+	)
+	return cmd
+}
+
+var registryDescription = `
+The **docker registry** command has subcommands for browsing the repositories
+of a private registry and their tags, with the credentials stored by
+**docker login**.
+
+The repositories of Docker Hub cannot be listed, use **docker search** to find
+them.
+
+To see help for a subcommand, use:
+
+    docker registry CMD help
+
+For full details on using docker registry visit Docker's online documentation.
+
+`
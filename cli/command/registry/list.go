@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/registry"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	hostname string
+	limit    int
+}
+
+func newListCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts listOptions
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS] REGISTRY",
+		Aliases: []string{"list"},
+		Short:   "List the repositories of a registry",
+		Args:    cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.hostname = args[0]
+			return runList(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&opts.limit, "limit", 0, "Max number of repositories to list, 0 lists all of them")
+
+	return cmd
+}
+
+func runList(dockerCli *command.DockerCli, opts listOptions) error {
+	if opts.limit < 0 {
+		return fmt.Errorf("invalid limit %d", opts.limit)
+	}
+	hostname := registry.ConvertToHostname(opts.hostname)
+
+	ctx := context.Background()
+	authConfig := command.ResolveAuthConfig(ctx, dockerCli, &registrytypes.IndexInfo{Name: hostname})
+	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
+	if err != nil {
+		return err
+	}
+
+	// The repositories are listed by pages, following the last repository
+	// of each page.
+	options := types.RegistryRepositoriesOptions{RegistryAuth: encodedAuth}
+	listed := 0
+	for {
+		options.Limit = registry.DefaultCatalogLimit
+		if opts.limit > 0 && opts.limit-listed < options.Limit {
+			options.Limit = opts.limit - listed
+		}
+		repositories, err := dockerCli.Client().RegistryRepositories(ctx, hostname, options)
+		if err != nil {
+			return err
+		}
+		for _, repository := range repositories.Repositories {
+			fmt.Fprintf(dockerCli.Out(), "%s/%s\n", hostname, repository)
+		}
+		listed += len(repositories.Repositories)
+		if repositories.Next == "" || listed == opts.limit {
+			return nil
+		}
+		options.Last = repositories.Next
+	}
+}
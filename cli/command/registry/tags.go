@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+func newTagsCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tags REPOSITORY",
+		Short: "List the tags of a repository in its registry",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTags(dockerCli, args[0])
+		},
+	}
+}
+
+func runTags(dockerCli *command.DockerCli, name string) error {
+	ctx := context.Background()
+	encodedAuth, err := command.RetrieveAuthTokenFromImage(ctx, dockerCli, name)
+	if err != nil {
+		return err
+	}
+
+	tags, err := dockerCli.Client().RepositoryTags(ctx, name, types.RepositoryTagsOptions{RegistryAuth: encodedAuth})
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags.Tags {
+		fmt.Fprintln(dockerCli.Out(), tag)
+	}
+	return nil
+}
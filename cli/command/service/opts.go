@@ -48,16 +48,9 @@ func (c *nanoCPUs) String() string {
 }
 
 func (c *nanoCPUs) Set(value string) error {
-	cpu, ok := new(big.Rat).SetString(value)
-	if !ok {
-		return fmt.Errorf("Failed to parse %v as a rational number", value)
-	}
-	nano := cpu.Mul(cpu, big.NewRat(1e9, 1))
-	if !nano.IsInt() {
-		return fmt.Errorf("value is too precise")
-	}
-	*c = nanoCPUs(nano.Num().Int64())
-	return nil
+	cpus, err := opts.ParseCPUs(value)
+	*c = nanoCPUs(cpus)
+	return err
 }
 
 func (c *nanoCPUs) Type() string {
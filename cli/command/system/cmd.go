@@ -23,6 +23,8 @@ func NewSystemCommand(dockerCli *command.DockerCli) *cobra.Command {
 		NewEventsCommand(dockerCli),
 		NewInfoCommand(dockerCli),
 		NewDiskUsageCommand(dockerCli),
+		NewMaintenanceCommand(dockerCli),
+		NewMountsCommand(dockerCli),
 		NewPruneCommand(dockerCli),
 	)
 	return cmd
@@ -73,6 +73,9 @@ func prettyPrintInfo(dockerCli *command.DockerCli, info types.Info) error {
 		}
 
 	}
+	if info.StorageDriver.LeakedMounts > 0 {
+		fmt.Fprintf(dockerCli.Out(), " Leaked Mounts: %d\n", info.StorageDriver.LeakedMounts)
+	}
 	if info.SystemStatus != nil {
 		for _, pair := range info.SystemStatus {
 			fmt.Fprintf(dockerCli.Out(), "%s: %s\n", pair[0], pair[1])
@@ -138,11 +141,15 @@ func prettyPrintInfo(dockerCli *command.DockerCli, info types.Info) error {
 		fmt.Fprint(dockerCli.Out(), "\n")
 		fmt.Fprintf(dockerCli.Out(), "Default Runtime: %s\n", info.DefaultRuntime)
 	}
+	printComponentVersion(dockerCli, "containerd", info.ContainerdVersion)
+	printComponentVersion(dockerCli, "runc", info.RuncVersion)
 
 	if info.OSType == "linux" {
 		fmt.Fprintf(dockerCli.Out(), "Security Options:")
 		ioutils.FprintfIfNotEmpty(dockerCli.Out(), " %s", strings.Join(info.SecurityOptions, " "))
 		fmt.Fprintf(dockerCli.Out(), "\n")
+		ioutils.FprintfIfNotEmpty(dockerCli.Out(), " Default Seccomp Profile: %s\n", info.DefaultSeccompProfile)
+		ioutils.FprintfIfNotEmpty(dockerCli.Out(), " Default AppArmor Profile: %s\n", info.DefaultAppArmorProfile)
 	}
 
 	// Isolation only has meaning on a Windows daemon.
@@ -257,9 +264,31 @@ func prettyPrintInfo(dockerCli *command.DockerCli, info types.Info) error {
 
 	fmt.Fprintf(dockerCli.Out(), "Live Restore Enabled: %v\n", info.LiveRestoreEnabled)
 
+	if info.PublishedPortRange != "" {
+		fmt.Fprintf(dockerCli.Out(), "Published Port Range: %s\n", info.PublishedPortRange)
+	}
+
+	if info.Maintenance.Enabled {
+		fmt.Fprintf(dockerCli.Out(), "Maintenance: %s\n", info.Maintenance.Mode)
+		if info.Maintenance.QueuedContainers > 0 {
+			fmt.Fprintf(dockerCli.Out(), " Queued Containers: %d\n", info.Maintenance.QueuedContainers)
+		}
+	}
+
 	return nil
 }
 
+// printComponentVersion prints the version of a component of the daemon, if
+// it is known.
+func printComponentVersion(dockerCli *command.DockerCli, name string, v types.ComponentVersion) {
+	if v.Version == "" && v.Commit == "" {
+		return
+	}
+	fmt.Fprintf(dockerCli.Out(), "%s version: %s", name, v.Version)
+	ioutils.FprintfIfNotEmpty(dockerCli.Out(), " (commit %s)", v.Commit)
+	fmt.Fprint(dockerCli.Out(), "\n")
+}
+
 func formatInfo(dockerCli *command.DockerCli, info types.Info, format string) error {
 	tmpl, err := templates.Parse(format)
 	if err != nil {
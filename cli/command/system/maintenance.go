@@ -0,0 +1,66 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type maintenanceOptions struct {
+	state   string
+	mode    string
+	stop    bool
+	timeout int
+
+	timeoutChanged bool
+}
+
+// NewMaintenanceCommand creates a new cobra.Command for `docker system maintenance`
+func NewMaintenanceCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts maintenanceOptions
+
+	cmd := &cobra.Command{
+		Use:   "maintenance [OPTIONS] on|off",
+		Short: "Enable or disable the maintenance mode of the daemon",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.state = args[0]
+			opts.timeoutChanged = cmd.Flags().Changed("time")
+			return runMaintenance(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.mode, "mode", types.MaintenanceReject, "Handling of container creates and starts during the maintenance (reject|queue)")
+	flags.BoolVar(&opts.stop, "stop", false, "Stop the running containers, and start them again when the maintenance ends")
+	flags.IntVarP(&opts.timeout, "time", "t", 0, "Seconds to wait for the containers to stop before killing them")
+
+	return cmd
+}
+
+func runMaintenance(dockerCli *command.DockerCli, opts maintenanceOptions) error {
+	var config types.MaintenanceConfig
+	switch opts.state {
+	case "on":
+		config = types.MaintenanceConfig{
+			Enabled:        true,
+			Mode:           opts.mode,
+			StopContainers: opts.stop,
+		}
+		if opts.timeoutChanged {
+			config.StopTimeout = &opts.timeout
+		}
+	case "off":
+		if opts.stop || opts.timeoutChanged {
+			return fmt.Errorf("--stop and --time can only be used to enable the maintenance mode")
+		}
+	default:
+		return fmt.Errorf("invalid maintenance state: %s (must be on or off)", opts.state)
+	}
+
+	return dockerCli.Client().SystemMaintenance(context.Background(), config)
+}
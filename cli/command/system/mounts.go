@@ -0,0 +1,62 @@
+package system
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/spf13/cobra"
+)
+
+type mountsOptions struct {
+	unmount bool
+}
+
+// NewMountsCommand creates a new cobra.Command for `docker system mounts`
+func NewMountsCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts mountsOptions
+
+	cmd := &cobra.Command{
+		Use:   "mounts [OPTIONS]",
+		Short: "List the leaked mounts of container filesystems",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMounts(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.unmount, "unmount", false, "Force-unmount the leaked mounts")
+
+	return cmd
+}
+
+func runMounts(dockerCli *command.DockerCli, opts mountsOptions) error {
+	var (
+		leaks []types.LeakedMount
+		err   error
+	)
+	if opts.unmount {
+		leaks, err = dockerCli.Client().SystemUnmountLeakedMounts(context.Background())
+	} else {
+		leaks, err = dockerCli.Client().SystemLeakedMounts(context.Background())
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(leaks) > 0 {
+		w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "CONTAINER ID\tMOUNT ID\tPATH\n")
+		for _, leak := range leaks {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", stringid.TruncateID(leak.ContainerID), stringid.TruncateID(leak.MountID), leak.Path)
+		}
+		w.Flush()
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package system
 import (
 	"fmt"
 
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/prune"
@@ -39,21 +40,29 @@ const (
 	warning = `WARNING! This will remove:
 	- all stopped containers
 	- all volumes not used by at least one container
-	%s
+	%s%s
 Are you sure you want to continue?`
 
+	networkDesc = "- all networks not used by at least one container\n\t"
+
 	danglingImageDesc = "- all dangling images"
 	allImageDesc      = `- all images without at least one container associated to them`
 )
 
 func runPrune(dockerCli *command.DockerCli, opts pruneOptions) error {
-	var message string
+	// The networks are only pruned by the daemons supporting it.
+	pruneNetworks := !versions.LessThan(dockerCli.Client().ClientVersion(), "1.25")
 
+	var networks, images string
+	if pruneNetworks {
+		networks = networkDesc
+	}
 	if opts.all {
-		message = fmt.Sprintf(warning, allImageDesc)
+		images = allImageDesc
 	} else {
-		message = fmt.Sprintf(warning, danglingImageDesc)
+		images = danglingImageDesc
 	}
+	message := fmt.Sprintf(warning, networks, images)
 
 	if !opts.force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), message) {
 		return nil
@@ -75,6 +84,16 @@ func runPrune(dockerCli *command.DockerCli, opts pruneOptions) error {
 		}
 	}
 
+	if pruneNetworks {
+		_, networkOutput, err := prune.RunNetworkPrune(dockerCli)
+		if err != nil {
+			return err
+		}
+		if networkOutput != "" {
+			fmt.Fprintln(dockerCli.Out(), networkOutput)
+		}
+	}
+
 	spc, output, err := prune.RunImagePrune(dockerCli, opts.all)
 	if err != nil {
 		return err
@@ -0,0 +1,27 @@
+package trust
+
+import (
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/image"
+	"github.com/docker/notary/cryptoservice"
+	"github.com/docker/notary/passphrase"
+	"github.com/docker/notary/trustmanager"
+)
+
+// daemonKeyAlias is the name of the trust key of the daemon in the prompts.
+const daemonKeyAlias = "daemon"
+
+// daemonKeyRetriever returns a retriever which prompts for the passphrase of
+// the trust key of the daemon.
+func daemonKeyRetriever(dockerCli *command.DockerCli) passphrase.Retriever {
+	return passphrase.PromptRetrieverWithInOut(dockerCli.In(), dockerCli.Out(), nil)
+}
+
+// signingKeys returns the crypto service of the signing keys of the client.
+func signingKeys(retriever passphrase.Retriever) (*cryptoservice.CryptoService, error) {
+	keyStore, err := trustmanager.NewKeyFileStore(image.TrustDirectory(), retriever)
+	if err != nil {
+		return nil, err
+	}
+	return cryptoservice.NewCryptoService(keyStore), nil
+}
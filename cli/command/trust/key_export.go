@@ -0,0 +1,99 @@
+package trust
+
+import (
+	"errors"
+	"io"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/image"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"github.com/spf13/cobra"
+)
+
+type keyExportOptions struct {
+	repository string
+	output     string
+}
+
+func newKeyExportCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts keyExportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] [REPOSITORY]",
+		Short: "Export the trust key of the daemon, or the signing keys of a repository",
+		Args:  cli.RequiresMaxArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repository = args[0]
+			}
+			return runKeyExport(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file")
+
+	return cmd
+}
+
+func runKeyExport(dockerCli *command.DockerCli, opts keyExportOptions) error {
+	// The passphrases are prompted on the output, so the keys are never
+	// written to it.
+	if opts.output == "" {
+		return errors.New("The keys must be exported to a file. Use the -o flag.")
+	}
+
+	if opts.repository == "" {
+		return exportDaemonKey(dockerCli, opts.output)
+	}
+	return exportSigningKeys(dockerCli, opts.repository, opts.output)
+}
+
+// exportDaemonKey exports the trust key of the daemon, encrypted with a new
+// passphrase, in PEM format.
+func exportDaemonKey(dockerCli *command.DockerCli, output string) error {
+	passphrase, _, err := daemonKeyRetriever(dockerCli)("", daemonKeyAlias, true, 0)
+	if err != nil {
+		return err
+	}
+
+	responseBody, err := dockerCli.Client().TrustKeyExport(context.Background(), types.TrustKeyExportOptions{
+		Passphrase: passphrase,
+	})
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	return command.CopyToFile(output, responseBody)
+}
+
+// exportSigningKeys exports the signing keys of the repository, encrypted
+// with new passphrases, in a zip archive.
+func exportSigningKeys(dockerCli *command.DockerCli, repository, output string) error {
+	named, err := reference.ParseNamed(repository)
+	if err != nil {
+		return err
+	}
+	repoInfo, err := registry.ParseRepositoryInfo(named)
+	if err != nil {
+		return err
+	}
+
+	retriever := image.GetPassphraseRetriever(dockerCli)
+	keys, err := signingKeys(retriever)
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(keys.ExportKeysByGUN(pw, repoInfo.FullName(), retriever))
+	}()
+	return command.CopyToFile(output, pr)
+}
@@ -0,0 +1,165 @@
+package trust
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/image"
+	"github.com/docker/notary/cryptoservice"
+	"github.com/docker/notary/passphrase"
+	"github.com/docker/notary/trustmanager"
+	"github.com/docker/notary/tuf/data"
+	"github.com/spf13/cobra"
+)
+
+// zipMagic is the signature at the start of the zip archives.
+var zipMagic = []byte("PK\x03\x04")
+
+func newKeyImportCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import the trust key of the daemon, or the signing keys of a repository",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKeyImport(dockerCli, args[0])
+		},
+	}
+}
+
+// runKeyImport imports the keys of the file. A zip archive holds the signing
+// keys of a repository, as exported by `docker trust key export REPOSITORY`;
+// otherwise the file holds the trust key of the daemon in PEM format.
+func runKeyImport(dockerCli *command.DockerCli, file string) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if bytes.HasPrefix(content, zipMagic) {
+		return importSigningKeys(dockerCli, content)
+	}
+	return importDaemonKey(dockerCli, content)
+}
+
+// importDaemonKey replaces the trust key of the daemon.
+func importDaemonKey(dockerCli *command.DockerCli, content []byte) error {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return errors.New("The file is neither a zip archive of signing keys nor a PEM encoded key.")
+	}
+
+	var passphrase string
+	if x509.IsEncryptedPEMBlock(block) {
+		var err error
+		passphrase, _, err = daemonKeyRetriever(dockerCli)("", daemonKeyAlias, false, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := dockerCli.Client().TrustKeyImport(context.Background(), types.TrustKeyImportOptions{
+		Key:        content,
+		Passphrase: passphrase,
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), "The trust key of the daemon is imported. Restart the daemon to use it.")
+	return nil
+}
+
+// importSigningKeys adds the signing keys of the archive to the signing keys
+// of the client.
+func importSigningKeys(dockerCli *command.DockerCli, content []byte) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	}
+
+	retriever := image.GetPassphraseRetriever(dockerCli)
+	keys, err := signingKeys(retriever)
+	if err != nil {
+		return err
+	}
+	return importKeysZip(keys, zipReader, retriever)
+}
+
+// importKeysZip adds the keys of the archive to cs as its ImportKeysZip
+// method does, without copying the archive reader. The root keys must be
+// encrypted, and the other keys are decrypted with a passphrase of retriever
+// if they are encrypted. No key is added if one of them is invalid, and the
+// keys added are removed if one of them cannot be added.
+func importKeysZip(cs *cryptoservice.CryptoService, zipReader *zip.Reader, retriever passphrase.Retriever) error {
+	newKeys := make(map[string][]byte)
+	for _, f := range zipReader.File {
+		name := strings.TrimSuffix(f.Name, path.Ext(f.Name))
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		pemBytes, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(name, "_root") {
+			if err := cryptoservice.CheckRootKeyIsEncrypted(pemBytes); err != nil {
+				return err
+			}
+		}
+		newKeys[name] = pemBytes
+	}
+
+	// The keys are all decoded before any of them is added.
+	var keys []importedKey
+	for name, pemBytes := range newKeys {
+		_, keyInfo, err := trustmanager.KeyInfoFromPEM(pemBytes, name)
+		if err != nil {
+			return err
+		}
+		privKey, err := trustmanager.ParsePEMPrivateKey(pemBytes, "")
+		if err != nil {
+			privKey, _, err = trustmanager.GetPasswdDecryptBytes(retriever, pemBytes, "", "imported "+keyInfo.Role)
+			if err != nil {
+				return err
+			}
+		}
+		keys = append(keys, importedKey{info: keyInfo, key: privKey})
+	}
+
+	var added []string
+	for _, k := range keys {
+		if _, _, err := cs.GetPrivateKey(k.key.ID()); err == nil {
+			// Already imported, it must not be removed on a rollback.
+			continue
+		}
+		if err := cs.AddKey(k.info.Role, k.info.Gun, k.key); err != nil {
+			for _, id := range added {
+				if rerr := cs.RemoveKey(id); rerr != nil {
+					logrus.Warnf("Failed to remove the imported key %s: %v", id, rerr)
+				}
+			}
+			return err
+		}
+		added = append(added, k.key.ID())
+	}
+	return nil
+}
+
+// importedKey is a key of an archive to import, with its role and GUN.
+type importedKey struct {
+	info trustmanager.KeyInfo
+	key  data.PrivateKey
+}
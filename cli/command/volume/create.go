@@ -107,4 +107,10 @@ Another example:
 
     $ docker volume create --driver local --opt type=btrfs --opt device=/dev/sda2
 
+Remote **nfs** and **cifs** filesystems are mounted when the first container
+that uses the volume starts, the host names in their **addr** option or device
+being resolved by the driver:
+
+    $ docker volume create --driver local --opt type=nfs --opt o=addr=nas.example.com,rw --opt device=:/export
+
 `
@@ -87,5 +87,6 @@ The currently supported filters are:
 * **driver** (a volume driver's name)
 * **label** (**label=<key>** or **label=<key>=<value>**)
 * **name** (a volume's name)
+* **scope** (**local** or **global**, the scope of a volume's driver)
 
 `
@@ -4,6 +4,8 @@ import (
 	"net/url"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/pkg/flowstream"
 	"golang.org/x/net/context"
 )
 
@@ -28,7 +30,18 @@ func (cli *Client) ContainerAttach(ctx context.Context, container string, option
 	if options.DetachKeys != "" {
 		query.Set("detachKeys", options.DetachKeys)
 	}
+	if options.Scrollback {
+		query.Set("scrollback", "1")
+	}
+	if options.SinceDetach {
+		query.Set("sinceDetach", "1")
+	}
 
 	headers := map[string][]string{"Content-Type": {"text/plain"}}
+	if options.FlowControl && (cli.version == "" || versions.GreaterThanOrEqualTo(cli.version, "1.25")) {
+		// Flow control is only requested from the API versions that support
+		// it, and the daemons that do not support it use the raw stream.
+		headers["Upgrade"] = []string{flowstream.Protocol + ", tcp"}
+	}
 	return cli.postHijacked(ctx, "/containers/"+container+"/attach", query, nil, headers)
 }
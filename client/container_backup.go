@@ -0,0 +1,35 @@
+package client
+
+import (
+	"io"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// ContainerBackup retrieves an archive of the changes of the filesystem of a
+// container since the snapshot taken by an earlier backup, or since its image
+// if since is empty. It returns the snapshot taken by this backup, to pass as
+// since to the next one. It's up to the caller to close the stream.
+func (cli *Client) ContainerBackup(ctx context.Context, containerID, since string) (io.ReadCloser, string, error) {
+	query := url.Values{}
+	if since != "" {
+		query.Set("since", since)
+	}
+
+	resp, err := cli.get(ctx, "/containers/"+containerID+"/backup", query, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.body, resp.header.Get("X-Docker-Container-Snapshot"), nil
+}
+
+// ContainerRestore replays an archive returned by ContainerBackup onto the
+// filesystem of a container which is not running.
+func (cli *Client) ContainerRestore(ctx context.Context, containerID string, content io.Reader) error {
+	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
+	resp, err := cli.postRaw(ctx, "/containers/"+containerID+"/restore", nil, content, headers)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestContainerBackupError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, _, err := client.ContainerBackup(context.Background(), "nothing", "")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerBackup(t *testing.T) {
+	expectedURL := "/containers/container_id/backup"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if since := r.URL.Query().Get("since"); since != "sha256:abc" {
+				return nil, fmt.Errorf("since not set in URL query properly. Expected 'sha256:abc', got %s", since)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"X-Docker-Container-Snapshot": []string{"sha256:def"}},
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	body, snapshot, err := client.ContainerBackup(context.Background(), "container_id", "sha256:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if snapshot != "sha256:def" {
+		t.Fatalf("expected snapshot sha256:def, got %s", snapshot)
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "response" {
+		t.Fatalf("expected response to contain 'response', got %s", string(content))
+	}
+}
+
+func TestContainerRestoreError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusConflict, "Conflict")),
+	}
+	err := client.ContainerRestore(context.Background(), "nothing", bytes.NewReader(nil))
+	if err == nil || err.Error() != "Error response from daemon: Conflict" {
+		t.Fatalf("expected a Conflict error, got %v", err)
+	}
+}
+
+func TestContainerRestore(t *testing.T) {
+	expectedURL := "/containers/container_id/restore"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", r.Method)
+			}
+			content, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			if string(content) != "archive" {
+				return nil, fmt.Errorf("expected the archive as body, got %s", string(content))
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}),
+	}
+	if err := client.ContainerRestore(context.Background(), "container_id", strings.NewReader("archive")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestContainerCloneError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerClone(context.Background(), "container_id", types.ContainerCloneConfig{}, "clone")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerClone(t *testing.T) {
+	expectedURL := "/containers/container_id/clone"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			name := req.URL.Query().Get("name")
+			if name != "clone" {
+				return nil, fmt.Errorf("container name not set in URL query properly. Expected `clone`, got %s", name)
+			}
+			var config types.ContainerCloneConfig
+			if err := json.NewDecoder(req.Body).Decode(&config); err != nil {
+				return nil, err
+			}
+			if config.Image != ":v2" || len(config.Env) != 1 || config.Env[0] != "A=1" || !config.CopyVolumes {
+				return nil, fmt.Errorf("clone config not sent properly, got %+v", config)
+			}
+			b, err := json.Marshal(types.ContainerCreateResponse{
+				ID: "clone_id",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	r, err := client.ContainerClone(context.Background(), "container_id", types.ContainerCloneConfig{
+		Image:       ":v2",
+		Env:         []string{"A=1"},
+		CopyVolumes: true,
+	}, "clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != "clone_id" {
+		t.Fatalf("expected `clone_id`, got %s", r.ID)
+	}
+}
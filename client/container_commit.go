@@ -40,6 +40,12 @@ func (cli *Client) ContainerCommit(ctx context.Context, container string, option
 	if options.Pause != true {
 		query.Set("pause", "0")
 	}
+	if options.Consistent {
+		if err := cli.NewVersionError("1.25", "consistent commit"); err != nil {
+			return types.ContainerCommitResponse{}, err
+		}
+		query.Set("consistent", "1")
+	}
 
 	var response types.ContainerCommitResponse
 	resp, err := cli.post(ctx, "/commit", query, options.Config, nil)
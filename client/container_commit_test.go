@@ -94,3 +94,52 @@ func TestContainerCommit(t *testing.T) {
 		t.Fatalf("expected `container_id`, got %s", r.ID)
 	}
 }
+
+func TestContainerCommitConsistent(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			if pause := query.Get("pause"); pause != "" {
+				return nil, fmt.Errorf("container pause not set in URL query properly. Expected '', got %v'", pause)
+			}
+			if consistent := query.Get("consistent"); consistent != "1" {
+				return nil, fmt.Errorf("container consistent not set in URL query properly. Expected '1', got %v'", consistent)
+			}
+			b, err := json.Marshal(types.ContainerCommitResponse{
+				ID:     "new_container_id",
+				Timing: &types.ContainerCommitTiming{Commit: 1000, Frozen: 1500},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	r, err := client.ContainerCommit(context.Background(), "container_id", types.ContainerCommitOptions{
+		Pause:      true,
+		Consistent: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Timing == nil || r.Timing.Commit != 1000 || r.Timing.Frozen != 1500 {
+		t.Fatalf("expected the timing of the commit, got %+v", r.Timing)
+	}
+}
+
+func TestContainerCommitConsistentVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.24",
+	}
+	_, err := client.ContainerCommit(context.Background(), "container_id", types.ContainerCommitOptions{
+		Consistent: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
@@ -53,6 +53,36 @@ func (cli *Client) CopyToContainer(ctx context.Context, container, path string,
 	return nil
 }
 
+// CopyBetweenContainers copies content from the filesystem of a container into
+// the filesystem of another container. The content is not sent through the client.
+func (cli *Client) CopyBetweenContainers(ctx context.Context, srcContainer, srcPath, dstContainer, dstPath string, options types.CopyBetweenContainersOptions) error {
+	query := url.Values{}
+	query.Set("path", filepath.ToSlash(dstPath)) // Normalize the paths used in the API.
+	query.Set("fromContainer", srcContainer)
+	query.Set("fromPath", filepath.ToSlash(srcPath))
+	// Do not allow for an existing directory to be overwritten by a non-directory and vice versa.
+	if !options.AllowOverwriteDirWithFile {
+		query.Set("noOverwriteDirNonDir", "true")
+	}
+	if options.FollowLink {
+		query.Set("followLink", "1")
+	}
+
+	apiPath := fmt.Sprintf("/containers/%s/archive", dstContainer)
+
+	response, err := cli.putRaw(ctx, apiPath, query, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer ensureReaderClosed(response)
+
+	if response.statusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code from daemon: %d", response.statusCode)
+	}
+
+	return nil
+}
+
 // CopyFromContainer gets the content from the container and returns it as a Reader
 // to manipulate it in the host. It's up to the caller to close the reader.
 func (cli *Client) CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
@@ -151,6 +151,44 @@ func TestCopyToContainer(t *testing.T) {
 	}
 }
 
+func TestCopyBetweenContainers(t *testing.T) {
+	expectedURL := "/containers/dst_id/archive"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "PUT" {
+				return nil, fmt.Errorf("expected PUT method, got %s", req.Method)
+			}
+			query := req.URL.Query()
+			expectedQuery := map[string]string{
+				"path":                 "/dst/dir",
+				"fromContainer":        "src_id",
+				"fromPath":             "/src/file",
+				"noOverwriteDirNonDir": "true",
+				"followLink":           "1",
+			}
+			for key, expected := range expectedQuery {
+				if actual := query.Get(key); actual != expected {
+					return nil, fmt.Errorf("%s not set in URL query properly, expected '%s', got '%s'", key, expected, actual)
+				}
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+	err := client.CopyBetweenContainers(context.Background(), "src_id", "/src/file", "dst_id", "/dst/dir", types.CopyBetweenContainersOptions{
+		FollowLink: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCopyFromContainerError(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ContainerCoreDumps returns the core dumps captured for a container, oldest
+// first.
+func (cli *Client) ContainerCoreDumps(ctx context.Context, containerID string) ([]types.CoreDump, error) {
+	var cores []types.CoreDump
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/cores", url.Values{}, nil)
+	if err != nil {
+		return cores, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&cores)
+	ensureReaderClosed(serverResp)
+	return cores, err
+}
+
+// ContainerCoreDump retrieves a core dump captured for a container and
+// returns it as an io.ReadCloser. It's up to the caller to close the stream.
+func (cli *Client) ContainerCoreDump(ctx context.Context, containerID, core string) (io.ReadCloser, error) {
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/cores/"+core, url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverResp.body, nil
+}
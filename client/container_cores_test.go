@@ -0,0 +1,92 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestContainerCoreDumpsError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerCoreDumps(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerCoreDumps(t *testing.T) {
+	expectedURL := "/containers/container_id/cores"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			b, err := json.Marshal([]types.CoreDump{
+				{Name: "core.app.42", Size: 1024},
+				{Name: "core.app.57", Size: 2048},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	cores, err := client.ContainerCoreDumps(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cores) != 2 || cores[0].Name != "core.app.42" || cores[1].Size != 2048 {
+		t.Fatalf("expected 2 core dumps, got %v", cores)
+	}
+}
+
+func TestContainerCoreDumpError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "No such core dump")),
+	}
+	_, err := client.ContainerCoreDump(context.Background(), "nothing", "core.app.42")
+	if err == nil || err.Error() != "Error response from daemon: No such core dump" {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestContainerCoreDump(t *testing.T) {
+	expectedURL := "/containers/container_id/cores/core.app.42"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("ELF"))),
+			}, nil
+		}),
+	}
+
+	body, err := client.ContainerCoreDump(context.Background(), "container_id", "core.app.42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "ELF" {
+		t.Fatalf("expected the content of the core dump, got %q", content)
+	}
+}
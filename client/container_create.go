@@ -19,12 +19,22 @@ type configWrapper struct {
 
 // ContainerCreate creates a new container based in the given configuration.
 // It can be associated with a name, but it's not mandatory.
-func (cli *Client) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, containerName string) (types.ContainerCreateResponse, error) {
+func (cli *Client) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, containerName string, options types.ContainerCreateOptions) (types.ContainerCreateResponse, error) {
 	var response types.ContainerCreateResponse
+
+	if hostConfig != nil && len(hostConfig.EnvFileIDs) > 0 {
+		if err := cli.NewVersionError("1.25", "environment files"); err != nil {
+			return response, err
+		}
+	}
+
 	query := url.Values{}
 	if containerName != "" {
 		query.Set("name", containerName)
 	}
+	if options.ForceOomKillDisable {
+		query.Set("forceoomkilldisable", "1")
+	}
 
 	body := configWrapper{
 		Config:           config,
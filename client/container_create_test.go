@@ -18,7 +18,7 @@ func TestContainerCreateError(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
 	}
-	_, err := client.ContainerCreate(context.Background(), nil, nil, nil, "nothing")
+	_, err := client.ContainerCreate(context.Background(), nil, nil, nil, "nothing", types.ContainerCreateOptions{})
 	if err == nil || err.Error() != "Error response from daemon: Server error" {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
@@ -27,17 +27,29 @@ func TestContainerCreateError(t *testing.T) {
 	client = &Client{
 		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
 	}
-	_, err = client.ContainerCreate(context.Background(), nil, nil, nil, "nothing")
+	_, err = client.ContainerCreate(context.Background(), nil, nil, nil, "nothing", types.ContainerCreateOptions{})
 	if err == nil || err.Error() != "Error response from daemon: Server error" {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
 }
 
+func TestContainerCreateEnvFilesVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	hostConfig := &container.HostConfig{EnvFileIDs: []string{"db"}}
+	_, err := client.ContainerCreate(context.Background(), &container.Config{}, hostConfig, nil, "", types.ContainerCreateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
 func TestContainerCreateImageNotFound(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusNotFound, "No such image")),
 	}
-	_, err := client.ContainerCreate(context.Background(), &container.Config{Image: "unknown_image"}, nil, nil, "unknown")
+	_, err := client.ContainerCreate(context.Background(), &container.Config{Image: "unknown_image"}, nil, nil, "unknown", types.ContainerCreateOptions{})
 	if err == nil || !IsErrImageNotFound(err) {
 		t.Fatalf("expected an imageNotFound error, got %v", err)
 	}
@@ -67,7 +79,7 @@ func TestContainerCreateWithName(t *testing.T) {
 		}),
 	}
 
-	r, err := client.ContainerCreate(context.Background(), nil, nil, nil, "container_name")
+	r, err := client.ContainerCreate(context.Background(), nil, nil, nil, "container_name", types.ContainerCreateOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -75,3 +87,27 @@ func TestContainerCreateWithName(t *testing.T) {
 		t.Fatalf("expected `container_id`, got %s", r.ID)
 	}
 }
+
+func TestContainerCreateForceOomKillDisable(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if force := req.URL.Query().Get("forceoomkilldisable"); force != "1" {
+				return nil, fmt.Errorf("forceoomkilldisable not set in URL query properly. Expected `1`, got %s", force)
+			}
+			b, err := json.Marshal(types.ContainerCreateResponse{
+				ID: "container_id",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	if _, err := client.ContainerCreate(context.Background(), nil, nil, nil, "", types.ContainerCreateOptions{ForceOomKillDisable: true}); err != nil {
+		t.Fatal(err)
+	}
+}
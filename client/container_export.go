@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/url"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
@@ -11,7 +12,24 @@ import (
 // and returns them as an io.ReadCloser. It's up to the caller
 // to close the stream.
 func (cli *Client) ContainerExport(ctx context.Context, containerID string) (io.ReadCloser, error) {
-	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/export", url.Values{}, nil)
+	return cli.ContainerExportWithOptions(ctx, containerID, types.ContainerExportOptions{})
+}
+
+// ContainerExportWithOptions retrieves the contents of a container in the
+// format requested by the options and returns them as an io.ReadCloser.
+// It's up to the caller to close the stream.
+func (cli *Client) ContainerExportWithOptions(ctx context.Context, containerID string, options types.ContainerExportOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	// A tar archive is the only format of the daemons before 1.25, so it
+	// is not passed to keep exporting from them.
+	if options.Format != "" && options.Format != "tar" {
+		if err := cli.NewVersionError("1.25", "export format"); err != nil {
+			return nil, err
+		}
+		query.Set("format", options.Format)
+	}
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/export", query, nil)
 	if err != nil {
 		return nil, err
 	}
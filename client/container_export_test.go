@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
@@ -28,6 +29,9 @@ func TestContainerExport(t *testing.T) {
 			if !strings.HasPrefix(r.URL.Path, expectedURL) {
 				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
 			}
+			if format := r.URL.Query().Get("format"); format != "oci-bundle" {
+				return nil, fmt.Errorf("format not set in URL query properly. Expected 'oci-bundle', got %s", format)
+			}
 
 			return &http.Response{
 				StatusCode: http.StatusOK,
@@ -35,7 +39,7 @@ func TestContainerExport(t *testing.T) {
 			}, nil
 		}),
 	}
-	body, err := client.ContainerExport(context.Background(), "container_id")
+	body, err := client.ContainerExportWithOptions(context.Background(), "container_id", types.ContainerExportOptions{Format: "oci-bundle"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -48,3 +52,34 @@ func TestContainerExport(t *testing.T) {
 		t.Fatalf("expected response to contain 'response', got %s", string(content))
 	}
 }
+
+func TestContainerExportFormatVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerExportWithOptions(context.Background(), "container_id", types.ContainerExportOptions{Format: "oci-bundle"})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestContainerExportTarVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if _, ok := r.URL.Query()["format"]; ok {
+				return nil, fmt.Errorf("format should not be set in URL query for a tar export")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	body, err := client.ContainerExportWithOptions(context.Background(), "container_id", types.ContainerExportOptions{Format: "tar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ContainerFilesystemUsage returns the usage of the read-write layer of a
+// container. The daemon caches the usage for a short time, unless refresh is
+// set.
+func (cli *Client) ContainerFilesystemUsage(ctx context.Context, containerID string, refresh bool) (types.ContainerFilesystemUsage, error) {
+	var usage types.ContainerFilesystemUsage
+
+	query := url.Values{}
+	if refresh {
+		query.Set("refresh", "1")
+	}
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/filesystem-usage", query, nil)
+	if err != nil {
+		return usage, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&usage)
+	ensureReaderClosed(serverResp)
+	return usage, err
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestContainerFilesystemUsageError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerFilesystemUsage(context.Background(), "nothing", false)
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerFilesystemUsage(t *testing.T) {
+	expectedURL := "/containers/container_id/filesystem-usage"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if refresh := req.URL.Query().Get("refresh"); refresh != "1" {
+				return nil, fmt.Errorf("refresh not set in URL query properly. Expected '1', got %s", refresh)
+			}
+			b, err := json.Marshal(types.ContainerFilesystemUsage{
+				SizeRw: 110,
+				Inodes: 4,
+				Directories: []types.ContainerDirectoryUsage{
+					{Path: "/var", Size: 100, Inodes: 3},
+					{Path: "/", Size: 10, Inodes: 1},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	usage, err := client.ContainerFilesystemUsage(context.Background(), "container_id", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.SizeRw != 110 || len(usage.Directories) != 2 || usage.Directories[0].Path != "/var" {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"net/url"
+	"time"
+
+	timetypes "github.com/docker/docker/api/types/time"
+	"golang.org/x/net/context"
+)
+
+// ContainerHandoff starts a container in place of the running container
+// from, handing off the host ports they both publish. from is stopped,
+// waiting for the timeout before killing it, or its stop timeout if nil.
+func (cli *Client) ContainerHandoff(ctx context.Context, containerID, from string, timeout *time.Duration) error {
+	if err := cli.NewVersionError("1.25", "container handoff"); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("from", from)
+	if timeout != nil {
+		query.Set("t", timetypes.DurationToSecondsString(*timeout))
+	}
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/handoff", query, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
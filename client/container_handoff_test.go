@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestContainerHandoffError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerHandoff(context.Background(), "nothing", "old", nil)
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerHandoffVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerHandoff(context.Background(), "nothing", "old", nil)
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestContainerHandoff(t *testing.T) {
+	expectedURL := "/containers/container_id/handoff"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			query := req.URL.Query()
+			if from := query.Get("from"); from != "old_id" {
+				return nil, fmt.Errorf("from not set in URL query properly. Expected 'old_id', got %s", from)
+			}
+			if t := query.Get("t"); t != "30" {
+				return nil, fmt.Errorf("t (timeout) not set in URL query properly. Expected '30', got %s", t)
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+	timeout := 30 * time.Second
+	if err := client.ContainerHandoff(context.Background(), "container_id", "old_id", &timeout); err != nil {
+		t.Fatal(err)
+	}
+}
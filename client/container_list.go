@@ -34,6 +34,10 @@ func (cli *Client) ContainerList(ctx context.Context, options types.ContainerLis
 		query.Set("size", "1")
 	}
 
+	if options.Resources {
+		query.Set("resources", "1")
+	}
+
 	if options.Filter.Len() > 0 {
 		filterJSON, err := filters.ToParamWithVersion(cli.version, options.Filter)
 
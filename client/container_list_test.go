@@ -53,6 +53,10 @@ func TestContainerList(t *testing.T) {
 			if size != "1" {
 				return nil, fmt.Errorf("size not set in URL query properly. Expected '1', got %s", size)
 			}
+			resources := query.Get("resources")
+			if resources != "1" {
+				return nil, fmt.Errorf("resources not set in URL query properly. Expected '1', got %s", resources)
+			}
 			filters := query.Get("filters")
 			if filters != expectedFilters {
 				return nil, fmt.Errorf("expected filters incoherent '%v' with actual filters %v", expectedFilters, filters)
@@ -82,10 +86,11 @@ func TestContainerList(t *testing.T) {
 	filters.Add("label", "label2")
 	filters.Add("before", "container")
 	containers, err := client.ContainerList(context.Background(), types.ContainerListOptions{
-		Size:   true,
-		All:    true,
-		Since:  "container",
-		Filter: filters,
+		Size:      true,
+		Resources: true,
+		All:       true,
+		Since:     "container",
+		Filter:    filters,
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ContainerPortsCheck asks the daemon whether the port bindings can be allocated now
+func (cli *Client) ContainerPortsCheck(ctx context.Context, req types.PortsCheckRequest) (types.PortsCheckResponse, error) {
+	var resp types.PortsCheckResponse
+
+	if err := cli.NewVersionError("1.25", "ports check"); err != nil {
+		return resp, err
+	}
+
+	serverResp, err := cli.post(ctx, "/containers/ports/check", nil, req, nil)
+	if err != nil {
+		return resp, err
+	}
+	defer ensureReaderClosed(serverResp)
+
+	err = json.NewDecoder(serverResp.body).Decode(&resp)
+	return resp, err
+}
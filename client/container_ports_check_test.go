@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"golang.org/x/net/context"
+)
+
+func TestContainerPortsCheckError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.ContainerPortsCheck(context.Background(), types.PortsCheckRequest{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerPortsCheckVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.ContainerPortsCheck(context.Background(), types.PortsCheckRequest{})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestContainerPortsCheck(t *testing.T) {
+	expectedURL := "/containers/ports/check"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var portsCheck types.PortsCheckRequest
+			if err := json.NewDecoder(req.Body).Decode(&portsCheck); err != nil {
+				return nil, err
+			}
+			if pbs := portsCheck.PortBindings["80/tcp"]; len(pbs) != 1 || pbs[0].HostPort != "8080" {
+				return nil, fmt.Errorf("unexpected port bindings %v", portsCheck.PortBindings)
+			}
+			b, err := json.Marshal(types.PortsCheckResponse{
+				Conflicts: []types.PortConflict{{Port: "80/tcp", HostPort: "8080", ContainerName: "web"}},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	resp, err := client.ContainerPortsCheck(context.Background(), types.PortsCheckRequest{
+		PortBindings: nat.PortMap{"80/tcp": {{HostPort: "8080"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Conflicts) != 1 || resp.Conflicts[0].ContainerName != "web" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
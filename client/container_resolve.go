@@ -0,0 +1,31 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ContainerResolve resolves a host name as the running container would,
+// and returns the lookups made and the addresses found.
+func (cli *Client) ContainerResolve(ctx context.Context, containerID, name string) (types.ContainerResolveResponse, error) {
+	var resp types.ContainerResolveResponse
+
+	if err := cli.NewVersionError("1.25", "container resolve"); err != nil {
+		return resp, err
+	}
+
+	query := url.Values{}
+	query.Set("name", name)
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/resolve", query, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&resp)
+	ensureReaderClosed(serverResp)
+	return resp, err
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestContainerResolveError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerResolve(context.Background(), "nothing", "db")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerResolve(t *testing.T) {
+	expectedURL := "/containers/container_id/resolve"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if name := req.URL.Query().Get("name"); name != "db" {
+				return nil, fmt.Errorf("name not set in URL query properly. Expected 'db', got %s", name)
+			}
+			b, err := json.Marshal(types.ContainerResolveResponse{
+				Name: "db",
+				Attempts: []types.ContainerResolveAttempt{
+					{Name: "db", Source: "hosts"},
+					{Name: "db", Source: "embedded", Addresses: []string{"172.18.0.2"}},
+				},
+				Addresses: []string{"172.18.0.2"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	resp, err := client.ContainerResolve(context.Background(), "container_id", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Attempts) != 2 || len(resp.Addresses) != 1 || resp.Addresses[0] != "172.18.0.2" {
+		t.Fatalf("expected db to resolve to 172.18.0.2, got %v", resp)
+	}
+}
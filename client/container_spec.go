@@ -0,0 +1,27 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/net/context"
+)
+
+// ContainerSpec returns the OCI runtime spec the container would be started
+// with. The container must not be running.
+func (cli *Client) ContainerSpec(ctx context.Context, containerID string) (specs.Spec, error) {
+	var spec specs.Spec
+	if err := cli.NewVersionError("1.25", "container spec"); err != nil {
+		return spec, err
+	}
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/spec", url.Values{}, nil)
+	if err != nil {
+		return spec, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&spec)
+	ensureReaderClosed(serverResp)
+	return spec, err
+}
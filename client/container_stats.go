@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"net/url"
 
 	"github.com/docker/docker/api/types"
@@ -24,3 +25,22 @@ func (cli *Client) ContainerStats(ctx context.Context, containerID string, strea
 	osType := GetDockerOS(resp.header.Get("Server"))
 	return types.ContainerStats{Body: resp.body, OSType: osType}, err
 }
+
+// ContainerStatsSummary returns a one-shot summary of the resource usage of a
+// given container, with the CPU percentage computed by the daemon.
+func (cli *Client) ContainerStatsSummary(ctx context.Context, containerID string) (types.StatsSummary, error) {
+	var summary types.StatsSummary
+
+	query := url.Values{}
+	query.Set("stream", "0")
+	query.Set("summary", "1")
+
+	resp, err := cli.get(ctx, "/containers/"+containerID+"/stats", query, nil)
+	if err != nil {
+		return summary, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&summary)
+	ensureReaderClosed(resp)
+	return summary, err
+}
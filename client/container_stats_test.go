@@ -2,12 +2,14 @@ package client
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
@@ -68,3 +70,46 @@ func TestContainerStats(t *testing.T) {
 		}
 	}
 }
+
+func TestContainerStatsSummaryError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerStatsSummary(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerStatsSummary(t *testing.T) {
+	expectedURL := "/containers/container_id/stats"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			query := r.URL.Query()
+			if stream := query.Get("stream"); stream != "0" {
+				return nil, fmt.Errorf("stream not set in URL query properly. Expected '0', got %s", stream)
+			}
+			if summary := query.Get("summary"); summary != "1" {
+				return nil, fmt.Errorf("summary not set in URL query properly. Expected '1', got %s", summary)
+			}
+			b, err := json.Marshal(types.StatsSummary{ID: "container_id", CPUPercentage: 12.5})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	summary, err := client.ContainerStatsSummary(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.ID != "container_id" || summary.CPUPercentage != 12.5 {
+		t.Fatalf("expected summary for container_id with 12.5%% CPU, got %+v", summary)
+	}
+}
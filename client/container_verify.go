@@ -0,0 +1,27 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ContainerVerify compares the configuration of a running container with
+// the state of the kernel, and returns the differences.
+func (cli *Client) ContainerVerify(ctx context.Context, containerID string) (types.ContainerVerifyReport, error) {
+	var report types.ContainerVerifyReport
+	if err := cli.NewVersionError("1.25", "container verify"); err != nil {
+		return report, err
+	}
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/verify", url.Values{}, nil)
+	if err != nil {
+		return report, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&report)
+	ensureReaderClosed(serverResp)
+	return report, err
+}
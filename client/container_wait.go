@@ -11,16 +11,23 @@ import (
 // ContainerWait pauses execution until a container exits.
 // It returns the API status code as response of its readiness.
 func (cli *Client) ContainerWait(ctx context.Context, containerID string) (int, error) {
-	resp, err := cli.post(ctx, "/containers/"+containerID+"/wait", nil, nil, nil)
+	res, err := cli.ContainerWaitExit(ctx, containerID)
 	if err != nil {
 		return -1, err
 	}
-	defer ensureReaderClosed(resp)
+	return res.StatusCode, nil
+}
 
+// ContainerWaitExit pauses execution until a container exits.
+// It returns the exit code of the container, and how it exited.
+func (cli *Client) ContainerWaitExit(ctx context.Context, containerID string) (types.ContainerWaitResponse, error) {
 	var res types.ContainerWaitResponse
-	if err := json.NewDecoder(resp.body).Decode(&res); err != nil {
-		return -1, err
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/wait", nil, nil, nil)
+	if err != nil {
+		return res, err
 	}
+	defer ensureReaderClosed(resp)
 
-	return res.StatusCode, nil
+	err = json.NewDecoder(resp.body).Decode(&res)
+	return res, err
 }
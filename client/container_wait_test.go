@@ -58,6 +58,42 @@ func TestContainerWait(t *testing.T) {
 	}
 }
 
+func TestContainerWaitExit(t *testing.T) {
+	expectedURL := "/containers/container_id/wait"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			b, err := json.Marshal(types.ContainerWaitResponse{
+				StatusCode: 137,
+				Exit: &types.ContainerExitStatus{
+					Signal:    "SIGKILL",
+					OOMKilled: true,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	res, err := client.ContainerWaitExit(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != 137 {
+		t.Fatalf("expected a status code equal to '137', got %d", res.StatusCode)
+	}
+	if res.Exit == nil || res.Exit.Signal != "SIGKILL" || !res.Exit.OOMKilled {
+		t.Fatalf("expected the container to be killed by SIGKILL for running out of memory, got %+v", res.Exit)
+	}
+}
+
 func ExampleClient_ContainerWait_withTimeout() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// EnvFileCreate stores an environment file in the docker host.
+func (cli *Client) EnvFileCreate(ctx context.Context, req types.EnvFileCreateRequest) (types.EnvFile, error) {
+	var f types.EnvFile
+
+	if err := cli.NewVersionError("1.25", "environment file create"); err != nil {
+		return f, err
+	}
+
+	resp, err := cli.post(ctx, "/envfiles/create", nil, req, nil)
+	if err != nil {
+		return f, err
+	}
+	err = json.NewDecoder(resp.body).Decode(&f)
+	ensureReaderClosed(resp)
+	return f, err
+}
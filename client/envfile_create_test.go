@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestEnvFileCreateError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.EnvFileCreate(context.Background(), types.EnvFileCreateRequest{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestEnvFileCreateVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.EnvFileCreate(context.Background(), types.EnvFileCreateRequest{})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestEnvFileCreate(t *testing.T) {
+	expectedURL := "/envfiles/create"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var envFileReq types.EnvFileCreateRequest
+			if err := json.NewDecoder(req.Body).Decode(&envFileReq); err != nil {
+				return nil, err
+			}
+			if envFileReq.Name != "db" || len(envFileReq.Env) != 1 {
+				return nil, fmt.Errorf("unexpected request %+v", envFileReq)
+			}
+			content, err := json.Marshal(types.EnvFile{ID: "envfile_id", Name: "db", Keys: []string{"PASSWORD"}})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	f, err := client.EnvFileCreate(context.Background(), types.EnvFileCreateRequest{
+		Name: "db",
+		Env:  []string{"PASSWORD=secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ID != "envfile_id" {
+		t.Fatalf("unexpected environment file %+v", f)
+	}
+}
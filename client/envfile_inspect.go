@@ -0,0 +1,50 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// EnvFileInspect returns an environment file in the docker host. The values
+// of its variables are only returned if reveal is set.
+func (cli *Client) EnvFileInspect(ctx context.Context, envFileID string, reveal bool) (types.EnvFile, error) {
+	f, _, err := cli.EnvFileInspectWithRaw(ctx, envFileID, reveal)
+	return f, err
+}
+
+// EnvFileInspectWithRaw returns an environment file in the docker host and its raw representation
+func (cli *Client) EnvFileInspectWithRaw(ctx context.Context, envFileID string, reveal bool) (types.EnvFile, []byte, error) {
+	var (
+		f    types.EnvFile
+		resp serverResponse
+		err  error
+	)
+	if err := cli.NewVersionError("1.25", "environment file inspect"); err != nil {
+		return f, nil, err
+	}
+	if reveal {
+		resp, err = cli.post(ctx, "/envfiles/"+envFileID+"/reveal", nil, nil, nil)
+	} else {
+		resp, err = cli.get(ctx, "/envfiles/"+envFileID, nil, nil)
+	}
+	if err != nil {
+		if resp.statusCode == http.StatusNotFound {
+			return f, nil, envFileNotFoundError{envFileID}
+		}
+		return f, nil, err
+	}
+	defer ensureReaderClosed(resp)
+
+	body, err := ioutil.ReadAll(resp.body)
+	if err != nil {
+		return f, nil, err
+	}
+	rdr := bytes.NewReader(body)
+	err = json.NewDecoder(rdr).Decode(&f)
+	return f, body, err
+}
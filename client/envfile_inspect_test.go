@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestEnvFileInspectError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.EnvFileInspect(context.Background(), "nothing", false)
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestEnvFileInspectNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
+	}
+
+	_, err := client.EnvFileInspect(context.Background(), "unknown", false)
+	if err == nil || !IsErrNotFound(err) {
+		t.Fatalf("expected an envFileNotFound error, got %v", err)
+	}
+}
+
+func TestEnvFileInspectVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.EnvFileInspect(context.Background(), "db", false)
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestEnvFileInspect(t *testing.T) {
+	expectedURL := "/envfiles/db"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			f := types.EnvFile{ID: "envfile_id", Name: "db", Keys: []string{"PASSWORD"}}
+			switch {
+			case req.Method == "GET" && req.URL.Path == expectedURL:
+			case req.Method == "POST" && req.URL.Path == expectedURL+"/reveal":
+				f.Env = []string{"PASSWORD=secret"}
+			default:
+				return nil, fmt.Errorf("unexpected request %s %s", req.Method, req.URL)
+			}
+			content, err := json.Marshal(f)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	f, err := client.EnvFileInspect(context.Background(), "db", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ID != "envfile_id" || len(f.Keys) != 1 || len(f.Env) != 0 {
+		t.Fatalf("unexpected environment file %+v", f)
+	}
+
+	f, err = client.EnvFileInspect(context.Background(), "db", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Env) != 1 || f.Env[0] != "PASSWORD=secret" {
+		t.Fatalf("expected the variables to be revealed, got %+v", f)
+	}
+}
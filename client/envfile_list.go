@@ -0,0 +1,27 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// EnvFileList returns the environment files in the docker host, without the
+// values of their variables.
+func (cli *Client) EnvFileList(ctx context.Context) ([]types.EnvFile, error) {
+	var files []types.EnvFile
+
+	if err := cli.NewVersionError("1.25", "environment file list"); err != nil {
+		return files, err
+	}
+
+	resp, err := cli.get(ctx, "/envfiles", nil, nil)
+	if err != nil {
+		return files, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&files)
+	ensureReaderClosed(resp)
+	return files, err
+}
@@ -0,0 +1,13 @@
+package client
+
+import "golang.org/x/net/context"
+
+// EnvFileRemove removes an environment file from the docker host.
+func (cli *Client) EnvFileRemove(ctx context.Context, envFileID string) error {
+	if err := cli.NewVersionError("1.25", "environment file remove"); err != nil {
+		return err
+	}
+	resp, err := cli.delete(ctx, "/envfiles/"+envFileID, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -3,6 +3,8 @@ package client
 import (
 	"errors"
 	"fmt"
+
+	"github.com/docker/docker/api/types/versions"
 )
 
 // ErrConnectionFailed is an error raised when the connection between the client and the server failed.
@@ -109,6 +111,51 @@ func IsErrVolumeNotFound(err error) bool {
 	return IsErrNotFound(err)
 }
 
+// podNotFoundError implements an error returned when a pod is not in the docker host.
+type podNotFoundError struct {
+	podName string
+}
+
+// NotFound indicates that this error type is of NotFound
+func (e podNotFoundError) NotFound() bool {
+	return true
+}
+
+// Error returns a string representation of a podNotFoundError
+func (e podNotFoundError) Error() string {
+	return fmt.Sprintf("Error: No such pod: %s", e.podName)
+}
+
+// envFileNotFoundError implements an error returned when an environment file is not in the docker host.
+type envFileNotFoundError struct {
+	envFileID string
+}
+
+// NotFound indicates that this error type is of NotFound
+func (e envFileNotFoundError) NotFound() bool {
+	return true
+}
+
+// Error returns a string representation of an envFileNotFoundError
+func (e envFileNotFoundError) Error() string {
+	return fmt.Sprintf("Error: No such environment file: %s", e.envFileID)
+}
+
+// jobNotFoundError implements an error returned when a job is not in the docker host.
+type jobNotFoundError struct {
+	jobName string
+}
+
+// NotFound indicates that this error type is of NotFound
+func (e jobNotFoundError) NotFound() bool {
+	return true
+}
+
+// Error returns a string representation of a jobNotFoundError
+func (e jobNotFoundError) Error() string {
+	return fmt.Sprintf("Error: No such job: %s", e.jobName)
+}
+
 // unauthorizedError represents an authorization error in a remote registry.
 type unauthorizedError struct {
 	cause error
@@ -206,3 +253,12 @@ func IsErrPluginPermissionDenied(err error) bool {
 	_, ok := err.(pluginPermissionDenied)
 	return ok
 }
+
+// NewVersionError returns an error if the API version of the client is older
+// than APIrequired, the version that introduced the given feature.
+func (cli *Client) NewVersionError(APIrequired, feature string) error {
+	if cli.version != "" && versions.LessThan(cli.version, APIrequired) {
+		return fmt.Errorf("%q requires API version %s, but the Docker daemon API version is %s", feature, APIrequired, cli.version)
+	}
+	return nil
+}
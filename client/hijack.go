@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bufio"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/flowstream"
 	"github.com/docker/docker/pkg/tlsconfig"
 	"github.com/docker/go-connections/sockets"
 	"golang.org/x/net/context"
@@ -45,7 +47,9 @@ func (cli *Client) postHijacked(ctx context.Context, path string, query url.Valu
 	req.Host = cli.addr
 
 	req.Header.Set("Connection", "Upgrade")
-	req.Header.Set("Upgrade", "tcp")
+	if req.Header.Get("Upgrade") == "" {
+		req.Header.Set("Upgrade", "tcp")
+	}
 
 	tlsConfig, err := resolveTLSConfig(cli.client.Transport)
 	if err != nil {
@@ -74,13 +78,61 @@ func (cli *Client) postHijacked(ctx context.Context, path string, query url.Valu
 	defer clientconn.Close()
 
 	// Server hijacks the connection, error 'connection closed' expected
-	_, err = clientconn.Do(req)
+	resp, err := clientconn.Do(req)
 
 	rwc, br := clientconn.Hijack()
 
+	if resp != nil && resp.Header.Get("Upgrade") == flowstream.Protocol {
+		// The daemon accepted to use flow control: the session is exposed
+		// as a raw stream, so that it is used in the same way.
+		session := flowstream.NewSession(&bufferedConn{Conn: rwc, r: br})
+		conn := &flowConn{
+			Conn:    rwc,
+			session: session,
+			stdin:   session.Stream(flowstream.AttachStdinStream),
+			output:  session.Stream(flowstream.AttachOutputStream),
+		}
+		return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn.output)}, err
+	}
+
 	return types.HijackedResponse{Conn: rwc, Reader: br}, err
 }
 
+// bufferedConn is a connection whose first bytes were buffered by r.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// flowConn is a hijacked connection using flowstream. It writes to the
+// stdin stream and reads from the output stream of the session.
+type flowConn struct {
+	net.Conn
+	session *flowstream.Session
+	stdin   *flowstream.Stream
+	output  *flowstream.Stream
+}
+
+func (c *flowConn) Read(p []byte) (int, error) {
+	return c.output.Read(p)
+}
+
+func (c *flowConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+func (c *flowConn) CloseWrite() error {
+	return c.stdin.CloseWrite()
+}
+
+func (c *flowConn) Close() error {
+	return c.session.Close()
+}
+
 func tlsDial(network, addr string, config *tls.Config) (net.Conn, error) {
 	return tlsDialWithDialer(new(net.Dialer), network, addr, config)
 }
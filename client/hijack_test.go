@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/flowstream"
+	"golang.org/x/net/context"
+)
+
+// serveAttach accepts a connection on l, reads the attach request, sends
+// its Upgrade header to upgrade, and echoes the input back, using flowstream
+// if supported and requested.
+func serveAttach(t *testing.T, l net.Listener, flowControl bool, upgrade chan<- string) {
+	conn, err := l.Accept()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	upgrade <- req.Header.Get("Upgrade")
+	if flowControl && strings.Contains(req.Header.Get("Upgrade"), flowstream.Protocol) {
+		fmt.Fprintf(conn, "HTTP/1.1 101 UPGRADED\r\nConnection: Upgrade\r\nUpgrade: %s\r\n\r\n", flowstream.Protocol)
+		session := flowstream.NewSession(conn)
+		output := session.Stream(flowstream.AttachOutputStream)
+		io.Copy(output, session.Stream(flowstream.AttachStdinStream))
+		output.CloseWrite()
+		session.Close()
+		return
+	}
+	fmt.Fprintf(conn, "HTTP/1.1 101 UPGRADED\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+	io.Copy(conn, conn)
+}
+
+func testContainerAttach(t *testing.T, version string, daemonFlowControl bool) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	upgrade := make(chan string, 1)
+	go serveAttach(t, l, daemonFlowControl, upgrade)
+
+	client, err := NewClient("tcp://"+l.Addr().String(), version, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.ContainerAttach(context.Background(), "container_id", types.ContainerAttachOptions{
+		Stream:      true,
+		Stdin:       true,
+		FlowControl: true,
+	})
+	if err != nil && err != httputil.ErrPersistEOF {
+		t.Fatal(err)
+	}
+	defer resp.Close()
+
+	if _, err := resp.Conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := resp.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(resp.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "hello" {
+		t.Fatalf("expected hello, got %q", b)
+	}
+	return <-upgrade
+}
+
+func TestContainerAttachFlowControl(t *testing.T) {
+	if upgrade := testContainerAttach(t, "1.25", true); upgrade != flowstream.Protocol+", tcp" {
+		t.Fatalf("expected flow control to be requested, got %q", upgrade)
+	}
+}
+
+func TestContainerAttachFlowControlFallback(t *testing.T) {
+	testContainerAttach(t, "1.25", false)
+}
+
+func TestContainerAttachFlowControlOldVersion(t *testing.T) {
+	if upgrade := testContainerAttach(t, "1.24", true); upgrade != "tcp" {
+		t.Fatalf("expected flow control not to be requested from API 1.24, got %q", upgrade)
+	}
+}
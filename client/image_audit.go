@@ -0,0 +1,25 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ImagesAudit reports the dangling references of the images, their parents
+// and their layers found in the docker host.
+func (cli *Client) ImagesAudit(ctx context.Context) (types.ImagesAuditReport, error) {
+	var report types.ImagesAuditReport
+	if err := cli.NewVersionError("1.25", "image audit"); err != nil {
+		return report, err
+	}
+	serverResp, err := cli.get(ctx, "/images/audit", nil, nil)
+	if err != nil {
+		return report, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&report)
+	ensureReaderClosed(serverResp)
+	return report, err
+}
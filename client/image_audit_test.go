@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestImagesAuditError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImagesAudit(context.Background())
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestImagesAuditVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImagesAudit(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestImagesAudit(t *testing.T) {
+	expectedURL := "/images/audit"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", r.Method)
+			}
+			b, err := json.Marshal(types.ImagesAuditReport{
+				DanglingReferences: []types.ImageAuditEntry{
+					{Type: types.AuditReference, ID: "busybox:old", Message: "references the missing image sha256:abcd"},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	report, err := client.ImagesAudit(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.DanglingReferences) != 1 || report.DanglingReferences[0].ID != "busybox:old" {
+		t.Fatalf("unexpected report %v", report)
+	}
+}
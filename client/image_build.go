@@ -25,6 +25,13 @@ func (cli *Client) ImageBuild(ctx context.Context, buildContext io.Reader, optio
 	if err != nil {
 		return types.ImageBuildResponse{}, err
 	}
+	if options.FromPolicy == types.BuildFromPolicyPinned {
+		// An older daemon would ignore the policy, and build from the
+		// images referenced by tag.
+		if err := cli.NewVersionError("1.25", "from-policy"); err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+	}
 
 	headers := http.Header(make(map[string][]string))
 	buf, err := json.Marshal(options.AuthConfigs)
@@ -32,7 +39,27 @@ func (cli *Client) ImageBuild(ctx context.Context, buildContext io.Reader, optio
 		return types.ImageBuildResponse{}, err
 	}
 	headers.Add("X-Registry-Config", base64.URLEncoding.EncodeToString(buf))
+	if len(options.Secrets) > 0 {
+		buf, err := json.Marshal(options.Secrets)
+		if err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+		headers.Add("X-Build-Secrets", base64.URLEncoding.EncodeToString(buf))
+	}
+	if options.GitAuth != nil {
+		buf, err := json.Marshal(options.GitAuth)
+		if err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+		headers.Add("X-Build-Git-Auth", base64.URLEncoding.EncodeToString(buf))
+	}
 	headers.Set("Content-Type", "application/tar")
+	if options.StructuredProgress {
+		if err := cli.NewVersionError("1.25", "structured build progress"); err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+		headers.Set("Accept", "application/json-seq")
+	}
 
 	serverResp, err := cli.postRaw(ctx, "/build", query, buildContext, headers)
 	if err != nil {
@@ -78,6 +105,14 @@ func imageBuildOptionsToQuery(options types.ImageBuildOptions) (url.Values, erro
 		query.Set("squash", "1")
 	}
 
+	if options.Validate {
+		query.Set("validate", "1")
+	}
+
+	if options.FromPolicy != "" {
+		query.Set("frompolicy", options.FromPolicy)
+	}
+
 	if !container.Isolation.IsDefault(options.Isolation) {
 		query.Set("isolation", string(options.Isolation))
 	}
@@ -111,6 +146,14 @@ func imageBuildOptionsToQuery(options types.ImageBuildOptions) (url.Values, erro
 	}
 	query.Set("labels", string(labelsJSON))
 
+	if len(options.Annotations) > 0 {
+		annotationsJSON, err := json.Marshal(options.Annotations)
+		if err != nil {
+			return query, err
+		}
+		query.Set("annotations", string(annotationsJSON))
+	}
+
 	cacheFromJSON, err := json.Marshal(options.CacheFrom)
 	if err != nil {
 		return query, err
@@ -153,6 +153,28 @@ func TestImageBuild(t *testing.T) {
 			expectedTags:           []string{},
 			expectedRegistryConfig: "eyJodHRwczovL2luZGV4LmRvY2tlci5pby92MS8iOnsiYXV0aCI6ImRHOTBid289In19",
 		},
+		{
+			buildOptions: types.ImageBuildOptions{
+				Validate: true,
+			},
+			expectedQueryParams: map[string]string{
+				"validate": "1",
+				"rm":       "0",
+			},
+			expectedTags:           []string{},
+			expectedRegistryConfig: emptyRegistryConfig,
+		},
+		{
+			buildOptions: types.ImageBuildOptions{
+				FromPolicy: types.BuildFromPolicyPinned,
+			},
+			expectedQueryParams: map[string]string{
+				"frompolicy": "pinned",
+				"rm":         "0",
+			},
+			expectedTags:           []string{},
+			expectedRegistryConfig: emptyRegistryConfig,
+		},
 	}
 	for _, buildCase := range buildCases {
 		expectedURL := "/build"
@@ -215,6 +237,102 @@ func TestImageBuild(t *testing.T) {
 	}
 }
 
+func TestImageBuildSecrets(t *testing.T) {
+	// {"foo":"c2VjcmV0"}, base64 encoded
+	expectedSecrets := "eyJmb28iOiJjMlZqY21WMCJ9"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			secrets := r.Header.Get("X-Build-Secrets")
+			if secrets != expectedSecrets {
+				return nil, fmt.Errorf("X-Build-Secrets header not properly set in the request. Expected '%s', got %s", expectedSecrets, secrets)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("body"))),
+			}, nil
+		}),
+	}
+	_, err := client.ImageBuild(context.Background(), nil, types.ImageBuildOptions{
+		Secrets: map[string][]byte{"foo": []byte("secret")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImageBuildGitAuth(t *testing.T) {
+	// {"Token":"token"}, base64 encoded
+	expectedGitAuth := "eyJUb2tlbiI6InRva2VuIn0="
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			gitAuth := r.Header.Get("X-Build-Git-Auth")
+			if gitAuth != expectedGitAuth {
+				return nil, fmt.Errorf("X-Build-Git-Auth header not properly set in the request. Expected '%s', got %s", expectedGitAuth, gitAuth)
+			}
+			if remote := r.URL.Query().Get("remote"); remote != "git@github.com:docker/private.git" {
+				return nil, fmt.Errorf("remote not properly set in the request, got %s", remote)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("body"))),
+			}, nil
+		}),
+	}
+	_, err := client.ImageBuild(context.Background(), nil, types.ImageBuildOptions{
+		RemoteContext: "git@github.com:docker/private.git",
+		GitAuth:       &types.GitAuthConfig{Token: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImageBuildStructuredProgress(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if accept := r.Header.Get("Accept"); accept != "application/json-seq" {
+				return nil, fmt.Errorf("Accept header not properly set in the request. Expected 'application/json-seq', got %s", accept)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("body"))),
+			}, nil
+		}),
+	}
+	_, err := client.ImageBuild(context.Background(), nil, types.ImageBuildOptions{
+		StructuredProgress: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImageBuildStructuredProgressVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.24",
+	}
+	_, err := client.ImageBuild(context.Background(), nil, types.ImageBuildOptions{
+		StructuredProgress: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestImageBuildFromPolicyVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.24",
+	}
+	_, err := client.ImageBuild(context.Background(), nil, types.ImageBuildOptions{
+		FromPolicy: types.BuildFromPolicyPinned,
+	})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
 func TestGetDockerOS(t *testing.T) {
 	cases := map[string]string{
 		"Docker/v1.22 (linux)":   "linux",
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"golang.org/x/net/context"
+
+	distreference "github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/reference"
+)
+
+// ImageFlatten creates a new image with a single layer holding the
+// filesystem of an image, and the configuration of the image.
+func (cli *Client) ImageFlatten(ctx context.Context, image string, options types.ImageFlattenOptions) (types.ImageFlattenResponse, error) {
+	if err := cli.NewVersionError("1.25", "image flatten"); err != nil {
+		return types.ImageFlattenResponse{}, err
+	}
+
+	var repository, tag string
+	if options.Ref != "" {
+		distributionRef, err := distreference.ParseNamed(options.Ref)
+		if err != nil {
+			return types.ImageFlattenResponse{}, err
+		}
+
+		if _, isCanonical := distributionRef.(distreference.Canonical); isCanonical {
+			return types.ImageFlattenResponse{}, errors.New("refusing to create a tag with a digest reference")
+		}
+
+		tag = reference.GetTagFromNamedRef(distributionRef)
+		repository = distributionRef.Name()
+	}
+
+	query := url.Values{}
+	query.Set("repo", repository)
+	query.Set("tag", tag)
+
+	var response types.ImageFlattenResponse
+	resp, err := cli.post(ctx, "/images/"+image+"/flatten", query, nil, nil)
+	if err != nil {
+		return response, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response, err
+}
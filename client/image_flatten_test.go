@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestImageFlattenError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageFlatten(context.Background(), "image_id", types.ImageFlattenOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestImageFlattenDigestReference(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageFlatten(context.Background(), "image_id", types.ImageFlattenOptions{
+		Ref: "repository@sha256:ecda3bd9a8a1a5c7bea8eee0b5f2d9ab1b8b0ea6ae9e1b4e7e9a2bbaf1a2c13d",
+	})
+	if err == nil || err.Error() != "refusing to create a tag with a digest reference" {
+		t.Fatalf("expected a digest reference error, got %v", err)
+	}
+}
+
+func TestImageFlattenVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageFlatten(context.Background(), "image_id", types.ImageFlattenOptions{})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestImageFlatten(t *testing.T) {
+	expectedURL := "/images/image_id/flatten"
+	expectedQueryParams := map[string]string{
+		"repo": "repository_name",
+		"tag":  "tag",
+	}
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", r.Method)
+			}
+			query := r.URL.Query()
+			for key, expected := range expectedQueryParams {
+				actual := query.Get(key)
+				if actual != expected {
+					return nil, fmt.Errorf("%s not set in URL query properly. Expected '%s', got %s", key, expected, actual)
+				}
+			}
+			b, err := json.Marshal(types.ImageFlattenResponse{
+				ID: "new_image_id",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	r, err := client.ImageFlatten(context.Background(), "image_id", types.ImageFlattenOptions{
+		Ref: "repository_name:tag",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != "new_image_id" {
+		t.Fatalf("expected `new_image_id`, got %s", r.ID)
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+
+	"golang.org/x/net/context"
+
+	distreference "github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/reference"
+)
+
+// ImageLayerExport retrieves the layer of an image with the given DiffID as
+// an io.ReadCloser. It's up to the caller to store the layer and close the
+// stream.
+func (cli *Client) ImageLayerExport(ctx context.Context, image, diffID string) (io.ReadCloser, error) {
+	resp, err := cli.get(ctx, "/images/"+image+"/layers/"+diffID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// ImageLayerImport applies a layer tar archive on top of an image, creating a
+// new image.
+func (cli *Client) ImageLayerImport(ctx context.Context, image string, layer io.Reader, options types.ImageLayerImportOptions) (types.ImageLayerImportResponse, error) {
+	var repository, tag string
+	if options.Ref != "" {
+		distributionRef, err := distreference.ParseNamed(options.Ref)
+		if err != nil {
+			return types.ImageLayerImportResponse{}, err
+		}
+
+		if _, isCanonical := distributionRef.(distreference.Canonical); isCanonical {
+			return types.ImageLayerImportResponse{}, errors.New("refusing to create a tag with a digest reference")
+		}
+
+		tag = reference.GetTagFromNamedRef(distributionRef)
+		repository = distributionRef.Name()
+	}
+
+	query := url.Values{}
+	query.Set("repo", repository)
+	query.Set("tag", tag)
+	query.Set("comment", options.Message)
+
+	var response types.ImageLayerImportResponse
+	resp, err := cli.postRaw(ctx, "/images/"+image+"/layers", query, layer, nil)
+	if err != nil {
+		return response, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response, err
+}
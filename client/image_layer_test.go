@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestImageLayerExportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageLayerExport(context.Background(), "image_id", "diff_id")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestImageLayerExport(t *testing.T) {
+	expectedURL := "/images/image_id/layers/diff_id"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", r.Method)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	body, err := client.ImageLayerExport(context.Background(), "image_id", "diff_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	response, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(response) != "response" {
+		t.Fatalf("expected response to contain 'response', got %s", string(response))
+	}
+}
+
+func TestImageLayerImportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageLayerImport(context.Background(), "image_id", bytes.NewReader(nil), types.ImageLayerImportOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestImageLayerImportDigestReference(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageLayerImport(context.Background(), "image_id", bytes.NewReader(nil), types.ImageLayerImportOptions{
+		Ref: "repository@sha256:ecda3bd9a8a1a5c7bea8eee0b5f2d9ab1b8b0ea6ae9e1b4e7e9a2bbaf1a2c13d",
+	})
+	if err == nil || err.Error() != "refusing to create a tag with a digest reference" {
+		t.Fatalf("expected a digest reference error, got %v", err)
+	}
+}
+
+func TestImageLayerImport(t *testing.T) {
+	expectedURL := "/images/image_id/layers"
+	expectedQueryParams := map[string]string{
+		"repo":    "repository_name",
+		"tag":     "tag",
+		"comment": "comment",
+	}
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", r.Method)
+			}
+			query := r.URL.Query()
+			for key, expected := range expectedQueryParams {
+				actual := query.Get(key)
+				if actual != expected {
+					return nil, fmt.Errorf("%s not set in URL query properly. Expected '%s', got %s", key, expected, actual)
+				}
+			}
+			layer, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			if string(layer) != "layer" {
+				return nil, fmt.Errorf("expected body to contain 'layer', got %s", string(layer))
+			}
+			b, err := json.Marshal(types.ImageLayerImportResponse{
+				ID: "new_image_id",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	r, err := client.ImageLayerImport(context.Background(), "image_id", bytes.NewReader([]byte("layer")), types.ImageLayerImportOptions{
+		Ref:     "repository_name:tag",
+		Message: "comment",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != "new_image_id" {
+		t.Fatalf("expected `new_image_id`, got %s", r.ID)
+	}
+}
@@ -3,8 +3,10 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"golang.org/x/net/context"
 )
 
@@ -12,7 +14,16 @@ import (
 func (cli *Client) ImagesPrune(ctx context.Context, cfg types.ImagesPruneConfig) (types.ImagesPruneReport, error) {
 	var report types.ImagesPruneReport
 
-	serverResp, err := cli.post(ctx, "/images/prune", nil, cfg, nil)
+	query := url.Values{}
+	if cfg.Filters.Len() > 0 {
+		filterJSON, err := filters.ToParam(cfg.Filters)
+		if err != nil {
+			return report, err
+		}
+		query.Set("filters", filterJSON)
+	}
+
+	serverResp, err := cli.post(ctx, "/images/prune", query, cfg, nil)
 	if err != nil {
 		return report, err
 	}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/net/context"
+)
+
+func TestImagesPruneError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.ImagesPrune(context.Background(), types.ImagesPruneConfig{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestImagesPrune(t *testing.T) {
+	expectedURL := "/images/prune"
+
+	pruneFilters := filters.NewArgs()
+	pruneFilters.Add("until", "72h")
+	pruneFilters.Add("label!", "keep")
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			actualFilters, err := filters.FromParam(req.URL.Query().Get("filters"))
+			if err != nil {
+				return nil, err
+			}
+			if !actualFilters.ExactMatch("until", "72h") || !actualFilters.ExactMatch("label!", "keep") {
+				return nil, fmt.Errorf("filters not set in URL query properly, got %s", req.URL.Query().Get("filters"))
+			}
+			var cfg types.ImagesPruneConfig
+			if err := json.NewDecoder(req.Body).Decode(&cfg); err != nil {
+				return nil, err
+			}
+			if !cfg.DryRun || cfg.DanglingOnly {
+				return nil, fmt.Errorf("unexpected prune configuration %+v", cfg)
+			}
+			b, err := json.Marshal(types.ImagesPruneReport{
+				ImagesDeleted:  []types.ImageDelete{{Deleted: "image_id"}},
+				SpaceReclaimed: 42,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	report, err := client.ImagesPrune(context.Background(), types.ImagesPruneConfig{
+		Filters: pruneFilters,
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.ImagesDeleted) != 1 || report.SpaceReclaimed != 42 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"golang.org/x/net/context"
 
@@ -33,6 +34,17 @@ func (cli *Client) ImagePush(ctx context.Context, ref string, options types.Imag
 
 	query := url.Values{}
 	query.Set("tag", tag)
+	if options.Compression != "" || options.CompressionLevel != 0 {
+		if err := cli.NewVersionError("1.25", "push compression"); err != nil {
+			return nil, err
+		}
+	}
+	if options.Compression != "" {
+		query.Set("compression", options.Compression)
+	}
+	if options.CompressionLevel != 0 {
+		query.Set("compressionLevel", strconv.Itoa(options.CompressionLevel))
+	}
 
 	resp, err := cli.tryImagePush(ctx, distributionRef.Name(), query, options.RegistryAuth)
 	if resp.statusCode == http.StatusUnauthorized && options.PrivilegeFunc != nil {
@@ -178,3 +178,29 @@ func TestImagePushWithoutErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestImagePushCompression(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			if compression := query.Get("compression"); compression != "zstd" {
+				return nil, fmt.Errorf("compression not set in URL query properly. Expected 'zstd', got %s", compression)
+			}
+			if level := query.Get("compressionLevel"); level != "19" {
+				return nil, fmt.Errorf("compressionLevel not set in URL query properly. Expected '19', got %s", level)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("hello world"))),
+			}, nil
+		}),
+	}
+	resp, err := client.ImagePush(context.Background(), "myimage", types.ImagePushOptions{
+		Compression:      "zstd",
+		CompressionLevel: 19,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+}
@@ -3,16 +3,37 @@ package client
 import (
 	"io"
 	"net/url"
+	"strconv"
 
 	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
 )
 
 // ImageSave retrieves one or more images from the docker host as an io.ReadCloser.
 // It's up to the caller to store the images and close the stream.
 func (cli *Client) ImageSave(ctx context.Context, imageIDs []string) (io.ReadCloser, error) {
+	return cli.ImageSaveWithOptions(ctx, imageIDs, types.ImageSaveOptions{})
+}
+
+// ImageSaveWithOptions retrieves one or more images from the docker host as an io.ReadCloser,
+// with the layers compressed as requested by the options.
+// It's up to the caller to store the images and close the stream.
+func (cli *Client) ImageSaveWithOptions(ctx context.Context, imageIDs []string, options types.ImageSaveOptions) (io.ReadCloser, error) {
 	query := url.Values{
 		"names": imageIDs,
 	}
+	if options.Compression != "" || options.CompressionLevel != 0 {
+		if err := cli.NewVersionError("1.25", "save compression"); err != nil {
+			return nil, err
+		}
+	}
+	if options.Compression != "" {
+		query.Set("compression", options.Compression)
+	}
+	if options.CompressionLevel != 0 {
+		query.Set("compressionLevel", strconv.Itoa(options.CompressionLevel))
+	}
 
 	resp, err := cli.get(ctx, "/images/get", query, nil)
 	if err != nil {
@@ -11,6 +11,8 @@ import (
 	"golang.org/x/net/context"
 
 	"strings"
+
+	"github.com/docker/docker/api/types"
 )
 
 func TestImageSaveError(t *testing.T) {
@@ -56,3 +58,40 @@ func TestImageSave(t *testing.T) {
 		t.Fatalf("expected response to contain 'response', got %s", string(response))
 	}
 }
+
+func TestImageSaveCompression(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			query := r.URL.Query()
+			if compression := query.Get("compression"); compression != "zstd" {
+				return nil, fmt.Errorf("compression not set in URL query properly. Expected 'zstd', got %s", compression)
+			}
+			if level := query.Get("compressionLevel"); level != "3" {
+				return nil, fmt.Errorf("compressionLevel not set in URL query properly. Expected '3', got %s", level)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	saveResponse, err := client.ImageSaveWithOptions(context.Background(), []string{"image_id1"}, types.ImageSaveOptions{
+		Compression:      "zstd",
+		CompressionLevel: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveResponse.Close()
+}
+
+func TestImageSaveCompressionVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageSaveWithOptions(context.Background(), []string{"image_id1"}, types.ImageSaveOptions{Compression: "zstd"})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ImageVerify verifies the configuration and the layers of an image against
+// their digests in the docker host.
+func (cli *Client) ImageVerify(ctx context.Context, imageID string) (types.ImageVerifyResponse, error) {
+	var resp types.ImageVerifyResponse
+	serverResp, err := cli.get(ctx, "/images/"+imageID+"/verify", nil, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&resp)
+	ensureReaderClosed(serverResp)
+	return resp, err
+}
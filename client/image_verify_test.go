@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestImageVerifyError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImageVerify(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestImageVerify(t *testing.T) {
+	expectedURL := "/images/image_id/verify"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", r.Method)
+			}
+			b, err := json.Marshal(types.ImageVerifyResponse{
+				ID:     "image_id",
+				Config: types.BlobVerification{Digest: "image_id", Status: types.BlobStatusOK},
+				Layers: []types.BlobVerification{
+					{Digest: "diff_id1", ChainID: "chain_id1", Status: types.BlobStatusOK},
+					{Digest: "diff_id2", ChainID: "chain_id2", Status: types.BlobStatusCorrupt, Message: "could not verify layer data"},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	resp, err := client.ImageVerify(context.Background(), "image_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Layers) != 2 || resp.Layers[1].Status != types.BlobStatusCorrupt {
+		t.Fatalf("expected the second layer to be corrupt, got %v", resp.Layers)
+	}
+	if !resp.Damaged() {
+		t.Fatal("expected the image to be damaged")
+	}
+}
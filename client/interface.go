@@ -11,15 +11,21 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"golang.org/x/net/context"
 )
 
 // CommonAPIClient is the common methods between stable and experimental versions of APIClient.
 type CommonAPIClient interface {
 	ContainerAPIClient
+	EnvFileAPIClient
 	ImageAPIClient
+	JobAPIClient
+	ManifestAPIClient
 	NodeAPIClient
 	NetworkAPIClient
+	PodAPIClient
+	RegistryAPIClient
 	ServiceAPIClient
 	SwarmAPIClient
 	SystemAPIClient
@@ -32,8 +38,12 @@ type CommonAPIClient interface {
 // ContainerAPIClient defines API client methods for the containers
 type ContainerAPIClient interface {
 	ContainerAttach(ctx context.Context, container string, options types.ContainerAttachOptions) (types.HijackedResponse, error)
+	ContainerBackup(ctx context.Context, container, since string) (io.ReadCloser, string, error)
+	ContainerClone(ctx context.Context, container string, config types.ContainerCloneConfig, containerName string) (types.ContainerCreateResponse, error)
 	ContainerCommit(ctx context.Context, container string, options types.ContainerCommitOptions) (types.ContainerCommitResponse, error)
-	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, containerName string) (types.ContainerCreateResponse, error)
+	ContainerCoreDump(ctx context.Context, container, core string) (io.ReadCloser, error)
+	ContainerCoreDumps(ctx context.Context, container string) ([]types.CoreDump, error)
+	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, containerName string, options types.ContainerCreateOptions) (types.ContainerCreateResponse, error)
 	ContainerDiff(ctx context.Context, container string) ([]types.ContainerChange, error)
 	ContainerExecAttach(ctx context.Context, execID string, config types.ExecConfig) (types.HijackedResponse, error)
 	ContainerExecCreate(ctx context.Context, container string, config types.ExecConfig) (types.ContainerExecCreateResponse, error)
@@ -41,6 +51,9 @@ type ContainerAPIClient interface {
 	ContainerExecResize(ctx context.Context, execID string, options types.ResizeOptions) error
 	ContainerExecStart(ctx context.Context, execID string, config types.ExecStartCheck) error
 	ContainerExport(ctx context.Context, container string) (io.ReadCloser, error)
+	ContainerExportWithOptions(ctx context.Context, container string, options types.ContainerExportOptions) (io.ReadCloser, error)
+	ContainerFilesystemUsage(ctx context.Context, container string, refresh bool) (types.ContainerFilesystemUsage, error)
+	ContainerHandoff(ctx context.Context, container, from string, timeout *time.Duration) error
 	ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error)
 	ContainerInspectWithRaw(ctx context.Context, container string, getSize bool) (types.ContainerJSON, []byte, error)
 	ContainerKill(ctx context.Context, container, signal string) error
@@ -50,27 +63,38 @@ type ContainerAPIClient interface {
 	ContainerRemove(ctx context.Context, container string, options types.ContainerRemoveOptions) error
 	ContainerRename(ctx context.Context, container, newContainerName string) error
 	ContainerResize(ctx context.Context, container string, options types.ResizeOptions) error
+	ContainerResolve(ctx context.Context, container, name string) (types.ContainerResolveResponse, error)
 	ContainerRestart(ctx context.Context, container string, timeout *time.Duration) error
+	ContainerRestore(ctx context.Context, container string, content io.Reader) error
 	ContainerStatPath(ctx context.Context, container, path string) (types.ContainerPathStat, error)
+	ContainerSpec(ctx context.Context, container string) (specs.Spec, error)
 	ContainerStats(ctx context.Context, container string, stream bool) (types.ContainerStats, error)
+	ContainerStatsSummary(ctx context.Context, container string) (types.StatsSummary, error)
 	ContainerStart(ctx context.Context, container string, options types.ContainerStartOptions) error
 	ContainerStop(ctx context.Context, container string, timeout *time.Duration) error
 	ContainerTop(ctx context.Context, container string, arguments []string) (types.ContainerProcessList, error)
 	ContainerUnpause(ctx context.Context, container string) error
 	ContainerUpdate(ctx context.Context, container string, updateConfig container.UpdateConfig) (types.ContainerUpdateResponse, error)
+	ContainerVerify(ctx context.Context, container string) (types.ContainerVerifyReport, error)
 	ContainerWait(ctx context.Context, container string) (int, error)
+	ContainerWaitExit(ctx context.Context, container string) (types.ContainerWaitResponse, error)
 	CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
 	CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error
+	CopyBetweenContainers(ctx context.Context, srcContainer, srcPath, dstContainer, dstPath string, options types.CopyBetweenContainersOptions) error
 	ContainersPrune(ctx context.Context, cfg types.ContainersPruneConfig) (types.ContainersPruneReport, error)
+	ContainerPortsCheck(ctx context.Context, req types.PortsCheckRequest) (types.PortsCheckResponse, error)
 }
 
 // ImageAPIClient defines API client methods for the images
 type ImageAPIClient interface {
 	ImageBuild(ctx context.Context, context io.Reader, options types.ImageBuildOptions) (types.ImageBuildResponse, error)
 	ImageCreate(ctx context.Context, parentReference string, options types.ImageCreateOptions) (io.ReadCloser, error)
+	ImageFlatten(ctx context.Context, image string, options types.ImageFlattenOptions) (types.ImageFlattenResponse, error)
 	ImageHistory(ctx context.Context, image string) ([]types.ImageHistory, error)
 	ImageImport(ctx context.Context, source types.ImageImportSource, ref string, options types.ImageImportOptions) (io.ReadCloser, error)
 	ImageInspectWithRaw(ctx context.Context, image string) (types.ImageInspect, []byte, error)
+	ImageLayerExport(ctx context.Context, image, diffID string) (io.ReadCloser, error)
+	ImageLayerImport(ctx context.Context, image string, layer io.Reader, options types.ImageLayerImportOptions) (types.ImageLayerImportResponse, error)
 	ImageList(ctx context.Context, options types.ImageListOptions) ([]types.Image, error)
 	ImageLoad(ctx context.Context, input io.Reader, quiet bool) (types.ImageLoadResponse, error)
 	ImagePull(ctx context.Context, ref string, options types.ImagePullOptions) (io.ReadCloser, error)
@@ -78,10 +102,20 @@ type ImageAPIClient interface {
 	ImageRemove(ctx context.Context, image string, options types.ImageRemoveOptions) ([]types.ImageDelete, error)
 	ImageSearch(ctx context.Context, term string, options types.ImageSearchOptions) ([]registry.SearchResult, error)
 	ImageSave(ctx context.Context, images []string) (io.ReadCloser, error)
+	ImageSaveWithOptions(ctx context.Context, images []string, options types.ImageSaveOptions) (io.ReadCloser, error)
 	ImageTag(ctx context.Context, image, ref string) error
+	ImageVerify(ctx context.Context, image string) (types.ImageVerifyResponse, error)
+	ImagesAudit(ctx context.Context) (types.ImagesAuditReport, error)
 	ImagesPrune(ctx context.Context, cfg types.ImagesPruneConfig) (types.ImagesPruneReport, error)
 }
 
+// ManifestAPIClient defines API client methods for the manifests in the
+// registries
+type ManifestAPIClient interface {
+	ManifestInspect(ctx context.Context, ref string, options types.ManifestOptions) (types.ManifestDescriptor, error)
+	ManifestListPush(ctx context.Context, ref string, manifests []types.ManifestDescriptor, options types.ManifestOptions) (string, error)
+}
+
 // NetworkAPIClient defines API client methods for the networks
 type NetworkAPIClient interface {
 	NetworkConnect(ctx context.Context, networkID, container string, config *network.EndpointSettings) error
@@ -89,8 +123,12 @@ type NetworkAPIClient interface {
 	NetworkDisconnect(ctx context.Context, networkID, container string, force bool) error
 	NetworkInspect(ctx context.Context, networkID string) (types.NetworkResource, error)
 	NetworkInspectWithRaw(ctx context.Context, networkID string) (types.NetworkResource, []byte, error)
+	NetworkInspectWithOptions(ctx context.Context, networkID string, options types.NetworkInspectOptions) (types.NetworkResource, []byte, error)
 	NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error)
+	NetworkPorts(ctx context.Context) ([]types.AllocatedPort, error)
 	NetworkRemove(ctx context.Context, networkID string) error
+	NetworksPrune(ctx context.Context, cfg types.NetworksPruneConfig) (types.NetworksPruneReport, error)
+	NetworksReconcile(ctx context.Context) (types.NetworksReconcileReport, error)
 }
 
 // NodeAPIClient defines API client methods for the nodes
@@ -101,6 +139,12 @@ type NodeAPIClient interface {
 	NodeUpdate(ctx context.Context, nodeID string, version swarm.Version, node swarm.NodeSpec) error
 }
 
+// RegistryAPIClient defines API client methods for browsing the registries
+type RegistryAPIClient interface {
+	RegistryRepositories(ctx context.Context, hostname string, options types.RegistryRepositoriesOptions) (types.RegistryRepositories, error)
+	RepositoryTags(ctx context.Context, name string, options types.RepositoryTagsOptions) (types.RepositoryTags, error)
+}
+
 // ServiceAPIClient defines API client methods for the services
 type ServiceAPIClient interface {
 	ServiceCreate(ctx context.Context, service swarm.ServiceSpec, options types.ServiceCreateOptions) (types.ServiceCreateResponse, error)
@@ -127,6 +171,41 @@ type SystemAPIClient interface {
 	Info(ctx context.Context) (types.Info, error)
 	RegistryLogin(ctx context.Context, auth types.AuthConfig) (types.AuthResponse, error)
 	DiskUsage(ctx context.Context) (types.DiskUsage, error)
+	LogDrivers(ctx context.Context) ([]types.LogDriver, error)
+	SystemMaintenance(ctx context.Context, config types.MaintenanceConfig) error
+	SystemLeakedMounts(ctx context.Context) ([]types.LeakedMount, error)
+	SystemUnmountLeakedMounts(ctx context.Context) ([]types.LeakedMount, error)
+	TrustKeyExport(ctx context.Context, options types.TrustKeyExportOptions) (io.ReadCloser, error)
+	TrustKeyImport(ctx context.Context, options types.TrustKeyImportOptions) error
+}
+
+// EnvFileAPIClient defines API client methods for the environment files
+type EnvFileAPIClient interface {
+	EnvFileCreate(ctx context.Context, req types.EnvFileCreateRequest) (types.EnvFile, error)
+	EnvFileInspect(ctx context.Context, envFileID string, reveal bool) (types.EnvFile, error)
+	EnvFileInspectWithRaw(ctx context.Context, envFileID string, reveal bool) (types.EnvFile, []byte, error)
+	EnvFileList(ctx context.Context) ([]types.EnvFile, error)
+	EnvFileRemove(ctx context.Context, envFileID string) error
+}
+
+// JobAPIClient defines API client methods for the jobs
+type JobAPIClient interface {
+	JobCreate(ctx context.Context, req types.JobCreateRequest) (types.Job, error)
+	JobInspect(ctx context.Context, jobName string) (types.Job, error)
+	JobInspectWithRaw(ctx context.Context, jobName string) (types.Job, []byte, error)
+	JobList(ctx context.Context) ([]types.Job, error)
+	JobRemove(ctx context.Context, jobName string, force bool) error
+}
+
+// PodAPIClient defines API client methods for the pods
+type PodAPIClient interface {
+	PodCreate(ctx context.Context, req types.PodCreateRequest) (types.Pod, error)
+	PodInspect(ctx context.Context, podName string) (types.Pod, error)
+	PodInspectWithRaw(ctx context.Context, podName string) (types.Pod, []byte, error)
+	PodList(ctx context.Context) ([]types.Pod, error)
+	PodRemove(ctx context.Context, podName string, options types.PodRemoveOptions) error
+	PodStart(ctx context.Context, podName string) error
+	PodStop(ctx context.Context, podName string, timeout *time.Duration) error
 }
 
 // VolumeAPIClient defines API client methods for the volumes
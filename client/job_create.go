@@ -0,0 +1,23 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// JobCreate creates a job in the docker host.
+func (cli *Client) JobCreate(ctx context.Context, req types.JobCreateRequest) (types.Job, error) {
+	if err := cli.NewVersionError("1.25", "jobs"); err != nil {
+		return types.Job{}, err
+	}
+	var job types.Job
+	resp, err := cli.post(ctx, "/jobs/create", nil, req, nil)
+	if err != nil {
+		return job, err
+	}
+	err = json.NewDecoder(resp.body).Decode(&job)
+	ensureReaderClosed(resp)
+	return job, err
+}
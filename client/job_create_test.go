@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"golang.org/x/net/context"
+)
+
+func TestJobCreateError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.JobCreate(context.Background(), types.JobCreateRequest{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestJobCreate(t *testing.T) {
+	expectedURL := "/jobs/create"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var jobReq types.JobCreateRequest
+			if err := json.NewDecoder(req.Body).Decode(&jobReq); err != nil {
+				return nil, err
+			}
+			if jobReq.Name != "backup" || jobReq.Schedule != "*/5 * * * *" || jobReq.Config == nil {
+				return nil, fmt.Errorf("unexpected request %+v", jobReq)
+			}
+			content, err := json.Marshal(types.Job{
+				Name:     jobReq.Name,
+				Schedule: jobReq.Schedule,
+				Config:   jobReq.Config,
+				History:  5,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	job, err := client.JobCreate(context.Background(), types.JobCreateRequest{
+		Name:     "backup",
+		Schedule: "*/5 * * * *",
+		Config:   &container.Config{Image: "busybox"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Name != "backup" || job.History != 5 {
+		t.Fatalf("unexpected job %+v", job)
+	}
+}
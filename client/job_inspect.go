@@ -0,0 +1,41 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// JobInspect returns a job and its runs in the docker host.
+func (cli *Client) JobInspect(ctx context.Context, jobName string) (types.Job, error) {
+	job, _, err := cli.JobInspectWithRaw(ctx, jobName)
+	return job, err
+}
+
+// JobInspectWithRaw returns a job and its runs in the docker host and its raw representation
+func (cli *Client) JobInspectWithRaw(ctx context.Context, jobName string) (types.Job, []byte, error) {
+	if err := cli.NewVersionError("1.25", "jobs"); err != nil {
+		return types.Job{}, nil, err
+	}
+	var job types.Job
+	resp, err := cli.get(ctx, "/jobs/"+jobName, nil, nil)
+	if err != nil {
+		if resp.statusCode == http.StatusNotFound {
+			return job, nil, jobNotFoundError{jobName}
+		}
+		return job, nil, err
+	}
+	defer ensureReaderClosed(resp)
+
+	body, err := ioutil.ReadAll(resp.body)
+	if err != nil {
+		return job, nil, err
+	}
+	rdr := bytes.NewReader(body)
+	err = json.NewDecoder(rdr).Decode(&job)
+	return job, body, err
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// JobList returns the jobs in the docker host.
+func (cli *Client) JobList(ctx context.Context) ([]types.Job, error) {
+	if err := cli.NewVersionError("1.25", "jobs"); err != nil {
+		return nil, err
+	}
+	var jobs []types.Job
+	resp, err := cli.get(ctx, "/jobs", nil, nil)
+	if err != nil {
+		return jobs, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&jobs)
+	ensureReaderClosed(resp)
+	return jobs, err
+}
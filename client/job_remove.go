@@ -0,0 +1,21 @@
+package client
+
+import (
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// JobRemove removes a job and the containers of its runs from the docker host.
+func (cli *Client) JobRemove(ctx context.Context, jobName string, force bool) error {
+	if err := cli.NewVersionError("1.25", "jobs"); err != nil {
+		return err
+	}
+	query := url.Values{}
+	if force {
+		query.Set("force", "1")
+	}
+	resp, err := cli.delete(ctx, "/jobs/"+jobName, query, nil)
+	ensureReaderClosed(resp)
+	return err
+}
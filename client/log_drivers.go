@@ -0,0 +1,23 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// LogDrivers returns the logging drivers available on the daemon, along
+// with the log options each of them supports.
+func (cli *Client) LogDrivers(ctx context.Context) ([]types.LogDriver, error) {
+	var drivers []types.LogDriver
+
+	serverResp, err := cli.get(ctx, "/info/log-drivers", nil, nil)
+	if err != nil {
+		return drivers, err
+	}
+	defer ensureReaderClosed(serverResp)
+
+	err = json.NewDecoder(serverResp.body).Decode(&drivers)
+	return drivers, err
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ManifestInspect makes the docker host return the descriptor of the
+// manifest of an image in its registry, with the platform of the image.
+func (cli *Client) ManifestInspect(ctx context.Context, ref string, options types.ManifestOptions) (types.ManifestDescriptor, error) {
+	var descriptor types.ManifestDescriptor
+	resp, err := cli.get(ctx, "/manifests/"+ref+"/json", nil, registryAuthHeaders(options.RegistryAuth))
+	if err != nil {
+		return descriptor, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&descriptor)
+	ensureReaderClosed(resp)
+	return descriptor, err
+}
+
+func registryAuthHeaders(registryAuth string) map[string][]string {
+	if registryAuth == "" {
+		return nil
+	}
+	return map[string][]string{"X-Registry-Auth": {registryAuth}}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestManifestInspectError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ManifestInspect(context.Background(), "nothing", types.ManifestOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestManifestInspect(t *testing.T) {
+	expectedURL := "/manifests/example.com/busybox:amd64/json"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if auth := r.Header.Get("X-Registry-Auth"); auth != "auth" {
+				return nil, fmt.Errorf("expected the registry auth header, got %q", auth)
+			}
+			b, err := json.Marshal(types.ManifestDescriptor{
+				MediaType: "application/vnd.docker.distribution.manifest.v2+json",
+				Digest:    "sha256:abcd",
+				Size:      528,
+				Platform:  types.ManifestPlatform{Architecture: "amd64", OS: "linux"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	descriptor, err := client.ManifestInspect(context.Background(), "example.com/busybox:amd64", types.ManifestOptions{RegistryAuth: "auth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if descriptor.Digest != "sha256:abcd" || descriptor.Platform.Architecture != "amd64" {
+		t.Fatalf("unexpected descriptor %v", descriptor)
+	}
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ManifestListPush makes the docker host push a manifest list referencing
+// the manifests to the registry, and returns its digest.
+func (cli *Client) ManifestListPush(ctx context.Context, ref string, manifests []types.ManifestDescriptor, options types.ManifestOptions) (string, error) {
+	resp, err := cli.post(ctx, "/manifests/"+ref+"/push", nil, manifests, registryAuthHeaders(options.RegistryAuth))
+	if err != nil {
+		return "", err
+	}
+
+	var response types.ManifestListPushResponse
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response.Digest, err
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestManifestListPushError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ManifestListPush(context.Background(), "nothing", nil, types.ManifestOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestManifestListPush(t *testing.T) {
+	expectedURL := "/manifests/example.com/busybox:latest/push"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			if r.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", r.Method)
+			}
+			var manifests []types.ManifestDescriptor
+			if err := json.NewDecoder(r.Body).Decode(&manifests); err != nil {
+				return nil, err
+			}
+			if len(manifests) != 2 || manifests[1].Platform.Architecture != "arm" {
+				return nil, fmt.Errorf("unexpected manifests %v", manifests)
+			}
+			b, err := json.Marshal(types.ManifestListPushResponse{Digest: "sha256:list"})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	manifests := []types.ManifestDescriptor{
+		{Digest: "sha256:amd64", Platform: types.ManifestPlatform{Architecture: "amd64", OS: "linux"}},
+		{Digest: "sha256:arm", Platform: types.ManifestPlatform{Architecture: "arm", OS: "linux", Variant: "v7"}},
+	}
+	dgst, err := client.ManifestListPush(context.Background(), "example.com/busybox:latest", manifests, types.ManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dgst != "sha256:list" {
+		t.Fatalf("expected the digest of the manifest list, got %s", dgst)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
@@ -18,8 +19,21 @@ func (cli *Client) NetworkInspect(ctx context.Context, networkID string) (types.
 
 // NetworkInspectWithRaw returns the information for a specific network configured in the docker host and its raw representation.
 func (cli *Client) NetworkInspectWithRaw(ctx context.Context, networkID string) (types.NetworkResource, []byte, error) {
+	return cli.NetworkInspectWithOptions(ctx, networkID, types.NetworkInspectOptions{})
+}
+
+// NetworkInspectWithOptions returns the information for a specific network configured in the docker host and its raw representation,
+// with the information requested by the options.
+func (cli *Client) NetworkInspectWithOptions(ctx context.Context, networkID string, options types.NetworkInspectOptions) (types.NetworkResource, []byte, error) {
 	var networkResource types.NetworkResource
-	resp, err := cli.get(ctx, "/networks/"+networkID, nil, nil)
+	query := url.Values{}
+	if options.Verbose {
+		if err := cli.NewVersionError("1.25", "verbose network inspect"); err != nil {
+			return networkResource, nil, err
+		}
+		query.Set("verbose", "1")
+	}
+	resp, err := cli.get(ctx, "/networks/"+networkID, query, nil)
 	if err != nil {
 		if resp.statusCode == http.StatusNotFound {
 			return networkResource, nil, networkNotFoundError{networkID}
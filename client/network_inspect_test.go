@@ -35,6 +35,18 @@ func TestNetworkInspectContainerNotFound(t *testing.T) {
 	}
 }
 
+func TestNetworkInspectVerboseVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, _, err := client.NetworkInspectWithOptions(context.Background(), "nothing", types.NetworkInspectOptions{Verbose: true})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
 func TestNetworkInspect(t *testing.T) {
 	expectedURL := "/networks/network_id"
 	client := &Client{
@@ -46,8 +58,13 @@ func TestNetworkInspect(t *testing.T) {
 				return nil, fmt.Errorf("expected GET method, got %s", req.Method)
 			}
 
+			var diagnostics *types.NetworkDiagnostics
+			if req.URL.Query().Get("verbose") == "1" {
+				diagnostics = &types.NetworkDiagnostics{IptablesRules: []string{"-t nat -A POSTROUTING -s 172.18.0.0/16 ! -o br-0 -j MASQUERADE"}}
+			}
 			content, err := json.Marshal(types.NetworkResource{
-				Name: "mynetwork",
+				Name:        "mynetwork",
+				Diagnostics: diagnostics,
 			})
 			if err != nil {
 				return nil, err
@@ -66,4 +83,15 @@ func TestNetworkInspect(t *testing.T) {
 	if r.Name != "mynetwork" {
 		t.Fatalf("expected `mynetwork`, got %s", r.Name)
 	}
+	if r.Diagnostics != nil {
+		t.Fatalf("expected no diagnostics, got %+v", r.Diagnostics)
+	}
+
+	r, _, err = client.NetworkInspectWithOptions(context.Background(), "network_id", types.NetworkInspectOptions{Verbose: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Diagnostics == nil || len(r.Diagnostics.IptablesRules) != 1 {
+		t.Fatalf("expected the diagnostics of the network, got %+v", r.Diagnostics)
+	}
 }
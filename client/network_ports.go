@@ -0,0 +1,28 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// NetworkPorts returns the host ports allocated by the daemon for the
+// published ports of the running containers, and the ones reserved without
+// being published by a running container.
+func (cli *Client) NetworkPorts(ctx context.Context) ([]types.AllocatedPort, error) {
+	var ports []types.AllocatedPort
+
+	if err := cli.NewVersionError("1.25", "network ports"); err != nil {
+		return ports, err
+	}
+	resp, err := cli.get(ctx, "/ports", url.Values{}, nil)
+	if err != nil {
+		return ports, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&ports)
+	ensureReaderClosed(resp)
+	return ports, err
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestNetworkPortsError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.NetworkPorts(context.Background())
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestNetworkPorts(t *testing.T) {
+	expectedURL := "/ports"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+			}
+			b, err := json.Marshal([]types.AllocatedPort{
+				{Proto: "tcp", HostIP: "0.0.0.0", HostPort: 8080, ContainerID: "container_id", ContainerPort: 80, UserlandProxy: true},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	ports, err := client.NetworkPorts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 1 || ports[0].HostPort != 8080 || !ports[0].UserlandProxy {
+		t.Fatalf("expected host port 8080 forwarded by a proxy, got %v", ports)
+	}
+}
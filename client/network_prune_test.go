@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/net/context"
+)
+
+func TestNetworksPruneError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.NetworksPrune(context.Background(), types.NetworksPruneConfig{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestNetworksPruneVersion(t *testing.T) {
+	client := &Client{
+		version: "1.24",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.NetworksPrune(context.Background(), types.NetworksPruneConfig{})
+	if err == nil || !strings.Contains(err.Error(), "requires API version 1.25") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+func TestNetworksPrune(t *testing.T) {
+	expectedURL := "/networks/prune"
+
+	pruneFilters := filters.NewArgs()
+	pruneFilters.Add("until", "24h")
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			actualFilters, err := filters.FromParam(req.URL.Query().Get("filters"))
+			if err != nil {
+				return nil, err
+			}
+			if !actualFilters.ExactMatch("until", "24h") {
+				return nil, fmt.Errorf("filters not set in URL query properly, got %s", req.URL.Query().Get("filters"))
+			}
+			b, err := json.Marshal(types.NetworksPruneReport{
+				NetworksDeleted: []string{"network_name"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	report, err := client.NetworksPrune(context.Background(), types.NetworksPruneConfig{
+		Filters: pruneFilters,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.NetworksDeleted) != 1 || report.NetworksDeleted[0] != "network_name" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// NetworksReconcile requests the daemon to re-create the missing iptables
+// rules of the published ports of the running containers.
+func (cli *Client) NetworksReconcile(ctx context.Context) (types.NetworksReconcileReport, error) {
+	var report types.NetworksReconcileReport
+
+	serverResp, err := cli.post(ctx, "/networks/reconcile", nil, nil, nil)
+	if err != nil {
+		return report, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&report)
+	ensureReaderClosed(serverResp)
+	return report, err
+}
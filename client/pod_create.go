@@ -0,0 +1,23 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// PodCreate creates the containers of a pod in the docker host.
+func (cli *Client) PodCreate(ctx context.Context, req types.PodCreateRequest) (types.Pod, error) {
+	if err := cli.NewVersionError("1.25", "pods"); err != nil {
+		return types.Pod{}, err
+	}
+	var pod types.Pod
+	resp, err := cli.post(ctx, "/pods/create", nil, req, nil)
+	if err != nil {
+		return pod, err
+	}
+	err = json.NewDecoder(resp.body).Decode(&pod)
+	ensureReaderClosed(resp)
+	return pod, err
+}
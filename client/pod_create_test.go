@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"golang.org/x/net/context"
+)
+
+func TestPodCreateError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.PodCreate(context.Background(), types.PodCreateRequest{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestPodCreate(t *testing.T) {
+	expectedURL := "/pods/create"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var podReq types.PodCreateRequest
+			if err := json.NewDecoder(req.Body).Decode(&podReq); err != nil {
+				return nil, err
+			}
+			if podReq.Name != "web" || len(podReq.Members) != 2 {
+				return nil, fmt.Errorf("unexpected request %+v", podReq)
+			}
+			content, err := json.Marshal(types.Pod{
+				Name: "web",
+				Containers: []types.PodContainer{
+					{ID: "holder_id", Name: "web-0", Holder: true, State: "created"},
+					{ID: "member_id", Name: "web-1", State: "created"},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	pod, err := client.PodCreate(context.Background(), types.PodCreateRequest{
+		Name: "web",
+		Members: []types.PodMember{
+			{Config: &container.Config{Image: "nginx"}},
+			{Config: &container.Config{Image: "redis"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pod.Containers) != 2 || !pod.Containers[0].Holder {
+		t.Fatalf("expected the holder and a member, got %+v", pod.Containers)
+	}
+}
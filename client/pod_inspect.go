@@ -0,0 +1,41 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// PodInspect returns the containers of a pod in the docker host.
+func (cli *Client) PodInspect(ctx context.Context, podName string) (types.Pod, error) {
+	pod, _, err := cli.PodInspectWithRaw(ctx, podName)
+	return pod, err
+}
+
+// PodInspectWithRaw returns the containers of a pod in the docker host and its raw representation
+func (cli *Client) PodInspectWithRaw(ctx context.Context, podName string) (types.Pod, []byte, error) {
+	if err := cli.NewVersionError("1.25", "pods"); err != nil {
+		return types.Pod{}, nil, err
+	}
+	var pod types.Pod
+	resp, err := cli.get(ctx, "/pods/"+podName, nil, nil)
+	if err != nil {
+		if resp.statusCode == http.StatusNotFound {
+			return pod, nil, podNotFoundError{podName}
+		}
+		return pod, nil, err
+	}
+	defer ensureReaderClosed(resp)
+
+	body, err := ioutil.ReadAll(resp.body)
+	if err != nil {
+		return pod, nil, err
+	}
+	rdr := bytes.NewReader(body)
+	err = json.NewDecoder(rdr).Decode(&pod)
+	return pod, body, err
+}
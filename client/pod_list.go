@@ -0,0 +1,24 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// PodList returns the pods in the docker host.
+func (cli *Client) PodList(ctx context.Context) ([]types.Pod, error) {
+	if err := cli.NewVersionError("1.25", "pods"); err != nil {
+		return nil, err
+	}
+	var pods []types.Pod
+	resp, err := cli.get(ctx, "/pods", nil, nil)
+	if err != nil {
+		return pods, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&pods)
+	ensureReaderClosed(resp)
+	return pods, err
+}
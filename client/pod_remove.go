@@ -0,0 +1,25 @@
+package client
+
+import (
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// PodRemove removes the containers of a pod from the docker host.
+func (cli *Client) PodRemove(ctx context.Context, podName string, options types.PodRemoveOptions) error {
+	if err := cli.NewVersionError("1.25", "pods"); err != nil {
+		return err
+	}
+	query := url.Values{}
+	if options.RemoveVolumes {
+		query.Set("v", "1")
+	}
+	if options.Force {
+		query.Set("force", "1")
+	}
+	resp, err := cli.delete(ctx, "/pods/"+podName, query, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,13 @@
+package client
+
+import "golang.org/x/net/context"
+
+// PodStart starts the containers of a pod, starting with its holder.
+func (cli *Client) PodStart(ctx context.Context, podName string) error {
+	if err := cli.NewVersionError("1.25", "pods"); err != nil {
+		return err
+	}
+	resp, err := cli.post(ctx, "/pods/"+podName+"/start", nil, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
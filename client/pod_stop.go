@@ -0,0 +1,25 @@
+package client
+
+import (
+	"net/url"
+	"time"
+
+	timetypes "github.com/docker/docker/api/types/time"
+	"golang.org/x/net/context"
+)
+
+// PodStop stops the containers of a pod, ending with its holder. The
+// containers are killed if they do not stop within the timeout, or within
+// their own stop timeout if timeout is nil.
+func (cli *Client) PodStop(ctx context.Context, podName string, timeout *time.Duration) error {
+	if err := cli.NewVersionError("1.25", "pods"); err != nil {
+		return err
+	}
+	query := url.Values{}
+	if timeout != nil {
+		query.Set("t", timetypes.DurationToSecondsString(*timeout))
+	}
+	resp, err := cli.post(ctx, "/pods/"+podName+"/stop", query, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// RegistryRepositories makes the docker host list the repositories of the
+// catalog of a registry.
+func (cli *Client) RegistryRepositories(ctx context.Context, hostname string, options types.RegistryRepositoriesOptions) (types.RegistryRepositories, error) {
+	var repositories types.RegistryRepositories
+	query := url.Values{}
+	if options.Last != "" {
+		query.Set("last", options.Last)
+	}
+	if options.Limit != 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+
+	resp, err := cli.get(ctx, "/registries/"+hostname+"/repositories", query, registryAuthHeaders(options.RegistryAuth))
+	if err != nil {
+		return repositories, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&repositories)
+	ensureReaderClosed(resp)
+	return repositories, err
+}
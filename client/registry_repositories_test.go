@@ -0,0 +1,66 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestRegistryRepositoriesError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.RegistryRepositories(context.Background(), "example.com", types.RegistryRepositoriesOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestRegistryRepositories(t *testing.T) {
+	expectedURL := "/registries/example.com:5000/repositories"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			query := r.URL.Query()
+			if last := query.Get("last"); last != "alpine" {
+				return nil, fmt.Errorf("last not set in URL query properly. Expected 'alpine', got %s", last)
+			}
+			if limit := query.Get("limit"); limit != "2" {
+				return nil, fmt.Errorf("limit not set in URL query properly. Expected '2', got %s", limit)
+			}
+			if auth := r.Header.Get("X-Registry-Auth"); auth != "auth" {
+				return nil, fmt.Errorf("expected the registry auth header, got %q", auth)
+			}
+			b, err := json.Marshal(types.RegistryRepositories{
+				Repositories: []string{"busybox", "debian"},
+				Next:         "debian",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	repositories, err := client.RegistryRepositories(context.Background(), "example.com:5000", types.RegistryRepositoriesOptions{
+		RegistryAuth: "auth",
+		Last:         "alpine",
+		Limit:        2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repositories.Repositories) != 2 || repositories.Next != "debian" {
+		t.Fatalf("unexpected repositories %v", repositories)
+	}
+}
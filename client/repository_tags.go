@@ -0,0 +1,22 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// RepositoryTags makes the docker host list the tags of a repository in its
+// registry.
+func (cli *Client) RepositoryTags(ctx context.Context, name string, options types.RepositoryTagsOptions) (types.RepositoryTags, error) {
+	var tags types.RepositoryTags
+	resp, err := cli.get(ctx, "/repositories/"+name+"/tags", nil, registryAuthHeaders(options.RegistryAuth))
+	if err != nil {
+		return tags, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&tags)
+	ensureReaderClosed(resp)
+	return tags, err
+}
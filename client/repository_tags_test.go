@@ -0,0 +1,52 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestRepositoryTagsError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.RepositoryTags(context.Background(), "nothing", types.RepositoryTagsOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestRepositoryTags(t *testing.T) {
+	expectedURL := "/repositories/example.com:5000/busybox/tags"
+	client := &Client{
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			b, err := json.Marshal(types.RepositoryTags{
+				Name: "example.com:5000/busybox",
+				Tags: []string{"1.25", "latest"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+	tags, err := client.RepositoryTags(context.Background(), "example.com:5000/busybox", types.RepositoryTagsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Name != "example.com:5000/busybox" || len(tags.Tags) != 2 {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+}
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// SystemMaintenance enables or disables the maintenance mode of the daemon
+func (cli *Client) SystemMaintenance(ctx context.Context, config types.MaintenanceConfig) error {
+	resp, err := cli.post(ctx, "/system/maintenance", nil, config, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestSystemMaintenanceError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.SystemMaintenance(context.Background(), types.MaintenanceConfig{Enabled: true})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestSystemMaintenance(t *testing.T) {
+	expectedURL := "/system/maintenance"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var config types.MaintenanceConfig
+			if err := json.NewDecoder(req.Body).Decode(&config); err != nil {
+				return nil, err
+			}
+			if !config.Enabled || config.Mode != types.MaintenanceQueue || !config.StopContainers {
+				return nil, fmt.Errorf("unexpected maintenance config %+v", config)
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.SystemMaintenance(context.Background(), types.MaintenanceConfig{
+		Enabled:        true,
+		Mode:           types.MaintenanceQueue,
+		StopContainers: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
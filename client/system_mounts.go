@@ -0,0 +1,39 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// SystemLeakedMounts returns the mounts of container filesystems which are
+// not expected to exist on the daemon host.
+func (cli *Client) SystemLeakedMounts(ctx context.Context) ([]types.LeakedMount, error) {
+	var leaks []types.LeakedMount
+
+	serverResp, err := cli.get(ctx, "/system/mounts/leaked", nil, nil)
+	if err != nil {
+		return leaks, err
+	}
+	defer ensureReaderClosed(serverResp)
+
+	err = json.NewDecoder(serverResp.body).Decode(&leaks)
+	return leaks, err
+}
+
+// SystemUnmountLeakedMounts force-unmounts the mounts of container
+// filesystems which are not expected to exist on the daemon host, and
+// returns the unmounted ones.
+func (cli *Client) SystemUnmountLeakedMounts(ctx context.Context) ([]types.LeakedMount, error) {
+	var unmounted []types.LeakedMount
+
+	serverResp, err := cli.post(ctx, "/system/mounts/leaked/unmount", nil, nil, nil)
+	if err != nil {
+		return unmounted, err
+	}
+	defer ensureReaderClosed(serverResp)
+
+	err = json.NewDecoder(serverResp.body).Decode(&unmounted)
+	return unmounted, err
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestSystemLeakedMountsError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.SystemLeakedMounts(context.Background())
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func leakedMountsMock(expectedURL, expectedMethod string, leaks []types.LeakedMount) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != expectedURL {
+			return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != expectedMethod {
+			return nil, fmt.Errorf("expected %s method, got %s", expectedMethod, req.Method)
+		}
+		b, err := json.Marshal(leaks)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	}
+}
+
+func TestSystemLeakedMounts(t *testing.T) {
+	expected := []types.LeakedMount{
+		{Path: "/var/lib/docker/overlay2/0123/merged", MountID: "0123", ContainerID: "c1"},
+		{Path: "/var/lib/docker/aufs/mnt/4567", MountID: "4567"},
+	}
+	client := &Client{
+		client: newMockClient(leakedMountsMock("/system/mounts/leaked", "GET", expected)),
+	}
+
+	leaks, err := client.SystemLeakedMounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(leaks, expected) {
+		t.Fatalf("expected %v, got %v", expected, leaks)
+	}
+}
+
+func TestSystemUnmountLeakedMountsError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.SystemUnmountLeakedMounts(context.Background())
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestSystemUnmountLeakedMounts(t *testing.T) {
+	expected := []types.LeakedMount{
+		{Path: "/var/lib/docker/overlay2/0123/merged", MountID: "0123"},
+	}
+	client := &Client{
+		client: newMockClient(leakedMountsMock("/system/mounts/leaked/unmount", "POST", expected)),
+	}
+
+	unmounted, err := client.SystemUnmountLeakedMounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unmounted, expected) {
+		t.Fatalf("expected %v, got %v", expected, unmounted)
+	}
+}
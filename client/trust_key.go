@@ -0,0 +1,27 @@
+package client
+
+import (
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// TrustKeyExport retrieves the trust key of the daemon in PEM format as an
+// io.ReadCloser, encrypted with the passphrase of the options. It's up to
+// the caller to store the key and close the stream.
+func (cli *Client) TrustKeyExport(ctx context.Context, options types.TrustKeyExportOptions) (io.ReadCloser, error) {
+	resp, err := cli.post(ctx, "/trust/key/export", nil, options, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// TrustKeyImport replaces the trust key of the daemon. The key is used once
+// the daemon is restarted.
+func (cli *Client) TrustKeyImport(ctx context.Context, options types.TrustKeyImportOptions) error {
+	resp, err := cli.post(ctx, "/trust/key/import", nil, options, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestTrustKeyExportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.TrustKeyExport(context.Background(), types.TrustKeyExportOptions{Passphrase: "secret"})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestTrustKeyExport(t *testing.T) {
+	expectedURL := "/trust/key/export"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var options types.TrustKeyExportOptions
+			if err := json.NewDecoder(req.Body).Decode(&options); err != nil {
+				return nil, err
+			}
+			if options.Passphrase != "secret" {
+				return nil, fmt.Errorf("unexpected export options %+v", options)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("key"))),
+			}, nil
+		}),
+	}
+
+	body, err := client.TrustKeyExport(context.Background(), types.TrustKeyExportOptions{Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	key, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(key) != "key" {
+		t.Fatalf("expected the key to be returned, got %q", key)
+	}
+}
+
+func TestTrustKeyImportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.TrustKeyImport(context.Background(), types.TrustKeyImportOptions{Key: []byte("key")})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestTrustKeyImport(t *testing.T) {
+	expectedURL := "/trust/key/import"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != expectedURL {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var options types.TrustKeyImportOptions
+			if err := json.NewDecoder(req.Body).Decode(&options); err != nil {
+				return nil, err
+			}
+			if string(options.Key) != "key" || options.Passphrase != "secret" {
+				return nil, fmt.Errorf("unexpected import options %+v", options)
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.TrustKeyImport(context.Background(), types.TrustKeyImportOptions{Key: []byte("key"), Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
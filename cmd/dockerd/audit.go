@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/server/middleware"
+	"github.com/docker/docker/daemon"
+)
+
+// newAuditLogger returns the logger of the audit log: the daemon events if
+// target is "events", or else the file at the path target.
+func newAuditLogger(target string, d *daemon.Daemon) (middleware.AuditLogger, error) {
+	if target == "events" {
+		return eventsAuditLogger{d}, nil
+	}
+	return middleware.NewAuditFileLogger(target)
+}
+
+// eventsAuditLogger logs the audit records as audit events of the daemon.
+type eventsAuditLogger struct {
+	d *daemon.Daemon
+}
+
+func (l eventsAuditLogger) LogAudit(record middleware.AuditRecord) {
+	attributes := map[string]string{
+		"method": record.Method,
+		"uri":    record.URI,
+	}
+	if record.User != "" {
+		attributes["user"] = record.User
+	}
+	if record.Peer != "" {
+		attributes["peer"] = record.Peer
+	}
+	if record.Body != nil {
+		if b, err := json.Marshal(record.Body); err == nil {
+			attributes["body"] = string(b)
+		}
+	}
+	if record.Error != "" {
+		attributes["error"] = record.Error
+	}
+	l.d.LogAuditEvent(attributes)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -17,8 +18,11 @@ import (
 	"github.com/docker/docker/api/server/router"
 	"github.com/docker/docker/api/server/router/build"
 	"github.com/docker/docker/api/server/router/container"
+	"github.com/docker/docker/api/server/router/envfile"
 	"github.com/docker/docker/api/server/router/image"
+	"github.com/docker/docker/api/server/router/job"
 	"github.com/docker/docker/api/server/router/network"
+	"github.com/docker/docker/api/server/router/pod"
 	swarmrouter "github.com/docker/docker/api/server/router/swarm"
 	systemrouter "github.com/docker/docker/api/server/router/system"
 	"github.com/docker/docker/api/server/router/volume"
@@ -166,6 +170,10 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 		Version:     dockerversion.Version,
 		EnableCors:  cli.Config.EnableCors,
 		CorsHeaders: cli.Config.CorsHeaders,
+
+		ReadTimeout:           time.Duration(cli.Config.APIReadTimeout) * time.Second,
+		IdleTimeout:           time.Duration(cli.Config.APIIdleTimeout) * time.Second,
+		MaxConcurrentRequests: cli.Config.APIMaxConcurrentRequests,
 	}
 
 	if cli.Config.TLS {
@@ -187,7 +195,7 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	}
 
 	if len(cli.Config.Hosts) == 0 {
-		cli.Config.Hosts = make([]string, 1)
+		cli.Config.Hosts = []string{getDefaultHost(cli.Config)}
 	}
 
 	api := apiserver.New(serverConfig)
@@ -212,10 +220,20 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 		if proto == "tcp" && (serverConfig.TLSConfig == nil || serverConfig.TLSConfig.ClientAuth != tls.RequireAndVerifyClientCert) {
 			logrus.Warn("[!] DON'T BIND ON ANY IP ADDRESS WITHOUT setting -tlsverify IF YOU DON'T KNOW WHAT YOU'RE DOING [!]")
 		}
-		ls, err := listeners.Init(proto, addr, serverConfig.SocketGroup, serverConfig.TLSConfig)
+		keepAlive := time.Duration(cli.Config.APITCPKeepAlive) * time.Second
+		writeTimeout := time.Duration(cli.Config.APIWriteTimeout) * time.Second
+		ls, err := listeners.Init(proto, addr, serverConfig.SocketGroup, serverConfig.TLSConfig, keepAlive, writeTimeout)
 		if err != nil {
 			return err
 		}
+		for i := range ls {
+			if cli.Config.AuditLog != "" || len(cli.Config.SocketRoles) > 0 {
+				// The audit log records the credentials of the clients of
+				// the unix sockets, and the socket roles are given by them.
+				ls[i] = listeners.WithPeerCredentials(ls[i])
+			}
+			ls[i] = listeners.WithWriteTimeout(ls[i], writeTimeout)
+		}
 		ls = wrapListeners(proto, ls)
 		// If we're binding to a TCP port, make sure that a container doesn't try to use it.
 		if proto == "tcp" {
@@ -275,7 +293,9 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 		"graphdriver": d.GraphDriverName(),
 	}).Info("Docker daemon")
 
-	cli.initMiddlewares(api, serverConfig)
+	if err := cli.initMiddlewares(api, serverConfig, d); err != nil {
+		return err
+	}
 	initRouter(api, d, c)
 
 	cli.d = d
@@ -294,7 +314,7 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	// Wait for serve API to complete
 	errAPI := <-serveAPIWait
 	c.Cleanup()
-	shutdownDaemon(d, 15)
+	shutdownDaemon(d)
 	containerdRemote.Cleanup()
 	if errAPI != nil {
 		return fmt.Errorf("Shutting down due to ServeAPI error: %v", errAPI)
@@ -340,16 +360,22 @@ func (cli *DaemonCli) stop() {
 // shutdownDaemon just wraps daemon.Shutdown() to handle a timeout in case
 // d.Shutdown() is waiting too long to kill container or worst it's
 // blocked there
-func shutdownDaemon(d *daemon.Daemon, timeout time.Duration) {
+func shutdownDaemon(d *daemon.Daemon) {
+	shutdownTimeout := d.ShutdownTimeout()
 	ch := make(chan struct{})
 	go func() {
 		d.Shutdown()
 		close(ch)
 	}()
+	if shutdownTimeout < 0 {
+		<-ch
+		logrus.Debug("Clean shutdown succeeded")
+		return
+	}
 	select {
 	case <-ch:
 		logrus.Debug("Clean shutdown succeeded")
-	case <-time.After(timeout * time.Second):
+	case <-time.After(time.Duration(shutdownTimeout) * time.Second):
 		logrus.Error("Force shutdown daemon")
 	}
 }
@@ -413,6 +439,9 @@ func initRouter(s *apiserver.Server, d *daemon.Daemon, c *cluster.Cluster) {
 		image.NewRouter(d, decoder),
 		systemrouter.NewRouter(d, c),
 		volume.NewRouter(d),
+		pod.NewRouter(d),
+		job.NewRouter(d),
+		envfile.NewRouter(d),
 		build.NewRouter(dockerfile.NewBuildManager(d)),
 		swarmrouter.NewRouter(c),
 	}...)
@@ -424,7 +453,7 @@ func initRouter(s *apiserver.Server, d *daemon.Daemon, c *cluster.Cluster) {
 	s.InitRouter(utils.IsDebugEnabled(), routers...)
 }
 
-func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config) {
+func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config, d *daemon.Daemon) error {
 	v := cfg.Version
 
 	vm := middleware.NewVersionMiddleware(v, api.DefaultVersion, api.MinVersion)
@@ -440,4 +469,37 @@ func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config
 
 	cli.authzMiddleware = authorization.NewMiddleware(cli.Config.AuthorizationPlugins)
 	s.UseMiddleware(cli.authzMiddleware)
+
+	if len(cli.Config.SocketRoles) > 0 {
+		if runtime.GOOS != "linux" {
+			return fmt.Errorf("socket roles are only supported on Linux")
+		}
+		for name, role := range cli.Config.SocketRoles {
+			// Without the supplementary groups of the clients, their
+			// requests would not be restricted by the roles of these groups.
+			if len(role.GIDs) > 0 && !listeners.PeerGroupsSupported() {
+				return fmt.Errorf("socket role %s: the group ids require the kernel to report the groups of the clients of the unix sockets (SO_PEERGROUPS, Linux 4.13 or later)", name)
+			}
+		}
+		rm, err := middleware.NewSocketRolesMiddleware(cli.Config.SocketRoles)
+		if err != nil {
+			return err
+		}
+		s.UseMiddleware(rm)
+	}
+
+	// The audit middleware is the outermost one, so that the requests the
+	// other middlewares reject are logged too.
+	if cli.Config.AuditLog != "" {
+		logger, err := newAuditLogger(cli.Config.AuditLog, d)
+		if err != nil {
+			return fmt.Errorf("Error opening the audit log: %v", err)
+		}
+		level := cli.Config.AuditLevel
+		if level == "" {
+			level = middleware.AuditLevelMetadata
+		}
+		s.UseMiddleware(middleware.NewAuditMiddleware(logger, level, cli.Config.AuditRedact))
+	}
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/docker/docker/daemon"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/system"
 )
@@ -42,6 +43,12 @@ func getDaemonConfDir() string {
 	return "/etc/docker"
 }
 
+// getDefaultHost returns the address the daemon listens on if no host is
+// set: the default address of the platform.
+func getDefaultHost(config *daemon.Config) string {
+	return ""
+}
+
 // setupConfigReloadTrap configures the USR2 signal to reload the configuration.
 func (cli *DaemonCli) setupConfigReloadTrap() {
 }
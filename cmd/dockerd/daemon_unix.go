@@ -14,11 +14,13 @@ import (
 	"github.com/docker/docker/cmd/dockerd/hack"
 	"github.com/docker/docker/daemon"
 	"github.com/docker/docker/libcontainerd"
+	"github.com/docker/docker/pkg/homedir"
+	"github.com/docker/docker/pkg/rootless"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/libnetwork/portallocator"
 )
 
-const defaultDaemonConfigFile = "/etc/docker/daemon.json"
+var defaultDaemonConfigFile = filepath.Join(getDaemonConfDir(), "daemon.json")
 
 // currentUserIsOwner checks whether the current user is the owner of the given
 // file.
@@ -44,9 +46,27 @@ func setDefaultUmask() error {
 }
 
 func getDaemonConfDir() string {
+	// In rootless mode, the configuration of the daemon is in the
+	// configuration directory of the user.
+	if rootless.RunningWithRootlessKit() {
+		if configHome, err := homedir.GetConfigHome(); err == nil {
+			return filepath.Join(configHome, "docker")
+		}
+	}
 	return "/etc/docker"
 }
 
+// getDefaultHost returns the address the daemon listens on if no host is
+// set. In rootless mode, the socket is in the runtime directory of the user.
+func getDefaultHost(config *daemon.Config) string {
+	if config.Rootless {
+		if runtimeDir, err := homedir.GetRuntimeDir(); err == nil {
+			return "unix://" + filepath.Join(runtimeDir, "docker.sock")
+		}
+	}
+	return ""
+}
+
 // setupConfigReloadTrap configures the USR2 signal to reload the configuration.
 func (cli *DaemonCli) setupConfigReloadTrap() {
 	c := make(chan os.Signal, 1)
@@ -61,7 +81,7 @@ func (cli *DaemonCli) setupConfigReloadTrap() {
 func (cli *DaemonCli) getPlatformRemoteOptions() []libcontainerd.RemoteOption {
 	opts := []libcontainerd.RemoteOption{
 		libcontainerd.WithDebugLog(cli.Config.Debug),
-		libcontainerd.WithOOMScore(cli.Config.OOMScoreAdjust),
+		libcontainerd.WithOOMScore(cli.Config.GetOOMScoreAdjust()),
 	}
 	if cli.Config.ContainerdAddr != "" {
 		opts = append(opts, libcontainerd.WithRemoteAddr(cli.Config.ContainerdAddr))
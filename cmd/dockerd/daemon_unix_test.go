@@ -3,6 +3,7 @@
 package main
 
 import (
+	"os"
 	"testing"
 
 	"github.com/docker/docker/daemon"
@@ -110,3 +111,22 @@ func TestLoadDaemonConfigWithLegacyRegistryOptions(t *testing.T) {
 	assert.NotNil(t, loadedConfig)
 	assert.Equal(t, loadedConfig.V2Only, true)
 }
+
+func TestLoadDaemonConfigWithRootless(t *testing.T) {
+	content := `{"rootless": true}`
+	tempFile := tempfile.NewTempFile(t, "config", content)
+	defer tempFile.Remove()
+
+	opts := defaultOptions(tempFile.Name())
+	loadedConfig, err := loadDaemonCliConfig(opts)
+	assert.NilError(t, err)
+	assert.NotNil(t, loadedConfig)
+	assert.Equal(t, loadedConfig.Rootless, true)
+
+	defer os.Setenv("XDG_RUNTIME_DIR", os.Getenv("XDG_RUNTIME_DIR"))
+	os.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, getDefaultHost(loadedConfig), "unix:///run/user/1000/docker.sock")
+
+	loadedConfig.Rootless = false
+	assert.Equal(t, getDefaultHost(loadedConfig), "")
+}
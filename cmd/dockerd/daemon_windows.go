@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/system"
 )
@@ -28,6 +29,12 @@ func getDaemonConfDir() string {
 	return os.Getenv("PROGRAMDATA") + `\docker\config`
 }
 
+// getDefaultHost returns the address the daemon listens on if no host is
+// set: the default address of the platform.
+func getDefaultHost(config *daemon.Config) string {
+	return ""
+}
+
 // notifySystem sends a message to the host when the server is ready to be used
 func notifySystem() {
 	if service != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	"github.com/docker/docker/daemon/logger/local"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -44,6 +45,23 @@ import (
 
 const configFileName = "config.v2.json"
 
+// DefaultStopTimeout is the timeout (in seconds) for the stop signal to
+// stop a container before it is killed, when none is set in its config.
+const DefaultStopTimeout = 10
+
+// PublishedPortRangeOption is the driver option of a network which sets the
+// range of the host ports allocated to the ports published without a host
+// port on the network. It overrides the range set with
+// --published-port-range.
+const PublishedPortRangeOption = netlabel.Prefix + ".published_port_range"
+
+// The logs of the drivers that cannot read logs back are kept in a local
+// cache of at most logCacheMaxFile files of logCacheMaxSize.
+const (
+	logCacheMaxSize = "20m"
+	logCacheMaxFile = "2"
+)
+
 var (
 	errInvalidEndpoint = fmt.Errorf("invalid endpoint while building port map info")
 	errInvalidNetwork  = fmt.Errorf("invalid network settings while building port map info")
@@ -165,6 +183,68 @@ func (container *Container) ToDiskLocking() error {
 	return err
 }
 
+// UpdateLabels adds and removes labels of the container and saves the
+// result to disk. Labels in add overwrite existing values; keys in remove
+// that are not set are ignored.
+func (container *Container) UpdateLabels(add map[string]string, remove []string) error {
+	container.Lock()
+	defer container.Unlock()
+
+	labels := make(map[string]string, len(container.Config.Labels)+len(add))
+	for k, v := range container.Config.Labels {
+		labels[k] = v
+	}
+	for _, k := range remove {
+		delete(labels, k)
+	}
+	for k, v := range add {
+		labels[k] = v
+	}
+
+	backup := container.Config.Labels
+	container.Config.Labels = labels
+	if err := container.ToDisk(); err != nil {
+		container.Config.Labels = backup
+		return err
+	}
+	return nil
+}
+
+// UpdateExtraHosts adds and removes extra hosts entries of the container and
+// saves the result to disk. Entries in add, in the host:ip form, replace the
+// existing entries of the same host; all the entries of the hosts in remove
+// are removed. It returns the entries that were replaced or removed.
+func (container *Container) UpdateExtraHosts(add, remove []string) ([]string, error) {
+	container.Lock()
+	defer container.Unlock()
+
+	removed := make(map[string]bool, len(remove)+len(add))
+	for _, h := range remove {
+		removed[h] = true
+	}
+	for _, h := range add {
+		removed[strings.SplitN(h, ":", 2)[0]] = true
+	}
+
+	var extraHosts, dropped []string
+	for _, h := range container.HostConfig.ExtraHosts {
+		if removed[strings.SplitN(h, ":", 2)[0]] {
+			dropped = append(dropped, h)
+			continue
+		}
+		extraHosts = append(extraHosts, h)
+	}
+	extraHosts = append(extraHosts, add...)
+
+	backup := container.HostConfig.ExtraHosts
+	container.HostConfig.ExtraHosts = extraHosts
+	if err := container.ToDisk(); err != nil {
+		container.HostConfig.ExtraHosts = backup
+		return nil, err
+	}
+	return dropped, nil
+}
+
 // readHostConfig reads the host configuration from disk for the container.
 func (container *Container) readHostConfig() error {
 	container.HostConfig = &containertypes.HostConfig{}
@@ -332,14 +412,75 @@ func (container *Container) StartLogger(cfg containertypes.LogConfig) (logger.Lo
 		DaemonName:          "docker",
 	}
 
-	// Set logging file for "json-logger"
-	if cfg.Type == jsonfilelog.Name {
+	// Set logging file for "json-logger" and "local"
+	switch cfg.Type {
+	case jsonfilelog.Name:
 		ctx.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s-json.log", container.ID))
-		if err != nil {
-			return nil, err
+	case local.Name:
+		ctx.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s-local.log", container.ID))
+	}
+	if err != nil {
+		return nil, err
+	}
+	l, err := c(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep a local copy of the logs of the drivers that cannot read them
+	// back, when it is enabled, so that they remain available through the
+	// daemon.
+	if _, ok := l.(logger.LogReader); ok || !logger.CacheEnabled(cfg.Config) {
+		return l, nil
+	}
+	cache, err := container.newLogCache(ctx)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return logger.NewReadableLogger(l, cache), nil
+}
+
+// OpenLogCache opens the local cache of the logs of a container whose
+// logging driver cannot read logs back, without starting the driver. It
+// returns nil if the container has no cache.
+func (container *Container) OpenLogCache() (logger.ReadCacheLogger, error) {
+	path, err := container.logCachePath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
 		}
+		return nil, err
+	}
+	return container.newLogCache(logger.Context{
+		ContainerID:   container.ID,
+		ContainerName: container.Name,
+	})
+}
+
+func (container *Container) logCachePath() (string, error) {
+	return container.GetRootResourcePath(fmt.Sprintf("%s-cache.log", container.ID))
+}
+
+// newLogCache creates the local cache of the logs written with ctx.
+func (container *Container) newLogCache(ctx logger.Context) (logger.ReadCacheLogger, error) {
+	var err error
+	ctx.Config = map[string]string{
+		"max-size": logCacheMaxSize,
+		"max-file": logCacheMaxFile,
+	}
+	ctx.LogPath, err = container.logCachePath()
+	if err != nil {
+		return nil, err
 	}
-	return c(ctx)
+	cache, err := local.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize the log cache: %v", err)
+	}
+	return cache.(logger.ReadCacheLogger), nil
 }
 
 // GetProcessLabel returns the process label for the container.
@@ -365,14 +506,15 @@ func (container *Container) GetExecIDs() []string {
 
 // Attach connects to the container's TTY, delegating to standard
 // streams or websockets depending on the configuration.
-func (container *Container) Attach(stdin io.ReadCloser, stdout io.Writer, stderr io.Writer, keys []byte) chan error {
+func (container *Container) Attach(stdin io.ReadCloser, stdout io.Writer, stderr io.Writer, keys []byte, scrollback runconfig.Scrollback) chan error {
 	ctx := container.InitAttachContext()
-	return AttachStreams(ctx, container.StreamConfig, container.Config.OpenStdin, container.Config.StdinOnce, container.Config.Tty, stdin, stdout, stderr, keys)
+	return AttachStreams(ctx, container.StreamConfig, container.Config.OpenStdin, container.Config.StdinOnce, container.Config.Tty, stdin, stdout, stderr, keys, scrollback)
 }
 
-// AttachStreams connects streams to a TTY.
+// AttachStreams connects streams to a TTY. The output of the scrollback
+// selected by scrollback is replayed on stdout first.
 // Used by exec too. Should this move somewhere else?
-func AttachStreams(ctx context.Context, streamConfig *runconfig.StreamConfig, openStdin, stdinOnce, tty bool, stdin io.ReadCloser, stdout io.Writer, stderr io.Writer, keys []byte) chan error {
+func AttachStreams(ctx context.Context, streamConfig *runconfig.StreamConfig, openStdin, stdinOnce, tty bool, stdin io.ReadCloser, stdout io.Writer, stderr io.Writer, keys []byte, scrollback runconfig.Scrollback) chan error {
 	var (
 		cStdout, cStderr io.ReadCloser
 		cStdin           io.WriteCloser
@@ -386,7 +528,7 @@ func AttachStreams(ctx context.Context, streamConfig *runconfig.StreamConfig, op
 	}
 
 	if stdout != nil {
-		cStdout = streamConfig.StdoutPipe()
+		cStdout = streamConfig.StdoutPipeWithScrollback(scrollback)
 		wg.Add(1)
 	}
 
@@ -580,6 +722,14 @@ func (container *Container) StopSignal() int {
 	return int(stopSignal)
 }
 
+// StopTimeout returns the timeout (in seconds) used to stop the container.
+func (container *Container) StopTimeout() int {
+	if container.Config.StopTimeout != nil {
+		return *container.Config.StopTimeout
+	}
+	return DefaultStopTimeout
+}
+
 // InitDNSHostConfig ensures that the dns fields are never nil.
 // New containers don't ever have those fields nil,
 // but pre created containers can still have those nil values.
@@ -782,6 +932,9 @@ func (container *Container) BuildJoinOptions(n libnetwork.Network) ([]libnetwork
 }
 
 // BuildCreateEndpointOptions builds endpoint options from a given network.
+// The ports published without a host port are allocated from the range set
+// with the PublishedPortRangeOption of the network, or from the range of the
+// daemon if the network has none.
 func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epConfig *networktypes.EndpointSettings, sb libnetwork.Sandbox, daemonDNS []string) ([]libnetwork.EndpointOption, error) {
 	var (
 		bindings      = make(nat.PortMap)
@@ -878,6 +1031,12 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 		}
 	}
 
+	dynamicPortRange := n.Info().DriverOptions()[PublishedPortRangeOption]
+	dynamicStart, dynamicEnd, err := nat.ParsePortRangeToInt(dynamicPortRange)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing published port range(%s):%v", dynamicPortRange, err)
+	}
+
 	portSpecs := container.Config.ExposedPorts
 	ports := make([]nat.Port, len(portSpecs))
 	var i int
@@ -904,6 +1063,9 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 			if err != nil {
 				return nil, fmt.Errorf("Error parsing HostPort value(%s):%v", binding[i].HostPort, err)
 			}
+			if portStart == 0 {
+				portStart, portEnd = dynamicStart, dynamicEnd
+			}
 			pbCopy.HostPort = uint16(portStart)
 			pbCopy.HostPortEnd = uint16(portEnd)
 			pbCopy.HostIP = net.ParseIP(binding[i].HostIP)
@@ -911,7 +1073,10 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 		}
 
 		if container.HostConfig.PublishAllPorts && len(binding) == 0 {
-			pbList = append(pbList, pb)
+			pbCopy := pb.GetCopy()
+			pbCopy.HostPort = uint16(dynamicStart)
+			pbCopy.HostPortEnd = uint16(dynamicEnd)
+			pbList = append(pbList, pbCopy)
 		}
 	}
 
@@ -49,8 +49,8 @@ func (container *Container) NetworkMounts() []Mount {
 }
 
 // CopyImagePathContent copies files in destination to the volume.
-func (container *Container) CopyImagePathContent(v volume.Volume, destination string) error {
-	return nil
+func (container *Container) CopyImagePathContent(v volume.Volume, destination string) (bool, error) {
+	return false, nil
 }
 
 // UnmountIpcMounts unmount Ipc related mounts.
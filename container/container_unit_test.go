@@ -1,9 +1,15 @@
 package container
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/pkg/signal"
 )
 
@@ -34,3 +40,133 @@ func TestContainerStopSignal(t *testing.T) {
 		t.Fatalf("Expected 9, got %v", s)
 	}
 }
+
+func TestContainerStopTimeout(t *testing.T) {
+	c := &Container{
+		CommonContainer: CommonContainer{
+			Config: &container.Config{},
+		},
+	}
+
+	s := c.StopTimeout()
+	if s != DefaultStopTimeout {
+		t.Fatalf("Expected %v, got %v", DefaultStopTimeout, s)
+	}
+
+	stopTimeout := 15
+	c = &Container{
+		CommonContainer: CommonContainer{
+			Config: &container.Config{StopTimeout: &stopTimeout},
+		},
+	}
+	s = c.StopTimeout()
+	if s != stopTimeout {
+		t.Fatalf("Expected %v, got %v", stopTimeout, s)
+	}
+}
+
+func TestContainerUpdateLabels(t *testing.T) {
+	root, err := ioutil.TempDir("", "docker-container-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	c := NewBaseContainer("labels", root)
+	c.Config = &container.Config{
+		Labels: map[string]string{"keep": "1", "change": "old", "drop": "1"},
+	}
+	c.HostConfig = &container.HostConfig{}
+
+	if err := c.UpdateLabels(map[string]string{"change": "new", "added": "1"}, []string{"drop", "missing"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"keep": "1", "change": "new", "added": "1"}
+	if len(c.Config.Labels) != len(expected) {
+		t.Fatalf("Expected labels %v, got %v", expected, c.Config.Labels)
+	}
+	for k, v := range expected {
+		if c.Config.Labels[k] != v {
+			t.Fatalf("Expected label %s=%s, got %v", k, v, c.Config.Labels)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, configFileName)); err != nil {
+		t.Fatalf("Expected container config to be saved to disk: %v", err)
+	}
+}
+
+func TestContainerUpdateExtraHosts(t *testing.T) {
+	root, err := ioutil.TempDir("", "docker-container-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	c := NewBaseContainer("hosts", root)
+	c.Config = &container.Config{}
+	c.HostConfig = &container.HostConfig{
+		ExtraHosts: []string{"keep:10.0.0.1", "change:10.0.0.2", "drop:10.0.0.3"},
+	}
+
+	dropped, err := c.UpdateExtraHosts([]string{"change:10.0.0.4", "added:10.0.0.5"}, []string{"drop", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"change:10.0.0.2", "drop:10.0.0.3"}; !reflect.DeepEqual(dropped, expected) {
+		t.Fatalf("Expected dropped entries %v, got %v", expected, dropped)
+	}
+
+	expected := []string{"keep:10.0.0.1", "change:10.0.0.4", "added:10.0.0.5"}
+	if !reflect.DeepEqual(c.HostConfig.ExtraHosts, expected) {
+		t.Fatalf("Expected extra hosts %v, got %v", expected, c.HostConfig.ExtraHosts)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "hostconfig.json")); err != nil {
+		t.Fatalf("Expected host config to be saved to disk: %v", err)
+	}
+}
+
+func TestContainerOpenLogCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "docker-container-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	c := NewBaseContainer("logcache", root)
+	if cache, err := c.OpenLogCache(); err != nil || cache != nil {
+		t.Fatalf("Expected no log cache, got %v, %v", cache, err)
+	}
+
+	cache, err := c.newLogCache(logger.Context{ContainerID: c.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Log(&logger.Message{Line: []byte("hello"), Source: "stdout", Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	cache.Close()
+
+	reader, err := c.OpenLogCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reader == nil {
+		t.Fatal("Expected the log cache to be opened")
+	}
+	defer reader.Close()
+	watcher := reader.ReadLogs(logger.ReadConfig{Tail: -1})
+	defer watcher.Close()
+	select {
+	case msg, ok := <-watcher.Msg:
+		if !ok || string(msg.Line) != "hello\n" {
+			t.Fatalf("Expected the cached message, got %v", msg)
+		}
+	case err := <-watcher.Err:
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the cached message")
+	}
+}
@@ -174,24 +174,25 @@ func (container *Container) NetworkMounts() []Mount {
 	return mounts
 }
 
-// CopyImagePathContent copies files in destination to the volume.
-func (container *Container) CopyImagePathContent(v volume.Volume, destination string) error {
+// CopyImagePathContent copies files in destination to the volume. It returns
+// whether files were copied, which is not the case if the volume is not empty.
+func (container *Container) CopyImagePathContent(v volume.Volume, destination string) (bool, error) {
 	rootfs, err := symlink.FollowSymlinkInScope(filepath.Join(container.BaseFS, destination), container.BaseFS)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if _, err = ioutil.ReadDir(rootfs); err != nil {
 		if os.IsNotExist(err) {
-			return nil
+			return false, nil
 		}
-		return err
+		return false, err
 	}
 
 	id := stringid.GenerateNonCryptoID()
 	path, err := v.Mount(id)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	defer func() {
@@ -200,7 +201,7 @@ func (container *Container) CopyImagePathContent(v volume.Volume, destination st
 		}
 	}()
 	if err := label.Relabel(path, container.MountLabel, true); err != nil && err != syscall.ENOTSUP {
-		return err
+		return false, err
 	}
 	return copyExistingContents(rootfs, path)
 }
@@ -218,7 +219,7 @@ func (container *Container) HasMountFor(path string) bool {
 
 // UnmountIpcMounts uses the provided unmount function to unmount shm and mqueue if they were mounted
 func (container *Container) UnmountIpcMounts(unmount func(pth string) error) {
-	if container.HostConfig.IpcMode.IsContainer() || container.HostConfig.IpcMode.IsHost() {
+	if container.HostConfig.IpcMode.IsContainer() || container.HostConfig.IpcMode.IsHost() || container.HostConfig.IpcMode.IsNone() {
 		return
 	}
 
@@ -246,7 +247,7 @@ func (container *Container) UnmountIpcMounts(unmount func(pth string) error) {
 func (container *Container) IpcMounts() []Mount {
 	var mounts []Mount
 
-	if !container.HasMountFor("/dev/shm") {
+	if !container.HasMountFor("/dev/shm") && container.ShmPath != "" {
 		label.SetFileLabel(container.ShmPath, container.MountLabel)
 		mounts = append(mounts, Mount{
 			Source:      container.ShmPath,
@@ -273,6 +274,18 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.CPUShares != 0 {
 		cResources.CPUShares = resources.CPUShares
 	}
+	// the CPU period and quota of the containers limited with NanoCPUs are
+	// computed by the daemon, so a container limited one way cannot be
+	// updated the other way
+	if cResources.NanoCPUs > 0 && (resources.CPUPeriod != 0 || resources.CPUQuota != 0) {
+		return fmt.Errorf("Conflicting options: CPU Period or Quota cannot be updated as NanoCPUs has already been set")
+	}
+	if resources.NanoCPUs > 0 && (cResources.CPUPeriod != 0 || cResources.CPUQuota != 0) {
+		return fmt.Errorf("Conflicting options: NanoCPUs cannot be updated as CPU Period or Quota has already been set")
+	}
+	if resources.NanoCPUs != 0 {
+		cResources.NanoCPUs = resources.NanoCPUs
+	}
 	if resources.CPUPeriod != 0 {
 		cResources.CPUPeriod = resources.CPUPeriod
 	}
@@ -302,6 +315,10 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	// the OOM killer setting is applied when the container is started
+	if resources.OomKillDisable != nil {
+		cResources.OomKillDisable = resources.OomKillDisable
+	}
 
 	// update HostConfig of container
 	if hostConfig.RestartPolicy.Name != "" {
@@ -370,24 +387,26 @@ func (container *Container) UnmountVolumes(forceSyscall bool, volumeEventLog fun
 
 // copyExistingContents copies from the source to the destination and
 // ensures the ownership is appropriately set.
-func copyExistingContents(source, destination string) error {
+func copyExistingContents(source, destination string) (bool, error) {
+	copied := false
 	volList, err := ioutil.ReadDir(source)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if len(volList) > 0 {
 		srcList, err := ioutil.ReadDir(destination)
 		if err != nil {
-			return err
+			return false, err
 		}
 		if len(srcList) == 0 {
 			// If the source volume is empty, copies files from the root into the volume
 			if err := chrootarchive.CopyWithTar(source, destination); err != nil {
-				return err
+				return false, err
 			}
+			copied = true
 		}
 	}
-	return copyOwnership(source, destination)
+	return copied, copyOwnership(source, destination)
 }
 
 // copyOwnership copies the permissions and uid:gid of the source file
@@ -405,7 +424,8 @@ func copyOwnership(source, destination string) error {
 	return os.Chmod(destination, os.FileMode(stat.Mode()))
 }
 
-// TmpfsMounts returns the list of tmpfs mounts
+// TmpfsMounts returns the list of tmpfs mounts, including the writable paths
+// of a read-only container that are not covered by another mount.
 func (container *Container) TmpfsMounts() []Mount {
 	var mounts []Mount
 	for dest, data := range container.HostConfig.Tmpfs {
@@ -415,6 +435,21 @@ func (container *Container) TmpfsMounts() []Mount {
 			Data:        data,
 		})
 	}
+	seen := make(map[string]bool)
+	for _, dest := range container.HostConfig.RwPaths {
+		dest = filepath.Clean(dest)
+		if _, exists := container.HostConfig.Tmpfs[dest]; exists || seen[dest] {
+			continue
+		}
+		if _, exists := container.MountPoints[dest]; exists {
+			continue
+		}
+		seen[dest] = true
+		mounts = append(mounts, Mount{
+			Source:      "tmpfs",
+			Destination: dest,
+		})
+	}
 	return mounts
 }
 
@@ -0,0 +1,106 @@
+// +build linux freebsd
+
+package container
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/volume"
+)
+
+func TestTmpfsMountsWithRwPaths(t *testing.T) {
+	c := &Container{
+		CommonContainer: CommonContainer{
+			HostConfig: &containertypes.HostConfig{
+				ReadonlyRootfs: true,
+				Tmpfs:          map[string]string{"/run": "size=64k"},
+				RwPaths:        []string{"/tmp", "/tmp/", "/run", "/data"},
+			},
+			MountPoints: map[string]*volume.MountPoint{
+				"/data": {Destination: "/data", RW: true},
+			},
+		},
+	}
+
+	mounts := c.TmpfsMounts()
+	if len(mounts) != 2 {
+		t.Fatalf("expected 2 tmpfs mounts, got %v", mounts)
+	}
+	byDest := make(map[string]Mount)
+	for _, m := range mounts {
+		byDest[m.Destination] = m
+	}
+	if m, ok := byDest["/run"]; !ok || m.Data != "size=64k" {
+		t.Fatalf("expected the --tmpfs mount of /run to be kept, got %v", mounts)
+	}
+	if m, ok := byDest["/tmp"]; !ok || m.Source != "tmpfs" || m.Data != "" {
+		t.Fatalf("expected a tmpfs mount for the writable path /tmp, got %v", mounts)
+	}
+}
+
+func TestCopyExistingContentsSkipped(t *testing.T) {
+	source, err := ioutil.TempDir("", "copy-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+	destination, err := ioutil.TempDir("", "copy-destination")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(destination)
+
+	// nothing to copy from an empty source
+	if copied, err := copyExistingContents(source, destination); err != nil || copied {
+		t.Fatalf("expected nothing to be copied from an empty source, got %v, %v", copied, err)
+	}
+
+	// a volume that is not empty is not populated
+	if err := ioutil.WriteFile(filepath.Join(source, "image"), []byte("image"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(destination, "volume"), []byte("volume"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if copied, err := copyExistingContents(source, destination); err != nil || copied {
+		t.Fatalf("expected nothing to be copied into a volume that is not empty, got %v, %v", copied, err)
+	}
+	if _, err := os.Stat(filepath.Join(destination, "image")); !os.IsNotExist(err) {
+		t.Fatalf("expected the image data not to be copied, got %v", err)
+	}
+}
+
+func TestUpdateContainerNanoCPUs(t *testing.T) {
+	root, err := ioutil.TempDir("", "docker-container-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	c := NewBaseContainer("cpus", root)
+	c.Config = &containertypes.Config{}
+	c.HostConfig = &containertypes.HostConfig{}
+
+	update := &containertypes.HostConfig{Resources: containertypes.Resources{NanoCPUs: 1500000000}}
+	if err := c.UpdateContainer(update); err != nil {
+		t.Fatal(err)
+	}
+	if c.HostConfig.NanoCPUs != 1500000000 {
+		t.Fatalf("Expected NanoCPUs to be updated, got %d", c.HostConfig.NanoCPUs)
+	}
+
+	update = &containertypes.HostConfig{Resources: containertypes.Resources{CPUQuota: 50000}}
+	if err := c.UpdateContainer(update); err == nil {
+		t.Fatal("Expected the CPU quota of a container limited with NanoCPUs not to be updated")
+	}
+
+	c.HostConfig.Resources = containertypes.Resources{CPUPeriod: 100000}
+	update = &containertypes.HostConfig{Resources: containertypes.Resources{NanoCPUs: 1500000000}}
+	if err := c.UpdateContainer(update); err == nil {
+		t.Fatal("Expected the NanoCPUs of a container limited with a CPU period not to be updated")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/go-units"
 )
 
@@ -22,6 +23,7 @@ type State struct {
 	Restarting        bool
 	OOMKilled         bool
 	RemovalInProgress bool // Not need for this to be persistent on disk.
+	RestoreInProgress bool `json:"-"`
 	Dead              bool
 	Pid               int
 	ExitCodeValue     int    `json:"ExitCode"`
@@ -30,6 +32,9 @@ type State struct {
 	FinishedAt        time.Time
 	waitChan          chan struct{}
 	Health            *Health
+	// ResourceAccounting is the resource usage of the last run of the
+	// container, recorded when it exited.
+	ResourceAccounting *types.ContainerResourceAccounting `json:",omitempty"`
 }
 
 // StateStatus is used to return an error type implementing both
@@ -237,6 +242,7 @@ func (s *State) SetRunning(pid int, initial bool) {
 	s.Running = true
 	s.Restarting = false
 	s.ExitCodeValue = 0
+	s.OOMKilled = false
 	s.Pid = pid
 	if initial {
 		s.StartedAt = time.Now().UTC()
@@ -311,6 +317,13 @@ func (s *State) ResetRemovalInProgress() {
 	s.Unlock()
 }
 
+// ResetRestoreInProgress makes the RestoreInProgress state to false.
+func (s *State) ResetRestoreInProgress() {
+	s.Lock()
+	s.RestoreInProgress = false
+	s.Unlock()
+}
+
 // SetDead sets the container state to "dead"
 func (s *State) SetDead() {
 	s.Lock()
@@ -0,0 +1,23 @@
+// +build linux freebsd
+
+package container
+
+import "testing"
+
+func TestStateOOMKilledReset(t *testing.T) {
+	s := NewState()
+	s.Lock()
+	s.SetRunning(100, true)
+	s.SetStopped(&ExitStatus{ExitCode: 137, OOMKilled: true})
+	s.Unlock()
+	if !s.OOMKilled {
+		t.Fatal("Expected OOMKilled to be set after an OOM exit")
+	}
+
+	s.Lock()
+	s.SetRunning(101, true)
+	s.Unlock()
+	if s.OOMKilled {
+		t.Fatal("Expected OOMKilled to be reset when the container starts again")
+	}
+}
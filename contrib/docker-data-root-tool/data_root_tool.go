@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/daemon/dataroot"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <flags> move new-data-root\n", os.Args[0])
+	flag.PrintDefaults()
+	os.Exit(1)
+}
+
+func main() {
+	root := flag.String("r", "/var/lib/docker", "Docker root dir")
+	pidfile := flag.String("p", "/var/run/docker.pid", "Path to the PID file of the daemon")
+	link := flag.Bool("link", false, "Replace the root dir by a link to the new one")
+	keep := flag.Bool("keep", false, "Keep the root dir once it is copied")
+
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 2 || args[0] != "move" {
+		usage()
+	}
+
+	opts := dataroot.Options{
+		Pidfile:    *pidfile,
+		Link:       *link,
+		KeepSource: *keep,
+		Out:        os.Stdout,
+	}
+	if err := dataroot.Move(*root, args[1], opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Can't move the data root: %v\n", err)
+		os.Exit(1)
+	}
+	if !*link {
+		fmt.Printf("Start the daemon with --graph=%s, or set \"graph\" in its configuration file\n", args[1])
+	}
+}
@@ -0,0 +1,50 @@
+#!/usr/bin/env bash
+set -e
+
+# dockerd-rootless.sh runs the daemon as an unprivileged user, in rootless
+# mode. It starts dockerd in the namespaces created by RootlessKit: a user
+# namespace mapping the user to root, a mount namespace, and a network
+# namespace connected to the host by slirp4netns, a usermode network stack.
+#
+# Requirements:
+#  - rootlesskit and slirp4netns in $PATH
+#  - subordinate ids for the user in /etc/subuid and /etc/subgid
+#  - $XDG_RUNTIME_DIR set to a directory owned by the user
+#
+# The arguments are passed to dockerd. Usage:
+#
+#    $ dockerd-rootless.sh --experimental
+#    $ export DOCKER_HOST=unix://$XDG_RUNTIME_DIR/docker.sock
+#    $ docker run -d -p 8080:80 nginx
+
+if [ "$(id -u)" = 0 ]; then
+	echo >&2 "dockerd-rootless.sh must be run as an unprivileged user"
+	exit 1
+fi
+if [ -z "$XDG_RUNTIME_DIR" ]; then
+	echo >&2 "XDG_RUNTIME_DIR needs to be set"
+	exit 1
+fi
+if [ -z "$HOME" ]; then
+	echo >&2 "HOME needs to be set"
+	exit 1
+fi
+for binary in rootlesskit slirp4netns; do
+	if ! command -v "$binary" &> /dev/null; then
+		echo >&2 "$binary needs to be installed"
+		exit 1
+	fi
+done
+
+: "${DOCKERD_ROOTLESS_MTU:=1500}"
+
+# The state of RootlessKit, including its API socket which forwards the
+# published ports from the host, is in $XDG_RUNTIME_DIR.
+exec rootlesskit \
+	--state-dir="$XDG_RUNTIME_DIR/dockerd-rootlesskit" \
+	--net=slirp4netns --mtu="$DOCKERD_ROOTLESS_MTU" \
+	--disable-host-loopback \
+	--port-driver=builtin \
+	--copy-up=/etc --copy-up=/run \
+	--propagation=rslave \
+	dockerd --rootless "$@"
@@ -3,9 +3,12 @@
 package daemon
 
 import (
+	"fmt"
+
 	"github.com/Sirupsen/logrus"
 	aaprofile "github.com/docker/docker/profiles/apparmor"
 	"github.com/opencontainers/runc/libcontainer/apparmor"
+	rsystem "github.com/opencontainers/runc/libcontainer/system"
 )
 
 // Define constants for native driver
@@ -14,7 +17,9 @@ const (
 )
 
 func installDefaultAppArmorProfile() {
-	if apparmor.IsEnabled() {
+	// The profiles cannot be loaded from a user namespace, as in rootless
+	// mode.
+	if apparmor.IsEnabled() && !rsystem.RunningInUserNS() {
 		if err := aaprofile.InstallDefault(defaultApparmorProfile); err != nil {
 			apparmorProfiles := []string{defaultApparmorProfile}
 
@@ -28,3 +33,16 @@ func installDefaultAppArmorProfile() {
 		}
 	}
 }
+
+// verifyDefaultAppArmorProfile checks that the default AppArmor profile of
+// the containers set in the configuration is loaded.
+func verifyDefaultAppArmorProfile(config *Config) error {
+	profile := config.DefaultAppArmorProfile
+	if profile == "" || profile == "unconfined" || profile == defaultApparmorProfile || !apparmor.IsEnabled() {
+		return nil
+	}
+	if err := aaprofile.IsLoaded(profile); err != nil {
+		return fmt.Errorf("the default AppArmor profile %s is not loaded", profile)
+	}
+	return nil
+}
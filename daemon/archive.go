@@ -72,7 +72,109 @@ func (daemon *Daemon) ContainerExtractToDir(name, path string, noOverwriteDirNon
 		return err
 	}
 
-	return daemon.containerExtractToDir(container, path, noOverwriteDirNonDir, content)
+	return daemon.containerExtractToDir(container, path, noOverwriteDirNonDir, false, content)
+}
+
+// ContainerCopyBetween copies the filesystem resource at srcPath in the
+// container identified by srcName to dstPath in the container identified by
+// dstName, following the same rules as copying from and to the host. If
+// followLink is true and srcPath is a symbolic link, the target of the link
+// is copied. The ownership of the copied files is preserved.
+func (daemon *Daemon) ContainerCopyBetween(srcName, srcPath, dstName, dstPath string, followLink, noOverwriteDirNonDir bool) error {
+	src, err := daemon.GetContainer(srcName)
+	if err != nil {
+		return err
+	}
+	dst, err := daemon.GetContainer(dstName)
+	if err != nil {
+		return err
+	}
+
+	var rebaseName string
+	if followLink {
+		srcStat, err := daemon.containerStatPath(src, srcPath)
+		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
+			linkTarget := srcStat.LinkTarget
+			if !system.IsAbs(linkTarget) {
+				// Join with the parent directory.
+				srcParent, _ := archive.SplitPathDirEntry(srcPath)
+				linkTarget = filepath.Join(srcParent, linkTarget)
+			}
+
+			linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
+			srcPath = linkTarget
+		}
+	}
+
+	// The source archive is spooled to a temporary file, so that the source
+	// container is unlocked before the destination is locked. Holding both
+	// locks could otherwise deadlock with a copy in the other direction.
+	spool, err := daemon.tempFile("docker-cp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	content, srcStat, err := daemon.containerArchivePath(src, srcPath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(spool, content)
+	content.Close()
+	if err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, 0); err != nil {
+		return err
+	}
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      srcStat.Mode.IsDir(),
+		RebaseName: rebaseName,
+	}
+
+	var srcArchive io.Reader = spool
+	if len(srcInfo.RebaseName) != 0 {
+		_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
+		rebased := archive.RebaseArchiveEntries(spool, srcBase, srcInfo.RebaseName)
+		defer rebased.Close()
+		srcArchive = rebased
+	}
+
+	// Prepare destination copy info by stat-ing the container path, and
+	// following the last element if it is a symbolic link.
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	dstStat, err := daemon.containerStatPath(dst, dstPath)
+	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+
+		dstInfo.Path = linkTarget
+		dstStat, err = daemon.containerStatPath(dst, linkTarget)
+	}
+
+	// Ignore any error and assume that the parent directory of the destination
+	// path exists, in which case the copy may still succeed.
+	if err == nil {
+		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	}
+
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(srcArchive, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer preparedArchive.Close()
+
+	return daemon.containerExtractToDir(dst, dstDir, noOverwriteDirNonDir, true, preparedArchive)
 }
 
 // containerStatPath stats the filesystem resource at the specified path in this
@@ -173,8 +275,9 @@ func (daemon *Daemon) containerArchivePath(container *container.Container, path
 // container. If it is not, the error will be ErrExtractPointNotDirectory. If
 // noOverwriteDirNonDir is true then it will be an error if unpacking the
 // given content would cause an existing directory to be replaced with a non-
-// directory and vice versa.
-func (daemon *Daemon) containerExtractToDir(container *container.Container, path string, noOverwriteDirNonDir bool, content io.Reader) (err error) {
+// directory and vice versa. If preserveOwnership is false, the extracted
+// files are owned by the (remapped) root user.
+func (daemon *Daemon) containerExtractToDir(container *container.Container, path string, noOverwriteDirNonDir, preserveOwnership bool, content io.Reader) (err error) {
 	container.Lock()
 	defer container.Unlock()
 
@@ -257,12 +360,16 @@ func (daemon *Daemon) containerExtractToDir(container *container.Container, path
 		return ErrRootFSReadOnly
 	}
 
-	uid, gid := daemon.GetRemappedUIDGID()
 	options := &archive.TarOptions{
 		NoOverwriteDirNonDir: noOverwriteDirNonDir,
-		ChownOpts: &archive.TarChownOptions{
+	}
+	// The archives of container paths hold the IDs as stored on disk, which
+	// already account for the user namespace remapping of the daemon.
+	if !preserveOwnership {
+		uid, gid := daemon.GetRemappedUIDGID()
+		options.ChownOpts = &archive.TarChownOptions{
 			UID: uid, GID: gid, // TODO: should all ownership be set to root (either real or remapped)?
-		},
+		}
 	}
 	if err := chrootarchive.Untar(content, resolvedPath, options); err != nil {
 		return err
@@ -434,3 +541,47 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 
 	return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
 }
+
+// RemoveMountPointsOnBuild removes the empty files and directories created in
+// the RW layer of the container cID as the mount points of paths, and their
+// parent directories created with them, so that they are not committed. The
+// files and the directories of the image are kept.
+func (daemon *Daemon) RemoveMountPointsOnBuild(cID string, paths []string) error {
+	c, err := daemon.GetContainer(cID)
+	if err != nil {
+		return err
+	}
+	if err := daemon.Mount(c); err != nil {
+		return err
+	}
+	defer daemon.Unmount(c)
+
+	changes, err := c.RWLayer.Changes()
+	if err != nil {
+		return err
+	}
+	added := make(map[string]bool)
+	for _, change := range changes {
+		if change.Kind == archive.ChangeAdd {
+			added[change.Path] = true
+		}
+	}
+
+	for _, p := range paths {
+		for p = filepath.Clean(filepath.FromSlash(p)); added[p]; p = filepath.Dir(p) {
+			fullPath, err := c.GetResourcePath(p)
+			if err != nil {
+				return err
+			}
+			fi, err := os.Lstat(fullPath)
+			if err != nil || (!fi.IsDir() && fi.Size() != 0) {
+				break
+			}
+			// The directories holding other files are kept.
+			if err := os.Remove(fullPath); err != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
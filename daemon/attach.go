@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/docker/pkg/term"
+	"github.com/docker/docker/runconfig"
 )
 
 // ContainerAttach attaches to logs according to the config passed in. See ContainerAttachConfig.
@@ -58,7 +59,14 @@ func (daemon *Daemon) ContainerAttach(prefixOrName string, c *backend.ContainerA
 		stderr = errStream
 	}
 
-	if err := daemon.containerAttach(container, stdin, stdout, stderr, c.Logs, c.Stream, keys); err != nil {
+	scrollback := runconfig.ScrollbackNone
+	if c.SinceDetach {
+		scrollback = runconfig.ScrollbackSinceDetach
+	} else if c.Scrollback {
+		scrollback = runconfig.ScrollbackAll
+	}
+
+	if err := daemon.containerAttach(container, stdin, stdout, stderr, c.Logs, c.Stream, keys, scrollback); err != nil {
 		fmt.Fprintf(outStream, "Error attaching: %s\n", err)
 	}
 	return nil
@@ -70,10 +78,10 @@ func (daemon *Daemon) ContainerAttachRaw(prefixOrName string, stdin io.ReadClose
 	if err != nil {
 		return err
 	}
-	return daemon.containerAttach(container, stdin, stdout, stderr, false, stream, nil)
+	return daemon.containerAttach(container, stdin, stdout, stderr, false, stream, nil, runconfig.ScrollbackNone)
 }
 
-func (daemon *Daemon) containerAttach(c *container.Container, stdin io.ReadCloser, stdout, stderr io.Writer, logs, stream bool, keys []byte) error {
+func (daemon *Daemon) containerAttach(c *container.Container, stdin io.ReadCloser, stdout, stderr io.Writer, logs, stream bool, keys []byte, scrollback runconfig.Scrollback) error {
 	if logs {
 		logDriver, err := daemon.getLogger(c)
 		if err != nil {
@@ -128,9 +136,10 @@ func (daemon *Daemon) containerAttach(c *container.Container, stdin io.ReadClose
 			}()
 		}
 
-		err := <-c.Attach(stdinPipe, stdout, stderr, keys)
+		err := <-c.Attach(stdinPipe, stdout, stderr, keys, scrollback)
 		if err != nil {
 			if _, ok := err.(container.DetachError); ok {
+				c.StreamConfig.MarkDetached()
 				daemon.LogContainerEvent(c, "detach")
 			} else {
 				logrus.Errorf("attach failed with error: %v", err)
@@ -0,0 +1,186 @@
+package daemon
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+const (
+	// autohealLabel enables or disables the autoheal of a container,
+	// overriding the autoheal policy of the daemon.
+	autohealLabel = "com.docker.autoheal"
+
+	// Default number of consecutive probes reporting a container unhealthy
+	// before it is autohealed.
+	defaultAutohealThreshold = 1
+
+	// Delay before the second autoheal of a container which did not become
+	// healthy. It doubles with each following autoheal, up to
+	// maxAutohealBackoff. The first autoheal is not delayed.
+	autohealBackoff    = 10 * time.Second
+	maxAutohealBackoff = 5 * time.Minute
+
+	// Maximum number of consecutive autoheals of a container which does not
+	// become healthy. The container is then left unhealthy, so that it is not
+	// restarted in a loop.
+	maxAutohealAttempts = 5
+)
+
+// autohealState is the state of the autoheals of a container since it was
+// last healthy.
+type autohealState struct {
+	attempts int
+	pending  bool // an autoheal is scheduled or running
+	gaveUp   bool
+}
+
+// autohealer tracks the autoheals of the containers by ID.
+type autohealer struct {
+	sync.Mutex
+	containers map[string]*autohealState
+}
+
+// schedule records a new autoheal of the container. It returns the attempt
+// of the autoheal and the delay before it, or 0 if the container must not be
+// autohealed: an autoheal is already pending, or the container was
+// autohealed too many times. giveUp is set the first time the container was
+// autohealed too many times.
+func (a *autohealer) schedule(id string) (attempt int, delay time.Duration, giveUp bool) {
+	a.Lock()
+	defer a.Unlock()
+	if a.containers == nil {
+		a.containers = make(map[string]*autohealState)
+	}
+	s, ok := a.containers[id]
+	if !ok {
+		s = &autohealState{}
+		a.containers[id] = s
+	}
+	if s.pending {
+		return 0, 0, false
+	}
+	if s.attempts >= maxAutohealAttempts {
+		giveUp = !s.gaveUp
+		s.gaveUp = true
+		return 0, 0, giveUp
+	}
+	s.attempts++
+	s.pending = true
+	return s.attempts, autohealDelay(s.attempts), false
+}
+
+// done records the end of the pending autoheal of the container.
+func (a *autohealer) done(id string) {
+	a.Lock()
+	if s, ok := a.containers[id]; ok {
+		s.pending = false
+	}
+	a.Unlock()
+}
+
+// reset forgets the autoheals of the container, once it is healthy again or
+// removed.
+func (a *autohealer) reset(id string) {
+	a.Lock()
+	delete(a.containers, id)
+	a.Unlock()
+}
+
+// autohealDelay returns the delay before the given autoheal attempt.
+func autohealDelay(attempt int) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+	delay := autohealBackoff
+	for i := 2; i < attempt && delay < maxAutohealBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxAutohealBackoff {
+		delay = maxAutohealBackoff
+	}
+	return delay
+}
+
+// autohealEnabled returns whether the container is autohealed, according to
+// its autoheal label or else to the autoheal policy of the daemon.
+func (d *Daemon) autohealEnabled(c *container.Container) bool {
+	if v, ok := c.Config.Labels[autohealLabel]; ok {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			return enabled
+		}
+		logrus.Warnf("Invalid %s label %q for container %s", autohealLabel, v, c.ID)
+	}
+	return d.configStore != nil && d.configStore.Autoheal
+}
+
+// autohealThreshold returns the number of consecutive probes reporting a
+// container unhealthy before it is autohealed.
+func (d *Daemon) autohealThreshold() int {
+	if d.configStore == nil || d.configStore.AutohealThreshold <= 0 {
+		return defaultAutohealThreshold
+	}
+	return d.configStore.AutohealThreshold
+}
+
+// handleAutoheal schedules the autoheal of an unhealthy container once it
+// was reported unhealthy by enough consecutive probes, and forgets the
+// autoheals of a container which is healthy again.
+// Called from handleProbeResult, with c locked.
+func (d *Daemon) handleAutoheal(c *container.Container, retries int) {
+	h := c.State.Health
+	switch h.Status {
+	case types.Healthy:
+		d.autoheal.reset(c.ID)
+		return
+	case types.Unhealthy:
+	default:
+		return
+	}
+	if !d.autohealEnabled(c) || h.FailingStreak-retries+1 < d.autohealThreshold() {
+		return
+	}
+
+	attempt, delay, giveUp := d.autoheal.schedule(c.ID)
+	if giveUp {
+		logrus.Warnf("Container %s is still unhealthy after %d autoheals, giving up", c.ID, maxAutohealAttempts)
+		d.LogContainerEventWithAttributes(c, "autoheal_give_up", map[string]string{
+			"attempts": strconv.Itoa(maxAutohealAttempts),
+		})
+	}
+	if attempt == 0 {
+		return
+	}
+	go func() {
+		defer d.autoheal.done(c.ID)
+		time.Sleep(delay)
+		d.autohealContainer(c, attempt)
+	}()
+}
+
+// autohealContainer restarts the container if it is still running and
+// unhealthy.
+func (d *Daemon) autohealContainer(c *container.Container, attempt int) {
+	if d.IsShuttingDown() {
+		return
+	}
+	c.Lock()
+	unhealthy := c.Running && !c.Paused && !c.RemovalInProgress && !c.Dead &&
+		c.State.Health != nil && c.State.Health.Status == types.Unhealthy
+	c.Unlock()
+	if !unhealthy {
+		return
+	}
+
+	logrus.Infof("Restarting unhealthy container %s (autoheal attempt %d)", c.ID, attempt)
+	d.LogContainerEventWithAttributes(c, "autoheal", map[string]string{
+		"attempt": strconv.Itoa(attempt),
+	})
+	if err := d.containerRestart(c, c.StopTimeout()); err != nil {
+		logrus.Errorf("Failed to autoheal container %s: %v", c.ID, err)
+	}
+}
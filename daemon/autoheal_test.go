@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+)
+
+func TestAutohealDelay(t *testing.T) {
+	expected := []time.Duration{0, 10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second, 160 * time.Second, 5 * time.Minute, 5 * time.Minute}
+	for i, delay := range expected {
+		if d := autohealDelay(i + 1); d != delay {
+			t.Fatalf("attempt %d: expected a delay of %v, got %v", i+1, delay, d)
+		}
+	}
+}
+
+func TestAutohealerSchedule(t *testing.T) {
+	var a autohealer
+
+	for i := 1; i <= maxAutohealAttempts; i++ {
+		attempt, delay, giveUp := a.schedule("c1")
+		if attempt != i || delay != autohealDelay(i) || giveUp {
+			t.Fatalf("expected autoheal attempt %d, got %d (delay %v, give up %v)", i, attempt, delay, giveUp)
+		}
+		if attempt, _, _ := a.schedule("c1"); attempt != 0 {
+			t.Fatalf("expected no autoheal while one is pending, got attempt %d", attempt)
+		}
+		a.done("c1")
+	}
+
+	if attempt, _, giveUp := a.schedule("c1"); attempt != 0 || !giveUp {
+		t.Fatalf("expected to give up after %d attempts, got attempt %d (give up %v)", maxAutohealAttempts, attempt, giveUp)
+	}
+	if attempt, _, giveUp := a.schedule("c1"); attempt != 0 || giveUp {
+		t.Fatalf("expected to give up once, got attempt %d (give up %v)", attempt, giveUp)
+	}
+
+	a.reset("c1")
+	if attempt, delay, _ := a.schedule("c1"); attempt != 1 || delay != 0 {
+		t.Fatalf("expected a first autoheal once reset, got attempt %d (delay %v)", attempt, delay)
+	}
+}
+
+func TestAutohealEnabled(t *testing.T) {
+	d := &Daemon{configStore: &Config{}}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			Config: &containertypes.Config{Labels: map[string]string{}},
+		},
+	}
+
+	if d.autohealEnabled(c) {
+		t.Fatal("expected autoheal to be disabled by default")
+	}
+	c.Config.Labels[autohealLabel] = "true"
+	if !d.autohealEnabled(c) {
+		t.Fatal("expected the label to enable autoheal")
+	}
+
+	d.configStore.Autoheal = true
+	c.Config.Labels[autohealLabel] = "false"
+	if d.autohealEnabled(c) {
+		t.Fatal("expected the label to disable autoheal")
+	}
+	c.Config.Labels[autohealLabel] = "invalid"
+	if !d.autohealEnabled(c) {
+		t.Fatal("expected the daemon policy for an invalid label")
+	}
+}
+
+func TestHandleAutohealThreshold(t *testing.T) {
+	d := &Daemon{configStore: &Config{CommonConfig: CommonConfig{AutohealThreshold: 2}}}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:     "container_id",
+			Config: &containertypes.Config{Labels: map[string]string{autohealLabel: "false"}},
+		},
+	}
+	c.State = container.NewState()
+	c.State.Health = &container.Health{}
+	c.State.Health.Status = types.Unhealthy
+	c.State.Health.FailingStreak = 3
+
+	// A disabled autoheal does not schedule anything.
+	d.handleAutoheal(c, 3)
+	if _, ok := d.autoheal.containers[c.ID]; ok {
+		t.Fatal("expected no autoheal when it is disabled")
+	}
+
+	// Below the threshold, nothing is scheduled either.
+	c.Config.Labels[autohealLabel] = "true"
+	d.handleAutoheal(c, 3)
+	if _, ok := d.autoheal.containers[c.ID]; ok {
+		t.Fatal("expected no autoheal below the threshold")
+	}
+
+	// A healthy container forgets its autoheals.
+	d.autoheal.schedule(c.ID)
+	c.State.Health.Status = types.Healthy
+	d.handleAutoheal(c, 3)
+	if _, ok := d.autoheal.containers[c.ID]; ok {
+		t.Fatal("expected the autoheals to be reset once healthy")
+	}
+}
@@ -0,0 +1,403 @@
+package daemon
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+// snapshotsDir is the directory of the container root holding the manifests
+// of the snapshots of the RW layer of the container taken by its backups. It
+// is removed with the container.
+const snapshotsDir = "snapshots"
+
+// maxSnapshots is the number of the most recent snapshots of a container
+// whose manifests are kept, to back up the changes since them.
+const maxSnapshots = 10
+
+// deletedEntry stands for the digest of a file of the image deleted in the RW
+// layer of a container.
+const deletedEntry = "deleted"
+
+// snapshotEntry is a change of the RW layer of a container in a snapshot.
+type snapshotEntry struct {
+	// Metadata is the digest of the metadata of the file, such as its size
+	// and modification time, so that the content of the files whose
+	// metadata did not change since the snapshot is not read again.
+	Metadata string `json:",omitempty"`
+	// Digest is the digest of the metadata and of the content of the file,
+	// or deletedEntry.
+	Digest string
+}
+
+// snapshotManifest maps the path of each change of the RW layer of a
+// container to its entry. A snapshot is identified by the digest of its
+// manifest.
+type snapshotManifest map[string]snapshotEntry
+
+type byChangePath []archive.Change
+
+func (c byChangePath) Len() int           { return len(c) }
+func (c byChangePath) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byChangePath) Less(i, j int) bool { return c[i].Path < c[j].Path }
+
+type byModTime []os.FileInfo
+
+func (f byModTime) Len() int           { return len(f) }
+func (f byModTime) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f byModTime) Less(i, j int) bool { return f[i].ModTime().Before(f[j].ModTime()) }
+
+// snapshotPath returns the path of the manifest of a snapshot of the RW layer
+// of a container.
+func snapshotPath(c *container.Container, snapshot digest.Digest) (string, error) {
+	return c.GetRootResourcePath(filepath.Join(snapshotsDir, snapshot.Hex()))
+}
+
+// loadSnapshot returns the manifest of a snapshot of the RW layer of a
+// container.
+func loadSnapshot(c *container.Container, snapshot string) (snapshotManifest, error) {
+	dgst, err := digest.ParseDigest(snapshot)
+	if err != nil {
+		return nil, errors.NewBadRequestError(fmt.Errorf("invalid snapshot %q: %v", snapshot, err))
+	}
+	p, err := snapshotPath(c, dgst)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewRequestNotFoundError(fmt.Errorf("No such snapshot for container %s: %s", c.ID, snapshot))
+		}
+		return nil, err
+	}
+	var manifest snapshotManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s of container %s: %v", snapshot, c.ID, err)
+	}
+	return manifest, nil
+}
+
+// saveSnapshot stores the manifest of a snapshot of the RW layer of a
+// container, and returns the digest identifying it.
+func saveSnapshot(c *container.Container, manifest snapshotManifest) (string, error) {
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	dgst := digest.FromBytes(b)
+	p, err := snapshotPath(c, dgst)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return "", err
+	}
+	if err := ioutils.AtomicWriteFile(p, b, 0600); err != nil {
+		return "", err
+	}
+	return dgst.String(), nil
+}
+
+// pruneSnapshots removes the manifests of the snapshots of a container but
+// the maxSnapshots most recently taken.
+func pruneSnapshots(c *container.Container) error {
+	dir, err := c.GetRootResourcePath(snapshotsDir)
+	if err != nil {
+		return err
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(fis) <= maxSnapshots {
+		return nil
+	}
+	sort.Sort(byModTime(fis))
+	for _, fi := range fis[:len(fis)-maxSnapshots] {
+		if err := os.Remove(filepath.Join(dir, fi.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileDigest returns the entry of a file, with the digest of its metadata and
+// content, which changes whenever the file would be archived differently. The
+// entry prev of the file in an earlier snapshot is returned if the metadata
+// of the file did not change since, without reading its content again.
+func fileDigest(path string, prev snapshotEntry) (snapshotEntry, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return snapshotEntry{}, err
+	}
+	var link string
+	if fi.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(path); err != nil {
+			return snapshotEntry{}, err
+		}
+	}
+	hdr, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return snapshotEntry{}, err
+	}
+	metadata := fmt.Sprintf("%o %d %d %d %d %d %d %q\n", hdr.Mode, hdr.Uid, hdr.Gid, hdr.Size, hdr.ModTime.UnixNano(), hdr.Devmajor, hdr.Devminor, hdr.Linkname)
+	m := sha256.Sum256([]byte(metadata))
+	entry := snapshotEntry{Metadata: hex.EncodeToString(m[:])}
+	if prev.Metadata == entry.Metadata && prev.Digest != deletedEntry {
+		return prev, nil
+	}
+
+	h := sha256.New()
+	io.WriteString(h, metadata)
+	if fi.Mode().IsRegular() {
+		f, err := os.Open(path)
+		if err != nil {
+			return snapshotEntry{}, err
+		}
+		defer f.Close()
+		if _, err := io.Copy(h, f); err != nil {
+			return snapshotEntry{}, err
+		}
+	}
+	entry.Digest = hex.EncodeToString(h.Sum(nil))
+	return entry, nil
+}
+
+// snapshotRWLayer returns the manifest of the RW layer of a mounted container.
+// The content of the files is only read if their metadata changed since the
+// snapshot from.
+func snapshotRWLayer(c *container.Container, from snapshotManifest) (snapshotManifest, error) {
+	c.Lock()
+	changes, err := c.RWLayer.Changes()
+	c.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	manifest := make(snapshotManifest, len(changes))
+	for _, change := range changes {
+		if change.Kind == archive.ChangeDelete {
+			manifest[change.Path] = snapshotEntry{Digest: deletedEntry}
+			continue
+		}
+		entry, err := fileDigest(filepath.Join(c.BaseFS, change.Path), from[change.Path])
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Deleted since the changes were listed.
+				continue
+			}
+			return nil, err
+		}
+		manifest[change.Path] = entry
+	}
+	return manifest, nil
+}
+
+// diffSnapshots returns the changes turning the files of a snapshot into
+// those of a later one. A path of the earlier snapshot missing from the later
+// one is back to the file of the image if exists reports it, and deleted
+// otherwise.
+func diffSnapshots(from, to snapshotManifest, exists func(path string) bool) []archive.Change {
+	var changes []archive.Change
+	deleted := make(map[string]bool)
+	for p, entry := range to {
+		if from[p].Digest == entry.Digest {
+			continue
+		}
+		if entry.Digest == deletedEntry {
+			deleted[p] = true
+		} else {
+			changes = append(changes, archive.Change{Path: p, Kind: archive.ChangeModify})
+		}
+	}
+	for p, entry := range from {
+		if _, ok := to[p]; ok {
+			continue
+		}
+		if exists(p) {
+			changes = append(changes, archive.Change{Path: p, Kind: archive.ChangeModify})
+		} else if entry.Digest != deletedEntry {
+			deleted[p] = true
+		}
+	}
+	// The files of a deleted directory are deleted with it.
+	for p := range deleted {
+		parentDeleted := false
+		for dir := filepath.Dir(p); dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+			if deleted[dir] {
+				parentDeleted = true
+				break
+			}
+		}
+		if !parentDeleted {
+			changes = append(changes, archive.Change{Path: p, Kind: archive.ChangeDelete})
+		}
+	}
+	sort.Sort(byChangePath(changes))
+	return changes
+}
+
+// ContainerBackup returns an archive of the changes of the RW layer of a
+// container since a snapshot taken by an earlier backup, or since the image
+// if since is empty, along with the snapshot of the RW layer it takes. The
+// files deleted are whiteouts in the archive, as in the layers of images, so
+// that the archives can be replayed in order with ContainerRestore. A running
+// container is only paused while the snapshot is taken, the changes being
+// archived to a staging file once it is resumed, and the archive is then read
+// from the staging file.
+func (daemon *Daemon) ContainerBackup(name, since string) (io.ReadCloser, string, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, "", err
+	}
+	if runtime.GOOS == "windows" {
+		return nil, "", fmt.Errorf("Windows does not support the backup of containers")
+	}
+
+	var from snapshotManifest
+	if since != "" {
+		if from, err = loadSnapshot(container, since); err != nil {
+			return nil, "", err
+		}
+	}
+
+	staging, err := daemon.tempFile("docker-backup-")
+	if err != nil {
+		return nil, "", err
+	}
+	snapshot, err := daemon.stageBackup(container, from, staging)
+	if err == nil {
+		_, err = staging.Seek(0, os.SEEK_SET)
+	}
+	if err != nil {
+		staging.Close()
+		os.Remove(staging.Name())
+		return nil, "", fmt.Errorf("Error backing up container %s: %v", name, err)
+	}
+	content := ioutils.NewReadCloserWrapper(staging, func() error {
+		err := staging.Close()
+		os.Remove(staging.Name())
+		return err
+	})
+	daemon.LogContainerEventWithAttributes(container, "backup", map[string]string{
+		"since":    since,
+		"snapshot": snapshot,
+	})
+	return content, snapshot, nil
+}
+
+// stageBackup takes a snapshot of the RW layer of a container and writes the
+// archive of its changes since the snapshot from to staging. A running
+// container is paused until the snapshot is taken. The files changed while
+// the archive is written may be archived with their later content; as they
+// differ from the snapshot, they are archived again by the next backup.
+func (daemon *Daemon) stageBackup(container *container.Container, from snapshotManifest, staging io.Writer) (string, error) {
+	if err := daemon.Mount(container); err != nil {
+		return "", err
+	}
+	defer daemon.Unmount(container)
+
+	to, err := daemon.snapshotContainer(container, from)
+	if err != nil {
+		return "", err
+	}
+	snapshot, err := saveSnapshot(container, to)
+	if err != nil {
+		return "", err
+	}
+	if err := pruneSnapshots(container); err != nil {
+		logrus.Warnf("Failed to prune the snapshots of container %s: %v", container.ID, err)
+	}
+
+	changes := diffSnapshots(from, to, func(p string) bool {
+		_, err := os.Lstat(filepath.Join(container.BaseFS, p))
+		return err == nil
+	})
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	data, err := archive.ExportChanges(container.BaseFS, changes, uidMaps, gidMaps)
+	if err != nil {
+		return "", err
+	}
+	defer data.Close()
+	if _, err := io.Copy(staging, data); err != nil {
+		return "", err
+	}
+	return snapshot, nil
+}
+
+// snapshotContainer returns the manifest of the RW layer of a mounted
+// container, which is paused while it is taken if it is running.
+func (daemon *Daemon) snapshotContainer(container *container.Container, from snapshotManifest) (snapshotManifest, error) {
+	if container.IsRunning() && !container.IsPaused() {
+		if err := daemon.containerPause(container); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := daemon.containerUnpause(container); err != nil {
+				logrus.Errorf("Failed to resume container %s: %v", container.ID, err)
+			}
+		}()
+	}
+	return snapshotRWLayer(container, from)
+}
+
+// ContainerRestore replays an archive of the changes of the RW layer of a
+// container, as returned by ContainerBackup, onto a container which is not
+// running. The container cannot be started or removed during the restore.
+func (daemon *Daemon) ContainerRestore(name string, content io.Reader) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("Windows does not support the restore of containers")
+	}
+
+	container.Lock()
+	switch {
+	case container.Running:
+		err = fmt.Errorf("Cannot restore container %s: it is running, stop it first", name)
+	case container.RemovalInProgress || container.Dead:
+		err = fmt.Errorf("Cannot restore container %s: it is marked for removal", name)
+	case container.RestoreInProgress:
+		err = fmt.Errorf("Cannot restore container %s: a restore is already in progress", name)
+	default:
+		container.RestoreInProgress = true
+	}
+	container.Unlock()
+	if err != nil {
+		return errors.NewRequestConflictError(err)
+	}
+	defer container.ResetRestoreInProgress()
+
+	if err := daemon.Mount(container); err != nil {
+		return err
+	}
+	defer daemon.Unmount(container)
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	if _, err := chrootarchive.ApplyUncompressedLayer(container.BaseFS, content, &archive.TarOptions{
+		UIDMaps: uidMaps,
+		GIDMaps: gidMaps,
+	}); err != nil {
+		return fmt.Errorf("Error restoring container %s: %v", name, err)
+	}
+	daemon.LogContainerEvent(container, "restore")
+	return nil
+}
@@ -0,0 +1,176 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/archive"
+)
+
+// testManifest returns the manifest of the files with the given digests.
+func testManifest(digests map[string]string) snapshotManifest {
+	manifest := make(snapshotManifest, len(digests))
+	for p, dgst := range digests {
+		manifest[p] = snapshotEntry{Digest: dgst}
+	}
+	return manifest
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	from := testManifest(map[string]string{
+		"/etc":          "d1",
+		"/etc/app.conf": "f1",
+		"/tmp/a":        "f2",
+		"/tmp/b":        "f3",
+		"/var/lib/db":   "d2",
+		"/var/lib/db/x": "f4",
+		"/usr/bin/tool": deletedEntry,
+	})
+	to := testManifest(map[string]string{
+		"/etc":          "d1",
+		"/etc/app.conf": "f5",
+		"/tmp/a":        "f2",
+		"/tmp/c":        "f6",
+		"/var/lib/db":   deletedEntry,
+		"/usr/bin/tool": deletedEntry,
+	})
+	// /tmp/b was added since the image and deleted, /var/lib/db/x is deleted
+	// with its directory.
+	exists := func(p string) bool { return false }
+
+	changes := diffSnapshots(from, to, exists)
+	expected := []archive.Change{
+		{Path: "/etc/app.conf", Kind: archive.ChangeModify},
+		{Path: "/tmp/b", Kind: archive.ChangeDelete},
+		{Path: "/tmp/c", Kind: archive.ChangeModify},
+		{Path: "/var/lib/db", Kind: archive.ChangeDelete},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+}
+
+func TestDiffSnapshotsRevertedToImage(t *testing.T) {
+	from := testManifest(map[string]string{"/etc/hosts.allow": "f1"})
+	to := snapshotManifest{}
+	exists := func(p string) bool { return p == "/etc/hosts.allow" }
+
+	changes := diffSnapshots(from, to, exists)
+	expected := []archive.Change{{Path: "/etc/hosts.allow", Kind: archive.ChangeModify}}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+
+	if changes := diffSnapshots(nil, to, exists); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
+
+func TestFileDigest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docker-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(p, []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d1, err := fileDigest(p, snapshotEntry{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d2, err := fileDigest(p, snapshotEntry{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d1 != d2 {
+		t.Fatalf("expected the digest of an unchanged file to be stable, got %s and %s", d1, d2)
+	}
+
+	fi, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(p, []byte("bar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Keep the modification time, so that only the content differs.
+	if err := os.Chtimes(p, fi.ModTime(), fi.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	d3, err := fileDigest(p, snapshotEntry{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d3.Digest == d1.Digest {
+		t.Fatal("expected the digest to change with the content of the file")
+	}
+
+	// The content of a file whose metadata did not change is not read.
+	d4, err := fileDigest(p, d1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d4 != d1 {
+		t.Fatalf("expected the entry of the unchanged metadata to be kept, got %v", d4)
+	}
+	if err := os.Chmod(p, 0600); err != nil {
+		t.Fatal(err)
+	}
+	d5, err := fileDigest(p, d1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d5.Metadata == d1.Metadata || d5.Digest == d1.Digest {
+		t.Fatal("expected the digest to change with the metadata of the file")
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docker-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := container.NewBaseContainer("backup", dir)
+	var snapshots []string
+	for i := 0; i < maxSnapshots+2; i++ {
+		snapshot, err := saveSnapshot(c, testManifest(map[string]string{"/file": fmt.Sprintf("f%d", i)}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Order the snapshots by modification time.
+		p, err := snapshotPath(c, digest.Digest(snapshot))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mtime := time.Now().Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := pruneSnapshots(c); err != nil {
+		t.Fatal(err)
+	}
+	for i, snapshot := range snapshots {
+		_, err := loadSnapshot(c, snapshot)
+		if i < 2 && err == nil {
+			t.Fatalf("expected snapshot %d to be pruned", i)
+		}
+		if i >= 2 && err != nil {
+			t.Fatalf("expected snapshot %d to be kept, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/utils"
+	"github.com/docker/go-connections/nat"
+)
+
+// ContainerClone creates a new container named newName from the
+// configuration of an existing container, applying the overrides given in
+// config. The source container is left untouched.
+func (daemon *Daemon) ContainerClone(name, newName string, config *types.ContainerCloneConfig) (types.ContainerCreateResponse, error) {
+	src, err := daemon.GetContainer(name)
+	if err != nil {
+		return types.ContainerCreateResponse{}, err
+	}
+
+	src.Lock()
+	cfg, hostConfig, nwConfig, err := cloneContainerConfig(src, config)
+	src.Unlock()
+	if err != nil {
+		return types.ContainerCreateResponse{}, err
+	}
+
+	ccr, err := daemon.ContainerCreate(types.ContainerCreateConfig{
+		Name:             newName,
+		Config:           cfg,
+		HostConfig:       hostConfig,
+		NetworkingConfig: nwConfig,
+		// The OOM killer of the source container was disabled without a
+		// memory limit, which was forced when it was created.
+		ForceOomKillDisable: hostConfig.OomKillDisable != nil && *hostConfig.OomKillDisable && hostConfig.Memory == 0,
+	}, true)
+	if err != nil {
+		return ccr, err
+	}
+
+	if config.CopyVolumes {
+		if err := daemon.copyCloneVolumes(src, ccr.ID); err != nil {
+			return ccr, fmt.Errorf("Container %s was created but copying volumes from %s failed: %v", stringid.TruncateID(ccr.ID), strings.TrimPrefix(src.Name, "/"), err)
+		}
+	}
+	return ccr, nil
+}
+
+// copyCloneVolumes copies the content of the volumes of src that were
+// replaced by a new volume in the clone, that is the anonymous volumes.
+// Named volumes and volumes from other containers are shared by both
+// containers and are not copied.
+func (daemon *Daemon) copyCloneVolumes(src *container.Container, cloneID string) error {
+	clone, err := daemon.GetContainer(cloneID)
+	if err != nil {
+		return err
+	}
+
+	volumes := make(map[string]string)
+	src.Lock()
+	for dest, m := range src.MountPoints {
+		if m.Name != "" {
+			volumes[dest] = m.Name
+		}
+	}
+	src.Unlock()
+
+	var destinations []string
+	clone.Lock()
+	for dest, m := range clone.MountPoints {
+		if name, ok := volumes[dest]; ok && m.Name != "" && m.Name != name {
+			destinations = append(destinations, dest)
+		}
+	}
+	clone.Unlock()
+	sort.Strings(destinations)
+
+	for _, dest := range destinations {
+		// The trailing "." copies the content of the directory rather than
+		// the directory itself.
+		srcPath := dest + string(filepath.Separator) + "."
+		if err := daemon.ContainerCopyBetween(src.ID, srcPath, clone.ID, dest, false, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneContainerConfig returns copies of the configuration of c with the
+// overrides of config applied. The caller must hold the container lock.
+func cloneContainerConfig(c *container.Container, config *types.ContainerCloneConfig) (*containertypes.Config, *containertypes.HostConfig, *networktypes.NetworkingConfig, error) {
+	var (
+		cfg        containertypes.Config
+		hostConfig containertypes.HostConfig
+	)
+	if err := deepCopy(c.Config, &cfg); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := deepCopy(c.HostConfig, &hostConfig); err != nil {
+		return nil, nil, nil, err
+	}
+	// The clone is not a member of the pod of the source container.
+	for _, l := range reservedLabels {
+		delete(cfg.Labels, l)
+	}
+
+	// A hostname generated for the source container must be generated
+	// again for the clone.
+	if cfg.Hostname == stringid.TruncateID(c.ID) || cfg.Hostname == strings.TrimPrefix(c.Name, "/") {
+		cfg.Hostname = ""
+	}
+
+	if config.Image != "" {
+		image, err := cloneImageReference(cfg.Image, config.Image)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.Image = image
+	}
+	if len(config.Env) > 0 {
+		cfg.Env = utils.ReplaceOrAppendEnvValues(cfg.Env, config.Env)
+	}
+	if config.PortBindings != nil {
+		hostConfig.PortBindings = config.PortBindings
+		for port := range config.PortBindings {
+			if cfg.ExposedPorts == nil {
+				cfg.ExposedPorts = make(map[nat.Port]struct{})
+			}
+			cfg.ExposedPorts[port] = struct{}{}
+		}
+	} else {
+		hostConfig.PortBindings = ephemeralPortBindings(hostConfig.PortBindings)
+	}
+
+	mode := hostConfig.NetworkMode
+	if c.NetworkSettings == nil || mode.IsHost() || mode.IsNone() || mode.IsContainer() {
+		return &cfg, &hostConfig, nil, nil
+	}
+
+	shortID := stringid.TruncateID(c.ID)
+	nwConfig := &networktypes.NetworkingConfig{
+		EndpointsConfig: make(map[string]*networktypes.EndpointSettings),
+	}
+	for name, ep := range c.NetworkSettings.Networks {
+		epConfig := &networktypes.EndpointSettings{}
+		if ep != nil && ep.EndpointSettings != nil {
+			// Static addresses are not copied as they are already in
+			// use by the source container.
+			for _, alias := range ep.Aliases {
+				if alias != shortID {
+					epConfig.Aliases = append(epConfig.Aliases, alias)
+				}
+			}
+			epConfig.Links = append(epConfig.Links, ep.Links...)
+		}
+		nwConfig.EndpointsConfig[name] = epConfig
+	}
+	return &cfg, &hostConfig, nwConfig, nil
+}
+
+// ephemeralPortBindings returns the bindings with their fixed host ports
+// cleared, so that the ports are published on ephemeral host ports.
+func ephemeralPortBindings(bindings nat.PortMap) nat.PortMap {
+	if bindings == nil {
+		return nil
+	}
+	ephemeral := make(nat.PortMap, len(bindings))
+	for port, pbs := range bindings {
+		for _, pb := range pbs {
+			ephemeral[port] = append(ephemeral[port], nat.PortBinding{HostIP: pb.HostIP})
+		}
+	}
+	return ephemeral
+}
+
+// cloneImageReference returns the image to use for a clone of a container
+// created from image. A value of the form ":tag" replaces the tag of image.
+func cloneImageReference(image, override string) (string, error) {
+	if !strings.HasPrefix(override, ":") {
+		return override, nil
+	}
+	named, err := reference.ParseNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("cannot replace the tag of image %s: %v", image, err)
+	}
+	tagged, err := reference.WithTag(named, override[1:])
+	if err != nil {
+		return "", err
+	}
+	return tagged.String(), nil
+}
+
+// deepCopy copies src into dst by marshaling it to JSON.
+func deepCopy(src, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
@@ -0,0 +1,170 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func newCloneSource() *container.Container {
+	return &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:   "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			Name: "/web",
+			Config: &containertypes.Config{
+				Hostname: "0123456789ab",
+				Image:    "example.com/app:v1",
+				Env:      []string{"A=1", "B=2"},
+			},
+			HostConfig: &containertypes.HostConfig{
+				NetworkMode: "front",
+			},
+			NetworkSettings: &network.Settings{
+				Networks: map[string]*network.EndpointSettings{
+					"front": {
+						EndpointSettings: &networktypes.EndpointSettings{
+							Aliases: []string{"web", "0123456789ab"},
+							Links:   []string{"db:db"},
+							IPAMConfig: &networktypes.EndpointIPAMConfig{
+								IPv4Address: "10.0.0.2",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCloneContainerConfig(t *testing.T) {
+	src := newCloneSource()
+	cfg, hostConfig, nwConfig, err := cloneContainerConfig(src, &types.ContainerCloneConfig{
+		Image: ":v2",
+		Env:   []string{"B=3", "C=4"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Hostname != "" {
+		t.Fatalf("expected generated hostname to be reset, got %q", cfg.Hostname)
+	}
+	if cfg.Image != "example.com/app:v2" {
+		t.Fatalf("expected image example.com/app:v2, got %q", cfg.Image)
+	}
+	if expected := []string{"A=1", "B=3", "C=4"}; !reflect.DeepEqual(cfg.Env, expected) {
+		t.Fatalf("expected env %v, got %v", expected, cfg.Env)
+	}
+	if hostConfig.NetworkMode != "front" {
+		t.Fatalf("expected network mode front, got %q", hostConfig.NetworkMode)
+	}
+
+	ep, ok := nwConfig.EndpointsConfig["front"]
+	if !ok {
+		t.Fatalf("expected an endpoint on network front, got %v", nwConfig.EndpointsConfig)
+	}
+	if !reflect.DeepEqual(ep.Aliases, []string{"web"}) {
+		t.Fatalf("expected aliases [web], got %v", ep.Aliases)
+	}
+	if !reflect.DeepEqual(ep.Links, []string{"db:db"}) {
+		t.Fatalf("expected links [db:db], got %v", ep.Links)
+	}
+	if ep.IPAMConfig != nil {
+		t.Fatalf("expected static addresses not to be copied, got %v", ep.IPAMConfig)
+	}
+
+	// the source container must be left untouched
+	if src.Config.Image != "example.com/app:v1" || len(src.Config.Env) != 2 || src.Config.Hostname != "0123456789ab" {
+		t.Fatalf("source config was modified: %+v", src.Config)
+	}
+}
+
+func TestCloneContainerConfigHostNetwork(t *testing.T) {
+	src := newCloneSource()
+	src.Config.Hostname = "myhost"
+	src.HostConfig.NetworkMode = "host"
+
+	cfg, _, nwConfig, err := cloneContainerConfig(src, &types.ContainerCloneConfig{Image: "other:latest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Hostname != "myhost" {
+		t.Fatalf("expected explicit hostname to be kept, got %q", cfg.Hostname)
+	}
+	if cfg.Image != "other:latest" {
+		t.Fatalf("expected image other:latest, got %q", cfg.Image)
+	}
+	if nwConfig != nil {
+		t.Fatalf("expected no endpoints in host network mode, got %v", nwConfig.EndpointsConfig)
+	}
+}
+
+func TestCloneContainerConfigPortBindings(t *testing.T) {
+	src := newCloneSource()
+	src.HostConfig.PortBindings = nat.PortMap{
+		"80/tcp":  []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "8080"}},
+		"443/tcp": []nat.PortBinding{{HostPort: ""}},
+	}
+
+	_, hostConfig, _, err := cloneContainerConfig(src, &types.ContainerCloneConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := nat.PortMap{
+		"80/tcp":  []nat.PortBinding{{HostIP: "127.0.0.1"}},
+		"443/tcp": []nat.PortBinding{{}},
+	}
+	if !reflect.DeepEqual(hostConfig.PortBindings, expected) {
+		t.Fatalf("expected the fixed host ports to be cleared, got %v", hostConfig.PortBindings)
+	}
+	if src.HostConfig.PortBindings["80/tcp"][0].HostPort != "8080" {
+		t.Fatalf("source port bindings were modified: %v", src.HostConfig.PortBindings)
+	}
+
+	bindings := nat.PortMap{"8000/tcp": []nat.PortBinding{{HostPort: "9000"}}}
+	cfg, hostConfig, _, err := cloneContainerConfig(src, &types.ContainerCloneConfig{PortBindings: bindings})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hostConfig.PortBindings, bindings) {
+		t.Fatalf("expected the port bindings to be replaced, got %v", hostConfig.PortBindings)
+	}
+	if _, ok := cfg.ExposedPorts["8000/tcp"]; !ok {
+		t.Fatalf("expected the published port to be exposed, got %v", cfg.ExposedPorts)
+	}
+}
+
+func TestCloneImageReference(t *testing.T) {
+	cases := []struct {
+		image, override, expected string
+		fail                      bool
+	}{
+		{"busybox", ":1.25", "busybox:1.25", false},
+		{"busybox:latest", ":musl", "busybox:musl", false},
+		{"localhost:5000/app:v1", ":v2", "localhost:5000/app:v2", false},
+		{"busybox", "alpine", "alpine", false},
+		{"busybox", ":", "", true},
+		{"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", ":v2", "", true},
+	}
+	for _, c := range cases {
+		image, err := cloneImageReference(c.image, c.override)
+		if c.fail {
+			if err == nil {
+				t.Fatalf("expected an error for %s with %s, got %s", c.image, c.override, image)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error for %s with %s: %v", c.image, c.override, err)
+		}
+		if image != c.expected {
+			t.Fatalf("expected %s, got %s", c.expected, image)
+		}
+	}
+}
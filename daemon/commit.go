@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/builder/dockerfile"
@@ -119,7 +121,7 @@ func merge(userConf, imageConf *containertypes.Config) error {
 
 // Commit creates a new filesystem image from the current state of a container.
 // The image can optionally be tagged into a repository.
-func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (string, error) {
+func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (_ string, retErr error) {
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return "", err
@@ -214,10 +216,15 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		return "", err
 	}
 
-	id, err := daemon.imageStore.Create(config)
+	id, err := daemon.imageStore.CreateHeld(config, c.Holder)
 	if err != nil {
 		return "", err
 	}
+	defer func() {
+		if retErr != nil {
+			daemon.imageStore.Unhold(id, c.Holder)
+		}
+	}()
 
 	if container.ImageID != "" {
 		if err := daemon.imageStore.SetParent(id, container.ImageID); err != nil {
@@ -225,6 +232,7 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		}
 	}
 
+	var refName string
 	if c.Repo != "" {
 		newTag, err := reference.WithName(c.Repo) // todo: should move this to API layer
 		if err != nil {
@@ -238,7 +246,9 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		if err := daemon.TagImageWithReference(id, newTag); err != nil {
 			return "", err
 		}
+		refName = newTag.String()
 	}
+	daemon.checkLayerDepth(id, refName)
 
 	attributes := map[string]string{
 		"comment": c.Comment,
@@ -247,6 +257,76 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 	return id.String(), nil
 }
 
+// ConsistentCommit creates a new image from a crash-consistent state of a
+// container. A running container is paused, the writes to its filesystem are
+// flushed, and it is resumed once committed, so that the image does not
+// capture files half-written by the processes of the container. A container
+// already paused is left paused. It returns the duration of each step.
+func (daemon *Daemon) ConsistentCommit(name string, c *backend.ContainerCommitConfig) (string, *types.ContainerCommitTiming, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	timing := &types.ContainerCommitTiming{}
+	frozen := time.Now()
+	// The state of the container is checked and the container paused under
+	// its lock, so that it is not paused, stopped or started in between.
+	container.Lock()
+	running, paused := container.Running, false
+	switch {
+	case running && runtime.GOOS == "windows":
+		err = fmt.Errorf("Windows does not support commit of a running container")
+	case running && !container.Paused:
+		err = daemon.pauseLocked(container)
+		paused = err == nil
+	}
+	container.Unlock()
+	if err != nil {
+		return "", nil, err
+	}
+	if paused {
+		timing.Pause = time.Since(frozen)
+	}
+
+	if running {
+		start := time.Now()
+		err := syncContainerFilesystem(container)
+		timing.Sync = time.Since(start)
+		if err != nil {
+			if paused {
+				if err := daemon.containerUnpause(container); err != nil {
+					logrus.Errorf("Failed to resume container %s: %v", container.ID, err)
+				}
+			}
+			return "", nil, fmt.Errorf("Error flushing the filesystem of container %s: %v", container.ID, err)
+		}
+	}
+
+	commitCfg := *c
+	commitCfg.Pause = false
+	start := time.Now()
+	id, err := daemon.Commit(container.ID, &commitCfg)
+	timing.Commit = time.Since(start)
+
+	if paused {
+		start := time.Now()
+		if uerr := daemon.containerUnpause(container); uerr != nil {
+			if err == nil {
+				err = fmt.Errorf("Committed container %s to image %s, but could not resume it: %v", container.ID, id, uerr)
+			} else {
+				logrus.Errorf("Failed to resume container %s: %v", container.ID, uerr)
+			}
+		}
+		timing.Resume = time.Since(start)
+		timing.Frozen = time.Since(frozen)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return id, timing, nil
+}
+
 func (daemon *Daemon) exportContainerRw(container *container.Container) (archive.Archive, error) {
 	if err := daemon.Mount(container); err != nil {
 		return nil, err
@@ -259,7 +339,7 @@ func (daemon *Daemon) exportContainerRw(container *container.Container) (archive
 	}
 	return ioutils.NewReadCloserWrapper(archive, func() error {
 			archive.Close()
-			return container.RWLayer.Unmount()
+			return daemon.Unmount(container)
 		}),
 		nil
 }
@@ -0,0 +1,43 @@
+package daemon
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+
+	"github.com/docker/docker/container"
+)
+
+// syncfsTraps are the numbers of the syncfs system call, which the syscall
+// package does not define on every architecture.
+var syncfsTraps = map[string]uintptr{
+	"386":     344,
+	"amd64":   306,
+	"arm":     373,
+	"arm64":   267,
+	"ppc64":   348,
+	"ppc64le": 348,
+	"s390x":   338,
+}
+
+// syncContainerFilesystem flushes the writes of the processes of a paused
+// container to its filesystem, with syncfs on its root filesystem, so that
+// the other filesystems of the host are not flushed while the container is
+// paused. The filesystems of the host are all flushed on the architectures
+// whose syncfs is unknown.
+func syncContainerFilesystem(c *container.Container) error {
+	trap, ok := syncfsTraps[runtime.GOARCH]
+	if !ok {
+		syscall.Sync()
+		return nil
+	}
+	f, err := os.Open(c.BaseFS)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, _, errno := syscall.Syscall(trap, f.Fd(), 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/container"
+)
+
+// syncContainerFilesystem is not supported, so a running container cannot be
+// committed consistently on this platform.
+func syncContainerFilesystem(c *container.Container) error {
+	return fmt.Errorf("flushing the filesystem of a container is not supported on this platform")
+}
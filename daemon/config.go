@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/discovery"
 	"github.com/docker/docker/registry"
 	"github.com/imdario/mergo"
@@ -27,6 +30,20 @@ const (
 	// maximum number of uploads that
 	// may take place at a time for each push.
 	defaultMaxConcurrentUploads = 5
+	// defaultMaxDownloadAttempts is the default value for the number of
+	// attempts of the download of a layer before the pull fails.
+	defaultMaxDownloadAttempts = 5
+	// defaultMaxConcurrentExports is the default value for the maximum
+	// number of layers written at a time by each save, and of images whose
+	// layers are loaded at a time by each load.
+	defaultMaxConcurrentExports = 3
+	// defaultLayerDepthWarning is the default value for the number of
+	// layers of an image above which the daemon warns that the image should
+	// be flattened.
+	defaultLayerDepthWarning = 100
+	// defaultShutdownTimeout is the default value for the time (in
+	// seconds) the daemon waits for containers to stop on shutdown.
+	defaultShutdownTimeout = 15
 	// stockRuntimeName is the reserved name/alias used to represent the
 	// OCI runtime being shipped with the docker daemon package.
 	stockRuntimeName = "runc"
@@ -45,6 +62,7 @@ var flatOptions = map[string]bool{
 	"cluster-store-opts": true,
 	"log-opts":           true,
 	"runtimes":           true,
+	"socket-roles":       true,
 }
 
 // LogConfig represents the default log configuration.
@@ -100,6 +118,10 @@ type CommonConfig struct {
 	// alive upon daemon shutdown/start
 	LiveRestoreEnabled bool `json:"live-restore,omitempty"`
 
+	// MigrateOverlay determines whether the images and containers of the
+	// overlay storage driver are migrated when starting with overlay2.
+	MigrateOverlay bool `json:"migrate-overlay,omitempty"`
+
 	// ClusterStore is the storage backend used for the cluster information. It is used by both
 	// multihost networking (to store networks and endpoints information) and by the node discovery
 	// mechanism.
@@ -122,6 +144,93 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// MaxDownloadAttempts is the number of attempts of the download of a
+	// layer before the pull fails.
+	MaxDownloadAttempts *int `json:"max-download-attempts,omitempty"`
+
+	// MaxConcurrentExports is the maximum number of layers written at a
+	// time by each save, and of images whose layers are loaded at a time by
+	// each load.
+	MaxConcurrentExports *int `json:"max-concurrent-exports,omitempty"`
+
+	// LayerDepthWarning is the number of layers of an image above which
+	// the daemon warns that the image should be flattened, 0 to disable
+	// the warning.
+	LayerDepthWarning *int `json:"layer-depth-warning,omitempty"`
+
+	// BuildFromPolicy is the resolution policy of the FROM references of
+	// the builds, any or pinned, unless a build sets a stricter one.
+	BuildFromPolicy string `json:"build-from-policy,omitempty"`
+
+	// PushCompression is the compression of the layers pushed, gzip or
+	// zstd, unless the push sets another one.
+	PushCompression string `json:"push-compression,omitempty"`
+
+	// PushCompressionLevel is the compression level of the layers pushed,
+	// 0 being the default level of the compression.
+	PushCompressionLevel int `json:"push-compression-level,omitempty"`
+
+	// ShutdownTimeout is the time (in seconds) the daemon waits for
+	// containers to stop when it shuts down.
+	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
+
+	// AuditLog is where the state-changing API requests are logged: the
+	// absolute path of a file, or "events" for the events of the daemon.
+	// They are not logged if it is empty.
+	AuditLog string `json:"audit-log,omitempty"`
+
+	// AuditLevel is the verbosity of the audit log: "metadata" for who
+	// called which endpoint, or "request" to also log the bodies of the
+	// requests.
+	AuditLevel string `json:"audit-level,omitempty"`
+
+	// AuditRedact are the keys of the bodies of the requests whose values
+	// are redacted in the audit log, in addition to the passwords, secrets
+	// and join tokens.
+	AuditRedact []string `json:"audit-redact,omitempty"`
+
+	// SocketRoles restrict the endpoints of the API the clients of the
+	// unix sockets can access, by the user and group ids of the client
+	// processes. The clients without a role are not restricted.
+	SocketRoles map[string]types.SocketRole `json:"socket-roles,omitempty"`
+
+	// APIReadTimeout is the time (in seconds) a client of the API has to
+	// send the headers of its first request, and APIIdleTimeout the time a
+	// connection is kept open between two requests.
+	APIReadTimeout int `json:"api-read-timeout,omitempty"`
+	APIIdleTimeout int `json:"api-idle-timeout,omitempty"`
+
+	// APIWriteTimeout is the time (in seconds) a write to an API
+	// connection, such as a streamed response or the output of an attach,
+	// can block.
+	APIWriteTimeout int `json:"api-write-timeout,omitempty"`
+
+	// APITCPKeepAlive is the period (in seconds) of the TCP keepalive of
+	// the connections to the TCP sockets of the API.
+	APITCPKeepAlive int `json:"api-tcp-keepalive,omitempty"`
+
+	// APIMaxConcurrentRequests is the maximum number of requests the API
+	// handles at once, beyond which it rejects them with a 503 status.
+	APIMaxConcurrentRequests int `json:"api-max-concurrent-requests,omitempty"`
+
+	// LogCache enables the local cache of the logs of the containers whose
+	// logging driver cannot read logs back, unless they set the
+	// cache-enabled log option.
+	LogCache bool `json:"log-cache,omitempty"`
+
+	// Autoheal restarts the unhealthy containers, unless they disable it
+	// with the com.docker.autoheal label.
+	Autoheal bool `json:"autoheal,omitempty"`
+
+	// AutohealThreshold is the number of consecutive probes reporting a
+	// container unhealthy before it is autohealed.
+	AutohealThreshold int `json:"autoheal-threshold,omitempty"`
+
+	// ResourceAccountingInterval is the period (in seconds) of the samples
+	// of the resource usage of the running containers, recorded when they
+	// exit. 0 disables the periodic samples.
+	ResourceAccountingInterval int `json:"resource-accounting-interval,omitempty"`
+
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
 	LogLevel  string   `json:"log-level,omitempty"`
@@ -148,7 +257,7 @@ type CommonConfig struct {
 
 // InstallCommonFlags adds flags to the pflag.FlagSet to configure the daemon
 func (config *Config) InstallCommonFlags(flags *pflag.FlagSet) {
-	var maxConcurrentDownloads, maxConcurrentUploads int
+	var maxConcurrentDownloads, maxConcurrentUploads, maxDownloadAttempts, maxConcurrentExports, layerDepthWarning int
 
 	config.ServiceOptions.InstallCliFlags(flags)
 
@@ -169,17 +278,41 @@ func (config *Config) InstallCommonFlags(flags *pflag.FlagSet) {
 	flags.Var(opts.NewNamedListOptsRef("labels", &config.Labels, opts.ValidateLabel), "label", "Set key=value labels to the daemon")
 	flags.StringVar(&config.LogConfig.Type, "log-driver", "json-file", "Default driver for container logs")
 	flags.Var(opts.NewNamedMapOpts("log-opts", config.LogConfig.Config, nil), "log-opt", "Default log driver options for containers")
+	flags.BoolVar(&config.LogCache, "log-cache", false, "Keep a local copy of the logs of the drivers that cannot read them back, for docker logs")
 	flags.StringVar(&config.ClusterAdvertise, "cluster-advertise", "", "Address or interface name to advertise")
 	flags.StringVar(&config.ClusterStore, "cluster-store", "", "URL of the distributed storage backend")
 	flags.Var(opts.NewNamedMapOpts("cluster-store-opts", config.ClusterOpts, nil), "cluster-store-opt", "Set cluster store options")
 	flags.StringVar(&config.CorsHeaders, "api-cors-header", "", "Set CORS headers in the remote API")
+	flags.IntVar(&config.APIReadTimeout, "api-read-timeout", 0, "Seconds a client has to send the headers of its first request, 0 for no limit")
+	flags.IntVar(&config.APIIdleTimeout, "api-idle-timeout", 0, "Seconds an idle connection to the API is kept open, 0 for no limit")
+	flags.IntVar(&config.APIWriteTimeout, "api-write-timeout", 0, "Seconds a write to an API connection, such as a stream of events or an attach, can block, 0 for no limit")
+	flags.IntVar(&config.APITCPKeepAlive, "api-tcp-keepalive", 0, "Period in seconds of the TCP keepalive of the API connections, 0 to disable it")
+	flags.IntVar(&config.APIMaxConcurrentRequests, "api-max-concurrent-requests", 0, "Maximum number of API requests handled at once, 0 for no limit")
 	flags.IntVar(&maxConcurrentDownloads, "max-concurrent-downloads", defaultMaxConcurrentDownloads, "Set the max concurrent downloads for each pull")
 	flags.IntVar(&maxConcurrentUploads, "max-concurrent-uploads", defaultMaxConcurrentUploads, "Set the max concurrent uploads for each push")
+	flags.IntVar(&maxDownloadAttempts, "max-download-attempts", defaultMaxDownloadAttempts, "Set the max download attempts for each layer of a pull")
+	flags.IntVar(&maxConcurrentExports, "max-concurrent-exports", defaultMaxConcurrentExports, "Set the max concurrent layers written by each save, and images loaded by each load")
+	flags.IntVar(&layerDepthWarning, "layer-depth-warning", defaultLayerDepthWarning, "Warn when an image has more layers than this, 0 to disable the warning")
+	flags.StringVar(&config.BuildFromPolicy, "build-from-policy", types.BuildFromPolicyAny, "Set the resolution policy of the FROM references of the builds (any or pinned)")
+	flags.StringVar(&config.PushCompression, "push-compression", "gzip", "Set the default compression of the layers pushed (gzip or zstd)")
+	flags.IntVar(&config.PushCompressionLevel, "push-compression-level", 0, "Set the default compression level of the layers pushed, 0 for the default level of the compression")
+
+	flags.IntVar(&config.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout, "Set the default shutdown timeout")
+	flags.BoolVar(&config.Autoheal, "autoheal", false, "Restart the containers which become unhealthy")
+	flags.IntVar(&config.AutohealThreshold, "autoheal-threshold", defaultAutohealThreshold, "Number of consecutive unhealthy probes before a container is restarted")
+	flags.IntVar(&config.ResourceAccountingInterval, "resource-accounting-interval", 0, "Seconds between the samples of the resource usage of the running containers, 0 to only sample it from their stats and before they are signaled")
 
 	flags.StringVar(&config.SwarmDefaultAdvertiseAddr, "swarm-default-advertise-addr", "", "Set default address or interface for swarm advertised address")
 
+	flags.StringVar(&config.AuditLog, "audit-log", "", "Log the state-changing API requests to a file, or to the daemon events with \"events\"")
+	flags.StringVar(&config.AuditLevel, "audit-level", "metadata", "Verbosity of the audit log (metadata or request)")
+	flags.Var(opts.NewNamedListOptsRef("audit-redact", &config.AuditRedact, nil), "audit-redact", "Redact the values of a key of the request bodies in the audit log")
+
 	config.MaxConcurrentDownloads = &maxConcurrentDownloads
 	config.MaxConcurrentUploads = &maxConcurrentUploads
+	config.MaxDownloadAttempts = &maxDownloadAttempts
+	config.MaxConcurrentExports = &maxConcurrentExports
+	config.LayerDepthWarning = &layerDepthWarning
 }
 
 // IsValueSet returns true if a configuration value
@@ -413,7 +546,10 @@ func findConfigurationConflicts(config map[string]interface{}, flags *pflag.Flag
 
 // ValidateConfiguration validates some specific configs.
 // such as config.DNS, config.Labels, config.DNSSearch,
-// as well as config.MaxConcurrentDownloads, config.MaxConcurrentUploads.
+// as well as config.MaxConcurrentDownloads, config.MaxConcurrentUploads,
+// config.MaxDownloadAttempts, config.MaxConcurrentExports, config.LayerDepthWarning,
+// config.BuildFromPolicy, config.PushCompression, config.Limits, config.ShutdownTimeout,
+// config.AutohealThreshold and the limits of the API connections.
 func ValidateConfiguration(config *Config) error {
 	// validate DNS
 	for _, dns := range config.DNS {
@@ -446,6 +582,81 @@ func ValidateConfiguration(config *Config) error {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
 
+	// validate MaxDownloadAttempts
+	if config.IsValueSet("max-download-attempts") && config.MaxDownloadAttempts != nil && *config.MaxDownloadAttempts < 1 {
+		return fmt.Errorf("invalid max download attempts: %d", *config.MaxDownloadAttempts)
+	}
+
+	// validate MaxConcurrentExports
+	if config.IsValueSet("max-concurrent-exports") && config.MaxConcurrentExports != nil && *config.MaxConcurrentExports < 1 {
+		return fmt.Errorf("invalid max concurrent exports: %d", *config.MaxConcurrentExports)
+	}
+
+	// validate LayerDepthWarning
+	if config.IsValueSet("layer-depth-warning") && config.LayerDepthWarning != nil && *config.LayerDepthWarning < 0 {
+		return fmt.Errorf("invalid layer depth warning: %d", *config.LayerDepthWarning)
+	}
+
+	// validate BuildFromPolicy
+	switch config.BuildFromPolicy {
+	case "", types.BuildFromPolicyAny, types.BuildFromPolicyPinned:
+	default:
+		return fmt.Errorf("invalid build from policy: %s", config.BuildFromPolicy)
+	}
+
+	// validate PushCompression and PushCompressionLevel
+	if config.PushCompression != "" {
+		compression, err := archive.ParseCompression(config.PushCompression)
+		if err != nil {
+			return fmt.Errorf("invalid push compression: %v", err)
+		}
+		if err := archive.ValidateCompressionLevel(compression, config.PushCompressionLevel); err != nil {
+			return fmt.Errorf("invalid push compression level: %v", err)
+		}
+	}
+
+	// validate the registry limits
+	if _, err := registry.ValidateLimits(config.Limits); err != nil {
+		return err
+	}
+
+	// validate ShutdownTimeout
+	if config.IsValueSet("shutdown-timeout") && config.ShutdownTimeout < 0 {
+		return fmt.Errorf("invalid shutdown timeout: %d", config.ShutdownTimeout)
+	}
+
+	// validate AutohealThreshold
+	if config.IsValueSet("autoheal-threshold") && config.AutohealThreshold < 1 {
+		return fmt.Errorf("invalid autoheal threshold: %d", config.AutohealThreshold)
+	}
+
+	// validate the limits of the API connections
+	apiLimits := []struct {
+		name  string
+		value int
+	}{
+		{"api-read-timeout", config.APIReadTimeout},
+		{"api-idle-timeout", config.APIIdleTimeout},
+		{"api-write-timeout", config.APIWriteTimeout},
+		{"api-tcp-keepalive", config.APITCPKeepAlive},
+		{"api-max-concurrent-requests", config.APIMaxConcurrentRequests},
+	}
+	for _, l := range apiLimits {
+		if l.value < 0 {
+			return fmt.Errorf("invalid %s: %d", l.name, l.value)
+		}
+	}
+
+	// validate the audit log
+	if config.AuditLog != "" && config.AuditLog != "events" && !filepath.IsAbs(config.AuditLog) {
+		return fmt.Errorf("invalid audit log %s: it must be an absolute path or events", config.AuditLog)
+	}
+	switch config.AuditLevel {
+	case "", "metadata", "request":
+	default:
+		return fmt.Errorf("invalid audit level %s: it must be metadata or request", config.AuditLevel)
+	}
+
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
 		if _, ok := runtimes[stockRuntimeName]; ok {
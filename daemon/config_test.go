@@ -257,4 +257,109 @@ func TestValidateConfiguration(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
+	c7 := &Config{
+		CommonConfig: CommonConfig{
+			ShutdownTimeout: -1,
+			valuesSet:       map[string]interface{}{"shutdown-timeout": -1},
+		},
+	}
+
+	err = ValidateConfiguration(c7)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	c8 := &Config{
+		CommonConfig: CommonConfig{
+			AuditLog: "audit.log",
+		},
+	}
+
+	err = ValidateConfiguration(c8)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	c9 := &Config{
+		CommonConfig: CommonConfig{
+			AuditLog:   "events",
+			AuditLevel: "verbose",
+		},
+	}
+
+	err = ValidateConfiguration(c9)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	c10 := &Config{
+		CommonConfig: CommonConfig{
+			APIMaxConcurrentRequests: -1,
+		},
+	}
+
+	err = ValidateConfiguration(c10)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	c11 := &Config{
+		CommonConfig: CommonConfig{
+			PushCompression: "xz",
+		},
+	}
+
+	err = ValidateConfiguration(c11)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	c12 := &Config{
+		CommonConfig: CommonConfig{
+			PushCompression:      "gzip",
+			PushCompressionLevel: 19,
+		},
+	}
+
+	err = ValidateConfiguration(c12)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	maxConcurrentExports := 0
+	c13 := &Config{
+		CommonConfig: CommonConfig{
+			MaxConcurrentExports: &maxConcurrentExports,
+			valuesSet:            map[string]interface{}{"max-concurrent-exports": 0},
+		},
+	}
+
+	err = ValidateConfiguration(c13)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	layerDepthWarning := -1
+	c14 := &Config{
+		CommonConfig: CommonConfig{
+			LayerDepthWarning: &layerDepthWarning,
+			valuesSet:         map[string]interface{}{"layer-depth-warning": -1},
+		},
+	}
+
+	err = ValidateConfiguration(c14)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	c15 := &Config{
+		CommonConfig: CommonConfig{
+			BuildFromPolicy: "signed",
+		},
+	}
+
+	err = ValidateConfiguration(c15)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
 }
@@ -4,10 +4,16 @@ package daemon
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/homedir"
+	"github.com/docker/docker/pkg/rootless"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	units "github.com/docker/go-units"
 	"github.com/spf13/pflag"
@@ -19,6 +25,21 @@ var (
 	defaultExecRoot = "/var/run/docker"
 )
 
+func init() {
+	// In rootless mode, the daemon cannot write to the directories of the
+	// system, and uses the directories of the user instead.
+	if !rootless.RunningWithRootlessKit() {
+		return
+	}
+	if runtimeDir, err := homedir.GetRuntimeDir(); err == nil {
+		defaultPidFile = filepath.Join(runtimeDir, "docker.pid")
+		defaultExecRoot = filepath.Join(runtimeDir, "docker")
+	}
+	if dataHome, err := homedir.GetDataHome(); err == nil {
+		defaultGraph = filepath.Join(dataHome, "docker")
+	}
+}
+
 // Config defines the configuration of a docker daemon.
 // It includes json tags to deserialize configuration from a file
 // using the same names that the flags in the command line uses.
@@ -37,6 +58,17 @@ type Config struct {
 	OOMScoreAdjust       int                      `json:"oom-score-adjust,omitempty"`
 	Init                 bool                     `json:"init,omitempty"`
 	InitPath             string                   `json:"init-path,omitempty"`
+	CgroupNamespaceMode  string                   `json:"default-cgroupns-mode,omitempty"`
+	IpcMode              string                   `json:"default-ipc-mode,omitempty"`
+	CoreDumps            string                   `json:"default-core-dumps,omitempty"`
+	CoreDumpMaxSize      string                   `json:"default-core-dump-max-size,omitempty"`
+	CoreDumpsMaxTotal    string                   `json:"core-dumps-max-total-size,omitempty"`
+	Rootless             bool                     `json:"rootless,omitempty"`
+
+	// SeccompProfile is the path to the default seccomp profile of the
+	// containers, or unconfined or builtin.
+	SeccompProfile         string `json:"seccomp-profile,omitempty"`
+	DefaultAppArmorProfile string `json:"default-apparmor-profile,omitempty"`
 }
 
 // bridgeConfig stores all the bridge driver specific
@@ -50,6 +82,8 @@ type bridgeConfig struct {
 	EnableIPForward             bool   `json:"ip-forward,omitempty"`
 	EnableIPMasq                bool   `json:"ip-masq,omitempty"`
 	EnableUserlandProxy         bool   `json:"userland-proxy,omitempty"`
+	IptablesReconcileInterval   int    `json:"iptables-reconcile-interval,omitempty"`
+	PublishedPortRange          string `json:"published-port-range,omitempty"`
 	DefaultIP                   net.IP `json:"ip,omitempty"`
 	IP                          string `json:"bip,omitempty"`
 	FixedCIDRv6                 string `json:"fixed-cidr-v6,omitempty"`
@@ -65,12 +99,16 @@ func (config *Config) InstallFlags(flags *pflag.FlagSet) {
 
 	config.Ulimits = make(map[string]*units.Ulimit)
 	config.Runtimes = make(map[string]types.Runtime)
+	config.SocketRoles = make(map[string]types.SocketRole)
 
 	// Then platform-specific install flags
 	flags.BoolVar(&config.EnableSelinuxSupport, "selinux-enabled", false, "Enable selinux support")
 	flags.StringVarP(&config.SocketGroup, "group", "G", "docker", "Group for the unix socket")
+	flags.Var(runconfigopts.NewNamedSocketRoleOpt("socket-roles", &config.SocketRoles), "socket-role", "Restrict the API endpoints the clients of the unix socket can access, by user or group id")
 	flags.Var(runconfigopts.NewUlimitOpt(&config.Ulimits), "default-ulimit", "Default ulimits for containers")
 	flags.BoolVar(&config.bridgeConfig.EnableIPTables, "iptables", true, "Enable addition of iptables rules")
+	flags.IntVar(&config.bridgeConfig.IptablesReconcileInterval, "iptables-reconcile-interval", 0, "Seconds between the re-creations of the missing iptables rules of the published ports, 0 to disable them")
+	flags.StringVar(&config.bridgeConfig.PublishedPortRange, "published-port-range", "", "Range of the host ports allocated to the ports published without a host port, such as 40000-45000 (default the local port range of the kernel)")
 	flags.BoolVar(&config.bridgeConfig.EnableIPForward, "ip-forward", true, "Enable net.ipv4.ip_forward")
 	flags.BoolVar(&config.bridgeConfig.EnableIPMasq, "ip-masq", true, "Enable IP masquerading")
 	flags.BoolVar(&config.bridgeConfig.EnableIPv6, "ipv6", false, "Enable IPv6 networking")
@@ -90,11 +128,20 @@ func (config *Config) InstallFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&config.RemappedRoot, "userns-remap", "", "User/Group setting for user namespaces")
 	flags.StringVar(&config.ContainerdAddr, "containerd", "", "Path to containerd socket")
 	flags.BoolVar(&config.LiveRestoreEnabled, "live-restore", false, "Enable live restore of docker when containers are still running")
+	flags.BoolVar(&config.MigrateOverlay, "migrate-overlay", false, "Migrate the images and containers of the overlay storage driver when starting with overlay2")
 	flags.Var(runconfigopts.NewNamedRuntimeOpt("runtimes", &config.Runtimes, stockRuntimeName), "add-runtime", "Register an additional OCI compatible runtime")
 	flags.StringVar(&config.DefaultRuntime, "default-runtime", stockRuntimeName, "Default OCI runtime for containers")
 	flags.IntVar(&config.OOMScoreAdjust, "oom-score-adjust", -500, "Set the oom_score_adj for the daemon")
 	flags.BoolVar(&config.Init, "init", false, "Run an init in the container to forward signals and reap processes")
 	flags.StringVar(&config.InitPath, "init-path", "", "Path to the docker-init binary")
+	flags.StringVar(&config.CgroupNamespaceMode, "default-cgroupns-mode", "host", "Default mode for containers cgroup namespace (host|private)")
+	flags.StringVar(&config.IpcMode, "default-ipc-mode", "shareable", "Default mode for containers ipc (shareable|private)")
+	flags.StringVar(&config.CoreDumps, "default-core-dumps", "kernel", "Default handling of the core dumps of the containers (kernel|capture|discard)")
+	flags.StringVar(&config.CoreDumpMaxSize, "default-core-dump-max-size", "", "Default maximum size of a core dump of the containers")
+	flags.StringVar(&config.CoreDumpsMaxTotal, "core-dumps-max-total-size", "", "Maximum total size of the core dumps captured for a container, the oldest are removed beyond it")
+	flags.BoolVar(&config.Rootless, "rootless", rootless.RunningWithRootlessKit(), "Run the daemon as an unprivileged user, in the namespaces of RootlessKit")
+	flags.StringVar(&config.SeccompProfile, "seccomp-profile", "", "Path to the default seccomp profile of the containers, or unconfined")
+	flags.StringVar(&config.DefaultAppArmorProfile, "default-apparmor-profile", "", "Default AppArmor profile of the containers")
 
 	config.attachExperimentalFlags(flags)
 }
@@ -141,3 +188,21 @@ func (config *Config) isSwarmCompatible() error {
 	}
 	return nil
 }
+
+// GetOOMScoreAdjust returns the oom_score_adj of the daemon and containerd.
+// In rootless mode, it cannot be lowered below the current score of the
+// daemon, which is kept instead.
+func (config *Config) GetOOMScoreAdjust() int {
+	if !config.Rootless {
+		return config.OOMScoreAdjust
+	}
+	b, err := ioutil.ReadFile("/proc/self/oom_score_adj")
+	if err != nil {
+		return config.OOMScoreAdjust
+	}
+	score, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || score < config.OOMScoreAdjust {
+		return config.OOMScoreAdjust
+	}
+	return score
+}
@@ -1,9 +1,12 @@
 package daemon
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/docker/docker/api/errors"
@@ -101,7 +104,7 @@ func (daemon *Daemon) Register(c *container.Container) error {
 	return nil
 }
 
-func (daemon *Daemon) newContainer(name string, config *containertypes.Config, imgID image.ID, managed bool) (*container.Container, error) {
+func (daemon *Daemon) newContainer(name string, config *containertypes.Config, hostConfig *containertypes.HostConfig, imgID image.ID, managed bool) (*container.Container, error) {
 	var (
 		id             string
 		err            error
@@ -112,7 +115,9 @@ func (daemon *Daemon) newContainer(name string, config *containertypes.Config, i
 		return nil, err
 	}
 
-	daemon.generateHostname(id, config)
+	if err := daemon.generateHostname(id, name, !noExplicitName, config, hostConfig); err != nil {
+		return nil, err
+	}
 	entrypoint, args := daemon.getEntrypointAndArgs(config.Entrypoint, config.Cmd)
 
 	base := daemon.newBaseContainer(id)
@@ -163,11 +168,85 @@ func (daemon *Daemon) getEntrypointAndArgs(configEntrypoint strslice.StrSlice, c
 	return configCmd[0], configCmd[1:]
 }
 
-func (daemon *Daemon) generateHostname(id string, config *containertypes.Config) {
+// hostnameTemplateData holds the values a hostname template can refer to.
+type hostnameTemplateData struct {
+	// ID is the truncated ID of the container.
+	ID string
+	// Name is the name of the container, without the leading slash.
+	Name string
+}
+
+// isHostnameTemplate returns whether the hostname contains placeholders
+// that are resolved when the container is created.
+func isHostnameTemplate(hostname string) bool {
+	return strings.Contains(hostname, "{{")
+}
+
+func (daemon *Daemon) generateHostname(id, name string, explicitName bool, config *containertypes.Config, hostConfig *containertypes.HostConfig) error {
+	name = strings.TrimPrefix(name, "/")
+
+	if isHostnameTemplate(config.Hostname) {
+		tmpl, err := template.New("hostname").Parse(config.Hostname)
+		if err != nil {
+			return fmt.Errorf("invalid hostname template: %v", err)
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, hostnameTemplateData{ID: id[:12], Name: hostnameFromName(name)}); err != nil {
+			return fmt.Errorf("invalid hostname template: %v", err)
+		}
+		hostname := truncateHostname(b.String())
+		if err := validateHostnameFormat(hostname); err != nil {
+			return err
+		}
+		config.Hostname = hostname
+		return nil
+	}
+
+	if config.Hostname != "" {
+		return nil
+	}
+
+	// On user-defined networks other containers resolve this one by its name,
+	// so use the name as hostname when it is a valid one.
+	if explicitName && hostConfig != nil && hostConfig.NetworkMode.IsUserDefined() {
+		if hostname := truncateHostname(hostnameFromName(name)); validateHostnameFormat(hostname) == nil {
+			config.Hostname = hostname
+			return nil
+		}
+	}
+
 	// Generate default hostname
-	if config.Hostname == "" {
-		config.Hostname = id[:12]
+	config.Hostname = id[:12]
+	return nil
+}
+
+// hostnameFromName turns the underscores of a container name, such as the
+// generated ones, into hyphens, as hostnames cannot contain them.
+func hostnameFromName(name string) string {
+	return strings.Replace(name, "_", "-", -1)
+}
+
+// truncateHostname truncates a hostname to the 63 bytes of a valid hostname,
+// without a trailing hyphen or dot.
+func truncateHostname(hostname string) string {
+	if len(hostname) <= 63 {
+		return hostname
 	}
+	return strings.TrimRight(hostname[:63], "-.")
+}
+
+// validateHostnameFormat checks that the hostname is RFC 1123
+// (https://tools.ietf.org/html/rfc1123) compliant.
+func validateHostnameFormat(hostname string) error {
+	// RFC1123 specifies that 63 bytes is the maximium length
+	// Windows has the limitation of 63 bytes in length
+	// Linux hostname is limited to HOST_NAME_MAX=64, not including the terminating null byte.
+	// We limit the length to 63 bytes here to match RFC1035 and RFC1123.
+	matched, _ := regexp.MatchString("^(([[:alnum:]]|[[:alnum:]][[:alnum:]\\-]*[[:alnum:]])\\.)*([[:alnum:]]|[[:alnum:]][[:alnum:]\\-]*[[:alnum:]])$", hostname)
+	if len(hostname) > 63 || !matched {
+		return fmt.Errorf("invalid hostname format: %s", hostname)
+	}
+	return nil
 }
 
 func (daemon *Daemon) setSecurityOptions(container *container.Container, hostConfig *containertypes.HostConfig) error {
@@ -221,15 +300,14 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 			}
 		}
 
-		// Validate if the given hostname is RFC 1123 (https://tools.ietf.org/html/rfc1123) compliant.
+		// Hostname templates are validated once they are resolved at create time.
 		if validateHostname && len(config.Hostname) > 0 {
-			// RFC1123 specifies that 63 bytes is the maximium length
-			// Windows has the limitation of 63 bytes in length
-			// Linux hostname is limited to HOST_NAME_MAX=64, not including the terminating null byte.
-			// We limit the length to 63 bytes here to match RFC1035 and RFC1123.
-			matched, _ := regexp.MatchString("^(([[:alnum:]]|[[:alnum:]][[:alnum:]\\-]*[[:alnum:]])\\.)*([[:alnum:]]|[[:alnum:]][[:alnum:]\\-]*[[:alnum:]])$", config.Hostname)
-			if len(config.Hostname) > 63 || !matched {
-				return nil, fmt.Errorf("invalid hostname format: %s", config.Hostname)
+			if isHostnameTemplate(config.Hostname) {
+				if _, err := template.New("hostname").Parse(config.Hostname); err != nil {
+					return nil, fmt.Errorf("invalid hostname template: %v", err)
+				}
+			} else if err := validateHostnameFormat(config.Hostname); err != nil {
+				return nil, err
 			}
 		}
 	}
@@ -242,6 +320,10 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 		return nil, fmt.Errorf("can't create 'AutoRemove' container with restart policy")
 	}
 
+	if hostConfig.MaxRuntime < 0 {
+		return nil, fmt.Errorf("the maximum runtime of a container cannot be negative")
+	}
+
 	for port := range hostConfig.PortBindings {
 		_, portStr := nat.SplitProtoPort(string(port))
 		if _, err := nat.ParsePort(portStr); err != nil {
@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/libkv/store"
+)
+
+const (
+	// containerMirrorOpt is the cluster store option enabling the mirroring
+	// of the containers of the daemon in the cluster store.
+	containerMirrorOpt = "containers.mirror"
+	// containerMirrorPath is the path of the mirrored containers in the
+	// cluster store, under the store prefix and the advertised address of
+	// the daemon.
+	containerMirrorPath = "docker/containers"
+	// containerMirrorQueueSize is the number of pending container updates
+	// after which the updates are dropped.
+	containerMirrorQueueSize = 1024
+)
+
+// containerMirrorActions are the container events, and the network events of
+// the container, after which the container is mirrored.
+var containerMirrorActions = map[string]bool{
+	"create":     true,
+	"start":      true,
+	"die":        true,
+	"pause":      true,
+	"unpause":    true,
+	"rename":     true,
+	"update":     true,
+	"destroy":    true,
+	"connect":    true,
+	"disconnect": true,
+}
+
+// containerMirrorSummary is the summary of a container mirrored in the
+// cluster store.
+type containerMirrorSummary struct {
+	ID          string
+	Name        string
+	Image       string
+	State       string
+	Labels      map[string]string
+	IPAddresses map[string]string // IP address of the container in each network
+}
+
+// kvBackend is a discovery backend backed by a key-value store.
+type kvBackend interface {
+	Store() store.Store
+	Prefix() string
+}
+
+type containerMirrorUpdate struct {
+	container *container.Container
+	removed   bool
+}
+
+// containerMirror mirrors the summaries of the containers in the cluster
+// store, so that the containers of the node can be inspected without using
+// the API. The summaries are written in order by a single goroutine.
+type containerMirror struct {
+	store   store.Store
+	path    string
+	updates chan containerMirrorUpdate
+}
+
+// containerMirrorEnabled returns whether the mirroring of the containers is
+// enabled in the cluster store options.
+func containerMirrorEnabled(clusterOpts map[string]string) (bool, error) {
+	v, ok := clusterOpts[containerMirrorOpt]
+	if !ok {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s: %s", containerMirrorOpt, v)
+	}
+	return enabled, nil
+}
+
+// newContainerMirror returns a mirror of the containers of the daemon
+// advertised with the given address in the cluster store.
+func newContainerMirror(backendAddress, advertise string, clusterOpts map[string]string) (*containerMirror, error) {
+	_, backend, err := parseDiscoveryOptions(backendAddress, clusterOpts)
+	if err != nil {
+		return nil, err
+	}
+	kv, ok := backend.(kvBackend)
+	if !ok {
+		return nil, fmt.Errorf("%s requires a key-value cluster store", containerMirrorOpt)
+	}
+	return &containerMirror{
+		store:   kv.Store(),
+		path:    path.Join(kv.Prefix(), containerMirrorPath, advertise),
+		updates: make(chan containerMirrorUpdate, containerMirrorQueueSize),
+	}, nil
+}
+
+// start replaces the containers mirrored in the store with the given
+// containers, then mirrors the updated containers until the daemon exits.
+func (m *containerMirror) start(containers []*container.Container) {
+	go func() {
+		if err := m.store.DeleteTree(m.path); err != nil && err != store.ErrKeyNotFound {
+			logrus.Warnf("Failed to remove the mirrored containers from the cluster store: %v", err)
+		}
+		for _, c := range containers {
+			m.write(containerMirrorUpdate{container: c})
+		}
+		for u := range m.updates {
+			m.write(u)
+		}
+	}()
+}
+
+// update queues the container to be mirrored after the event action.
+func (m *containerMirror) update(c *container.Container, action string) {
+	if !containerMirrorActions[action] {
+		return
+	}
+	select {
+	case m.updates <- containerMirrorUpdate{container: c, removed: action == "destroy"}:
+	default:
+		logrus.Warnf("Dropping the update of container %s in the cluster store: too many pending updates", c.ID)
+	}
+}
+
+func (m *containerMirror) write(u containerMirrorUpdate) {
+	key := path.Join(m.path, u.container.ID)
+	if u.removed {
+		if err := m.store.Delete(key); err != nil && err != store.ErrKeyNotFound {
+			logrus.Warnf("Failed to remove container %s from the cluster store: %v", u.container.ID, err)
+		}
+		return
+	}
+
+	b, err := json.Marshal(newContainerMirrorSummary(u.container))
+	if err != nil {
+		logrus.Warnf("Failed to mirror container %s in the cluster store: %v", u.container.ID, err)
+		return
+	}
+	if err := m.store.Put(key, b, nil); err != nil {
+		logrus.Warnf("Failed to mirror container %s in the cluster store: %v", u.container.ID, err)
+	}
+}
+
+func newContainerMirrorSummary(c *container.Container) *containerMirrorSummary {
+	c.Lock()
+	defer c.Unlock()
+
+	s := &containerMirrorSummary{
+		ID:          c.ID,
+		Name:        strings.TrimPrefix(c.Name, "/"),
+		Image:       c.Config.Image,
+		State:       c.State.StateString(),
+		Labels:      make(map[string]string, len(c.Config.Labels)),
+		IPAddresses: make(map[string]string),
+	}
+	for k, v := range c.Config.Labels {
+		s.Labels[k] = v
+	}
+	if c.NetworkSettings != nil {
+		for name, ep := range c.NetworkSettings.Networks {
+			if ep != nil && ep.EndpointSettings != nil && ep.IPAddress != "" {
+				s.IPAddresses[name] = ep.IPAddress
+			}
+		}
+	}
+	return s
+}
+
+// mirrorContainer queues the container to be mirrored in the cluster store
+// after the event action, if the mirroring is enabled.
+func (daemon *Daemon) mirrorContainer(c *container.Container, action string) {
+	if daemon.containerMirror != nil {
+		daemon.containerMirror.update(c, action)
+	}
+}
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/network"
+	"github.com/docker/libkv/store"
+	"github.com/docker/libkv/store/boltdb"
+)
+
+func TestContainerMirrorEnabled(t *testing.T) {
+	cases := map[string]bool{
+		"":      false,
+		"true":  true,
+		"1":     true,
+		"false": false,
+	}
+	for v, expected := range cases {
+		opts := map[string]string{}
+		if v != "" {
+			opts[containerMirrorOpt] = v
+		}
+		enabled, err := containerMirrorEnabled(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enabled != expected {
+			t.Fatalf("Expected %s=%q to be %v, got %v", containerMirrorOpt, v, expected, enabled)
+		}
+	}
+	if _, err := containerMirrorEnabled(map[string]string{containerMirrorOpt: "yes please"}); err == nil {
+		t.Fatal("Expected an invalid value to fail")
+	}
+}
+
+func TestContainerMirror(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-container-mirror-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	kv, err := boltdb.New([]string{filepath.Join(tmp, "kv.db")}, &store.Config{Bucket: "test", PersistConnection: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kv.Close()
+
+	m := &containerMirror{
+		store:   kv,
+		path:    "docker/containers/10.0.0.1:2376",
+		updates: make(chan containerMirrorUpdate, containerMirrorQueueSize),
+	}
+	if err := kv.Put("docker/containers/10.0.0.1:2376/stale", []byte("{}"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	newContainer := func(id, name string) *container.Container {
+		c := container.NewBaseContainer(id, filepath.Join(tmp, id))
+		c.Name = "/" + name
+		c.Config = &containertypes.Config{Image: "busybox", Labels: map[string]string{"app": name}}
+		c.HostConfig = &containertypes.HostConfig{}
+		return c
+	}
+	web := newContainer("web-id", "web")
+	db := newContainer("db-id", "db")
+
+	get := func(id string) (*containerMirrorSummary, error) {
+		var pair *store.KVPair
+		var err error
+		for i := 0; i < 100; i++ {
+			if pair, err = kv.Get("docker/containers/10.0.0.1:2376/" + id); err != store.ErrKeyNotFound {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var s containerMirrorSummary
+		return &s, json.Unmarshal(pair.Value, &s)
+	}
+
+	m.start([]*container.Container{web})
+	s, err := get("web-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &containerMirrorSummary{
+		ID:          "web-id",
+		Name:        "web",
+		Image:       "busybox",
+		State:       "created",
+		Labels:      map[string]string{"app": "web"},
+		IPAddresses: map[string]string{},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, s)
+	}
+	if _, err := kv.Get("docker/containers/10.0.0.1:2376/stale"); err != store.ErrKeyNotFound {
+		t.Fatalf("Expected the stale container to be removed, got %v", err)
+	}
+
+	db.NetworkSettings = &network.Settings{
+		Networks: map[string]*network.EndpointSettings{
+			"bridge": {EndpointSettings: &networktypes.EndpointSettings{IPAddress: "172.17.0.2"}},
+		},
+	}
+	m.update(db, "exec_start")
+	m.update(db, "create")
+	s, err = get("db-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.IPAddresses["bridge"] != "172.17.0.2" {
+		t.Fatalf("Expected the IP address of the container to be mirrored, got %+v", s)
+	}
+
+	m.update(web, "destroy")
+	for i := 0; i < 100; i++ {
+		if _, err = kv.Get("docker/containers/10.0.0.1:2376/web-id"); err == store.ErrKeyNotFound {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != store.ErrKeyNotFound {
+		t.Fatalf("Expected the destroyed container to be removed, got %v", err)
+	}
+}
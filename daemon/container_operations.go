@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -446,21 +447,37 @@ func (daemon *Daemon) updateContainerNetworkSettings(container *container.Contai
 		return nil
 	}
 	// Make sure to internally store the per network endpoint config by network name
-	if _, ok := container.NetworkSettings.Networks[networkName]; ok {
-		return nil
-	}
-
-	if n != nil {
+	if _, ok := container.NetworkSettings.Networks[networkName]; !ok && n != nil {
 		if nwConfig, ok := container.NetworkSettings.Networks[n.ID()]; ok {
 			container.NetworkSettings.Networks[networkName] = nwConfig
 			delete(container.NetworkSettings.Networks, n.ID())
-			return nil
+		}
+	}
+
+	// When endpoint configs are only given for additional networks, the
+	// container still joins the network it was created on.
+	if _, ok := container.NetworkSettings.Networks[networkName]; !ok {
+		container.NetworkSettings.Networks[networkName] = &network.EndpointSettings{
+			EndpointSettings: &networktypes.EndpointSettings{},
 		}
 	}
 
 	return nil
 }
 
+// networkModeName returns the name of the network of the network mode of the
+// container, as the networks of its settings are named.
+func (daemon *Daemon) networkModeName(container *container.Container) string {
+	mode := container.HostConfig.NetworkMode
+	if !mode.IsUserDefined() {
+		return mode.NetworkName()
+	}
+	if n, err := daemon.FindNetwork(mode.NetworkName()); err == nil {
+		return n.Name()
+	}
+	return mode.NetworkName()
+}
+
 func (daemon *Daemon) allocateNetwork(container *container.Container) error {
 	controller := daemon.netController
 
@@ -468,6 +485,10 @@ func (daemon *Daemon) allocateNetwork(container *container.Container) error {
 		return nil
 	}
 
+	if err := daemon.portHandoffs.takeOver(container); err != nil {
+		return err
+	}
+
 	// Cleanup any stale sandbox left over due to ungraceful daemon shutdown
 	if err := controller.SandboxDestroy(container.ID); err != nil {
 		logrus.Errorf("failed to cleanup up stale network sandbox for container %s", container.ID)
@@ -501,21 +522,37 @@ func (daemon *Daemon) allocateNetwork(container *container.Container) error {
 
 	// the intermediate map is necessary because "connectToNetwork" modifies "container.NetworkSettings.Networks"
 	networks := make(map[string]*network.EndpointSettings)
+	var names []string
+	modeNetName := daemon.networkModeName(container)
 	for n, epConf := range container.NetworkSettings.Networks {
 		if n == defaultNetName {
 			continue
 		}
 
 		networks[n] = epConf
+		if n != modeNetName {
+			names = append(names, n)
+		}
+	}
+
+	// The network of the network mode is connected next, so that it is the
+	// first network of the container when it joins several ones at create
+	// time, and the others in order.
+	sort.Strings(names)
+	if _, ok := networks[modeNetName]; ok {
+		names = append([]string{modeNetName}, names...)
 	}
 
-	for netName, epConf := range networks {
+	for _, netName := range names {
+		epConf := networks[netName]
 		cleanOperationalData(epConf)
 		if err := daemon.connectToNetwork(container, netName, epConf.EndpointSettings, updateSettings); err != nil {
 			return err
 		}
 	}
 
+	daemon.rootlessPorts.expose(container)
+
 	return container.WriteHostConfig()
 }
 
@@ -673,6 +710,16 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 	if err != nil {
 		return err
 	}
+	// The MAC address set with --mac-address, in the options built for the
+	// container, overrides the address derived from the MAC prefix of the
+	// network.
+	macOption, err := endpointMacOption(container, n)
+	if err != nil {
+		return err
+	}
+	if macOption != nil {
+		createOptions = append([]libnetwork.EndpointOption{macOption}, createOptions...)
+	}
 
 	endpointName := strings.TrimPrefix(container.Name, "/")
 	ep, err := n.CreateEndpoint(endpointName, createOptions...)
@@ -726,7 +773,9 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 
 	container.NetworkSettings.Ports = getPortMapInfo(sb)
 
+	daemon.networkUsage.touch(n.ID())
 	daemon.LogNetworkEventWithAttributes(n, "connect", map[string]string{"container": container.ID})
+	daemon.mirrorContainer(container, "connect")
 	return nil
 }
 
@@ -790,6 +839,7 @@ func (daemon *Daemon) disconnectFromNetwork(container *container.Container, n li
 	}
 
 	delete(container.NetworkSettings.Networks, n.Name())
+	daemon.networkUsage.touch(n.ID())
 
 	if daemon.clusterProvider != nil && n.Info().Dynamic() && !container.Managed {
 		if err := daemon.clusterProvider.DetachNetwork(n.Name(), container.ID); err != nil {
@@ -857,6 +907,8 @@ func (daemon *Daemon) releaseNetwork(container *container.Container) {
 		return
 	}
 
+	daemon.rootlessPorts.unexpose(container)
+
 	sid := container.NetworkSettings.SandboxID
 	settings := container.NetworkSettings.Networks
 	container.NetworkSettings.Ports = nil
@@ -889,6 +941,7 @@ func (daemon *Daemon) releaseNetwork(container *container.Container) {
 	}
 
 	for _, nw := range networks {
+		daemon.networkUsage.touch(nw.ID())
 		if daemon.clusterProvider != nil && nw.Info().Dynamic() && !container.Managed {
 			if err := daemon.clusterProvider.DetachNetwork(nw.Name(), container.ID); err != nil {
 				logrus.Warnf("error detaching from network %s: %v", nw.Name(), err)
@@ -978,6 +1031,7 @@ func (daemon *Daemon) DisconnectFromNetwork(container *container.Container, netw
 			"container": container.ID,
 		}
 		daemon.LogNetworkEventWithAttributes(n, "disconnect", attributes)
+		daemon.mirrorContainer(container, "disconnect")
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ package daemon
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -20,6 +21,8 @@ import (
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/runconfig"
+	"github.com/docker/libnetwork/resolvconf"
+	"github.com/docker/libnetwork/types"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/devices"
 	"github.com/opencontainers/runc/libcontainer/label"
@@ -107,7 +110,10 @@ func (daemon *Daemon) getIpcContainer(container *container.Container) (*containe
 		return nil, fmt.Errorf("cannot join IPC of a non running container: %s", containerID)
 	}
 	if c.IsRestarting() {
-		return nil, errContainerIsRestarting(container.ID)
+		return nil, errContainerIsRestarting(c.ID)
+	}
+	if c.HostConfig.IpcMode.IsPrivateMode() || c.HostConfig.IpcMode.IsNone() {
+		return nil, fmt.Errorf("cannot join IPC of container %s: its IPC mode is %s, use --ipc=shareable to share it", containerID, c.HostConfig.IpcMode)
 	}
 	return c, nil
 }
@@ -122,7 +128,7 @@ func (daemon *Daemon) getPidContainer(container *container.Container) (*containe
 		return nil, fmt.Errorf("cannot join PID of a non running container: %s", containerID)
 	}
 	if c.IsRestarting() {
-		return nil, errContainerIsRestarting(container.ID)
+		return nil, errContainerIsRestarting(c.ID)
 	}
 	return c, nil
 }
@@ -146,6 +152,10 @@ func (daemon *Daemon) setupIpcDirs(c *container.Container) error {
 			return fmt.Errorf("/dev/shm is not mounted, but must be for --ipc=host")
 		}
 		c.ShmPath = "/dev/shm"
+	} else if c.HostConfig.IpcMode.IsNone() {
+		// The /dev/shm of the container is a tmpfs mounted by the runtime,
+		// see createSpec, and not a directory of the daemon.
+		c.ShmPath = ""
 	} else {
 		rootUID, rootGID := daemon.GetRemappedUIDGID()
 		if !c.HasMountFor("/dev/shm") {
@@ -330,3 +340,104 @@ func enableIPOnPredefinedNetwork() bool {
 func (daemon *Daemon) isNetworkHotPluggable() bool {
 	return true
 }
+
+// reloadContainersDNS rewrites the resolv.conf file of the running containers
+// with the DNS configuration of the daemon, and emits an update event for
+// every container whose file changed.
+func (daemon *Daemon) reloadContainersDNS() {
+	for _, c := range daemon.List() {
+		if !c.IsRunning() {
+			continue
+		}
+		updated, err := daemon.updateResolvConf(c)
+		if err != nil {
+			logrus.Warnf("Failed to update resolv.conf of container %s: %v", c.ID, err)
+			continue
+		}
+		if updated {
+			daemon.LogContainerEvent(c, "update")
+		}
+	}
+}
+
+// updateResolvConf regenerates the resolv.conf file of the container the way
+// it is generated when the container starts. The file is left untouched if it
+// was modified since it was generated, or if it is not managed by the daemon
+// DNS configuration: the container uses the host or another container's
+// network stack, or the embedded DNS server of a user-defined network.
+func (daemon *Daemon) updateResolvConf(c *container.Container) (bool, error) {
+	if c.ResolvConfPath == "" || c.HostConfig.NetworkMode.IsHost() || c.HostConfig.NetworkMode.IsContainer() {
+		return false, nil
+	}
+	if c.NetworkSettings != nil {
+		for name := range c.NetworkSettings.Networks {
+			if containertypes.NetworkMode(name).IsUserDefined() {
+				return false, nil
+			}
+		}
+	}
+
+	hashFile := c.ResolvConfPath + ".hash"
+	currRC, err := resolvconf.GetSpecific(c.ResolvConfPath)
+	if err != nil {
+		return false, err
+	}
+	currHash, err := ioutil.ReadFile(hashFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if string(currHash) != currRC.Hash {
+		// The resolv.conf file was modified, keep the changes.
+		return false, nil
+	}
+
+	dns := daemon.configStore.DNS
+	if len(c.HostConfig.DNS) > 0 {
+		dns = c.HostConfig.DNS
+	}
+	dnsSearch := daemon.configStore.DNSSearch
+	if len(c.HostConfig.DNSSearch) > 0 {
+		dnsSearch = c.HostConfig.DNSSearch
+	}
+	dnsOptions := daemon.configStore.DNSOptions
+	if len(c.HostConfig.DNSOptions) > 0 {
+		dnsOptions = c.HostConfig.DNSOptions
+	}
+
+	hostRC, err := resolvconf.Get()
+	if err != nil {
+		return false, err
+	}
+
+	// The file is bind mounted in the container, it is written in place.
+	var newRC *resolvconf.File
+	if len(dns) > 0 || len(dnsSearch) > 0 || len(dnsOptions) > 0 {
+		if len(dns) == 0 {
+			dns = resolvconf.GetNameservers(hostRC.Content, types.IP)
+		}
+		if len(dnsSearch) == 0 {
+			dnsSearch = resolvconf.GetSearchDomains(hostRC.Content)
+		}
+		if len(dnsOptions) == 0 {
+			dnsOptions = resolvconf.GetOptions(hostRC.Content)
+		}
+		if newRC, err = resolvconf.Build(c.ResolvConfPath, dns, dnsSearch, dnsOptions); err != nil {
+			return false, err
+		}
+	} else {
+		if newRC, err = resolvconf.FilterResolvDNS(hostRC.Content, daemon.configStore.bridgeConfig.EnableIPv6); err != nil {
+			return false, err
+		}
+		if err := ioutil.WriteFile(c.ResolvConfPath, newRC.Content, 0644); err != nil {
+			return false, err
+		}
+	}
+
+	if newRC.Hash == currRC.Hash {
+		return false, nil
+	}
+	return true, ioutil.WriteFile(hashFile, []byte(newRC.Hash), 0644)
+}
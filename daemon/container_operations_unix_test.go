@@ -0,0 +1,63 @@
+// +build linux freebsd
+
+package daemon
+
+import (
+	"strings"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/registrar"
+	"github.com/docker/docker/pkg/truncindex"
+)
+
+func TestGetPidContainer(t *testing.T) {
+	target := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:   "5a4ff6a163ad4533d22d69a2b8960bf7fafdcba06e72d2febdba229008b0bf57",
+			Name: "main",
+		},
+	}
+	target.State = container.NewState()
+
+	store := container.NewMemoryStore()
+	store.Add(target.ID, target)
+	index := truncindex.NewTruncIndex([]string{target.ID})
+	daemon := &Daemon{
+		containers: store,
+		idIndex:    index,
+		nameIndex:  registrar.NewRegistrar(),
+	}
+	daemon.reserveName(target.ID, target.Name)
+
+	sidecar := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:         "3cdbd1aa394fd68559fd1441d6eff2ab7c1e6363582c82febfaa8045df3bd8de",
+			HostConfig: &containertypes.HostConfig{PidMode: "container:missing"},
+		},
+	}
+	if _, err := daemon.getPidContainer(sidecar); err == nil || !strings.Contains(err.Error(), "No such container") {
+		t.Fatalf("expected an error for a missing container, got %v", err)
+	}
+
+	sidecar.HostConfig.PidMode = "container:main"
+	if _, err := daemon.getPidContainer(sidecar); err == nil || !strings.Contains(err.Error(), "non running container") {
+		t.Fatalf("expected an error for a stopped container, got %v", err)
+	}
+
+	target.SetRunning(1234, true)
+	target.SetRestarting(&container.ExitStatus{})
+	if _, err := daemon.getPidContainer(sidecar); err == nil || !strings.Contains(err.Error(), target.ID) {
+		t.Fatalf("expected an error for the restarting container %s, got %v", target.ID, err)
+	}
+
+	target.SetRunning(1234, false)
+	c, err := daemon.getPidContainer(sidecar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != target {
+		t.Fatalf("expected to join the PID namespace of %s, got %s", target.ID, c.ID)
+	}
+}
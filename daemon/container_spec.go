@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/utils"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ContainerSpec returns the OCI runtime spec the container would be started
+// with, after all the changes the daemon makes to its configuration, such as
+// its mounts, devices, seccomp profile and user namespace mappings. The
+// container is not started, and it must not be running: the spec of a
+// running container is only generated when it starts. The containers whose
+// namespaces it joins must be running. The values of the variables of the
+// environment files of the container are redacted, as they are only revealed
+// by POST /envfiles/(name)/reveal.
+func (daemon *Daemon) ContainerSpec(name string) (*specs.Spec, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	if container.Running {
+		return nil, errors.NewRequestConflictError(fmt.Errorf("Container %s is running, stop it to generate its spec", name))
+	}
+	if container.RemovalInProgress || container.Dead {
+		return nil, errors.NewRequestConflictError(fmt.Errorf("Container %s is marked for removal", name))
+	}
+	if err := daemon.verifySpecNamespaces(container); err != nil {
+		return nil, err
+	}
+
+	if err := daemon.conditionalMountOnStart(container); err != nil {
+		return nil, err
+	}
+	defer daemon.releaseSpecResources(container)
+
+	s, err := daemon.createSpec(container)
+	if err != nil {
+		return nil, err
+	}
+	if err := daemon.addSpecNetworkMounts(s, container); err != nil {
+		return nil, err
+	}
+	envFileEnv, err := daemon.redactedEnvFileVariables(container)
+	if err != nil {
+		return nil, err
+	}
+	s.Process.Env = utils.ReplaceOrAppendEnvValues(s.Process.Env, envFileEnv)
+	return s, nil
+}
+
+// releaseSpecResources releases what the generation of the spec of a
+// container which is not started set up: its devices, IPC mounts, volumes
+// and root filesystem.
+func (daemon *Daemon) releaseSpecResources(container *container.Container) {
+	daemon.releaseDevices(container)
+	container.UnmountIpcMounts(detachMounted)
+	if err := container.UnmountVolumes(false, daemon.LogVolumeEvent); err != nil {
+		logrus.Warnf("%s spec: Failed to umount volumes: %v", container.ID, err)
+	}
+	if err := daemon.conditionalUnmountOnCleanup(container); err != nil {
+		logrus.Warnf("%s spec: Failed to umount the root filesystem: %v", container.ID, err)
+	}
+}
@@ -0,0 +1,92 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/volume"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// verifySpecNamespaces returns an error if the container joins the
+// namespaces of a container which is not running: the paths of these
+// namespaces are only known once that container runs.
+func (daemon *Daemon) verifySpecNamespaces(c *container.Container) error {
+	joined := map[string]string{}
+	if c.HostConfig.NetworkMode.IsContainer() {
+		joined["network"] = c.HostConfig.NetworkMode.ConnectedContainer()
+	}
+	if c.HostConfig.IpcMode.IsContainer() {
+		joined["IPC"] = c.HostConfig.IpcMode.Container()
+	}
+	if c.HostConfig.PidMode.IsContainer() {
+		joined["PID"] = c.HostConfig.PidMode.Container()
+	}
+	for ns, name := range joined {
+		target, err := daemon.GetContainer(name)
+		if err != nil {
+			return err
+		}
+		if !target.IsRunning() {
+			return errors.NewRequestConflictError(fmt.Errorf("Container %s joins the %s namespace of container %s, which is not running: start it to generate the spec", c.Name, ns, name))
+		}
+	}
+	return nil
+}
+
+// addSpecNetworkMounts adds to the spec the bind mounts of the hosts,
+// hostname and resolv.conf files of a container which were left out because
+// the files are only created when the container starts.
+func (daemon *Daemon) addSpecNetworkMounts(s *specs.Spec, c *container.Container) error {
+	if c.Config.NetworkDisabled {
+		return nil
+	}
+	paths := map[string]string{
+		"/etc/hosts":       c.HostsPath,
+		"/etc/hostname":    c.HostnamePath,
+		"/etc/resolv.conf": c.ResolvConfPath,
+	}
+	if c.HostConfig.NetworkMode.IsContainer() {
+		nc, err := daemon.getNetworkedContainer(c.ID, c.HostConfig.NetworkMode.ConnectedContainer())
+		if err != nil {
+			return err
+		}
+		paths["/etc/hosts"] = nc.HostsPath
+		paths["/etc/hostname"] = nc.HostnamePath
+		paths["/etc/resolv.conf"] = nc.ResolvConfPath
+	} else {
+		for dest, name := range map[string]string{"/etc/hosts": "hosts", "/etc/hostname": "hostname", "/etc/resolv.conf": "resolv.conf"} {
+			if paths[dest] != "" {
+				continue
+			}
+			p, err := c.GetRootResourcePath(name)
+			if err != nil {
+				return err
+			}
+			paths[dest] = p
+		}
+	}
+
+	for _, dest := range []string{"/etc/hostname", "/etc/hosts", "/etc/resolv.conf"} {
+		if paths[dest] == "" || c.HasMountFor(dest) || hasSpecMount(s, dest) {
+			continue
+		}
+		opts := []string{"rbind"}
+		if c.HostConfig.ReadonlyRootfs {
+			opts = append(opts, "ro")
+		}
+		opts = append(opts, string(volume.DefaultPropagationMode))
+		s.Mounts = append(s.Mounts, specs.Mount{Destination: dest, Source: paths[dest], Type: "bind", Options: opts})
+	}
+	return nil
+}
+
+func hasSpecMount(s *specs.Spec, dest string) bool {
+	for _, m := range s.Mounts {
+		if m.Destination == dest {
+			return true
+		}
+	}
+	return false
+}
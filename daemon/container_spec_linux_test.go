@@ -0,0 +1,48 @@
+package daemon
+
+import (
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/volume"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestAddSpecNetworkMounts(t *testing.T) {
+	daemon := &Daemon{}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			Root:       "/var/lib/docker/containers/abc",
+			Config:     &containertypes.Config{},
+			HostConfig: &containertypes.HostConfig{NetworkMode: "bridge"},
+			MountPoints: map[string]*volume.MountPoint{
+				"/etc/resolv.conf": {Destination: "/etc/resolv.conf", Source: "/srv/resolv.conf"},
+			},
+		},
+	}
+	s := &specs.Spec{Mounts: []specs.Mount{{Destination: "/etc/hostname", Source: "/existing", Type: "bind"}}}
+
+	if err := daemon.addSpecNetworkMounts(s, c); err != nil {
+		t.Fatal(err)
+	}
+	// The mount of /etc/hostname is already in the spec, and /etc/resolv.conf
+	// is mounted by the user.
+	if len(s.Mounts) != 2 {
+		t.Fatalf("Expected a single mount to be added, got %+v", s.Mounts)
+	}
+	m := s.Mounts[1]
+	if m.Destination != "/etc/hosts" || m.Source != filepath.Join(c.Root, "hosts") || m.Type != "bind" {
+		t.Fatalf("Unexpected mount of the hosts file: %+v", m)
+	}
+
+	c.Config.NetworkDisabled = true
+	s = &specs.Spec{}
+	if err := daemon.addSpecNetworkMounts(s, c); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Mounts) != 0 {
+		t.Fatalf("Expected no mounts for a container without network, got %+v", s.Mounts)
+	}
+}
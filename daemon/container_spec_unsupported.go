@@ -0,0 +1,16 @@
+// +build !linux
+
+package daemon
+
+import (
+	"github.com/docker/docker/container"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func (daemon *Daemon) verifySpecNamespaces(c *container.Container) error {
+	return nil
+}
+
+func (daemon *Daemon) addSpecNetworkMounts(s *specs.Spec, c *container.Container) error {
+	return nil
+}
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/go-units"
+)
+
+// coreDumpsDir is the directory of the container root where the core dumps
+// of the processes of the container are captured. It is removed with the
+// container.
+const coreDumpsDir = "cores"
+
+// coreDumpsPath returns the directory where the core dumps of the container
+// are captured.
+func coreDumpsPath(c *container.Container) (string, error) {
+	return c.GetRootResourcePath(coreDumpsDir)
+}
+
+// parseCoreDumpSize parses a size of core dumps such as 512m, an empty
+// size meaning no limit.
+func parseCoreDumpSize(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	size, err := units.RAMInBytes(s)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid core dump size %q", s)
+	}
+	return size, nil
+}
+
+type byCoreDumpCreated []types.CoreDump
+
+func (r byCoreDumpCreated) Len() int      { return len(r) }
+func (r byCoreDumpCreated) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r byCoreDumpCreated) Less(i, j int) bool {
+	if !r[i].Created.Equal(r[j].Created) {
+		return r[i].Created.Before(r[j].Created)
+	}
+	return r[i].Name < r[j].Name
+}
+
+// listCoreDumps returns the core dumps in a directory, oldest first. The
+// entries other than regular files, such as the symbolic links the
+// processes of the container could create, are ignored.
+func listCoreDumps(dir string) ([]types.CoreDump, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	cores := []types.CoreDump{}
+	for _, fi := range entries {
+		if !fi.Mode().IsRegular() {
+			continue
+		}
+		cores = append(cores, types.CoreDump{
+			Name:    fi.Name(),
+			Size:    fi.Size(),
+			Created: fi.ModTime().UTC(),
+		})
+	}
+	sort.Sort(byCoreDumpCreated(cores))
+	return cores, nil
+}
+
+// pruneCoreDumps removes the oldest core dumps of a directory until their
+// total size is at most maxTotal.
+func pruneCoreDumps(dir string, maxTotal int64) error {
+	cores, err := listCoreDumps(dir)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, core := range cores {
+		total += core.Size
+	}
+	for _, core := range cores {
+		if total <= maxTotal {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, core.Name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= core.Size
+	}
+	return nil
+}
+
+// ContainerCoreDumps returns the core dumps captured for a container,
+// oldest first.
+func (daemon *Daemon) ContainerCoreDumps(name string) ([]types.CoreDump, error) {
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := coreDumpsPath(c)
+	if err != nil {
+		return nil, err
+	}
+	return listCoreDumps(dir)
+}
+
+// ContainerCoreDump writes a core dump captured for a container to out.
+func (daemon *Daemon) ContainerCoreDump(name, core string, out io.Writer) error {
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	if core == "" || core == "." || core == ".." || strings.ContainsAny(core, `/\`) {
+		return errors.NewBadRequestError(fmt.Errorf("invalid core dump name %q", core))
+	}
+	dir, err := coreDumpsPath(c)
+	if err != nil {
+		return err
+	}
+	f, err := openCoreDump(filepath.Join(dir, core))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewRequestNotFoundError(fmt.Errorf("No such core dump for container %s: %s", name, core))
+		}
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(out, f); err != nil {
+		return fmt.Errorf("Error reading core dump %s of container %s: %v", core, name, err)
+	}
+	return nil
+}
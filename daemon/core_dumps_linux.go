@@ -0,0 +1,179 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/volume"
+	"github.com/opencontainers/runc/libcontainer/label"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// corePatternFile holds the kernel.core_pattern of the host, which names the
+// core dumps of the processes of all the containers.
+const corePatternFile = "/proc/sys/kernel/core_pattern"
+
+// systemDirs are the directories of the containers which hold other files
+// than core dumps. The directory of a kernel.core_pattern cannot be one of
+// them, as the directory capturing the core dumps is mounted over it.
+var systemDirs = map[string]bool{
+	"/bin": true, "/boot": true, "/dev": true, "/dev/shm": true, "/etc": true,
+	"/home": true, "/lib": true, "/lib64": true, "/media": true, "/mnt": true,
+	"/opt": true, "/proc": true, "/root": true, "/run": true, "/sbin": true,
+	"/srv": true, "/sys": true, "/tmp": true, "/usr": true, "/var": true,
+	"/var/lib": true, "/var/log": true, "/var/run": true, "/var/tmp": true,
+}
+
+// coreDumpsTarget returns the directory where the kernel writes the core
+// dumps according to a kernel.core_pattern, which is resolved in the mount
+// namespace of the dumping process. The core dumps can only be captured if
+// the pattern is an absolute path in a fixed directory dedicated to them.
+func coreDumpsTarget(pattern string) (string, error) {
+	pattern = strings.TrimSpace(pattern)
+	if strings.HasPrefix(pattern, "|") {
+		return "", fmt.Errorf("the core dumps cannot be captured: kernel.core_pattern %q pipes them to a program", pattern)
+	}
+	if !filepath.IsAbs(pattern) {
+		return "", fmt.Errorf("the core dumps cannot be captured: kernel.core_pattern %q is not an absolute path, such as /cores/core.%%e.%%p", pattern)
+	}
+	dir := filepath.Dir(pattern)
+	if dir == "/" || strings.Contains(dir, "%") {
+		return "", fmt.Errorf("the core dumps cannot be captured: kernel.core_pattern %q is not in a fixed directory other than /, such as /cores/core.%%e.%%p", pattern)
+	}
+	if systemDirs[dir] {
+		return "", fmt.Errorf("the core dumps cannot be captured: kernel.core_pattern %q is not in a directory dedicated to them, such as /cores/core.%%e.%%p", pattern)
+	}
+	return dir, nil
+}
+
+// verifyCoreDumpsTarget checks that the directory of the core dumps is
+// missing or empty in the filesystem of the container, so that mounting the
+// directory capturing them does not hide files of the container.
+func verifyCoreDumpsTarget(c *container.Container, target string) error {
+	path, err := c.GetResourcePath(target)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Readdirnames(1); err != io.EOF {
+		return fmt.Errorf("the core dumps cannot be captured: %s is not an empty directory of the container", target)
+	}
+	return nil
+}
+
+// coreDumpMounts returns the mount of the directory capturing the core dumps
+// of the container over the directory of the kernel.core_pattern, if the
+// container captures them.
+func (daemon *Daemon) coreDumpMounts(c *container.Container) ([]container.Mount, error) {
+	if !c.HostConfig.CoreDumps.IsCapture() {
+		return nil, nil
+	}
+	pattern, err := ioutil.ReadFile(corePatternFile)
+	if err != nil {
+		return nil, err
+	}
+	target, err := coreDumpsTarget(string(pattern))
+	if err != nil {
+		return nil, err
+	}
+	if c.HasMountFor(target) {
+		return nil, fmt.Errorf("the core dumps cannot be captured: %s is already a mount of the container", target)
+	}
+	if err := verifyCoreDumpsTarget(c, target); err != nil {
+		return nil, err
+	}
+	dir, err := coreDumpsPath(c)
+	if err != nil {
+		return nil, err
+	}
+	rootUID, rootGID := daemon.GetRemappedUIDGID()
+	if err := idtools.MkdirAllAs(dir, 0700, rootUID, rootGID); err != nil {
+		return nil, err
+	}
+	// The kernel writes the core dumps as the user of the dumping process.
+	if err := os.Chmod(dir, 0777|os.ModeSticky); err != nil {
+		return nil, err
+	}
+	label.SetFileLabel(dir, c.MountLabel)
+	return []container.Mount{{
+		Source:      dir,
+		Destination: target,
+		Writable:    true,
+		Propagation: string(volume.DefaultPropagationMode),
+	}}, nil
+}
+
+// setCoreDumpRlimit limits the size of the core dumps of the processes of
+// the container, which is 0 if the container discards them. The ulimit of
+// the container is kept if the size is not limited.
+func setCoreDumpRlimit(s *specs.Spec, c *container.Container) {
+	var limit uint64
+	switch {
+	case c.HostConfig.CoreDumps.IsDiscard():
+		limit = 0
+	case c.HostConfig.CoreDumpMaxSize > 0:
+		limit = uint64(c.HostConfig.CoreDumpMaxSize)
+	default:
+		return
+	}
+	for i, rlimit := range s.Process.Rlimits {
+		if rlimit.Type == "RLIMIT_CORE" {
+			s.Process.Rlimits[i].Soft, s.Process.Rlimits[i].Hard = limit, limit
+			return
+		}
+	}
+	s.Process.Rlimits = append(s.Process.Rlimits, specs.Rlimit{Type: "RLIMIT_CORE", Soft: limit, Hard: limit})
+}
+
+// pruneContainerCoreDumps removes the oldest core dumps captured for the
+// container beyond the total size set with --core-dumps-max-total-size.
+func (daemon *Daemon) pruneContainerCoreDumps(c *container.Container) {
+	maxTotal, err := parseCoreDumpSize(daemon.configStore.CoreDumpsMaxTotal)
+	if err != nil || maxTotal == 0 || !c.HostConfig.CoreDumps.IsCapture() {
+		return
+	}
+	dir, err := coreDumpsPath(c)
+	if err != nil {
+		return
+	}
+	if err := pruneCoreDumps(dir, maxTotal); err != nil {
+		logrus.Warnf("Failed to prune the core dumps of container %s: %v", c.ID, err)
+	}
+}
+
+// openCoreDump opens a core dump without following symbolic links, as the
+// directory of the core dumps is writable by the processes of the container.
+func openCoreDump(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NOFOLLOW|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		if pe, ok := err.(*os.PathError); ok && pe.Err == syscall.ELOOP {
+			return nil, os.ErrNotExist
+		}
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		f.Close()
+		return nil, os.ErrNotExist
+	}
+	return f, nil
+}
@@ -0,0 +1,114 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestCoreDumpsTarget(t *testing.T) {
+	valid := map[string]string{
+		"/cores/core.%e.%p\n":   "/cores",
+		"/var/crash/core.%e.%t": "/var/crash",
+	}
+	for pattern, expected := range valid {
+		target, err := coreDumpsTarget(pattern)
+		if err != nil || target != expected {
+			t.Fatalf("%q: expected %s, got %s, %v", pattern, expected, target, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"|/usr/lib/systemd/systemd-coredump %P": "pipes them to a program",
+		"core":                                  "not an absolute path",
+		"/core.%e":                              "not in a fixed directory",
+		"/cores/%h/core.%e":                     "not in a fixed directory",
+		"/tmp/core.%e.%p":                       "not in a directory dedicated to them",
+	}
+	for pattern, expected := range invalid {
+		if _, err := coreDumpsTarget(pattern); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%q: expected error containing %q, got %v", pattern, expected, err)
+		}
+	}
+}
+
+func TestSetCoreDumpRlimit(t *testing.T) {
+	c := &container.Container{HostConfig: &containertypes.HostConfig{CoreDumps: "capture"}}
+	s := &specs.Spec{Process: specs.Process{Rlimits: []specs.Rlimit{{Type: "RLIMIT_CORE", Soft: 10, Hard: 20}}}}
+	setCoreDumpRlimit(s, c)
+	if s.Process.Rlimits[0].Soft != 10 || s.Process.Rlimits[0].Hard != 20 {
+		t.Fatalf("expected the ulimit of the container to be kept, got %v", s.Process.Rlimits)
+	}
+
+	c.HostConfig.CoreDumpMaxSize = 1024
+	setCoreDumpRlimit(s, c)
+	if len(s.Process.Rlimits) != 1 || s.Process.Rlimits[0].Soft != 1024 || s.Process.Rlimits[0].Hard != 1024 {
+		t.Fatalf("expected the core dumps to be limited to 1024 bytes, got %v", s.Process.Rlimits)
+	}
+
+	c.HostConfig = &containertypes.HostConfig{CoreDumps: "discard"}
+	s = &specs.Spec{}
+	setCoreDumpRlimit(s, c)
+	if len(s.Process.Rlimits) != 1 || s.Process.Rlimits[0].Type != "RLIMIT_CORE" || s.Process.Rlimits[0].Hard != 0 {
+		t.Fatalf("expected the core dumps to be discarded, got %v", s.Process.Rlimits)
+	}
+}
+
+func TestOpenCoreDumpRefusesSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-dumps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "core.app.1"), []byte("ELF"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc/passwd", filepath.Join(dir, "core.link")); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := openCoreDump(filepath.Join(dir, "core.app.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if _, err := openCoreDump(filepath.Join(dir, "core.link")); !os.IsNotExist(err) {
+		t.Fatalf("expected a symbolic link to be refused, got %v", err)
+	}
+	if _, err := openCoreDump(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected a directory to be refused, got %v", err)
+	}
+}
+
+func TestVerifyCoreDumpsTarget(t *testing.T) {
+	root, err := ioutil.TempDir("", "core-dumps-target")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	c := &container.Container{CommonContainer: container.CommonContainer{BaseFS: root}}
+
+	if err := verifyCoreDumpsTarget(c, "/cores"); err != nil {
+		t.Fatalf("expected a missing directory to be accepted, got %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "cores"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCoreDumpsTarget(c, "/cores"); err != nil {
+		t.Fatalf("expected an empty directory to be accepted, got %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "cores", "data"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCoreDumpsTarget(c, "/cores"); err == nil || !strings.Contains(err.Error(), "not an empty directory") {
+		t.Fatalf("expected an error for a directory with files, got %v", err)
+	}
+}
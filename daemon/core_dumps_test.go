@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCoreDump(t *testing.T, dir, name string, size int, created time.Time) {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, make([]byte, size), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, created, created); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListCoreDumps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-dumps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cores, err := listCoreDumps(filepath.Join(dir, "missing"))
+	if err != nil || len(cores) != 0 {
+		t.Fatalf("expected no core dumps for a missing directory, got %v, %v", cores, err)
+	}
+
+	now := time.Now()
+	writeCoreDump(t, dir, "core.app.2", 20, now)
+	writeCoreDump(t, dir, "core.app.1", 10, now.Add(-time.Hour))
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	cores, err = listCoreDumps(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cores) != 2 || cores[0].Name != "core.app.1" || cores[0].Size != 10 || cores[1].Name != "core.app.2" {
+		t.Fatalf("expected the core dumps oldest first, got %v", cores)
+	}
+}
+
+func TestPruneCoreDumps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-dumps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	writeCoreDump(t, dir, "core.app.1", 30, now.Add(-2*time.Hour))
+	writeCoreDump(t, dir, "core.app.2", 30, now.Add(-time.Hour))
+	writeCoreDump(t, dir, "core.app.3", 30, now)
+
+	if err := pruneCoreDumps(dir, 70); err != nil {
+		t.Fatal(err)
+	}
+	cores, err := listCoreDumps(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cores) != 2 || cores[0].Name != "core.app.2" || cores[1].Name != "core.app.3" {
+		t.Fatalf("expected the oldest core dump to be removed, got %v", cores)
+	}
+}
+
+func TestParseCoreDumpSize(t *testing.T) {
+	valid := map[string]int64{
+		"":     0,
+		"512m": 512 * 1024 * 1024,
+		"1g":   1024 * 1024 * 1024,
+	}
+	for value, expected := range valid {
+		size, err := parseCoreDumpSize(value)
+		if err != nil || size != expected {
+			t.Fatalf("%q: expected %d, got %d, %v", value, expected, size, err)
+		}
+	}
+	if _, err := parseCoreDumpSize("big"); err == nil {
+		t.Fatal("expected an error for an invalid size")
+	}
+}
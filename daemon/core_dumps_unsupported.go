@@ -0,0 +1,26 @@
+// +build !linux
+
+package daemon
+
+import (
+	"os"
+
+	"github.com/docker/docker/container"
+)
+
+// pruneContainerCoreDumps is a no-op, as the core dumps are only captured on
+// Linux.
+func (daemon *Daemon) pruneContainerCoreDumps(c *container.Container) {
+}
+
+// openCoreDump opens a core dump, which must be a regular file.
+func openCoreDump(path string) (*os.File, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, os.ErrNotExist
+	}
+	return os.Open(path)
+}
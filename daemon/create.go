@@ -3,6 +3,7 @@ package daemon
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -22,29 +23,67 @@ import (
 
 // CreateManagedContainer creates a container that is managed by a Service
 func (daemon *Daemon) CreateManagedContainer(params types.ContainerCreateConfig, validateHostname bool) (types.ContainerCreateResponse, error) {
-	return daemon.containerCreate(params, true, validateHostname)
+	return daemon.containerCreate(params, true, validateHostname, nil)
 }
 
 // ContainerCreate creates a regular container
 func (daemon *Daemon) ContainerCreate(params types.ContainerCreateConfig, validateHostname bool) (types.ContainerCreateResponse, error) {
-	return daemon.containerCreate(params, false, validateHostname)
+	return daemon.containerCreate(params, false, validateHostname, nil)
 }
 
-func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, managed bool, validateHostname bool) (types.ContainerCreateResponse, error) {
+// reservedLabels are the labels of the containers which are only set by the
+// daemon, with the labels argument of containerCreate: they hold the
+// membership of the containers in the pods and the jobs.
+var reservedLabels = []string{types.ContainerPodLabel, types.ContainerPodIndexLabel, types.ContainerJobLabel}
+
+// verifyReservedLabels returns an error if labels has a reserved label.
+func verifyReservedLabels(labels map[string]string) error {
+	for _, l := range reservedLabels {
+		if _, ok := labels[l]; ok {
+			return fmt.Errorf("label %s is reserved", l)
+		}
+	}
+	return nil
+}
+
+// containerCreate creates a container with the reserved labels given in
+// labels. The reserved labels of the configuration of the image are not
+// inherited.
+func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, managed bool, validateHostname bool, labels map[string]string) (types.ContainerCreateResponse, error) {
 	if params.Config == nil {
 		return types.ContainerCreateResponse{}, fmt.Errorf("Config cannot be empty in order to create a container")
 	}
 
+	if err := verifyReservedLabels(params.Config.Labels); err != nil {
+		return types.ContainerCreateResponse{}, errors.NewBadRequestError(err)
+	}
+
+	if err := daemon.checkMaintenanceCreate(); err != nil {
+		return types.ContainerCreateResponse{}, err
+	}
+
 	warnings, err := daemon.verifyContainerSettings(params.HostConfig, params.Config, false, validateHostname)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, err
 	}
 
-	err = daemon.verifyNetworkingConfig(params.NetworkingConfig)
+	if err := verifyOomKillDisable(params.HostConfig, params.ForceOomKillDisable); err != nil {
+		return types.ContainerCreateResponse{Warnings: warnings}, err
+	}
+
+	err = daemon.verifyNetworkingConfig(params.NetworkingConfig, params.HostConfig)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, err
 	}
 
+	if err := daemon.verifyDependencies(params.Name, params.HostConfig); err != nil {
+		return types.ContainerCreateResponse{Warnings: warnings}, err
+	}
+
+	if err := daemon.verifyEnvFiles(params.HostConfig); err != nil {
+		return types.ContainerCreateResponse{Warnings: warnings}, err
+	}
+
 	if params.HostConfig == nil {
 		params.HostConfig = &containertypes.HostConfig{}
 	}
@@ -53,7 +92,7 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 		return types.ContainerCreateResponse{Warnings: warnings}, err
 	}
 
-	container, err := daemon.create(params, managed)
+	container, err := daemon.create(params, managed, labels)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, daemon.imageNotExistToErrcode(err)
 	}
@@ -62,7 +101,7 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 }
 
 // Create creates a new container from the given configuration with a given name.
-func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (retC *container.Container, retErr error) {
+func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool, labels map[string]string) (retC *container.Container, retErr error) {
 	var (
 		container *container.Container
 		img       *image.Image
@@ -81,12 +120,25 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 	if err := daemon.mergeAndVerifyConfig(params.Config, img); err != nil {
 		return nil, err
 	}
+	for _, l := range reservedLabels {
+		delete(params.Config.Labels, l)
+	}
+	if len(labels) > 0 {
+		merged := make(map[string]string, len(params.Config.Labels)+len(labels))
+		for k, v := range params.Config.Labels {
+			merged[k] = v
+		}
+		for k, v := range labels {
+			merged[k] = v
+		}
+		params.Config.Labels = merged
+	}
 
 	if err := daemon.mergeAndVerifyLogConfig(&params.HostConfig.LogConfig); err != nil {
 		return nil, err
 	}
 
-	if container, err = daemon.newContainer(params.Name, params.Config, imgID, managed); err != nil {
+	if container, err = daemon.newContainer(params.Name, params.Config, params.HostConfig, imgID, managed); err != nil {
 		return nil, err
 	}
 	defer func() {
@@ -249,34 +301,52 @@ func (daemon *Daemon) mergeAndVerifyConfig(config *containertypes.Config, img *i
 	return nil
 }
 
-// Checks if the client set configurations for more than one network while creating a container
+// verifyOomKillDisable checks that the OOM killer is only disabled for the
+// containers with a memory limit, unless it is forced, as these containers
+// could exhaust the memory of the host.
+func verifyOomKillDisable(hostConfig *containertypes.HostConfig, force bool) error {
+	if hostConfig == nil || hostConfig.OomKillDisable == nil || !*hostConfig.OomKillDisable {
+		return nil
+	}
+	if hostConfig.Memory == 0 && !force {
+		return errors.NewBadRequestError(fmt.Errorf("Disabling the OOM killer requires a memory limit, unless ForceOomKillDisable is set"))
+	}
+	return nil
+}
+
+// Checks that the network mode allows the container to be connected to all the
+// networks the client set configurations for while creating a container
 // Also checks if the IPAMConfig is valid
-func (daemon *Daemon) verifyNetworkingConfig(nwConfig *networktypes.NetworkingConfig) error {
+func (daemon *Daemon) verifyNetworkingConfig(nwConfig *networktypes.NetworkingConfig, hostConfig *containertypes.HostConfig) error {
 	if nwConfig == nil || len(nwConfig.EndpointsConfig) == 0 {
 		return nil
 	}
-	if len(nwConfig.EndpointsConfig) == 1 {
-		for _, v := range nwConfig.EndpointsConfig {
-			if v != nil && v.IPAMConfig != nil {
-				if v.IPAMConfig.IPv4Address != "" && net.ParseIP(v.IPAMConfig.IPv4Address).To4() == nil {
-					return errors.NewBadRequestError(fmt.Errorf("invalid IPv4 address: %s", v.IPAMConfig.IPv4Address))
-				}
-				if v.IPAMConfig.IPv6Address != "" {
-					n := net.ParseIP(v.IPAMConfig.IPv6Address)
-					// if the address is an invalid network address (ParseIP == nil) or if it is
-					// an IPv4 address (To4() != nil), then it is an invalid IPv6 address
-					if n == nil || n.To4() != nil {
-						return errors.NewBadRequestError(fmt.Errorf("invalid IPv6 address: %s", v.IPAMConfig.IPv6Address))
-					}
-				}
+	if len(nwConfig.EndpointsConfig) > 1 && hostConfig != nil {
+		mode := hostConfig.NetworkMode
+		if mode.IsHost() || mode.IsNone() || mode.IsContainer() {
+			l := make([]string, 0, len(nwConfig.EndpointsConfig))
+			for k := range nwConfig.EndpointsConfig {
+				l = append(l, k)
 			}
+			sort.Strings(l)
+			err := fmt.Errorf("Container in network mode %s cannot be connected to network endpoints: %s", mode, strings.Join(l, ", "))
+			return errors.NewBadRequestError(err)
 		}
-		return nil
 	}
-	l := make([]string, 0, len(nwConfig.EndpointsConfig))
-	for k := range nwConfig.EndpointsConfig {
-		l = append(l, k)
+	for _, v := range nwConfig.EndpointsConfig {
+		if v != nil && v.IPAMConfig != nil {
+			if v.IPAMConfig.IPv4Address != "" && net.ParseIP(v.IPAMConfig.IPv4Address).To4() == nil {
+				return errors.NewBadRequestError(fmt.Errorf("invalid IPv4 address: %s", v.IPAMConfig.IPv4Address))
+			}
+			if v.IPAMConfig.IPv6Address != "" {
+				n := net.ParseIP(v.IPAMConfig.IPv6Address)
+				// if the address is an invalid network address (ParseIP == nil) or if it is
+				// an IPv4 address (To4() != nil), then it is an invalid IPv6 address
+				if n == nil || n.To4() != nil {
+					return errors.NewBadRequestError(fmt.Errorf("invalid IPv6 address: %s", v.IPAMConfig.IPv6Address))
+				}
+			}
+		}
 	}
-	err := fmt.Errorf("Container cannot be connected to network endpoints: %s", strings.Join(l, ", "))
-	return errors.NewBadRequestError(err)
+	return nil
 }
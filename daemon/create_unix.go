@@ -12,6 +12,7 @@ import (
 	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
 	"github.com/opencontainers/runc/libcontainer/label"
 )
 
@@ -60,22 +61,31 @@ func (daemon *Daemon) createContainerPlatformSpecificSettings(container *contain
 	return daemon.populateVolumes(container)
 }
 
-// populateVolumes copies data from the container's rootfs into the volume for non-binds.
+// populateVolumes copies data from the container's rootfs into the volume for non-binds,
+// and records the result on the mount points.
 // this is only called when the container is created.
 func (daemon *Daemon) populateVolumes(c *container.Container) error {
 	for _, mnt := range c.MountPoints {
-		if mnt.Volume == nil {
+		if mnt.Volume == nil || mnt.Type != mounttypes.TypeVolume {
 			continue
 		}
 
-		if mnt.Type != mounttypes.TypeVolume || !mnt.CopyData {
+		if !mnt.CopyData {
+			if mnt.Spec.VolumeOptions != nil && mnt.Spec.VolumeOptions.NoCopy {
+				mnt.CopyResult = volume.CopyResultDisabled
+			}
 			continue
 		}
 
 		logrus.Debugf("copying image data from %s:%s, to %s", c.ID, mnt.Destination, mnt.Name)
-		if err := c.CopyImagePathContent(mnt.Volume, mnt.Destination); err != nil {
+		copied, err := c.CopyImagePathContent(mnt.Volume, mnt.Destination)
+		if err != nil {
 			return err
 		}
+		mnt.CopyResult = volume.CopyResultSkipped
+		if copied {
+			mnt.CopyResult = volume.CopyResultCopied
+		}
 	}
 	return nil
 }
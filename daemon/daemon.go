@@ -15,6 +15,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -26,17 +27,24 @@ import (
 	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/envfiles"
 	"github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/daemon/helpers"
+	"github.com/docker/docker/daemon/jobs"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/libnetwork/cluster"
 	// register graph drivers
 	_ "github.com/docker/docker/daemon/graphdriver/register"
+	"github.com/docker/docker/distribution"
 	dmetadata "github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/libcontainerd"
+	overlaymigrate "github.com/docker/docker/migrate/overlay"
 	"github.com/docker/docker/migrate/v1"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/graphdb"
 	"github.com/docker/docker/pkg/idtools"
@@ -68,6 +76,14 @@ var (
 	errSystemNotSupported = fmt.Errorf("The Docker daemon is not supported on this platform.")
 )
 
+// shutdownParallelLimit is the maximum number of containers stopped at the
+// same time when the daemon shuts down.
+const shutdownParallelLimit = 32
+
+// shutdownGraceTimeout is the time (in seconds) given to Shutdown on top of
+// the stop timeout of the containers, to clean up after them.
+const shutdownGraceTimeout = 5
+
 // Daemon holds information about the Docker daemon.
 type Daemon struct {
 	ID                        string
@@ -78,6 +94,7 @@ type Daemon struct {
 	downloadManager           *xfer.LayerDownloadManager
 	uploadManager             *xfer.LayerUploadManager
 	distributionMetadataStore dmetadata.Store
+	partialDownloadsDir       string
 	trustKey                  libtrust.PrivateKey
 	idIndex                   *truncindex.TruncIndex
 	configStore               *Config
@@ -88,14 +105,33 @@ type Daemon struct {
 	netController             libnetwork.NetworkController
 	volumes                   *store.VolumeStore
 	discoveryWatcher          discoveryReloader
+	containerMirror           *containerMirror
+	maintenance               maintenanceState
+	filesystemUsage           filesystemUsageCache
+	rwMounts                  mountTracker
+	leakedMountsCache         leakedMountsCache
+	autoheal                  autohealer
+	runtimeLimits             runtimeLimiter
+	portHandoffs              portHandoffs
+	resourceAccounting        resourceAccounting
+	portRulesLock             sync.Mutex
+	podsLock                  sync.Mutex
+	jobStore                  *jobs.Store
+	envFiles                  *envfiles.Store
+	jobSchedules              jobScheduler
+	jobsLock                  sync.Mutex
 	root                      string
 	seccompEnabled            bool
+	seccompProfile            []byte
 	shutdown                  bool
 	uidMaps                   []idtools.IDMap
 	gidMaps                   []idtools.IDMap
 	layerStore                layer.Store
 	imageStore                image.Store
 	pluginStore               *pluginstore.Store
+	helpers                   *helpers.Store
+	networkUsage              *networkUsage
+	rootlessPorts             *rootlessPorts
 	nameIndex                 *registrar.Registrar
 	linkIndex                 *linkIndex
 	containerd                libcontainerd.Client
@@ -296,6 +332,7 @@ func (daemon *Daemon) restore() error {
 					}
 				}
 			}
+			daemon.waitForDependencies(c, restartContainers, dependencyTimeout)
 
 			// Make sure networks are available before starting
 			daemon.waitForNetworks(c)
@@ -462,6 +499,14 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
+	// The zstd layers are compressed and decompressed by the zstd command.
+	if err := archive.ZstdAvailable(); err != nil {
+		if config.PushCompression == "zstd" {
+			return nil, fmt.Errorf("invalid push compression: %v", err)
+		}
+		logrus.Warnf("zstd layers cannot be pulled, loaded or pushed: %v", err)
+	}
+
 	// Do we have a disabled network?
 	config.DisableBridge = isBridgeNetworkDisabled(config)
 
@@ -541,6 +586,9 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	}
 
 	installDefaultAppArmorProfile()
+	if err := verifyDefaultAppArmorProfile(config); err != nil {
+		return nil, err
+	}
 	daemonRepo := filepath.Join(config.Root, "containers")
 	if err := idtools.MkdirAllAs(daemonRepo, 0700, rootUID, rootGID); err != nil && !os.IsExist(err) {
 		return nil, err
@@ -553,6 +601,46 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 
 	d.pluginStore = pluginstore.NewStore(config.Root)
 
+	d.helpers, err = helpers.New(filepath.Join(config.Root, "helpers"))
+	if err != nil {
+		return nil, err
+	}
+	d.installHelpers()
+
+	d.jobStore, err = jobs.New(filepath.Join(config.Root, "jobs"))
+	if err != nil {
+		return nil, err
+	}
+
+	d.envFiles, err = envfiles.New(filepath.Join(config.Root, "envfiles"))
+	if err != nil {
+		return nil, err
+	}
+
+	d.networkUsage, err = newNetworkUsage(filepath.Join(config.Root, "network", "usage.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	d.rootlessPorts, err = newRootlessPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	// The images and containers of the overlay storage driver are migrated
+	// when switching to overlay2, if requested.
+	if driverName == "overlay2" {
+		if config.MigrateOverlay {
+			if err := overlaymigrate.Migrate(config.Root, config.GraphOptions, uidMaps, gidMaps); err != nil {
+				logrus.Errorf("Migration from the overlay storage driver to overlay2 failed: %v. The images and containers of the overlay storage driver are left in place, and can be used again by starting the daemon with --storage-driver=overlay.", err)
+			}
+		} else if pending, err := overlaymigrate.Pending(config.Root); err != nil {
+			logrus.Warnf("Could not check for images of the overlay storage driver: %v", err)
+		} else if pending {
+			logrus.Warn("The images and containers of the overlay storage driver are not available with overlay2. Once the overlay containers are stopped, restart the daemon with --migrate-overlay to migrate them, which copies their layers.")
+		}
+	}
+
 	d.layerStore, err = layer.NewStoreFromOptions(layer.StoreOptions{
 		StorePath:                 config.Root,
 		MetadataStorePathTemplate: filepath.Join(config.Root, "image", "%s", "layerdb"),
@@ -576,6 +664,13 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 
 	logrus.Debugf("Max Concurrent Downloads: %d", *config.MaxConcurrentDownloads)
 	d.downloadManager = xfer.NewLayerDownloadManager(d.layerStore, *config.MaxConcurrentDownloads)
+	logrus.Debugf("Max Download Attempts: %d", *config.MaxDownloadAttempts)
+	d.downloadManager.SetMaxDownloadAttempts(*config.MaxDownloadAttempts)
+	downloadLimits, err := registryDownloadLimits(config.Limits)
+	if err != nil {
+		return nil, err
+	}
+	d.downloadManager.SetRegistryLimits(downloadLimits)
 	logrus.Debugf("Max Concurrent Uploads: %d", *config.MaxConcurrentUploads)
 	d.uploadManager = xfer.NewLayerUploadManager(*config.MaxConcurrentUploads)
 
@@ -611,6 +706,12 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
+	// The partial downloads of the layers are resumed by the next pull.
+	partialDownloadsDir := filepath.Join(imageRoot, "downloads")
+	if err := distribution.RemoveStalePartialDownloads(partialDownloadsDir); err != nil {
+		logrus.Warnf("Failed to remove stale partial downloads: %v", err)
+	}
+
 	eventsService := events.New()
 
 	referenceStore, err := reference.NewReferenceStore(filepath.Join(imageRoot, "repositories.json"))
@@ -643,6 +744,7 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	d.execCommands = exec.NewStore()
 	d.referenceStore = referenceStore
 	d.distributionMetadataStore = distributionMetadataStore
+	d.partialDownloadsDir = partialDownloadsDir
 	d.trustKey = trustKey
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
@@ -657,6 +759,9 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	d.uidMaps = uidMaps
 	d.gidMaps = gidMaps
 	d.seccompEnabled = sysInfo.Seccomp
+	if err := d.setupSeccompProfile(); err != nil {
+		return nil, err
+	}
 
 	d.nameIndex = registrar.NewRegistrar()
 	d.linkIndex = newLinkIndex()
@@ -678,10 +783,31 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
+	// Mounts of container filesystems may be left behind by a crash.
+	if leaks, err := d.leakedMounts(); err != nil {
+		logrus.Warnf("Could not detect the leaked mounts of container filesystems: %v", err)
+	} else {
+		d.leakedMountsCache.set(len(leaks))
+		if len(leaks) > 0 {
+			logrus.Warnf("Found %d leaked mounts of container filesystems. They can be listed with `docker system mounts`, and unmounted with `docker system mounts --unmount`.", len(leaks))
+		}
+	}
+
+	if d.containerMirror != nil {
+		d.containerMirror.start(d.List())
+	}
+
+	d.startJobs()
+	d.startPortRulesReconciliation()
+	d.startResourceAccounting()
+
 	return d, nil
 }
 
-func (daemon *Daemon) shutdownContainer(c *container.Container) error {
+// shutdownContainer stops c within its stop timeout, and reports whether it
+// had to be killed.
+func (daemon *Daemon) shutdownContainer(c *container.Container) (bool, error) {
+	stopTimeout := c.StopTimeout()
 	// TODO(windows): Handle docker restart with paused containers
 	if c.IsPaused() {
 		// To terminate a process in freezer cgroup, we should send
@@ -690,39 +816,62 @@ func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 		logrus.Debugf("Found container %s is paused, sending SIGTERM before unpausing it", c.ID)
 		sig, ok := signal.SignalMap["TERM"]
 		if !ok {
-			return fmt.Errorf("System does not support SIGTERM")
+			return false, fmt.Errorf("System does not support SIGTERM")
 		}
 		if err := daemon.kill(c, int(sig)); err != nil {
-			return fmt.Errorf("sending SIGTERM to container %s with error: %v", c.ID, err)
+			return false, fmt.Errorf("sending SIGTERM to container %s with error: %v", c.ID, err)
 		}
 		if err := daemon.containerUnpause(c); err != nil {
-			return fmt.Errorf("Failed to unpause container %s with error: %v", c.ID, err)
+			return false, fmt.Errorf("Failed to unpause container %s with error: %v", c.ID, err)
 		}
-		if _, err := c.WaitStop(10 * time.Second); err != nil {
-			logrus.Debugf("container %s failed to exit in 10 seconds of SIGTERM, sending SIGKILL to force", c.ID)
+		if _, err := c.WaitStop(time.Duration(stopTimeout) * time.Second); err != nil {
+			logrus.Debugf("container %s failed to exit in %d seconds of SIGTERM, sending SIGKILL to force", c.ID, stopTimeout)
 			sig, ok := signal.SignalMap["KILL"]
 			if !ok {
-				return fmt.Errorf("System does not support SIGKILL")
+				return false, fmt.Errorf("System does not support SIGKILL")
 			}
 			if err := daemon.kill(c, int(sig)); err != nil {
 				logrus.Errorf("Failed to SIGKILL container %s", c.ID)
 			}
 			c.WaitStop(-1 * time.Second)
-			return err
+			return true, err
 		}
 	}
-	// If container failed to exit in 10 seconds of SIGTERM, then using the force
-	if err := daemon.containerStop(c, 10); err != nil {
-		return fmt.Errorf("Failed to stop container %s with error: %v", c.ID, err)
+	// If container failed to exit in stopTimeout seconds of SIGTERM, then using the force
+	killed, err := daemon.containerStopOrKill(c, stopTimeout)
+	if err != nil {
+		return killed, fmt.Errorf("Failed to stop container %s with error: %v", c.ID, err)
 	}
 
 	c.WaitStop(-1 * time.Second)
-	return nil
+	return killed, nil
+}
+
+// ShutdownTimeout returns the time (in seconds) to wait for Shutdown to
+// complete. It is the configured shutdown timeout, raised if needed to let
+// every container run to the end of its stop timeout. A negative value
+// means to wait until all containers are stopped.
+func (daemon *Daemon) ShutdownTimeout() int {
+	shutdownTimeout := daemon.configStore.ShutdownTimeout
+	if daemon.containers == nil {
+		return shutdownTimeout
+	}
+	for _, c := range daemon.containers.List() {
+		stopTimeout := c.StopTimeout()
+		if stopTimeout < 0 {
+			return -1
+		}
+		if stopTimeout+shutdownGraceTimeout > shutdownTimeout {
+			shutdownTimeout = stopTimeout + shutdownGraceTimeout
+		}
+	}
+	return shutdownTimeout
 }
 
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	daemon.jobSchedules.removeAll()
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -737,12 +886,27 @@ func (daemon *Daemon) Shutdown() error {
 
 	if daemon.containers != nil {
 		logrus.Debug("starting clean shutdown of all containers...")
+		var (
+			mu     sync.Mutex
+			killed []string
+			// limit the number of containers stopped at the same time
+			sem = make(chan struct{}, shutdownParallelLimit)
+		)
 		daemon.containers.ApplyAll(func(c *container.Container) {
 			if !c.IsRunning() {
 				return
 			}
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
 			logrus.Debugf("stopping %s", c.ID)
-			if err := daemon.shutdownContainer(c); err != nil {
+			k, err := daemon.shutdownContainer(c)
+			if k {
+				mu.Lock()
+				killed = append(killed, strings.TrimPrefix(c.Name, "/"))
+				mu.Unlock()
+			}
+			if err != nil {
 				logrus.Errorf("Stop container error: %v", err)
 				return
 			}
@@ -751,6 +915,10 @@ func (daemon *Daemon) Shutdown() error {
 			}
 			logrus.Debugf("container stopped %s", c.ID)
 		})
+		if len(killed) > 0 {
+			sort.Strings(killed)
+			logrus.Warnf("%d container(s) did not stop within their stop timeout and had to be killed: %s", len(killed), strings.Join(killed, ", "))
+		}
 	}
 
 	// trigger libnetwork Stop only if it's initialized
@@ -774,8 +942,12 @@ func (daemon *Daemon) Shutdown() error {
 // Mount sets container.BaseFS
 // (is it not set coming in? why is it unset?)
 func (daemon *Daemon) Mount(container *container.Container) error {
+	// The mount is tracked before it is made, so that it is never reported
+	// nor unmounted as leaked while it is in use.
+	daemon.rwMounts.addContainer(container.ID)
 	dir, err := container.RWLayer.Mount(container.GetMountLabel())
 	if err != nil {
+		daemon.rwMounts.removeContainer(container.ID)
 		return err
 	}
 	logrus.Debugf("container mounted via layerStore: %v", dir)
@@ -800,6 +972,7 @@ func (daemon *Daemon) Unmount(container *container.Container) error {
 		logrus.Errorf("Error unmounting container %s: %s", container.ID, err)
 		return err
 	}
+	daemon.rwMounts.removeContainer(container.ID)
 	return nil
 }
 
@@ -898,6 +1071,18 @@ func tempDir(rootDir string, rootUID, rootGID int) (string, error) {
 	return tmpDir, idtools.MkdirAllAs(tmpDir, 0700, rootUID, rootGID)
 }
 
+// tempFile creates a temporary file in the temporary directory of the
+// daemon, under its root, so that large files are not written to the
+// system temporary directory, which may be a small tmpfs.
+func (daemon *Daemon) tempFile(prefix string) (*os.File, error) {
+	rootUID, rootGID := daemon.GetRemappedUIDGID()
+	dir, err := tempDir(daemon.root, rootUID, rootGID)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.TempFile(dir, prefix)
+}
+
 func (daemon *Daemon) setupInitLayer(initPath string) error {
 	rootUID, rootGID := daemon.GetRemappedUIDGID()
 	return setupInitLayer(initPath, rootUID, rootGID)
@@ -947,6 +1132,16 @@ func (daemon *Daemon) initDiscovery(config *Config) error {
 	}
 
 	daemon.discoveryWatcher = discoveryWatcher
+
+	mirror, err := containerMirrorEnabled(config.ClusterOpts)
+	if err != nil {
+		return err
+	}
+	if mirror {
+		if daemon.containerMirror, err = newContainerMirror(config.ClusterStore, config.ClusterAdvertise, config.ClusterOpts); err != nil {
+			return fmt.Errorf("container mirror initialization failed (%v)", err)
+		}
+	}
 	return nil
 }
 
@@ -957,8 +1152,10 @@ func (daemon *Daemon) initDiscovery(config *Config) error {
 // - Daemon debug log level.
 // - Daemon max concurrent downloads
 // - Daemon max concurrent uploads
+// - Daemon shutdown timeout
 // - Cluster discovery (reconfigure and restart).
 // - Daemon live restore
+// - Default log driver and log options
 func (daemon *Daemon) Reload(config *Config) error {
 	var err error
 	// used to hold reloaded changes
@@ -975,6 +1172,32 @@ func (daemon *Daemon) Reload(config *Config) error {
 	daemon.configStore.reloadLock.Lock()
 	defer daemon.configStore.reloadLock.Unlock()
 
+	// Validate the new logging defaults before anything is changed, so that
+	// an invalid configuration does not leave the daemon partially reloaded.
+	reloadLogConfig := config.IsValueSet("log-driver") || config.IsValueSet("log-opts")
+	logConfig := daemon.defaultLogConfig
+	if reloadLogConfig {
+		if config.IsValueSet("log-driver") {
+			logConfig.Type = config.LogConfig.Type
+		}
+		if config.IsValueSet("log-opts") {
+			logConfig.Config = config.LogConfig.Config
+		}
+		if err = logger.ValidateLogOpts(logConfig.Type, logConfig.Config); err != nil {
+			return err
+		}
+	}
+
+	// If no value is set for registry-limits the registries are not limited
+	var registryLimits map[string]registry.Limits
+	if config.IsValueSet("registry-limits") {
+		registryLimits = config.Limits
+	}
+	downloadLimits, err := registryDownloadLimits(registryLimits)
+	if err != nil {
+		return err
+	}
+
 	daemon.platformReload(config, &attributes)
 
 	if err = daemon.reloadClusterDiscovery(config); err != nil {
@@ -987,6 +1210,11 @@ func (daemon *Daemon) Reload(config *Config) error {
 	if config.IsValueSet("debug") {
 		daemon.configStore.Debug = config.Debug
 	}
+	if reloadLogConfig {
+		daemon.configStore.LogConfig.Type = logConfig.Type
+		daemon.configStore.LogConfig.Config = logConfig.Config
+		daemon.defaultLogConfig = logConfig
+	}
 	if config.IsValueSet("live-restore") {
 		daemon.configStore.LiveRestoreEnabled = config.LiveRestoreEnabled
 		if err := daemon.containerdRemote.UpdateOptions(libcontainerd.WithLiveRestore(config.LiveRestoreEnabled)); err != nil {
@@ -1008,6 +1236,23 @@ func (daemon *Daemon) Reload(config *Config) error {
 		daemon.downloadManager.SetConcurrency(*daemon.configStore.MaxConcurrentDownloads)
 	}
 
+	// If no value is set for max-download-attempts we assume it is the default value
+	if config.IsValueSet("max-download-attempts") && config.MaxDownloadAttempts != nil {
+		*daemon.configStore.MaxDownloadAttempts = *config.MaxDownloadAttempts
+	} else {
+		maxDownloadAttempts := defaultMaxDownloadAttempts
+		daemon.configStore.MaxDownloadAttempts = &maxDownloadAttempts
+	}
+	logrus.Debugf("Reset Max Download Attempts: %d", *daemon.configStore.MaxDownloadAttempts)
+	if daemon.downloadManager != nil {
+		daemon.downloadManager.SetMaxDownloadAttempts(*daemon.configStore.MaxDownloadAttempts)
+	}
+
+	daemon.configStore.Limits = registryLimits
+	if daemon.downloadManager != nil {
+		daemon.downloadManager.SetRegistryLimits(downloadLimits)
+	}
+
 	// If no value is set for max-concurrent-upload we assume it is the default value
 	// We always "reset" as the cost is lightweight and easy to maintain.
 	if config.IsValueSet("max-concurrent-uploads") && config.MaxConcurrentUploads != nil {
@@ -1021,9 +1266,56 @@ func (daemon *Daemon) Reload(config *Config) error {
 		daemon.uploadManager.SetConcurrency(*daemon.configStore.MaxConcurrentUploads)
 	}
 
+	// If no value is set for max-concurrent-exports we assume it is the default value
+	if config.IsValueSet("max-concurrent-exports") && config.MaxConcurrentExports != nil {
+		maxConcurrentExports := *config.MaxConcurrentExports
+		daemon.configStore.MaxConcurrentExports = &maxConcurrentExports
+	} else {
+		maxConcurrentExports := defaultMaxConcurrentExports
+		daemon.configStore.MaxConcurrentExports = &maxConcurrentExports
+	}
+	logrus.Debugf("Reset Max Concurrent Exports: %d", *daemon.configStore.MaxConcurrentExports)
+
+	// If no value is set for layer-depth-warning we assume it is the default value
+	if config.IsValueSet("layer-depth-warning") && config.LayerDepthWarning != nil {
+		layerDepthWarning := *config.LayerDepthWarning
+		daemon.configStore.LayerDepthWarning = &layerDepthWarning
+	} else {
+		layerDepthWarning := defaultLayerDepthWarning
+		daemon.configStore.LayerDepthWarning = &layerDepthWarning
+	}
+	logrus.Debugf("Reset Layer Depth Warning: %d", *daemon.configStore.LayerDepthWarning)
+
+	// If no value is set for build-from-policy we assume it is the default value
+	if config.IsValueSet("build-from-policy") {
+		daemon.configStore.BuildFromPolicy = config.BuildFromPolicy
+	} else {
+		daemon.configStore.BuildFromPolicy = types.BuildFromPolicyAny
+	}
+	logrus.Debugf("Reset Build From Policy: %s", daemon.configStore.BuildFromPolicy)
+
+	// If no value is set for shutdown-timeout we assume it is the default value
+	if config.IsValueSet("shutdown-timeout") {
+		daemon.configStore.ShutdownTimeout = config.ShutdownTimeout
+	} else {
+		daemon.configStore.ShutdownTimeout = defaultShutdownTimeout
+	}
+	logrus.Debugf("Reset Shutdown Timeout: %d", daemon.configStore.ShutdownTimeout)
+
+	// If no value is set for autoheal we assume it is disabled
+	daemon.configStore.Autoheal = config.IsValueSet("autoheal") && config.Autoheal
+	if config.IsValueSet("autoheal-threshold") {
+		daemon.configStore.AutohealThreshold = config.AutohealThreshold
+	} else {
+		daemon.configStore.AutohealThreshold = defaultAutohealThreshold
+	}
+
 	// We emit daemon reload event here with updatable configurations
 	attributes["debug"] = fmt.Sprintf("%t", daemon.configStore.Debug)
 	attributes["live-restore"] = fmt.Sprintf("%t", daemon.configStore.LiveRestoreEnabled)
+	attributes["shutdown-timeout"] = fmt.Sprintf("%d", daemon.configStore.ShutdownTimeout)
+	attributes["autoheal"] = fmt.Sprintf("%t", daemon.configStore.Autoheal)
+	attributes["autoheal-threshold"] = fmt.Sprintf("%d", daemon.configStore.AutohealThreshold)
 	attributes["cluster-store"] = daemon.configStore.ClusterStore
 	if daemon.configStore.ClusterOpts != nil {
 		opts, _ := json.Marshal(daemon.configStore.ClusterOpts)
@@ -1040,10 +1332,48 @@ func (daemon *Daemon) Reload(config *Config) error {
 	}
 	attributes["max-concurrent-downloads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentDownloads)
 	attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
+	attributes["max-download-attempts"] = fmt.Sprintf("%d", *daemon.configStore.MaxDownloadAttempts)
+	attributes["max-concurrent-exports"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentExports)
+	attributes["layer-depth-warning"] = fmt.Sprintf("%d", *daemon.configStore.LayerDepthWarning)
+	attributes["build-from-policy"] = daemon.configStore.BuildFromPolicy
+	if daemon.configStore.Limits != nil {
+		limits, _ := json.Marshal(daemon.configStore.Limits)
+		attributes["registry-limits"] = string(limits)
+	} else {
+		attributes["registry-limits"] = "{}"
+	}
+	attributes["log-driver"] = daemon.defaultLogConfig.Type
+	if daemon.defaultLogConfig.Config != nil {
+		logOpts, _ := json.Marshal(daemon.defaultLogConfig.Config)
+		attributes["log-opts"] = string(logOpts)
+	} else {
+		attributes["log-opts"] = "{}"
+	}
 
 	return nil
 }
 
+// registryDownloadLimits returns the limits of the downloads from the
+// registries of the configuration.
+func registryDownloadLimits(limits map[string]registry.Limits) (map[string]xfer.RegistryLimits, error) {
+	validated, err := registry.ValidateLimits(limits)
+	if err != nil {
+		return nil, err
+	}
+	downloadLimits := make(map[string]xfer.RegistryLimits, len(validated))
+	for name, l := range validated {
+		rate, err := l.DownloadRate()
+		if err != nil {
+			return nil, err
+		}
+		downloadLimits[name] = xfer.RegistryLimits{
+			MaxConcurrentDownloads: l.MaxConcurrentDownloads,
+			MaxDownloadRate:        rate,
+		}
+	}
+	return downloadLimits, nil
+}
+
 func (daemon *Daemon) reloadClusterDiscovery(config *Config) error {
 	var err error
 	newAdvertise := daemon.configStore.ClusterAdvertise
@@ -3,8 +3,18 @@
 package daemon
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/go-units"
+	"github.com/opencontainers/runc/libcontainer/user"
 )
 
 const mountsFixture = `142 78 0:38 / / rw,relatime - aufs none rw,si=573b861da0b3a05b,dio
@@ -102,3 +112,172 @@ func TestNotCleanupMounts(t *testing.T) {
 		t.Fatalf("Expected not to clean up /dev/shm")
 	}
 }
+
+func TestMergeUlimits(t *testing.T) {
+	d := &Daemon{
+		configStore: &Config{
+			Ulimits: map[string]*units.Ulimit{
+				"nproc":  {Name: "nproc", Soft: 1024, Hard: 2048},
+				"nofile": {Name: "nofile", Soft: 65535, Hard: 65535},
+				"core":   {Name: "core", Soft: 0, Hard: 0},
+			},
+		},
+	}
+
+	hostConfig := &containertypes.HostConfig{}
+	hostConfig.Ulimits = []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}}
+	d.mergeUlimits(hostConfig)
+
+	expected := []units.Ulimit{
+		{Name: "nofile", Soft: 1024, Hard: 1024},
+		{Name: "core", Soft: 0, Hard: 0},
+		{Name: "nproc", Soft: 1024, Hard: 2048},
+	}
+	if len(hostConfig.Ulimits) != len(expected) {
+		t.Fatalf("Expected %d ulimits, got %v", len(expected), hostConfig.Ulimits)
+	}
+	for i, ul := range hostConfig.Ulimits {
+		if *ul != expected[i] {
+			t.Fatalf("Expected ulimit %v at index %d, got %v", expected[i], i, *ul)
+		}
+	}
+
+	hostConfig.Ulimits[2].Soft = 1
+	if d.configStore.Ulimits["nproc"].Soft != 1024 {
+		t.Fatal("Expected the daemon default ulimits to be left untouched")
+	}
+}
+
+func TestVerifyBindPropagation(t *testing.T) {
+	// sources that do not exist yet, and private mounts, are not checked at
+	// creation
+	hostConfig := &containertypes.HostConfig{
+		Binds: []string{
+			"/nonexistent/source:/shared:rshared",
+			"/nonexistent/source:/slave:rslave",
+			"/:/private:rprivate",
+			"volume:/volume",
+		},
+	}
+	if err := verifyBindPropagation(hostConfig); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetAdditionalGroups(t *testing.T) {
+	groups := []user.Group{
+		{Name: "audio", Gid: 29},
+		{Name: "staff", Gid: 50},
+	}
+
+	gids, err := getAdditionalGroups([]string{"staff", "777", "audio", "50", "777"}, groups, "/etc/group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gids, []int{50, 777, 29}) {
+		t.Fatalf("expected [50 777 29], got %v", gids)
+	}
+
+	// numeric groups do not need a group file
+	if gids, err := getAdditionalGroups([]string{"777"}, nil, "/etc/group"); err != nil || !reflect.DeepEqual(gids, []int{777}) {
+		t.Fatalf("expected [777], got %v, %v", gids, err)
+	}
+
+	_, err = getAdditionalGroups([]string{"wheel"}, groups, "/etc/group")
+	if err == nil || err.Error() != "Unable to find group wheel in the /etc/group file of the image" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = getAdditionalGroups([]string{"wheel"}, nil, "/etc/group")
+	if err == nil || err.Error() != "Unable to find group wheel: the image has no /etc/group file to resolve group names" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := getAdditionalGroups([]string{"-1"}, groups, "/etc/group"); err == nil {
+		t.Fatal("expected an error for a negative GID")
+	}
+}
+
+func TestUpdateResolvConf(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-resolvconf-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	daemon := &Daemon{configStore: &Config{}}
+	daemon.configStore.DNS = []string{"10.0.0.2"}
+	daemon.configStore.DNSSearch = []string{"example.com"}
+
+	newContainer := func(name, content string, hostConfig *containertypes.HostConfig) *container.Container {
+		c := container.NewBaseContainer(name, filepath.Join(tmp, name))
+		c.HostConfig = hostConfig
+		c.ResolvConfPath = filepath.Join(tmp, name+"-resolv.conf")
+		if err := ioutil.WriteFile(c.ResolvConfPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		hash, err := ioutils.HashData(strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(c.ResolvConfPath+".hash", []byte(hash), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	readFile := func(path string) string {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	generated := "search old.example.com\nnameserver 10.0.0.1\n"
+	expected := "search example.com\nnameserver 10.0.0.2\n"
+
+	c := newContainer("generated", generated, &containertypes.HostConfig{})
+	updated, err := daemon.updateResolvConf(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated {
+		t.Fatal("Expected the generated resolv.conf to be updated")
+	}
+	if content := readFile(c.ResolvConfPath); content != expected {
+		t.Fatalf("Expected resolv.conf %q, got %q", expected, content)
+	}
+	hash, _ := ioutils.HashData(strings.NewReader(expected))
+	if h := readFile(c.ResolvConfPath + ".hash"); h != hash {
+		t.Fatalf("Expected the resolv.conf hash to be updated, got %q", h)
+	}
+
+	// A second update has nothing to change.
+	if updated, err := daemon.updateResolvConf(c); err != nil || updated {
+		t.Fatalf("Expected no update, got %v, %v", updated, err)
+	}
+
+	c = newContainer("modified", generated, &containertypes.HostConfig{})
+	if err := ioutil.WriteFile(c.ResolvConfPath, []byte("nameserver 10.0.0.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if updated, err := daemon.updateResolvConf(c); err != nil || updated {
+		t.Fatalf("Expected a modified resolv.conf not to be updated, got %v, %v", updated, err)
+	}
+	if content := readFile(c.ResolvConfPath); content != "nameserver 10.0.0.3\n" {
+		t.Fatalf("Expected the modified resolv.conf to be kept, got %q", content)
+	}
+
+	own := "search old.example.com\nnameserver 10.0.0.1\noptions ndots:2\n"
+	c = newContainer("own", own, &containertypes.HostConfig{
+		DNS:        []string{"10.0.0.1"},
+		DNSSearch:  []string{"old.example.com"},
+		DNSOptions: []string{"ndots:2"},
+	})
+	if updated, err := daemon.updateResolvConf(c); err != nil || updated {
+		t.Fatalf("Expected the container DNS settings to be kept, got %v, %v", updated, err)
+	}
+
+	c = newContainer("host", generated, &containertypes.HostConfig{NetworkMode: "host"})
+	if updated, err := daemon.updateResolvConf(c); err != nil || updated {
+		t.Fatalf("Expected a host network container not to be updated, got %v, %v", updated, err)
+	}
+}
@@ -176,3 +176,16 @@ func setupDaemonProcess(config *Config) error {
 func (daemon *Daemon) verifyVolumesInfo(container *container.Container) error {
 	return nil
 }
+
+// setupSeccompProfile is a no-op on Solaris, as seccomp is not supported.
+func (daemon *Daemon) setupSeccompProfile() error {
+	return nil
+}
+
+func (daemon *Daemon) defaultSeccompProfile() string {
+	return ""
+}
+
+func (daemon *Daemon) defaultAppArmorProfile() string {
+	return ""
+}
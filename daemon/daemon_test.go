@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -530,3 +531,180 @@ func TestDaemonDiscoveryReloadOnlyClusterAdvertise(t *testing.T) {
 	}
 
 }
+
+func TestDaemonReloadLogConfig(t *testing.T) {
+	daemon := &Daemon{
+		defaultLogConfig: containertypes.LogConfig{Type: "none"},
+	}
+	daemon.configStore = &Config{}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["log-opts"] = map[string]interface{}{"tag": "{{.Name}}"}
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			LogConfig: LogConfig{Config: map[string]string{"tag": "{{.Name}}"}},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+	if daemon.defaultLogConfig.Type != "none" {
+		t.Fatalf("Expected log driver `none` to be kept, got %s", daemon.defaultLogConfig.Type)
+	}
+	if daemon.defaultLogConfig.Config["tag"] != "{{.Name}}" {
+		t.Fatalf("Expected log opts to be reloaded, got %v", daemon.defaultLogConfig.Config)
+	}
+
+	valuesSets = make(map[string]interface{})
+	valuesSets["log-driver"] = "unknown-driver"
+	valuesSets["labels"] = "foo:baz"
+	newConfig = &Config{
+		CommonConfig: CommonConfig{
+			Labels:    []string{"foo:baz"},
+			LogConfig: LogConfig{Type: "unknown-driver"},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err == nil {
+		t.Fatal("Expected an error reloading an unknown log driver")
+	}
+	if daemon.defaultLogConfig.Type != "none" {
+		t.Fatalf("Expected log driver `none` to be kept, got %s", daemon.defaultLogConfig.Type)
+	}
+	if len(daemon.configStore.Labels) != 0 {
+		t.Fatalf("Expected labels not to be reloaded, got %v", daemon.configStore.Labels)
+	}
+}
+
+func TestGenerateHostname(t *testing.T) {
+	daemon := &Daemon{}
+	id := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	userNet := &containertypes.HostConfig{NetworkMode: "mynet"}
+	defaultNet := &containertypes.HostConfig{NetworkMode: "default"}
+
+	cases := []struct {
+		hostname     string
+		name         string
+		explicitName bool
+		hostConfig   *containertypes.HostConfig
+		expected     string
+	}{
+		{"", "/web", true, defaultNet, "0123456789ab"},
+		{"", "/web", true, userNet, "web"},
+		{"", "/fervent_panini", false, userNet, "0123456789ab"},
+		{"", "/my_web", true, userNet, "my-web"},
+		{"", "/my.-web", true, userNet, "0123456789ab"},
+		{"myhost", "/web", true, userNet, "myhost"},
+		{"{{.Name}}", "/web", true, defaultNet, "web"},
+		{"{{.Name}}-{{.ID}}", "/web", true, defaultNet, "web-0123456789ab"},
+		// A generated name
+		{"{{.Name}}", "/fervent_panini", false, defaultNet, "fervent-panini"},
+		{"{{.Name}}-{{.ID}}", "/" + strings.Repeat("a", 50) + "_b", true, defaultNet, strings.Repeat("a", 50) + "-b-0123456789"},
+	}
+	for _, c := range cases {
+		config := &containertypes.Config{Hostname: c.hostname}
+		if err := daemon.generateHostname(id, c.name, c.explicitName, config, c.hostConfig); err != nil {
+			t.Fatalf("Unexpected error for hostname %q: %v", c.hostname, err)
+		}
+		if config.Hostname != c.expected {
+			t.Fatalf("Expected hostname %q for %q, got %q", c.expected, c.hostname, config.Hostname)
+		}
+	}
+
+	for _, invalid := range []string{"{{.Name}", "{{.Unknown}}", "{{.Name}}_x"} {
+		config := &containertypes.Config{Hostname: invalid}
+		if err := daemon.generateHostname(id, "/web", true, config, defaultNet); err == nil {
+			t.Fatalf("Expected an error for hostname %q, got %q", invalid, config.Hostname)
+		}
+	}
+}
+
+func TestDaemonShutdownTimeout(t *testing.T) {
+	newContainer := func(id string, stopTimeout *int) *container.Container {
+		return &container.Container{
+			CommonContainer: container.CommonContainer{
+				ID:     id,
+				Config: &containertypes.Config{StopTimeout: stopTimeout},
+			},
+		}
+	}
+
+	daemon := &Daemon{
+		containers: container.NewMemoryStore(),
+	}
+	daemon.configStore = &Config{
+		CommonConfig: CommonConfig{
+			ShutdownTimeout: 15,
+		},
+	}
+
+	daemon.containers.Add("c1", newContainer("c1", nil))
+	if timeout := daemon.ShutdownTimeout(); timeout != 15 {
+		t.Fatalf("Expected shutdown timeout 15, got %d", timeout)
+	}
+
+	stopTimeout := 30
+	daemon.containers.Add("c2", newContainer("c2", &stopTimeout))
+	if timeout := daemon.ShutdownTimeout(); timeout != 30+shutdownGraceTimeout {
+		t.Fatalf("Expected shutdown timeout %d, got %d", 30+shutdownGraceTimeout, timeout)
+	}
+
+	noTimeout := -1
+	daemon.containers.Add("c3", newContainer("c3", &noTimeout))
+	if timeout := daemon.ShutdownTimeout(); timeout != -1 {
+		t.Fatalf("Expected no shutdown timeout, got %d", timeout)
+	}
+}
+
+func TestDaemonReloadShutdownTimeout(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{
+		CommonConfig: CommonConfig{
+			ShutdownTimeout: 15,
+		},
+	}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["shutdown-timeout"] = 60
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			ShutdownTimeout: 60,
+			valuesSet:       valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+	if daemon.configStore.ShutdownTimeout != 60 {
+		t.Fatalf("Expected shutdown timeout 60, got %d", daemon.configStore.ShutdownTimeout)
+	}
+
+	if err := daemon.Reload(&Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if daemon.configStore.ShutdownTimeout != defaultShutdownTimeout {
+		t.Fatalf("Expected default shutdown timeout %d, got %d", defaultShutdownTimeout, daemon.configStore.ShutdownTimeout)
+	}
+}
+
+func TestVerifyOomKillDisable(t *testing.T) {
+	disable := true
+	hostConfig := &containertypes.HostConfig{}
+	hostConfig.OomKillDisable = &disable
+	if err := verifyOomKillDisable(hostConfig, false); err == nil {
+		t.Fatal("Expected an error for disabling the OOM killer without a memory limit")
+	}
+
+	if err := verifyOomKillDisable(hostConfig, true); err != nil {
+		t.Fatalf("Unexpected error for a forced OOM killer disable: %v", err)
+	}
+
+	hostConfig.Memory = 64 * 1024 * 1024
+	if err := verifyOomKillDisable(hostConfig, false); err != nil {
+		t.Fatalf("Unexpected error for disabling the OOM killer with a memory limit: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ package daemon
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -11,9 +12,11 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
@@ -34,8 +37,10 @@ import (
 	"github.com/docker/libnetwork/netlabel"
 	"github.com/docker/libnetwork/netutils"
 	"github.com/docker/libnetwork/options"
+	"github.com/docker/libnetwork/portallocator"
 	lntypes "github.com/docker/libnetwork/types"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/opencontainers/runc/libcontainer/apparmor"
 	"github.com/opencontainers/runc/libcontainer/label"
 	"github.com/opencontainers/runc/libcontainer/user"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -116,6 +121,15 @@ func getCPUResources(config containertypes.Resources) *specs.CPU {
 		cpu.Quota = &quota
 	}
 
+	if config.NanoCPUs > 0 {
+		// The quota is relative to the default period of 100ms, see
+		// https://www.kernel.org/doc/Documentation/scheduler/sched-bwc.txt
+		period := uint64(100 * time.Millisecond / time.Microsecond)
+		quota := uint64(config.NanoCPUs) * period / 1e9
+		cpu.Period = &period
+		cpu.Quota = &quota
+	}
+
 	return &cpu
 }
 
@@ -161,6 +175,9 @@ func parseSecurityOpt(container *container.Container, config *containertypes.Hos
 
 			switch con[0] {
 			case "label":
+				if err := validateLabelOpt(con[1]); err != nil {
+					return err
+				}
 				labelOpts = append(labelOpts, con[1])
 			case "apparmor":
 				container.AppArmorProfile = con[1]
@@ -176,6 +193,99 @@ func parseSecurityOpt(container *container.Container, config *containertypes.Hos
 	return err
 }
 
+// validLabelOpts are the keys of the label options, followed by ':' and a
+// value.
+var validLabelOpts = map[string]bool{
+	"user":  true,
+	"role":  true,
+	"type":  true,
+	"level": true,
+}
+
+// validateLabelOpt validates a label option, as the AppArmor and seccomp
+// options, even if SELinux is disabled on the host.
+func validateLabelOpt(opt string) error {
+	if opt == "disable" {
+		return nil
+	}
+	con := strings.SplitN(opt, ":", 2)
+	if len(con) != 2 || !validLabelOpts[con[0]] || con[1] == "" {
+		return fmt.Errorf("Invalid --security-opt: label option %q, valid options are disable, or user, role, type and level followed by ':' and a value", opt)
+	}
+	return nil
+}
+
+// setupSeccompProfile loads the default seccomp profile of the containers
+// set in the configuration. The profile is validated when the daemon starts,
+// not when a container starts.
+func (daemon *Daemon) setupSeccompProfile() error {
+	path := daemon.configStore.SeccompProfile
+	if path == "" || path == "unconfined" || path == "builtin" {
+		return nil
+	}
+	if !supportsSeccomp {
+		return fmt.Errorf("seccomp profiles are not supported on this daemon, you cannot set a default seccomp profile")
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("opening seccomp profile (%s) failed: %v", path, err)
+	}
+	var profile types.Seccomp
+	if err := json.Unmarshal(b, &profile); err != nil {
+		return fmt.Errorf("decoding seccomp profile (%s) failed: %v", path, err)
+	}
+	daemon.seccompProfile = b
+	return nil
+}
+
+// defaultSeccompProfile returns the seccomp profile of the containers that
+// do not set their own: unconfined, builtin for the default profile of
+// docker, or the path of the profile set in the configuration.
+func (daemon *Daemon) defaultSeccompProfile() string {
+	if daemon.configStore.SeccompProfile == "" {
+		return "builtin"
+	}
+	return daemon.configStore.SeccompProfile
+}
+
+// containerSeccompProfile returns the seccomp profile the container runs with, as
+// defaultSeccompProfile, or custom if the container sets its own.
+func (daemon *Daemon) containerSeccompProfile(c *container.Container) string {
+	switch {
+	case c.HostConfig.Privileged, c.SeccompProfile == "unconfined", !supportsSeccomp, !daemon.seccompEnabled:
+		return "unconfined"
+	case c.SeccompProfile != "":
+		return "custom"
+	}
+	return daemon.defaultSeccompProfile()
+}
+
+// defaultAppArmorProfile returns the AppArmor profile of the containers that
+// do not set their own.
+func (daemon *Daemon) defaultAppArmorProfile() string {
+	if daemon.configStore.DefaultAppArmorProfile == "" {
+		return "docker-default"
+	}
+	return daemon.configStore.DefaultAppArmorProfile
+}
+
+// containerAppArmorProfile returns the AppArmor profile the container runs with, or
+// an empty string if it is not confined by AppArmor. In rootless mode, the
+// containers are only confined by the profile they set.
+func (daemon *Daemon) containerAppArmorProfile(c *container.Container) string {
+	switch {
+	case !apparmor.IsEnabled():
+		return ""
+	case c.AppArmorProfile != "":
+		return c.AppArmorProfile
+	case daemon.configStore.Rootless:
+		return ""
+	case c.HostConfig.Privileged:
+		return "unconfined"
+	}
+	return daemon.defaultAppArmorProfile()
+}
+
 func getBlkioThrottleDevices(devs []*blkiodev.ThrottleDevice) ([]specs.ThrottleDevice, error) {
 	var throttleDevices []specs.ThrottleDevice
 	var stat syscall.Stat_t
@@ -248,10 +358,31 @@ func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConf
 		defaultOomKillDisable := false
 		hostConfig.OomKillDisable = &defaultOomKillDisable
 	}
+	if hostConfig.CgroupnsMode.IsEmpty() && daemon.configStore != nil {
+		hostConfig.CgroupnsMode = containertypes.CgroupnsMode(daemon.configStore.CgroupNamespaceMode)
+	}
+	if hostConfig.IpcMode.IsEmpty() && daemon.configStore != nil {
+		hostConfig.IpcMode = containertypes.IpcMode(daemon.configStore.IpcMode)
+	}
+	if hostConfig.CoreDumps.IsEmpty() && daemon.configStore != nil {
+		hostConfig.CoreDumps = containertypes.CoreDumpMode(daemon.configStore.CoreDumps)
+	}
+	if hostConfig.CoreDumpMaxSize == 0 && !hostConfig.CoreDumps.IsDiscard() && daemon.configStore != nil {
+		hostConfig.CoreDumpMaxSize, _ = parseCoreDumpSize(daemon.configStore.CoreDumpMaxSize)
+	}
 
 	return nil
 }
 
+// formatCpusetList formats a list of CPUs or memory nodes as in cpuset.cpus.
+func formatCpusetList(list []int) string {
+	s := make([]string, len(list))
+	for i, n := range list {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
 func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysinfo.SysInfo, update bool) ([]string, error) {
 	warnings := []string{}
 
@@ -348,6 +479,19 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 	if resources.CPUQuota > 0 && resources.CPUQuota < 1000 {
 		return warnings, fmt.Errorf("CPU cfs quota can not be less than 1ms (i.e. 1000)")
 	}
+	if resources.NanoCPUs > 0 && resources.CPUPeriod > 0 {
+		return warnings, fmt.Errorf("Conflicting options: Nano CPUs and CPU Period cannot both be set")
+	}
+	if resources.NanoCPUs > 0 && resources.CPUQuota > 0 {
+		return warnings, fmt.Errorf("Conflicting options: Nano CPUs and CPU Quota cannot both be set")
+	}
+	if resources.NanoCPUs > 0 && (!sysInfo.CPUCfsPeriod || !sysInfo.CPUCfsQuota) {
+		return warnings, fmt.Errorf("NanoCPUs can not be set, as your kernel does not support CPU cfs period/quota or the cgroup is not mounted")
+	}
+	// The quota of 0.01 CPUs is 1ms, the lowest quota of the kernel.
+	if resources.NanoCPUs != 0 && (resources.NanoCPUs < 1e7 || resources.NanoCPUs > int64(sysinfo.NumCPU())*1e9) {
+		return warnings, fmt.Errorf("Range of CPUs is from 0.01 to %d.00, as there are only %d CPUs available", sysinfo.NumCPU(), sysinfo.NumCPU())
+	}
 	if resources.CPUPercent > 0 {
 		warnings = append(warnings, "%s does not support CPU percent. Percent discarded.", runtime.GOOS)
 		logrus.Warnf("%s does not support CPU percent. Percent discarded.", runtime.GOOS)
@@ -361,19 +505,19 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 		resources.CpusetCpus = ""
 		resources.CpusetMems = ""
 	}
-	cpusAvailable, err := sysInfo.IsCpusetCpusAvailable(resources.CpusetCpus)
+	unavailableCpus, err := sysInfo.UnavailableCpusetCpus(resources.CpusetCpus)
 	if err != nil {
-		return warnings, fmt.Errorf("Invalid value %s for cpuset cpus", resources.CpusetCpus)
+		return warnings, fmt.Errorf("Invalid value %s for cpuset cpus: expected a list of CPUs, such as 0-3 or 0,1", resources.CpusetCpus)
 	}
-	if !cpusAvailable {
-		return warnings, fmt.Errorf("Requested CPUs are not available - requested %s, available: %s", resources.CpusetCpus, sysInfo.Cpus)
+	if len(unavailableCpus) > 0 {
+		return warnings, fmt.Errorf("Requested CPUs are not available - requested %s, available: %s, unavailable: %s", resources.CpusetCpus, sysInfo.Cpus, formatCpusetList(unavailableCpus))
 	}
-	memsAvailable, err := sysInfo.IsCpusetMemsAvailable(resources.CpusetMems)
+	unavailableMems, err := sysInfo.UnavailableCpusetMems(resources.CpusetMems)
 	if err != nil {
-		return warnings, fmt.Errorf("Invalid value %s for cpuset mems", resources.CpusetMems)
+		return warnings, fmt.Errorf("Invalid value %s for cpuset mems: expected a list of memory nodes, such as 0-3 or 0,1", resources.CpusetMems)
 	}
-	if !memsAvailable {
-		return warnings, fmt.Errorf("Requested memory nodes are not available - requested %s, available: %s", resources.CpusetMems, sysInfo.Mems)
+	if len(unavailableMems) > 0 {
+		return warnings, fmt.Errorf("Requested memory nodes are not available - requested %s, available: %s, unavailable: %s", resources.CpusetMems, sysInfo.Mems, formatCpusetList(unavailableMems))
 	}
 
 	// blkio subsystem checks and adjustments
@@ -463,6 +607,10 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, err
 	}
 
+	if daemon.configStore.Rootless {
+		warnings = append(warnings, discardRootlessResources(&hostConfig.Resources)...)
+	}
+
 	w, err := verifyContainerResources(&hostConfig.Resources, sysInfo, update)
 
 	// no matter err is nil or not, w could have data in itself.
@@ -476,6 +624,49 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("SHM size can not be less than 0")
 	}
 
+	if !update {
+		if err := verifyBindPropagation(hostConfig); err != nil {
+			return warnings, err
+		}
+	}
+
+	if len(hostConfig.RwPaths) > 0 && !hostConfig.ReadonlyRootfs {
+		return warnings, fmt.Errorf("Writable paths can only be set for a container with a read-only root filesystem")
+	}
+	for _, p := range hostConfig.RwPaths {
+		if !filepath.IsAbs(p) || filepath.Clean(p) == "/" {
+			return warnings, fmt.Errorf("Invalid writable path %q: must be an absolute path other than /", p)
+		}
+	}
+
+	if !hostConfig.CgroupnsMode.Valid() {
+		return warnings, fmt.Errorf("invalid cgroup namespace mode: %v", hostConfig.CgroupnsMode)
+	}
+	if !hostConfig.CoreDumps.Valid() {
+		return warnings, fmt.Errorf("invalid core dump mode %q, must be one of kernel, capture or discard", hostConfig.CoreDumps)
+	}
+	if hostConfig.CoreDumpMaxSize < 0 {
+		return warnings, fmt.Errorf("the maximum size of a core dump cannot be negative")
+	}
+	if hostConfig.CoreDumpMaxSize > 0 && hostConfig.CoreDumps.IsDiscard() {
+		return warnings, fmt.Errorf("a maximum core dump size cannot be set for a container discarding its core dumps")
+	}
+	if hostConfig.CgroupnsMode.IsPrivate() && !sysInfo.CgroupNamespaces {
+		return warnings, fmt.Errorf("Your kernel does not support cgroup namespaces")
+	}
+
+	for _, req := range hostConfig.DeviceRequests {
+		if req.Driver == "" {
+			return warnings, fmt.Errorf("A device driver is required to request devices")
+		}
+		if req.Count < -1 {
+			return warnings, fmt.Errorf("Invalid count %d of devices for device driver %s", req.Count, req.Driver)
+		}
+		if req.Count != 0 && len(req.DeviceIDs) > 0 {
+			return warnings, fmt.Errorf("Conflicting options: count and device IDs cannot both be requested from device driver %s", req.Driver)
+		}
+	}
+
 	if hostConfig.OomScoreAdj < -1000 || hostConfig.OomScoreAdj > 1000 {
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
@@ -526,6 +717,27 @@ func (daemon *Daemon) platformReload(config *Config, attributes *map[string]stri
 		daemon.configStore.DefaultRuntime = config.DefaultRuntime
 	}
 
+	if config.IsValueSet("default-ulimits") {
+		daemon.configStore.Ulimits = config.Ulimits
+	}
+
+	reloadDNS := false
+	if config.IsValueSet("dns") {
+		daemon.configStore.DNS = config.DNS
+		reloadDNS = true
+	}
+	if config.IsValueSet("dns-opts") {
+		daemon.configStore.DNSOptions = config.DNSOptions
+		reloadDNS = true
+	}
+	if config.IsValueSet("dns-search") {
+		daemon.configStore.DNSSearch = config.DNSSearch
+		reloadDNS = true
+	}
+	if reloadDNS {
+		daemon.reloadContainersDNS()
+	}
+
 	// Update attributes
 	var runtimeList bytes.Buffer
 	for name, rt := range daemon.configStore.Runtimes {
@@ -537,6 +749,20 @@ func (daemon *Daemon) platformReload(config *Config, attributes *map[string]stri
 
 	(*attributes)["runtimes"] = runtimeList.String()
 	(*attributes)["default-runtime"] = daemon.configStore.DefaultRuntime
+
+	var ulimitNames []string
+	for name := range daemon.configStore.Ulimits {
+		ulimitNames = append(ulimitNames, name)
+	}
+	sort.Strings(ulimitNames)
+	var ulimitList []string
+	for _, name := range ulimitNames {
+		ulimitList = append(ulimitList, daemon.configStore.Ulimits[name].String())
+	}
+	(*attributes)["default-ulimits"] = strings.Join(ulimitList, " ")
+	(*attributes)["dns"] = strings.Join(daemon.configStore.DNS, " ")
+	(*attributes)["dns-opts"] = strings.Join(daemon.configStore.DNSOptions, " ")
+	(*attributes)["dns-search"] = strings.Join(daemon.configStore.DNSSearch, " ")
 }
 
 // verifyDaemonSettings performs validation of daemon config struct
@@ -551,15 +777,53 @@ func verifyDaemonSettings(config *Config) error {
 	if !config.bridgeConfig.EnableIPTables && config.bridgeConfig.EnableIPMasq {
 		config.bridgeConfig.EnableIPMasq = false
 	}
+	if config.bridgeConfig.PublishedPortRange != "" {
+		if _, _, err := parsePublishedPortRange(config.bridgeConfig.PublishedPortRange); err != nil {
+			return err
+		}
+	}
 	if err := VerifyCgroupDriver(config); err != nil {
 		return err
 	}
+	if err := verifyRootlessSettings(config); err != nil {
+		return err
+	}
 	if config.CgroupParent != "" && UsingSystemd(config) {
 		if len(config.CgroupParent) <= 6 || !strings.HasSuffix(config.CgroupParent, ".slice") {
 			return fmt.Errorf("cgroup-parent for systemd cgroup should be a valid slice named as \"xxx.slice\"")
 		}
 	}
 
+	switch mode := containertypes.CgroupnsMode(config.CgroupNamespaceMode); {
+	case mode.IsEmpty():
+		config.CgroupNamespaceMode = "host"
+	case !mode.Valid():
+		return fmt.Errorf("invalid default cgroup namespace mode %q, must be either \"host\" or \"private\"", config.CgroupNamespaceMode)
+	case mode.IsPrivate() && !sysinfo.New(true).CgroupNamespaces:
+		logrus.Warn("Your kernel does not support cgroup namespaces, falling back to the \"host\" cgroup namespace mode")
+		config.CgroupNamespaceMode = "host"
+	}
+
+	switch mode := containertypes.IpcMode(config.IpcMode); {
+	case mode.IsEmpty():
+		config.IpcMode = "shareable"
+	case !mode.IsShareable() && !mode.IsPrivateMode():
+		return fmt.Errorf("invalid default ipc mode %q, must be either \"shareable\" or \"private\"", config.IpcMode)
+	}
+
+	switch mode := containertypes.CoreDumpMode(config.CoreDumps); {
+	case mode.IsEmpty():
+		config.CoreDumps = "kernel"
+	case !mode.Valid():
+		return fmt.Errorf("invalid default core dump mode %q, must be one of kernel, capture or discard", config.CoreDumps)
+	}
+	if _, err := parseCoreDumpSize(config.CoreDumpMaxSize); err != nil {
+		return fmt.Errorf("invalid default maximum core dump size: %v", err)
+	}
+	if _, err := parseCoreDumpSize(config.CoreDumpsMaxTotal); err != nil {
+		return fmt.Errorf("invalid maximum total size of the core dumps: %v", err)
+	}
+
 	if config.DefaultRuntime == "" {
 		config.DefaultRuntime = stockRuntimeName
 	}
@@ -614,6 +878,17 @@ func (daemon *Daemon) initNetworkController(config *Config, activeSandboxes map[
 		return nil, err
 	}
 
+	if config.bridgeConfig.PublishedPortRange != "" {
+		begin, end, err := verifyPublishedPortRange(config.bridgeConfig.PublishedPortRange)
+		if err != nil {
+			return nil, err
+		}
+		// The range must be set before the controller restores the port
+		// mappings of the endpoints, which allocates their host ports.
+		allocator := portallocator.Get()
+		allocator.Begin, allocator.End = begin, end
+	}
+
 	controller, err := libnetwork.New(netOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("error obtaining controller instance: %v", err)
@@ -1143,7 +1418,7 @@ func rootFSToAPIType(rootfs *image.RootFS) types.RootFS {
 // setupDaemonProcess sets various settings for the daemon's process
 func setupDaemonProcess(config *Config) error {
 	// setup the daemons oom_score_adj
-	return setupOOMScoreAdj(config.OOMScoreAdjust)
+	return setupOOMScoreAdj(config.GetOOMScoreAdjust())
 }
 
 func setupOOMScoreAdj(score int) error {
@@ -1155,3 +1430,12 @@ func setupOOMScoreAdj(score int) error {
 	f.Close()
 	return err
 }
+
+// helperSource returns the location of the helper binary set in the
+// configuration of the daemon, if any.
+func (daemon *Daemon) helperSource(name string) string {
+	if name == "init" {
+		return daemon.configStore.InitPath
+	}
+	return ""
+}
@@ -6,14 +6,18 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	containertypes "github.com/docker/docker/api/types/container"
+	networktypes "github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/local"
 	"github.com/docker/docker/volume/store"
+	"github.com/docker/go-units"
 )
 
 // Unix test as uses settings which are not available on Windows
@@ -160,16 +164,20 @@ func TestParseSecurityOpt(t *testing.T) {
 		t.Fatalf("Unexpected SeccompProfile, expected: %q, got %q", sp, container.SeccompProfile)
 	}
 
-	// test valid label
-	config.SecurityOpt = []string{"label=user:USER"}
-	if err := parseSecurityOpt(container, config); err != nil {
-		t.Fatalf("Unexpected parseSecurityOpt error: %v", err)
+	// test valid labels
+	for _, opt := range []string{"label=user:USER", "label=type:svirt_apache_t", "label=level:s0:c100,c200", "label=disable"} {
+		config.SecurityOpt = []string{opt}
+		if err := parseSecurityOpt(container, config); err != nil {
+			t.Fatalf("Unexpected parseSecurityOpt error for %q: %v", opt, err)
+		}
 	}
 
-	// test invalid label
-	config.SecurityOpt = []string{"label"}
-	if err := parseSecurityOpt(container, config); err == nil {
-		t.Fatal("Expected parseSecurityOpt error, got nil")
+	// test invalid labels
+	for _, opt := range []string{"label", "label=foo:bar", "label=type:", "label=type"} {
+		config.SecurityOpt = []string{opt}
+		if err := parseSecurityOpt(container, config); err == nil {
+			t.Fatalf("Expected parseSecurityOpt error for %q, got nil", opt)
+		}
 	}
 
 	// test invalid opt
@@ -179,6 +187,96 @@ func TestParseSecurityOpt(t *testing.T) {
 	}
 }
 
+func TestSetupSeccompProfile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-daemon-unix-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, profile := range []string{"", "unconfined", "builtin"} {
+		daemon := &Daemon{configStore: &Config{SeccompProfile: profile}}
+		if err := daemon.setupSeccompProfile(); err != nil {
+			t.Fatalf("Unexpected setupSeccompProfile error for %q: %v", profile, err)
+		}
+		if daemon.seccompProfile != nil {
+			t.Fatalf("Expected no seccomp profile to be loaded for %q", profile)
+		}
+	}
+
+	invalid := filepath.Join(tmp, "invalid.json")
+	if err := ioutil.WriteFile(invalid, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, profile := range []string{invalid, filepath.Join(tmp, "missing.json")} {
+		daemon := &Daemon{configStore: &Config{SeccompProfile: profile}}
+		if err := daemon.setupSeccompProfile(); err == nil {
+			t.Fatalf("Expected setupSeccompProfile error for %q, got nil", profile)
+		}
+	}
+
+	if !supportsSeccomp {
+		return
+	}
+	valid := filepath.Join(tmp, "valid.json")
+	if err := ioutil.WriteFile(valid, []byte(`{"defaultAction": "SCMP_ACT_ALLOW"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	daemon := &Daemon{configStore: &Config{SeccompProfile: valid}}
+	if err := daemon.setupSeccompProfile(); err != nil {
+		t.Fatalf("Unexpected setupSeccompProfile error: %v", err)
+	}
+	if daemon.seccompProfile == nil {
+		t.Fatal("Expected the seccomp profile to be loaded")
+	}
+}
+
+func TestContainerSeccompProfile(t *testing.T) {
+	daemon := &Daemon{configStore: &Config{}, seccompEnabled: true}
+	c := &container.Container{HostConfig: &containertypes.HostConfig{}}
+
+	expected := "builtin"
+	if !supportsSeccomp {
+		expected = "unconfined"
+	}
+	if p := daemon.containerSeccompProfile(c); p != expected {
+		t.Fatalf("Expected seccomp profile %q, got %q", expected, p)
+	}
+
+	daemon.configStore.SeccompProfile = "/etc/docker/seccomp.json"
+	if supportsSeccomp {
+		expected = "/etc/docker/seccomp.json"
+	}
+	if p := daemon.containerSeccompProfile(c); p != expected {
+		t.Fatalf("Expected seccomp profile %q, got %q", expected, p)
+	}
+	if p := daemon.defaultSeccompProfile(); p != "/etc/docker/seccomp.json" {
+		t.Fatalf("Expected default seccomp profile %q, got %q", "/etc/docker/seccomp.json", p)
+	}
+
+	c.SeccompProfile = "unconfined"
+	if p := daemon.containerSeccompProfile(c); p != "unconfined" {
+		t.Fatalf("Expected seccomp profile %q, got %q", "unconfined", p)
+	}
+
+	c.SeccompProfile = ""
+	c.HostConfig.Privileged = true
+	if p := daemon.containerSeccompProfile(c); p != "unconfined" {
+		t.Fatalf("Expected seccomp profile %q for a privileged container, got %q", "unconfined", p)
+	}
+}
+
+func TestDefaultAppArmorProfile(t *testing.T) {
+	daemon := &Daemon{configStore: &Config{}}
+	if p := daemon.defaultAppArmorProfile(); p != "docker-default" {
+		t.Fatalf("Expected default AppArmor profile %q, got %q", "docker-default", p)
+	}
+	daemon.configStore.DefaultAppArmorProfile = "custom-profile"
+	if p := daemon.defaultAppArmorProfile(); p != "custom-profile" {
+		t.Fatalf("Expected default AppArmor profile %q, got %q", "custom-profile", p)
+	}
+}
+
 func TestNetworkOptions(t *testing.T) {
 	daemon := &Daemon{}
 	dconfigCorrect := &Config{
@@ -281,3 +379,102 @@ func TestMigratePre17Volumes(t *testing.T) {
 		}
 	}
 }
+
+func TestDaemonReloadDefaultUlimits(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{
+		Ulimits: map[string]*units.Ulimit{
+			"nofile": {Name: "nofile", Soft: 1024, Hard: 1024},
+		},
+	}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["default-ulimits"] = map[string]interface{}{}
+	newConfig := &Config{
+		Ulimits: map[string]*units.Ulimit{
+			"nproc": {Name: "nproc", Soft: 2048, Hard: 4096},
+		},
+	}
+	newConfig.valuesSet = valuesSets
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := daemon.configStore.Ulimits["nofile"]; exists {
+		t.Fatal("Expected the `nofile` default ulimit to be removed")
+	}
+	if ul := daemon.configStore.Ulimits["nproc"]; ul == nil || ul.Soft != 2048 || ul.Hard != 4096 {
+		t.Fatalf("Expected the `nproc` default ulimit to be reloaded, got %v", ul)
+	}
+}
+
+func TestVerifyNetworkingConfig(t *testing.T) {
+	daemon := &Daemon{}
+	nwConfig := &networktypes.NetworkingConfig{
+		EndpointsConfig: map[string]*networktypes.EndpointSettings{
+			"net1": {Aliases: []string{"server_x"}},
+			"net2": {IPAMConfig: &networktypes.EndpointIPAMConfig{IPv4Address: "172.20.30.33"}},
+		},
+	}
+
+	for _, mode := range []string{"default", "bridge", "net1"} {
+		hostConfig := &containertypes.HostConfig{NetworkMode: containertypes.NetworkMode(mode)}
+		if err := daemon.verifyNetworkingConfig(nwConfig, hostConfig); err != nil {
+			t.Fatalf("Unexpected error for network mode %s: %v", mode, err)
+		}
+	}
+
+	for _, mode := range []string{"host", "none", "container:abc"} {
+		hostConfig := &containertypes.HostConfig{NetworkMode: containertypes.NetworkMode(mode)}
+		if err := daemon.verifyNetworkingConfig(nwConfig, hostConfig); err == nil {
+			t.Fatalf("Expected an error for network mode %s", mode)
+		}
+	}
+
+	nwConfig.EndpointsConfig["net2"].IPAMConfig.IPv4Address = "2001:db8::33"
+	if err := daemon.verifyNetworkingConfig(nwConfig, nil); err == nil {
+		t.Fatal("Expected an error for an invalid IPv4 address")
+	}
+}
+
+func TestGetCPUResourcesNanoCPUs(t *testing.T) {
+	cpu := getCPUResources(containertypes.Resources{NanoCPUs: 1500000000})
+	if cpu.Period == nil || *cpu.Period != 100000 {
+		t.Fatalf("expected a CPU period of 100000, got %v", cpu.Period)
+	}
+	if cpu.Quota == nil || *cpu.Quota != 150000 {
+		t.Fatalf("expected a CPU quota of 150000, got %v", cpu.Quota)
+	}
+}
+
+func TestVerifyContainerResourcesCPUs(t *testing.T) {
+	sysInfo := &sysinfo.SysInfo{}
+	sysInfo.CPUCfsPeriod = true
+	sysInfo.CPUCfsQuota = true
+	sysInfo.Cpuset = true
+	sysInfo.Cpus = "0-3"
+	sysInfo.Mems = "0"
+
+	cases := []struct {
+		resources containertypes.Resources
+		expected  string
+	}{
+		{containertypes.Resources{NanoCPUs: 1e9, CPUPeriod: 100000}, "Nano CPUs and CPU Period cannot both be set"},
+		{containertypes.Resources{NanoCPUs: 1e9, CPUQuota: 100000}, "Nano CPUs and CPU Quota cannot both be set"},
+		{containertypes.Resources{NanoCPUs: 1e6}, "Range of CPUs is from 0.01"},
+		{containertypes.Resources{CpusetCpus: "1-"}, "Invalid value 1- for cpuset cpus: expected a list of CPUs"},
+		{containertypes.Resources{CpusetCpus: "2-5,7"}, "requested 2-5,7, available: 0-3, unavailable: 4,5,7"},
+		{containertypes.Resources{CpusetMems: "0,1"}, "Requested memory nodes are not available - requested 0,1, available: 0, unavailable: 1"},
+	}
+	for _, c := range cases {
+		resources := c.resources
+		if _, err := verifyContainerResources(&resources, sysInfo, false); err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Fatalf("%+v: expected an error containing %q, got %v", c.resources, c.expected, err)
+		}
+	}
+
+	resources := containertypes.Resources{NanoCPUs: 5e8, CpusetCpus: "0-1", CpusetMems: "0"}
+	if _, err := verifyContainerResources(&resources, sysInfo, false); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -103,6 +103,10 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 		return warnings, fmt.Errorf("Conflicting options: CPU Shares and CPU Percent cannot both be set")
 	}
 
+	if resources.NanoCPUs > 0 {
+		return warnings, fmt.Errorf("Windows does not support Nano CPUs, use CPU Percent instead")
+	}
+
 	// TODO Windows: Add more validation of resource settings not supported on Windows
 
 	if resources.BlkioWeight > 0 {
@@ -149,6 +153,18 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, err
 	}
 
+	if len(hostConfig.RwPaths) > 0 {
+		return warnings, fmt.Errorf("Writable paths are not supported on Windows")
+	}
+
+	if len(hostConfig.DeviceRequests) > 0 {
+		return warnings, fmt.Errorf("Device requests are not supported on Windows")
+	}
+
+	if !hostConfig.CoreDumps.IsEmpty() || hostConfig.CoreDumpMaxSize != 0 {
+		return warnings, fmt.Errorf("Core dump options are not supported on Windows")
+	}
+
 	return warnings, nil
 }
 
@@ -529,3 +545,22 @@ func setupDaemonProcess(config *Config) error {
 func (daemon *Daemon) verifyVolumesInfo(container *container.Container) error {
 	return nil
 }
+
+// helperSource returns the location of the helper binary set in the
+// configuration of the daemon. It is not configurable on Windows.
+func (daemon *Daemon) helperSource(name string) string {
+	return ""
+}
+
+// setupSeccompProfile is a no-op on Windows, as seccomp is not supported.
+func (daemon *Daemon) setupSeccompProfile() error {
+	return nil
+}
+
+func (daemon *Daemon) defaultSeccompProfile() string {
+	return ""
+}
+
+func (daemon *Daemon) defaultAppArmorProfile() string {
+	return ""
+}
@@ -0,0 +1,29 @@
+// Package dataroot moves the data root of a stopped daemon, such as
+// /var/lib/docker, to a new location.
+//
+// The data root is renamed if the new location is on the same filesystem.
+// Otherwise it is copied to a staging directory next to the new location,
+// with the files cloned if the filesystem supports it, and the hardlinks,
+// sparse files, ownership, modes and extended attributes preserved. The
+// content-addressed files, stored under their digest, are verified against
+// it before the staging directory is renamed to the new location. The source
+// is left untouched until the move succeeds.
+package dataroot
+
+import "io"
+
+// Options are the options of a move of the data root.
+type Options struct {
+	// Pidfile is the pid file of the daemon. The move is refused if the
+	// daemon is running, and the pid file is held during the move so that
+	// the daemon cannot start.
+	Pidfile string
+	// Link replaces the source by a symbolic link to the new location, so
+	// that the daemon uses it without a change of its configuration.
+	Link bool
+	// KeepSource keeps the source once it is copied, renamed with the
+	// .old suffix if Link is set.
+	KeepSource bool
+	// Out receives the progress of the move, if it is set.
+	Out io.Writer
+}
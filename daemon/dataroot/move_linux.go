@@ -0,0 +1,434 @@
+// +build linux
+
+package dataroot
+
+import (
+	"bytes"
+	// the digests of the content-addressed files are sha256
+	_ "crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/pidfile"
+	"github.com/docker/docker/pkg/system"
+	"github.com/docker/go-units"
+)
+
+// ficlone is the ioctl cloning a file on the filesystems which support
+// reflinks, such as btrfs and XFS.
+const ficlone = 0x40049409
+
+// contentAddressed matches the path, relative to the data root, of the files
+// stored under their digest: the configurations of the images. The other
+// sha256 directories, such as the distribution metadata of the pulled
+// images, are indexed by digest but do not hold the content of the digest.
+var contentAddressed = regexp.MustCompile(`^image/[^/]+/imagedb/content/sha256/[a-f0-9]{64}$`)
+
+// copyStats counts the files of a copy.
+type copyStats struct {
+	files  int
+	cloned int
+	size   int64
+}
+
+// Move moves the data root src of a stopped daemon to dst, which must not
+// exist.
+func Move(src, dst string, opts Options) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	dst, err = filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if dst == src || strings.HasPrefix(dst, src+string(filepath.Separator)) {
+		return fmt.Errorf("cannot move the data root %s into itself", src)
+	}
+
+	fi, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("%s is a symbolic link, move the directory it links to", src)
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", src)
+	}
+	if _, err := os.Lstat(dst); err == nil {
+		return fmt.Errorf("%s already exists", dst)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	parent := filepath.Dir(dst)
+	if _, err := os.Stat(parent); err != nil {
+		return err
+	}
+
+	if opts.Pidfile != "" {
+		pf, err := pidfile.New(opts.Pidfile)
+		if err != nil {
+			return err
+		}
+		defer pf.Remove()
+	}
+	if err := checkMounts(src); err != nil {
+		return err
+	}
+
+	same, err := sameDevice(src, parent)
+	if err != nil {
+		return err
+	}
+	if same {
+		opts.printf("Renaming %s to %s\n", src, dst)
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+		if opts.Link {
+			return os.Symlink(dst, src)
+		}
+		return nil
+	}
+
+	// The data root is copied next to its new location, so that it is
+	// renamed to it only once it is complete.
+	staging := dst + ".tmp"
+	if _, err := os.Lstat(staging); err == nil {
+		return fmt.Errorf("%s already exists, remove it to move the data root", staging)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	opts.printf("Copying %s to %s\n", src, staging)
+	stats, err := copyTree(src, staging)
+	if err == nil {
+		opts.printf("Copied %d files (%s), %d of them cloned\n", stats.files, units.HumanSize(float64(stats.size)), stats.cloned)
+		var verified int
+		verified, err = verifyTree(staging)
+		opts.printf("Verified %d content-addressed files\n", verified)
+	}
+	if err == nil {
+		err = os.Rename(staging, dst)
+	}
+	if err != nil {
+		os.RemoveAll(staging)
+		return err
+	}
+	opts.printf("Moved %s to %s\n", src, dst)
+
+	return switchSource(src, dst, opts)
+}
+
+// switchSource removes or keeps the copied source, and replaces it by a
+// symbolic link to its new location if it is requested.
+func switchSource(src, dst string, opts Options) error {
+	if !opts.Link {
+		if opts.KeepSource {
+			return nil
+		}
+		return os.RemoveAll(src)
+	}
+
+	old := src + ".old"
+	if err := os.Rename(src, old); err != nil {
+		return fmt.Errorf("the data root was moved to %s, but %s could not be replaced by a link to it: %v", dst, src, err)
+	}
+	if err := os.Symlink(dst, src); err != nil {
+		return err
+	}
+	if opts.KeepSource {
+		opts.printf("Kept the source in %s\n", old)
+		return nil
+	}
+	return os.RemoveAll(old)
+}
+
+func (opts Options) printf(format string, a ...interface{}) {
+	if opts.Out != nil {
+		fmt.Fprintf(opts.Out, format, a...)
+	}
+}
+
+// checkMounts checks that nothing is mounted in the data root, such as the
+// layers of a running container.
+func checkMounts(root string) error {
+	mounts, err := mount.GetMounts()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if strings.HasPrefix(m.Mountpoint, root+string(filepath.Separator)) {
+			return fmt.Errorf("%s is mounted, ensure docker is not running and unmount it", m.Mountpoint)
+		}
+	}
+	return nil
+}
+
+func sameDevice(a, b string) (bool, error) {
+	var sa, sb syscall.Stat_t
+	if err := syscall.Stat(a, &sa); err != nil {
+		return false, err
+	}
+	if err := syscall.Stat(b, &sb); err != nil {
+		return false, err
+	}
+	return sa.Dev == sb.Dev, nil
+}
+
+// copyTree copies the directory src to dst, preserving the hardlinks and
+// the metadata of the files.
+func copyTree(src, dst string) (copyStats, error) {
+	var stats copyStats
+
+	type inode struct {
+		dev uint64
+		ino uint64
+	}
+	links := make(map[inode]string)
+	var dirs []string
+
+	err := filepath.Walk(src, func(srcPath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, srcPath)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dst, rel)
+
+		stat, ok := fi.Sys().(*syscall.Stat_t)
+		if !ok {
+			return fmt.Errorf("unable to get the raw stat data of %s", srcPath)
+		}
+
+		switch fi.Mode() & os.ModeType {
+		case 0:
+			if stat.Nlink > 1 {
+				key := inode{dev: uint64(stat.Dev), ino: stat.Ino}
+				if first, ok := links[key]; ok {
+					return os.Link(first, dstPath)
+				}
+				links[key] = dstPath
+			}
+			cloned, err := copyFile(srcPath, dstPath, fi)
+			if err != nil {
+				return err
+			}
+			stats.files++
+			stats.size += fi.Size()
+			if cloned {
+				stats.cloned++
+			}
+		case os.ModeDir:
+			if err := os.Mkdir(dstPath, 0700); err != nil {
+				return err
+			}
+			dirs = append(dirs, srcPath)
+		case os.ModeSymlink:
+			link, err := os.Readlink(srcPath)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, dstPath); err != nil {
+				return err
+			}
+		case os.ModeNamedPipe:
+			if err := syscall.Mkfifo(dstPath, stat.Mode); err != nil {
+				return err
+			}
+		case os.ModeDevice, os.ModeDevice | os.ModeCharDevice:
+			// such as the whiteouts of the overlay layers
+			if err := syscall.Mknod(dstPath, stat.Mode, int(stat.Rdev)); err != nil {
+				return err
+			}
+		case os.ModeSocket:
+			// the sockets are only meaningful to the process listening
+			return nil
+		default:
+			return fmt.Errorf("unknown file type for %s", srcPath)
+		}
+
+		if fi.IsDir() {
+			// the times of the directories are set once their content
+			// is copied
+			return copyMetadata(srcPath, dstPath, fi, false)
+		}
+		return copyMetadata(srcPath, dstPath, fi, true)
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rel, err := filepath.Rel(src, dirs[i])
+		if err != nil {
+			return stats, err
+		}
+		fi, err := os.Lstat(dirs[i])
+		if err != nil {
+			return stats, err
+		}
+		if err := copyTimes(filepath.Join(dst, rel), fi); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// copyFile copies a regular file. The file is cloned if the filesystem
+// supports it, otherwise the blocks of zeros are skipped so that the holes
+// of sparse files, such as the loopback files of devicemapper, are
+// preserved.
+func copyFile(srcPath, dstPath string, fi os.FileInfo) (bool, error) {
+	srcFile, err := os.Open(srcPath)
+	if err != nil {
+		return false, err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return false, err
+	}
+	defer dstFile.Close()
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dstFile.Fd(), ficlone, srcFile.Fd()); errno == 0 {
+		return true, nil
+	}
+
+	buf := make([]byte, 32*1024)
+	zero := make([]byte, len(buf))
+	for {
+		n, err := io.ReadFull(srcFile, buf)
+		if n > 0 {
+			if bytes.Equal(buf[:n], zero[:n]) {
+				_, err = dstFile.Seek(int64(n), os.SEEK_CUR)
+			} else {
+				_, err = dstFile.Write(buf[:n])
+			}
+			if err != nil {
+				return false, err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	if err := dstFile.Truncate(fi.Size()); err != nil {
+		return false, err
+	}
+
+	dstInfo, err := dstFile.Stat()
+	if err != nil {
+		return false, err
+	}
+	if dstInfo.Size() != fi.Size() {
+		return false, fmt.Errorf("%s changed during the copy", srcPath)
+	}
+	return false, nil
+}
+
+// copyXattrs copies all the extended attributes of a file, such as its
+// capabilities, its SELinux label, its ACLs, the attributes of the overlay
+// storage drivers and the user ones.
+func copyXattrs(srcPath, dstPath string) error {
+	attrs, err := system.Llistxattr(srcPath)
+	if err == syscall.ENOTSUP {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, attr := range attrs {
+		data, err := system.Lgetxattr(srcPath, attr)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		if err := system.Lsetxattr(dstPath, attr, data, 0); err != nil {
+			return fmt.Errorf("failed to copy the extended attribute %s of %s: %v", attr, srcPath, err)
+		}
+	}
+	return nil
+}
+
+// copyMetadata copies the ownership, extended attributes, mode and,
+// optionally, times of a file.
+func copyMetadata(srcPath, dstPath string, fi os.FileInfo, times bool) error {
+	stat := fi.Sys().(*syscall.Stat_t)
+	if err := os.Lchown(dstPath, int(stat.Uid), int(stat.Gid)); err != nil {
+		return err
+	}
+	if err := copyXattrs(srcPath, dstPath); err != nil {
+		return err
+	}
+	// There is no Lchmod, and the mode is set after the ownership, which
+	// clears the setuid and setgid bits.
+	if fi.Mode()&os.ModeSymlink == 0 {
+		if err := os.Chmod(dstPath, fi.Mode()); err != nil {
+			return err
+		}
+	}
+	if !times {
+		return nil
+	}
+	return copyTimes(dstPath, fi)
+}
+
+func copyTimes(dstPath string, fi os.FileInfo) error {
+	stat := fi.Sys().(*syscall.Stat_t)
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return system.LUtimesNano(dstPath, []syscall.Timespec{stat.Atim, stat.Mtim})
+	}
+	aTime := time.Unix(int64(stat.Atim.Sec), int64(stat.Atim.Nsec))
+	mTime := time.Unix(int64(stat.Mtim.Sec), int64(stat.Mtim.Nsec))
+	return system.Chtimes(dstPath, aTime, mTime)
+}
+
+// verifyTree verifies the content-addressed files of the directory, the
+// image configurations stored under their digest, and returns their number.
+func verifyTree(root string) (int, error) {
+	var verified int
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || !contentAddressed.MatchString(filepath.ToSlash(rel)) {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		dgst, err := digest.FromReader(f)
+		if err != nil {
+			return err
+		}
+		if dgst.Hex() != fi.Name() {
+			return fmt.Errorf("%s does not match its digest, it is %s", rel, dgst)
+		}
+		verified++
+		return nil
+	})
+	return verified, err
+}
@@ -0,0 +1,195 @@
+// +build linux
+
+package dataroot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/pkg/system"
+)
+
+// createDataRoot creates a data root with a content-addressed file, a
+// hardlink, a symbolic link and a sparse file.
+func createDataRoot(t *testing.T, root string) string {
+	content := []byte(`{"architecture":"amd64"}`)
+	dgst := digest.FromBytes(content)
+
+	contentDir := filepath.Join(root, "image", "vfs", "imagedb", "content", "sha256")
+	if err := os.MkdirAll(contentDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(contentDir, dgst.Hex()), content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(filepath.Join(contentDir, dgst.Hex()), filepath.Join(root, "hardlink")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("image", filepath.Join(root, "symlink")); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(filepath.Join(root, "sparse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Truncate(64 * 1024 * 1024); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return dgst.Hex()
+}
+
+func TestCopyTree(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "dataroot-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+	hex := createDataRoot(t, src)
+
+	stats, err := copyTree(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.files != 2 {
+		t.Fatalf("expected 2 copied files, got %d", stats.files)
+	}
+
+	copied := filepath.Join(dst, "image", "vfs", "imagedb", "content", "sha256", hex)
+	var sc, sl syscall.Stat_t
+	if err := syscall.Stat(copied, &sc); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Stat(filepath.Join(dst, "hardlink"), &sl); err != nil {
+		t.Fatal(err)
+	}
+	if sc.Ino != sl.Ino {
+		t.Fatal("expected the hardlink to be preserved")
+	}
+	if link, err := os.Readlink(filepath.Join(dst, "symlink")); err != nil || link != "image" {
+		t.Fatalf("expected the symbolic link to be preserved, got %q, %v", link, err)
+	}
+
+	var ss syscall.Stat_t
+	if err := syscall.Stat(filepath.Join(dst, "sparse"), &ss); err != nil {
+		t.Fatal(err)
+	}
+	if ss.Size != 64*1024*1024 || ss.Blocks*512 >= ss.Size {
+		t.Fatalf("expected the sparse file to be preserved, got size %d and %d blocks", ss.Size, ss.Blocks)
+	}
+
+	if n, err := verifyTree(dst); err != nil || n != 1 {
+		t.Fatalf("expected 1 verified file, got %d, %v", n, err)
+	}
+	if err := ioutil.WriteFile(copied, []byte("corrupted"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyTree(dst); err == nil {
+		t.Fatal("expected an error for a corrupted content-addressed file")
+	}
+}
+
+
+func TestCopyXattrs(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "dataroot-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+	if err := ioutil.WriteFile(src, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(dst, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := system.Lsetxattr(src, "user.docker.test", []byte("value"), 0); err != nil {
+		if err == syscall.ENOTSUP {
+			t.Skip("the file system does not support the user extended attributes")
+		}
+		t.Fatal(err)
+	}
+
+	if err := copyXattrs(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	data, err := system.Lgetxattr(dst, "user.docker.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "value" {
+		t.Fatalf("expected the extended attribute to be copied, got %q", data)
+	}
+}
+func TestVerifyTreePulledImage(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "dataroot-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	createDataRoot(t, tmp)
+
+	// The distribution metadata of a pulled image is indexed by digest, but
+	// does not hold the content of the digest.
+	blobSum := digest.FromBytes([]byte("compressed layer"))
+	diffID := digest.FromBytes([]byte("layer"))
+	metadata := map[string]string{
+		filepath.Join("diffid-by-digest", "sha256", blobSum.Hex()):    diffID.String(),
+		filepath.Join("v2metadata-by-diffid", "sha256", diffID.Hex()): `[{"Digest":"` + blobSum.String() + `","SourceRepository":"docker.io/library/busybox"}]`,
+	}
+	for name, content := range metadata {
+		path := filepath.Join(tmp, "image", "vfs", "distribution", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n, err := verifyTree(tmp); err != nil || n != 1 {
+		t.Fatalf("expected only the image configuration to be verified, got %d, %v", n, err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "dataroot-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+	createDataRoot(t, src)
+
+	if err := Move(src, filepath.Join(src, "nested"), Options{}); err == nil {
+		t.Fatal("expected an error for a move of the data root into itself")
+	}
+	if err := Move(src, tmp, Options{}); err == nil {
+		t.Fatal("expected an error for an existing destination")
+	}
+
+	if err := Move(src, dst, Options{Link: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "hardlink")); err != nil {
+		t.Fatal(err)
+	}
+	if link, err := os.Readlink(src); err != nil || link != dst {
+		t.Fatalf("expected the source to link to %s, got %q, %v", dst, link, err)
+	}
+	if err := Move(src, filepath.Join(tmp, "other"), Options{}); err == nil {
+		t.Fatal("expected an error for a source which is a symbolic link")
+	}
+}
@@ -0,0 +1,10 @@
+// +build !linux
+
+package dataroot
+
+import "fmt"
+
+// Move is not supported on this platform.
+func Move(src, dst string, opts Options) error {
+	return fmt.Errorf("moving the data root is not supported on this platform")
+}
@@ -72,6 +72,14 @@ func (daemon *Daemon) rmLink(container *container.Container, name string) error
 // cleanupContainer unregisters a container from the daemon, stops stats
 // collection and cleanly removes contents and metadata from the filesystem.
 func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemove, removeVolume bool) (err error) {
+	container.Lock()
+	restoring := container.RestoreInProgress
+	container.Unlock()
+	if restoring {
+		err := fmt.Errorf("You cannot remove container %s while it is being restored", container.ID)
+		return errors.NewRequestConflictError(err)
+	}
+
 	if container.IsRunning() {
 		if !forceRemove {
 			err := fmt.Errorf("You cannot remove a running container %s. Stop the container before attempting removal or use -f", container.ID)
@@ -109,6 +117,8 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 			selinuxFreeLxcContexts(container.ProcessLabel)
 			daemon.idIndex.Delete(container.ID)
 			daemon.containers.Delete(container.ID)
+			daemon.filesystemUsage.delete(container.ID)
+			daemon.autoheal.reset(container.ID)
 			if e := daemon.removeMountPoints(container, removeVolume); e != nil {
 				logrus.Error(e)
 			}
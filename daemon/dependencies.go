@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+)
+
+// dependencyTimeout is the maximum time the restart of a container at daemon
+// start waits for its dependencies.
+const dependencyTimeout = 60 * time.Second
+
+// dependency is a container that must be started before the container that
+// depends on it, along with the condition to wait for.
+type dependency struct {
+	container *container.Container
+	condition string
+}
+
+// dependencies returns the dependencies of the container. The dependencies
+// that do not exist anymore are ignored.
+func (daemon *Daemon) dependencies(c *container.Container) []dependency {
+	var deps []dependency
+	for _, d := range c.HostConfig.DependsOn {
+		name, condition, err := runconfigopts.ParseDependsOn(d)
+		if err != nil {
+			continue
+		}
+		dep, err := daemon.GetContainer(name)
+		if err != nil {
+			logrus.Debugf("Ignoring dependency %s of container %s: %v", name, c.ID, err)
+			continue
+		}
+		deps = append(deps, dependency{container: dep, condition: condition})
+	}
+	return deps
+}
+
+// dependsOn returns whether the container depends, directly or through its
+// dependencies, on the container with the given name.
+func (daemon *Daemon) dependsOn(c *container.Container, name string, visited map[string]bool) bool {
+	if visited[c.ID] {
+		return false
+	}
+	visited[c.ID] = true
+
+	name = strings.TrimPrefix(name, "/")
+	for _, d := range c.HostConfig.DependsOn {
+		depName, _, err := runconfigopts.ParseDependsOn(d)
+		if err != nil {
+			continue
+		}
+		if depName == name {
+			return true
+		}
+		dep, err := daemon.GetContainer(depName)
+		if err != nil {
+			continue
+		}
+		if strings.TrimPrefix(dep.Name, "/") == name || daemon.dependsOn(dep, name, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyDependencies checks that the dependencies of a new container with the
+// given name exist, and that none of them depends on the new container.
+func (daemon *Daemon) verifyDependencies(name string, hostConfig *containertypes.HostConfig) error {
+	if hostConfig == nil {
+		return nil
+	}
+	name = strings.TrimPrefix(name, "/")
+	for _, d := range hostConfig.DependsOn {
+		depName, _, err := runconfigopts.ParseDependsOn(d)
+		if err != nil {
+			return err
+		}
+		if name != "" && depName == name {
+			return fmt.Errorf("Container %s cannot depend on itself", name)
+		}
+		dep, err := daemon.GetContainer(depName)
+		if err != nil {
+			return fmt.Errorf("Could not get dependency %s: %v", depName, err)
+		}
+		if name != "" && daemon.dependsOn(dep, name, make(map[string]bool)) {
+			return fmt.Errorf("Dependency cycle: %s depends on %s", depName, name)
+		}
+	}
+	return nil
+}
+
+// waitForDependencies waits for the dependencies of the container restarted
+// at daemon start to meet their condition, until the timeout expires. The
+// notifiers of the restarted containers are closed once they were started.
+func (daemon *Daemon) waitForDependencies(c *container.Container, notifiers map[*container.Container]chan struct{}, timeout time.Duration) {
+	if daemon.dependsOn(c, c.Name, make(map[string]bool)) {
+		logrus.Errorf("Ignoring the dependencies of container %s: dependency cycle", c.ID)
+		return
+	}
+
+	deadline := time.Now().Add(timeout)
+	for _, d := range daemon.dependencies(c) {
+		notifier, exists := notifiers[d.container]
+		if exists {
+			select {
+			case <-notifier:
+			case <-time.After(deadline.Sub(time.Now())):
+				logrus.Warnf("Timed out waiting for dependency %s of container %s to start", d.container.ID, c.ID)
+				return
+			}
+		}
+		// A dependency that is not restarted cannot become healthy.
+		if d.condition != runconfigopts.DependencyHealthy || (!exists && !d.container.IsRunning()) {
+			continue
+		}
+		for !isHealthy(d.container) {
+			if time.Now().After(deadline) {
+				logrus.Warnf("Timed out waiting for dependency %s of container %s to be healthy", d.container.ID, c.ID)
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+}
+
+// isHealthy returns whether the container is running and healthy.
+func isHealthy(c *container.Container) bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.Running && c.Health != nil && c.Health.Status == types.Healthy
+}
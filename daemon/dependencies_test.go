@@ -0,0 +1,116 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/registrar"
+	"github.com/docker/docker/pkg/truncindex"
+)
+
+func newDependenciesDaemon(t *testing.T, containers map[string][]string) *Daemon {
+	daemon := &Daemon{
+		containers: container.NewMemoryStore(),
+		idIndex:    truncindex.NewTruncIndex([]string{}),
+		nameIndex:  registrar.NewRegistrar(),
+	}
+	for name, dependsOn := range containers {
+		c := &container.Container{
+			CommonContainer: container.CommonContainer{
+				ID:         name + "-id",
+				Name:       "/" + name,
+				State:      container.NewState(),
+				HostConfig: &containertypes.HostConfig{DependsOn: dependsOn},
+			},
+		}
+		daemon.containers.Add(c.ID, c)
+		if err := daemon.idIndex.Add(c.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := daemon.reserveName(c.ID, c.Name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return daemon
+}
+
+func TestVerifyDependencies(t *testing.T) {
+	daemon := newDependenciesDaemon(t, map[string][]string{
+		"db":    nil,
+		"app":   {"db:healthy"},
+		"proxy": {"app", "web"},
+	})
+
+	valid := [][]string{
+		nil,
+		{"db"},
+		{"/db:started", "app:healthy"},
+	}
+	for _, dependsOn := range valid {
+		if err := daemon.verifyDependencies("web", &containertypes.HostConfig{DependsOn: dependsOn}); err != nil {
+			t.Fatalf("Expected dependencies %v to be valid, got %v", dependsOn, err)
+		}
+	}
+
+	invalid := map[string][]string{
+		"cannot depend on itself":        {"web"},
+		"Could not get dependency other": {"db", "other"},
+		"Dependency cycle":               {"proxy"},
+		"invalid condition":              {"db:stopped"},
+	}
+	for expected, dependsOn := range invalid {
+		err := daemon.verifyDependencies("web", &containertypes.HostConfig{DependsOn: dependsOn})
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected dependencies %v to fail with %q, got %v", dependsOn, expected, err)
+		}
+	}
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	daemon := newDependenciesDaemon(t, map[string][]string{
+		"db":     nil,
+		"app":    {"db"},
+		"first":  {"second"},
+		"second": {"first"},
+	})
+	db, _ := daemon.GetContainer("db")
+	app, _ := daemon.GetContainer("app")
+	first, _ := daemon.GetContainer("first")
+	second, _ := daemon.GetContainer("second")
+
+	notifiers := map[*container.Container]chan struct{}{
+		db:  make(chan struct{}),
+		app: make(chan struct{}),
+	}
+	done := make(chan struct{})
+	go func() {
+		daemon.waitForDependencies(app, notifiers, 10*time.Second)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Expected to wait for the dependency to start")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(notifiers[db])
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected to stop waiting once the dependency started")
+	}
+
+	// Containers in a dependency cycle do not wait for each other.
+	done = make(chan struct{})
+	go func() {
+		daemon.waitForDependencies(first, map[*container.Container]chan struct{}{second: make(chan struct{})}, 10*time.Second)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected not to wait for dependencies in a cycle")
+	}
+}
@@ -0,0 +1,148 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/docker/plugin/getter"
+)
+
+// deviceDriverImplements is the capability of the device driver plugins.
+const deviceDriverImplements = "DeviceDriver"
+
+// deviceAllocateRequest is the request of the DeviceDriver.Allocate call.
+type deviceAllocateRequest struct {
+	ContainerID string
+	Count       int
+	DeviceIDs   []string
+	Options     map[string]string
+}
+
+// deviceMount is a mount injected into the container by a device driver.
+type deviceMount struct {
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// deviceAllocation is what the device drivers inject into the container,
+// as returned by the DeviceDriver.Allocate call.
+type deviceAllocation struct {
+	Devices []containertypes.DeviceMapping
+	Env     []string
+	Mounts  []deviceMount
+}
+
+type deviceAllocateResponse struct {
+	deviceAllocation
+	Err string
+}
+
+// deviceReleaseRequest is the request of the DeviceDriver.Release call.
+type deviceReleaseRequest struct {
+	ContainerID string
+}
+
+type deviceReleaseResponse struct {
+	Err string
+}
+
+// deviceDriver calls a device driver plugin.
+type deviceDriver struct {
+	name   string
+	client *plugins.Client
+}
+
+func (d *deviceDriver) allocate(containerID string, req containertypes.DeviceRequest) (*deviceAllocation, error) {
+	var ret deviceAllocateResponse
+	if err := d.client.Call("DeviceDriver.Allocate", deviceAllocateRequest{
+		ContainerID: containerID,
+		Count:       req.Count,
+		DeviceIDs:   req.DeviceIDs,
+		Options:     req.Options,
+	}, &ret); err != nil {
+		return nil, err
+	}
+	if ret.Err != "" {
+		return nil, fmt.Errorf("%s", ret.Err)
+	}
+	return &ret.deviceAllocation, nil
+}
+
+func (d *deviceDriver) release(containerID string) error {
+	var ret deviceReleaseResponse
+	if err := d.client.Call("DeviceDriver.Release", deviceReleaseRequest{ContainerID: containerID}, &ret); err != nil {
+		return err
+	}
+	if ret.Err != "" {
+		return fmt.Errorf("%s", ret.Err)
+	}
+	return nil
+}
+
+// getDeviceDriver returns the device driver plugin with the given name.
+func (daemon *Daemon) getDeviceDriver(name string) (*deviceDriver, error) {
+	p, err := daemon.pluginStore.Get(name, deviceDriverImplements, getter.LOOKUP)
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up device driver plugin %s: %v", name, err)
+	}
+	return &deviceDriver{name: p.Name(), client: p.Client()}, nil
+}
+
+// allocateDevices requests the devices of the container from the device
+// drivers, and merges what they inject into the container.
+func (daemon *Daemon) allocateDevices(c *container.Container) (*deviceAllocation, error) {
+	alloc := &deviceAllocation{}
+	for _, req := range c.HostConfig.DeviceRequests {
+		d, err := daemon.getDeviceDriver(req.Driver)
+		if err != nil {
+			return nil, err
+		}
+		a, err := d.allocate(c.ID, req)
+		if err != nil {
+			return nil, fmt.Errorf("Error allocating devices from device driver %s: %v", req.Driver, err)
+		}
+		alloc.Devices = append(alloc.Devices, a.Devices...)
+		alloc.Env = append(alloc.Env, a.Env...)
+		alloc.Mounts = append(alloc.Mounts, a.Mounts...)
+	}
+	return alloc, nil
+}
+
+// releaseDevices releases the devices allocated to the container by the
+// device drivers. A driver may be asked to release a container it did not
+// allocate devices to, if the start of the container failed.
+func (daemon *Daemon) releaseDevices(c *container.Container) {
+	released := make(map[string]bool)
+	for _, req := range c.HostConfig.DeviceRequests {
+		if released[req.Driver] {
+			continue
+		}
+		released[req.Driver] = true
+
+		d, err := daemon.getDeviceDriver(req.Driver)
+		if err == nil {
+			err = d.release(c.ID)
+		}
+		if err != nil {
+			logrus.Warnf("%s cleanup: Failed to release devices of device driver %s: %v", c.ID, req.Driver, err)
+		}
+	}
+}
+
+// mounts returns the mounts injected into the container by the device
+// drivers.
+func (alloc *deviceAllocation) mounts() []container.Mount {
+	var mounts []container.Mount
+	for _, m := range alloc.Mounts {
+		mounts = append(mounts, container.Mount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			Writable:    !m.ReadOnly,
+		})
+	}
+	return mounts
+}
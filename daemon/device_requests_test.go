@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+func TestDeviceDriver(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var released string
+	mux.HandleFunc("/DeviceDriver.Allocate", func(w http.ResponseWriter, r *http.Request) {
+		var req deviceAllocateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		if req.Count > 1 {
+			fmt.Fprintln(w, `{"Err": "not enough devices"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"Devices": [{"PathOnHost": "/dev/gpu0"}], "Env": ["GPU_DEVICES=0", "GPU_MODE=%s"], "Mounts": [{"Source": "/usr/lib/gpu", "Destination": "/usr/lib/gpu", "ReadOnly": true}]}`, req.Options["mode"])
+	})
+	mux.HandleFunc("/DeviceDriver.Release", func(w http.ResponseWriter, r *http.Request) {
+		var req deviceReleaseRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		released = req.ContainerID
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
+		fmt.Fprintln(w, `{}`)
+	})
+
+	u, _ := url.Parse(server.URL)
+	client, err := plugins.NewClient("tcp://"+u.Host, &tlsconfig.Options{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &deviceDriver{name: "gpu", client: client}
+
+	if _, err := d.allocate("id", containertypes.DeviceRequest{Driver: "gpu", Count: 2}); err == nil || err.Error() != "not enough devices" {
+		t.Fatalf("expected the error of the driver, got %v", err)
+	}
+
+	alloc, err := d.allocate("id", containertypes.DeviceRequest{Driver: "gpu", Count: 1, Options: map[string]string{"mode": "compute"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alloc.Devices) != 1 || alloc.Devices[0].PathOnHost != "/dev/gpu0" {
+		t.Fatalf("unexpected devices: %v", alloc.Devices)
+	}
+	if !reflect.DeepEqual(alloc.Env, []string{"GPU_DEVICES=0", "GPU_MODE=compute"}) {
+		t.Fatalf("unexpected environment variables: %v", alloc.Env)
+	}
+	mounts := alloc.mounts()
+	if len(mounts) != 1 || mounts[0].Destination != "/usr/lib/gpu" || mounts[0].Writable {
+		t.Fatalf("unexpected mounts: %v", mounts)
+	}
+
+	if err := d.release("id"); err != nil {
+		t.Fatal(err)
+	}
+	if released != "id" {
+		t.Fatalf("expected the devices of the container to be released, got %q", released)
+	}
+}
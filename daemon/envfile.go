@@ -0,0 +1,165 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// redactedEnvValue replaces the values of the variables of the environment
+// files where they must not be revealed.
+const redactedEnvValue = "<redacted>"
+
+type errNoSuchEnvFile struct {
+	ref string
+}
+
+func (e errNoSuchEnvFile) Error() string {
+	return fmt.Sprintf("No such environment file: %s", e.ref)
+}
+
+// validateEnvFileVariable checks that v is an environment variable in the
+// KEY=VALUE form.
+func validateEnvFileVariable(v string) error {
+	kv := strings.SplitN(v, "=", 2)
+	if len(kv) != 2 || kv[0] == "" || strings.ContainsAny(kv[0], " \t\n") {
+		return fmt.Errorf("invalid environment variable %q, expected KEY=VALUE", redactEnv(v))
+	}
+	return nil
+}
+
+// redactEnv returns the name of the environment variable v, without its
+// value.
+func redactEnv(v string) string {
+	return strings.SplitN(v, "=", 2)[0]
+}
+
+// envFileView returns the environment file f as returned by the API: the
+// names of its variables, and their values if reveal is set.
+func envFileView(f *types.EnvFile, reveal bool) *types.EnvFile {
+	view := &types.EnvFile{
+		ID:      f.ID,
+		Name:    f.Name,
+		Created: f.Created,
+	}
+	for _, v := range f.Env {
+		view.Keys = append(view.Keys, redactEnv(v))
+	}
+	if reveal {
+		view.Env = append([]string(nil), f.Env...)
+	}
+	return view
+}
+
+// EnvFileCreate stores a new environment file.
+func (daemon *Daemon) EnvFileCreate(req *types.EnvFileCreateRequest) (*types.EnvFile, error) {
+	if req.Name != "" && !validContainerNamePattern.MatchString(req.Name) {
+		return nil, errors.NewBadRequestError(fmt.Errorf("Invalid environment file name (%s), only %s are allowed", req.Name, validContainerNameChars))
+	}
+	for _, v := range req.Env {
+		if err := validateEnvFileVariable(v); err != nil {
+			return nil, errors.NewBadRequestError(err)
+		}
+	}
+	if req.Name != "" && daemon.envFiles.Get(req.Name) != nil {
+		return nil, errors.NewRequestConflictError(fmt.Errorf("environment file %s already exists", req.Name))
+	}
+
+	f := &types.EnvFile{
+		ID:      stringid.GenerateRandomID(),
+		Name:    req.Name,
+		Created: time.Now().UTC(),
+		Env:     req.Env,
+	}
+	if err := daemon.envFiles.Add(f); err != nil {
+		return nil, err
+	}
+	return envFileView(f, false), nil
+}
+
+// EnvFileInspect returns the environment file of the given ID or name. The
+// values of its variables are only returned if reveal is set.
+func (daemon *Daemon) EnvFileInspect(ref string, reveal bool) (*types.EnvFile, error) {
+	f := daemon.envFiles.Get(ref)
+	if f == nil {
+		return nil, errors.NewRequestNotFoundError(errNoSuchEnvFile{ref})
+	}
+	return envFileView(f, reveal), nil
+}
+
+// EnvFiles returns the environment files, without the values of their
+// variables.
+func (daemon *Daemon) EnvFiles() []*types.EnvFile {
+	files := []*types.EnvFile{}
+	for _, f := range daemon.envFiles.List() {
+		files = append(files, envFileView(f, false))
+	}
+	return files
+}
+
+// EnvFileRm removes the environment file of the given ID or name. It fails
+// if a container references it.
+func (daemon *Daemon) EnvFileRm(ref string) error {
+	f := daemon.envFiles.Get(ref)
+	if f == nil {
+		return errors.NewRequestNotFoundError(errNoSuchEnvFile{ref})
+	}
+	for _, c := range daemon.List() {
+		for _, id := range c.HostConfig.EnvFileIDs {
+			if id == f.ID {
+				return errors.NewRequestConflictError(fmt.Errorf("environment file %s is in use by container %s", ref, stringid.TruncateID(c.ID)))
+			}
+		}
+	}
+	return daemon.envFiles.Remove(f.ID)
+}
+
+// verifyEnvFiles checks that the environment files referenced by a new
+// container exist, and replaces their names by their IDs.
+func (daemon *Daemon) verifyEnvFiles(hostConfig *containertypes.HostConfig) error {
+	if hostConfig == nil {
+		return nil
+	}
+	for i, ref := range hostConfig.EnvFileIDs {
+		f := daemon.envFiles.Get(ref)
+		if f == nil {
+			return errors.NewRequestNotFoundError(errNoSuchEnvFile{ref})
+		}
+		hostConfig.EnvFileIDs[i] = f.ID
+	}
+	return nil
+}
+
+// envFileVariables returns the variables of the environment files of the
+// container, in their order, which are set in its processes over the
+// variables of its configuration.
+func (daemon *Daemon) envFileVariables(c *container.Container) ([]string, error) {
+	var env []string
+	for _, id := range c.HostConfig.EnvFileIDs {
+		f := daemon.envFiles.Get(id)
+		if f == nil {
+			return nil, fmt.Errorf("the environment file %s of the container does not exist anymore", id)
+		}
+		env = append(env, f.Env...)
+	}
+	return env, nil
+}
+
+// redactedEnvFileVariables returns the variables of the environment files of
+// the container, as envFileVariables does, with their values redacted.
+func (daemon *Daemon) redactedEnvFileVariables(c *container.Container) ([]string, error) {
+	env, err := daemon.envFileVariables(c)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range env {
+		env[i] = redactEnv(v) + "=" + redactedEnvValue
+	}
+	return env, nil
+}
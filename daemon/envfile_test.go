@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/envfiles"
+)
+
+func TestValidateEnvFileVariable(t *testing.T) {
+	for _, v := range []string{"KEY=value", "KEY=", "KEY=a=b"} {
+		if err := validateEnvFileVariable(v); err != nil {
+			t.Errorf("%s: %v", v, err)
+		}
+	}
+	for _, v := range []string{"KEY", "=value", "MY KEY=value"} {
+		if err := validateEnvFileVariable(v); err == nil {
+			t.Errorf("expected an error for %q", v)
+		}
+	}
+	// The value is not part of the error.
+	if err := validateEnvFileVariable("MY PASSWORD=secret"); err == nil || strings.Contains(err.Error(), "secret") {
+		t.Fatalf("expected an error without the value, got %v", err)
+	}
+}
+
+func TestEnvFileView(t *testing.T) {
+	f := &types.EnvFile{ID: "id", Name: "db", Env: []string{"USER=admin", "PASSWORD=secret"}}
+
+	view := envFileView(f, false)
+	if len(view.Keys) != 2 || view.Keys[0] != "USER" || view.Keys[1] != "PASSWORD" {
+		t.Fatalf("expected the keys of the variables, got %v", view.Keys)
+	}
+	if len(view.Env) != 0 {
+		t.Fatalf("expected the values of the variables to be redacted, got %v", view.Env)
+	}
+
+	view = envFileView(f, true)
+	if len(view.Env) != 2 || view.Env[1] != "PASSWORD=secret" {
+		t.Fatalf("expected the variables to be revealed, got %v", view.Env)
+	}
+}
+
+func TestRedactedEnvFileVariables(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "envfile-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	store, err := envfiles.New(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	daemon := &Daemon{envFiles: store}
+	f, err := daemon.EnvFileCreate(&types.EnvFileCreateRequest{Name: "db", Env: []string{"USER=admin", "PASSWORD=secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &container.Container{HostConfig: &containertypes.HostConfig{EnvFileIDs: []string{f.ID}}}
+	env, err := daemon.redactedEnvFileVariables(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(env) != 2 || env[0] != "USER=<redacted>" || env[1] != "PASSWORD=<redacted>" {
+		t.Fatalf("expected the values of the variables to be redacted, got %v", env)
+	}
+}
@@ -0,0 +1,146 @@
+// Package envfiles stores the environment files uploaded to the daemon, the
+// sets of environment variables given to the containers which reference
+// them with --env-file-id.
+//
+// The variables are stored apart from the configuration of the containers,
+// one file per environment file, only readable by root, so that their values
+// are not written in the configuration of the containers nor returned by
+// their inspection.
+package envfiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+// Store is the set of the environment files, persisted in a directory.
+type Store struct {
+	mu    sync.Mutex
+	root  string
+	files map[string]*types.EnvFile
+}
+
+// New returns the store of the environment files in the given directory,
+// which is created if it does not exist.
+func New(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	s := &Store{root: root, files: make(map[string]*types.EnvFile)}
+
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(root, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var f types.EnvFile
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("invalid environment file %s: %v", e.Name(), err)
+		}
+		s.files[f.ID] = &f
+	}
+	return s, nil
+}
+
+// path returns the path of the environment file id.
+func (s *Store) path(id string) string {
+	return filepath.Join(s.root, id+".json")
+}
+
+// Get returns the environment file of the given ID or name, or nil if there
+// is no such environment file.
+func (s *Store) Get(idOrName string) *types.EnvFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(idOrName)
+}
+
+func (s *Store) get(idOrName string) *types.EnvFile {
+	if f, ok := s.files[idOrName]; ok {
+		return f
+	}
+	if idOrName == "" {
+		return nil
+	}
+	for _, f := range s.files {
+		if f.Name == idOrName {
+			return f
+		}
+	}
+	return nil
+}
+
+// List returns the environment files, sorted by name and ID.
+func (s *Store) List() []*types.EnvFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files := make([]*types.EnvFile, 0, len(s.files))
+	for _, f := range s.files {
+		files = append(files, f)
+	}
+	sort.Sort(byName(files))
+	return files
+}
+
+type byName []*types.EnvFile
+
+func (r byName) Len() int      { return len(r) }
+func (r byName) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r byName) Less(i, j int) bool {
+	if r[i].Name != r[j].Name {
+		return r[i].Name < r[j].Name
+	}
+	return r[i].ID < r[j].ID
+}
+
+// Add adds the environment file to the store. It fails if an environment
+// file of the same ID or name exists.
+func (s *Store) Add(f *types.EnvFile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.get(f.ID) != nil {
+		return fmt.Errorf("environment file %s already exists", f.ID)
+	}
+	if f.Name != "" && s.get(f.Name) != nil {
+		return fmt.Errorf("environment file %s already exists", f.Name)
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := ioutils.AtomicWriteFile(s.path(f.ID), b, 0600); err != nil {
+		return err
+	}
+	s.files[f.ID] = f
+	return nil
+}
+
+// Remove removes the environment file id from the store.
+func (s *Store) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	delete(s.files, id)
+	return nil
+}
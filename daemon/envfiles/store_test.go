@@ -0,0 +1,65 @@
+package envfiles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestStore(t *testing.T) {
+	root, err := ioutil.TempDir("", "envfiles-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	s, err := New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(&types.EnvFile{ID: "id1", Name: "db", Env: []string{"PASSWORD=secret"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(&types.EnvFile{ID: "id2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(&types.EnvFile{ID: "id3", Name: "db"}); err == nil {
+		t.Fatal("expected an error adding an environment file with the name of another one")
+	}
+
+	fi, err := os.Stat(filepath.Join(root, "id1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Fatalf("expected the environment file to be only readable by its owner, got %v", fi.Mode())
+	}
+
+	// The environment files are reloaded from disk.
+	s, err = New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := s.List()
+	if len(files) != 2 || files[0].ID != "id2" || files[1].ID != "id1" {
+		t.Fatalf("expected the environment files id2 and id1, got %+v", files)
+	}
+	for _, ref := range []string{"id1", "db"} {
+		if f := s.Get(ref); f == nil || f.ID != "id1" || len(f.Env) != 1 || f.Env[0] != "PASSWORD=secret" {
+			t.Fatalf("expected the environment file id1 for %s, got %+v", ref, f)
+		}
+	}
+
+	if err := s.Remove("id1"); err != nil {
+		t.Fatal(err)
+	}
+	if f := s.Get("db"); f != nil {
+		t.Fatalf("expected the environment file id1 to be removed, got %+v", f)
+	}
+	if _, err := os.Stat(filepath.Join(root, "id1.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected the file of id1 to be removed, got %v", err)
+	}
+}
@@ -29,6 +29,7 @@ func (daemon *Daemon) LogContainerEventWithAttributes(container *container.Conta
 		Attributes: attributes,
 	}
 	daemon.EventsService.Log(action, events.ContainerEventType, actor)
+	daemon.mirrorContainer(container, action)
 }
 
 // LogImageEvent generates an event related to an image with only the default attributes.
@@ -95,6 +96,18 @@ func (daemon *Daemon) LogNetworkEventWithAttributes(nw libnetwork.Network, actio
 	daemon.EventsService.Log(action, events.NetworkEventType, actor)
 }
 
+// LogAuditEvent generates an audit event of the daemon for a state-changing
+// API request, described by the attributes.
+func (daemon *Daemon) LogAuditEvent(attributes map[string]string) {
+	if daemon.EventsService != nil {
+		actor := events.Actor{
+			ID:         daemon.ID,
+			Attributes: attributes,
+		}
+		daemon.EventsService.Log("audit", events.DaemonEventType, actor)
+	}
+}
+
 // LogDaemonEventWithAttributes generates an event related to the daemon itself with specific given attributes.
 func (daemon *Daemon) LogDaemonEventWithAttributes(action string, attributes map[string]string) {
 	if daemon.EventsService != nil {
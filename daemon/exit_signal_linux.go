@@ -0,0 +1,30 @@
+package daemon
+
+import (
+	"sort"
+
+	"github.com/docker/docker/pkg/signal"
+)
+
+// exitSignal returns the name of the signal which killed the process of a
+// container exiting with exitCode, or "" if the process exited on its own.
+// The runtime reports the processes killed by a signal with the exit code
+// 128 plus the number of the signal.
+func exitSignal(exitCode int) string {
+	if exitCode <= 128 {
+		return ""
+	}
+	var names []string
+	for name, s := range signal.SignalMap {
+		if int(s) == exitCode-128 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	// Some signals have aliases, as IOT for ABRT, the usual name of which
+	// comes first.
+	sort.Strings(names)
+	return "SIG" + names[0]
+}
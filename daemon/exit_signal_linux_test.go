@@ -0,0 +1,23 @@
+package daemon
+
+import "testing"
+
+func TestExitSignal(t *testing.T) {
+	cases := map[int]string{
+		0:   "",
+		1:   "",
+		128: "",
+		130: "SIGINT",
+		134: "SIGABRT",
+		137: "SIGKILL",
+		143: "SIGTERM",
+		145: "SIGCHLD",
+		157: "SIGIO",
+		255: "",
+	}
+	for exitCode, expected := range cases {
+		if signal := exitSignal(exitCode); signal != expected {
+			t.Fatalf("expected the exit code %d to report %q, got %q", exitCode, expected, signal)
+		}
+	}
+}
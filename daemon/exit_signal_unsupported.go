@@ -0,0 +1,9 @@
+// +build !linux
+
+package daemon
+
+// exitSignal returns "": the exit codes of the containers do not report the
+// signals which killed them on this platform.
+func exitSignal(exitCode int) string {
+	return ""
+}
@@ -1,23 +1,45 @@
 package daemon
 
 import (
+	"archive/tar"
 	"fmt"
 	"io"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 )
 
+const (
+	// ExportFormatTar is the format of the exports which are a tar archive
+	// of the filesystem of the container, the default.
+	ExportFormatTar = "tar"
+	// ExportFormatOCIBundle is the format of the exports which are an OCI
+	// runtime bundle, with the filesystem of the container in its rootfs
+	// directory and a config.json generated from its configuration.
+	ExportFormatOCIBundle = "oci-bundle"
+)
+
 // ContainerExport writes the contents of the container to the given
-// writer. An error is returned if the container cannot be found.
-func (daemon *Daemon) ContainerExport(name string, out io.Writer) error {
+// writer, in the given format. An error is returned if the container
+// cannot be found.
+func (daemon *Daemon) ContainerExport(name, format string, out io.Writer) error {
+	switch format {
+	case "", ExportFormatTar, ExportFormatOCIBundle:
+	default:
+		return errors.NewBadRequestError(fmt.Errorf("invalid export format %q, it must be %s or %s", format, ExportFormatTar, ExportFormatOCIBundle))
+	}
+
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
 	}
 
-	data, err := daemon.containerExport(container)
+	data, err := daemon.containerExport(container, format)
 	if err != nil {
 		return fmt.Errorf("Error exporting container %s: %v", name, err)
 	}
@@ -30,11 +52,20 @@ func (daemon *Daemon) ContainerExport(name string, out io.Writer) error {
 	return nil
 }
 
-func (daemon *Daemon) containerExport(container *container.Container) (archive.Archive, error) {
+func (daemon *Daemon) containerExport(container *container.Container, format string) (archive.Archive, error) {
 	if err := daemon.Mount(container); err != nil {
 		return nil, err
 	}
 
+	var config []byte
+	if format == ExportFormatOCIBundle {
+		var err error
+		if config, err = daemon.bundleConfig(container); err != nil {
+			daemon.Unmount(container)
+			return nil, err
+		}
+	}
+
 	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
 	archive, err := archive.TarWithOptions(container.BaseFS, &archive.TarOptions{
 		Compression: archive.Uncompressed,
@@ -45,11 +76,86 @@ func (daemon *Daemon) containerExport(container *container.Container) (archive.A
 		daemon.Unmount(container)
 		return nil, err
 	}
-	arch := ioutils.NewReadCloserWrapper(archive, func() error {
-		err := archive.Close()
+	data := io.ReadCloser(archive)
+	if config != nil {
+		data = bundleArchive(config, archive)
+	}
+	arch := ioutils.NewReadCloserWrapper(data, func() error {
+		err := data.Close()
 		daemon.Unmount(container)
 		return err
 	})
 	daemon.LogContainerEvent(container, "export")
 	return arch, err
 }
+
+// bundleArchive returns a tar archive of an OCI runtime bundle made of the
+// config.json config and of the root filesystem in the tar archive rootfs.
+// Closing it closes rootfs.
+func bundleArchive(config []byte, rootfs io.ReadCloser) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBundle(pw, config, rootfs))
+	}()
+	return ioutils.NewReadCloserWrapper(pr, func() error {
+		pr.Close()
+		return rootfs.Close()
+	})
+}
+
+func writeBundle(w io.Writer, config []byte, rootfs io.Reader) error {
+	tw := tar.NewWriter(w)
+	now := time.Now()
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "config.json",
+		Mode:     0644,
+		Size:     int64(len(config)),
+		ModTime:  now,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(config); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "rootfs/",
+		Mode:     0755,
+		ModTime:  now,
+		Typeflag: tar.TypeDir,
+	}); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(rootfs)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		hdr.Name = bundlePath(hdr.Name)
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = bundlePath(hdr.Linkname)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// bundlePath returns the path in a bundle of a path in its root filesystem.
+func bundlePath(name string) string {
+	p := path.Join("rootfs", name)
+	if strings.HasSuffix(name, "/") {
+		p += "/"
+	}
+	return p
+}
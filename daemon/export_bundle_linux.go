@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/oci"
+	"github.com/docker/docker/utils"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// bundleConfig returns the config.json of an OCI runtime bundle running the
+// container from the rootfs directory next to it. The container must be
+// mounted.
+//
+// The configuration is portable: it references nothing on the host, so the
+// volumes, the networks, the cgroup parent and the AppArmor and SELinux
+// labels of the container are not part of it, and it gets its own
+// namespaces unless it shares those of the host. The variables of the
+// environment files of the container are part of it with their values
+// redacted, as in the spec returned by ContainerSpec.
+func (daemon *Daemon) bundleConfig(c *container.Container) ([]byte, error) {
+	s := oci.DefaultSpec()
+	s.Root = specs.Root{
+		Path:     "rootfs",
+		Readonly: c.HostConfig.ReadonlyRootfs,
+	}
+	cwd := c.Config.WorkingDir
+	if len(cwd) == 0 {
+		cwd = "/"
+	}
+	s.Process.Args = append([]string{c.Path}, c.Args...)
+	s.Process.Cwd = cwd
+	envFileEnv, err := daemon.redactedEnvFileVariables(c)
+	if err != nil {
+		return nil, err
+	}
+	s.Process.Env = utils.ReplaceOrAppendEnvValues(c.CreateDaemonEnvironment(c.Config.Tty, nil), envFileEnv)
+	s.Process.Terminal = c.Config.Tty
+	s.Process.NoNewPrivileges = c.NoNewPrivileges
+	s.Hostname = c.Config.Hostname
+
+	if err := setResources(&s, c.HostConfig.Resources); err != nil {
+		return nil, fmt.Errorf("linux runtime spec resources: %v", err)
+	}
+	s.Linux.Resources.OOMScoreAdj = &c.HostConfig.OomScoreAdj
+	s.Linux.Sysctl = c.HostConfig.Sysctls
+	if err := setDevices(&s, c); err != nil {
+		return nil, fmt.Errorf("linux runtime spec devices: %v", err)
+	}
+	if err := setRlimits(daemon, &s, c); err != nil {
+		return nil, fmt.Errorf("linux runtime spec rlimits: %v", err)
+	}
+	if err := setUser(&s, c); err != nil {
+		return nil, fmt.Errorf("linux spec user: %v", err)
+	}
+	if err := setCapabilities(&s, c); err != nil {
+		return nil, fmt.Errorf("linux spec capabilities: %v", err)
+	}
+	if err := setSeccomp(daemon, &s, c); err != nil {
+		return nil, fmt.Errorf("linux seccomp: %v", err)
+	}
+
+	if c.HostConfig.NetworkMode.IsHost() {
+		delNamespace(&s, specs.NamespaceType("network"))
+	}
+	if c.HostConfig.IpcMode.IsHost() {
+		delNamespace(&s, specs.NamespaceType("ipc"))
+	}
+	if c.HostConfig.PidMode.IsHost() {
+		delNamespace(&s, specs.NamespaceType("pid"))
+	}
+	if c.HostConfig.UTSMode.IsHost() {
+		delNamespace(&s, specs.NamespaceType("uts"))
+		s.Hostname = ""
+	}
+
+	// The /dev/shm of the container is not shared with the host in the
+	// bundle.
+	shmOptions := []string{"nosuid", "noexec", "nodev", "mode=1777"}
+	if c.HostConfig.ShmSize > 0 {
+		shmOptions = append(shmOptions, "size="+strconv.FormatInt(c.HostConfig.ShmSize, 10))
+	}
+	s.Mounts = append(s.Mounts, specs.Mount{
+		Destination: "/dev/shm",
+		Type:        "tmpfs",
+		Source:      "shm",
+		Options:     shmOptions,
+	})
+	ms := c.TmpfsMounts()
+	sort.Sort(mounts(ms))
+	if err := setMounts(daemon, &s, c, ms); err != nil {
+		return nil, fmt.Errorf("linux mounts: %v", err)
+	}
+
+	return json.MarshalIndent(s, "", "\t")
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/container"
+)
+
+// bundleConfig is not supported on this platform.
+func (daemon *Daemon) bundleConfig(c *container.Container) ([]byte, error) {
+	return nil, fmt.Errorf("exporting a container as an OCI runtime bundle is not supported on this platform")
+}
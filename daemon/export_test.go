@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestWriteBundle(t *testing.T) {
+	var rootfs bytes.Buffer
+	tw := tar.NewWriter(&rootfs)
+	for _, hdr := range []*tar.Header{
+		{Name: "bin/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "bin/sh", Typeflag: tar.TypeReg, Mode: 0755, Size: 2},
+		{Name: "bin/bash", Typeflag: tar.TypeLink, Linkname: "bin/sh"},
+		{Name: "bin/ash", Typeflag: tar.TypeSymlink, Linkname: "/bin/sh"},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			tw.Write([]byte("sh"))
+		}
+	}
+	tw.Close()
+
+	var bundle bytes.Buffer
+	if err := writeBundle(&bundle, []byte(`{"ociVersion":"1.0.0"}`), &rootfs); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		name     string
+		linkname string
+		content  string
+	}{
+		{name: "config.json", content: `{"ociVersion":"1.0.0"}`},
+		{name: "rootfs/"},
+		{name: "rootfs/bin/"},
+		{name: "rootfs/bin/sh", content: "sh"},
+		{name: "rootfs/bin/bash", linkname: "rootfs/bin/sh"},
+		{name: "rootfs/bin/ash", linkname: "/bin/sh"},
+	}
+	tr := tar.NewReader(&bundle)
+	for _, e := range expected {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatalf("expected %s, got %v", e.name, err)
+		}
+		if hdr.Name != e.name || hdr.Linkname != e.linkname {
+			t.Fatalf("expected %s -> %q, got %s -> %q", e.name, e.linkname, hdr.Name, hdr.Linkname)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != e.content {
+			t.Fatalf("expected %s to contain %q, got %q", e.name, e.content, content)
+		}
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Fatalf("expected the end of the bundle, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+)
+
+// filesystemUsageTTL is how long the filesystem usage of a container is
+// cached, as computing it walks the read-write layer of the container.
+const filesystemUsageTTL = 30 * time.Second
+
+// filesystemUsageCache caches the filesystem usage of the containers by ID.
+type filesystemUsageCache struct {
+	sync.Mutex
+	usage map[string]*types.ContainerFilesystemUsage
+}
+
+// get returns the cached usage of the container, if it has not expired.
+func (c *filesystemUsageCache) get(id string) *types.ContainerFilesystemUsage {
+	c.Lock()
+	defer c.Unlock()
+	if u, ok := c.usage[id]; ok && time.Since(u.Computed) < filesystemUsageTTL {
+		return u
+	}
+	return nil
+}
+
+// set caches the usage of the container, and drops the expired usages.
+func (c *filesystemUsageCache) set(id string, u *types.ContainerFilesystemUsage) {
+	c.Lock()
+	defer c.Unlock()
+	if c.usage == nil {
+		c.usage = make(map[string]*types.ContainerFilesystemUsage)
+	}
+	for k, v := range c.usage {
+		if time.Since(v.Computed) >= filesystemUsageTTL {
+			delete(c.usage, k)
+		}
+	}
+	c.usage[id] = u
+}
+
+func (c *filesystemUsageCache) delete(id string) {
+	c.Lock()
+	delete(c.usage, id)
+	c.Unlock()
+}
+
+// ContainerFilesystemUsage returns the usage of the read-write layer of the
+// container, broken down by top-level directory. The usage is cached for a
+// short time, unless refresh is set.
+func (daemon *Daemon) ContainerFilesystemUsage(name string, refresh bool) (*types.ContainerFilesystemUsage, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !refresh {
+		if u := daemon.filesystemUsage.get(container.ID); u != nil {
+			return u, nil
+		}
+	}
+
+	if err := daemon.Mount(container); err != nil {
+		return nil, err
+	}
+	defer daemon.Unmount(container)
+
+	sizeRw, err := container.RWLayer.Size()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := container.RWLayer.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	u := &types.ContainerFilesystemUsage{
+		SizeRw:      sizeRw,
+		Directories: []types.ContainerDirectoryUsage{},
+		Computed:    time.Now().UTC(),
+	}
+	for dir, du := range archive.ChangesUsageByDir(container.BaseFS, changes) {
+		u.Inodes += du.Inodes
+		u.Directories = append(u.Directories, types.ContainerDirectoryUsage{Path: dir, Size: du.Size, Inodes: du.Inodes})
+	}
+	sort.Sort(byDirectorySize(u.Directories))
+
+	daemon.filesystemUsage.set(container.ID, u)
+	return u, nil
+}
+
+// byDirectorySize sorts the directories by decreasing size, then by path.
+type byDirectorySize []types.ContainerDirectoryUsage
+
+func (s byDirectorySize) Len() int      { return len(s) }
+func (s byDirectorySize) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byDirectorySize) Less(i, j int) bool {
+	if s[i].Size != s[j].Size {
+		return s[i].Size > s[j].Size
+	}
+	return s[i].Path < s[j].Path
+}
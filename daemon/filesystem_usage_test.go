@@ -0,0 +1,47 @@
+package daemon
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestFilesystemUsageCache(t *testing.T) {
+	var c filesystemUsageCache
+	if u := c.get("foo"); u != nil {
+		t.Fatalf("expected no cached usage, got %v", u)
+	}
+
+	c.set("expired", &types.ContainerFilesystemUsage{Computed: time.Now().Add(-filesystemUsageTTL)})
+	if u := c.get("expired"); u != nil {
+		t.Fatalf("expected the expired usage not to be returned, got %v", u)
+	}
+
+	usage := &types.ContainerFilesystemUsage{SizeRw: 42, Computed: time.Now()}
+	c.set("foo", usage)
+	if u := c.get("foo"); u != usage {
+		t.Fatalf("expected the cached usage, got %v", u)
+	}
+	if _, ok := c.usage["expired"]; ok {
+		t.Fatal("expected the expired usage to be dropped")
+	}
+
+	c.delete("foo")
+	if u := c.get("foo"); u != nil {
+		t.Fatalf("expected the usage to be deleted, got %v", u)
+	}
+}
+
+func TestSortByDirectorySize(t *testing.T) {
+	dirs := []types.ContainerDirectoryUsage{
+		{Path: "/tmp", Size: 10},
+		{Path: "/var", Size: 100},
+		{Path: "/", Size: 10},
+	}
+	sort.Sort(byDirectorySize(dirs))
+	if dirs[0].Path != "/var" || dirs[1].Path != "/" || dirs[2].Path != "/tmp" {
+		t.Fatalf("unexpected order %v", dirs)
+	}
+}
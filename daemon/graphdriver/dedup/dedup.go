@@ -0,0 +1,215 @@
+// Package dedup shares the content of the identical files of the layers of a
+// storage driver, by making them clones of each other on the filesystems
+// supporting reflinks, such as XFS.
+package dedup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+const (
+	// indexFileName is the file of the home of the driver indexing the
+	// files of its layers by content.
+	indexFileName = "dedup.json"
+	// minSize is the size of the smallest files shared, sharing smaller
+	// files saving too little for the cost of indexing them.
+	minSize = 64 << 10
+)
+
+// ErrNotSupported is returned by New if the filesystem of the layers does not
+// support reflinks.
+var ErrNotSupported = errors.New("the filesystem does not support reflinks")
+
+// fileRef is a file of a layer, by its path in the layer.
+type fileRef struct {
+	Layer string
+	Path  string
+}
+
+// Deduplicator shares the content of the identical files of the layers of a
+// storage driver.
+type Deduplicator struct {
+	home     string
+	layerDir func(id string) string
+
+	mu    sync.Mutex
+	files map[digest.Digest][]fileRef
+}
+
+// New returns a Deduplicator of the layers of a driver whose home is home,
+// the content of the layer id being in layerDir(id). It returns
+// ErrNotSupported if the filesystem of home does not support reflinks.
+func New(home string, layerDir func(id string) string) (*Deduplicator, error) {
+	if err := checkSupport(home); err != nil {
+		logrus.Debugf("reflinks are not supported in %s: %v", home, err)
+		return nil, ErrNotSupported
+	}
+	d := &Deduplicator{
+		home:     home,
+		layerDir: layerDir,
+		files:    make(map[digest.Digest][]fileRef),
+	}
+	b, err := ioutil.ReadFile(filepath.Join(home, indexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return d, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &d.files); err != nil {
+		return nil, fmt.Errorf("invalid index of the files of the layers: %v", err)
+	}
+	return d, nil
+}
+
+// checkSupport checks that the files of dir can be cloned.
+func checkSupport(dir string) error {
+	src, err := ioutil.TempFile(dir, ".dedup-check-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(src.Name())
+	defer src.Close()
+	if _, err := src.Write([]byte("reflink")); err != nil {
+		return err
+	}
+	dst, err := ioutil.TempFile(dir, ".dedup-check-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dst.Name())
+	defer dst.Close()
+	return cloneFile(src, dst)
+}
+
+// Dedup makes the files of the layer id identical to a file of another layer
+// clones of it, and indexes them for the next layers. It returns the size of
+// the files shared. The files which cannot be read or shared are skipped, as
+// they are only stored twice, and the error returned is the error of the
+// update of the index.
+func (d *Deduplicator) Dedup(id string) (int64, error) {
+	root := d.layerDir(id)
+	var (
+		shared int64
+		refs   = make(map[digest.Digest][]fileRef)
+	)
+	// Replacing a file changes the times of its directory, which are
+	// restored once the layer is deduplicated.
+	dirs := make(map[string]os.FileInfo)
+	filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			logrus.Debugf("failed to dedup %s of layer %s: %v", path, id, err)
+			return nil
+		}
+		if fi.Size() < minSize || !shareable(path, fi) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		// The files are hashed and cloned without holding the lock, which
+		// would serialize the layers applied concurrently.
+		dgst, err := fileDigest(path)
+		if err != nil {
+			logrus.Debugf("failed to hash %s of layer %s: %v", rel, id, err)
+			return nil
+		}
+		if src := d.source(dgst, fi.Size()); src != "" {
+			dir := filepath.Dir(path)
+			if _, ok := dirs[dir]; !ok {
+				if dirs[dir], err = os.Lstat(dir); err != nil {
+					delete(dirs, dir)
+					logrus.Debugf("failed to share %s of layer %s: %v", rel, id, err)
+					return nil
+				}
+			}
+			if err := replaceWithClone(src, path, fi); err != nil {
+				logrus.Debugf("failed to share %s of layer %s with %s: %v", rel, id, src, err)
+			} else {
+				shared += fi.Size()
+			}
+		}
+		refs[dgst] = append(refs[dgst], fileRef{Layer: id, Path: rel})
+		return nil
+	})
+	for dir, fi := range dirs {
+		if err := restoreTimes(dir, fi); err != nil {
+			logrus.Debugf("failed to restore the times of %s: %v", dir, err)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for dgst, r := range refs {
+		d.files[dgst] = append(d.files[dgst], r...)
+	}
+	return shared, d.saveIndex()
+}
+
+// Remove forgets the files of the layer id.
+func (d *Deduplicator) Remove(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for dgst, refs := range d.files {
+		kept := refs[:0]
+		for _, ref := range refs {
+			if ref.Layer != id {
+				kept = append(kept, ref)
+			}
+		}
+		if len(kept) == 0 {
+			delete(d.files, dgst)
+		} else {
+			d.files[dgst] = kept
+		}
+	}
+	return d.saveIndex()
+}
+
+// source returns the path of a file of the layers with the given digest and
+// size, or an empty string if there is none.
+func (d *Deduplicator) source(dgst digest.Digest, size int64) string {
+	d.mu.Lock()
+	var paths []string
+	for _, ref := range d.files[dgst] {
+		paths = append(paths, filepath.Join(d.layerDir(ref.Layer), ref.Path))
+	}
+	d.mu.Unlock()
+
+	for _, path := range paths {
+		if fi, err := os.Lstat(path); err == nil && fi.Mode().IsRegular() && fi.Size() == size {
+			return path
+		}
+	}
+	return ""
+}
+
+func (d *Deduplicator) saveIndex() error {
+	b, err := json.Marshal(d.files)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(filepath.Join(d.home, indexFileName), b, 0600)
+}
+
+// fileDigest returns the digest of the content of a file.
+func fileDigest(path string) (digest.Digest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return digest.FromReader(f)
+}
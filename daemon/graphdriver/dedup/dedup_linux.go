@@ -0,0 +1,82 @@
+package dedup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// ficlone is the FICLONE ioctl, making a file share the extents of another.
+const ficlone = 0x40049409
+
+// cloneFile makes dst, which must be empty, share the extents of src. It is a
+// variable so that the tests can run on filesystems without reflinks.
+var cloneFile = func(src, dst *os.File) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dst.Fd(), ficlone, src.Fd()); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// shareable reports whether the file at path can be replaced with a clone,
+// which is only done for the regular files without hard links or extended
+// attributes, that a clone would not preserve.
+func shareable(path string, fi os.FileInfo) bool {
+	if !fi.Mode().IsRegular() {
+		return false
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok || st.Nlink != 1 {
+		return false
+	}
+	sz, err := syscall.Listxattr(path, nil)
+	return err == nil && sz == 0
+}
+
+// replaceWithClone replaces the file at dst, whose info is fi, with a clone
+// of the file at src with the owner, the mode and the times of dst.
+func replaceWithClone(src, dst string, fi os.FileInfo) (err error) {
+	st := fi.Sys().(*syscall.Stat_t)
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(dst), ".dedup-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+	if err := cloneFile(in, tmp); err != nil {
+		return err
+	}
+	if err := tmp.Chown(int(st.Uid), int(st.Gid)); err != nil {
+		return err
+	}
+	// The mode is set after the owner, which clears the setuid bits.
+	if err := tmp.Chmod(fi.Mode()); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp.Name(), time.Unix(st.Atim.Unix()), fi.ModTime()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// restoreTimes restores the times of the directory at path, whose info was
+// fi.
+func restoreTimes(path string, fi os.FileInfo) error {
+	st := fi.Sys().(*syscall.Stat_t)
+	return os.Chtimes(path, time.Unix(st.Atim.Unix()), fi.ModTime())
+}
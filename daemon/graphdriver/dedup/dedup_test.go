@@ -0,0 +1,176 @@
+// +build linux
+
+package dedup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution/digest"
+)
+
+// copyFile stands for the clone of a file on the filesystems of the tests.
+func copyFile(src, dst *os.File) error {
+	_, err := io.Copy(dst, src)
+	return err
+}
+
+func TestDedup(t *testing.T) {
+	defer func(clone func(src, dst *os.File) error) { cloneFile = clone }(cloneFile)
+	var clones int
+	cloneFile = func(src, dst *os.File) error {
+		clones++
+		return copyFile(src, dst)
+	}
+
+	home, err := ioutil.TempDir("", "docker-dedup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	layerDir := func(id string) string {
+		return filepath.Join(home, id, "diff")
+	}
+
+	content := bytes.Repeat([]byte("layer"), minSize)
+	for _, id := range []string{"a", "b"} {
+		if err := os.MkdirAll(filepath.Join(layerDir(id), "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(layerDir(id), "bin", "app"), content, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(layerDir(id), "small"), []byte(id), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := New(home, layerDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clones = 0
+
+	shared, err := d.Dedup("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared != 0 || clones != 0 {
+		t.Fatalf("expected nothing to share in the first layer, got %d bytes shared", shared)
+	}
+
+	shared, err = d.Dedup("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared != int64(len(content)) || clones != 1 {
+		t.Fatalf("expected %d bytes shared with one clone, got %d bytes with %d clones", len(content), shared, clones)
+	}
+	app := filepath.Join(layerDir("b"), "bin", "app")
+	b, err := ioutil.ReadFile(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, content) {
+		t.Fatal("the content of the file shared changed")
+	}
+	fi, err := os.Stat(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0755 {
+		t.Fatalf("expected the mode of the file shared to be kept, got %v", fi.Mode())
+	}
+
+	// The index is kept across restarts, and forgets the removed layers.
+	d, err = New(home, layerDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Remove("a"); err != nil {
+		t.Fatal(err)
+	}
+	if src := d.source(mustDigest(t, app), int64(len(content))); src != app {
+		t.Fatalf("expected the file of the remaining layer to be shared, got %q", src)
+	}
+}
+
+func TestDedupCloneError(t *testing.T) {
+	defer func(clone func(src, dst *os.File) error) { cloneFile = clone }(cloneFile)
+	cloneFile = copyFile
+
+	home, err := ioutil.TempDir("", "docker-dedup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	layerDir := func(id string) string {
+		return filepath.Join(home, id, "diff")
+	}
+
+	content := bytes.Repeat([]byte("layer"), minSize)
+	for _, id := range []string{"a", "b"} {
+		if err := os.MkdirAll(layerDir(id), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(layerDir(id), "app"), content, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := New(home, layerDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Dedup("a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The files which cannot be shared are kept as they are.
+	cloneFile = func(src, dst *os.File) error {
+		return os.ErrInvalid
+	}
+	shared, err := d.Dedup("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared != 0 {
+		t.Fatalf("expected nothing to be shared, got %d bytes", shared)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(layerDir("b"), "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, content) {
+		t.Fatal("the content of the file which could not be shared changed")
+	}
+}
+
+func TestNewNotSupported(t *testing.T) {
+	defer func(clone func(src, dst *os.File) error) { cloneFile = clone }(cloneFile)
+	cloneFile = func(src, dst *os.File) error {
+		return os.ErrInvalid
+	}
+
+	home, err := ioutil.TempDir("", "docker-dedup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	if _, err := New(home, func(id string) string { return filepath.Join(home, id) }); err != ErrNotSupported {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func mustDigest(t *testing.T, path string) digest.Digest {
+	dgst, err := fileDigest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dgst
+}
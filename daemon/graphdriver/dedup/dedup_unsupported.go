@@ -0,0 +1,23 @@
+// +build !linux
+
+package dedup
+
+import (
+	"os"
+)
+
+var cloneFile = func(src, dst *os.File) error {
+	return ErrNotSupported
+}
+
+func shareable(path string, fi os.FileInfo) bool {
+	return false
+}
+
+func replaceWithClone(src, dst string, fi os.FileInfo) error {
+	return ErrNotSupported
+}
+
+func restoreTimes(path string, fi os.FileInfo) error {
+	return nil
+}
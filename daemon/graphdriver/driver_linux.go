@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/rootless"
 )
 
 const (
@@ -48,6 +49,8 @@ const (
 	FsMagicZfs = FsMagic(0x2fc12fc1)
 	// FsMagicOverlay filesystem id for overlay
 	FsMagicOverlay = FsMagic(0x794C7630)
+	// FsMagicFUSE filesystem id for FUSE
+	FsMagicFUSE = FsMagic(0x65735546)
 )
 
 var (
@@ -61,6 +64,14 @@ var (
 		"vfs",
 	}
 
+	// Slice of drivers that should be used in an order in rootless mode,
+	// where the drivers which require privileges cannot be used.
+	rootlessPriority = []string{
+		"overlay2",
+		"fuse-overlayfs",
+		"vfs",
+	}
+
 	// FsNames maps filesystem id to name of the filesystem.
 	FsNames = map[FsMagic]string{
 		FsMagicAufs:        "aufs",
@@ -73,6 +84,7 @@ var (
 		FsMagicJfs:         "jfs",
 		FsMagicNfsFs:       "nfs",
 		FsMagicOverlay:     "overlayfs",
+		FsMagicFUSE:        "fuse",
 		FsMagicRAMFs:       "ramfs",
 		FsMagicReiserFs:    "reiserfs",
 		FsMagicSmbFs:       "smb",
@@ -85,6 +97,12 @@ var (
 	}
 )
 
+func init() {
+	if rootless.RunningWithRootlessKit() {
+		priority = rootlessPriority
+	}
+}
+
 // GetFSMagic returns the filesystem id given the path.
 func GetFSMagic(rootpath string) (FsMagic, error) {
 	var buf syscall.Statfs_t
@@ -0,0 +1,88 @@
+// +build linux
+
+package overlay2
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+)
+
+// mountTestOverlay mounts, then unmounts, a test overlay with the given
+// number of lower directories and extra mount options in a temporary
+// directory of root.
+func mountTestOverlay(root string, lowers int, extraOpts string) error {
+	td, err := ioutil.TempDir(root, "check-overlay2")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(td)
+
+	var lowerDirs []string
+	for i := 0; i < lowers; i++ {
+		lowerDirs = append(lowerDirs, path.Join(td, fmt.Sprintf("lower%d", i)))
+	}
+	for _, dir := range append(lowerDirs, path.Join(td, "upper"), path.Join(td, "work"), path.Join(td, "merged")) {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return err
+		}
+	}
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(lowerDirs, ":"), path.Join(td, "upper"), path.Join(td, "work"))
+	if extraOpts != "" {
+		opts += "," + extraOpts
+	}
+	if err := syscall.Mount("overlay", path.Join(td, "merged"), "overlay", 0, opts); err != nil {
+		return err
+	}
+	return syscall.Unmount(path.Join(td, "merged"), 0)
+}
+
+// supportsMultipleLowerDir checks that the kernel supports mounting an
+// overlay with several lower directories, which some kernels older than
+// 4.0.0 are patched to support.
+func supportsMultipleLowerDir(root string) error {
+	return mountTestOverlay(root, 2, "")
+}
+
+// supportsUserNSOverlay checks that overlay can be mounted in the user
+// namespace of the daemon, by mounting a test overlay in the root directory.
+func supportsUserNSOverlay(root string) error {
+	return mountTestOverlay(root, 1, "")
+}
+
+// features lists the optional overlay features supported by the kernel.
+type features struct {
+	// redirectDir is set if the kernel can rename directories by storing
+	// a redirect in the upper directory (4.10 and later).
+	redirectDir bool
+	// metacopy is set if the kernel can copy up the metadata of a file
+	// without its data (4.19 and later).
+	metacopy bool
+}
+
+// probeFeatures finds the optional overlay features supported by the kernel
+// by mounting a test overlay with the mount option of each feature.
+func probeFeatures(root string) features {
+	return features{
+		redirectDir: mountTestOverlay(root, 1, "redirect_dir=off") == nil,
+		metacopy:    mountTestOverlay(root, 1, "metacopy=off") == nil,
+	}
+}
+
+// mountOptions returns the mount options disabling the supported features
+// which the kernel may enable by default. The diff of a layer is its upper
+// directory, which must then hold the full content of the renamed
+// directories and of the modified files.
+func (f features) mountOptions() string {
+	var opts []string
+	if f.redirectDir {
+		opts = append(opts, "redirect_dir=off")
+	}
+	if f.metacopy {
+		opts = append(opts, "metacopy=off")
+	}
+	return strings.Join(opts, ",")
+}
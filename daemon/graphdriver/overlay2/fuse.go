@@ -0,0 +1,69 @@
+// +build linux
+
+package overlay2
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/pkg/idtools"
+)
+
+// The fuse-overlayfs driver has the layout of the overlay2 driver, but
+// mounts the layers with fuse-overlayfs, a FUSE implementation of overlay
+// which can be used without privileges, in rootless mode, on the kernels
+// which do not support mounting overlay in a user namespace.
+
+const (
+	fuseDriverName = "fuse-overlayfs"
+	fuseBinary     = "fuse-overlayfs"
+)
+
+func init() {
+	graphdriver.Register(fuseDriverName, InitFuse)
+}
+
+// InitFuse returns the fuse-overlayfs driver.
+// If the fuse-overlayfs binary is not found, graphdriver.ErrNotSupported is
+// returned as error.
+func InitFuse(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (graphdriver.Driver, error) {
+	if len(options) != 0 {
+		return nil, fmt.Errorf("fuse-overlayfs: options are not supported")
+	}
+
+	if _, err := exec.LookPath(fuseBinary); err != nil {
+		return nil, graphdriver.ErrNotSupported
+	}
+
+	rootUID, rootGID, err := idtools.GetRootUIDGID(uidMaps, gidMaps)
+	if err != nil {
+		return nil, err
+	}
+	// Create the driver home dir
+	if err := idtools.MkdirAllAs(path.Join(home, linkDir), 0700, rootUID, rootGID); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+
+	return &Driver{
+		home:    home,
+		uidMaps: uidMaps,
+		gidMaps: gidMaps,
+		ctr:     graphdriver.NewRefCounter(graphdriver.NewFsChecker(graphdriver.FsMagicFUSE)),
+		fuse:    true,
+	}, nil
+}
+
+// mountFuse mounts the layers on the merged directory with fuse-overlayfs.
+func mountFuse(lowers []string, upperDir, workDir, mergedDir string) error {
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(lowers, ":"), upperDir, workDir)
+	out, err := exec.Command(fuseBinary, "-o", opts, mergedDir).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error creating fuse-overlayfs mount to %s: %v: %s", mergedDir, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/Sirupsen/logrus"
 
 	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/graphdriver/dedup"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/directory"
@@ -26,6 +27,7 @@ import (
 	"github.com/docker/docker/pkg/parsers/kernel"
 
 	"github.com/opencontainers/runc/libcontainer/label"
+	rsystem "github.com/opencontainers/runc/libcontainer/system"
 )
 
 var (
@@ -82,6 +84,12 @@ type Driver struct {
 	uidMaps []idtools.IDMap
 	gidMaps []idtools.IDMap
 	ctr     *graphdriver.RefCounter
+	fuse    bool // whether the layers are mounted with fuse-overlayfs
+	// features are the optional overlay features supported by the kernel.
+	features features
+	// dedup shares the content of the identical files of the layers, if
+	// enabled with the reflink_dedup option.
+	dedup *dedup.Deduplicator
 }
 
 var backingFs = "<unknown>"
@@ -103,16 +111,19 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 		return nil, graphdriver.ErrNotSupported
 	}
 
-	// require kernel 4.0.0 to ensure multiple lower dirs are supported
+	// require kernel 4.0.0, or a patched kernel, to ensure multiple lower
+	// dirs are supported
 	v, err := kernel.GetKernelVersion()
 	if err != nil {
 		return nil, err
 	}
 	if kernel.CompareKernelVersion(*v, kernel.VersionInfo{Kernel: 4, Major: 0, Minor: 0}) < 0 {
-		if !opts.overrideKernelCheck {
+		if opts.overrideKernelCheck {
+			logrus.Warnf("Using pre-4.0.0 kernel for overlay2, mount failures may require kernel update")
+		} else if err := supportsMultipleLowerDir(path.Dir(home)); err != nil {
+			logrus.Errorf("'overlay2' requires multiple lower directories support, added to the kernel in 4.0.0, which kernel %s does not provide: %v. Please update the kernel, or use the 'overlay' storage driver.", v, err)
 			return nil, graphdriver.ErrNotSupported
 		}
-		logrus.Warnf("Using pre-4.0.0 kernel for overlay2, mount failures may require kernel update")
 	}
 
 	fsMagic, err := graphdriver.GetFSMagic(home)
@@ -126,10 +137,19 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 	// check if they are running over btrfs, aufs, zfs, overlay, or ecryptfs
 	switch fsMagic {
 	case graphdriver.FsMagicBtrfs, graphdriver.FsMagicAufs, graphdriver.FsMagicZfs, graphdriver.FsMagicOverlay, graphdriver.FsMagicEcryptfs:
-		logrus.Errorf("'overlay2' is not supported over %s", backingFs)
+		logrus.Errorf("'overlay2' is not supported over %s. Please move the docker root directory (--graph) to an ext4 or xfs filesystem, or use another storage driver.", backingFs)
 		return nil, graphdriver.ErrIncompatibleFS
 	}
 
+	// overlay can only be mounted from a user namespace, as in rootless
+	// mode, on some kernels.
+	if rsystem.RunningInUserNS() {
+		if err := supportsUserNSOverlay(path.Dir(home)); err != nil {
+			logrus.Errorf("'overlay2' cannot be mounted in the user namespace of the daemon: %v. Please use the 'fuse-overlayfs' storage driver, or a kernel supporting overlay in user namespaces.", err)
+			return nil, graphdriver.ErrNotSupported
+		}
+	}
+
 	rootUID, rootGID, err := idtools.GetRootUIDGID(uidMaps, gidMaps)
 	if err != nil {
 		return nil, err
@@ -144,10 +164,17 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 	}
 
 	d := &Driver{
-		home:    home,
-		uidMaps: uidMaps,
-		gidMaps: gidMaps,
-		ctr:     graphdriver.NewRefCounter(graphdriver.NewFsChecker(graphdriver.FsMagicOverlay)),
+		home:     home,
+		uidMaps:  uidMaps,
+		gidMaps:  gidMaps,
+		ctr:      graphdriver.NewRefCounter(graphdriver.NewFsChecker(graphdriver.FsMagicOverlay)),
+		features: probeFeatures(path.Dir(home)),
+	}
+
+	if opts.reflinkDedup {
+		if d.dedup, err = dedup.New(home, d.getDiffPath); err != nil {
+			return nil, fmt.Errorf("overlay2: cannot enable reflink_dedup over %s: %v", backingFs, err)
+		}
 	}
 
 	return d, nil
@@ -155,6 +182,7 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 
 type overlayOptions struct {
 	overrideKernelCheck bool
+	reflinkDedup        bool
 }
 
 func parseOptions(options []string) (*overlayOptions, error) {
@@ -171,6 +199,11 @@ func parseOptions(options []string) (*overlayOptions, error) {
 			if err != nil {
 				return nil, err
 			}
+		case "overlay2.reflink_dedup":
+			o.reflinkDedup, err = strconv.ParseBool(val)
+			if err != nil {
+				return nil, err
+			}
 		default:
 			return nil, fmt.Errorf("overlay2: Unknown option %s\n", key)
 		}
@@ -200,14 +233,21 @@ func supportsOverlay() error {
 }
 
 func (d *Driver) String() string {
+	if d.fuse {
+		return fuseDriverName
+	}
 	return driverName
 }
 
 // Status returns current driver information in a two dimensional string array.
-// Output contains "Backing Filesystem" used in this implementation.
+// Output contains "Backing Filesystem" used in this implementation, and the
+// optional overlay features supported by the kernel.
 func (d *Driver) Status() [][2]string {
 	return [][2]string{
 		{"Backing Filesystem", backingFs},
+		{"Supports redirect_dir", strconv.FormatBool(d.features.redirectDir)},
+		{"Supports metacopy", strconv.FormatBool(d.features.metacopy)},
+		{"Reflink Dedup", strconv.FormatBool(d.dedup != nil)},
 	}
 }
 
@@ -376,6 +416,11 @@ func (d *Driver) Remove(id string) error {
 	if err := os.RemoveAll(dir); err != nil && !os.IsNotExist(err) {
 		return err
 	}
+	if d.dedup != nil {
+		if err := d.dedup.Remove(id); err != nil {
+			logrus.Debugf("Failed to remove the files of layer %s from the dedup index: %v", id, err)
+		}
+	}
 	return nil
 }
 
@@ -414,7 +459,17 @@ func (d *Driver) Get(id string, mountLabel string) (s string, err error) {
 	for i, s := range splitLowers {
 		absLowers[i] = path.Join(d.home, s)
 	}
-	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(absLowers, ":"), path.Join(dir, "diff"), path.Join(dir, "work"))
+	if d.fuse {
+		if err := mountFuse(absLowers, path.Join(dir, "diff"), workDir, mergedDir); err != nil {
+			return "", err
+		}
+		return mergedDir, nil
+	}
+	featureOpts := d.features.mountOptions()
+	if featureOpts != "" {
+		featureOpts = "," + featureOpts
+	}
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s%s", strings.Join(absLowers, ":"), path.Join(dir, "diff"), path.Join(dir, "work"), featureOpts)
 	mountData := label.FormatMountLabel(opts, mountLabel)
 	mount := syscall.Mount
 	mountTarget := mergedDir
@@ -426,7 +481,7 @@ func (d *Driver) Get(id string, mountLabel string) (s string, err error) {
 	// fit within a page and relative links make the mount data much
 	// smaller at the expense of requiring a fork exec to chroot.
 	if len(mountData) > pageSize {
-		opts = fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", string(lowers), path.Join(id, "diff"), path.Join(id, "work"))
+		opts = fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s%s", string(lowers), path.Join(id, "diff"), path.Join(id, "work"), featureOpts)
 		mountData = label.FormatMountLabel(opts, mountLabel)
 		if len(mountData) > pageSize {
 			return "", fmt.Errorf("cannot mount layer, mount label too large %d", len(mountData))
@@ -479,15 +534,30 @@ func (d *Driver) ApplyDiff(id string, parent string, diff archive.Reader) (size
 	applyDir := d.getDiffPath(id)
 
 	logrus.Debugf("Applying tar in %s", applyDir)
+	// fuse-overlayfs also handles the whiteout files of the layers, which
+	// can be extracted without privileges.
+	whiteoutFormat := archive.OverlayWhiteoutFormat
+	if d.fuse {
+		whiteoutFormat = archive.AUFSWhiteoutFormat
+	}
 	// Overlay doesn't need the parent id to apply the diff
 	if err := untar(diff, applyDir, &archive.TarOptions{
 		UIDMaps:        d.uidMaps,
 		GIDMaps:        d.gidMaps,
-		WhiteoutFormat: archive.OverlayWhiteoutFormat,
+		WhiteoutFormat: whiteoutFormat,
 	}); err != nil {
 		return 0, err
 	}
 
+	if d.dedup != nil {
+		// The layer is applied even if its files could not be shared.
+		shared, err := d.dedup.Dedup(id)
+		if err != nil {
+			logrus.Warnf("Failed to index the files of layer %s for reflink_dedup: %v", id, err)
+		}
+		logrus.Debugf("Shared %d bytes of layer %s with other layers", shared, id)
+	}
+
 	return d.DiffSize(id, parent)
 }
 
@@ -528,5 +598,9 @@ func (d *Driver) Changes(id, parent string) ([]archive.Change, error) {
 		return nil, err
 	}
 
+	if d.fuse {
+		// Without privileges, fuse-overlayfs creates whiteout files.
+		return archive.Changes(layers, diffPath)
+	}
 	return archive.OverlayChanges(layers, diffPath)
 }
@@ -139,6 +139,8 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 	if oldStatus != h.Status {
 		d.LogContainerEvent(c, "health_status: "+h.Status)
 	}
+
+	d.handleAutoheal(c, retries)
 }
 
 // Run the container's monitoring thread until notified via "stop".
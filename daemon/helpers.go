@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"os/exec"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// helperBinaries are the helper binaries managed by the daemon, with the name
+// of their executable.
+var helperBinaries = map[string]string{
+	"init": "docker-init",
+}
+
+// installHelpers installs the helper binaries found on the host into the
+// store of the daemon when it starts, and removes their previous versions.
+func (daemon *Daemon) installHelpers() {
+	for name := range helperBinaries {
+		daemon.installHelper(name)
+	}
+	daemon.helpers.RemoveOldVersions()
+}
+
+// installHelper installs the helper binary found on the host into the store
+// of the daemon, as a new version if it changed since it was last installed.
+// The binary is only hashed again if its size or modification time changed.
+func (daemon *Daemon) installHelper(name string) {
+	src := daemon.helperSource(name)
+	if src == "" {
+		var err error
+		if src, err = exec.LookPath(helperBinaries[name]); err != nil {
+			logrus.Debugf("Helper binary %s not found: %v", helperBinaries[name], err)
+			return
+		}
+	}
+	if _, err := daemon.helpers.Install(name, src); err != nil {
+		logrus.Warnf("Failed to install helper binary %s: %v", src, err)
+	}
+}
+
+// helperPath returns the path of the current version of the helper binary,
+// to be bind-mounted into a container. The binary is looked up on the host
+// again, so that a binary replaced while the daemon runs is used by the
+// containers started afterwards.
+func (daemon *Daemon) helperPath(name string) (string, error) {
+	daemon.installHelper(name)
+	_, p, err := daemon.helpers.Get(name)
+	return p, err
+}
@@ -0,0 +1,242 @@
+// Package helpers manages the static helper binaries of the daemon, such as
+// docker-init, that are bind-mounted read-only into the containers that need
+// them.
+//
+// Each version of a helper is stored in its own directory, named after the
+// digest of its binary, and the index of the store records the current
+// version of each helper. The digest of the binary is validated before it is
+// given to a container. The digests are cached by the size and modification
+// time of the files, so that a binary is only hashed again once it changed.
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+const (
+	indexFile = "helpers.json"
+
+	// versionLength is the length of the versions, the prefix of the hex
+	// digest of the binary.
+	versionLength = 12
+)
+
+// Helper is a version of a helper binary.
+type Helper struct {
+	Name    string
+	Version string
+	Digest  digest.Digest
+}
+
+// Store is a directory of versioned helper binaries.
+type Store struct {
+	mu      sync.Mutex
+	root    string
+	helpers map[string]*Helper
+	digests map[string]cachedDigest // digests of the files, by path
+}
+
+// cachedDigest is the digest of a file, valid as long as the size and the
+// modification time of the file do not change.
+type cachedDigest struct {
+	size    int64
+	modTime time.Time
+	digest  digest.Digest
+}
+
+// New returns the store of helper binaries in the given directory, which is
+// created if it does not exist.
+func New(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	s := &Store{root: root, helpers: make(map[string]*Helper), digests: make(map[string]cachedDigest)}
+
+	b, err := ioutil.ReadFile(filepath.Join(root, indexFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, &s.helpers); err != nil {
+			return nil, fmt.Errorf("invalid index of the helper binaries: %v", err)
+		}
+	}
+	return s, nil
+}
+
+// path returns the path of the binary of the helper version.
+func (s *Store) path(h *Helper) string {
+	return filepath.Join(s.root, h.Name, h.Version, h.Name)
+}
+
+// Get returns the current version of the helper and the path of its binary,
+// after validating its digest.
+func (s *Store) Get(name string) (*Helper, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.helpers[name]
+	if !ok {
+		return nil, "", fmt.Errorf("helper binary %s is not installed", name)
+	}
+	p := s.path(h)
+	dgst, err := s.digestFile(p)
+	if err != nil {
+		return nil, "", err
+	}
+	if dgst != h.Digest {
+		return nil, "", fmt.Errorf("helper binary %s %s is corrupted: expected digest %s, got %s", name, h.Version, h.Digest, dgst)
+	}
+	return h, p, nil
+}
+
+// Install copies the binary at src into the store as a new version of the
+// helper, which becomes its current version. The binary is not copied if it
+// is the current version already, unless the installed copy is corrupted. The
+// previous versions are kept until RemoveOldVersions is called, as containers
+// being started may still use them.
+func (s *Store) Install(name, src string) (*Helper, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dgst, err := s.digestFile(src)
+	if err != nil {
+		return nil, err
+	}
+	version := dgst.Hex()[:versionLength]
+
+	if h, ok := s.helpers[name]; ok && h.Version == version && h.Digest == dgst {
+		if current, err := s.digestFile(s.path(h)); err == nil && current == dgst {
+			return h, nil
+		}
+	}
+
+	h := &Helper{Name: name, Version: version, Digest: dgst}
+	p := s.path(h)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return nil, err
+	}
+	if err := copyBinary(src, p, dgst); err != nil {
+		os.RemoveAll(filepath.Dir(p))
+		return nil, err
+	}
+
+	previous := s.helpers[name]
+	s.helpers[name] = h
+	if err := s.save(); err != nil {
+		if previous != nil {
+			s.helpers[name] = previous
+		} else {
+			delete(s.helpers, name)
+		}
+		return nil, err
+	}
+
+	if previous != nil && previous.Version != version {
+		logrus.Infof("Upgraded helper binary %s from %s to %s", name, previous.Version, version)
+	}
+	return h, nil
+}
+
+// save writes the index of the store.
+func (s *Store) save() error {
+	b, err := json.Marshal(s.helpers)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(filepath.Join(s.root, indexFile), b, 0644)
+}
+
+// RemoveOldVersions removes the versions of the helpers other than their
+// current one. The running containers keep the binary they were started with.
+func (s *Store) RemoveOldVersions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, h := range s.helpers {
+		versions, err := ioutil.ReadDir(filepath.Join(s.root, h.Name))
+		if err != nil {
+			continue
+		}
+		for _, v := range versions {
+			if v.Name() == h.Version {
+				continue
+			}
+			dir := filepath.Join(s.root, h.Name, v.Name())
+			delete(s.digests, filepath.Join(dir, h.Name))
+			if err := os.RemoveAll(dir); err != nil {
+				logrus.Warnf("Failed to remove version %s of helper binary %s: %v", v.Name(), h.Name, err)
+			}
+		}
+	}
+}
+
+// digestFile returns the digest of the file, from the cache if the file did
+// not change since it was hashed. The caller must hold the lock of the store.
+func (s *Store) digestFile(p string) (digest.Digest, error) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return "", err
+	}
+	if c, ok := s.digests[p]; ok && c.size == fi.Size() && c.modTime.Equal(fi.ModTime()) {
+		return c.digest, nil
+	}
+	dgst, err := digestFile(p)
+	if err != nil {
+		return "", err
+	}
+	s.digests[p] = cachedDigest{size: fi.Size(), modTime: fi.ModTime(), digest: dgst}
+	return dgst, nil
+}
+
+// copyBinary copies the binary at src to dst, and checks that the copy has
+// the expected digest.
+func copyBinary(src, dst string, dgst digest.Digest) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := ioutils.NewAtomicFileWriter(dst, 0755)
+	if err != nil {
+		return err
+	}
+	verifier, err := digest.NewDigestVerifier(dgst)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(out, verifier), in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("the digest of %s changed while it was copied", src)
+	}
+	return nil
+}
+
+// digestFile returns the digest of the file.
+func digestFile(p string) (digest.Digest, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return digest.FromReader(f)
+}
@@ -0,0 +1,106 @@
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeBinary(t *testing.T, dir, content string) string {
+	p := filepath.Join(dir, "docker-init")
+	if err := ioutil.WriteFile(p, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestInstallAndGet(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helpers-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	root := filepath.Join(tmp, "helpers")
+
+	s, err := New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Get("init"); err == nil || !strings.Contains(err.Error(), "is not installed") {
+		t.Fatalf("expected an error for a helper that is not installed, got %v", err)
+	}
+
+	src := writeBinary(t, tmp, "v1")
+	h, err := s.Install("init", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, p, err := s.Get("init")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, err := ioutil.ReadFile(p); err != nil || string(content) != "v1" {
+		t.Fatalf("expected the installed binary, got %q (%v)", content, err)
+	}
+
+	// The index is reloaded from disk.
+	s, err = New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded, _, err := s.Get("init")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *reloaded != *h {
+		t.Fatalf("expected %+v, got %+v", h, reloaded)
+	}
+
+	// Installing the same binary again keeps the version.
+	if again, err := s.Install("init", src); err != nil || *again != *h {
+		t.Fatalf("expected %+v, got %+v (%v)", h, again, err)
+	}
+
+	// A new binary is an upgrade. The previous version is kept until the old
+	// versions are removed.
+	src = writeBinary(t, tmp, "v2")
+	upgraded, err := s.Install("init", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upgraded.Version == h.Version {
+		t.Fatalf("expected a new version, got %s", upgraded.Version)
+	}
+	if _, err := os.Stat(filepath.Join(root, "init", h.Version)); err != nil {
+		t.Fatalf("expected the previous version to be kept, got %v", err)
+	}
+	s.RemoveOldVersions()
+	if _, err := os.Stat(filepath.Join(root, "init", h.Version)); !os.IsNotExist(err) {
+		t.Fatalf("expected the previous version to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "init", upgraded.Version)); err != nil {
+		t.Fatalf("expected the current version to be kept, got %v", err)
+	}
+
+	// A corrupted binary is not returned.
+	_, p, err = s.Get("init")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(p, []byte("corrupted"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Get("init"); err == nil || !strings.Contains(err.Error(), "is corrupted") {
+		t.Fatalf("expected an error for a corrupted helper, got %v", err)
+	}
+
+	// It is repaired when the binary is installed again.
+	if _, err := s.Install("init", src); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Get("init"); err != nil {
+		t.Fatal(err)
+	}
+}
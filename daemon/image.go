@@ -3,6 +3,7 @@ package daemon
 import (
 	"fmt"
 
+	"github.com/docker/distribution/digest"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/reference"
@@ -61,6 +62,17 @@ func (daemon *Daemon) GetImage(refOrID string) (*image.Image, error) {
 	return daemon.imageStore.Get(imgID)
 }
 
+// HoldImage prevents the deletion of the image imageID until the holder
+// releases it with UnholdImage.
+func (daemon *Daemon) HoldImage(imageID, holder string) error {
+	return daemon.imageStore.Hold(image.ID(imageID), holder)
+}
+
+// UnholdImage releases a hold of the holder on the image imageID.
+func (daemon *Daemon) UnholdImage(imageID, holder string) {
+	daemon.imageStore.Unhold(image.ID(imageID), holder)
+}
+
 // GetImageOnBuild looks up a Docker image referenced by `name`.
 func (daemon *Daemon) GetImageOnBuild(name string) (builder.Image, error) {
 	img, err := daemon.GetImage(name)
@@ -69,3 +81,27 @@ func (daemon *Daemon) GetImageOnBuild(name string) (builder.Image, error) {
 	}
 	return img, nil
 }
+
+// RepoDigestOnBuild returns the reference by digest, in the repository of
+// `name`, of the image imageID, or nil if it has none.
+func (daemon *Daemon) RepoDigestOnBuild(name, imageID string) reference.Canonical {
+	ref, err := reference.ParseNamed(name)
+	if err != nil {
+		return nil
+	}
+	if canonical, isCanonical := ref.(reference.Canonical); isCanonical {
+		return canonical
+	}
+	for _, r := range daemon.referenceStore.References(digest.Digest(imageID)) {
+		if canonical, isCanonical := r.(reference.Canonical); isCanonical && canonical.Name() == ref.Name() {
+			return canonical
+		}
+	}
+	return nil
+}
+
+// BuildFromPolicy returns the resolution policy of the FROM references of
+// the builds set on the daemon.
+func (daemon *Daemon) BuildFromPolicy() string {
+	return daemon.configStore.BuildFromPolicy
+}
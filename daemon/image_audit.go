@@ -0,0 +1,63 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+)
+
+// ImagesAudit reports the dangling references found in the reference, image
+// and layer stores: the tags and digests of missing images, the missing
+// parents of images, and the layers whose reference count does not match
+// the references retaining them. Nothing is removed.
+func (daemon *Daemon) ImagesAudit() (*types.ImagesAuditReport, error) {
+	report := &types.ImagesAuditReport{DanglingReferences: []types.ImageAuditEntry{}}
+	images := daemon.imageStore.Map()
+
+	for _, association := range daemon.referenceStore.Associations() {
+		if _, ok := images[image.IDFromDigest(association.ID)]; !ok {
+			report.DanglingReferences = append(report.DanglingReferences, types.ImageAuditEntry{
+				Type:    types.AuditReference,
+				ID:      association.Ref.String(),
+				Message: fmt.Sprintf("references the missing image %s", association.ID),
+			})
+		}
+	}
+
+	var orphans []string
+	for id, img := range images {
+		if img.Parent == "" {
+			continue
+		}
+		if _, ok := images[img.Parent]; !ok {
+			orphans = append(orphans, id.String())
+		}
+	}
+	sort.Strings(orphans)
+	for _, id := range orphans {
+		report.DanglingReferences = append(report.DanglingReferences, types.ImageAuditEntry{
+			Type:    types.AuditParent,
+			ID:      id,
+			Message: fmt.Sprintf("has the missing parent %s", images[image.ID(id)].Parent),
+		})
+	}
+
+	if ls, ok := daemon.layerStore.(layer.AuditableStore); ok {
+		for _, entry := range ls.Audit() {
+			message := fmt.Sprintf("has a reference count of %d, but %d references were found", entry.ReferenceCount, entry.References)
+			if entry.References == 0 {
+				message = "is retained by no image, layer or container"
+			}
+			report.DanglingReferences = append(report.DanglingReferences, types.ImageAuditEntry{
+				Type:    types.AuditLayer,
+				ID:      entry.ChainID.String(),
+				Message: message,
+			})
+		}
+	}
+
+	return report, nil
+}
@@ -19,7 +19,8 @@ const (
 	conflictRunningContainer
 	conflictActiveReference
 	conflictStoppedContainer
-	conflictHard = conflictDependentChild | conflictRunningContainer
+	conflictHeld
+	conflictHard = conflictDependentChild | conflictRunningContainer | conflictHeld
 	conflictSoft = conflictActiveReference | conflictStoppedContainer
 )
 
@@ -342,8 +343,8 @@ func (daemon *Daemon) imageDeleteHelper(imgID image.ID, records *[]types.ImageDe
 
 // checkImageDeleteConflict determines whether there are any conflicts
 // preventing deletion of the given image from this daemon. A hard conflict is
-// any image which has the given image as a parent or any running container
-// using the image. A soft conflict is any tags/digest referencing the given
+// any image which has the given image as a parent, any running container
+// using the image or any hold of the image, such as by a build. A soft conflict is any tags/digest referencing the given
 // image or any stopped container using the image. If ignoreSoftConflicts is
 // true, this function will not check for soft conflict conditions.
 func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType) *imageDeleteConflict {
@@ -356,6 +357,18 @@ func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType
 		}
 	}
 
+	// Check if a build or another holder is using the image.
+	if mask&conflictHeld != 0 {
+		if holders := daemon.imageStore.Holders(imgID); len(holders) > 0 {
+			return &imageDeleteConflict{
+				imgID:   imgID,
+				hard:    true,
+				used:    true,
+				message: fmt.Sprintf("image is in use by %s", strings.Join(holders, ", ")),
+			}
+		}
+	}
+
 	if mask&conflictRunningContainer != 0 {
 		// Check if any running container is using the image.
 		running := func(c *container.Container) bool {
@@ -3,16 +3,30 @@ package daemon
 import (
 	"io"
 
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/image/tarexport"
+	"github.com/docker/docker/pkg/archive"
 )
 
 // ExportImage exports a list of images to the given output stream. The
 // exported images are archived into a tar when written to the output
 // stream. All images with the given tag and all versions containing
-// the same tag are exported. names is the set of tags to export, and
-// outStream is the writer which the images are written to.
-func (daemon *Daemon) ExportImage(names []string, outStream io.Writer) error {
-	imageExporter := tarexport.NewTarExporter(daemon.imageStore, daemon.layerStore, daemon.referenceStore, daemon)
+// the same tag are exported. names is the set of tags to export, the layers
+// being compressed with compression if it is set, and outStream is the
+// writer which the images are written to.
+func (daemon *Daemon) ExportImage(names []string, compression backend.LayerCompression, outStream io.Writer) error {
+	layerCompression := archive.Uncompressed
+	if compression.Algorithm != "" {
+		var err error
+		if layerCompression, err = archive.ParseCompression(compression.Algorithm); err != nil {
+			return err
+		}
+	}
+	imageExporter := tarexport.NewTarExporterWithOptions(daemon.imageStore, daemon.layerStore, daemon.referenceStore, daemon, tarexport.Options{
+		Compression:      layerCompression,
+		CompressionLevel: compression.Level,
+		Concurrency:      daemon.maxConcurrentExports(),
+	})
 	return imageExporter.Save(names, outStream)
 }
 
@@ -20,6 +34,17 @@ func (daemon *Daemon) ExportImage(names []string, outStream io.Writer) error {
 // complement of ImageExport.  The input stream is an uncompressed tar
 // ball containing images and metadata.
 func (daemon *Daemon) LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool) error {
-	imageExporter := tarexport.NewTarExporter(daemon.imageStore, daemon.layerStore, daemon.referenceStore, daemon)
+	imageExporter := tarexport.NewTarExporterWithOptions(daemon.imageStore, daemon.layerStore, daemon.referenceStore, layerDepthEventLogger{daemon}, tarexport.Options{
+		Concurrency: daemon.maxConcurrentExports(),
+	})
 	return imageExporter.Load(inTar, outStream, quiet)
 }
+
+// maxConcurrentExports returns the maximum number of layers written at a time
+// by each save, and of images whose layers are loaded at a time by each load.
+func (daemon *Daemon) maxConcurrentExports() int {
+	if daemon.configStore == nil || daemon.configStore.MaxConcurrentExports == nil {
+		return defaultMaxConcurrentExports
+	}
+	return *daemon.configStore.MaxConcurrentExports
+}
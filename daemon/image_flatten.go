@@ -0,0 +1,145 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/reference"
+)
+
+// FlattenImage creates an image with a single layer holding the filesystem
+// of the image with the given name, and the configuration of the image. The
+// new image is tagged with repository and tag if repository is not empty.
+func (daemon *Daemon) FlattenImage(name, repository, tag string) (string, error) {
+	var newRef reference.Named
+	if repository != "" {
+		var err error
+		if newRef, err = reference.WithName(repository); err != nil {
+			return "", err
+		}
+		if tag != "" {
+			if newRef, err = reference.WithTag(newRef, tag); err != nil {
+				return "", err
+			}
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "", fmt.Errorf("Windows does not support flattening images")
+	}
+
+	img, err := daemon.GetImage(name)
+	if err != nil {
+		return "", err
+	}
+
+	l, err := daemon.flattenLayer(img)
+	if err != nil {
+		return "", fmt.Errorf("Error flattening image %s: %v", name, err)
+	}
+	defer layer.ReleaseAndLog(daemon.layerStore, l)
+
+	comment := fmt.Sprintf("Flattened from %s (%d layers)", img.ID(), len(img.RootFS.DiffIDs))
+	h := image.History{
+		Created:    time.Now().UTC(),
+		CreatedBy:  "docker image flatten",
+		Comment:    comment,
+		EmptyLayer: true,
+	}
+	rootFS := image.NewRootFS()
+	if diffID := l.DiffID(); layer.DigestSHA256EmptyTar != diffID {
+		h.EmptyLayer = false
+		rootFS.Append(diffID)
+	}
+
+	config, err := json.Marshal(&image.Image{
+		V1Image: image.V1Image{
+			DockerVersion:   dockerversion.Version,
+			Config:          img.Config,
+			ContainerConfig: img.ContainerConfig,
+			Architecture:    img.Architecture,
+			OS:              img.OS,
+			Author:          img.Author,
+			Comment:         comment,
+			Created:         h.Created,
+		},
+		RootFS:     rootFS,
+		History:    []image.History{h},
+		OSFeatures: img.OSFeatures,
+		OSVersion:  img.OSVersion,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	id, err := daemon.imageStore.Create(config)
+	if err != nil {
+		return "", err
+	}
+
+	if newRef != nil {
+		if err := daemon.TagImageWithReference(id, newRef); err != nil {
+			return "", err
+		}
+	}
+
+	daemon.LogImageEventWithAttributes(id.String(), id.String(), "flatten", map[string]string{
+		"source": img.ID().String(),
+	})
+	return id.String(), nil
+}
+
+// flattenLayer registers a layer without parent holding the filesystem of
+// the image, read from a RW layer created on top of the image.
+func (daemon *Daemon) flattenLayer(img *image.Image) (layer.Layer, error) {
+	rwID := stringid.GenerateRandomID()
+	rwLayer, err := daemon.layerStore.CreateRWLayer(rwID, img.RootFS.ChainID(), "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, err := daemon.layerStore.ReleaseRWLayer(rwLayer); err != nil {
+			logrus.Errorf("Failed to release the RW layer used to flatten an image: %v", err)
+		}
+	}()
+
+	// The mount is tracked by mount ID so that it is not reported as
+	// leaked while the image is flattened. It is tracked before it is
+	// mounted, as a leak check may run in between.
+	mountID, err := daemon.layerStore.GetMountID(rwID)
+	if err != nil {
+		return nil, err
+	}
+	daemon.rwMounts.addLayer(mountID)
+	dir, err := rwLayer.Mount("")
+	if err != nil {
+		daemon.rwMounts.removeLayer(mountID)
+		return nil, err
+	}
+	defer func() {
+		if err := rwLayer.Unmount(); err != nil {
+			logrus.Errorf("Failed to unmount the RW layer used to flatten an image: %v", err)
+		}
+		daemon.rwMounts.removeLayer(mountID)
+	}()
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	data, err := archive.TarWithOptions(dir, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		UIDMaps:     uidMaps,
+		GIDMaps:     gidMaps,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+
+	return daemon.layerStore.Register(data, "")
+}
@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/reference"
+)
+
+// ImageLayerExport writes the tar stream of the layer of the image with the
+// given DiffID to outStream. The DiffID may be given without its algorithm.
+func (daemon *Daemon) ImageLayerExport(name, diffID string, outStream io.Writer) error {
+	img, err := daemon.GetImage(name)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(diffID, ":") {
+		diffID = string(digest.Canonical) + ":" + diffID
+	}
+
+	for i, id := range img.RootFS.DiffIDs {
+		if string(id) != diffID {
+			continue
+		}
+		l, err := daemon.layerStore.Get(layer.CreateChainID(img.RootFS.DiffIDs[:i+1]))
+		if err != nil {
+			return err
+		}
+		defer layer.ReleaseAndLog(daemon.layerStore, l)
+
+		ts, err := l.TarStream()
+		if err != nil {
+			return err
+		}
+		defer ts.Close()
+		_, err = io.Copy(outStream, ts)
+		return err
+	}
+	return fmt.Errorf("No such layer in image %s: %s", name, diffID)
+}
+
+// ImageLayerImport applies the layer tar stream on top of the image with the
+// given name, creating a new image with the configuration of the base image.
+// The new image is tagged with repository and tag if repository is not empty.
+func (daemon *Daemon) ImageLayerImport(name string, layerData io.Reader, repository, tag, comment string) (string, error) {
+	var newRef reference.Named
+	if repository != "" {
+		var err error
+		if newRef, err = reference.WithName(repository); err != nil {
+			return "", err
+		}
+		if tag != "" {
+			if newRef, err = reference.WithTag(newRef, tag); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	base, err := daemon.GetImage(name)
+	if err != nil {
+		return "", err
+	}
+
+	inflatedLayerData, err := archive.DecompressStream(layerData)
+	if err != nil {
+		return "", err
+	}
+	defer inflatedLayerData.Close()
+
+	rootFS := image.NewRootFS()
+	rootFS.DiffIDs = append(rootFS.DiffIDs, base.RootFS.DiffIDs...)
+	l, err := daemon.layerStore.Register(inflatedLayerData, rootFS.ChainID())
+	if err != nil {
+		return "", err
+	}
+	defer layer.ReleaseAndLog(daemon.layerStore, l)
+
+	if comment == "" {
+		comment = "Imported layer " + l.DiffID().String()
+	}
+	h := image.History{
+		Created:    time.Now().UTC(),
+		Comment:    comment,
+		EmptyLayer: true,
+	}
+	if diffID := l.DiffID(); layer.DigestSHA256EmptyTar != diffID {
+		h.EmptyLayer = false
+		rootFS.Append(diffID)
+	}
+
+	config, err := json.Marshal(&image.Image{
+		V1Image: image.V1Image{
+			DockerVersion: dockerversion.Version,
+			Config:        base.Config,
+			Architecture:  runtime.GOARCH,
+			OS:            runtime.GOOS,
+			Author:        base.Author,
+			Comment:       comment,
+			Created:       h.Created,
+		},
+		RootFS:     rootFS,
+		History:    append(append([]image.History{}, base.History...), h),
+		OSFeatures: base.OSFeatures,
+		OSVersion:  base.OSVersion,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	id, err := daemon.imageStore.Create(config)
+	if err != nil {
+		return "", err
+	}
+	if err := daemon.imageStore.SetParent(id, base.ID()); err != nil {
+		return "", err
+	}
+
+	if newRef != nil {
+		if err := daemon.TagImageWithReference(id, newRef); err != nil {
+			return "", err
+		}
+	}
+
+	daemon.LogImageEvent(id.String(), id.String(), "import")
+	return id.String(), nil
+}
@@ -3,11 +3,13 @@ package daemon
 import (
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/docker/distribution/digest"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/distribution"
+	"github.com/docker/docker/image"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
@@ -41,11 +43,12 @@ func (daemon *Daemon) PullImage(ctx context.Context, image, tag string, metaHead
 		}
 	}
 
-	return daemon.pullImageWithReference(ctx, ref, metaHeaders, authConfig, outStream)
+	return daemon.pullImageWithReference(ctx, ref, metaHeaders, authConfig, daemon.imageStore, outStream)
 }
 
-// PullOnBuild tells Docker to pull image referenced by `name`.
-func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (builder.Image, error) {
+// PullOnBuild tells Docker to pull image referenced by `name`. The image is
+// held for the holder, which releases it with UnholdImage.
+func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, holder string, output io.Writer) (builder.Image, error) {
 	ref, err := reference.ParseNamed(name)
 	if err != nil {
 		return nil, err
@@ -67,13 +70,72 @@ func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs
 		pullRegistryAuth = &resolvedConfig
 	}
 
-	if err := daemon.pullImageWithReference(ctx, ref, nil, pullRegistryAuth, output); err != nil {
+	// The images are held as they are created by the pull, so that they
+	// cannot be deleted before the build holds the pulled image.
+	store := &heldImageStore{Store: daemon.imageStore, holder: holder}
+	defer store.release()
+	if err := daemon.pullImageWithReference(ctx, ref, nil, pullRegistryAuth, store, output); err != nil {
 		return nil, err
 	}
-	return daemon.GetImage(name)
+	img, err := daemon.GetImage(name)
+	if err != nil {
+		return nil, err
+	}
+	if !store.keep(img.ID()) {
+		// The image was not created by the pull, it was already there.
+		if err := daemon.imageStore.Hold(img.ID(), holder); err != nil {
+			return nil, err
+		}
+	}
+	return img, nil
 }
 
-func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.Named, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// heldImageStore is an image store whose images are created held for a
+// holder.
+type heldImageStore struct {
+	image.Store
+	holder string
+
+	mu      sync.Mutex
+	created []image.ID
+}
+
+func (s *heldImageStore) Create(config []byte) (image.ID, error) {
+	id, err := s.Store.CreateHeld(config, s.holder)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.created = append(s.created, id)
+	s.mu.Unlock()
+	return id, nil
+}
+
+// keep keeps one hold on the image id, and reports whether the image was
+// created held.
+func (s *heldImageStore) keep(id image.ID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, created := range s.created {
+		if created == id {
+			s.created = append(s.created[:i], s.created[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// release releases the holds on the created images which are not kept.
+func (s *heldImageStore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.created {
+		s.Store.Unhold(id, s.holder)
+	}
+	s.created = nil
+}
+
+func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.Named, metaHeaders map[string][]string, authConfig *types.AuthConfig, imageStore image.Store, outStream io.Writer) error {
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -92,15 +154,19 @@ func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.
 		AuthConfig:       authConfig,
 		ProgressOutput:   progress.ChanOutput(progressChan),
 		RegistryService:  daemon.RegistryService,
-		ImageEventLogger: daemon.LogImageEvent,
+		ImageEventLogger: daemon.LogImageEventWithAttributes,
 		MetadataStore:    daemon.distributionMetadataStore,
-		ImageStore:       daemon.imageStore,
+		ImageStore:       imageStore,
 		ReferenceStore:   daemon.referenceStore,
 		DownloadManager:  daemon.downloadManager,
 	}
+	imagePullConfig.PartialDownloadsDir = daemon.partialDownloadsDir
 
 	err := distribution.Pull(ctx, ref, imagePullConfig)
 	close(progressChan)
 	<-writesDone
+	if err == nil {
+		daemon.checkPulledLayerDepth(ref)
+	}
 	return err
 }
@@ -4,14 +4,18 @@ import (
 	"io"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/distribution"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"golang.org/x/net/context"
 )
 
 // PushImage initiates a push operation on the repository named localName.
-func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// The layers are compressed with compression, or with the push compression of
+// the daemon if it is not set.
+func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, compression backend.LayerCompression, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
 	ref, err := reference.ParseNamed(image)
 	if err != nil {
 		return err
@@ -24,6 +28,19 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 		}
 	}
 
+	if compression.Algorithm == "" && daemon.configStore != nil {
+		compression = backend.LayerCompression{
+			Algorithm: daemon.configStore.PushCompression,
+			Level:     daemon.configStore.PushCompressionLevel,
+		}
+	}
+	layerCompression := archive.Gzip
+	if compression.Algorithm != "" {
+		if layerCompression, err = archive.ParseCompression(compression.Algorithm); err != nil {
+			return err
+		}
+	}
+
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -42,13 +59,15 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 		AuthConfig:       authConfig,
 		ProgressOutput:   progress.ChanOutput(progressChan),
 		RegistryService:  daemon.RegistryService,
-		ImageEventLogger: daemon.LogImageEvent,
+		ImageEventLogger: daemon.LogImageEventWithAttributes,
 		MetadataStore:    daemon.distributionMetadataStore,
 		LayerStore:       daemon.layerStore,
 		ImageStore:       daemon.imageStore,
 		ReferenceStore:   daemon.referenceStore,
 		TrustKey:         daemon.trustKey,
 		UploadManager:    daemon.uploadManager,
+		Compression:      layerCompression,
+		CompressionLevel: compression.Level,
 	}
 
 	err = distribution.Push(ctx, ref, imagePushConfig)
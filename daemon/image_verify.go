@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/reference"
+)
+
+// ImageVerify recomputes the digest of the configuration and the DiffIDs of
+// the layers of the image with the given name, and reports the blobs which
+// are missing from the stores or do not match their digest.
+func (daemon *Daemon) ImageVerify(name string) (*types.ImageVerifyResponse, error) {
+	imgID, err := daemon.GetImageID(name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.ImageVerifyResponse{
+		ID:          imgID.String(),
+		RepoTags:    []string{},
+		RepoDigests: []string{},
+		Config:      types.BlobVerification{Digest: imgID.String(), Status: types.BlobStatusOK},
+		Layers:      []types.BlobVerification{},
+	}
+	for _, ref := range daemon.referenceStore.References(imgID.Digest()) {
+		switch ref.(type) {
+		case reference.NamedTagged:
+			resp.RepoTags = append(resp.RepoTags, ref.String())
+		case reference.Canonical:
+			resp.RepoDigests = append(resp.RepoDigests, ref.String())
+		}
+	}
+
+	// The image store verifies the configuration against its digest when
+	// it is read.
+	img, err := daemon.imageStore.Get(imgID)
+	if err != nil {
+		resp.Config.Status = types.BlobStatusCorrupt
+		if os.IsNotExist(err) {
+			resp.Config.Status = types.BlobStatusMissing
+		}
+		resp.Config.Message = err.Error()
+		return resp, nil
+	}
+
+	for i, diffID := range img.RootFS.DiffIDs {
+		chainID := layer.CreateChainID(img.RootFS.DiffIDs[:i+1])
+		v := types.BlobVerification{
+			Digest:  diffID.String(),
+			ChainID: chainID.String(),
+			Status:  types.BlobStatusOK,
+		}
+		if status, err := daemon.verifyLayer(chainID); err != nil {
+			v.Status = status
+			v.Message = err.Error()
+		}
+		resp.Layers = append(resp.Layers, v)
+	}
+	return resp, nil
+}
+
+// verifyLayer reads the tar stream of the layer, which is verified against
+// the DiffID of the layer, and returns the status of the layer if it fails.
+func (daemon *Daemon) verifyLayer(chainID layer.ChainID) (string, error) {
+	l, err := daemon.layerStore.Get(chainID)
+	if err != nil {
+		return types.BlobStatusMissing, err
+	}
+	defer layer.ReleaseAndLog(daemon.layerStore, l)
+
+	// The tar stream cannot be opened if the tar-split metadata of the
+	// layer is missing.
+	ts, err := l.TarStream()
+	if err != nil {
+		return types.BlobStatusMissing, err
+	}
+	defer ts.Close()
+	if _, err := io.Copy(ioutil.Discard, ts); err != nil {
+		return types.BlobStatusCorrupt, err
+	}
+	return types.BlobStatusOK, nil
+}
@@ -1,14 +1,21 @@
 package daemon
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"runtime"
+	"sort"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"golang.org/x/net/context"
+
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/parsers/kernel"
@@ -20,6 +27,7 @@ import (
 	"github.com/docker/docker/utils"
 	"github.com/docker/docker/volume/drivers"
 	"github.com/docker/go-connections/sockets"
+	"github.com/docker/libnetwork/portallocator"
 )
 
 // SystemInfo returns information about the host server the daemon is running on.
@@ -68,16 +76,41 @@ func (daemon *Daemon) SystemInfo() (*types.Info, error) {
 		}
 	})
 
-	var securityOptions []string
-	if sysInfo.AppArmor {
+	var (
+		securityOptions        []string
+		defaultSeccompProfile  string
+		defaultAppArmorProfile string
+	)
+	security := types.SecurityInfo{
+		Seccomp:  sysInfo.Seccomp && supportsSeccomp,
+		AppArmor: sysInfo.AppArmor,
+		SELinux:  selinuxEnabled(),
+		UserNS:   len(daemon.uidMaps) > 0,
+	}
+	if security.AppArmor {
 		securityOptions = append(securityOptions, "apparmor")
+		defaultAppArmorProfile = daemon.defaultAppArmorProfile()
 	}
-	if sysInfo.Seccomp && supportsSeccomp {
+	if security.Seccomp {
 		securityOptions = append(securityOptions, "seccomp")
+		defaultSeccompProfile = daemon.defaultSeccompProfile()
 	}
-	if selinuxEnabled() {
+	if security.SELinux {
 		securityOptions = append(securityOptions, "selinux")
 	}
+	if security.UserNS {
+		securityOptions = append(securityOptions, "userns")
+	}
+
+	driverStatus := daemon.layerStore.DriverStatus()
+	storageDriver := types.StorageDriverInfo{
+		Name:   daemon.GraphDriverName(),
+		Status: make(map[string]string, len(driverStatus)),
+	}
+	for _, s := range driverStatus {
+		storageDriver.Status[s[0]] = s[1]
+	}
+	storageDriver.LeakedMounts = daemon.leakedMountsCount()
 
 	v := &types.Info{
 		ID:                 daemon.ID,
@@ -87,7 +120,7 @@ func (daemon *Daemon) SystemInfo() (*types.Info, error) {
 		ContainersStopped:  int(cStopped),
 		Images:             len(daemon.imageStore.Map()),
 		Driver:             daemon.GraphDriverName(),
-		DriverStatus:       daemon.layerStore.DriverStatus(),
+		DriverStatus:       driverStatus,
 		Plugins:            daemon.showPluginsInfo(),
 		IPv4Forwarding:     !sysInfo.IPv4ForwardingDisabled,
 		BridgeNfIptables:   !sysInfo.BridgeNFCallIPTablesDisabled,
@@ -119,7 +152,12 @@ func (daemon *Daemon) SystemInfo() (*types.Info, error) {
 		SecurityOptions:    securityOptions,
 		LiveRestoreEnabled: daemon.configStore.LiveRestoreEnabled,
 		Isolation:          daemon.defaultIsolation,
+		Maintenance:        daemon.maintenanceInfo(),
 	}
+	v.DefaultSeccompProfile = defaultSeccompProfile
+	v.DefaultAppArmorProfile = defaultAppArmorProfile
+	v.Security = security
+	v.StorageDriver = storageDriver
 
 	// TODO Windows. Refactor this more once sysinfo is refactored into
 	// platform specific code. On Windows, sysinfo.cgroupMemInfo and
@@ -136,6 +174,10 @@ func (daemon *Daemon) SystemInfo() (*types.Info, error) {
 		v.CPUSet = sysInfo.Cpuset
 		v.Runtimes = daemon.configStore.GetAllRuntimes()
 		v.DefaultRuntime = daemon.configStore.GetDefaultRuntimeName()
+		v.ContainerdVersion = daemon.containerdVersion()
+		v.RuncVersion = runcVersion(DefaultRuntimeBinary)
+		allocator := portallocator.Get()
+		v.PublishedPortRange = fmt.Sprintf("%d-%d", allocator.Begin, allocator.End)
 	}
 
 	hostname := ""
@@ -172,6 +214,26 @@ func (daemon *Daemon) SystemVersion() types.Version {
 	return v
 }
 
+// SystemLogDrivers returns the logging drivers that containers can use,
+// along with the log options each of them supports.
+func (daemon *Daemon) SystemLogDrivers() []types.LogDriver {
+	drivers := []types.LogDriver{{Name: "none", Options: []string{}}}
+	for _, name := range logger.ListLogDrivers() {
+		drivers = append(drivers, types.LogDriver{
+			Name:    name,
+			Options: logger.GetLogOpts(name),
+		})
+	}
+	sort.Sort(byLogDriverName(drivers))
+	return drivers
+}
+
+type byLogDriverName []types.LogDriver
+
+func (d byLogDriverName) Len() int           { return len(d) }
+func (d byLogDriverName) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d byLogDriverName) Less(i, j int) bool { return d[i].Name < d[j].Name }
+
 func (daemon *Daemon) showPluginsInfo() types.PluginsInfo {
 	var pluginsInfo types.PluginsInfo
 
@@ -181,3 +243,49 @@ func (daemon *Daemon) showPluginsInfo() types.PluginsInfo {
 
 	return pluginsInfo
 }
+
+// containerdVersion returns the version of the containerd server the
+// containers run with.
+func (daemon *Daemon) containerdVersion() types.ComponentVersion {
+	if daemon.containerd == nil {
+		return types.ComponentVersion{}
+	}
+	sv, err := daemon.containerd.GetServerVersion(context.Background())
+	if err != nil {
+		logrus.Warnf("Could not get containerd version: %v", err)
+		return types.ComponentVersion{}
+	}
+	return types.ComponentVersion{
+		Version: fmt.Sprintf("%d.%d.%d", sv.Major, sv.Minor, sv.Patch),
+		Commit:  sv.Revision,
+	}
+}
+
+// runcVersion returns the version of the runc binary.
+func runcVersion(path string) types.ComponentVersion {
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		logrus.Warnf("Could not get %s version: %v", path, err)
+		return types.ComponentVersion{}
+	}
+	return parseRuncVersion(string(out))
+}
+
+// parseRuncVersion parses the output of runc --version:
+//
+//	runc version 1.0.0-rc2
+//	commit: c91b5bea4830a57eac7882d7455d59518cdf70ec
+//	spec: 1.0.0-rc2-dev
+func parseRuncVersion(out string) types.ComponentVersion {
+	var v types.ComponentVersion
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "runc version "):
+			v.Version = strings.TrimPrefix(line, "runc version ")
+		case strings.HasPrefix(line, "commit: "):
+			v.Commit = strings.TrimPrefix(line, "commit: ")
+		}
+	}
+	return v
+}
@@ -0,0 +1,22 @@
+package daemon
+
+import "testing"
+
+func TestParseRuncVersion(t *testing.T) {
+	out := `runc version 1.0.0-rc2
+commit: c91b5bea4830a57eac7882d7455d59518cdf70ec
+spec: 1.0.0-rc2-dev
+`
+	v := parseRuncVersion(out)
+	if v.Version != "1.0.0-rc2" {
+		t.Fatalf("Expected version 1.0.0-rc2, got %q", v.Version)
+	}
+	if v.Commit != "c91b5bea4830a57eac7882d7455d59518cdf70ec" {
+		t.Fatalf("Expected commit c91b5bea4830a57eac7882d7455d59518cdf70ec, got %q", v.Commit)
+	}
+
+	v = parseRuncVersion("unexpected output")
+	if v.Version != "" || v.Commit != "" {
+		t.Fatalf("Expected no version for an unexpected output, got %+v", v)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/api/types/versions/v1p20"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/network"
 )
 
@@ -138,11 +139,19 @@ func (daemon *Daemon) getInspectData(container *container.Container, size bool)
 		Dead:       container.State.Dead,
 		Pid:        container.State.Pid,
 		ExitCode:   container.State.ExitCode(),
+		Signal:     exitSignal(container.State.ExitCode()),
 		Error:      container.State.Error(),
 		StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
 		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
 		Health:     containerHealth,
 	}
+	if container.State.ResourceAccounting != nil {
+		accounting := *container.State.ResourceAccounting
+		containerState.ResourceAccounting = &accounting
+	}
+	if c, ok := container.LogDriver.(logger.DropCounter); ok && container.State.Running {
+		containerState.LogMessagesDropped = c.Dropped()
+	}
 
 	contJSONBase := &types.ContainerJSONBase{
 		ID:           container.ID,
@@ -157,6 +166,7 @@ func (daemon *Daemon) getInspectData(container *container.Container, size bool)
 		Driver:       container.Driver,
 		MountLabel:   container.MountLabel,
 		ProcessLabel: container.ProcessLabel,
+		Pod:          container.Config.Labels[types.ContainerPodLabel],
 		ExecIDs:      container.GetExecIDs(),
 		HostConfig:   &hostConfig,
 	}
@@ -172,7 +182,7 @@ func (daemon *Daemon) getInspectData(container *container.Container, size bool)
 	}
 
 	// Now set any platform-specific fields
-	contJSONBase = setPlatformSpecificContainerFields(container, contJSONBase)
+	contJSONBase = daemon.setPlatformSpecificContainerFields(container, contJSONBase)
 
 	contJSONBase.GraphDriver.Name = container.Driver
 
@@ -5,14 +5,20 @@ package daemon
 import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
+	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/versions/v1p19"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/volume"
 )
 
 // This sets platform-specific fields
-func setPlatformSpecificContainerFields(container *container.Container, contJSONBase *types.ContainerJSONBase) *types.ContainerJSONBase {
+func (daemon *Daemon) setPlatformSpecificContainerFields(container *container.Container, contJSONBase *types.ContainerJSONBase) *types.ContainerJSONBase {
 	contJSONBase.AppArmorProfile = container.AppArmorProfile
+	if appArmorProfile := daemon.containerAppArmorProfile(container); appArmorProfile != "" {
+		contJSONBase.AppArmorProfile = appArmorProfile
+	}
+	contJSONBase.SeccompProfile = daemon.containerSeccompProfile(container)
 	contJSONBase.ResolvConfPath = container.ResolvConfPath
 	contJSONBase.HostnamePath = container.HostnamePath
 	contJSONBase.HostsPath = container.HostsPath
@@ -67,15 +73,23 @@ func (daemon *Daemon) containerInspectPre120(name string) (*v1p19.ContainerJSON,
 func addMountPoints(container *container.Container) []types.MountPoint {
 	mountPoints := make([]types.MountPoint, 0, len(container.MountPoints))
 	for _, m := range container.MountPoints {
+		// report the propagation that is applied to bind mounts without
+		// an explicit mode
+		propagation := m.Propagation
+		if m.Type == mounttypes.TypeBind && propagation == "" {
+			propagation = volume.DefaultPropagationMode
+		}
 		mountPoints = append(mountPoints, types.MountPoint{
-			Type:        m.Type,
-			Name:        m.Name,
-			Source:      m.Path(),
-			Destination: m.Destination,
-			Driver:      m.Driver,
-			Mode:        m.Mode,
-			RW:          m.RW,
-			Propagation: m.Propagation,
+			Type:         m.Type,
+			Name:         m.Name,
+			Source:       m.Path(),
+			Destination:  m.Destination,
+			Driver:       m.Driver,
+			Mode:         m.Mode,
+			RW:           m.RW,
+			Propagation:  propagation,
+			SELinuxLabel: m.SELinuxLabel,
+			CopyResult:   m.CopyResult,
 		})
 	}
 	return mountPoints
@@ -0,0 +1,319 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/jobs"
+	"github.com/docker/docker/pkg/namesgenerator"
+)
+
+// defaultJobHistory is the default number of runs of a job of which the
+// containers are kept.
+const defaultJobHistory = 5
+
+type errNoSuchJob struct {
+	name string
+}
+
+func (e errNoSuchJob) Error() string {
+	return fmt.Sprintf("No such job: %s", e.name)
+}
+
+// jobScheduler holds the channels stopping the schedulers of the jobs, by
+// name.
+type jobScheduler struct {
+	sync.Mutex
+	stops map[string]chan struct{}
+}
+
+// add registers the scheduler of the job name, and returns the channel
+// stopping it.
+func (s *jobScheduler) add(name string) chan struct{} {
+	s.Lock()
+	defer s.Unlock()
+	if s.stops == nil {
+		s.stops = make(map[string]chan struct{})
+	}
+	stop := make(chan struct{})
+	s.stops[name] = stop
+	return stop
+}
+
+// remove stops the scheduler of the job name, if any.
+func (s *jobScheduler) remove(name string) {
+	s.Lock()
+	defer s.Unlock()
+	if stop, ok := s.stops[name]; ok {
+		close(stop)
+		delete(s.stops, name)
+	}
+}
+
+// removeAll stops the schedulers of all the jobs.
+func (s *jobScheduler) removeAll() {
+	s.Lock()
+	defer s.Unlock()
+	for name, stop := range s.stops {
+		close(stop)
+		delete(s.stops, name)
+	}
+}
+
+type byCreatedDesc []*container.Container
+
+func (r byCreatedDesc) Len() int           { return len(r) }
+func (r byCreatedDesc) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byCreatedDesc) Less(i, j int) bool { return r[i].Created.After(r[j].Created) }
+
+// jobContainers returns the containers run by the job name, the latest
+// first.
+func (daemon *Daemon) jobContainers(name string) []*container.Container {
+	var containers []*container.Container
+	for _, c := range daemon.List() {
+		if c.Config.Labels[types.ContainerJobLabel] == name {
+			containers = append(containers, c)
+		}
+	}
+	sort.Sort(byCreatedDesc(containers))
+	return containers
+}
+
+// jobRunConfig returns the configuration of the containers run by the job,
+// without the reserved label holding the name of the job.
+func jobRunConfig(j *types.Job) (*containertypes.Config, *containertypes.HostConfig) {
+	config := *j.Config
+
+	hostConfig := &containertypes.HostConfig{}
+	if j.HostConfig != nil {
+		c := *j.HostConfig
+		hostConfig = &c
+	}
+	return &config, hostConfig
+}
+
+// startJobs starts the schedulers of the jobs of the store. It is called
+// once the containers are restored.
+func (daemon *Daemon) startJobs() {
+	for _, j := range daemon.jobStore.List() {
+		schedule, err := jobs.ParseSchedule(j.Schedule)
+		if err != nil {
+			logrus.Errorf("Error scheduling the job %s: %v", j.Name, err)
+			continue
+		}
+		go daemon.scheduleJob(j.Name, schedule, daemon.jobSchedules.add(j.Name))
+	}
+}
+
+// scheduleJob runs the job name on its schedule, until stop is closed.
+func (daemon *Daemon) scheduleJob(name string, schedule *jobs.Schedule, stop chan struct{}) {
+	for {
+		now := time.Now()
+		next := schedule.Next(now)
+		if next.IsZero() {
+			logrus.Errorf("The job %s is not scheduled anymore, its schedule does not match in the next years", name)
+			return
+		}
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if err := daemon.runJob(name); err != nil {
+			logrus.Errorf("Error running the job %s: %v", name, err)
+		}
+	}
+}
+
+// runJob creates and starts a container running the job name, unless its
+// previous run is still running, and removes the containers of its runs
+// beyond its history.
+func (daemon *Daemon) runJob(name string) error {
+	daemon.jobsLock.Lock()
+	defer daemon.jobsLock.Unlock()
+
+	j := daemon.jobStore.Get(name)
+	if j == nil || daemon.shutdown {
+		return nil
+	}
+	containers := daemon.jobContainers(name)
+	if len(containers) > 0 && containers[0].IsRunning() {
+		logrus.Warnf("Skipping a run of the job %s, its previous run %s is still running", name, strings.TrimPrefix(containers[0].Name, "/"))
+		return nil
+	}
+
+	config, hostConfig := jobRunConfig(j)
+	ccr, err := daemon.containerCreate(types.ContainerCreateConfig{
+		Name:             fmt.Sprintf("%s-%d", name, time.Now().Unix()),
+		Config:           config,
+		HostConfig:       hostConfig,
+		NetworkingConfig: j.NetworkingConfig,
+	}, false, true, map[string]string{types.ContainerJobLabel: name})
+	if err != nil {
+		return err
+	}
+	if err := daemon.ContainerStart(ccr.ID, nil, true, ""); err != nil {
+		return err
+	}
+
+	// The new run is the latest, it is not part of containers.
+	for i, c := range containers {
+		if i < j.History-1 || c.IsRunning() {
+			continue
+		}
+		if err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{RemoveVolume: true}); err != nil {
+			logrus.Errorf("Error removing the run %s of the job %s: %v", c.ID, name, err)
+		}
+	}
+	return nil
+}
+
+// JobCreate creates a job, which runs a container with the given
+// configuration on its schedule.
+func (daemon *Daemon) JobCreate(req *types.JobCreateRequest) (*types.Job, error) {
+	name := req.Name
+	if name == "" {
+		name = namesgenerator.GetRandomName(0)
+	}
+	if !validContainerNamePattern.MatchString(name) {
+		return nil, errors.NewBadRequestError(fmt.Errorf("Invalid job name (%s), only %s are allowed", name, validContainerNameChars))
+	}
+	schedule, err := jobs.ParseSchedule(req.Schedule)
+	if err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+	if req.Config == nil || req.Config.Image == "" {
+		return nil, errors.NewBadRequestError(fmt.Errorf("the image of the job is missing"))
+	}
+	if _, err := daemon.GetImage(req.Config.Image); err != nil {
+		return nil, err
+	}
+	if err := verifyReservedLabels(req.Config.Labels); err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+
+	hostConfig := req.HostConfig
+	if hostConfig == nil {
+		hostConfig = &containertypes.HostConfig{}
+	}
+	if _, err := daemon.verifyContainerSettings(hostConfig, req.Config, false, true); err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+	if hostConfig.AutoRemove {
+		return nil, errors.NewBadRequestError(fmt.Errorf("the runs of a job cannot be removed automatically, they are kept for their logs"))
+	}
+	if hostConfig.RestartPolicy.IsAlways() || hostConfig.RestartPolicy.IsUnlessStopped() {
+		return nil, errors.NewBadRequestError(fmt.Errorf("the runs of a job cannot be restarted %s", hostConfig.RestartPolicy.Name))
+	}
+	history := req.History
+	if history < 0 {
+		return nil, errors.NewBadRequestError(fmt.Errorf("the history of a job cannot be negative"))
+	}
+	if history == 0 {
+		history = defaultJobHistory
+	}
+
+	daemon.jobsLock.Lock()
+	defer daemon.jobsLock.Unlock()
+
+	if daemon.jobStore.Get(name) != nil {
+		return nil, errors.NewRequestConflictError(fmt.Errorf("job %s already exists", name))
+	}
+	j := &types.Job{
+		Name:             name,
+		Schedule:         req.Schedule,
+		Config:           req.Config,
+		HostConfig:       hostConfig,
+		NetworkingConfig: req.NetworkingConfig,
+		History:          history,
+		Created:          time.Now().UTC(),
+	}
+	if err := daemon.jobStore.Add(j); err != nil {
+		return nil, err
+	}
+	go daemon.scheduleJob(name, schedule, daemon.jobSchedules.add(name))
+
+	return daemon.jobInspect(j), nil
+}
+
+// JobRm removes the containers of the runs of a job, and then the job.
+// Unless force is set, the job must not be running.
+func (daemon *Daemon) JobRm(name string, force bool) error {
+	daemon.jobsLock.Lock()
+	defer daemon.jobsLock.Unlock()
+
+	if daemon.jobStore.Get(name) == nil {
+		return errors.NewRequestNotFoundError(errNoSuchJob{name})
+	}
+	containers := daemon.jobContainers(name)
+	if !force {
+		for _, c := range containers {
+			if c.IsRunning() {
+				return errors.NewRequestConflictError(fmt.Errorf("You cannot remove the running job %s. Wait for its run to exit before attempting removal or use force", name))
+			}
+		}
+	}
+
+	// The job is only removed once all its runs are, so that a failed
+	// removal leaves no runs without their job.
+	for _, c := range containers {
+		if err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{ForceRemove: force, RemoveVolume: true}); err != nil {
+			return err
+		}
+	}
+	daemon.jobSchedules.remove(name)
+	return daemon.jobStore.Remove(name)
+}
+
+// JobInspect returns a job, with its next run and the runs which are kept.
+func (daemon *Daemon) JobInspect(name string) (*types.Job, error) {
+	j := daemon.jobStore.Get(name)
+	if j == nil {
+		return nil, errors.NewRequestNotFoundError(errNoSuchJob{name})
+	}
+	return daemon.jobInspect(j), nil
+}
+
+func (daemon *Daemon) jobInspect(j *types.Job) *types.Job {
+	job := *j
+	if schedule, err := jobs.ParseSchedule(j.Schedule); err == nil {
+		job.NextRun = schedule.Next(time.Now())
+	}
+	for _, c := range daemon.jobContainers(j.Name) {
+		c.Lock()
+		run := types.JobRun{
+			ID:       c.ID,
+			Name:     strings.TrimPrefix(c.Name, "/"),
+			State:    c.State.StateString(),
+			ExitCode: c.ExitCode(),
+		}
+		if !c.StartedAt.IsZero() {
+			run.StartedAt = c.StartedAt.Format(time.RFC3339Nano)
+		}
+		if !c.FinishedAt.IsZero() {
+			run.FinishedAt = c.FinishedAt.Format(time.RFC3339Nano)
+		}
+		c.Unlock()
+		job.Runs = append(job.Runs, run)
+	}
+	return &job
+}
+
+// Jobs returns the jobs, sorted by name.
+func (daemon *Daemon) Jobs() []*types.Job {
+	list := []*types.Job{}
+	for _, j := range daemon.jobStore.List() {
+		list = append(list, daemon.jobInspect(j))
+	}
+	return list
+}
@@ -0,0 +1,30 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+)
+
+func TestJobRunConfig(t *testing.T) {
+	labels := map[string]string{"team": "backup"}
+	j := &types.Job{
+		Name:       "nightly",
+		Config:     &containertypes.Config{Image: "busybox", Labels: labels},
+		HostConfig: &containertypes.HostConfig{Privileged: true},
+	}
+	config, hostConfig := jobRunConfig(j)
+	if len(config.Labels) != 1 || config.Labels["team"] != "backup" {
+		t.Fatalf("unexpected labels %v", config.Labels)
+	}
+	hostConfig.Privileged = false
+	if !j.HostConfig.Privileged {
+		t.Fatal("expected the host configuration of the job to be copied")
+	}
+
+	j.HostConfig = nil
+	if _, hostConfig = jobRunConfig(j); hostConfig == nil {
+		t.Fatal("expected an empty host configuration")
+	}
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@never",
+		"0 0 30 2 *",
+	} {
+		if _, err := ParseSchedule(spec); err == nil || !strings.Contains(err.Error(), "invalid schedule") {
+			t.Errorf("expected an error for the schedule %q, got %v", spec, err)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday, March 1st 2017
+	now := time.Date(2017, time.March, 1, 10, 7, 30, 0, time.UTC)
+	for _, c := range []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2017, time.March, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/5 * * * *", time.Date(2017, time.March, 1, 10, 10, 0, 0, time.UTC)},
+		{"7 * * * *", time.Date(2017, time.March, 1, 11, 7, 0, 0, time.UTC)},
+		{"0,30 9-17 * * *", time.Date(2017, time.March, 1, 10, 30, 0, 0, time.UTC)},
+		{"10-50/20 * * * *", time.Date(2017, time.March, 1, 10, 10, 0, 0, time.UTC)},
+		{"15/20 * * * *", time.Date(2017, time.March, 1, 10, 15, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2017, time.March, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2017, time.March, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2017, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2017, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Either the day of the month or the day of the week matches when
+		// both are restricted.
+		{"0 0 15 * 5", time.Date(2017, time.March, 3, 0, 0, 0, 0, time.UTC)},
+		// Both match otherwise.
+		{"0 0 * 4 5", time.Date(2017, time.April, 7, 0, 0, 0, 0, time.UTC)},
+	} {
+		s, err := ParseSchedule(c.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", c.spec, err)
+		}
+		if next := s.Next(now); !next.Equal(c.next) {
+			t.Errorf("%s: expected the next run at %s, got %s", c.spec, c.next, next)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Package jobs persists the jobs of the daemon, the containers it runs on a
+// cron schedule, and parses their schedules.
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+const indexFile = "jobs.json"
+
+// Store is the set of the jobs, persisted in a directory.
+type Store struct {
+	mu   sync.Mutex
+	root string
+	jobs map[string]*types.Job
+}
+
+// New returns the store of the jobs in the given directory, which is created
+// if it does not exist.
+func New(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	s := &Store{root: root, jobs: make(map[string]*types.Job)}
+
+	b, err := ioutil.ReadFile(filepath.Join(root, indexFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, &s.jobs); err != nil {
+			return nil, fmt.Errorf("invalid index of the jobs: %v", err)
+		}
+	}
+	return s, nil
+}
+
+// Get returns the job name, or nil if there is no such job.
+func (s *Store) Get(name string) *types.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs[name]
+}
+
+// List returns the jobs, sorted by name.
+func (s *Store) List() []*types.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	jobs := make([]*types.Job, 0, len(names))
+	for _, name := range names {
+		jobs = append(jobs, s.jobs[name])
+	}
+	return jobs
+}
+
+// Add adds the job to the store. It fails if a job of the same name exists.
+func (s *Store) Add(j *types.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[j.Name]; ok {
+		return fmt.Errorf("job %s already exists", j.Name)
+	}
+	s.jobs[j.Name] = j
+	if err := s.save(); err != nil {
+		delete(s.jobs, j.Name)
+		return err
+	}
+	return nil
+}
+
+// Remove removes the job name from the store.
+func (s *Store) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[name]
+	if !ok {
+		return nil
+	}
+	delete(s.jobs, name)
+	if err := s.save(); err != nil {
+		s.jobs[name] = j
+		return err
+	}
+	return nil
+}
+
+// save writes the index of the store.
+func (s *Store) save() error {
+	b, err := json.Marshal(s.jobs)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(filepath.Join(s.root, indexFile), b, 0600)
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestStore(t *testing.T) {
+	root, err := ioutil.TempDir("", "jobs-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	s, err := New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"b", "a"} {
+		if err := s.Add(&types.Job{Name: name, Schedule: "@daily"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Add(&types.Job{Name: "a"}); err == nil {
+		t.Fatal("expected an error adding a job twice")
+	}
+
+	// The jobs are reloaded from disk.
+	s, err = New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := s.List()
+	if len(jobs) != 2 || jobs[0].Name != "a" || jobs[1].Name != "b" {
+		t.Fatalf("expected the jobs a and b, got %+v", jobs)
+	}
+	if j := s.Get("a"); j == nil || j.Schedule != "@daily" {
+		t.Fatalf("expected the job a, got %+v", j)
+	}
+
+	if err := s.Remove("a"); err != nil {
+		t.Fatal(err)
+	}
+	if j := s.Get("a"); j != nil {
+		t.Fatalf("expected the job a to be removed, got %+v", j)
+	}
+	s, err = New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobs := s.List(); len(jobs) != 1 || jobs[0].Name != "b" {
+		t.Fatalf("expected the job b, got %+v", jobs)
+	}
+}
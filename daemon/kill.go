@@ -56,6 +56,9 @@ func (daemon *Daemon) ContainerKill(name string, sig uint64) error {
 // underlying kill command.
 func (daemon *Daemon) killWithSignal(container *container.Container, sig int) error {
 	logrus.Debugf("Sending kill signal %d to container %s", sig, container.ID)
+	// The resource usage of the container cannot be sampled anymore once
+	// its processes are killed.
+	daemon.sampleResourceUsage(container)
 	container.Lock()
 	defer container.Unlock()
 
@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/reference"
+)
+
+// layerDepthWarning returns the number of layers of an image above which
+// the daemon warns that the image should be flattened, 0 if the warning is
+// disabled.
+func (daemon *Daemon) layerDepthWarning() int {
+	if daemon.configStore == nil || daemon.configStore.LayerDepthWarning == nil {
+		return defaultLayerDepthWarning
+	}
+	return *daemon.configStore.LayerDepthWarning
+}
+
+// imageLayerDepthWarning returns a warning if the image has more layers than
+// the daemon warns about, or an empty string. Deep layer chains degrade the
+// performance of the union filesystems, such as aufs and overlay.
+func (daemon *Daemon) imageLayerDepthWarning(img *image.Image) string {
+	limit := daemon.layerDepthWarning()
+	if limit == 0 || len(img.RootFS.DiffIDs) <= limit {
+		return ""
+	}
+	return fmt.Sprintf("The image has %d layers, more than %d. Deep layer chains degrade the performance of the storage driver; the image can be flattened with `docker image flatten`.", len(img.RootFS.DiffIDs), limit)
+}
+
+// LayerDepthWarning returns a warning if the image has more layers than the
+// daemon warns about, or an empty string.
+func (daemon *Daemon) LayerDepthWarning(imageID string) string {
+	img, err := daemon.imageStore.Get(image.ID(imageID))
+	if err != nil {
+		return ""
+	}
+	return daemon.imageLayerDepthWarning(img)
+}
+
+// checkLayerDepth logs a layer-depth event for the image if it has more
+// layers than the daemon warns about.
+func (daemon *Daemon) checkLayerDepth(id image.ID, refName string) {
+	img, err := daemon.imageStore.Get(id)
+	if err != nil {
+		return
+	}
+	warning := daemon.imageLayerDepthWarning(img)
+	if warning == "" {
+		return
+	}
+	logrus.Warnf("Image %s: %s", id, warning)
+	daemon.LogImageEventWithAttributes(id.String(), refName, "layer-depth", map[string]string{
+		"layers": strconv.Itoa(len(img.RootFS.DiffIDs)),
+		"limit":  strconv.Itoa(daemon.layerDepthWarning()),
+	})
+}
+
+// checkPulledLayerDepth checks the layer depth of the images pulled with ref,
+// which references all the tags of a repository if it has no tag or digest.
+func (daemon *Daemon) checkPulledLayerDepth(ref reference.Named) {
+	switch ref.(type) {
+	case reference.NamedTagged, reference.Canonical:
+		if id, err := daemon.referenceStore.Get(ref); err == nil {
+			daemon.checkLayerDepth(image.ID(id), ref.String())
+		}
+		return
+	}
+
+	checked := make(map[digest.Digest]bool)
+	for _, assoc := range daemon.referenceStore.ReferencesByName(ref) {
+		if !checked[assoc.ID] {
+			checked[assoc.ID] = true
+			daemon.checkLayerDepth(image.ID(assoc.ID), assoc.Ref.String())
+		}
+	}
+}
+
+// layerDepthEventLogger logs the image events of the loads, and checks the
+// layer depth of the images loaded.
+type layerDepthEventLogger struct {
+	daemon *Daemon
+}
+
+func (l layerDepthEventLogger) LogImageEvent(imageID, refName, action string) {
+	l.daemon.LogImageEvent(imageID, refName, action)
+	if action == "load" {
+		l.daemon.checkLayerDepth(image.ID(imageID), refName)
+	}
+}
@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+)
+
+func newTestLayerDepthImage(layers int) *image.Image {
+	img := &image.Image{RootFS: image.NewRootFS()}
+	for i := 0; i < layers; i++ {
+		img.RootFS.Append(layer.DiffID(layer.DigestSHA256EmptyTar))
+	}
+	return img
+}
+
+func TestImageLayerDepthWarning(t *testing.T) {
+	d := &Daemon{configStore: &Config{}}
+	if warning := d.imageLayerDepthWarning(newTestLayerDepthImage(defaultLayerDepthWarning)); warning != "" {
+		t.Fatalf("Expected no warning for an image at the default limit, got %q", warning)
+	}
+	if warning := d.imageLayerDepthWarning(newTestLayerDepthImage(defaultLayerDepthWarning + 1)); warning == "" {
+		t.Fatal("Expected a warning for an image above the default limit")
+	}
+
+	limit := 2
+	d.configStore.LayerDepthWarning = &limit
+	expected := "The image has 3 layers, more than 2. Deep layer chains degrade the performance of the storage driver; the image can be flattened with `docker image flatten`."
+	if warning := d.imageLayerDepthWarning(newTestLayerDepthImage(3)); warning != expected {
+		t.Fatalf("Expected warning %q, got %q", expected, warning)
+	}
+
+	limit = 0
+	if warning := d.imageLayerDepthWarning(newTestLayerDepthImage(1000)); warning != "" {
+		t.Fatalf("Expected no warning when the warning is disabled, got %q", warning)
+	}
+}
@@ -22,12 +22,14 @@ var acceptedVolumeFilterTags = map[string]bool{
 	"name":     true,
 	"driver":   true,
 	"label":    true,
+	"scope":    true,
 }
 
 var acceptedPsFilterTags = map[string]bool{
 	"ancestor":  true,
 	"before":    true,
 	"exited":    true,
+	"group":     true,
 	"id":        true,
 	"isolation": true,
 	"label":     true,
@@ -37,6 +39,7 @@ var acceptedPsFilterTags = map[string]bool{
 	"volume":    true,
 	"network":   true,
 	"is-task":   true,
+	"pod":       true,
 }
 
 // iterationAction represents possible outcomes happening during the container iteration.
@@ -103,7 +106,19 @@ func (r byContainerCreated) Less(i, j int) bool {
 
 // Containers returns the list of containers to show given the user's filtering.
 func (daemon *Daemon) Containers(config *types.ContainerListOptions) ([]*types.Container, error) {
-	return daemon.reduceContainers(config, daemon.transformContainer)
+	containers, err := daemon.reduceContainers(config, daemon.transformContainer)
+	if err != nil || !config.Resources {
+		return containers, err
+	}
+
+	// Resource usage is collected once the containers are no longer locked,
+	// as sampling the stats of a container needs to inspect its state.
+	for _, c := range containers {
+		if ctr := daemon.containers.Get(c.ID); ctr != nil && ctr.IsRunning() {
+			c.Resources = daemon.getResourceUsage(ctr)
+		}
+	}
+	return containers, nil
 }
 
 func (daemon *Daemon) filterByNameIDMatches(ctx *listContext) []*container.Container {
@@ -355,6 +370,16 @@ func includeContainerInList(container *container.Container, ctx *listContext) it
 		return excludeContainer
 	}
 
+	// Do not include container if it is not in the pod
+	if ctx.filters.Include("pod") && !ctx.filters.ExactMatch("pod", container.Config.Labels[types.ContainerPodLabel]) {
+		return excludeContainer
+	}
+
+	// Do not include container if it is not a replica of the group
+	if ctx.filters.Include("group") && !ctx.filters.ExactMatch("group", container.Config.Labels[types.ContainerGroupLabel]) {
+		return excludeContainer
+	}
+
 	// Do not include container if isolation doesn't match
 	if excludeContainer == excludeByIsolation(container, ctx) {
 		return excludeContainer
@@ -607,6 +632,15 @@ func (daemon *Daemon) filterVolumes(vols []volume.Volume, filter filters.Args) (
 				continue
 			}
 		}
+		if filter.Include("scope") {
+			scope := volume.LocalScope
+			if v, ok := vol.(volume.ScopedVolume); ok {
+				scope = v.Scope()
+			}
+			if !filter.ExactMatch("scope", scope) {
+				continue
+			}
+		}
 		if filter.Include("label") {
 			v, ok := vol.(volume.LabeledVolume)
 			if !ok {
@@ -78,6 +78,9 @@ func init() {
 	if err := logger.RegisterLogOptValidator(name, ValidateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(name, logGroupKey, logStreamKey, regionKey); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // New creates an awslogs logger using the configuration passed in on the
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -15,6 +16,7 @@ type LogOptValidator func(cfg map[string]string) error
 type logdriverFactory struct {
 	registry     map[string]Creator
 	optValidator map[string]LogOptValidator
+	opts         map[string][]string
 	m            sync.Mutex
 }
 
@@ -47,6 +49,38 @@ func (lf *logdriverFactory) registerLogOptValidator(name string, l LogOptValidat
 	return nil
 }
 
+func (lf *logdriverFactory) registerLogOpts(name string, opts []string) error {
+	lf.m.Lock()
+	defer lf.m.Unlock()
+
+	if _, ok := lf.opts[name]; ok {
+		return fmt.Errorf("logger: log options for '%s' are already registered", name)
+	}
+	sorted := append([]string(nil), opts...)
+	sort.Strings(sorted)
+	lf.opts[name] = sorted
+	return nil
+}
+
+func (lf *logdriverFactory) drivers() []string {
+	lf.m.Lock()
+	defer lf.m.Unlock()
+
+	names := make([]string, 0, len(lf.registry))
+	for name := range lf.registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (lf *logdriverFactory) getLogOpts(name string) []string {
+	lf.m.Lock()
+	defer lf.m.Unlock()
+
+	return append([]string(nil), lf.opts[name]...)
+}
+
 func (lf *logdriverFactory) get(name string) (Creator, error) {
 	lf.m.Lock()
 	defer lf.m.Unlock()
@@ -66,7 +100,7 @@ func (lf *logdriverFactory) getLogOptValidator(name string) LogOptValidator {
 	return c
 }
 
-var factory = &logdriverFactory{registry: make(map[string]Creator), optValidator: make(map[string]LogOptValidator)} // global factory instance
+var factory = &logdriverFactory{registry: make(map[string]Creator), optValidator: make(map[string]LogOptValidator), opts: make(map[string][]string)} // global factory instance
 
 // RegisterLogDriver registers the given logging driver builder with given logging
 // driver name.
@@ -80,6 +114,23 @@ func RegisterLogOptValidator(name string, l LogOptValidator) error {
 	return factory.registerLogOptValidator(name, l)
 }
 
+// RegisterLogOpts registers the names of the options supported by the
+// logging driver with the given name.
+func RegisterLogOpts(name string, opts ...string) error {
+	return factory.registerLogOpts(name, opts)
+}
+
+// ListLogDrivers returns the sorted names of the registered logging drivers.
+func ListLogDrivers() []string {
+	return factory.drivers()
+}
+
+// GetLogOpts returns the sorted names of the options supported by the
+// logging driver with the given name.
+func GetLogOpts(name string) []string {
+	return factory.getLogOpts(name)
+}
+
 // GetLogDriver provides the logging driver builder for a logging driver name.
 func GetLogDriver(name string) (Creator, error) {
 	return factory.get(name)
@@ -96,6 +147,30 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
 	}
 
+	if err := validateCacheOpts(cfg); err != nil {
+		return err
+	}
+	if _, ok := cfg[CacheEnabledKey]; ok {
+		// the cache options are supported by all the drivers and are not
+		// passed to their validators
+		driverCfg := make(map[string]string, len(cfg))
+		for k, v := range cfg {
+			if k != CacheEnabledKey {
+				driverCfg[k] = v
+			}
+		}
+		cfg = driverCfg
+	}
+
+	if opts := factory.getLogOpts(name); len(opts) > 0 {
+		for key := range cfg {
+			i := sort.SearchStrings(opts, key)
+			if i == len(opts) || opts[i] != key {
+				return fmt.Errorf("unknown log opt '%s' for %s log driver", key, name)
+			}
+		}
+	}
+
 	validator := factory.getLogOptValidator(name)
 	if validator != nil {
 		return validator(cfg)
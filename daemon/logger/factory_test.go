@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateLogOptsRegisteredOpts(t *testing.T) {
+	const name = "test-validate-opts"
+	if err := RegisterLogDriver(name, func(Context) (Logger, error) { return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterLogOpts(name, "tag", "address"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterLogOpts(name, "tag"); err == nil {
+		t.Fatal("expected an error registering the log options twice")
+	}
+
+	if opts := GetLogOpts(name); !reflect.DeepEqual(opts, []string{"address", "tag"}) {
+		t.Fatalf("expected sorted log options, got %v", opts)
+	}
+
+	found := false
+	for _, driver := range ListLogDrivers() {
+		if driver == name {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected %s in the list of log drivers, got %v", name, ListLogDrivers())
+	}
+
+	if err := ValidateLogOpts(name, map[string]string{"tag": "foo", "address": "bar"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateLogOpts(name, map[string]string{"unknown": "foo"}); err == nil {
+		t.Fatal("expected an error for an unknown log option")
+	}
+	if err := ValidateLogOpts(name, map[string]string{"tag": "foo", CacheEnabledKey: "true"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateLogOpts(name, map[string]string{CacheEnabledKey: "maybe"}); err == nil {
+		t.Fatal("expected an error for an invalid cache-enabled value")
+	}
+	if err := ValidateLogOpts("test-unregistered", nil); err == nil {
+		t.Fatal("expected an error for an unregistered log driver")
+	}
+}
@@ -0,0 +1,146 @@
+package fluentd
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// asyncMessage is a message waiting in the queue of an async fluentd logger.
+type asyncMessage struct {
+	timestamp time.Time
+	data      map[string]string
+	size      int
+}
+
+// asyncQueue decouples the container output from the fluentd collector.
+// Messages are queued by push, which never blocks, and sent in the
+// background. When the queue is full, new messages are dropped and counted.
+type asyncQueue struct {
+	f             *fluentd
+	limit         int
+	flushInterval time.Duration
+
+	mu       sync.Mutex
+	messages []asyncMessage
+	size     int
+	dropped  uint64
+
+	notify  chan struct{}
+	closing chan struct{}
+	done    chan struct{}
+}
+
+func newAsyncQueue(f *fluentd, limit int, flushInterval time.Duration) *asyncQueue {
+	q := &asyncQueue{
+		f:             f,
+		limit:         limit,
+		flushInterval: flushInterval,
+		notify:        make(chan struct{}, 1),
+		closing:       make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// push queues a message, or drops it if the queue is full.
+func (q *asyncQueue) push(timestamp time.Time, data map[string]string) {
+	size := 0
+	for k, v := range data {
+		size += len(k) + len(v)
+	}
+
+	q.mu.Lock()
+	if q.size+size > q.limit {
+		q.mu.Unlock()
+		// the count is reported by the inspect of the container, the
+		// daemon logs are only a reminder
+		if i := atomic.AddUint64(&q.dropped, 1); i%1000 == 1 {
+			logrus.Errorf("fluentd driver has dropped %v logs of container %s", i, q.f.containerID)
+		}
+		return
+	}
+	q.messages = append(q.messages, asyncMessage{timestamp: timestamp, data: data, size: size})
+	q.size += size
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// droppedCount returns the number of messages dropped so far.
+func (q *asyncQueue) droppedCount() uint64 {
+	return atomic.LoadUint64(&q.dropped)
+}
+
+// run sends the queued messages when new messages are queued, and retries
+// every flush interval when the collector could not take them.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.notify:
+		case <-ticker.C:
+		case <-q.closing:
+			q.flush()
+			return
+		}
+		q.flush()
+	}
+}
+
+// flush sends the queued messages in order, until the queue is empty or the
+// client buffer of the collector is full.
+func (q *asyncQueue) flush() {
+	for {
+		q.mu.Lock()
+		if len(q.messages) == 0 {
+			q.mu.Unlock()
+			return
+		}
+		msg := q.messages[0]
+		q.mu.Unlock()
+
+		err := q.f.writer.PostWithTime(q.f.tag, msg.timestamp, msg.data)
+		if err != nil && isBufferFull(err) {
+			// keep the message and retry on the next tick
+			return
+		}
+		if err != nil {
+			// the message is buffered by the client and is sent when the
+			// connection to the collector is restored
+			logrus.Debugf("fluentd: error sending logs of container %s: %v", q.f.containerID, err)
+		}
+
+		q.mu.Lock()
+		q.messages = q.messages[1:]
+		q.size -= msg.size
+		q.mu.Unlock()
+	}
+}
+
+// close stops the background sender after a last attempt to send the
+// queued messages.
+func (q *asyncQueue) close() {
+	close(q.closing)
+	<-q.done
+	if dropped := q.droppedCount(); dropped > 0 {
+		logrus.Warnf("fluentd driver has dropped %v logs of container %s in total", dropped, q.f.containerID)
+	}
+}
+
+// isBufferFull returns whether err was returned by the fluent client
+// because its buffer is full, in which case the message was not buffered.
+func isBufferFull(err error) bool {
+	return strings.Contains(err.Error(), "Buffer full")
+}
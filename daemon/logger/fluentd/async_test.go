@@ -0,0 +1,90 @@
+package fluentd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeWriter struct {
+	mu       sync.Mutex
+	full     bool
+	received []string
+}
+
+func (w *fakeWriter) PostWithTime(tag string, tm time.Time, message interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.full {
+		return errors.New("fluent#appendBuffer: Buffer full, limit 10")
+	}
+	w.received = append(w.received, message.(map[string]string)["log"])
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func (w *fakeWriter) setFull(full bool) {
+	w.mu.Lock()
+	w.full = full
+	w.mu.Unlock()
+}
+
+func (w *fakeWriter) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.received)
+}
+
+func TestAsyncQueueDropsWhenFull(t *testing.T) {
+	w := &fakeWriter{full: true}
+	f := &fluentd{containerID: "test", writer: w}
+	// room for two messages of 4 bytes
+	q := newAsyncQueue(f, 8, 10*time.Millisecond)
+	f.queue = q
+
+	for _, line := range []string{"a", "b", "c"} {
+		q.push(time.Now(), map[string]string{"log": line})
+	}
+	if dropped := f.Dropped(); dropped != 1 {
+		t.Fatalf("expected 1 dropped message, got %d", dropped)
+	}
+
+	// the queued messages are sent once the collector accepts them
+	w.setFull(false)
+	for i := 0; i < 100 && w.count() < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	q.close()
+
+	if len(w.received) != 2 || w.received[0] != "a" || w.received[1] != "b" {
+		t.Fatalf("expected messages a and b to be sent in order, got %v", w.received)
+	}
+	if q.size != 0 || len(q.messages) != 0 {
+		t.Fatalf("expected an empty queue, got %d messages of %d bytes", len(q.messages), q.size)
+	}
+}
+
+func TestValidateLogOptAsync(t *testing.T) {
+	valid := map[string]string{
+		asyncKey:         "true",
+		flushIntervalKey: "100ms",
+		bufferLimitKey:   "8m",
+	}
+	if err := ValidateLogOpt(valid); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cfg := range []map[string]string{
+		{asyncKey: "maybe"},
+		{flushIntervalKey: "0s"},
+		{flushIntervalKey: "fast"},
+	} {
+		if err := ValidateLogOpt(cfg); err == nil {
+			t.Fatalf("expected %v to be invalid", cfg)
+		}
+	}
+}
@@ -21,8 +21,16 @@ type fluentd struct {
 	tag           string
 	containerID   string
 	containerName string
-	writer        *fluent.Fluent
+	writer        fluentWriter
 	extra         map[string]string
+	// queue is only set in async mode
+	queue *asyncQueue
+}
+
+// fluentWriter is the part of the fluent client used by the driver.
+type fluentWriter interface {
+	PostWithTime(tag string, tm time.Time, message interface{}) error
+	Close() error
 }
 
 const (
@@ -39,12 +47,15 @@ const (
 	defaultTimeout                = 3 * time.Second
 	defaultMaxRetries             = math.MaxInt32
 	defaultReconnectWaitIncreRate = 1.5
+	defaultFlushInterval          = time.Second
 
-	addressKey      = "fluentd-address"
-	bufferLimitKey  = "fluentd-buffer-limit"
-	retryWaitKey    = "fluentd-retry-wait"
-	maxRetriesKey   = "fluentd-max-retries"
-	asyncConnectKey = "fluentd-async-connect"
+	addressKey       = "fluentd-address"
+	bufferLimitKey   = "fluentd-buffer-limit"
+	retryWaitKey     = "fluentd-retry-wait"
+	maxRetriesKey    = "fluentd-max-retries"
+	asyncConnectKey  = "fluentd-async-connect"
+	asyncKey         = "fluentd-async"
+	flushIntervalKey = "fluentd-flush-interval"
 )
 
 func init() {
@@ -54,11 +65,15 @@ func init() {
 	if err := logger.RegisterLogOptValidator(name, ValidateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(name, "env", "labels", "tag", addressKey, bufferLimitKey, retryWaitKey, maxRetriesKey, asyncConnectKey, asyncKey, flushIntervalKey); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // New creates a fluentd logger using the configuration passed in on
 // the context. The supported context configuration variable is
-// fluentd-address.
+// fluentd-address. With fluentd-async, messages are queued and sent in the
+// background, and dropped when the queue is full.
 func New(ctx logger.Context) (logger.Logger, error) {
 	host, port, err := parseAddress(ctx.Config[addressKey])
 	if err != nil {
@@ -106,6 +121,20 @@ func New(ctx logger.Context) (logger.Logger, error) {
 		}
 	}
 
+	async := false
+	if ctx.Config[asyncKey] != "" {
+		if async, err = strconv.ParseBool(ctx.Config[asyncKey]); err != nil {
+			return nil, err
+		}
+	}
+
+	flushInterval := defaultFlushInterval
+	if ctx.Config[flushIntervalKey] != "" {
+		if flushInterval, err = parseFlushInterval(ctx.Config[flushIntervalKey]); err != nil {
+			return nil, err
+		}
+	}
+
 	fluentConfig := fluent.Config{
 		FluentPort:   port,
 		FluentHost:   host,
@@ -118,17 +147,27 @@ func New(ctx logger.Context) (logger.Logger, error) {
 	logrus.WithField("container", ctx.ContainerID).WithField("config", fluentConfig).
 		Debug("logging driver fluentd configured")
 
+	if async {
+		// the connection is established by the background sender, so that
+		// the container does not wait for an unreachable collector
+		fluentConfig.AsyncConnect = true
+	}
+
 	log, err := fluent.New(fluentConfig)
 	if err != nil {
 		return nil, err
 	}
-	return &fluentd{
+	f := &fluentd{
 		tag:           tag,
 		containerID:   ctx.ContainerID,
 		containerName: ctx.ContainerName,
 		writer:        log,
 		extra:         extra,
-	}, nil
+	}
+	if async {
+		f.queue = newAsyncQueue(f, bufferLimit, flushInterval)
+	}
+	return f, nil
 }
 
 func (f *fluentd) Log(msg *logger.Message) error {
@@ -141,12 +180,19 @@ func (f *fluentd) Log(msg *logger.Message) error {
 	for k, v := range f.extra {
 		data[k] = v
 	}
+	if f.queue != nil {
+		f.queue.push(msg.Timestamp, data)
+		return nil
+	}
 	// fluent-logger-golang buffers logs from failures and disconnections,
 	// and these are transferred again automatically.
 	return f.writer.PostWithTime(f.tag, msg.Timestamp, data)
 }
 
 func (f *fluentd) Close() error {
+	if f.queue != nil {
+		f.queue.close()
+	}
 	return f.writer.Close()
 }
 
@@ -154,22 +200,41 @@ func (f *fluentd) Name() string {
 	return name
 }
 
+// Dropped returns the number of messages dropped because the queue of the
+// async mode was full.
+func (f *fluentd) Dropped() uint64 {
+	if f.queue == nil {
+		return 0
+	}
+	return f.queue.droppedCount()
+}
+
 // ValidateLogOpt looks for fluentd specific log option fluentd-address.
 func ValidateLogOpt(cfg map[string]string) error {
-	for key := range cfg {
+	for key, val := range cfg {
+		var err error
 		switch key {
 		case "env":
 		case "labels":
 		case "tag":
 		case addressKey:
 		case bufferLimitKey:
+			_, err = units.RAMInBytes(val)
 		case retryWaitKey:
+			_, err = time.ParseDuration(val)
 		case maxRetriesKey:
-		case asyncConnectKey:
-			// Accepted
+			_, err = strconv.ParseUint(val, 10, strconv.IntSize)
+		case asyncConnectKey, asyncKey:
+			_, err = strconv.ParseBool(val)
+		case flushIntervalKey:
+			_, err = parseFlushInterval(val)
 		default:
 			return fmt.Errorf("unknown log opt '%s' for fluentd log driver", key)
 		}
+		// empty values are ignored, as in New
+		if err != nil && val != "" {
+			return fmt.Errorf("invalid value %q for log opt '%s' for fluentd log driver", val, key)
+		}
 	}
 
 	if _, _, err := parseAddress(cfg["fluentd-address"]); err != nil {
@@ -179,6 +244,17 @@ func ValidateLogOpt(cfg map[string]string) error {
 	return nil
 }
 
+func parseFlushInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration", flushIntervalKey)
+	}
+	return interval, nil
+}
+
 func parseAddress(address string) (string, int, error) {
 	if address == "" {
 		return defaultHost, defaultPort, nil
@@ -48,6 +48,9 @@ func init() {
 	if err := logger.RegisterLogOptValidator(name, ValidateLogOpts); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(name, projectOptKey, logLabelsKey, logEnvKey, logCmdKey, logZoneKey, logNameKey, logIDKey); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 type gcplogs struct {
@@ -37,6 +37,9 @@ func init() {
 	if err := logger.RegisterLogOptValidator(name, ValidateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(name, "gelf-address", "tag", "labels", "env", "gelf-compression-level", "gelf-compression-type"); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // New creates a gelf logger using the configuration passed in on the
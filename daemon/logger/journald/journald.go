@@ -34,6 +34,9 @@ func init() {
 	if err := logger.RegisterLogOptValidator(name, validateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(name, "labels", "env", "tag"); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // sanitizeKeyMode returns the sanitized string so that it could be used in journald.
@@ -36,6 +36,9 @@ func init() {
 	if err := logger.RegisterLogOptValidator(Name, ValidateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(Name, "max-file", "max-size", "labels", "env"); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // New creates new JSONFileLogger which writes to filename passed in
@@ -115,10 +118,20 @@ func (l *JSONFileLogger) Log(msg *logger.Message) error {
 
 // ValidateLogOpt looks for json specific log options max-file & max-size.
 func ValidateLogOpt(cfg map[string]string) error {
-	for key := range cfg {
+	for key, val := range cfg {
 		switch key {
 		case "max-file":
+			maxFiles, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for log opt 'max-file' for json-file log driver", val)
+			}
+			if maxFiles < 1 {
+				return fmt.Errorf("max-file cannot be less than 1")
+			}
 		case "max-size":
+			if _, err := units.FromHumanSize(val); err != nil {
+				return fmt.Errorf("invalid value %q for log opt 'max-size' for json-file log driver", val)
+			}
 		case "labels":
 		case "env":
 		default:
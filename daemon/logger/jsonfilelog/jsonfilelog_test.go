@@ -246,3 +246,27 @@ func BenchmarkJSONFileLoggerWithReader(b *testing.B) {
 		}
 	}
 }
+
+func TestJSONFileLoggerValidateLogOpt(t *testing.T) {
+	valid := []map[string]string{
+		{"max-size": "10k", "max-file": "3"},
+		{"labels": "rack", "env": "STAGE"},
+	}
+	for _, cfg := range valid {
+		if err := ValidateLogOpt(cfg); err != nil {
+			t.Fatalf("expected %v to be valid, got %v", cfg, err)
+		}
+	}
+
+	invalid := []map[string]string{
+		{"max-size": "ten"},
+		{"max-file": "0"},
+		{"max-file": "three"},
+		{"max-buffer": "1"},
+	}
+	for _, cfg := range invalid {
+		if err := ValidateLogOpt(cfg); err == nil {
+			t.Fatalf("expected %v to be invalid", cfg)
+		}
+	}
+}
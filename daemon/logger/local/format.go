@@ -0,0 +1,166 @@
+package local
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+// The logs are stored as a sequence of entries. Each entry is the encoded
+// message, prefixed and suffixed by its size so that the file can be read
+// forward and backward:
+//
+//	size      uint32, big endian
+//	timestamp int64, big endian, nanoseconds since the epoch
+//	flags     uint8
+//	source    uint8 length, followed by the name of the stream
+//	line      the rest of the message
+//	size      uint32, big endian
+const (
+	sizeLen      = 4
+	headerLen    = 8 + 1 + 1
+	maxEntrySize = 16 * 1024 * 1024
+
+	flagPartial = 1 << 0
+)
+
+var errCorrupt = errors.New("local: corrupt log file")
+
+// encodeEntry appends the entry of msg to buf.
+func encodeEntry(buf []byte, msg *logger.Message) []byte {
+	source := msg.Source
+	if len(source) > 255 {
+		source = source[:255]
+	}
+	size := headerLen + len(source) + len(msg.Line)
+
+	var flags byte
+	if msg.Partial {
+		flags |= flagPartial
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:sizeLen], uint32(size))
+	buf = append(buf, b[:sizeLen]...)
+	binary.BigEndian.PutUint64(b[:], uint64(msg.Timestamp.UnixNano()))
+	buf = append(buf, b[:]...)
+	buf = append(buf, flags, byte(len(source)))
+	buf = append(buf, source...)
+	buf = append(buf, msg.Line...)
+	binary.BigEndian.PutUint32(b[:sizeLen], uint32(size))
+	return append(buf, b[:sizeLen]...)
+}
+
+// decodeMessage decodes the message of an entry, without its sizes.
+func decodeMessage(b []byte) (*logger.Message, error) {
+	if len(b) < headerLen || len(b) < headerLen+int(b[9]) {
+		return nil, errCorrupt
+	}
+	sourceLen := int(b[9])
+	msg := &logger.Message{
+		Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(b[:8]))).UTC(),
+		Partial:   b[8]&flagPartial != 0,
+		Source:    string(b[headerLen : headerLen+sourceLen]),
+	}
+	line := b[headerLen+sourceLen:]
+	if msg.Partial {
+		msg.Line = append([]byte(nil), line...)
+	} else {
+		msg.Line = make([]byte, len(line)+1)
+		copy(msg.Line, line)
+		msg.Line[len(line)] = '\n'
+	}
+	return msg, nil
+}
+
+// readEntry reads the next entry of r. It returns io.EOF if there is no
+// entry left.
+func readEntry(r io.Reader) (*logger.Message, error) {
+	var b [sizeLen]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errCorrupt
+		}
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(b[:])
+	if size > maxEntrySize {
+		return nil, errCorrupt
+	}
+	buf := make([]byte, int(size)+sizeLen)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, errCorrupt
+	}
+	if binary.BigEndian.Uint32(buf[size:]) != size {
+		return nil, errCorrupt
+	}
+	return decodeMessage(buf[:size])
+}
+
+// readEntryAt reads the entry at offset off of r and returns it with its
+// encoded size. It returns io.EOF if the entry is missing or incomplete,
+// which happens when it is being written.
+func readEntryAt(r io.ReaderAt, off int64) (*logger.Message, int64, error) {
+	var b [sizeLen]byte
+	if _, err := r.ReadAt(b[:], off); err != nil {
+		return nil, 0, eofOr(err)
+	}
+	size := binary.BigEndian.Uint32(b[:])
+	if size > maxEntrySize {
+		return nil, 0, errCorrupt
+	}
+	buf := make([]byte, int(size)+sizeLen)
+	if _, err := r.ReadAt(buf, off+sizeLen); err != nil {
+		return nil, 0, eofOr(err)
+	}
+	if binary.BigEndian.Uint32(buf[size:]) != size {
+		return nil, 0, errCorrupt
+	}
+	msg, err := decodeMessage(buf[:size])
+	if err != nil {
+		return nil, 0, err
+	}
+	return msg, int64(size) + 2*sizeLen, nil
+}
+
+// readLastEntries returns at most n of the last entries of the first size
+// bytes of r, in order.
+func readLastEntries(r io.ReaderAt, size int64, n int) ([]*logger.Message, error) {
+	var msgs []*logger.Message
+	for off := size; off > 0 && len(msgs) < n; {
+		if off < 2*sizeLen+headerLen {
+			return nil, errCorrupt
+		}
+		var b [sizeLen]byte
+		if _, err := r.ReadAt(b[:], off-sizeLen); err != nil {
+			return nil, err
+		}
+		start := off - 2*sizeLen - int64(binary.BigEndian.Uint32(b[:]))
+		if start < 0 {
+			return nil, errCorrupt
+		}
+		msg, entrySize, err := readEntryAt(r, start)
+		if err == io.EOF || (err == nil && start+entrySize != off) {
+			return nil, errCorrupt
+		}
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+		off = start
+	}
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+	return msgs, nil
+}
+
+func eofOr(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return io.EOF
+	}
+	return err
+}
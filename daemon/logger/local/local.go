@@ -0,0 +1,203 @@
+// Package local provides a Logger implementation that logs to files on the
+// host server in a compact binary format. It is optimized for containers
+// that write a lot of logs, and supports rotation and compression of the
+// rotated files.
+package local
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/go-units"
+)
+
+// Name is the name of the local logging driver.
+const Name = "local"
+
+const (
+	maxSizeKey  = "max-size"
+	maxFileKey  = "max-file"
+	compressKey = "compress"
+
+	defaultMaxSize  = 20 * 1024 * 1024
+	defaultMaxFile  = 5
+	defaultCompress = true
+)
+
+var errClosed = errors.New("local: logger is closed")
+
+func init() {
+	if err := logger.RegisterLogDriver(Name, New); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, ValidateLogOpt); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOpts(Name, maxSizeKey, maxFileKey, compressKey); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// config is the configuration of the rotation of the log files.
+type config struct {
+	maxSize  int64
+	maxFile  int
+	compress bool
+}
+
+// driver is the local logging driver. All the fields are protected by mu.
+type driver struct {
+	mu     sync.Mutex
+	buf    []byte
+	file   *logFile
+	closed bool
+
+	// wakeup is closed and replaced when a message is written while a
+	// reader is waiting for new messages, and closed when the driver is
+	// closed.
+	wakeup  chan struct{}
+	waiting bool
+
+	followers map[*follower]struct{}
+}
+
+// follower is a reader following the logs. As the files it follows may be
+// rotated and removed before it reads them, next holds the files that
+// replaced the one it reads, opened when they were created.
+type follower struct {
+	next []*os.File
+}
+
+// New creates a local logger that logs to the file passed in on the given
+// context.
+func New(ctx logger.Context) (logger.Logger, error) {
+	if ctx.LogPath == "" {
+		return nil, errors.New("local: log path is missing")
+	}
+	cfg, err := parseConfig(ctx.Config)
+	if err != nil {
+		return nil, err
+	}
+	file, err := openLogFile(ctx.LogPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &driver{
+		file:      file,
+		wakeup:    make(chan struct{}),
+		followers: make(map[*follower]struct{}),
+	}, nil
+}
+
+// ValidateLogOpt checks the options of the local logging driver.
+func ValidateLogOpt(cfg map[string]string) error {
+	_, err := parseConfig(cfg)
+	return err
+}
+
+func parseConfig(cfg map[string]string) (config, error) {
+	c := config{
+		maxSize:  defaultMaxSize,
+		maxFile:  defaultMaxFile,
+		compress: defaultCompress,
+	}
+	if v, ok := cfg[maxSizeKey]; ok {
+		size, err := units.FromHumanSize(v)
+		if err != nil || size <= 0 {
+			return c, fmt.Errorf("invalid value %q for log opt '%s' for %s log driver", v, maxSizeKey, Name)
+		}
+		c.maxSize = size
+	}
+	if v, ok := cfg[maxFileKey]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, fmt.Errorf("invalid value %q for log opt '%s' for %s log driver", v, maxFileKey, Name)
+		}
+		c.maxFile = n
+	}
+	if v, ok := cfg[compressKey]; ok {
+		compress, err := strconv.ParseBool(v)
+		if err != nil {
+			return c, fmt.Errorf("invalid value %q for log opt '%s' for %s log driver", v, compressKey, Name)
+		}
+		c.compress = compress
+	}
+	return c, nil
+}
+
+// Log encodes the message and writes it to the log file.
+func (d *driver) Log(msg *logger.Message) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return errClosed
+	}
+	d.buf = encodeEntry(d.buf[:0], msg)
+	rotated, err := d.file.write(d.buf)
+	if rotated {
+		d.openFollowed()
+	}
+	if err != nil {
+		return err
+	}
+	if d.waiting {
+		close(d.wakeup)
+		d.wakeup = make(chan struct{})
+		d.waiting = false
+	}
+	return nil
+}
+
+// openFollowed opens the new current file for the followers. A follower
+// that is too slow loses the oldest files. The caller must hold d.mu.
+func (d *driver) openFollowed() {
+	for fl := range d.followers {
+		f, err := os.Open(d.file.path)
+		if err != nil {
+			logrus.Errorf("Error opening log file %s for reading: %v", d.file.path, err)
+			continue
+		}
+		fl.next = append(fl.next, f)
+		if len(fl.next) > d.file.cfg.maxFile {
+			fl.next[0].Close()
+			fl.next = fl.next[1:]
+		}
+	}
+}
+
+// waitWrite returns a channel that is closed when the next message is
+// written or the driver is closed. The caller must hold d.mu.
+func (d *driver) waitWrite() <-chan struct{} {
+	d.waiting = true
+	return d.wakeup
+}
+
+// LogPath returns the location the logger logs to.
+func (d *driver) LogPath() string {
+	return d.file.path
+}
+
+// Name returns the name of the logger.
+func (d *driver) Name() string {
+	return Name
+}
+
+// Close closes the log file and signals the readers following the logs to
+// stop after the last message.
+func (d *driver) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	close(d.wakeup)
+	return d.file.close()
+}
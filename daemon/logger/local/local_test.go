@@ -0,0 +1,208 @@
+package local
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+func newTestDriver(t *testing.T, cfg map[string]string) (*driver, string) {
+	dir, err := ioutil.TempDir("", "local-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := New(logger.Context{
+		ContainerID: "container",
+		LogPath:     filepath.Join(dir, "container.log"),
+		Config:      cfg,
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return l.(*driver), dir
+}
+
+func logLines(t *testing.T, l logger.Logger, from, to int) {
+	for i := from; i < to; i++ {
+		msg := &logger.Message{
+			Source:    "stdout",
+			Timestamp: time.Unix(int64(i), 0).UTC(),
+			Line:      []byte(fmt.Sprintf("line %d", i)),
+		}
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readLines(t *testing.T, l *driver, config logger.ReadConfig) []string {
+	watcher := l.ReadLogs(config)
+	defer watcher.Close()
+
+	var lines []string
+	for {
+		select {
+		case msg, ok := <-watcher.Msg:
+			if !ok {
+				return lines
+			}
+			lines = append(lines, string(msg.Line))
+		case err := <-watcher.Err:
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout reading logs")
+		}
+	}
+}
+
+func expectLines(t *testing.T, lines []string, from, to int) {
+	if len(lines) != to-from {
+		t.Fatalf("expected %d lines, got %d: %v", to-from, len(lines), lines)
+	}
+	for i, line := range lines {
+		if expected := fmt.Sprintf("line %d\n", from+i); line != expected {
+			t.Fatalf("expected %q, got %q", expected, line)
+		}
+	}
+}
+
+func TestEncodeDecodeEntry(t *testing.T) {
+	msg := &logger.Message{
+		Source:    "stderr",
+		Timestamp: time.Unix(1, 2).UTC(),
+		Line:      []byte("partial"),
+		Partial:   true,
+	}
+	buf := encodeEntry(nil, msg)
+	decoded, err := readEntry(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Source != msg.Source || !decoded.Timestamp.Equal(msg.Timestamp) || !decoded.Partial || string(decoded.Line) != "partial" {
+		t.Fatalf("expected %+v, got %+v", msg, decoded)
+	}
+
+	decoded, n, err := readEntryAt(bytes.NewReader(buf), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(buf)) || string(decoded.Line) != "partial" {
+		t.Fatalf("unexpected entry %+v of size %d", decoded, n)
+	}
+	if _, _, err := readEntryAt(bytes.NewReader(buf[:len(buf)-1]), 0); err != io.EOF {
+		t.Fatalf("expected io.EOF for an incomplete entry, got %v", err)
+	}
+}
+
+func TestReadLogs(t *testing.T) {
+	l, dir := newTestDriver(t, nil)
+	defer os.RemoveAll(dir)
+	defer l.Close()
+
+	logLines(t, l, 0, 10)
+
+	expectLines(t, readLines(t, l, logger.ReadConfig{Tail: -1}), 0, 10)
+	expectLines(t, readLines(t, l, logger.ReadConfig{Tail: 3}), 7, 10)
+	expectLines(t, readLines(t, l, logger.ReadConfig{Tail: -1, Since: time.Unix(5, 0)}), 5, 10)
+	if lines := readLines(t, l, logger.ReadConfig{}); len(lines) != 0 {
+		t.Fatalf("expected no lines, got %v", lines)
+	}
+}
+
+func TestReadLogsRotated(t *testing.T) {
+	l, dir := newTestDriver(t, map[string]string{
+		maxSizeKey: "100",
+		maxFileKey: "3",
+	})
+	defer os.RemoveAll(dir)
+	defer l.Close()
+
+	// each entry is 32 bytes, so each file holds 3 entries
+	logLines(t, l, 0, 10)
+	l.mu.Lock()
+	l.file.compressing.Wait()
+	l.mu.Unlock()
+
+	for _, name := range []string{"container.log", "container.log.1.gz", "container.log.2.gz"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "container.log.3.gz")); !os.IsNotExist(err) {
+		t.Fatalf("expected at most 3 files, got %v", err)
+	}
+
+	expectLines(t, readLines(t, l, logger.ReadConfig{Tail: -1}), 3, 10)
+	expectLines(t, readLines(t, l, logger.ReadConfig{Tail: 5}), 5, 10)
+	expectLines(t, readLines(t, l, logger.ReadConfig{Tail: 100}), 3, 10)
+}
+
+func TestFollowLogs(t *testing.T) {
+	l, dir := newTestDriver(t, map[string]string{
+		maxSizeKey:  "100",
+		compressKey: "false",
+	})
+	defer os.RemoveAll(dir)
+
+	logLines(t, l, 0, 2)
+	watcher := l.ReadLogs(logger.ReadConfig{Tail: 1, Follow: true})
+	defer watcher.Close()
+
+	first := make(chan struct{})
+	done := make(chan []string)
+	go func() {
+		var lines []string
+		for msg := range watcher.Msg {
+			if lines = append(lines, string(msg.Line)); len(lines) == 1 {
+				close(first)
+			}
+		}
+		done <- lines
+	}()
+
+	select {
+	case <-first:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout reading the tail of the logs")
+	}
+
+	// the followed file is rotated several times
+	logLines(t, l, 2, 12)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case lines := <-done:
+		expectLines(t, lines, 1, 12)
+	case err := <-watcher.Err:
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout following logs")
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	valid := map[string]string{maxSizeKey: "10m", maxFileKey: "2", compressKey: "false"}
+	if err := ValidateLogOpt(valid); err != nil {
+		t.Fatal(err)
+	}
+	for _, cfg := range []map[string]string{
+		{maxSizeKey: "big"},
+		{maxSizeKey: "0"},
+		{maxFileKey: "0"},
+		{compressKey: "maybe"},
+	} {
+		if err := ValidateLogOpt(cfg); err == nil {
+			t.Fatalf("expected an error for %v", cfg)
+		}
+	}
+}
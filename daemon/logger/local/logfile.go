@@ -0,0 +1,194 @@
+package local
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+)
+
+const compressedExt = ".gz"
+
+// logFile writes the entries to the current log file and rotates it when
+// it reaches the maximum size. Rotated files are named after the current
+// file with a ".1" to ".N" suffix, ".1" being the most recent, and are
+// compressed in the background when compression is enabled. It is not
+// safe for concurrent use.
+type logFile struct {
+	path        string
+	cfg         config
+	f           *os.File
+	size        int64
+	compressing sync.WaitGroup
+}
+
+func openLogFile(path string, cfg config) (*logFile, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, err
+	}
+	size, err := f.Seek(0, os.SEEK_END)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &logFile{
+		path: path,
+		cfg:  cfg,
+		f:    f,
+		size: size,
+	}, nil
+}
+
+// write writes b to the current file, and returns whether the current file
+// was rotated before.
+func (lf *logFile) write(b []byte) (bool, error) {
+	rotated := false
+	if lf.size > 0 && lf.size+int64(len(b)) > lf.cfg.maxSize {
+		if err := lf.rotate(); err != nil {
+			return false, err
+		}
+		rotated = true
+	}
+	n, err := lf.f.Write(b)
+	lf.size += int64(n)
+	return rotated, err
+}
+
+// rotate replaces the current file by a new one. The current file is
+// removed rather than truncated, so that readers that have it open can
+// still read it to the end.
+func (lf *logFile) rotate() error {
+	if err := lf.f.Close(); err != nil {
+		return err
+	}
+
+	if lf.cfg.maxFile > 1 {
+		// the previous rotated file must be compressed before it is renamed
+		lf.compressing.Wait()
+		for i := lf.cfg.maxFile - 1; i > 1; i-- {
+			for _, ext := range []string{"", compressedExt} {
+				if err := renameIfExists(rotatedPath(lf.path, i-1)+ext, rotatedPath(lf.path, i)+ext); err != nil {
+					return err
+				}
+			}
+		}
+		rotated := rotatedPath(lf.path, 1)
+		if err := renameIfExists(lf.path, rotated); err != nil {
+			return err
+		}
+		if lf.cfg.compress {
+			lf.compressing.Add(1)
+			go func() {
+				defer lf.compressing.Done()
+				if err := compressFile(rotated); err != nil {
+					logrus.Errorf("Error compressing log file %s: %v", rotated, err)
+				}
+			}()
+		}
+	} else if err := os.Remove(lf.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f, err := os.OpenFile(lf.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	lf.f = f
+	lf.size = 0
+	return nil
+}
+
+func (lf *logFile) close() error {
+	err := lf.f.Close()
+	lf.compressing.Wait()
+	return err
+}
+
+func rotatedPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+// renameIfExists renames from to to, replacing both the compressed and
+// uncompressed versions of to.
+func renameIfExists(from, to string) error {
+	if _, err := os.Stat(from); os.IsNotExist(err) {
+		return nil
+	}
+	for _, p := range []string{to, to + compressedExt} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(from, to)
+}
+
+// compressFile replaces the file at path by a gzip-compressed file with
+// the same name and the ".gz" extension.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := path + compressedExt + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	w := gzip.NewWriter(dst)
+	if _, err := io.Copy(w, src); err != nil {
+		w.Close()
+		dst.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := w.Close(); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path+compressedExt); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// openRotated opens the rotated file i of the log file at path, which may
+// be compressed. It returns an error satisfying os.IsNotExist if there is
+// no such file.
+func openRotated(path string, i int) (io.ReadCloser, error) {
+	p := rotatedPath(path, i)
+	f, err := os.Open(p + compressedExt)
+	if err == nil {
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("local: error opening %s: %v", p+compressedExt, err)
+		}
+		return &gzipFile{Reader: r, f: f}, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.f.Close()
+}
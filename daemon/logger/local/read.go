@@ -0,0 +1,219 @@
+package local
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+// ReadLogs implements the logger's LogReader interface for the logs
+// created by this driver.
+func (d *driver) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	watcher := logger.NewLogWatcher()
+	go d.readLogs(watcher, config)
+	return watcher
+}
+
+func (d *driver) readLogs(watcher *logger.LogWatcher, config logger.ReadConfig) {
+	defer close(watcher.Msg)
+
+	// Lock so that the files are not rotated while they are opened. The
+	// size of the current file bounds what is read before following it.
+	d.mu.Lock()
+	current, err := os.Open(d.file.path)
+	if err != nil {
+		d.mu.Unlock()
+		watcher.Err <- err
+		return
+	}
+	size := d.file.size
+
+	// rotated files, the oldest first
+	var rotated []io.ReadCloser
+	if config.Tail != 0 {
+		for i := d.file.cfg.maxFile - 1; i > 0; i-- {
+			r, err := openRotated(d.file.path, i)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				d.mu.Unlock()
+				closeAll(rotated)
+				current.Close()
+				watcher.Err <- err
+				return
+			}
+			rotated = append(rotated, r)
+		}
+	}
+	var fl *follower
+	if config.Follow {
+		fl = &follower{}
+		d.followers[fl] = struct{}{}
+	}
+	d.mu.Unlock()
+
+	send := func(msg *logger.Message) bool {
+		if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+			return true
+		}
+		select {
+		case watcher.Msg <- msg:
+			return true
+		case <-watcher.WatchClose():
+			return false
+		}
+	}
+
+	err = sendTail(rotated, current, size, config.Tail, send)
+	closeAll(rotated)
+	if err == nil && config.Follow {
+		current, err = d.follow(watcher, fl, current, size, send)
+	}
+	current.Close()
+	if fl != nil {
+		d.mu.Lock()
+		delete(d.followers, fl)
+		for _, f := range fl.next {
+			f.Close()
+		}
+		d.mu.Unlock()
+	}
+	if err != nil && err != errStopped {
+		watcher.Err <- err
+	}
+}
+
+// errStopped is returned when the reader is closed while sending the logs.
+var errStopped = errors.New("local: reader stopped")
+
+// sendTail sends the last tail messages of the rotated files and the first
+// size bytes of the current file, or all of them if tail is negative.
+func sendTail(rotated []io.ReadCloser, current io.ReaderAt, size int64, tail int, send func(*logger.Message) bool) error {
+	if tail == 0 {
+		return nil
+	}
+
+	if tail < 0 {
+		readers := make([]io.Reader, 0, len(rotated)+1)
+		for _, r := range rotated {
+			readers = append(readers, r)
+		}
+		readers = append(readers, io.NewSectionReader(current, 0, size))
+		for _, r := range readers {
+			br := bufio.NewReader(r)
+			for {
+				msg, err := readEntry(br)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				if !send(msg) {
+					return errStopped
+				}
+			}
+		}
+		return nil
+	}
+
+	msgs, err := readLastEntries(current, size, tail)
+	if err != nil {
+		return err
+	}
+	for i := len(rotated) - 1; i >= 0 && len(msgs) < tail; i-- {
+		older, err := readLastEntriesFrom(rotated[i], tail-len(msgs))
+		if err != nil {
+			return err
+		}
+		msgs = append(older, msgs...)
+	}
+	for _, msg := range msgs {
+		if !send(msg) {
+			return errStopped
+		}
+	}
+	return nil
+}
+
+// readLastEntriesFrom returns at most n of the last entries of r, which
+// can only be read forward.
+func readLastEntriesFrom(r io.Reader, n int) ([]*logger.Message, error) {
+	var msgs []*logger.Message
+	br := bufio.NewReader(r)
+	for {
+		msg, err := readEntry(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+		if len(msgs) >= 2*n {
+			msgs = append(msgs[:0], msgs[len(msgs)-n:]...)
+		}
+	}
+	if len(msgs) > n {
+		msgs = msgs[len(msgs)-n:]
+	}
+	return msgs, nil
+}
+
+// follow sends the messages written to the current file f from offset
+// off, moving to the files that replace it when it is rotated, until the
+// watcher or the driver is closed. It returns the file it was reading.
+func (d *driver) follow(watcher *logger.LogWatcher, fl *follower, f *os.File, off int64, send func(*logger.Message) bool) (*os.File, error) {
+	drained := false
+	for {
+		msg, n, err := readEntryAt(f, off)
+		if err == nil {
+			off += n
+			if !send(msg) {
+				return f, errStopped
+			}
+			continue
+		}
+		if err != io.EOF {
+			return f, err
+		}
+
+		d.mu.Lock()
+		if len(fl.next) > 0 {
+			if !drained {
+				// The file was replaced and no more messages are written
+				// to it: read it to the end before moving to the next one.
+				d.mu.Unlock()
+				drained = true
+				continue
+			}
+			f.Close()
+			f, off, drained = fl.next[0], 0, false
+			fl.next = fl.next[1:]
+			d.mu.Unlock()
+			continue
+		}
+		if d.closed {
+			d.mu.Unlock()
+			return f, nil
+		}
+		wait := d.waitWrite()
+		d.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-watcher.WatchClose():
+			return f, errStopped
+		}
+	}
+}
+
+func closeAll(readers []io.ReadCloser) {
+	for _, r := range readers {
+		r.Close()
+	}
+}
@@ -86,6 +86,14 @@ type Logger interface {
 	Close() error
 }
 
+// DropCounter is the interface of the loggers which drop messages rather
+// than block the container, to report how many they dropped.
+type DropCounter interface {
+	// Dropped returns the number of messages dropped since the logger
+	// was started.
+	Dropped() uint64
+}
+
 // ReadConfig is the configuration passed into ReadLogs.
 type ReadConfig struct {
 	Since  time.Time
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// CacheEnabledKey is the log option that enables or disables the local
+// cache kept by the daemon for the logging drivers that cannot read logs
+// back.
+const CacheEnabledKey = "cache-enabled"
+
+// CacheEnabled returns whether the logs written with the given options must
+// be kept in a local cache when the logging driver cannot read them back.
+// The cache is disabled unless the options enable it.
+func CacheEnabled(cfg map[string]string) bool {
+	enabled, _ := strconv.ParseBool(cfg[CacheEnabledKey])
+	return enabled
+}
+
+func validateCacheOpts(cfg map[string]string) error {
+	if v, ok := cfg[CacheEnabledKey]; ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("invalid value for log opt '%s': %s", CacheEnabledKey, v)
+		}
+	}
+	return nil
+}
+
+// ReadCacheLogger is a logger that also supports reading logs.
+type ReadCacheLogger interface {
+	Logger
+	LogReader
+}
+
+// readableLogger sends the logs to a logging driver that cannot read logs
+// and keeps a copy of them in a cache that is used to read them back.
+type readableLogger struct {
+	Logger
+	cache ReadCacheLogger
+}
+
+// NewReadableLogger returns a logger that sends logs to l and to cache, and
+// reads them from cache. Errors writing to the cache are logged but do not
+// fail the message.
+func NewReadableLogger(l Logger, cache ReadCacheLogger) Logger {
+	return &readableLogger{Logger: l, cache: cache}
+}
+
+func (l *readableLogger) Log(msg *Message) error {
+	if err := l.cache.Log(msg); err != nil {
+		logrus.Errorf("Failed to write msg to the log cache of logger %s: %v", l.Name(), err)
+	}
+	return l.Logger.Log(msg)
+}
+
+func (l *readableLogger) ReadLogs(config ReadConfig) *LogWatcher {
+	return l.cache.ReadLogs(config)
+}
+
+// Dropped returns the number of messages dropped by the logging driver, if
+// it drops messages.
+func (l *readableLogger) Dropped() uint64 {
+	if c, ok := l.Logger.(DropCounter); ok {
+		return c.Dropped()
+	}
+	return 0
+}
+
+func (l *readableLogger) Close() error {
+	err := l.Logger.Close()
+	if cacheErr := l.cache.Close(); cacheErr != nil {
+		logrus.Errorf("Failed to close the log cache of logger %s: %v", l.Name(), cacheErr)
+	}
+	return err
+}
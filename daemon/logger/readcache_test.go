@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"testing"
+)
+
+type recordingLogger struct {
+	lines  []string
+	closed bool
+}
+
+func (l *recordingLogger) Log(msg *Message) error {
+	l.lines = append(l.lines, string(msg.Line))
+	return nil
+}
+
+func (l *recordingLogger) Name() string { return "recording" }
+
+func (l *recordingLogger) Close() error {
+	l.closed = true
+	return nil
+}
+
+type recordingReader struct {
+	recordingLogger
+	read *ReadConfig
+}
+
+func (l *recordingReader) ReadLogs(config ReadConfig) *LogWatcher {
+	l.read = &config
+	return NewLogWatcher()
+}
+
+func TestReadableLogger(t *testing.T) {
+	driver := &recordingLogger{}
+	cache := &recordingReader{}
+	l := NewReadableLogger(driver, cache)
+
+	if err := l.Log(&Message{Line: []byte("hello")}); err != nil {
+		t.Fatal(err)
+	}
+	if len(driver.lines) != 1 || len(cache.lines) != 1 {
+		t.Fatalf("expected the message to be sent to the driver and the cache, got %v and %v", driver.lines, cache.lines)
+	}
+	if l.Name() != "recording" {
+		t.Fatalf("expected the name of the driver, got %s", l.Name())
+	}
+
+	reader, ok := l.(LogReader)
+	if !ok {
+		t.Fatal("expected the logger to support reading logs")
+	}
+	reader.ReadLogs(ReadConfig{Tail: 10})
+	if cache.read == nil || cache.read.Tail != 10 {
+		t.Fatalf("expected the logs to be read from the cache, got %v", cache.read)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !driver.closed || !cache.closed {
+		t.Fatal("expected the driver and the cache to be closed")
+	}
+}
+
+func TestCacheEnabled(t *testing.T) {
+	if CacheEnabled(nil) {
+		t.Fatal("expected the cache to be disabled by default")
+	}
+	if !CacheEnabled(map[string]string{CacheEnabledKey: "true"}) {
+		t.Fatal("expected the cache to be enabled")
+	}
+	if CacheEnabled(map[string]string{CacheEnabledKey: "false"}) {
+		t.Fatal("expected the cache to be disabled")
+	}
+}
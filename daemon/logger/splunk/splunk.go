@@ -137,6 +137,12 @@ func init() {
 	if err := logger.RegisterLogOptValidator(driverName, ValidateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(driverName, splunkURLKey, splunkTokenKey, splunkSourceKey, splunkSourceTypeKey,
+		splunkIndexKey, splunkCAPathKey, splunkCANameKey, splunkInsecureSkipVerifyKey,
+		splunkFormatKey, splunkVerifyConnectionKey, splunkGzipCompressionKey,
+		splunkGzipCompressionLevelKey, envKey, labelsKey, tagKey); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // New creates splunk logger driver using configuration passed in context
@@ -60,6 +60,9 @@ func init() {
 	if err := logger.RegisterLogOptValidator(name, ValidateLogOpt); err != nil {
 		logrus.Fatal(err)
 	}
+	if err := logger.RegisterLogOpts(name, "env", "labels", "syslog-address", "syslog-facility", "syslog-tls-ca-cert", "syslog-tls-cert", "syslog-tls-key", "syslog-tls-skip-verify", "tag", "syslog-format"); err != nil {
+		logrus.Fatal(err)
+	}
 }
 
 // rsyslog uses appname part of syslog message to fill in an %syslogtag% template
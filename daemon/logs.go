@@ -14,7 +14,6 @@ import (
 	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/logger"
-	"github.com/docker/docker/daemon/logger/jsonfilelog"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/stdcopy"
 )
@@ -30,6 +29,9 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 	if !(config.ShowStdout || config.ShowStderr) {
 		return fmt.Errorf("You must choose at least one stream")
 	}
+	if container.HostConfig.LogConfig.Type == "none" {
+		return logger.ErrReadLogsNotSupported
+	}
 
 	cLog, err := daemon.getLogger(container)
 	if err != nil {
@@ -118,7 +120,36 @@ func (daemon *Daemon) getLogger(container *container.Container) (logger.Logger,
 	if container.LogDriver != nil && container.IsRunning() {
 		return container.LogDriver, nil
 	}
-	return container.StartLogger(container.HostConfig.LogConfig)
+	// The logs of a driver that cannot read them back are read from the
+	// local cache, which does not need the driver to be started.
+	cache, err := container.OpenLogCache()
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		return cache, nil
+	}
+	return container.StartLogger(daemon.loggerConfig(container))
+}
+
+// loggerConfig returns the log configuration the logger of the container is
+// started with: the local log cache is enabled when the daemon enables it by
+// default and the container does not set the cache-enabled option.
+func (daemon *Daemon) loggerConfig(container *container.Container) containertypes.LogConfig {
+	cfg := container.HostConfig.LogConfig
+	if daemon.configStore == nil || !daemon.configStore.LogCache {
+		return cfg
+	}
+	if _, ok := cfg.Config[logger.CacheEnabledKey]; ok {
+		return cfg
+	}
+	config := make(map[string]string, len(cfg.Config)+1)
+	for k, v := range cfg.Config {
+		config[k] = v
+	}
+	config[logger.CacheEnabledKey] = "true"
+	cfg.Config = config
+	return cfg
 }
 
 // StartLogging initializes and starts the container logging stream.
@@ -127,7 +158,7 @@ func (daemon *Daemon) StartLogging(container *container.Container) error {
 		return nil // do not start logging routines
 	}
 
-	l, err := container.StartLogger(container.HostConfig.LogConfig)
+	l, err := container.StartLogger(daemon.loggerConfig(container))
 	if err != nil {
 		return fmt.Errorf("Failed to initialize logging driver: %v", err)
 	}
@@ -137,9 +168,11 @@ func (daemon *Daemon) StartLogging(container *container.Container) error {
 	copier.Run()
 	container.LogDriver = l
 
-	// set LogPath field only for json-file logdriver
-	if jl, ok := l.(*jsonfilelog.JSONFileLogger); ok {
-		container.LogPath = jl.LogPath()
+	// set LogPath field only for the json-file and local logdrivers
+	if fl, ok := l.(interface {
+		LogPath() string
+	}); ok {
+		container.LogPath = fl.LogPath()
 	}
 
 	return nil
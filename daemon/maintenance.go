@@ -0,0 +1,197 @@
+package daemon
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// maintenanceState is the maintenance mode of the daemon.
+type maintenanceState struct {
+	sync.Mutex
+	enabled bool
+	mode    string
+	// queued holds the IDs of the containers to start when the maintenance
+	// ends, in order.
+	queued []string
+}
+
+var (
+	// errMaintenance is returned for the container creates and starts
+	// rejected during the maintenance.
+	errMaintenance = errors.NewErrorWithStatusCode(fmt.Errorf("The daemon is in maintenance mode"), http.StatusServiceUnavailable)
+	// errStartQueued is returned for the container starts queued until the
+	// maintenance ends, so that the API answers with a 202 status code
+	// instead of reporting the container as started.
+	errStartQueued = errors.NewErrorWithStatusCode(fmt.Errorf("The daemon is in maintenance mode, the container is started when the maintenance ends"), http.StatusAccepted)
+)
+
+// SystemMaintenance enables or disables the maintenance mode of the daemon.
+func (daemon *Daemon) SystemMaintenance(config types.MaintenanceConfig) error {
+	if !config.Enabled {
+		return daemon.endMaintenance()
+	}
+
+	mode := config.Mode
+	if mode == "" {
+		mode = types.MaintenanceReject
+	}
+	if mode != types.MaintenanceReject && mode != types.MaintenanceQueue {
+		return errors.NewBadRequestError(fmt.Errorf("invalid maintenance mode: %s (must be %s or %s)", mode, types.MaintenanceReject, types.MaintenanceQueue))
+	}
+	if config.StopTimeout != nil && *config.StopTimeout < 0 {
+		return errors.NewBadRequestError(fmt.Errorf("invalid stop timeout: %d", *config.StopTimeout))
+	}
+
+	daemon.maintenance.Lock()
+	daemon.maintenance.enabled = true
+	daemon.maintenance.mode = mode
+	daemon.maintenance.Unlock()
+	daemon.LogDaemonEventWithAttributes("maintenance", map[string]string{"enabled": "true", "mode": mode})
+
+	if config.StopContainers {
+		return daemon.stopForMaintenance(config.StopTimeout)
+	}
+	return nil
+}
+
+// stopForMaintenance stops the running containers in parallel, and queues
+// them to be started when the maintenance ends.
+func (daemon *Daemon) stopForMaintenance(timeout *int) error {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []string
+	)
+	for _, c := range daemon.List() {
+		if !c.IsRunning() || c.IsPaused() {
+			continue
+		}
+		wg.Add(1)
+		go func(c *container.Container) {
+			defer wg.Done()
+			seconds := c.StopTimeout()
+			if timeout != nil {
+				seconds = *timeout
+			}
+			if err := daemon.containerStop(c, seconds); err != nil {
+				logrus.Errorf("Failed to stop container %s for maintenance: %v", c.ID, err)
+				mu.Lock()
+				failed = append(failed, c.ID)
+				mu.Unlock()
+				return
+			}
+			daemon.maintenance.Lock()
+			daemon.maintenance.queue(c.ID)
+			daemon.maintenance.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to stop containers for maintenance: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// endMaintenance disables the maintenance mode, and starts the queued
+// containers.
+func (daemon *Daemon) endMaintenance() error {
+	daemon.maintenance.Lock()
+	queued := daemon.maintenance.queued
+	daemon.maintenance.enabled = false
+	daemon.maintenance.mode = ""
+	daemon.maintenance.queued = nil
+	daemon.maintenance.Unlock()
+	daemon.LogDaemonEventWithAttributes("maintenance", map[string]string{"enabled": "false"})
+
+	var failed []string
+	for _, id := range queued {
+		c, err := daemon.GetContainer(id)
+		if err != nil {
+			// The container was removed during the maintenance.
+			continue
+		}
+		if c.IsRunning() {
+			continue
+		}
+		if err := daemon.containerStart(c, ""); err != nil {
+			logrus.Errorf("Failed to start container %s after maintenance: %v", c.ID, err)
+			failed = append(failed, c.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to start containers after maintenance: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// queue adds the container to the containers started when the maintenance
+// ends, if it is not queued yet.
+func (m *maintenanceState) queue(id string) {
+	for _, q := range m.queued {
+		if q == id {
+			return
+		}
+	}
+	m.queued = append(m.queued, id)
+}
+
+// checkMaintenanceCreate returns an error if the daemon rejects the
+// container creates.
+func (daemon *Daemon) checkMaintenanceCreate() error {
+	daemon.maintenance.Lock()
+	defer daemon.maintenance.Unlock()
+	if daemon.maintenance.enabled && daemon.maintenance.mode == types.MaintenanceReject {
+		return errMaintenance
+	}
+	return nil
+}
+
+// checkMaintenanceStart returns errMaintenance if the daemon rejects the
+// container starts, or queues the start of the container until the
+// maintenance ends and returns errStartQueued.
+func (daemon *Daemon) checkMaintenanceStart(c *container.Container) error {
+	daemon.maintenance.Lock()
+	defer daemon.maintenance.Unlock()
+	if !daemon.maintenance.enabled {
+		return nil
+	}
+	if daemon.maintenance.mode == types.MaintenanceReject {
+		return errMaintenance
+	}
+	daemon.maintenance.queue(c.ID)
+	return errStartQueued
+}
+
+// cancelMaintenanceRestart cancels the restart of the container by its
+// restart policy during the maintenance, as the other starts. In queue mode,
+// the container is started when the maintenance ends instead.
+func (daemon *Daemon) cancelMaintenanceRestart(c *container.Container) {
+	daemon.maintenance.Lock()
+	defer daemon.maintenance.Unlock()
+	if !daemon.maintenance.enabled {
+		return
+	}
+	if daemon.maintenance.mode == types.MaintenanceQueue {
+		daemon.maintenance.queue(c.ID)
+	}
+	c.RestartManager(false).Cancel()
+}
+
+// maintenanceInfo returns the maintenance state reported by the daemon info.
+func (daemon *Daemon) maintenanceInfo() types.MaintenanceInfo {
+	daemon.maintenance.Lock()
+	defer daemon.maintenance.Unlock()
+	return types.MaintenanceInfo{
+		Enabled:          daemon.maintenance.enabled,
+		Mode:             daemon.maintenance.mode,
+		QueuedContainers: len(daemon.maintenance.queued),
+	}
+}
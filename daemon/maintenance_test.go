@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/registrar"
+	"github.com/docker/docker/pkg/truncindex"
+)
+
+func TestSystemMaintenance(t *testing.T) {
+	daemon := &Daemon{
+		containers: container.NewMemoryStore(),
+		idIndex:    truncindex.NewTruncIndex([]string{}),
+		nameIndex:  registrar.NewRegistrar(),
+	}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:    "queued",
+			State: container.NewState(),
+		},
+	}
+
+	if err := daemon.SystemMaintenance(types.MaintenanceConfig{Enabled: true, Mode: "drain"}); err == nil {
+		t.Fatal("Expected an invalid mode to fail")
+	}
+	if info := daemon.maintenanceInfo(); info.Enabled {
+		t.Fatalf("Expected the maintenance not to be enabled, got %+v", info)
+	}
+
+	if err := daemon.SystemMaintenance(types.MaintenanceConfig{Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := daemon.checkMaintenanceCreate(); err != errMaintenance {
+		t.Fatalf("Expected creates to be rejected, got %v", err)
+	}
+	if err := daemon.checkMaintenanceStart(c); err != errMaintenance {
+		t.Fatalf("Expected starts to be rejected, got %v", err)
+	}
+
+	if err := daemon.SystemMaintenance(types.MaintenanceConfig{Enabled: true, Mode: types.MaintenanceQueue}); err != nil {
+		t.Fatal(err)
+	}
+	if err := daemon.checkMaintenanceCreate(); err != nil {
+		t.Fatalf("Expected creates to be accepted, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := daemon.checkMaintenanceStart(c); err != errStartQueued {
+			t.Fatalf("Expected starts to be queued, got %v", err)
+		}
+	}
+	expected := types.MaintenanceInfo{Enabled: true, Mode: types.MaintenanceQueue, QueuedContainers: 1}
+	if info := daemon.maintenanceInfo(); info != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, info)
+	}
+
+	// The queued container does not exist anymore, it is skipped.
+	if err := daemon.SystemMaintenance(types.MaintenanceConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if info := daemon.maintenanceInfo(); info != (types.MaintenanceInfo{}) {
+		t.Fatalf("Expected the maintenance to be disabled, got %+v", info)
+	}
+	if err := daemon.checkMaintenanceStart(c); err != nil {
+		t.Fatalf("Expected starts not to be queued, got %v", err)
+	}
+}
+
+func TestMaintenanceRestart(t *testing.T) {
+	daemon := &Daemon{
+		containers: container.NewMemoryStore(),
+		idIndex:    truncindex.NewTruncIndex([]string{}),
+		nameIndex:  registrar.NewRegistrar(),
+	}
+	newContainer := func(id string) *container.Container {
+		return &container.Container{
+			CommonContainer: container.CommonContainer{
+				ID:         id,
+				State:      container.NewState(),
+				HostConfig: &containertypes.HostConfig{RestartPolicy: containertypes.RestartPolicy{Name: "always"}},
+			},
+		}
+	}
+
+	// Outside of the maintenance, the restarts are not canceled.
+	c := newContainer("restarted")
+	daemon.cancelMaintenanceRestart(c)
+	if restart, _, err := c.RestartManager(false).ShouldRestart(1, false, time.Minute); !restart || err != nil {
+		t.Fatalf("Expected the container to be restarted, got %v, %v", restart, err)
+	}
+
+	for _, mode := range []string{types.MaintenanceReject, types.MaintenanceQueue} {
+		if err := daemon.SystemMaintenance(types.MaintenanceConfig{Enabled: true, Mode: mode}); err != nil {
+			t.Fatal(err)
+		}
+		c := newContainer(mode)
+		daemon.cancelMaintenanceRestart(c)
+		if restart, _, _ := c.RestartManager(false).ShouldRestart(1, false, time.Minute); restart {
+			t.Fatalf("Expected the restart to be canceled in %s mode", mode)
+		}
+	}
+	// Only the restart canceled in queue mode is queued.
+	if info := daemon.maintenanceInfo(); info.QueuedContainers != 1 {
+		t.Fatalf("Expected 1 queued container, got %+v", info)
+	}
+}
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/distribution"
+	"github.com/docker/docker/reference"
+	"golang.org/x/net/context"
+)
+
+// InspectManifest returns the descriptor of the manifest of the image name
+// in its registry, with the platform of the image.
+func (daemon *Daemon) InspectManifest(ctx context.Context, name string, metaHeaders map[string][]string, authConfig *types.AuthConfig) (*types.ManifestDescriptor, error) {
+	ref, err := reference.ParseNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	return distribution.InspectManifest(ctx, ref, daemon.manifestConfig(metaHeaders, authConfig))
+}
+
+// PushManifestList pushes a manifest list named name, referencing the
+// manifests, to its registry and returns its digest.
+func (daemon *Daemon) PushManifestList(ctx context.Context, name string, manifests []types.ManifestDescriptor, metaHeaders map[string][]string, authConfig *types.AuthConfig) (string, error) {
+	ref, err := reference.ParseNamed(name)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := ref.(reference.Canonical); ok {
+		return "", fmt.Errorf("cannot push the manifest list %s by digest, use a tag", name)
+	}
+	tagged, ok := reference.WithDefaultTag(ref).(reference.NamedTagged)
+	if !ok {
+		return "", fmt.Errorf("invalid manifest list name %s", name)
+	}
+
+	dgst, err := distribution.PushManifestList(ctx, tagged, manifests, daemon.manifestConfig(metaHeaders, authConfig))
+	if err != nil {
+		return "", err
+	}
+	return dgst.String(), nil
+}
+
+func (daemon *Daemon) manifestConfig(metaHeaders map[string][]string, authConfig *types.AuthConfig) *distribution.ManifestConfig {
+	return &distribution.ManifestConfig{
+		MetaHeaders:     metaHeaders,
+		AuthConfig:      authConfig,
+		RegistryService: daemon.RegistryService,
+	}
+}
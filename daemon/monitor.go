@@ -28,10 +28,12 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 			return errors.New("Received StateOOM from libcontainerd on Windows. This should never happen.")
 		}
 		daemon.updateHealthMonitor(c)
+		daemon.sampleResourceUsage(c)
 		daemon.LogContainerEvent(c, "oom")
 	case libcontainerd.StateExit:
-		// if container's AutoRemove flag is set, remove it after clean up
-		if c.HostConfig.AutoRemove {
+		// if container's AutoRemove flag is set, remove it after clean up,
+		// unless its ports are handed off to another container
+		if c.HostConfig.AutoRemove && !daemon.portHandoffs.isStopping(c.ID) {
 			defer func() {
 				if err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
 					logrus.Errorf("can't remove container %s: %v", c.ID, err)
@@ -43,12 +45,19 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		c.Wait()
 		c.Reset(false)
 		c.SetStopped(platformConstructExitStatus(e))
+		daemon.recordResourceUsage(c)
 		attributes := map[string]string{
 			"exitCode": strconv.Itoa(int(e.ExitCode)),
 		}
+		if c.OOMKilled {
+			attributes["oomKilled"] = "true"
+		}
 		daemon.updateHealthMonitor(c)
+		daemon.runtimeLimits.clear(c.ID)
 		daemon.LogContainerEventWithAttributes(c, "die", attributes)
+		daemon.pruneContainerCoreDumps(c)
 		daemon.Cleanup(c)
+		daemon.portHandoffs.stopped(c.ID)
 		// FIXME: here is race condition between two RUN instructions in Dockerfile
 		// because they share same runconfig and change image. Must be fixed
 		// in builder/builder.go
@@ -62,11 +71,17 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		c.Reset(false)
 		c.RestartCount++
 		c.SetRestarting(platformConstructExitStatus(e))
+		daemon.recordResourceUsage(c)
 		attributes := map[string]string{
 			"exitCode": strconv.Itoa(int(e.ExitCode)),
 		}
+		if c.OOMKilled {
+			attributes["oomKilled"] = "true"
+		}
 		daemon.LogContainerEventWithAttributes(c, "die", attributes)
 		daemon.updateHealthMonitor(c)
+		daemon.pruneContainerCoreDumps(c)
+		daemon.cancelMaintenanceRestart(c)
 		return c.ToDisk()
 	case libcontainerd.StateExitProcess:
 		c.Lock()
@@ -89,6 +104,9 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 	case libcontainerd.StateStart, libcontainerd.StateRestore:
 		// Container is already locked in this case
 		c.SetRunning(int(e.Pid), e.State == libcontainerd.StateStart)
+		if e.State == libcontainerd.StateStart {
+			daemon.resetResourceUsage(c)
+		}
 		c.HasBeenManuallyStopped = false
 		c.HasBeenStartedBefore = true
 		if err := c.ToDisk(); err != nil {
@@ -96,6 +114,7 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 			return err
 		}
 		daemon.initHealthMonitor(c)
+		daemon.initRuntimeLimit(c)
 		daemon.LogContainerEvent(c, "start")
 	case libcontainerd.StatePause:
 		// Container is already locked in this case
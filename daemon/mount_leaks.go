@@ -0,0 +1,162 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// mountTracker counts the mounts of the filesystems of the containers made
+// by the daemon, by container ID, and the mounts of the RW layers made by the
+// daemon without a container, such as to flatten an image, by mount ID.
+// Along with the filesystems of the running containers, they are the mounts
+// expected to exist; any other mount of a container filesystem is leaked.
+type mountTracker struct {
+	sync.Mutex
+	containers map[string]int
+	layers     map[string]int
+}
+
+// addContainer tracks a mount of the filesystem of the container id.
+func (t *mountTracker) addContainer(id string) {
+	t.Lock()
+	defer t.Unlock()
+	if t.containers == nil {
+		t.containers = make(map[string]int)
+	}
+	t.containers[id]++
+}
+
+func (t *mountTracker) removeContainer(id string) {
+	t.Lock()
+	defer t.Unlock()
+	decrementMount(t.containers, id)
+}
+
+func (t *mountTracker) containerMounted(id string) bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.containers[id] > 0
+}
+
+// addLayer tracks a mount of the RW layer with the mount ID mountID, made
+// without a container.
+func (t *mountTracker) addLayer(mountID string) {
+	t.Lock()
+	defer t.Unlock()
+	if t.layers == nil {
+		t.layers = make(map[string]int)
+	}
+	t.layers[mountID]++
+}
+
+func (t *mountTracker) removeLayer(mountID string) {
+	t.Lock()
+	defer t.Unlock()
+	decrementMount(t.layers, mountID)
+}
+
+func (t *mountTracker) layerMounted(mountID string) bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.layers[mountID] > 0
+}
+
+func decrementMount(mounts map[string]int, id string) {
+	if mounts[id] <= 1 {
+		delete(mounts, id)
+		return
+	}
+	mounts[id]--
+}
+
+// expectedMount returns whether the filesystem of the container is expected
+// to be mounted.
+func (daemon *Daemon) expectedMount(c *container.Container) bool {
+	return c.IsRunning() || c.IsRestarting() || daemon.rwMounts.containerMounted(c.ID)
+}
+
+// leakedMountsTTL is how long the number of leaked mounts reported by the
+// system information is cached, as counting them reads the mount table of
+// the host.
+const leakedMountsTTL = time.Minute
+
+// leakedMountsCache caches the number of leaked mounts.
+type leakedMountsCache struct {
+	sync.Mutex
+	count   int
+	checked time.Time
+}
+
+// set caches the number of leaked mounts found by a scan of the mount table.
+func (c *leakedMountsCache) set(count int) {
+	c.Lock()
+	c.count, c.checked = count, time.Now()
+	c.Unlock()
+}
+
+// invalidate drops the cached number, which is counted again on the next
+// request.
+func (c *leakedMountsCache) invalidate() {
+	c.Lock()
+	c.checked = time.Time{}
+	c.Unlock()
+}
+
+// leakedMountsCount returns the number of leaked mounts, counted at most
+// leakedMountsTTL ago.
+func (daemon *Daemon) leakedMountsCount() int {
+	c := &daemon.leakedMountsCache
+	c.Lock()
+	defer c.Unlock()
+	if !c.checked.IsZero() && time.Since(c.checked) < leakedMountsTTL {
+		return c.count
+	}
+	leaks, err := daemon.leakedMounts()
+	if err != nil {
+		logrus.Warnf("Could not detect the leaked mounts of container filesystems: %v", err)
+		return c.count
+	}
+	c.count, c.checked = len(leaks), time.Now()
+	return c.count
+}
+
+// containersByMountID maps the IDs of the RW layers of the containers in the
+// storage driver to the containers.
+func (daemon *Daemon) containersByMountID() map[string]*container.Container {
+	containers := make(map[string]*container.Container)
+	for _, c := range daemon.List() {
+		if mountID, err := daemon.layerStore.GetMountID(c.ID); err == nil {
+			containers[mountID] = c
+		}
+	}
+	return containers
+}
+
+// SystemLeakedMounts returns the mounts of container filesystems which are
+// not expected to exist.
+func (daemon *Daemon) SystemLeakedMounts() ([]types.LeakedMount, error) {
+	leaks, err := daemon.leakedMounts()
+	if err != nil {
+		return nil, err
+	}
+	daemon.leakedMountsCache.set(len(leaks))
+	if leaks == nil {
+		leaks = []types.LeakedMount{}
+	}
+	return leaks, nil
+}
+
+// SystemUnmountLeakedMounts force-unmounts the mounts of container
+// filesystems which are not expected to exist, and returns them.
+func (daemon *Daemon) SystemUnmountLeakedMounts() ([]types.LeakedMount, error) {
+	unmounted, err := daemon.unmountLeakedMounts()
+	daemon.leakedMountsCache.invalidate()
+	if unmounted == nil {
+		unmounted = []types.LeakedMount{}
+	}
+	return unmounted, err
+}
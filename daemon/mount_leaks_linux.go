@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/mount"
+)
+
+// rwLayerMountPattern matches the mount points of the RW layers of the
+// containers in the storage drivers, and captures their mount ID.
+var rwLayerMountPattern = regexp.MustCompile(`(?:aufs/mnt|zfs/graph)/([0-9a-f]{64})$|(?:overlay|overlay2|fuse-overlayfs)/([0-9a-f]{64})/merged$`)
+
+// leakedMounts returns the mounts of the RW layers of the containers which
+// are not expected to exist: the mounts of removed containers, and of the
+// containers which are neither running nor mounted by the daemon, such as
+// the mounts left behind by a crash of the daemon.
+func (daemon *Daemon) leakedMounts() ([]types.LeakedMount, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return daemon.leakedMountsFromReader(f, daemon.containersByMountID())
+}
+
+func (daemon *Daemon) leakedMountsFromReader(reader io.Reader, containers map[string]*container.Container) ([]types.LeakedMount, error) {
+	if daemon.root == "" {
+		return nil, nil
+	}
+
+	var leaks []types.LeakedMount
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(reader)
+	for sc.Scan() {
+		// Most mounts are not under the root of the daemon, and are
+		// skipped before the line is split.
+		line := sc.Text()
+		if !strings.Contains(line, daemon.root) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		mnt := fields[4]
+		if !strings.HasPrefix(mnt, daemon.root) || seen[mnt] {
+			continue
+		}
+		m := rwLayerMountPattern.FindStringSubmatch(mnt)
+		if m == nil {
+			continue
+		}
+		seen[mnt] = true
+
+		mountID := m[1] + m[2]
+		if daemon.rwMounts.layerMounted(mountID) {
+			continue
+		}
+		leak := types.LeakedMount{Path: mnt, MountID: mountID}
+		if c := containers[mountID]; c != nil {
+			if daemon.expectedMount(c) {
+				continue
+			}
+			leak.ContainerID = c.ID
+		}
+		leaks = append(leaks, leak)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return leaks, nil
+}
+
+// unmountLeakedMounts unmounts the leaked mounts of the RW layers of the
+// containers, and returns them.
+func (daemon *Daemon) unmountLeakedMounts() ([]types.LeakedMount, error) {
+	leaks, err := daemon.leakedMounts()
+	if err != nil {
+		return nil, err
+	}
+	return daemon.unmountMounts(leaks, mount.Unmount)
+}
+
+// unmountMounts unmounts the leaks. The mounts of the containers are
+// unmounted through the layer store, which keeps the reference counts of the
+// storage driver consistent; the other mounts are force-unmounted with
+// unmount.
+func (daemon *Daemon) unmountMounts(leaks []types.LeakedMount, unmount func(target string) error) ([]types.LeakedMount, error) {
+	var (
+		unmounted []types.LeakedMount
+		errors    []string
+	)
+	for _, leak := range leaks {
+		var (
+			ok  bool
+			err error
+		)
+		if c := daemon.containers.Get(leak.ContainerID); leak.ContainerID != "" && c != nil {
+			ok, err = daemon.unmountContainerLeak(c)
+		} else if !daemon.rwMounts.layerMounted(leak.MountID) {
+			ok, err = true, unmount(leak.Path)
+		}
+		if !ok {
+			continue
+		}
+		if err != nil {
+			logrus.Errorf("Failed to unmount leaked mount %s: %v", leak.Path, err)
+			errors = append(errors, err.Error())
+			continue
+		}
+		logrus.Infof("Unmounted leaked mount %s", leak.Path)
+		unmounted = append(unmounted, leak)
+	}
+	if len(errors) > 0 {
+		return unmounted, fmt.Errorf("Error unmounting leaked mounts:\n%v", strings.Join(errors, "\n"))
+	}
+	return unmounted, nil
+}
+
+// unmountContainerLeak unmounts the leaked mount of the RW layer of the
+// container, unless the container was started or mounted since the mounts
+// were listed. It returns whether the mount was still leaked.
+func (daemon *Daemon) unmountContainerLeak(c *container.Container) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+	// The state is read directly, as expectedMount takes the lock.
+	if c.Running || c.Restarting || daemon.rwMounts.containerMounted(c.ID) || c.RWLayer == nil {
+		return false, nil
+	}
+	return true, c.RWLayer.Unmount()
+}
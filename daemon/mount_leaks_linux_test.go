@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/layer"
+)
+
+const (
+	runningMountID = "1111111111111111111111111111111111111111111111111111111111111111"
+	mountedMountID = "2222222222222222222222222222222222222222222222222222222222222222"
+	stoppedMountID = "3333333333333333333333333333333333333333333333333333333333333333"
+	removedMountID = "4444444444444444444444444444444444444444444444444444444444444444"
+)
+
+const leakedMountsFixture = `100 20 8:4 / /var/lib/docker/overlay2 rw,relatime - ext4 /dev/sda4 rw
+101 100 0:50 / /var/lib/docker/overlay2/` + runningMountID + `/merged rw,relatime - overlay overlay rw
+102 100 0:51 / /var/lib/docker/overlay2/` + mountedMountID + `/merged rw,relatime - overlay overlay rw
+103 100 0:52 / /var/lib/docker/overlay2/` + stoppedMountID + `/merged rw,relatime - overlay overlay rw
+104 100 0:53 / /var/lib/docker/overlay2/` + stoppedMountID + `-init/merged rw,relatime - overlay overlay rw
+105 20 0:54 / /var/lib/docker/aufs/mnt/` + removedMountID + ` rw,relatime - aufs none rw
+106 20 0:54 / /var/lib/docker/aufs/mnt/` + removedMountID + ` rw,relatime - aufs none rw
+107 20 0:55 / /var/lib/docker/containers/` + stoppedMountID + `/shm rw,nosuid - tmpfs shm rw
+108 20 0:56 / /mnt/overlay2/` + removedMountID + `/merged rw,relatime - overlay overlay rw
+`
+
+func TestLeakedMounts(t *testing.T) {
+	d := &Daemon{
+		root:       "/var/lib/docker/",
+		containers: container.NewMemoryStore(),
+	}
+
+	running := container.NewBaseContainer("running", "")
+	running.SetRunning(1234, true)
+	mounted := container.NewBaseContainer("mounted", "")
+	d.rwMounts.addContainer(mounted.ID)
+	stopped := container.NewBaseContainer("stopped", "")
+	containers := map[string]*container.Container{
+		runningMountID: running,
+		mountedMountID: mounted,
+		stoppedMountID: stopped,
+	}
+
+	leaks, err := d.leakedMountsFromReader(strings.NewReader(leakedMountsFixture), containers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.LeakedMount{
+		{Path: "/var/lib/docker/overlay2/" + stoppedMountID + "/merged", MountID: stoppedMountID, ContainerID: stopped.ID},
+		{Path: "/var/lib/docker/aufs/mnt/" + removedMountID, MountID: removedMountID},
+	}
+	if !reflect.DeepEqual(leaks, expected) {
+		t.Fatalf("Expected leaked mounts %v, got %v", expected, leaks)
+	}
+
+	// The mount of a container unmounted by the daemon is leaked.
+	d.rwMounts.removeContainer(mounted.ID)
+	leaks, err = d.leakedMountsFromReader(strings.NewReader(leakedMountsFixture), containers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaks) != 3 || leaks[0].ContainerID != mounted.ID {
+		t.Fatalf("Expected the mount of the unmounted container to be leaked, got %v", leaks)
+	}
+
+	// The RW layers mounted by the daemon without a container are tracked
+	// by mount ID.
+	d.rwMounts.addLayer(removedMountID)
+	leaks, err = d.leakedMountsFromReader(strings.NewReader(leakedMountsFixture), containers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaks) != 2 || leaks[1].MountID != stoppedMountID {
+		t.Fatalf("Expected the RW layer mounted by the daemon not to be leaked, got %v", leaks)
+	}
+}
+
+// unmountCountingLayer is a RW layer counting its unmounts.
+type unmountCountingLayer struct {
+	layer.RWLayer
+	unmounts int
+}
+
+func (l *unmountCountingLayer) Unmount() error {
+	l.unmounts++
+	return nil
+}
+
+func TestUnmountLeakedMounts(t *testing.T) {
+	d := &Daemon{
+		root:       "/var/lib/docker/",
+		containers: container.NewMemoryStore(),
+	}
+
+	// The container was started since its mount was listed.
+	started := container.NewBaseContainer("started", "")
+	started.SetRunning(1234, true)
+	d.containers.Add(started.ID, started)
+	// The mount of a stopped container is unmounted through its RW layer.
+	stopped := container.NewBaseContainer("stopped", "")
+	rwLayer := &unmountCountingLayer{}
+	stopped.RWLayer = rwLayer
+	d.containers.Add(stopped.ID, stopped)
+	// The RW layer was mounted by the daemon since the mounts were listed.
+	d.rwMounts.addLayer(mountedMountID)
+	leaks := []types.LeakedMount{
+		{Path: "/var/lib/docker/overlay2/" + runningMountID + "/merged", MountID: runningMountID, ContainerID: started.ID},
+		{Path: "/var/lib/docker/overlay2/" + stoppedMountID + "/merged", MountID: stoppedMountID, ContainerID: stopped.ID},
+		{Path: "/var/lib/docker/overlay2/" + mountedMountID + "/merged", MountID: mountedMountID},
+		{Path: "/var/lib/docker/aufs/mnt/" + removedMountID, MountID: removedMountID},
+	}
+
+	var targets []string
+	unmount := func(target string) error {
+		targets = append(targets, target)
+		return nil
+	}
+	unmounted, err := d.unmountMounts(leaks, unmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []types.LeakedMount{leaks[1], leaks[3]}
+	if !reflect.DeepEqual(unmounted, expected) {
+		t.Fatalf("Expected unmounted mounts %v, got %v", expected, unmounted)
+	}
+	if rwLayer.unmounts != 1 {
+		t.Fatalf("Expected the RW layer of the stopped container to be unmounted once, got %d", rwLayer.unmounts)
+	}
+	if !reflect.DeepEqual(targets, []string{leaks[3].Path}) {
+		t.Fatalf("Expected %s to be unmounted, got %v", leaks[3].Path, targets)
+	}
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package daemon
+
+import "github.com/docker/docker/api/types"
+
+// leakedMounts returns no mounts: leaked mounts are only detected on Linux.
+func (daemon *Daemon) leakedMounts() ([]types.LeakedMount, error) {
+	return nil, nil
+}
+
+func (daemon *Daemon) unmountLeakedMounts() ([]types.LeakedMount, error) {
+	return nil, nil
+}
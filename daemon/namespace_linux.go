@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/vishvananda/netns"
+)
+
+// inNamespace runs fn on a thread switched to the namespace ns, of type
+// nstype, of the process pid, such as its network namespace.
+//
+// fn runs in a goroutine of its own, whose thread is locked while it is in
+// the namespace of the process, and is switched back to its own namespace
+// before it is unlocked. A goroutine exiting while it is locked does not
+// terminate its thread with the Go versions the daemon is built with, so if
+// the namespace of the thread cannot be restored, the goroutine never exits:
+// the thread is leaked rather than running other goroutines in the namespace
+// of the process.
+func inNamespace(pid int, ns string, nstype int, fn func() error) error {
+	target, err := netns.GetFromPath(fmt.Sprintf("/proc/%d/ns/%s", pid, ns))
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		origin, err := netns.GetFromPath(fmt.Sprintf("/proc/self/task/%d/ns/%s", syscall.Gettid(), ns))
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer origin.Close()
+
+		if err := netns.Setns(target, nstype); err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		err = fn()
+		if nsErr := netns.Setns(origin, nstype); nsErr != nil {
+			logrus.Errorf("Failed to restore the %s namespace of a thread, it is not used anymore: %v", ns, nsErr)
+			errCh <- fmt.Errorf("failed to restore the %s namespace of the thread: %v", ns, nsErr)
+			select {}
+		}
+		runtime.UnlockOSThread()
+		errCh <- err
+	}()
+	return <-errCh
+}
@@ -0,0 +1,33 @@
+package daemon
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestInNamespace(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("switching namespaces requires root")
+	}
+	var ran bool
+	if err := inNamespace(os.Getpid(), "net", syscall.CLONE_NEWNET, func() error {
+		ran = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Fatal("expected the function to run")
+	}
+
+	expected := errors.New("failed")
+	if err := inNamespace(os.Getpid(), "net", syscall.CLONE_NEWNET, func() error { return expected }); err != expected {
+		t.Fatalf("expected the error of the function, got %v", err)
+	}
+
+	if err := inNamespace(-1, "net", syscall.CLONE_NEWNET, func() error { return nil }); err == nil {
+		t.Fatal("expected an error for a process which does not exist")
+	}
+}
@@ -253,6 +253,13 @@ func (daemon *Daemon) createNetwork(create types.NetworkCreateRequest, id string
 		warning = fmt.Sprintf("Network with name %s (id : %s) already exists", nw.Name(), nw.ID())
 	}
 
+	if err := daemon.validateMacPrefix(create.Options); err != nil {
+		return nil, err
+	}
+	if err := validatePublishedPortRange(create.Options); err != nil {
+		return nil, err
+	}
+
 	c := daemon.netController
 	driver := create.Driver
 	if driver == "" {
@@ -291,6 +298,7 @@ func (daemon *Daemon) createNetwork(create types.NetworkCreateRequest, id string
 		return nil, err
 	}
 
+	daemon.networkUsage.touch(n.ID())
 	daemon.LogNetworkEvent(n, "create")
 	return &types.NetworkCreateResponse{
 		ID:      n.ID(),
@@ -404,6 +412,7 @@ func (daemon *Daemon) deleteNetwork(networkID string, dynamic bool) error {
 	if err := nw.Delete(); err != nil {
 		return err
 	}
+	daemon.networkUsage.delete(nw.ID())
 	daemon.LogNetworkEvent(nw, "destroy")
 	return nil
 }
@@ -0,0 +1,202 @@
+package daemon
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/libnetwork"
+	"github.com/docker/libnetwork/drivers/bridge"
+	"github.com/docker/libnetwork/iptables"
+	nettypes "github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// NetworkDiagnostics gathers the state of the network on the host: the
+// network interfaces of its endpoints, the iptables rules which apply to it
+// and the records of its embedded DNS server.
+func (daemon *Daemon) NetworkDiagnostics(nw libnetwork.Network) (*types.NetworkDiagnostics, error) {
+	d := &types.NetworkDiagnostics{
+		Endpoints:     []types.EndpointDiagnostics{},
+		IptablesRules: networkIptablesRules(nw),
+		DNSRecords:    []types.DNSRecord{},
+	}
+
+	resolver, _ := nw.(nameResolver)
+	resolved := make(map[string]bool)
+	for _, ep := range nw.Endpoints() {
+		info := ep.Info()
+		if info == nil {
+			continue
+		}
+		ed := types.EndpointDiagnostics{EndpointID: ep.ID(), Name: ep.Name()}
+		sb := info.Sandbox()
+		if sb != nil {
+			ed.Container = sb.ContainerID()
+			if iface := info.Iface(); iface != nil && iface.MacAddress() != nil {
+				if err := endpointInterfaces(sb.Key(), iface.MacAddress(), &ed); err != nil {
+					logrus.Debugf("Failed to find the interfaces of endpoint %s: %v", ep.ID(), err)
+				}
+			}
+		}
+		d.Endpoints = append(d.Endpoints, ed)
+
+		if sb != nil && resolver != nil && containertypes.NetworkMode(nw.Name()).IsUserDefined() {
+			for _, name := range daemon.endpointDNSNames(nw, ep, sb.ContainerID()) {
+				if resolved[name] {
+					continue
+				}
+				resolved[name] = true
+				d.DNSRecords = append(d.DNSRecords, resolveDNSRecords(resolver, name)...)
+			}
+		}
+	}
+	sort.Sort(byDNSRecordName(d.DNSRecords))
+
+	return d, nil
+}
+
+// endpointInterfaces finds the interface of the endpoint with the given MAC
+// address in the network namespace of the sandbox, and its veth peer in the
+// network namespace of the host.
+func endpointInterfaces(nsPath string, mac []byte, ed *types.EndpointDiagnostics) error {
+	ns, err := netns.GetFromPath(nsPath)
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+	h, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return err
+	}
+	defer h.Delete()
+
+	links, err := h.LinkList()
+	if err != nil {
+		return err
+	}
+	var parentIndex int
+	for _, l := range links {
+		attrs := l.Attrs()
+		if bytes.Equal(attrs.HardwareAddr, mac) {
+			ed.InterfaceName = attrs.Name
+			ed.InterfaceIndex = attrs.Index
+			parentIndex = attrs.ParentIndex
+			break
+		}
+	}
+	if ed.InterfaceName == "" {
+		return fmt.Errorf("no interface with MAC address %s", mac)
+	}
+
+	// The peer of a veth is only in the network namespace of the host for
+	// the drivers like bridge; check that it is the peer of the interface
+	// and not an unrelated interface with the same index.
+	if parentIndex == 0 {
+		return nil
+	}
+	peer, err := netlink.LinkByIndex(parentIndex)
+	if err != nil {
+		return nil
+	}
+	if _, ok := peer.(*netlink.Veth); ok && peer.Attrs().ParentIndex == ed.InterfaceIndex {
+		ed.PeerName = peer.Attrs().Name
+		ed.PeerIndex = parentIndex
+	}
+	return nil
+}
+
+// endpointDNSNames returns the names under which the endpoint may be
+// registered in the embedded DNS server: the name of the endpoint and the
+// name and the aliases of the container on the network.
+func (daemon *Daemon) endpointDNSNames(nw libnetwork.Network, ep libnetwork.Endpoint, containerID string) []string {
+	names := []string{ep.Name()}
+	c, err := daemon.GetContainer(containerID)
+	if err != nil {
+		return names
+	}
+	c.Lock()
+	defer c.Unlock()
+	names = append(names, strings.TrimPrefix(c.Name, "/"))
+	if c.NetworkSettings != nil {
+		if es, ok := c.NetworkSettings.Networks[nw.Name()]; ok && es.EndpointSettings != nil {
+			names = append(names, es.Aliases...)
+		}
+	}
+	return names
+}
+
+// resolveDNSRecords returns the records of the embedded DNS server for the
+// name, as it answers the queries of the containers: a name shared by
+// several containers resolves to several addresses.
+func resolveDNSRecords(resolver nameResolver, name string) []types.DNSRecord {
+	ipv4, _ := resolver.ResolveName(name, nettypes.IPv4)
+	ipv6, _ := resolver.ResolveName(name, nettypes.IPv6)
+	var records []types.DNSRecord
+	for i := 0; i < len(ipv4) || i < len(ipv6); i++ {
+		r := types.DNSRecord{Name: name}
+		if i < len(ipv4) {
+			r.IPv4Address = ipv4[i].String()
+		}
+		if i < len(ipv6) {
+			r.IPv6Address = ipv6[i].String()
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// bridgeInterfaceName returns the name of the bridge interface of a network
+// of the bridge driver.
+func bridgeInterfaceName(nw libnetwork.Network) string {
+	if name, ok := nw.Info().DriverOptions()[bridge.BridgeName]; ok {
+		return name
+	}
+	return "br-" + nw.ID()[:12]
+}
+
+// networkIptablesRules returns the rules of the filter and nat tables which
+// apply to the bridge or to an IPv4 subnet of the network.
+func networkIptablesRules(nw libnetwork.Network) []string {
+	matches := make(map[string]bool)
+	info := nw.Info()
+	if nw.Type() == "bridge" {
+		matches[bridgeInterfaceName(nw)] = true
+	}
+	ipv4Info, _ := info.IpamInfo()
+	for _, i := range ipv4Info {
+		if i.Pool != nil {
+			matches[i.Pool.String()] = true
+		}
+	}
+
+	rules := []string{}
+	for _, table := range []string{"filter", "nat"} {
+		out, err := iptables.Raw("-t", table, "-S")
+		if err != nil {
+			logrus.Debugf("Failed to list the iptables rules of the %s table: %v", table, err)
+			continue
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			for _, field := range strings.Fields(line) {
+				if matches[field] {
+					rules = append(rules, "-t "+table+" "+line)
+					break
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// byDNSRecordName sorts the DNS records by name.
+type byDNSRecordName []types.DNSRecord
+
+func (r byDNSRecordName) Len() int           { return len(r) }
+func (r byDNSRecordName) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byDNSRecordName) Less(i, j int) bool { return r[i].Name < r[j].Name }
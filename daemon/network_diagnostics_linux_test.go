@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	nettypes "github.com/docker/libnetwork/types"
+)
+
+type fakeNameResolver map[int]map[string][]net.IP
+
+func (r fakeNameResolver) ResolveName(name string, ipType int) ([]net.IP, bool) {
+	return r[ipType][name], false
+}
+
+func TestResolveDNSRecords(t *testing.T) {
+	resolver := fakeNameResolver{
+		nettypes.IPv4: {
+			"web": {net.ParseIP("172.18.0.2"), net.ParseIP("172.18.0.3")},
+		},
+		nettypes.IPv6: {
+			"web": {net.ParseIP("fd00::2")},
+		},
+	}
+
+	expected := []types.DNSRecord{
+		{Name: "web", IPv4Address: "172.18.0.2", IPv6Address: "fd00::2"},
+		{Name: "web", IPv4Address: "172.18.0.3"},
+	}
+	if records := resolveDNSRecords(resolver, "web"); !reflect.DeepEqual(records, expected) {
+		t.Fatalf("expected %v, got %v", expected, records)
+	}
+	if records := resolveDNSRecords(resolver, "db"); len(records) != 0 {
+		t.Fatalf("expected no records for an unknown name, got %v", records)
+	}
+}
@@ -0,0 +1,16 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/libnetwork"
+)
+
+// NetworkDiagnostics gathers the state of the network on the host. It is
+// only supported on Linux.
+func (daemon *Daemon) NetworkDiagnostics(nw libnetwork.Network) (*types.NetworkDiagnostics, error) {
+	return nil, fmt.Errorf("network diagnostics are not supported on this platform")
+}
@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/options"
+)
+
+// macPrefixOption is the option of a network which sets the prefix of the
+// MAC addresses of its endpoints. The rest of the MAC address of an endpoint
+// is derived from the name of its container, so that the address does not
+// change when the container is recreated.
+const macPrefixOption = netlabel.Prefix + ".mac_prefix"
+
+// defaultMacPrefix is the prefix of the MAC addresses that the drivers
+// generate from the IP addresses of the endpoints.
+var defaultMacPrefix = []byte{0x02, 0x42}
+
+// parseMacPrefix parses a MAC prefix of 1 to 5 bytes, such as 02:42:0a. The
+// prefix must be the one of unicast addresses.
+func parseMacPrefix(s string) ([]byte, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 5 {
+		return nil, fmt.Errorf("invalid MAC prefix %s: expected 1 to 5 bytes, such as 02:42:0a", s)
+	}
+	prefix := make([]byte, len(parts))
+	for i, p := range parts {
+		b, err := strconv.ParseUint(p, 16, 8)
+		if err != nil || len(p) != 2 {
+			return nil, fmt.Errorf("invalid MAC prefix %s: expected 1 to 5 bytes, such as 02:42:0a", s)
+		}
+		prefix[i] = byte(b)
+	}
+	if prefix[0]&0x01 != 0 {
+		return nil, fmt.Errorf("invalid MAC prefix %s: the prefix of a multicast address cannot be used", s)
+	}
+	return prefix, nil
+}
+
+// macPrefixesOverlap returns whether the MAC addresses with one prefix can
+// have the other prefix.
+func macPrefixesOverlap(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	return bytes.Equal(a[:n], b[:n])
+}
+
+// deriveMacAddress returns the MAC address with the prefix derived from the
+// name of a container.
+func deriveMacAddress(prefix []byte, name string) net.HardwareAddr {
+	sum := sha256.Sum256([]byte(name))
+	mac := make(net.HardwareAddr, 6)
+	copy(mac, prefix)
+	copy(mac[len(prefix):], sum[:])
+	return mac
+}
+
+// validateMacPrefix checks the MAC prefix in the options of a network to
+// create, which must not overlap the MAC addresses generated by the drivers
+// or the MAC prefix of another network.
+func (daemon *Daemon) validateMacPrefix(opts map[string]string) error {
+	value, ok := opts[macPrefixOption]
+	if !ok {
+		return nil
+	}
+	prefix, err := parseMacPrefix(value)
+	if err != nil {
+		return errors.NewBadRequestError(err)
+	}
+	if macPrefixesOverlap(prefix, defaultMacPrefix) {
+		return errors.NewBadRequestError(fmt.Errorf("MAC prefix %s conflicts with the MAC addresses generated by the daemon, which start with 02:42", value))
+	}
+	for _, nw := range daemon.GetNetworks() {
+		other, ok := nw.Info().DriverOptions()[macPrefixOption]
+		if !ok {
+			continue
+		}
+		otherPrefix, err := parseMacPrefix(other)
+		if err != nil {
+			continue
+		}
+		if macPrefixesOverlap(prefix, otherPrefix) {
+			return errors.NewBadRequestError(fmt.Errorf("MAC prefix %s conflicts with the MAC prefix %s of network %s", value, other, nw.Name()))
+		}
+	}
+	return nil
+}
+
+// endpointMacOption returns the option of the endpoint of the container on
+// the network which sets the MAC address derived from the name of the
+// container, if the network has a MAC prefix. It is nil otherwise.
+func endpointMacOption(c *container.Container, n libnetwork.Network) (libnetwork.EndpointOption, error) {
+	value, ok := n.Info().DriverOptions()[macPrefixOption]
+	if !ok {
+		return nil, nil
+	}
+	prefix, err := parseMacPrefix(value)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimPrefix(c.Name, "/")
+	mac := deriveMacAddress(prefix, name)
+	for _, ep := range n.Endpoints() {
+		info := ep.Info()
+		if info == nil || info.Iface() == nil || ep.Name() == name {
+			continue
+		}
+		if bytes.Equal(info.Iface().MacAddress(), mac) {
+			return nil, fmt.Errorf("MAC address %s derived for container %s on network %s is already used by %s", mac, name, n.Name(), ep.Name())
+		}
+	}
+	return libnetwork.EndpointOptionGeneric(options.Generic{netlabel.MacAddress: mac}), nil
+}
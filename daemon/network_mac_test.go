@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseMacPrefix(t *testing.T) {
+	valid := map[string][]byte{
+		"02":             {0x02},
+		"02:42:0a":       {0x02, 0x42, 0x0a},
+		"0A:bc:00:11:22": {0x0a, 0xbc, 0x00, 0x11, 0x22},
+	}
+	for value, expected := range valid {
+		prefix, err := parseMacPrefix(value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", value, err)
+		}
+		if !bytes.Equal(prefix, expected) {
+			t.Fatalf("%s: expected %v, got %v", value, expected, prefix)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                  "expected 1 to 5 bytes",
+		"02:42:0a:00:00:01": "expected 1 to 5 bytes",
+		"2:42":              "expected 1 to 5 bytes",
+		"02:zz":             "expected 1 to 5 bytes",
+		"01:00:5e":          "multicast",
+	}
+	for value, expected := range invalid {
+		if _, err := parseMacPrefix(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected error containing %q, got %v", value, expected, err)
+		}
+	}
+}
+
+func TestMacPrefixesOverlap(t *testing.T) {
+	cases := []struct {
+		a, b    []byte
+		overlap bool
+	}{
+		{[]byte{0x02, 0x42}, []byte{0x02, 0x42, 0x0a}, true},
+		{[]byte{0x02}, []byte{0x02, 0x42}, true},
+		{[]byte{0x02, 0x43}, []byte{0x02, 0x42}, false},
+		{[]byte{0x0a, 0x00, 0x01}, []byte{0x0a, 0x00, 0x02}, false},
+	}
+	for _, c := range cases {
+		if overlap := macPrefixesOverlap(c.a, c.b); overlap != c.overlap {
+			t.Fatalf("%v and %v: expected overlap %v, got %v", c.a, c.b, c.overlap, overlap)
+		}
+		if overlap := macPrefixesOverlap(c.b, c.a); overlap != c.overlap {
+			t.Fatalf("%v and %v: expected overlap %v, got %v", c.b, c.a, c.overlap, overlap)
+		}
+	}
+}
+
+func TestDeriveMacAddress(t *testing.T) {
+	prefix := []byte{0x0a, 0x00, 0x01}
+	mac := deriveMacAddress(prefix, "web")
+	if !bytes.HasPrefix(mac, prefix) || len(mac) != 6 {
+		t.Fatalf("expected a MAC address with the prefix %v, got %s", prefix, mac)
+	}
+	if again := deriveMacAddress(prefix, "web"); again.String() != mac.String() {
+		t.Fatalf("expected the same MAC address for the same name, got %s and %s", mac, again)
+	}
+	if other := deriveMacAddress(prefix, "db"); other.String() == mac.String() {
+		t.Fatalf("expected different MAC addresses for different names, got %s", mac)
+	}
+}
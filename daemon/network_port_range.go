@@ -0,0 +1,89 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// localPortRangeFile holds the range of the local ports the kernel allocates
+// to the outgoing connections.
+const localPortRangeFile = "/proc/sys/net/ipv4/ip_local_port_range"
+
+// localPortRange returns the range of the local ports the kernel allocates
+// to the outgoing connections. It returns 0, 0 if the range is unknown.
+func localPortRange() (int, int) {
+	data, err := ioutil.ReadFile(localPortRangeFile)
+	if err != nil {
+		return 0, 0
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	begin, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0
+	}
+	end, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0
+	}
+	return begin, end
+}
+
+// parsePublishedPortRange parses a range of host ports such as 40000-45000.
+func parsePublishedPortRange(s string) (int, int, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid published port range %q: expected a range such as 40000-45000", s)
+	}
+	begin, end, err := nat.ParsePortRangeToInt(s)
+	if err != nil || begin == 0 || begin >= end {
+		return 0, 0, fmt.Errorf("invalid published port range %q: expected a range such as 40000-45000", s)
+	}
+	return begin, end, nil
+}
+
+// checkLocalPortRange returns an error if the published port range begin-end
+// overlaps the range localBegin-localEnd of the local ports of the kernel, as
+// the ports allocated to the outgoing connections could not be published
+// while they are in use. The overlap is not checked if localEnd is 0.
+func checkLocalPortRange(begin, end, localBegin, localEnd int) error {
+	if localEnd == 0 || begin > localEnd || localBegin > end {
+		return nil
+	}
+	return fmt.Errorf("published port range %d-%d overlaps the local port range %d-%d of the kernel (net.ipv4.ip_local_port_range)", begin, end, localBegin, localEnd)
+}
+
+// verifyPublishedPortRange parses a range of host ports and checks it
+// against the local port range of the kernel.
+func verifyPublishedPortRange(s string) (int, int, error) {
+	begin, end, err := parsePublishedPortRange(s)
+	if err != nil {
+		return 0, 0, err
+	}
+	localBegin, localEnd := localPortRange()
+	if err := checkLocalPortRange(begin, end, localBegin, localEnd); err != nil {
+		return 0, 0, err
+	}
+	return begin, end, nil
+}
+
+// validatePublishedPortRange checks the published port range in the options
+// of a network to create.
+func validatePublishedPortRange(opts map[string]string) error {
+	value, ok := opts[container.PublishedPortRangeOption]
+	if !ok {
+		return nil
+	}
+	if _, _, err := verifyPublishedPortRange(value); err != nil {
+		return errors.NewBadRequestError(err)
+	}
+	return nil
+}
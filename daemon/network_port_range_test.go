@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePublishedPortRange(t *testing.T) {
+	valid := map[string][2]int{
+		"20000-25000": {20000, 25000},
+		"1024-2048":   {1024, 2048},
+		"40000-45000": {40000, 45000},
+		"61000-65535": {61000, 65535},
+	}
+	for value, expected := range valid {
+		begin, end, err := parsePublishedPortRange(value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", value, err)
+		}
+		if begin != expected[0] || end != expected[1] {
+			t.Fatalf("%s: expected %d-%d, got %d-%d", value, expected[0], expected[1], begin, end)
+		}
+	}
+
+	for _, value := range []string{"", "40000", "40000-", "0-1000", "45000-40000", "40000-40000", "40000-70000", "1-2-3"} {
+		if _, _, err := parsePublishedPortRange(value); err == nil || !strings.Contains(err.Error(), "expected a range") {
+			t.Fatalf("%s: expected an invalid range error, got %v", value, err)
+		}
+	}
+}
+
+func TestCheckLocalPortRange(t *testing.T) {
+	overlapping := [][2]int{{30000, 40000}, {40000, 45000}, {60999, 61000}, {32768, 32768}}
+	for _, r := range overlapping {
+		if err := checkLocalPortRange(r[0], r[1], 32768, 60999); err == nil || !strings.Contains(err.Error(), "overlaps the local port range 32768-60999") {
+			t.Fatalf("%d-%d: expected an overlap error, got %v", r[0], r[1], err)
+		}
+	}
+
+	for _, r := range [][2]int{{20000, 25000}, {61000, 65535}, {1024, 32767}} {
+		if err := checkLocalPortRange(r[0], r[1], 32768, 60999); err != nil {
+			t.Fatalf("%d-%d: unexpected error: %v", r[0], r[1], err)
+		}
+	}
+
+	// The overlap is not checked if the local port range is unknown.
+	if err := checkLocalPortRange(40000, 45000, 0, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
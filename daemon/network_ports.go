@@ -0,0 +1,119 @@
+package daemon
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/libnetwork"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/portallocator"
+	netutils "github.com/docker/libnetwork/types"
+)
+
+// endpointPortBindings returns the host ports allocated for the published
+// ports of the endpoint.
+func endpointPortBindings(ep libnetwork.Endpoint) []netutils.PortBinding {
+	if ep.Info() == nil || ep.Info().Iface() == nil || ep.Info().Iface().Address() == nil {
+		return nil
+	}
+	info, err := ep.DriverInfo()
+	if err != nil || info == nil {
+		return nil
+	}
+	bindings, _ := info[netlabel.PortMap].([]netutils.PortBinding)
+
+	// The driver info of an endpoint is the one of the gateway endpoint of
+	// its sandbox: keep the bindings to the address of this endpoint.
+	ip := ep.Info().Iface().Address().IP
+	var pbs []netutils.PortBinding
+	for _, pb := range bindings {
+		if pb.IP.Equal(ip) && pb.HostPort != 0 {
+			pbs = append(pbs, pb)
+		}
+	}
+	return pbs
+}
+
+// userlandProxyKey returns the key of the proxy of a host port in the map
+// returned by userlandProxies.
+func userlandProxyKey(proto, hostIP string, hostPort uint16) string {
+	return proto + "/" + hostIP + ":" + strconv.Itoa(int(hostPort))
+}
+
+// NetworkPorts returns the host ports allocated by the daemon, sorted by
+// protocol and port: the published ports of the running containers, and
+// the ports reserved in the port allocator without being published by a
+// running container, such as the ones leaked by a failed start.
+func (daemon *Daemon) NetworkPorts() ([]types.AllocatedPort, error) {
+	ports := []types.AllocatedPort{}
+	if daemon.netController == nil {
+		return ports, nil
+	}
+	proxies := userlandProxies()
+	published := make(map[string]bool)
+
+	for _, c := range daemon.List() {
+		if !c.IsRunning() || c.NetworkSettings == nil || c.NetworkSettings.SandboxID == "" {
+			continue
+		}
+		sb, err := daemon.netController.SandboxByID(c.NetworkSettings.SandboxID)
+		if err != nil {
+			continue
+		}
+		for _, ep := range sb.Endpoints() {
+			for _, pb := range endpointPortBindings(ep) {
+				hostIP := "0.0.0.0"
+				if pb.HostIP != nil {
+					hostIP = pb.HostIP.String()
+				}
+				port := types.AllocatedPort{
+					Proto:         pb.Proto.String(),
+					HostIP:        hostIP,
+					HostPort:      pb.HostPort,
+					ContainerID:   c.ID,
+					ContainerName: strings.TrimPrefix(c.Name, "/"),
+					Network:       ep.Network(),
+					EndpointID:    ep.ID(),
+					ContainerIP:   pb.IP.String(),
+					ContainerPort: pb.Port,
+				}
+				key := userlandProxyKey(port.Proto, port.HostIP, port.HostPort)
+				port.UserlandProxy = proxies[key]
+				published[key] = true
+				ports = append(ports, port)
+			}
+		}
+	}
+
+	for _, p := range portallocator.Get().AllocatedPorts() {
+		key := userlandProxyKey(p.Proto, p.IP, uint16(p.Port))
+		if published[key] {
+			continue
+		}
+		ports = append(ports, types.AllocatedPort{
+			Proto:         p.Proto,
+			HostIP:        p.IP,
+			HostPort:      uint16(p.Port),
+			UserlandProxy: proxies[key],
+		})
+	}
+
+	sort.Sort(byAllocatedPort(ports))
+	return ports, nil
+}
+
+type byAllocatedPort []types.AllocatedPort
+
+func (p byAllocatedPort) Len() int      { return len(p) }
+func (p byAllocatedPort) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p byAllocatedPort) Less(i, j int) bool {
+	if p[i].Proto != p[j].Proto {
+		return p[i].Proto < p[j].Proto
+	}
+	if p[i].HostPort != p[j].HostPort {
+		return p[i].HostPort < p[j].HostPort
+	}
+	return p[i].HostIP < p[j].HostIP
+}
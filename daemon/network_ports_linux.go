@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// userlandProxies returns the host ports forwarded by a running
+// docker-proxy process.
+func userlandProxies() map[string]bool {
+	proxies := make(map[string]bool)
+	cmdlines, _ := filepath.Glob("/proc/[0-9]*/cmdline")
+	for _, path := range cmdlines {
+		data, err := ioutil.ReadFile(path)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+		if key, ok := parseUserlandProxyCmdline(data); ok {
+			proxies[key] = true
+		}
+	}
+	return proxies
+}
+
+// parseUserlandProxyCmdline returns the key of the host port forwarded by
+// a docker-proxy process, from the content of its /proc/<pid>/cmdline file.
+func parseUserlandProxyCmdline(data []byte) (string, bool) {
+	args := strings.Split(string(bytes.TrimRight(data, "\x00")), "\x00")
+	if filepath.Base(args[0]) != "docker-proxy" {
+		return "", false
+	}
+	var proto, hostIP, hostPort string
+	for i := 1; i+1 < len(args); i++ {
+		switch args[i] {
+		case "-proto":
+			proto = args[i+1]
+		case "-host-ip":
+			hostIP = args[i+1]
+		case "-host-port":
+			hostPort = args[i+1]
+		}
+	}
+	port, err := strconv.ParseUint(hostPort, 10, 16)
+	if proto == "" || hostIP == "" || err != nil {
+		return "", false
+	}
+	return userlandProxyKey(proto, hostIP, uint16(port)), true
+}
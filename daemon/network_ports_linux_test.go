@@ -0,0 +1,21 @@
+package daemon
+
+import "testing"
+
+func TestParseUserlandProxyCmdline(t *testing.T) {
+	for _, c := range []struct {
+		cmdline string
+		key     string
+	}{
+		{"/usr/bin/docker-proxy\x00-proto\x00tcp\x00-host-ip\x000.0.0.0\x00-host-port\x008080\x00-container-ip\x00172.17.0.2\x00-container-port\x0080\x00", "tcp/0.0.0.0:8080"},
+		{"docker-proxy\x00-proto\x00udp\x00-host-ip\x00127.0.0.1\x00-host-port\x005353\x00", "udp/127.0.0.1:5353"},
+		{"/usr/bin/dockerd\x00-proto\x00tcp\x00-host-ip\x000.0.0.0\x00-host-port\x008080\x00", ""},
+		{"/usr/bin/docker-proxy\x00-proto\x00tcp\x00-host-ip\x000.0.0.0\x00", ""},
+		{"/usr/bin/docker-proxy\x00-proto\x00tcp\x00-host-ip\x000.0.0.0\x00-host-port\x00http\x00", ""},
+	} {
+		key, ok := parseUserlandProxyCmdline([]byte(c.cmdline))
+		if ok != (c.key != "") || key != c.key {
+			t.Errorf("%q: expected %q, got %q", c.cmdline, c.key, key)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// +build !linux
+
+package daemon
+
+// userlandProxies returns no proxies: the userland proxy is only supported
+// on Linux.
+func userlandProxies() map[string]bool {
+	return nil
+}
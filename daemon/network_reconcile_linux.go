@@ -0,0 +1,244 @@
+package daemon
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork/iptables"
+	netutils "github.com/docker/libnetwork/types"
+)
+
+// portRule is an iptables rule set up by the bridge driver for a published
+// port.
+type portRule struct {
+	table iptables.Table
+	chain string
+	args  []string
+}
+
+// String returns the rule as listed by iptables -S.
+func (r portRule) String() string {
+	return "-A " + r.chain + " " + strings.Join(r.args, " ")
+}
+
+// publishedPortRules returns the rules the bridge driver sets up to forward
+// the host port of the binding to the container, as
+// iptables.ChainInfo.Forward does.
+func publishedPortRules(pb netutils.PortBinding, bridgeName string, hairpin bool) []portRule {
+	proto := pb.Proto.String()
+	daddr := "0/0"
+	if pb.HostIP != nil && !pb.HostIP.IsUnspecified() {
+		daddr = pb.HostIP.String()
+	}
+	dest := pb.IP.String()
+	destPort := strconv.Itoa(int(pb.Port))
+
+	dnat := []string{"-p", proto, "-d", daddr, "--dport", strconv.Itoa(int(pb.HostPort)),
+		"-j", "DNAT", "--to-destination", net.JoinHostPort(dest, destPort)}
+	if !hairpin {
+		dnat = append(dnat, "!", "-i", bridgeName)
+	}
+	return []portRule{
+		{table: iptables.Nat, chain: "DOCKER", args: dnat},
+		{table: iptables.Filter, chain: "DOCKER", args: []string{"!", "-i", bridgeName, "-o", bridgeName,
+			"-p", proto, "-d", dest, "--dport", destPort, "-j", "ACCEPT"}},
+		{table: iptables.Nat, chain: "POSTROUTING", args: []string{"-p", proto, "-s", dest, "-d", dest,
+			"--dport", destPort, "-j", "MASQUERADE"}},
+	}
+}
+
+// dockerChainRules returns the DOCKER chains the bridge driver sets up for
+// the bridge, and the rules jumping to them, as iptables.ProgramChain does.
+func dockerChainRules(bridgeName string, hairpin bool) []portRule {
+	output := []string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", "DOCKER"}
+	if !hairpin {
+		output = append(output, "!", "--dst", "127.0.0.0/8")
+	}
+	return []portRule{
+		{table: iptables.Nat, chain: "PREROUTING", args: []string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", "DOCKER"}},
+		{table: iptables.Nat, chain: "OUTPUT", args: output},
+		{table: iptables.Filter, chain: "FORWARD", args: []string{"-o", bridgeName, "-j", "DOCKER"}},
+	}
+}
+
+// reconcileDockerChains re-creates the DOCKER chains of the nat and filter
+// tables and the rules jumping to them, if they were deleted, so that the
+// rules of the published ports can be re-created in them.
+func (daemon *Daemon) reconcileDockerChains(hairpin bool) []types.NetworkRuleRepair {
+	var repairs []types.NetworkRuleRepair
+	for _, nw := range daemon.getAllNetworks() {
+		// the internal networks do not jump to the DOCKER chains
+		if nw.Type() != "bridge" || nw.Info().Internal() {
+			continue
+		}
+		bridgeName := bridgeInterfaceName(nw)
+
+		var missing []types.NetworkRuleRepair
+		for _, table := range []iptables.Table{iptables.Nat, iptables.Filter} {
+			if !iptables.ExistChain("DOCKER", table) {
+				missing = append(missing, types.NetworkRuleRepair{
+					Network: nw.Name(),
+					Table:   string(table),
+					Rule:    "-N DOCKER",
+				})
+			}
+		}
+		for _, rule := range dockerChainRules(bridgeName, hairpin) {
+			if !iptables.Exists(rule.table, rule.chain, rule.args...) {
+				missing = append(missing, types.NetworkRuleRepair{
+					Network: nw.Name(),
+					Table:   string(rule.table),
+					Rule:    rule.String(),
+				})
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		var err error
+		for _, table := range []iptables.Table{iptables.Nat, iptables.Filter} {
+			var chain *iptables.ChainInfo
+			if chain, err = iptables.NewChain("DOCKER", table, hairpin); err != nil {
+				break
+			}
+			if err = iptables.ProgramChain(chain, bridgeName, hairpin, true); err != nil {
+				break
+			}
+		}
+		for _, repair := range missing {
+			attributes := map[string]string{
+				"table": repair.Table,
+				"rule":  repair.Rule,
+			}
+			if err != nil {
+				repair.Error = err.Error()
+				logrus.Errorf("Failed to re-create the missing iptables rule %q of network %s: %v", repair.Rule, nw.Name(), err)
+				attributes["error"] = repair.Error
+			} else {
+				logrus.Warnf("Re-created the missing iptables rule %q of network %s", repair.Rule, nw.Name())
+			}
+			daemon.LogNetworkEventWithAttributes(nw, "reconcile", attributes)
+			repairs = append(repairs, repair)
+		}
+	}
+	return repairs
+}
+
+// NetworksReconcile re-creates the missing iptables rules of the published
+// ports of the running containers, and logs a reconcile event for each of
+// them. The DOCKER chains and their jump rules are re-created first. It
+// returns the rules which were missing.
+func (daemon *Daemon) NetworksReconcile() (*types.NetworksReconcileReport, error) {
+	report := &types.NetworksReconcileReport{Repairs: []types.NetworkRuleRepair{}}
+	if daemon.netController == nil || !daemon.configStore.bridgeConfig.EnableIPTables {
+		return report, nil
+	}
+	hairpin := !daemon.configStore.bridgeConfig.EnableUserlandProxy
+
+	daemon.portRulesLock.Lock()
+	defer daemon.portRulesLock.Unlock()
+
+	report.Repairs = append(report.Repairs, daemon.reconcileDockerChains(hairpin)...)
+	for _, c := range daemon.List() {
+		report.Repairs = append(report.Repairs, daemon.reconcileContainerPortRules(c, hairpin)...)
+	}
+	return report, nil
+}
+
+// reconcileContainerPortRules re-creates the missing rules of the container.
+// The container lock is held so that its network is not released meanwhile;
+// the containers being stopped, restarted or removed are skipped, as the
+// rules of their ports may be removed on purpose.
+func (daemon *Daemon) reconcileContainerPortRules(c *container.Container, hairpin bool) []types.NetworkRuleRepair {
+	c.Lock()
+	defer c.Unlock()
+	if !c.Running || c.Restarting || c.RemovalInProgress || c.Dead || c.HasBeenManuallyStopped {
+		return nil
+	}
+	if c.NetworkSettings == nil || c.NetworkSettings.SandboxID == "" {
+		return nil
+	}
+	sb, err := daemon.netController.SandboxByID(c.NetworkSettings.SandboxID)
+	if err != nil {
+		return nil
+	}
+
+	var repairs []types.NetworkRuleRepair
+endpoints:
+	for _, ep := range sb.Endpoints() {
+		nw, err := daemon.FindNetwork(ep.Network())
+		if err != nil {
+			continue
+		}
+		if nw.Type() != "bridge" {
+			continue
+		}
+		bridgeName := bridgeInterfaceName(nw)
+		for _, pb := range endpointPortBindings(ep) {
+			if pb.IP.To4() == nil {
+				continue
+			}
+			for _, rule := range publishedPortRules(pb, bridgeName, hairpin) {
+				if iptables.Exists(rule.table, rule.chain, rule.args...) {
+					continue
+				}
+				// The endpoint may have been removed since the
+				// sandbox was listed, along with its rules.
+				if _, err := nw.EndpointByID(ep.ID()); err != nil {
+					continue endpoints
+				}
+				repair := types.NetworkRuleRepair{
+					Container: c.ID,
+					Network:   nw.Name(),
+					Table:     string(rule.table),
+					Rule:      rule.String(),
+				}
+				args := append([]string{"-t", string(rule.table), string(iptables.Append), rule.chain}, rule.args...)
+				if output, err := iptables.Raw(args...); err != nil {
+					repair.Error = err.Error()
+				} else if len(output) != 0 {
+					repair.Error = iptables.ChainError{Chain: rule.chain, Output: output}.Error()
+				}
+				attributes := map[string]string{
+					"container": c.ID,
+					"table":     repair.Table,
+					"rule":      repair.Rule,
+				}
+				if repair.Error != "" {
+					logrus.Errorf("Failed to re-create the missing iptables rule %q of container %s: %s", repair.Rule, c.ID, repair.Error)
+					attributes["error"] = repair.Error
+				} else {
+					logrus.Warnf("Re-created the missing iptables rule %q of container %s", repair.Rule, c.ID)
+				}
+				daemon.LogNetworkEventWithAttributes(nw, "reconcile", attributes)
+				repairs = append(repairs, repair)
+			}
+		}
+	}
+	return repairs
+}
+
+// startPortRulesReconciliation starts re-creating the missing iptables rules
+// of the published ports periodically, if enabled.
+func (daemon *Daemon) startPortRulesReconciliation() {
+	interval := daemon.configStore.bridgeConfig.IptablesReconcileInterval
+	if interval <= 0 || !daemon.configStore.bridgeConfig.EnableIPTables {
+		return
+	}
+	go func() {
+		for range time.Tick(time.Duration(interval) * time.Second) {
+			if daemon.IsShuttingDown() {
+				return
+			}
+			if _, err := daemon.NetworksReconcile(); err != nil {
+				logrus.Warnf("Failed to reconcile the iptables rules of the published ports: %v", err)
+			}
+		}
+	}()
+}
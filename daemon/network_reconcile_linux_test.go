@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	netutils "github.com/docker/libnetwork/types"
+)
+
+func TestPublishedPortRules(t *testing.T) {
+	pb := netutils.PortBinding{
+		Proto:    netutils.TCP,
+		IP:       net.ParseIP("172.17.0.2"),
+		Port:     80,
+		HostIP:   net.ParseIP("0.0.0.0"),
+		HostPort: 8080,
+	}
+	expected := []string{
+		"-A DOCKER -p tcp -d 0/0 --dport 8080 -j DNAT --to-destination 172.17.0.2:80 ! -i docker0",
+		"-A DOCKER ! -i docker0 -o docker0 -p tcp -d 172.17.0.2 --dport 80 -j ACCEPT",
+		"-A POSTROUTING -p tcp -s 172.17.0.2 -d 172.17.0.2 --dport 80 -j MASQUERADE",
+	}
+	var rules []string
+	for _, r := range publishedPortRules(pb, "docker0", false) {
+		rules = append(rules, r.String())
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+
+	// In hairpin mode, the traffic from the bridge is forwarded too.
+	pb.HostIP = net.ParseIP("127.0.0.1")
+	pb.Proto = netutils.UDP
+	dnat := publishedPortRules(pb, "br-0123456789ab", true)[0]
+	if s := dnat.String(); s != "-A DOCKER -p udp -d 127.0.0.1 --dport 8080 -j DNAT --to-destination 172.17.0.2:80" {
+		t.Fatalf("unexpected DNAT rule in hairpin mode: %s", s)
+	}
+	if dnat.table != "nat" {
+		t.Fatalf("expected the DNAT rule in the nat table, got %s", dnat.table)
+	}
+}
+
+func TestDockerChainRules(t *testing.T) {
+	expected := []string{
+		"-A PREROUTING -m addrtype --dst-type LOCAL -j DOCKER",
+		"-A OUTPUT -m addrtype --dst-type LOCAL -j DOCKER ! --dst 127.0.0.0/8",
+		"-A FORWARD -o docker0 -j DOCKER",
+	}
+	var rules []string
+	for _, r := range dockerChainRules("docker0", false) {
+		rules = append(rules, r.String())
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+
+	// In hairpin mode, the traffic to the loopback addresses jumps too.
+	if s := dockerChainRules("docker0", true)[1].String(); s != "-A OUTPUT -m addrtype --dst-type LOCAL -j DOCKER" {
+		t.Fatalf("unexpected OUTPUT rule in hairpin mode: %s", s)
+	}
+}
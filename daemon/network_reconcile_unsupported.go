@@ -0,0 +1,19 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+)
+
+// NetworksReconcile re-creates the missing iptables rules of the published
+// ports of the running containers. It is only supported on Linux.
+func (daemon *Daemon) NetworksReconcile() (*types.NetworksReconcileReport, error) {
+	return nil, fmt.Errorf("reconciling the iptables rules is not supported on this platform")
+}
+
+// startPortRulesReconciliation does nothing on this platform.
+func (daemon *Daemon) startPortRulesReconciliation() {
+}
@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+// networkUsage records when the networks were last used, that is when a
+// container was last connected to or disconnected from them, to find the
+// networks that are not used anymore. It is saved in the root directory of
+// the daemon.
+type networkUsage struct {
+	sync.Mutex
+	path     string
+	lastUsed map[string]time.Time
+	// loaded is when the usage was loaded, which is used for the networks
+	// that were not recorded yet.
+	loaded time.Time
+}
+
+func newNetworkUsage(path string) (*networkUsage, error) {
+	u := &networkUsage{path: path, lastUsed: make(map[string]time.Time), loaded: time.Now().UTC()}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return u, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &u.lastUsed); err != nil {
+		logrus.Warnf("Ignoring the invalid network usage file %s: %v", path, err)
+	}
+	return u, nil
+}
+
+// touch records that the network is used now.
+func (u *networkUsage) touch(id string) {
+	u.Lock()
+	defer u.Unlock()
+	u.lastUsed[id] = time.Now().UTC()
+	u.save()
+}
+
+// get returns when the network was last used. A network that was not
+// recorded yet, such as a network created by a previous version of the
+// daemon, is reported as used when the daemon started, without recording
+// it: it is recorded the next time it is used.
+func (u *networkUsage) get(id string) time.Time {
+	u.Lock()
+	defer u.Unlock()
+	return u.getLocked(id)
+}
+
+// getLocked is get for a caller holding the lock.
+func (u *networkUsage) getLocked(id string) time.Time {
+	if t, ok := u.lastUsed[id]; ok {
+		return t
+	}
+	return u.loaded
+}
+
+func (u *networkUsage) delete(id string) {
+	u.Lock()
+	defer u.Unlock()
+	delete(u.lastUsed, id)
+	u.save()
+}
+
+// save writes the usage of the networks. The caller must hold the lock.
+func (u *networkUsage) save() {
+	b, err := json.Marshal(u.lastUsed)
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(u.path), 0700); err == nil {
+			err = ioutils.AtomicWriteFile(u.path, b, 0600)
+		}
+	}
+	if err != nil {
+		logrus.Warnf("Failed to save the network usage: %v", err)
+	}
+}
+
+// NetworksLastUsed returns when a container was last connected to, or
+// disconnected from, each of the networks, indexed by network ID.
+func (daemon *Daemon) NetworksLastUsed(ids []string) map[string]time.Time {
+	u := daemon.networkUsage
+	u.Lock()
+	defer u.Unlock()
+	lastUsed := make(map[string]time.Time, len(ids))
+	for _, id := range ids {
+		lastUsed[id] = u.getLocked(id)
+	}
+	return lastUsed
+}
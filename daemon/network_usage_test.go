@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNetworkUsage(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "network-usage-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "network", "usage.json")
+
+	u, err := newNetworkUsage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.touch("foo")
+	used := u.get("foo")
+	if time.Since(used) > time.Minute {
+		t.Fatalf("expected the network to be used now, got %v", used)
+	}
+
+	// The usage is reloaded from disk.
+	u, err = newNetworkUsage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded := u.get("foo"); !reloaded.Equal(used) {
+		t.Fatalf("expected %v, got %v", used, reloaded)
+	}
+
+	// A network that was not recorded is used since the usage was loaded,
+	// and is not recorded by reading it.
+	if unknown := u.get("bar"); !unknown.Equal(u.loaded) {
+		t.Fatalf("expected an unknown network to be used at %v, got %v", u.loaded, unknown)
+	}
+
+	u.delete("foo")
+	u, err = newNetworkUsage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := u.lastUsed["foo"]; ok {
+		t.Fatal("expected the usage of the deleted network to be removed")
+	}
+	if _, ok := u.lastUsed["bar"]; ok {
+		t.Fatal("expected the usage of the unknown network not to be saved")
+	}
+}
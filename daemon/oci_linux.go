@@ -1,10 +1,12 @@
 package daemon
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	containertypes "github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/caps"
 	"github.com/docker/docker/oci"
@@ -19,8 +22,8 @@ import (
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/pkg/stringutils"
 	"github.com/docker/docker/pkg/symlink"
+	"github.com/docker/docker/utils"
 	"github.com/docker/docker/volume"
-	"github.com/opencontainers/runc/libcontainer/apparmor"
 	"github.com/opencontainers/runc/libcontainer/devices"
 	"github.com/opencontainers/runc/libcontainer/user"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -112,6 +115,30 @@ func setDevices(s *specs.Spec, c *container.Container) error {
 	return nil
 }
 
+// setAllocatedDevices injects the devices and the environment variables
+// allocated by the device drivers into the spec.
+func setAllocatedDevices(s *specs.Spec, c *container.Container, alloc *deviceAllocation) error {
+	// a privileged container already has all the devices of the host
+	if !c.HostConfig.Privileged {
+		for _, deviceMapping := range alloc.Devices {
+			if deviceMapping.PathInContainer == "" {
+				deviceMapping.PathInContainer = deviceMapping.PathOnHost
+			}
+			if deviceMapping.CgroupPermissions == "" {
+				deviceMapping.CgroupPermissions = "rwm"
+			}
+			d, dPermissions, err := getDevicesFromPath(deviceMapping)
+			if err != nil {
+				return err
+			}
+			s.Linux.Devices = append(s.Linux.Devices, d...)
+			s.Linux.Resources.Devices = append(s.Linux.Resources.Devices, dPermissions...)
+		}
+	}
+	s.Process.Env = append(s.Process.Env, alloc.Env...)
+	return nil
+}
+
 func setRlimits(daemon *Daemon, s *specs.Spec, c *container.Container) error {
 	var rlimits []specs.Rlimit
 
@@ -159,13 +186,27 @@ func getUser(c *container.Container, username string) (uint32, uint32, []uint32,
 	if err != nil {
 		return 0, 0, nil, err
 	}
-	passwdFile, err := readUserFile(c, passwdPath)
-	if err == nil {
-		defer passwdFile.Close()
-	}
-	groupFile, err := readUserFile(c, groupPath)
-	if err == nil {
-		defer groupFile.Close()
+	var passwdFile io.Reader
+	if f, err := readUserFile(c, passwdPath); err == nil {
+		defer f.Close()
+		passwdFile = f
+	}
+	// the group file is read once, for the groups of the user and for the
+	// additional groups
+	var (
+		groupFile io.Reader
+		groups    []user.Group
+	)
+	if f, err := readUserFile(c, groupPath); err == nil {
+		b, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		if groups, err = user.ParseGroup(bytes.NewReader(b)); err != nil {
+			return 0, 0, nil, err
+		}
+		groupFile = bytes.NewReader(b)
 	}
 
 	execUser, err := user.GetExecUser(username, nil, passwdFile, groupFile)
@@ -173,17 +214,9 @@ func getUser(c *container.Container, username string) (uint32, uint32, []uint32,
 		return 0, 0, nil, err
 	}
 
-	// todo: fix this double read by a change to libcontainer/user pkg
-	groupFile, err = readUserFile(c, groupPath)
-	if err == nil {
-		defer groupFile.Close()
-	}
-	var addGroups []int
-	if len(c.HostConfig.GroupAdd) > 0 {
-		addGroups, err = user.GetAdditionalGroups(c.HostConfig.GroupAdd, groupFile)
-		if err != nil {
-			return 0, 0, nil, err
-		}
+	addGroups, err := getAdditionalGroups(c.HostConfig.GroupAdd, groups, groupPath)
+	if err != nil {
+		return 0, 0, nil, err
 	}
 	uid := uint32(execUser.Uid)
 	gid := uint32(execUser.Gid)
@@ -195,6 +228,40 @@ func getUser(c *container.Container, username string) (uint32, uint32, []uint32,
 	return uid, gid, additionalGids, nil
 }
 
+// getAdditionalGroups resolves the groups of --group-add, in order and without
+// duplicates. A numeric group is used as a GID as is, and a group name is
+// resolved with the groups of the group file of the image, found at
+// groupPath; groups is nil if the image has no group file.
+func getAdditionalGroups(groupAdd []string, groups []user.Group, groupPath string) ([]int, error) {
+	var gids []int
+	seen := make(map[int]bool)
+	for _, ag := range groupAdd {
+		gid, err := strconv.Atoi(ag)
+		if err != nil {
+			gid = -1
+			for _, g := range groups {
+				if g.Name == ag {
+					gid = g.Gid
+					break
+				}
+			}
+			if gid == -1 {
+				if groups == nil {
+					return nil, fmt.Errorf("Unable to find group %s: the image has no %s file to resolve group names", ag, groupPath)
+				}
+				return nil, fmt.Errorf("Unable to find group %s in the %s file of the image", ag, groupPath)
+			}
+		} else if gid < 0 || gid > math.MaxInt32 {
+			return nil, fmt.Errorf("Invalid group %s: GIDs must be in range 0-%d", ag, math.MaxInt32)
+		}
+		if !seen[gid] {
+			seen[gid] = true
+			gids = append(gids, gid)
+		}
+	}
+	return gids, nil
+}
+
 func setNamespace(s *specs.Spec, ns specs.Namespace) {
 	for i, n := range s.Linux.Namespaces {
 		if n.Type == ns.Type {
@@ -308,6 +375,12 @@ func setNamespaces(daemon *Daemon, s *specs.Spec, c *container.Container) error
 		ns := specs.Namespace{Type: "pid"}
 		setNamespace(s, ns)
 	}
+	// cgroup
+	if c.HostConfig.CgroupnsMode.IsPrivate() {
+		setNamespace(s, specs.Namespace{Type: specs.CgroupNamespace})
+	} else {
+		delNamespace(s, specs.CgroupNamespace)
+	}
 	// uts
 	if c.HostConfig.UTSMode.IsHost() {
 		delNamespace(s, specs.NamespaceType("uts"))
@@ -425,6 +498,45 @@ func ensureSharedOrSlave(path string) error {
 	return nil
 }
 
+// verifyBindPropagation checks that the source mount points of the bind
+// mounts of hostConfig support their propagation mode, so that a container
+// whose mounts would fail at start is refused at creation. Sources that do
+// not exist yet are checked when the container starts.
+func verifyBindPropagation(hostConfig *containertypes.HostConfig) error {
+	check := func(source string, propagation mounttypes.Propagation) error {
+		if _, err := os.Stat(source); err != nil {
+			return nil
+		}
+		switch propagation {
+		case mounttypes.PropagationShared, mounttypes.PropagationRShared:
+			return ensureShared(source)
+		case mounttypes.PropagationSlave, mounttypes.PropagationRSlave:
+			return ensureSharedOrSlave(source)
+		}
+		return nil
+	}
+
+	for _, b := range hostConfig.Binds {
+		mp, err := volume.ParseMountRaw(b, hostConfig.VolumeDriver)
+		if err != nil || mp.Type != mounttypes.TypeBind {
+			// invalid specs are reported when the mounts are registered
+			continue
+		}
+		if err := check(mp.Source, mp.Propagation); err != nil {
+			return err
+		}
+	}
+	for _, m := range hostConfig.Mounts {
+		if m.Type != mounttypes.TypeBind || m.BindOptions == nil {
+			continue
+		}
+		if err := check(m.Source, m.BindOptions.Propagation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var (
 	mountPropagationMap = map[string]int{
 		"private":  mount.PRIVATE,
@@ -593,19 +705,13 @@ func (daemon *Daemon) populateCommonSpec(s *specs.Spec, c *container.Container)
 		if (c.HostConfig.Init != nil && *c.HostConfig.Init) ||
 			(c.HostConfig.Init == nil && daemon.configStore.Init) {
 			s.Process.Args = append([]string{"/dev/init", c.Path}, c.Args...)
-			var path string
-			if daemon.configStore.InitPath == "" && c.HostConfig.InitPath == "" {
-				path, err = exec.LookPath("docker-init")
+			path := c.HostConfig.InitPath
+			if path == "" {
+				path, err = daemon.helperPath("init")
 				if err != nil {
-					return err
+					return fmt.Errorf("docker-init binary not available, set --init-path to its location: %v", err)
 				}
 			}
-			if daemon.configStore.InitPath != "" {
-				path = daemon.configStore.InitPath
-			}
-			if c.HostConfig.InitPath != "" {
-				path = c.HostConfig.InitPath
-			}
 			s.Mounts = append(s.Mounts, specs.Mount{
 				Destination: "/dev/init",
 				Type:        "bind",
@@ -615,7 +721,11 @@ func (daemon *Daemon) populateCommonSpec(s *specs.Spec, c *container.Container)
 		}
 	}
 	s.Process.Cwd = cwd
-	s.Process.Env = c.CreateDaemonEnvironment(c.Config.Tty, linkedEnv)
+	envFileEnv, err := daemon.envFileVariables(c)
+	if err != nil {
+		return err
+	}
+	s.Process.Env = utils.ReplaceOrAppendEnvValues(c.CreateDaemonEnvironment(c.Config.Tty, linkedEnv), envFileEnv)
 	s.Process.Terminal = c.Config.Tty
 	s.Hostname = c.FullHostname()
 
@@ -658,9 +768,17 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 	if err := setDevices(&s, c); err != nil {
 		return nil, fmt.Errorf("linux runtime spec devices: %v", err)
 	}
+	alloc, err := daemon.allocateDevices(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := setAllocatedDevices(&s, c, alloc); err != nil {
+		return nil, fmt.Errorf("linux runtime spec devices: %v", err)
+	}
 	if err := setRlimits(daemon, &s, c); err != nil {
 		return nil, fmt.Errorf("linux runtime spec rlimits: %v", err)
 	}
+	setCoreDumpRlimit(&s, c)
 	if err := setUser(&s, c); err != nil {
 		return nil, fmt.Errorf("linux spec user: %v", err)
 	}
@@ -684,6 +802,22 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 	}
 	ms = append(ms, c.IpcMounts()...)
 	ms = append(ms, c.TmpfsMounts()...)
+	ms = append(ms, alloc.mounts()...)
+	coreDumpMounts, err := daemon.coreDumpMounts(c)
+	if err != nil {
+		return nil, err
+	}
+	ms = append(ms, coreDumpMounts...)
+	if c.HostConfig.IpcMode.IsNone() {
+		// The /dev/shm of the container is not mounted from the host, so it
+		// cannot be shared with the other containers.
+		s.Mounts = append(s.Mounts, specs.Mount{
+			Destination: "/dev/shm",
+			Type:        "tmpfs",
+			Source:      "shm",
+			Options:     []string{"nosuid", "noexec", "nodev", "mode=1777", "size=" + strconv.FormatInt(c.HostConfig.ShmSize, 10)},
+		})
+	}
 	sort.Sort(mounts(ms))
 	if err := setMounts(daemon, &s, c, ms); err != nil {
 		return nil, fmt.Errorf("linux mounts: %v", err)
@@ -705,13 +839,14 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 		}
 	}
 
-	if apparmor.IsEnabled() {
-		appArmorProfile := "docker-default"
-		if len(c.AppArmorProfile) > 0 {
-			appArmorProfile = c.AppArmorProfile
-		} else if c.HostConfig.Privileged {
-			appArmorProfile = "unconfined"
-		}
+	if daemon.configStore.Rootless {
+		// An unprivileged user cannot set up the cgroups of the container,
+		// nor lower its oom_score_adj.
+		s.Linux.CgroupsPath = nil
+		s.Linux.Resources = nil
+	}
+
+	if appArmorProfile := daemon.containerAppArmorProfile(c); appArmorProfile != "" {
 		s.Process.ApparmorProfile = appArmorProfile
 	}
 	s.Process.SelinuxLabel = c.GetProcessLabel()
@@ -739,10 +874,18 @@ func (daemon *Daemon) mergeUlimits(c *containertypes.HostConfig) {
 	for _, ul := range ulimits {
 		ulIdx[ul.Name] = struct{}{}
 	}
-	for name, ul := range daemon.configStore.Ulimits {
+	// Add the defaults in a stable order, so that the effective set reported
+	// by inspect does not change between calls.
+	var names []string
+	for name := range daemon.configStore.Ulimits {
 		if _, exists := ulIdx[name]; !exists {
-			ulimits = append(ulimits, ul)
+			names = append(names, name)
 		}
 	}
+	sort.Strings(names)
+	for _, name := range names {
+		ul := *daemon.configStore.Ulimits[name]
+		ulimits = append(ulimits, &ul)
+	}
 	c.Ulimits = ulimits
 }
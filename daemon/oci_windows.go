@@ -6,6 +6,7 @@ import (
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/oci"
+	"github.com/docker/docker/utils"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -58,7 +59,11 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 		// as c:\. Hence, setting it to default of c:\ makes for consistency.
 		s.Process.Cwd = `C:\`
 	}
-	s.Process.Env = c.CreateDaemonEnvironment(c.Config.Tty, linkedEnv)
+	envFileEnv, err := daemon.envFileVariables(c)
+	if err != nil {
+		return nil, err
+	}
+	s.Process.Env = utils.ReplaceOrAppendEnvValues(c.CreateDaemonEnvironment(c.Config.Tty, linkedEnv), envFileEnv)
 	s.Process.ConsoleSize.Height = c.HostConfig.ConsoleSize[0]
 	s.Process.ConsoleSize.Width = c.HostConfig.ConsoleSize[1]
 	s.Process.Terminal = c.Config.Tty
@@ -25,7 +25,12 @@ func (daemon *Daemon) ContainerPause(name string) error {
 func (daemon *Daemon) containerPause(container *container.Container) error {
 	container.Lock()
 	defer container.Unlock()
+	return daemon.pauseLocked(container)
+}
 
+// pauseLocked pauses the container execution, like containerPause. It must be
+// called with the container locked.
+func (daemon *Daemon) pauseLocked(container *container.Container) error {
 	// We cannot Pause the container which is not running
 	if !container.Running {
 		return errNotRunning{container.ID}
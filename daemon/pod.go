@@ -0,0 +1,320 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+)
+
+type errNoSuchPod struct {
+	name string
+}
+
+func (e errNoSuchPod) Error() string {
+	return fmt.Sprintf("No such pod: %s", e.name)
+}
+
+// podIndex returns the index of a container in its pod, or false if the
+// container has no valid index.
+func podIndex(c *container.Container) (int, bool) {
+	index, err := strconv.Atoi(c.Config.Labels[types.ContainerPodIndexLabel])
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+type byPodIndex []*container.Container
+
+func (r byPodIndex) Len() int      { return len(r) }
+func (r byPodIndex) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r byPodIndex) Less(i, j int) bool {
+	a, _ := podIndex(r[i])
+	b, _ := podIndex(r[j])
+	return a < b
+}
+
+// podContainers returns the containers of the pod name, starting with its
+// holder, or an error if there is no such pod. The containers without a
+// valid index, or with the index of another container, are not members of
+// the pod.
+func (daemon *Daemon) podContainers(name string) ([]*container.Container, error) {
+	var containers []*container.Container
+	seen := make(map[int]bool)
+	for _, c := range daemon.List() {
+		if c.Config.Labels[types.ContainerPodLabel] != name {
+			continue
+		}
+		index, ok := podIndex(c)
+		if !ok || seen[index] {
+			logrus.Warnf("Ignoring the container %s of the pod %s with an invalid or duplicate index", c.ID, name)
+			continue
+		}
+		seen[index] = true
+		containers = append(containers, c)
+	}
+	if len(containers) == 0 {
+		return nil, errors.NewRequestNotFoundError(errNoSuchPod{name})
+	}
+	sort.Sort(byPodIndex(containers))
+	return containers, nil
+}
+
+// podLabels returns the reserved labels of the container at index in the
+// pod name.
+func podLabels(name string, index int) map[string]string {
+	return map[string]string{
+		types.ContainerPodLabel:      name,
+		types.ContainerPodIndexLabel: strconv.Itoa(index),
+	}
+}
+
+// podMemberConfig returns the configuration of the container at index in a
+// pod, without its reserved labels. The container joins the network and IPC
+// namespaces of the holder of the pod, unless it is the holder.
+func podMemberConfig(index int, holderID string, member types.PodMember) (*containertypes.Config, *containertypes.HostConfig, error) {
+	if member.Config == nil {
+		return nil, nil, fmt.Errorf("the configuration of the container %d of the pod is missing", index)
+	}
+	config := *member.Config
+
+	hostConfig := &containertypes.HostConfig{}
+	if member.HostConfig != nil {
+		c := *member.HostConfig
+		hostConfig = &c
+	}
+
+	if index == 0 {
+		if hostConfig.NetworkMode.IsContainer() || hostConfig.IpcMode.IsContainer() {
+			return nil, nil, fmt.Errorf("the holder of a pod cannot join the namespaces of another container")
+		}
+		if hostConfig.IpcMode.IsEmpty() {
+			hostConfig.IpcMode = "shareable"
+		}
+		if !hostConfig.IpcMode.IsShareable() && !hostConfig.IpcMode.IsHost() {
+			return nil, nil, fmt.Errorf("the IPC namespace of the holder of a pod must be shareable")
+		}
+		return &config, hostConfig, nil
+	}
+
+	if (hostConfig.NetworkMode != "" && !hostConfig.NetworkMode.IsDefault()) || !hostConfig.IpcMode.IsEmpty() {
+		return nil, nil, fmt.Errorf("the container %d of the pod cannot set a network or IPC mode, it joins the namespaces of the holder", index)
+	}
+	if member.NetworkingConfig != nil && len(member.NetworkingConfig.EndpointsConfig) > 0 {
+		return nil, nil, fmt.Errorf("the container %d of the pod cannot connect to networks, it joins the network of the holder", index)
+	}
+	hostConfig.NetworkMode = containertypes.NetworkMode("container:" + holderID)
+	hostConfig.IpcMode = containertypes.IpcMode("container:" + holderID)
+	return &config, hostConfig, nil
+}
+
+// PodCreate creates the containers of a pod: its holder, the first member,
+// and the other members, which join the network and IPC namespaces of the
+// holder. If a container fails to be created, the containers already
+// created are removed.
+func (daemon *Daemon) PodCreate(req *types.PodCreateRequest) (*types.Pod, error) {
+	if !validContainerNamePattern.MatchString(req.Name) {
+		return nil, errors.NewBadRequestError(fmt.Errorf("Invalid pod name (%s), only %s are allowed", req.Name, validContainerNameChars))
+	}
+	if len(req.Members) == 0 {
+		return nil, errors.NewBadRequestError(fmt.Errorf("a pod needs at least one container"))
+	}
+
+	daemon.podsLock.Lock()
+	defer daemon.podsLock.Unlock()
+
+	if _, err := daemon.podContainers(req.Name); err == nil {
+		return nil, errors.NewRequestConflictError(fmt.Errorf("pod %s already exists", req.Name))
+	}
+
+	var created []string
+	removeCreated := func() {
+		for i := len(created) - 1; i >= 0; i-- {
+			if err := daemon.ContainerRm(created[i], &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
+				logrus.Errorf("Error removing the container %s of the pod %s: %v", created[i], req.Name, err)
+			}
+		}
+	}
+
+	var holderID string
+	for i, member := range req.Members {
+		config, hostConfig, err := podMemberConfig(i, holderID, member)
+		if err != nil {
+			removeCreated()
+			return nil, errors.NewBadRequestError(err)
+		}
+		name := member.Name
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", req.Name, i)
+		}
+		ccr, err := daemon.containerCreate(types.ContainerCreateConfig{
+			Name:             name,
+			Config:           config,
+			HostConfig:       hostConfig,
+			NetworkingConfig: member.NetworkingConfig,
+		}, false, true, podLabels(req.Name, i))
+		if err != nil {
+			removeCreated()
+			return nil, err
+		}
+		created = append(created, ccr.ID)
+		if i == 0 {
+			holderID = ccr.ID
+		}
+	}
+
+	return daemon.podInspect(req.Name)
+}
+
+// PodStart starts the containers of a pod which are not running, starting
+// with its holder. If a container fails to start, the containers it
+// started are stopped.
+func (daemon *Daemon) PodStart(name string) error {
+	daemon.podsLock.Lock()
+	defer daemon.podsLock.Unlock()
+
+	containers, err := daemon.podContainers(name)
+	if err != nil {
+		return err
+	}
+
+	var started []*container.Container
+	for _, c := range containers {
+		if c.IsRunning() {
+			continue
+		}
+		if err := daemon.ContainerStart(c.ID, nil, true, ""); err != nil {
+			for i := len(started) - 1; i >= 0; i-- {
+				if err := daemon.containerStop(started[i], started[i].StopTimeout()); err != nil {
+					logrus.Errorf("Error stopping the container %s of the pod %s: %v", started[i].ID, name, err)
+				}
+			}
+			return fmt.Errorf("Cannot start the container %s of the pod %s: %v", strings.TrimPrefix(c.Name, "/"), name, err)
+		}
+		started = append(started, c)
+	}
+	return nil
+}
+
+// PodStop stops the running containers of a pod, in the reverse order of
+// their start, so that the holder is stopped last. The containers are
+// given seconds to stop gracefully, or their own stop timeout if seconds is
+// nil.
+func (daemon *Daemon) PodStop(name string, seconds *int) error {
+	daemon.podsLock.Lock()
+	defer daemon.podsLock.Unlock()
+
+	containers, err := daemon.podContainers(name)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for i := len(containers) - 1; i >= 0; i-- {
+		c := containers[i]
+		if !c.IsRunning() {
+			continue
+		}
+		timeout := c.StopTimeout()
+		if seconds != nil {
+			timeout = *seconds
+		}
+		if err := daemon.containerStop(c, timeout); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", strings.TrimPrefix(c.Name, "/"), err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("Cannot stop the containers of the pod %s: %s", name, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// PodRm removes the containers of a pod, starting with the members and
+// ending with the holder, and their anonymous volumes if removeVolumes is
+// set. Unless force is set, the pod must be stopped.
+func (daemon *Daemon) PodRm(name string, force, removeVolumes bool) error {
+	daemon.podsLock.Lock()
+	defer daemon.podsLock.Unlock()
+
+	containers, err := daemon.podContainers(name)
+	if err != nil {
+		return err
+	}
+	if !force {
+		for _, c := range containers {
+			if c.IsRunning() {
+				return errors.NewRequestConflictError(fmt.Errorf("You cannot remove the running pod %s. Stop the pod before attempting removal or use force", name))
+			}
+		}
+	}
+
+	for i := len(containers) - 1; i >= 0; i-- {
+		if err := daemon.ContainerRm(containers[i].ID, &types.ContainerRmConfig{ForceRemove: force, RemoveVolume: removeVolumes}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PodInspect returns the containers of a pod and their state.
+func (daemon *Daemon) PodInspect(name string) (*types.Pod, error) {
+	daemon.podsLock.Lock()
+	defer daemon.podsLock.Unlock()
+
+	return daemon.podInspect(name)
+}
+
+func (daemon *Daemon) podInspect(name string) (*types.Pod, error) {
+	containers, err := daemon.podContainers(name)
+	if err != nil {
+		return nil, err
+	}
+	pod := &types.Pod{Name: name}
+	for _, c := range containers {
+		index, _ := podIndex(c)
+		c.Lock()
+		pod.Containers = append(pod.Containers, types.PodContainer{
+			ID:     c.ID,
+			Name:   strings.TrimPrefix(c.Name, "/"),
+			Holder: index == 0,
+			State:  c.State.StateString(),
+		})
+		c.Unlock()
+	}
+	return pod, nil
+}
+
+// Pods returns the pods, sorted by name.
+func (daemon *Daemon) Pods() ([]*types.Pod, error) {
+	daemon.podsLock.Lock()
+	defer daemon.podsLock.Unlock()
+
+	names := make(map[string]bool)
+	for _, c := range daemon.List() {
+		if name, ok := c.Config.Labels[types.ContainerPodLabel]; ok {
+			names[name] = true
+		}
+	}
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	pods := []*types.Pod{}
+	for _, name := range sorted {
+		pod, err := daemon.podInspect(name)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+)
+
+func TestPodMemberConfig(t *testing.T) {
+	labels := map[string]string{"tier": "front"}
+	holder := types.PodMember{Config: &containertypes.Config{Image: "nginx", Labels: labels}}
+	config, hostConfig, err := podMemberConfig(0, "", holder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Labels) != 1 || config.Labels["tier"] != "front" {
+		t.Fatalf("unexpected labels %v", config.Labels)
+	}
+	if !hostConfig.IpcMode.IsShareable() {
+		t.Fatalf("expected the IPC namespace of the holder to be shareable, got %s", hostConfig.IpcMode)
+	}
+
+	member := types.PodMember{Config: &containertypes.Config{Image: "redis"}}
+	config, hostConfig, err = podMemberConfig(1, "holder_id", member)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hostConfig.NetworkMode != "container:holder_id" || hostConfig.IpcMode != "container:holder_id" {
+		t.Fatalf("expected the member to join the namespaces of the holder, got %s and %s", hostConfig.NetworkMode, hostConfig.IpcMode)
+	}
+
+	invalid := []struct {
+		index  int
+		member types.PodMember
+		err    string
+	}{
+		{0, types.PodMember{}, "is missing"},
+		{0, types.PodMember{Config: &containertypes.Config{}, HostConfig: &containertypes.HostConfig{NetworkMode: "container:other"}}, "cannot join"},
+		{0, types.PodMember{Config: &containertypes.Config{}, HostConfig: &containertypes.HostConfig{IpcMode: "private"}}, "must be shareable"},
+		{1, types.PodMember{Config: &containertypes.Config{}, HostConfig: &containertypes.HostConfig{NetworkMode: "bridge"}}, "cannot set a network"},
+		{1, types.PodMember{Config: &containertypes.Config{}, HostConfig: &containertypes.HostConfig{IpcMode: "host"}}, "cannot set a network"},
+	}
+	for _, c := range invalid {
+		if _, _, err := podMemberConfig(c.index, "holder_id", c.member); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Fatalf("expected an error containing %q for %+v, got %v", c.err, c.member.HostConfig, err)
+		}
+	}
+}
+
+func TestPodContainers(t *testing.T) {
+	daemon := &Daemon{containers: container.NewMemoryStore()}
+	for _, c := range []struct {
+		id     string
+		labels map[string]string
+	}{
+		{"member", podLabels("web", 1)},
+		{"holder", podLabels("web", 0)},
+		{"duplicate", podLabels("web", 1)},
+		{"noindex", map[string]string{types.ContainerPodLabel: "web"}},
+		{"other", podLabels("db", 0)},
+	} {
+		daemon.containers.Add(c.id, &container.Container{
+			CommonContainer: container.CommonContainer{
+				ID:     c.id,
+				Config: &containertypes.Config{Labels: c.labels},
+				State:  container.NewState(),
+			},
+		})
+	}
+
+	containers, err := daemon.podContainers("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(containers) != 2 || containers[0].ID != "holder" || (containers[1].ID != "member" && containers[1].ID != "duplicate") {
+		t.Fatalf("expected the holder and one member with a valid index, got %v", containers)
+	}
+	if _, err := daemon.podContainers("cache"); err == nil {
+		t.Fatal("expected an error for a missing pod")
+	}
+}
+
+func TestVerifyReservedLabels(t *testing.T) {
+	if err := verifyReservedLabels(map[string]string{"tier": "front"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []string{types.ContainerPodLabel, types.ContainerPodIndexLabel, types.ContainerJobLabel} {
+		if err := verifyReservedLabels(map[string]string{l: "web"}); err == nil {
+			t.Fatalf("expected an error for the reserved label %s", l)
+		}
+	}
+}
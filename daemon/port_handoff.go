@@ -0,0 +1,318 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// handoffPort is a published host port handed off from a container to
+// another.
+type handoffPort struct {
+	proto    string
+	hostIP   string // empty for all the addresses of the host
+	hostPort int
+}
+
+func (p handoffPort) String() string {
+	return fmt.Sprintf("%s/%s", net.JoinHostPort(p.hostIP, strconv.Itoa(p.hostPort)), p.proto)
+}
+
+// overlaps returns whether the two ports cannot be bound at the same time.
+func (p handoffPort) overlaps(o handoffPort) bool {
+	return p.proto == o.proto && p.hostPort == o.hostPort && hostIPsOverlap(p.hostIP, o.hostIP)
+}
+
+// publishedHostPorts returns the host ports of the bindings. The bindings
+// without a host port, or with a range of host ports, are ignored.
+func publishedHostPorts(bindings nat.PortMap) []handoffPort {
+	var ports []handoffPort
+	for port, pbs := range bindings {
+		for _, pb := range pbs {
+			hostPort, err := strconv.Atoi(pb.HostPort)
+			if err != nil {
+				continue
+			}
+			hostIP := pb.HostIP
+			if isUnspecifiedIP(hostIP) {
+				hostIP = ""
+			}
+			ports = append(ports, handoffPort{proto: port.Proto(), hostIP: hostIP, hostPort: hostPort})
+		}
+	}
+	return ports
+}
+
+// handedOffPorts returns the host ports published by the running container
+// from which the container c publishes too.
+func handedOffPorts(from, c *container.Container) []handoffPort {
+	var ports []handoffPort
+	published := publishedHostPorts(from.NetworkSettings.Ports)
+	for _, p := range publishedHostPorts(c.HostConfig.PortBindings) {
+		for _, o := range published {
+			if p.overlaps(o) {
+				ports = append(ports, p)
+				break
+			}
+		}
+	}
+	return ports
+}
+
+// portReservation is a host port reserved for the container it is handed
+// off to.
+type portReservation struct {
+	id string
+	// holder is the placeholder socket bound on the port while no
+	// container publishes it, if any.
+	holder io.Closer
+}
+
+// portHandoffs holds the host ports being handed off from a container to
+// another, so that no other container publishes them during the swap.
+type portHandoffs struct {
+	sync.Mutex
+	ports map[handoffPort]*portReservation
+	// stopping holds the containers stopped to hand off their ports, with
+	// the channels closed once their exit is handled.
+	stopping map[string]chan struct{}
+}
+
+// stop records that the container id is stopped to hand off its ports, and
+// returns a channel closed once its exit is handled, and its network
+// released.
+func (h *portHandoffs) stop(id string) <-chan struct{} {
+	h.Lock()
+	defer h.Unlock()
+	if h.stopping == nil {
+		h.stopping = make(map[string]chan struct{})
+	}
+	exited := make(chan struct{})
+	h.stopping[id] = exited
+	return exited
+}
+
+// isStopping returns whether the container id is stopped to hand off its
+// ports, in which case it is kept when it exits, to be started again if the
+// handoff fails.
+func (h *portHandoffs) isStopping(id string) bool {
+	h.Lock()
+	defer h.Unlock()
+	_, ok := h.stopping[id]
+	return ok
+}
+
+// stopped notifies that the exit of the container id is handled, if it is
+// stopped to hand off its ports.
+func (h *portHandoffs) stopped(id string) {
+	h.Lock()
+	defer h.Unlock()
+	if exited, ok := h.stopping[id]; ok {
+		close(exited)
+		delete(h.stopping, id)
+	}
+}
+
+// reserve reserves the ports for the container id.
+func (h *portHandoffs) reserve(ports []handoffPort, id string) error {
+	h.Lock()
+	defer h.Unlock()
+	if h.ports == nil {
+		h.ports = make(map[handoffPort]*portReservation)
+	}
+	for _, p := range ports {
+		for o := range h.ports {
+			if p.overlaps(o) {
+				return errors.NewRequestConflictError(fmt.Errorf("port %s is already being handed off", p))
+			}
+		}
+	}
+	for _, p := range ports {
+		h.ports[p] = &portReservation{id: id}
+	}
+	return nil
+}
+
+// hold binds placeholder sockets on the ports reserved for the container
+// id, which reset the connections made until the container publishes them.
+// It returns an error if a port cannot be bound, as another process may
+// publish it before the container.
+func (h *portHandoffs) hold(id string) error {
+	h.Lock()
+	defer h.Unlock()
+	for p, r := range h.ports {
+		if r.id != id || r.holder != nil {
+			continue
+		}
+		holder, err := holdPort(p)
+		if err != nil {
+			return fmt.Errorf("Cannot hold port %s handed off to container %s: %v", p, id, err)
+		}
+		r.holder = holder
+	}
+	return nil
+}
+
+// takeOver returns an error if the container publishes a port reserved for
+// another container, and releases the placeholder sockets of the ports
+// reserved for it, so that it can publish them.
+func (h *portHandoffs) takeOver(c *container.Container) error {
+	h.Lock()
+	defer h.Unlock()
+	ports := publishedHostPorts(c.HostConfig.PortBindings)
+	for o, r := range h.ports {
+		for _, p := range ports {
+			if !p.overlaps(o) {
+				continue
+			}
+			if r.id != c.ID {
+				return errors.NewRequestConflictError(fmt.Errorf("port %s is being handed off to container %s", o, r.id))
+			}
+		}
+	}
+	for _, r := range h.ports {
+		if r.id == c.ID && r.holder != nil {
+			r.holder.Close()
+			r.holder = nil
+		}
+	}
+	return nil
+}
+
+// release releases the reserved ports.
+func (h *portHandoffs) release(ports []handoffPort) {
+	h.Lock()
+	defer h.Unlock()
+	for _, p := range ports {
+		if r, ok := h.ports[p]; ok && r.holder != nil {
+			r.holder.Close()
+		}
+		delete(h.ports, p)
+	}
+}
+
+// holdPort binds a placeholder socket on the port. The TCP connections are
+// reset as soon as they are accepted, and the UDP datagrams are dropped.
+func holdPort(p handoffPort) (io.Closer, error) {
+	addr := net.JoinHostPort(p.hostIP, strconv.Itoa(p.hostPort))
+	switch p.proto {
+	case "tcp":
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				if tcpConn, ok := conn.(*net.TCPConn); ok {
+					tcpConn.SetLinger(0)
+				}
+				conn.Close()
+			}
+		}()
+		return l, nil
+	case "udp":
+		return net.ListenPacket("udp", addr)
+	}
+	return nil, fmt.Errorf("unsupported protocol %s", p.proto)
+}
+
+// ContainerHandoff starts the container name in place of the running
+// container from, handing off the host ports they both publish: from is
+// stopped, waiting up to seconds for it to exit, or its stop timeout if
+// seconds is nil, and the container is started while the daemon holds the
+// ports, so that no other container or process binds them in between. If the
+// container fails to start, from is started again.
+func (daemon *Daemon) ContainerHandoff(name, from string, seconds *int) error {
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	old, err := daemon.GetContainer(from)
+	if err != nil {
+		return err
+	}
+	if c.ID == old.ID {
+		return errors.NewBadRequestError(fmt.Errorf("cannot hand off the ports of a container to itself"))
+	}
+	if !old.IsRunning() {
+		return errors.NewRequestConflictError(errNotRunning{old.ID})
+	}
+	if c.IsRunning() {
+		return errors.NewRequestConflictError(fmt.Errorf("Container %s is already running", c.ID))
+	}
+	if c.IsPaused() || old.IsPaused() {
+		return errors.NewRequestConflictError(fmt.Errorf("cannot hand off the ports of a paused container"))
+	}
+	daemon.maintenance.Lock()
+	inMaintenance := daemon.maintenance.enabled
+	daemon.maintenance.Unlock()
+	if inMaintenance {
+		return errMaintenance
+	}
+
+	ports := handedOffPorts(old, c)
+	if len(ports) == 0 {
+		var names []string
+		for _, p := range publishedHostPorts(old.NetworkSettings.Ports) {
+			names = append(names, p.String())
+		}
+		return errors.NewBadRequestError(fmt.Errorf("container %s does not publish any of the host ports of container %s (%s)", c.ID, old.ID, strings.Join(names, ", ")))
+	}
+	if err := daemon.portHandoffs.reserve(ports, c.ID); err != nil {
+		return err
+	}
+	defer daemon.portHandoffs.release(ports)
+
+	timeout := old.StopTimeout()
+	if seconds != nil {
+		timeout = *seconds
+	}
+
+	// The container is kept when it exits, to start it again if the handoff
+	// fails.
+	exited := daemon.portHandoffs.stop(old.ID)
+	defer daemon.portHandoffs.stopped(old.ID)
+	if !old.IsRunning() {
+		return errors.NewRequestConflictError(errNotRunning{old.ID})
+	}
+	if err := daemon.containerStop(old, timeout); err != nil {
+		return fmt.Errorf("Cannot stop container %s: %v", old.ID, err)
+	}
+	<-exited
+
+	// If the handoff fails, the ports are released to start from again.
+	rollback := func() {
+		daemon.portHandoffs.release(ports)
+		if err := daemon.containerStart(old, ""); err != nil {
+			logrus.Errorf("Failed to start container %s again after the failed handoff of its ports: %v", old.ID, err)
+		}
+	}
+	if err := daemon.portHandoffs.hold(c.ID); err != nil {
+		rollback()
+		return err
+	}
+	if err := daemon.ContainerStart(c.ID, nil, true, ""); err != nil {
+		rollback()
+		return fmt.Errorf("Cannot start container %s: %v", c.ID, err)
+	}
+
+	if old.HostConfig.AutoRemove {
+		if err := daemon.ContainerRm(old.ID, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
+			logrus.Errorf("can't remove container %s: %v", old.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"net"
+	"reflect"
+	"strconv"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestHandedOffPorts(t *testing.T) {
+	old := container.NewBaseContainer("old", "")
+	old.NetworkSettings = &network.Settings{Ports: nat.PortMap{
+		"80/tcp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "8080"}},
+		"443/tcp":  []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "8443"}},
+		"53/udp":   []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "5353"}},
+		"9000/tcp": nil,
+	}}
+	c := container.NewBaseContainer("new", "")
+	c.HostConfig = &containertypes.HostConfig{PortBindings: nat.PortMap{
+		"8000/tcp": []nat.PortBinding{{HostPort: "8080"}},
+		"443/tcp":  []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "8443"}},
+		"53/tcp":   []nat.PortBinding{{HostPort: "5353"}},
+		"9000/tcp": []nat.PortBinding{{HostPort: ""}},
+		"9001/tcp": []nat.PortBinding{{HostPort: "9001-9002"}},
+	}}
+
+	ports := handedOffPorts(old, c)
+	expected := map[handoffPort]bool{
+		{proto: "tcp", hostPort: 8080}:                      true,
+		{proto: "tcp", hostIP: "127.0.0.1", hostPort: 8443}: true,
+	}
+	got := make(map[handoffPort]bool)
+	for _, p := range ports {
+		got[p] = true
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, ports)
+	}
+}
+
+func TestPortHandoffs(t *testing.T) {
+	hostPort, err := strconv.Atoi(freePort(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := handoffPort{proto: "tcp", hostIP: "127.0.0.1", hostPort: hostPort}
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(hostPort))
+	bindings := nat.PortMap{"80/tcp": []nat.PortBinding{{HostPort: strconv.Itoa(hostPort)}}}
+
+	var h portHandoffs
+	if err := h.reserve([]handoffPort{port}, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.reserve([]handoffPort{{proto: "tcp", hostPort: hostPort}}, "other"); err == nil {
+		t.Fatal("expected an error reserving a port being handed off")
+	}
+
+	// The port is held until the container publishes it.
+	if err := h.hold("new"); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := net.Listen("tcp", addr); err == nil {
+		l.Close()
+		t.Fatal("expected the port to be held")
+	}
+
+	other := container.NewBaseContainer("other", "")
+	other.HostConfig = &containertypes.HostConfig{PortBindings: bindings}
+	if err := h.takeOver(other); err == nil {
+		t.Fatal("expected an error publishing a port handed off to another container")
+	}
+
+	c := container.NewBaseContainer("new", "")
+	c.HostConfig = &containertypes.HostConfig{PortBindings: bindings}
+	if err := h.takeOver(c); err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("expected the placeholder to be closed: %v", err)
+	}
+	if err := h.hold("new"); err == nil {
+		t.Fatal("expected an error holding a port bound by another process")
+	}
+	l.Close()
+
+	h.release([]handoffPort{port})
+	if err := h.takeOver(other); err != nil {
+		t.Fatalf("expected the port to be released: %v", err)
+	}
+}
+
+func TestPortHandoffsStopping(t *testing.T) {
+	var h portHandoffs
+	if h.isStopping("old") {
+		t.Fatal("expected the container not to be stopping")
+	}
+	exited := h.stop("old")
+	if !h.isStopping("old") {
+		t.Fatal("expected the container to be stopping")
+	}
+	h.stopped("other")
+	select {
+	case <-exited:
+		t.Fatal("expected the exit of another container not to be notified")
+	default:
+	}
+	h.stopped("old")
+	select {
+	case <-exited:
+	default:
+		t.Fatal("expected the exit of the container to be notified")
+	}
+	if h.isStopping("old") {
+		t.Fatal("expected the container not to be stopping once it exited")
+	}
+	h.stopped("old")
+}
@@ -0,0 +1,144 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// heldPort is a host port held by a running container.
+type heldPort struct {
+	proto     string
+	hostIP    string
+	hostPort  int
+	container *container.Container
+}
+
+// ContainerPortsCheck reports the port bindings which cannot be allocated
+// now, because their host port is held by a running container or by another
+// process of the host. The bindings without a host port, which are given a
+// free port, are not checked; the bindings with a range of host ports can be
+// allocated if one of the ports of the range is free.
+func (daemon *Daemon) ContainerPortsCheck(bindings nat.PortMap) (*types.PortsCheckResponse, error) {
+	resp := &types.PortsCheckResponse{Conflicts: []types.PortConflict{}}
+
+	var held []heldPort
+	for _, c := range daemon.List() {
+		held = append(held, containerHeldPorts(c)...)
+	}
+
+	ports := make([]nat.Port, 0, len(bindings))
+	for port := range bindings {
+		ports = append(ports, port)
+	}
+	nat.Sort(ports, func(ip, jp nat.Port) bool { return ip < jp })
+
+	for _, port := range ports {
+		for _, pb := range bindings[port] {
+			if pb.HostPort == "" {
+				continue
+			}
+			start, end, err := nat.ParsePortRangeToInt(pb.HostPort)
+			if err != nil {
+				return nil, errors.NewBadRequestError(fmt.Errorf("invalid host port %s for port %s: %v", pb.HostPort, port, err))
+			}
+			if conflict := checkPortRange(held, port.Proto(), pb.HostIP, start, end); conflict != nil {
+				conflict.Port = string(port)
+				conflict.HostIP = pb.HostIP
+				conflict.HostPort = pb.HostPort
+				resp.Conflicts = append(resp.Conflicts, *conflict)
+			}
+		}
+	}
+	return resp, nil
+}
+
+// containerHeldPorts returns the host ports held by the container if it is
+// running. The ports are read under the lock of the container, as they are
+// set when it is started.
+func containerHeldPorts(c *container.Container) []heldPort {
+	c.Lock()
+	defer c.Unlock()
+	if !c.Running || c.NetworkSettings == nil {
+		return nil
+	}
+	var held []heldPort
+	for port, pbs := range c.NetworkSettings.Ports {
+		for _, pb := range pbs {
+			if hostPort, err := strconv.Atoi(pb.HostPort); err == nil {
+				held = append(held, heldPort{proto: port.Proto(), hostIP: pb.HostIP, hostPort: hostPort, container: c})
+			}
+		}
+	}
+	return held
+}
+
+// checkPortRange returns the conflict of the range of host ports, or nil if
+// one of its ports is free. The conflict of a range is the one of its first
+// port.
+func checkPortRange(held []heldPort, proto, hostIP string, start, end int) *types.PortConflict {
+	var first *types.PortConflict
+	for hostPort := start; hostPort <= end; hostPort++ {
+		conflict := checkPort(held, proto, hostIP, hostPort)
+		if conflict == nil {
+			return nil
+		}
+		if first == nil {
+			first = conflict
+		}
+	}
+	return first
+}
+
+// checkPort returns the conflict of the host port, or nil if it is free.
+func checkPort(held []heldPort, proto, hostIP string, hostPort int) *types.PortConflict {
+	addr := net.JoinHostPort(hostIP, strconv.Itoa(hostPort))
+	if hostIP == "" {
+		addr = net.JoinHostPort("0.0.0.0", strconv.Itoa(hostPort))
+	}
+	for _, h := range held {
+		if h.proto == proto && h.hostPort == hostPort && hostIPsOverlap(h.hostIP, hostIP) {
+			name := strings.TrimPrefix(h.container.Name, "/")
+			return &types.PortConflict{
+				ContainerID:   h.container.ID,
+				ContainerName: name,
+				Message:       fmt.Sprintf("Bind for %s failed: port is already allocated by container %s", addr, name),
+			}
+		}
+	}
+
+	// The port is not held by a container: check that another process of
+	// the host does not hold it.
+	var err error
+	if proto == "udp" {
+		var l net.PacketConn
+		if l, err = net.ListenPacket("udp", addr); err == nil {
+			l.Close()
+		}
+	} else {
+		var l net.Listener
+		if l, err = net.Listen("tcp", addr); err == nil {
+			l.Close()
+		}
+	}
+	if err != nil {
+		return &types.PortConflict{Message: fmt.Sprintf("Bind for %s failed: port is already in use on the host", addr)}
+	}
+	return nil
+}
+
+// hostIPsOverlap returns whether two bindings on the host IP addresses can
+// conflict, which is the case if one of them binds all the addresses.
+func hostIPsOverlap(a, b string) bool {
+	return isUnspecifiedIP(a) || isUnspecifiedIP(b) || net.ParseIP(a).Equal(net.ParseIP(b))
+}
+
+func isUnspecifiedIP(ip string) bool {
+	return ip == "" || net.ParseIP(ip).IsUnspecified()
+}
@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestContainerPortsCheck(t *testing.T) {
+	// A port held by another process of the host.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	_, hostPort, err := net.SplitHostPort(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A port held by a running container, and a free port.
+	held, free := freePort(t), freePort(t)
+
+	c := container.NewBaseContainer("c1", "")
+	c.Name = "/web"
+	c.NetworkSettings = &network.Settings{Ports: nat.PortMap{
+		"80/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: held}},
+	}}
+	c.SetRunning(1, true)
+	daemon := &Daemon{containers: container.NewMemoryStore()}
+	daemon.containers.Add(c.ID, c)
+
+	resp, err := daemon.ContainerPortsCheck(nat.PortMap{
+		"80/tcp":   []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: held}},
+		"81/tcp":   []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: hostPort}},
+		"82/tcp":   []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: free}},
+		"83/tcp":   []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: ""}},
+		"8080/udp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: held}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", resp.Conflicts)
+	}
+	if conflict := resp.Conflicts[0]; conflict.Port != "80/tcp" || conflict.ContainerID != "c1" || conflict.ContainerName != "web" ||
+		!strings.Contains(conflict.Message, "already allocated by container web") {
+		t.Fatalf("unexpected conflict with the container: %+v", conflict)
+	}
+	if conflict := resp.Conflicts[1]; conflict.Port != "81/tcp" || conflict.ContainerID != "" ||
+		!strings.Contains(conflict.Message, "already in use on the host") {
+		t.Fatalf("unexpected conflict with the host: %+v", conflict)
+	}
+
+	// A range is available if one of its ports is free.
+	resp, err = daemon.ContainerPortsCheck(nat.PortMap{
+		"80/tcp": []nat.PortBinding{{HostPort: hostPort + "-" + hostPort}},
+		"81/tcp": []nat.PortBinding{{HostPort: free + "-" + free}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Conflicts) != 1 || resp.Conflicts[0].Port != "80/tcp" {
+		t.Fatalf("expected a conflict for the range of port 80/tcp, got %+v", resp.Conflicts)
+	}
+
+	if _, err := daemon.ContainerPortsCheck(nat.PortMap{"80/tcp": []nat.PortBinding{{HostPort: "foo"}}}); err == nil {
+		t.Fatal("expected an error for an invalid host port")
+	}
+}
+
+// freePort returns a TCP port which is free on the host.
+func freePort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+}
@@ -1,13 +1,21 @@
 package daemon
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/reference"
+	"github.com/docker/docker/runconfig"
 	"github.com/docker/docker/volume"
 )
 
@@ -65,10 +73,69 @@ func (daemon *Daemon) VolumesPrune(config *types.VolumesPruneConfig) (*types.Vol
 	return rep, err
 }
 
-// ImagesPrune remove unused images
+// networksPruneFilters are the filters accepted by NetworksPrune.
+var networksPruneFilters = map[string]bool{
+	"until":  true,
+	"label":  true,
+	"label!": true,
+}
+
+// NetworksPrune removes the user-defined networks without endpoints. The
+// networks are selected with the filters of config; the until filter selects
+// the networks that were last used before the given time.
+func (daemon *Daemon) NetworksPrune(config *types.NetworksPruneConfig) (*types.NetworksPruneReport, error) {
+	rep := &types.NetworksPruneReport{}
+
+	if err := config.Filters.Validate(networksPruneFilters); err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+	until, err := pruneUntil(config.Filters)
+	if err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+
+	for _, nw := range daemon.GetNetworks() {
+		info := nw.Info()
+		if runconfig.IsPreDefinedNetwork(nw.Name()) || info.Dynamic() || len(nw.Endpoints()) > 0 {
+			continue
+		}
+		if !until.IsZero() && !daemon.networkUsage.get(nw.ID()).Before(until) {
+			continue
+		}
+		if !matchPruneLabels(config.Filters, info.Labels()) {
+			continue
+		}
+		if err := daemon.DeleteNetwork(nw.ID()); err != nil {
+			logrus.Warnf("could not remove network %s: %v", nw.Name(), err)
+			continue
+		}
+		rep.NetworksDeleted = append(rep.NetworksDeleted, nw.Name())
+	}
+
+	return rep, nil
+}
+
+// imagesPruneFilters are the filters accepted by ImagesPrune.
+var imagesPruneFilters = map[string]bool{
+	"until":  true,
+	"label":  true,
+	"label!": true,
+}
+
+// ImagesPrune remove unused images. The images are selected with the filters
+// of config. If config.DryRun is set, nothing is removed: the report holds
+// the images that would be removed and the space that would be reclaimed.
 func (daemon *Daemon) ImagesPrune(config *types.ImagesPruneConfig) (*types.ImagesPruneReport, error) {
 	rep := &types.ImagesPruneReport{}
 
+	if err := config.Filters.Validate(imagesPruneFilters); err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+	until, err := pruneUntil(config.Filters)
+	if err != nil {
+		return nil, errors.NewBadRequestError(err)
+	}
+
 	var allImages map[image.ID]*image.Image
 	if config.DanglingOnly {
 		allImages = daemon.imageStore.Heads()
@@ -76,9 +143,9 @@ func (daemon *Daemon) ImagesPrune(config *types.ImagesPruneConfig) (*types.Image
 		allImages = daemon.imageStore.Map()
 	}
 	allContainers := daemon.List()
-	imageRefs := map[string]bool{}
+	imageRefs := map[image.ID]bool{}
 	for _, c := range allContainers {
-		imageRefs[c.ID] = true
+		imageRefs[c.ImageID] = true
 	}
 
 	// Filter intermediary images and get their unique size
@@ -89,13 +156,23 @@ func (daemon *Daemon) ImagesPrune(config *types.ImagesPruneConfig) (*types.Image
 		if len(daemon.referenceStore.References(dgst)) == 0 && len(daemon.imageStore.Children(id)) != 0 {
 			continue
 		}
+		if !until.IsZero() && img.Created.After(until) {
+			continue
+		}
+		if !matchPruneLabels(config.Filters, imageLabels(img)) {
+			continue
+		}
 		topImages[id] = img
 	}
 
+	if config.DryRun {
+		return daemon.imagesPrunePlan(topImages, imageRefs, config.DanglingOnly, allLayers), nil
+	}
+
 	for id := range topImages {
 		dgst := digest.Digest(id)
 		hex := dgst.Hex()
-		if _, ok := imageRefs[hex]; ok {
+		if _, ok := imageRefs[id]; ok {
 			continue
 		}
 
@@ -150,3 +227,126 @@ func (daemon *Daemon) ImagesPrune(config *types.ImagesPruneConfig) (*types.Image
 
 	return rep, nil
 }
+
+// imagesPrunePlan returns the report of the prune of the top images without
+// removing anything. As ImageDelete does, an image is removed with all its
+// references if it has no children, and so are its parents which are neither
+// referenced nor used by a container once it is removed. The layers that
+// would be released are the layers of the removed images which are not
+// referenced by any remaining image.
+func (daemon *Daemon) imagesPrunePlan(topImages map[image.ID]*image.Image, imageRefs map[image.ID]bool, danglingOnly bool, allLayers map[layer.ChainID]layer.Layer) *types.ImagesPruneReport {
+	rep := &types.ImagesPruneReport{}
+	deleted := map[image.ID]bool{}
+
+	for id := range topImages {
+		if imageRefs[id] {
+			continue
+		}
+		refs := daemon.referenceStore.References(id.Digest())
+		if len(refs) > 0 && danglingOnly {
+			continue
+		}
+		for _, ref := range refs {
+			rep.ImagesDeleted = append(rep.ImagesDeleted, types.ImageDelete{Untagged: ref.String()})
+		}
+		if len(daemon.imageStore.Children(id)) > 0 {
+			continue
+		}
+		deleted[id] = true
+		rep.ImagesDeleted = append(rep.ImagesDeleted, types.ImageDelete{Deleted: id.String()})
+
+		// Prune the parents which would be left unused.
+		for parent, err := daemon.imageStore.GetParent(id); err == nil && parent != ""; parent, err = daemon.imageStore.GetParent(parent) {
+			if deleted[parent] || imageRefs[parent] || len(daemon.referenceStore.References(parent.Digest())) > 0 {
+				break
+			}
+			unused := true
+			for _, child := range daemon.imageStore.Children(parent) {
+				if !deleted[child] {
+					unused = false
+					break
+				}
+			}
+			if !unused {
+				break
+			}
+			deleted[parent] = true
+			rep.ImagesDeleted = append(rep.ImagesDeleted, types.ImageDelete{Deleted: parent.String()})
+		}
+	}
+
+	// Count the references of the remaining images to the layers.
+	retained := map[layer.ChainID]bool{}
+	released := map[layer.ChainID]bool{}
+	for id, img := range daemon.imageStore.Map() {
+		for i := range img.RootFS.DiffIDs {
+			chainID := layer.CreateChainID(img.RootFS.DiffIDs[:i+1])
+			if deleted[id] {
+				released[chainID] = true
+			} else {
+				retained[chainID] = true
+			}
+		}
+	}
+	for chainID := range released {
+		if retained[chainID] {
+			continue
+		}
+		l, ok := allLayers[chainID]
+		if !ok {
+			continue
+		}
+		rep.ImagesDeleted = append(rep.ImagesDeleted, types.ImageDelete{Deleted: chainID.String()})
+		diffSize, err := l.DiffSize()
+		if err != nil {
+			logrus.Warnf("failed to get layer %s size: %v", chainID, err)
+			continue
+		}
+		rep.SpaceReclaimed += uint64(diffSize)
+	}
+
+	return rep
+}
+
+// pruneUntil returns the time of the until filter, before which the images
+// are created to be pruned, or the zero time if it is not set.
+func pruneUntil(pruneFilters filters.Args) (time.Time, error) {
+	values := pruneFilters.Get("until")
+	if len(values) == 0 {
+		return time.Time{}, nil
+	}
+	if len(values) > 1 {
+		return time.Time{}, fmt.Errorf("more than one until filter specified")
+	}
+	ts, err := timetypes.GetTimestamp(values[0], time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+	seconds, nanoseconds, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, nanoseconds), nil
+}
+
+// matchPruneLabels returns whether the labels match all the label filters,
+// given as key or key=value, and none of the label! filters.
+func matchPruneLabels(pruneFilters filters.Args, labels map[string]string) bool {
+	if !pruneFilters.MatchKVList("label", labels) {
+		return false
+	}
+	for _, value := range pruneFilters.Get("label!") {
+		kv := strings.SplitN(value, "=", 2)
+		if v, ok := labels[kv[0]]; ok && (len(kv) == 1 || v == kv[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func imageLabels(img *image.Image) map[string]string {
+	if img.Config == nil {
+		return nil
+	}
+	return img.Config.Labels
+}
@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestPruneUntil(t *testing.T) {
+	until, err := pruneUntil(filters.NewArgs())
+	if err != nil || !until.IsZero() {
+		t.Fatalf("expected no time without an until filter, got %v, %v", until, err)
+	}
+
+	pruneFilters := filters.NewArgs()
+	pruneFilters.Add("until", "72h")
+	until, err = pruneUntil(pruneFilters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(until) - 72*time.Hour; d < 0 || d > time.Minute {
+		t.Fatalf("expected a time 72h ago, got %v", until)
+	}
+
+	pruneFilters.Add("until", "2016-01-01")
+	if _, err := pruneUntil(pruneFilters); err == nil {
+		t.Fatal("expected an error for more than one until filter")
+	}
+}
+
+func TestMatchPruneLabels(t *testing.T) {
+	labels := map[string]string{"keep": "", "env": "prod"}
+	cases := []struct {
+		filters  map[string]string
+		expected bool
+	}{
+		{nil, true},
+		{map[string]string{"label": "env"}, true},
+		{map[string]string{"label": "env=prod"}, true},
+		{map[string]string{"label": "env=dev"}, false},
+		{map[string]string{"label!": "keep"}, false},
+		{map[string]string{"label!": "env=dev"}, true},
+		{map[string]string{"label!": "env=prod"}, false},
+		{map[string]string{"label!": "other"}, true},
+	}
+	for _, c := range cases {
+		pruneFilters := filters.NewArgs()
+		for k, v := range c.filters {
+			pruneFilters.Add(k, v)
+		}
+		if matched := matchPruneLabels(pruneFilters, labels); matched != c.expected {
+			t.Fatalf("%v: expected %v, got %v", c.filters, c.expected, matched)
+		}
+	}
+	if !matchPruneLabels(filters.NewArgs(), nil) {
+		t.Fatal("expected an image without labels to match no filter")
+	}
+}
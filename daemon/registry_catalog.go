@@ -0,0 +1,33 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/distribution"
+	"github.com/docker/docker/reference"
+	"golang.org/x/net/context"
+)
+
+// RegistryRepositories lists at most limit repositories of the catalog of
+// the registry hostname, after the repository last.
+func (daemon *Daemon) RegistryRepositories(ctx context.Context, hostname, last string, limit int, metaHeaders map[string][]string, authConfig *types.AuthConfig) (*types.RegistryRepositories, error) {
+	return distribution.ListRepositories(ctx, hostname, last, limit, daemon.manifestConfig(metaHeaders, authConfig))
+}
+
+// RepositoryTags lists the tags of the repository name in its registry.
+func (daemon *Daemon) RepositoryTags(ctx context.Context, name string, metaHeaders map[string][]string, authConfig *types.AuthConfig) (*types.RepositoryTags, error) {
+	ref, err := reference.ParseNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	if !reference.IsNameOnly(ref) {
+		return nil, fmt.Errorf("%s is not a repository name, remove its tag or digest", name)
+	}
+
+	tags, err := distribution.ListTags(ctx, ref, daemon.manifestConfig(metaHeaders, authConfig))
+	if err != nil {
+		return nil, err
+	}
+	return &types.RepositoryTags{Name: ref.String(), Tags: tags}, nil
+}
@@ -0,0 +1,34 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerResolve resolves a host name as the running container would:
+// with its /etc/hosts file, then with the embedded DNS server of its
+// networks or the nameservers of its /etc/resolv.conf file, applying its
+// search domains. It returns the lookups made and the addresses found.
+func (daemon *Daemon) ContainerResolve(name, host string) (*types.ContainerResolveResponse, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return nil, errors.NewBadRequestError(fmt.Errorf("the name to resolve is missing"))
+	}
+
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	container.Lock()
+	if !container.Running {
+		container.Unlock()
+		return nil, errors.NewRequestConflictError(errNotRunning{container.ID})
+	}
+	container.Unlock()
+
+	return daemon.resolveInContainer(container, host)
+}
@@ -0,0 +1,247 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork/resolvconf"
+	netutils "github.com/docker/libnetwork/types"
+	"github.com/miekg/dns"
+)
+
+// embeddedDNSAddress is the address of the embedded DNS server in the
+// /etc/resolv.conf file of the containers connected to user-defined
+// networks.
+const embeddedDNSAddress = "127.0.0.11"
+
+// nameserverTimeout is the timeout of the dial, the write and the read of a
+// query to a nameserver.
+const nameserverTimeout = 2 * time.Second
+
+// nameResolver is implemented by the sandboxes and the networks of
+// libnetwork, which resolve the names of the containers and services of
+// their networks.
+type nameResolver interface {
+	ResolveName(name string, ipType int) ([]net.IP, bool)
+}
+
+// resolvNdots returns the ndots option of a resolv.conf file: a name with at
+// least as many dots is looked up as is before the search domains are
+// appended to it. It defaults to 1.
+func resolvNdots(options []string) int {
+	ndots := 1
+	for _, opt := range options {
+		if !strings.HasPrefix(opt, "ndots:") {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(opt, "ndots:")); err == nil && n >= 0 {
+			ndots = n
+		}
+	}
+	return ndots
+}
+
+// resolveCandidates returns the names looked up, in order, to resolve host
+// with the search domains, as the resolver of the C library does. A name
+// ending with a dot is absolute, and is looked up as is only.
+func resolveCandidates(host string, search []string, ndots int) []string {
+	if strings.HasSuffix(host, ".") {
+		return []string{strings.TrimSuffix(host, ".")}
+	}
+	var withSearch []string
+	for _, domain := range search {
+		withSearch = append(withSearch, host+"."+strings.TrimSuffix(domain, "."))
+	}
+	if strings.Count(host, ".") >= ndots {
+		return append([]string{host}, withSearch...)
+	}
+	return append(withSearch, host)
+}
+
+// hostsLookup returns the addresses of host in the content of a hosts
+// file.
+func hostsLookup(content []byte, host string) []string {
+	host = strings.TrimSuffix(host, ".")
+	var addrs []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		for _, name := range fields[1:] {
+			if strings.EqualFold(name, host) {
+				addrs = append(addrs, fields[0])
+				break
+			}
+		}
+	}
+	return addrs
+}
+
+// queryNameserver looks up the IPv4 and IPv6 addresses of host on the
+// nameserver. A name which does not exist has no addresses, other failures
+// are errors, after which the next nameserver is queried.
+func queryNameserver(server, host string) ([]string, error) {
+	client := &dns.Client{
+		DialTimeout:  nameserverTimeout,
+		ReadTimeout:  nameserverTimeout,
+		WriteTimeout: nameserverTimeout,
+	}
+	var addrs []string
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(host), qtype)
+		resp, _, err := client.Exchange(msg, net.JoinHostPort(server, "53"))
+		if err != nil {
+			return nil, err
+		}
+		switch resp.Rcode {
+		case dns.RcodeSuccess:
+		case dns.RcodeNameError:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("%s", dns.RcodeToString[resp.Rcode])
+		}
+		for _, rr := range resp.Answer {
+			switch rr := rr.(type) {
+			case *dns.A:
+				addrs = append(addrs, rr.A.String())
+			case *dns.AAAA:
+				addrs = append(addrs, rr.AAAA.String())
+			}
+		}
+	}
+	return addrs, nil
+}
+
+// queryNameservers looks up host on the nameservers, in order, until one of
+// them answers, and records the attempts in resp. The nameservers are
+// queried from the network namespace of the process pid, as they may only
+// be reachable from the container.
+func queryNameservers(resp *types.ContainerResolveResponse, pid int, servers []string, host string) []string {
+	for _, server := range servers {
+		var addrs []string
+		err := inNamespace(pid, "net", syscall.CLONE_NEWNET, func() error {
+			var err error
+			addrs, err = queryNameserver(server, host)
+			return err
+		})
+		attempt := types.ContainerResolveAttempt{Name: host, Source: server, Addresses: addrs}
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+		resp.Attempts = append(resp.Attempts, attempt)
+		if err == nil {
+			return addrs
+		}
+	}
+	return nil
+}
+
+// externalDNSServers returns the nameservers the embedded DNS server of the
+// container forwards the names it does not know to: the DNS servers of the
+// container or of the daemon, or those of the host.
+func (daemon *Daemon) externalDNSServers(c *container.Container) []string {
+	if len(c.HostConfig.DNS) > 0 {
+		return c.HostConfig.DNS
+	}
+	if len(daemon.configStore.DNS) > 0 {
+		return daemon.configStore.DNS
+	}
+	f, err := resolvconf.Get()
+	if err != nil {
+		return nil
+	}
+	if f, err = resolvconf.FilterResolvDNS(f.Content, true); err != nil {
+		return nil
+	}
+	return resolvconf.GetNameservers(f.Content, netutils.IP)
+}
+
+// embeddedLookup looks up host on the embedded DNS server of the container,
+// which resolves the names of the containers and services of its networks.
+func (daemon *Daemon) embeddedLookup(c *container.Container, host string) ([]string, error) {
+	sandboxID, err := daemon.getNetworkSandboxID(c)
+	if err != nil {
+		return nil, err
+	}
+	sb, err := daemon.netController.SandboxByID(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	resolver, ok := sb.(nameResolver)
+	if !ok {
+		return nil, fmt.Errorf("the network sandbox of the container does not resolve names")
+	}
+	var addrs []string
+	for _, ipType := range []int{netutils.IPv4, netutils.IPv6} {
+		ips, _ := resolver.ResolveName(host, ipType)
+		for _, ip := range ips {
+			addrs = append(addrs, ip.String())
+		}
+	}
+	return addrs, nil
+}
+
+func (daemon *Daemon) resolveInContainer(c *container.Container, host string) (*types.ContainerResolveResponse, error) {
+	resp := &types.ContainerResolveResponse{Name: host}
+	pid := c.GetPID()
+
+	if c.HostsPath != "" {
+		content, err := ioutil.ReadFile(c.HostsPath)
+		if err != nil {
+			return nil, err
+		}
+		addrs := hostsLookup(content, host)
+		resp.Attempts = append(resp.Attempts, types.ContainerResolveAttempt{Name: strings.TrimSuffix(host, "."), Source: "hosts", Addresses: addrs})
+		if len(addrs) > 0 {
+			resp.Addresses = addrs
+			return resp, nil
+		}
+	}
+
+	content, err := ioutil.ReadFile(c.ResolvConfPath)
+	if err != nil {
+		return nil, err
+	}
+	resp.Nameservers = resolvconf.GetNameservers(content, netutils.IP)
+	resp.Search = resolvconf.GetSearchDomains(content)
+	resp.Ndots = resolvNdots(resolvconf.GetOptions(content))
+
+	embedded := len(resp.Nameservers) == 1 && resp.Nameservers[0] == embeddedDNSAddress
+	for _, name := range resolveCandidates(host, resp.Search, resp.Ndots) {
+		var addrs []string
+		if embedded {
+			addrs, err = daemon.embeddedLookup(c, name)
+			attempt := types.ContainerResolveAttempt{Name: name, Source: "embedded", Addresses: addrs}
+			if err != nil {
+				attempt.Error = err.Error()
+			}
+			resp.Attempts = append(resp.Attempts, attempt)
+			if len(addrs) == 0 {
+				addrs = queryNameservers(resp, pid, daemon.externalDNSServers(c), name)
+			}
+		} else {
+			addrs = queryNameservers(resp, pid, resp.Nameservers, name)
+		}
+		if len(addrs) > 0 {
+			resp.Addresses = addrs
+			break
+		}
+	}
+	return resp, nil
+}
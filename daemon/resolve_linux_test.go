@@ -0,0 +1,69 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveCandidates(t *testing.T) {
+	search := []string{"example.com", "corp.example.com."}
+	for _, c := range []struct {
+		host     string
+		ndots    int
+		expected []string
+	}{
+		{"db", 1, []string{"db.example.com", "db.corp.example.com", "db"}},
+		{"db.internal", 1, []string{"db.internal", "db.internal.example.com", "db.internal.corp.example.com"}},
+		{"db.internal", 2, []string{"db.internal.example.com", "db.internal.corp.example.com", "db.internal"}},
+		// The embedded DNS server sets ndots to 0.
+		{"db", 0, []string{"db", "db.example.com", "db.corp.example.com"}},
+		{"db.example.org.", 1, []string{"db.example.org"}},
+	} {
+		if candidates := resolveCandidates(c.host, search, c.ndots); !reflect.DeepEqual(candidates, c.expected) {
+			t.Errorf("%s with ndots:%d: expected %v, got %v", c.host, c.ndots, c.expected, candidates)
+		}
+	}
+	if candidates := resolveCandidates("db", nil, 1); !reflect.DeepEqual(candidates, []string{"db"}) {
+		t.Errorf("expected db without search domains, got %v", candidates)
+	}
+}
+
+func TestResolvNdots(t *testing.T) {
+	for _, c := range []struct {
+		options  []string
+		expected int
+	}{
+		{nil, 1},
+		{[]string{"ndots:0"}, 0},
+		{[]string{"timeout:2", "ndots:5"}, 5},
+		{[]string{"ndots:x"}, 1},
+	} {
+		if ndots := resolvNdots(c.options); ndots != c.expected {
+			t.Errorf("%v: expected %d, got %d", c.options, c.expected, ndots)
+		}
+	}
+}
+
+func TestHostsLookup(t *testing.T) {
+	content := []byte(`127.0.0.1	localhost
+::1	localhost ip6-localhost
+# 10.0.0.9 db
+172.17.0.2	db db.local # the database
+172.17.0.3	DB-replica
+invalid	db
+`)
+	for _, c := range []struct {
+		host     string
+		expected []string
+	}{
+		{"localhost", []string{"127.0.0.1", "::1"}},
+		{"db", []string{"172.17.0.2"}},
+		{"db.local.", []string{"172.17.0.2"}},
+		{"db-replica", []string{"172.17.0.3"}},
+		{"web", nil},
+	} {
+		if addrs := hostsLookup(content, c.host); !reflect.DeepEqual(addrs, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.host, c.expected, addrs)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// resolveInContainer is not supported on this platform.
+func (daemon *Daemon) resolveInContainer(c *container.Container, host string) (*types.ContainerResolveResponse, error) {
+	return nil, fmt.Errorf("resolving names in containers is not supported on this platform")
+}
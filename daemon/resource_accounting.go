@@ -0,0 +1,122 @@
+package daemon
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// resourceAccounting keeps the last sample of the resource usage of the
+// running containers. The cgroups of a container are removed before the
+// daemon is notified of its exit, so the usage recorded in the state of the
+// container when it exits is the one of the last sample. The samples are
+// taken from the stats collected for the containers, before the daemon
+// signals them, and periodically with --resource-accounting-interval.
+type resourceAccounting struct {
+	sync.Mutex
+	samples map[string]*types.ContainerResourceAccounting
+}
+
+// add records a sample of the resource usage of a container. The peak memory
+// usage is the highest of the samples.
+func (r *resourceAccounting) add(id string, sample *types.ContainerResourceAccounting) {
+	r.Lock()
+	defer r.Unlock()
+	if r.samples == nil {
+		r.samples = make(map[string]*types.ContainerResourceAccounting)
+	}
+	if last, ok := r.samples[id]; ok && last.PeakMemoryBytes > sample.PeakMemoryBytes {
+		sample.PeakMemoryBytes = last.PeakMemoryBytes
+	}
+	r.samples[id] = sample
+}
+
+// take returns the last sample of the resource usage of a container and
+// forgets it, or nil if the container was not sampled.
+func (r *resourceAccounting) take(id string) *types.ContainerResourceAccounting {
+	r.Lock()
+	defer r.Unlock()
+	sample := r.samples[id]
+	delete(r.samples, id)
+	return sample
+}
+
+// resourceAccountingFromStats returns the cumulative resource usage of a
+// container in a stats sample.
+func resourceAccountingFromStats(c *container.Container, s *types.StatsJSON) *types.ContainerResourceAccounting {
+	summary := summarizeStats(c, s)
+	sample := &types.ContainerResourceAccounting{
+		PeakMemoryBytes: s.MemoryStats.MaxUsage,
+		CPUTimeNanos:    s.CPUStats.CPUUsage.TotalUsage,
+		BlkioReadBytes:  summary.BlockRead,
+		BlkioWriteBytes: summary.BlockWrite,
+		SampledAt:       s.Read.Format(time.RFC3339Nano),
+	}
+	if runtime.GOOS == "windows" {
+		sample.PeakMemoryBytes = s.MemoryStats.CommitPeak
+		// The CPU time is counted in 100's of nanoseconds on Windows.
+		sample.CPUTimeNanos = s.CPUStats.CPUUsage.TotalUsage * 100
+	}
+	return sample
+}
+
+// sampleResourceUsage records a sample of the resource usage of a running
+// container. The last sample of the stats collector is used when the
+// container is already being collected.
+func (daemon *Daemon) sampleResourceUsage(c *container.Container) {
+	stats, exists := daemon.statsCollector.lastStats(c)
+	if !exists {
+		var err error
+		if stats, err = daemon.stats(c); err != nil {
+			if _, ok := err.(errNotRunning); !ok {
+				logrus.Debugf("sampling resource usage of %s: %v", c.ID, err)
+			}
+			return
+		}
+	}
+	if stats == nil {
+		return
+	}
+	daemon.resourceAccounting.add(c.ID, resourceAccountingFromStats(c, stats))
+}
+
+// recordResourceUsage records the last sample of the resource usage of a
+// container in its state, when it exits. It must be called with the
+// container locked.
+func (daemon *Daemon) recordResourceUsage(c *container.Container) {
+	if sample := daemon.resourceAccounting.take(c.ID); sample != nil {
+		c.ResourceAccounting = sample
+	}
+}
+
+// resetResourceUsage forgets the resource usage of the previous run of a
+// container, when it starts. It must be called with the container locked.
+func (daemon *Daemon) resetResourceUsage(c *container.Container) {
+	daemon.resourceAccounting.take(c.ID)
+	c.ResourceAccounting = nil
+}
+
+// startResourceAccounting starts sampling the resource usage of the running
+// containers periodically, if --resource-accounting-interval is set.
+func (daemon *Daemon) startResourceAccounting() {
+	interval := daemon.configStore.ResourceAccountingInterval
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		for range time.Tick(time.Duration(interval) * time.Second) {
+			if daemon.IsShuttingDown() {
+				return
+			}
+			daemon.containers.ApplyAll(func(c *container.Container) {
+				if c.IsRunning() {
+					daemon.sampleResourceUsage(c)
+				}
+			})
+		}
+	}()
+}
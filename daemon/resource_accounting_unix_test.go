@@ -0,0 +1,80 @@
+// +build !windows
+
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+func TestResourceAccountingFromStats(t *testing.T) {
+	c := container.NewBaseContainer("abc123", "")
+	read := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	s := &types.StatsJSON{
+		Stats: types.Stats{
+			Read: read,
+			CPUStats: types.CPUStats{
+				CPUUsage: types.CPUUsage{TotalUsage: 1500000000},
+			},
+			MemoryStats: types.MemoryStats{Usage: 256, MaxUsage: 1024},
+			BlkioStats: types.BlkioStats{
+				IoServiceBytesRecursive: []types.BlkioStatEntry{
+					{Op: "Read", Value: 10},
+					{Op: "Write", Value: 20},
+					{Op: "Read", Value: 5},
+				},
+			},
+		},
+	}
+
+	sample := resourceAccountingFromStats(c, s)
+	expected := types.ContainerResourceAccounting{
+		PeakMemoryBytes: 1024,
+		CPUTimeNanos:    1500000000,
+		BlkioReadBytes:  15,
+		BlkioWriteBytes: 20,
+		SampledAt:       "2016-10-01T12:00:00Z",
+	}
+	if *sample != expected {
+		t.Fatalf("expected %+v, got %+v", expected, *sample)
+	}
+}
+
+func TestResourceAccountingKeepsPeakMemory(t *testing.T) {
+	var r resourceAccounting
+
+	if sample := r.take("abc123"); sample != nil {
+		t.Fatalf("expected no sample, got %+v", sample)
+	}
+
+	r.add("abc123", &types.ContainerResourceAccounting{PeakMemoryBytes: 2048, CPUTimeNanos: 100})
+	r.add("abc123", &types.ContainerResourceAccounting{PeakMemoryBytes: 1024, CPUTimeNanos: 200})
+	sample := r.take("abc123")
+	if sample == nil || sample.PeakMemoryBytes != 2048 || sample.CPUTimeNanos != 200 {
+		t.Fatalf("expected the peak memory of the first sample and the CPU time of the last one, got %+v", sample)
+	}
+
+	if sample := r.take("abc123"); sample != nil {
+		t.Fatalf("expected the sample to be forgotten, got %+v", sample)
+	}
+}
+
+func TestResetResourceUsage(t *testing.T) {
+	daemon := &Daemon{}
+	c := &container.Container{CommonContainer: container.CommonContainer{ID: "abc123", State: container.NewState()}}
+	c.ResourceAccounting = &types.ContainerResourceAccounting{PeakMemoryBytes: 2048}
+	daemon.resourceAccounting.add(c.ID, &types.ContainerResourceAccounting{PeakMemoryBytes: 1024})
+
+	daemon.resetResourceUsage(c)
+	if c.ResourceAccounting != nil {
+		t.Fatalf("expected the usage of the previous run to be reset, got %+v", c.ResourceAccounting)
+	}
+	daemon.recordResourceUsage(c)
+	if c.ResourceAccounting != nil {
+		t.Fatalf("expected the samples of the previous run to be forgotten, got %+v", c.ResourceAccounting)
+	}
+}
@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/rootless"
+)
+
+// rootlessPorts forwards the published ports of the containers from the
+// host in rootless mode, where the daemon runs in the network namespace of
+// RootlessKit.
+type rootlessPorts struct {
+	sync.Mutex
+	client *rootless.PortClient
+	ids    map[string][]int // IDs of the forwarded ports by container
+}
+
+// newRootlessPorts returns the forwarder of the published ports, or nil if
+// the daemon does not run in RootlessKit.
+func newRootlessPorts() (*rootlessPorts, error) {
+	if !rootless.RunningWithRootlessKit() {
+		return nil, nil
+	}
+	client, err := rootless.NewPortClient()
+	if err != nil {
+		return nil, err
+	}
+	return &rootlessPorts{client: client, ids: make(map[string][]int)}, nil
+}
+
+// expose forwards the published ports of the container from the host. The
+// ports which cannot be forwarded are logged.
+func (p *rootlessPorts) expose(c *container.Container) {
+	if p == nil || c.NetworkSettings == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+
+	for port, bindings := range c.NetworkSettings.Ports {
+		for _, b := range bindings {
+			hostPort, err := strconv.Atoi(b.HostPort)
+			if err != nil {
+				continue
+			}
+			spec := rootless.PortSpec{Proto: port.Proto(), ParentPort: hostPort, ChildPort: hostPort}
+			if ip := net.ParseIP(b.HostIP); ip != nil && !ip.IsUnspecified() {
+				spec.ParentIP = b.HostIP
+			}
+			id, err := p.client.AddPort(spec)
+			if err != nil {
+				logrus.Warnf("Failed to expose port %s of container %s on the host: %v", port, c.ID, err)
+				continue
+			}
+			p.ids[c.ID] = append(p.ids[c.ID], id)
+		}
+	}
+}
+
+// unexpose stops the forwarding of the published ports of the container.
+func (p *rootlessPorts) unexpose(c *container.Container) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+
+	for _, id := range p.ids[c.ID] {
+		if err := p.client.RemovePort(id); err != nil {
+			logrus.Warnf("Failed to remove the exposed port %d of container %s: %v", id, c.ID, err)
+		}
+	}
+	delete(p.ids, c.ID)
+}
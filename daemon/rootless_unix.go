@@ -0,0 +1,52 @@
+// +build linux freebsd
+
+package daemon
+
+import (
+	"fmt"
+	"reflect"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/homedir"
+	rsystem "github.com/opencontainers/runc/libcontainer/system"
+)
+
+// verifyRootlessSettings validates the configuration of a daemon in rootless
+// mode. The daemon must run in the user namespace created by RootlessKit, or
+// a similar tool, where the unprivileged user is mapped to root.
+func verifyRootlessSettings(config *Config) error {
+	if !config.Rootless {
+		return nil
+	}
+	if !rsystem.RunningInUserNS() {
+		return fmt.Errorf("--rootless requires the daemon to run in a user namespace, start it with dockerd-rootless.sh")
+	}
+	if config.RemappedRoot != "" {
+		return fmt.Errorf("--rootless and --userns-remap are mutually exclusive")
+	}
+	if config.EnableSelinuxSupport {
+		return fmt.Errorf("SELinux is not supported in rootless mode")
+	}
+	if _, err := homedir.GetRuntimeDir(); err != nil {
+		return fmt.Errorf("rootless mode requires XDG_RUNTIME_DIR to be set: %v", err)
+	}
+	return nil
+}
+
+// discardRootlessResources discards the resource limits of a container in
+// rootless mode, where the daemon cannot set up the cgroups of the
+// containers. The devices and the ulimits, which are not set with cgroups,
+// are kept.
+func discardRootlessResources(resources *containertypes.Resources) []string {
+	kept := containertypes.Resources{
+		Devices:        resources.Devices,
+		DeviceRequests: resources.DeviceRequests,
+		Ulimits:        resources.Ulimits,
+		DiskQuota:      resources.DiskQuota,
+	}
+	if reflect.DeepEqual(*resources, kept) {
+		return nil
+	}
+	*resources = kept
+	return []string{"Resource limits are not supported in rootless mode. Limitations discarded."}
+}
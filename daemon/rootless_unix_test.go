@@ -0,0 +1,31 @@
+// +build linux freebsd
+
+package daemon
+
+import (
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	units "github.com/docker/go-units"
+)
+
+func TestDiscardRootlessResources(t *testing.T) {
+	ulimits := []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}}
+	devices := []containertypes.DeviceMapping{{PathOnHost: "/dev/fuse", PathInContainer: "/dev/fuse", CgroupPermissions: "rwm"}}
+
+	resources := containertypes.Resources{Ulimits: ulimits, Devices: devices}
+	if warnings := discardRootlessResources(&resources); len(warnings) != 0 {
+		t.Fatalf("unexpected warnings without limits: %v", warnings)
+	}
+
+	resources = containertypes.Resources{Memory: 1 << 20, CPUShares: 512, PidsLimit: 10, Ulimits: ulimits, Devices: devices}
+	if warnings := discardRootlessResources(&resources); len(warnings) != 1 {
+		t.Fatalf("expected a warning for the discarded limits, got %v", warnings)
+	}
+	if resources.Memory != 0 || resources.CPUShares != 0 || resources.PidsLimit != 0 {
+		t.Fatalf("expected the limits to be discarded, got %+v", resources)
+	}
+	if len(resources.Ulimits) != 1 || len(resources.Devices) != 1 {
+		t.Fatalf("expected the ulimits and the devices to be kept, got %+v", resources)
+	}
+}
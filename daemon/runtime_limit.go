@@ -0,0 +1,84 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+)
+
+// runtimeLimiter holds the timers stopping the containers which have a
+// maximum runtime, by ID.
+type runtimeLimiter struct {
+	sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// set arms the timer of the container id to call f after d, replacing its
+// previous timer, if any.
+func (l *runtimeLimiter) set(id string, d time.Duration, f func()) {
+	l.Lock()
+	defer l.Unlock()
+	if l.timers == nil {
+		l.timers = make(map[string]*time.Timer)
+	}
+	if t, ok := l.timers[id]; ok {
+		t.Stop()
+	}
+	l.timers[id] = time.AfterFunc(d, f)
+}
+
+// clear stops the timer of the container id, if any.
+func (l *runtimeLimiter) clear(id string) {
+	l.Lock()
+	defer l.Unlock()
+	if t, ok := l.timers[id]; ok {
+		t.Stop()
+		delete(l.timers, id)
+	}
+}
+
+// runtimeLeft returns the time a container started at startedAt can still
+// run at now, given its maximum runtime.
+func runtimeLeft(startedAt time.Time, maxRuntime time.Duration, now time.Time) time.Duration {
+	left := startedAt.Add(maxRuntime).Sub(now)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// initRuntimeLimit is called from monitor.go, with the container locked,
+// when the container starts or is restored. It arms the timer stopping the
+// container once it has run for its maximum runtime. The runtime is counted
+// from the start of the container, so that it is enforced across the
+// restarts of the daemon.
+func (daemon *Daemon) initRuntimeLimit(c *container.Container) {
+	maxRuntime := c.HostConfig.MaxRuntime
+	if maxRuntime <= 0 {
+		return
+	}
+	startedAt := c.StartedAt
+	daemon.runtimeLimits.set(c.ID, runtimeLeft(startedAt, maxRuntime, time.Now().UTC()), func() {
+		daemon.enforceRuntimeLimit(c, startedAt)
+	})
+}
+
+// enforceRuntimeLimit stops the container, then kills it if it does not
+// exit within its stop timeout, unless it stopped or restarted since it
+// started at startedAt. Like with docker stop, the container is not
+// restarted by its restart policy.
+func (daemon *Daemon) enforceRuntimeLimit(c *container.Container, startedAt time.Time) {
+	c.Lock()
+	running := c.Running && !c.Restarting && c.StartedAt.Equal(startedAt)
+	c.Unlock()
+	if !running {
+		return
+	}
+
+	logrus.Infof("Container %s has run for its maximum runtime of %s, stopping it", c.ID, c.HostConfig.MaxRuntime)
+	if err := daemon.containerStop(c, c.StopTimeout()); err != nil {
+		logrus.Errorf("Error stopping the container %s after its maximum runtime: %v", c.ID, err)
+	}
+}
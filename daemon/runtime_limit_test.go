@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuntimeLeft(t *testing.T) {
+	startedAt := time.Date(2016, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		now      time.Time
+		expected time.Duration
+	}{
+		{startedAt, time.Hour},
+		{startedAt.Add(20 * time.Minute), 40 * time.Minute},
+		// The daemon was restarted after the deadline.
+		{startedAt.Add(2 * time.Hour), 0},
+	}
+	for _, c := range cases {
+		if left := runtimeLeft(startedAt, time.Hour, c.now); left != c.expected {
+			t.Fatalf("expected %s left at %s, got %s", c.expected, c.now, left)
+		}
+	}
+}
+
+func TestRuntimeLimiter(t *testing.T) {
+	var l runtimeLimiter
+	fired := make(chan string, 2)
+
+	l.set("replaced", time.Millisecond, func() { fired <- "first" })
+	l.set("replaced", 10*time.Millisecond, func() { fired <- "second" })
+	l.set("cleared", time.Millisecond, func() { fired <- "cleared" })
+	l.clear("cleared")
+	l.clear("unknown")
+
+	select {
+	case name := <-fired:
+		if name != "second" {
+			t.Fatalf("expected the replacing timer to fire, got %s", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the timer to fire")
+	}
+	select {
+	case name := <-fired:
+		t.Fatalf("expected a single timer to fire, got %s", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/registry"
 )
 
 var acceptedSearchFilterTags = map[string]bool{
@@ -34,12 +35,16 @@ func (daemon *Daemon) SearchRegistryForImages(ctx context.Context, filtersArgs s
 
 	var isAutomated, isOfficial bool
 	var hasStarFilter = 0
+	// the filters are passed to the index as well, so that an index
+	// supporting them filters the results before they are limited
+	var indexFilters registry.SearchFilters
 	if searchFilters.Include("is-automated") {
 		if searchFilters.UniqueExactMatch("is-automated", "true") {
 			isAutomated = true
 		} else if !searchFilters.UniqueExactMatch("is-automated", "false") {
 			return nil, fmt.Errorf("Invalid filter 'is-automated=%s'", searchFilters.Get("is-automated"))
 		}
+		indexFilters.IsAutomated = &isAutomated
 	}
 	if searchFilters.Include("is-official") {
 		if searchFilters.UniqueExactMatch("is-official", "true") {
@@ -47,6 +52,7 @@ func (daemon *Daemon) SearchRegistryForImages(ctx context.Context, filtersArgs s
 		} else if !searchFilters.UniqueExactMatch("is-official", "false") {
 			return nil, fmt.Errorf("Invalid filter 'is-official=%s'", searchFilters.Get("is-official"))
 		}
+		indexFilters.IsOfficial = &isOfficial
 	}
 	if searchFilters.Include("stars") {
 		hasStars := searchFilters.Get("stars")
@@ -61,7 +67,9 @@ func (daemon *Daemon) SearchRegistryForImages(ctx context.Context, filtersArgs s
 		}
 	}
 
-	unfilteredResult, err := daemon.RegistryService.Search(ctx, term, limit, authConfig, dockerversion.DockerUserAgent(ctx), headers)
+	indexFilters.Stars = hasStarFilter
+
+	unfilteredResult, err := daemon.RegistryService.Search(ctx, term, limit, indexFilters, authConfig, dockerversion.DockerUserAgent(ctx), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,7 @@ func setSeccomp(daemon *Daemon, rs *specs.Spec, c *container.Container) error {
 		logrus.Warn("Seccomp is not enabled in your kernel, running container without default profile.")
 		c.SeccompProfile = "unconfined"
 	}
-	if c.SeccompProfile == "unconfined" {
+	if c.SeccompProfile == "unconfined" || (c.SeccompProfile == "" && daemon.configStore.SeccompProfile == "unconfined") {
 		return nil
 	}
 	if c.SeccompProfile != "" {
@@ -36,6 +36,11 @@ func setSeccomp(daemon *Daemon, rs *specs.Spec, c *container.Container) error {
 		if err != nil {
 			return err
 		}
+	} else if daemon.seccompProfile != nil {
+		profile, err = seccomp.LoadProfile(string(daemon.seccompProfile), rs)
+		if err != nil {
+			return err
+		}
 	} else {
 		profile, err = seccomp.GetDefaultProfile(rs)
 		if err != nil {
@@ -18,6 +18,10 @@ import (
 	"github.com/docker/docker/runconfig"
 )
 
+// ttyScrollbackSize is the number of bytes of the output of a TTY kept by
+// the daemon, to be replayed when a client attaches.
+const ttyScrollbackSize = 64 * 1024
+
 // ContainerStart starts a container.
 func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.HostConfig, validateHostname bool, checkpoint string) error {
 	container, err := daemon.GetContainer(name)
@@ -78,6 +82,10 @@ func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.Hos
 		return err
 	}
 
+	if err := daemon.checkMaintenanceStart(container); err != nil {
+		return err
+	}
+
 	return daemon.containerStart(container, checkpoint)
 }
 
@@ -102,6 +110,10 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		return fmt.Errorf("Container is marked for removal and cannot be started.")
 	}
 
+	if container.RestoreInProgress {
+		return fmt.Errorf("Container is being restored and cannot be started.")
+	}
+
 	// if we encounter an error during start we need to ensure that any other
 	// setup has been cleaned up properly
 	defer func() {
@@ -141,6 +153,11 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		return err
 	}
 
+	// Keep the recent output of a TTY to replay it to re-attaching clients.
+	if container.Config.Tty {
+		container.StreamConfig.EnableScrollback(ttyScrollbackSize)
+	}
+
 	createOptions := []libcontainerd.CreateOption{libcontainerd.WithRestartManager(container.RestartManager(true))}
 	copts, err := daemon.getLibcontainerdCreateOptions(container)
 	if err != nil {
@@ -186,6 +203,8 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 func (daemon *Daemon) Cleanup(container *container.Container) {
 	daemon.releaseNetwork(container)
 
+	daemon.releaseDevices(container)
+
 	container.UnmountIpcMounts(detachMounted)
 
 	if err := daemon.conditionalUnmountOnCleanup(container); err != nil {
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"runtime"
+	"strings"
 	"time"
 
 	"golang.org/x/net/context"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/versions"
@@ -29,6 +31,13 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 
 	// If the container is not running and requires no stream, return an empty stats.
 	if !container.IsRunning() && !config.Stream {
+		if config.Summary {
+			return json.NewEncoder(config.OutStream).Encode(&types.StatsSummary{
+				ID:     container.ID,
+				Name:   container.Name,
+				OSType: runtime.GOOS,
+			})
+		}
 		return json.NewEncoder(config.OutStream).Encode(&types.Stats{})
 	}
 
@@ -66,7 +75,9 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 
 			var statsJSON interface{}
 			statsJSONPost120 := getStatJSON(v)
-			if versions.LessThan(apiVersion, "1.21") {
+			if config.Summary {
+				statsJSON = summarizeStats(container, statsJSONPost120)
+			} else if versions.LessThan(apiVersion, "1.21") {
 				if runtime.GOOS == "windows" {
 					return errors.New("API versions pre v1.21 do not support stats on Windows")
 				}
@@ -150,3 +161,80 @@ func (daemon *Daemon) GetContainerStats(container *container.Container) (*types.
 
 	return stats, nil
 }
+
+// getResourceUsage returns the cumulative I/O counters of a running container.
+// The last sample of the stats collector is used when the container is already
+// being collected, otherwise a new sample is taken.
+func (daemon *Daemon) getResourceUsage(c *container.Container) *types.ContainerResourceUsage {
+	stats, exists := daemon.statsCollector.lastStats(c)
+	if !exists {
+		var err error
+		if stats, err = daemon.GetContainerStats(c); err != nil {
+			if _, ok := err.(errNotRunning); !ok {
+				logrus.Debugf("collecting resource usage for %s: %v", c.ID, err)
+			}
+			return nil
+		}
+	}
+
+	summary := summarizeStats(c, stats)
+	return &types.ContainerResourceUsage{
+		BlkioReadBytes:  summary.BlockRead,
+		BlkioWriteBytes: summary.BlockWrite,
+		NetworkRxBytes:  summary.NetworkRx,
+		NetworkTxBytes:  summary.NetworkTx,
+	}
+}
+
+// summarizeStats computes a StatsSummary from a stats sample and the
+// previous sample recorded in its PreCPUStats and PreRead fields.
+func summarizeStats(c *container.Container, s *types.StatsJSON) *types.StatsSummary {
+	summary := &types.StatsSummary{
+		ID:     c.ID,
+		Name:   c.Name,
+		OSType: runtime.GOOS,
+		Read:   s.Read,
+	}
+
+	for _, n := range s.Networks {
+		summary.NetworkRx += n.RxBytes
+		summary.NetworkTx += n.TxBytes
+	}
+
+	if runtime.GOOS == "windows" {
+		// Number of 100ns intervals that were available between the samples
+		possIntervals := uint64(s.Read.Sub(s.PreRead).Nanoseconds()) / 100 * uint64(s.NumProcs)
+		intervalsUsed := s.CPUStats.CPUUsage.TotalUsage - s.PreCPUStats.CPUUsage.TotalUsage
+		if possIntervals > 0 {
+			summary.CPUPercentage = float64(intervalsUsed) / float64(possIntervals) * 100.0
+		}
+		summary.MemoryUsage = s.MemoryStats.PrivateWorkingSet
+		summary.BlockRead = s.StorageStats.ReadSizeBytes
+		summary.BlockWrite = s.StorageStats.WriteSizeBytes
+		return summary
+	}
+
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	if systemDelta > 0.0 && cpuDelta > 0.0 {
+		summary.CPUPercentage = (cpuDelta / systemDelta) * float64(len(s.CPUStats.CPUUsage.PercpuUsage)) * 100.0
+	}
+
+	summary.MemoryUsage = s.MemoryStats.Usage
+	summary.MemoryLimit = s.MemoryStats.Limit
+	if s.MemoryStats.Limit != 0 {
+		summary.MemoryPercentage = float64(s.MemoryStats.Usage) / float64(s.MemoryStats.Limit) * 100.0
+	}
+
+	for _, entry := range s.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			summary.BlockRead += entry.Value
+		case "write":
+			summary.BlockWrite += entry.Value
+		}
+	}
+	summary.PidsCurrent = s.PidsStats.Current
+
+	return summary
+}
@@ -27,7 +27,11 @@ func (daemon *Daemon) newStatsCollector(interval time.Duration) *statsCollector
 		interval:   interval,
 		supervisor: daemon,
 		publishers: make(map[*container.Container]*pubsub.Publisher),
+		last:       make(map[*container.Container]*types.StatsJSON),
 		bufReader:  bufio.NewReaderSize(nil, 128),
+		sampled: func(c *container.Container, stats *types.StatsJSON) {
+			daemon.resourceAccounting.add(c.ID, resourceAccountingFromStats(c, stats))
+		},
 	}
 	platformNewStatsCollector(s)
 	go s.run()
@@ -40,7 +44,11 @@ type statsCollector struct {
 	supervisor statsSupervisor
 	interval   time.Duration
 	publishers map[*container.Container]*pubsub.Publisher
+	last       map[*container.Container]*types.StatsJSON
 	bufReader  *bufio.Reader
+	// sampled is called with each sample collected, which is kept as the
+	// last sample of the resource usage of the container.
+	sampled func(*container.Container, *types.StatsJSON)
 
 	// The following fields are not set on Windows currently.
 	clockTicksPerSecond uint64
@@ -69,6 +77,7 @@ func (s *statsCollector) stopCollection(c *container.Container) {
 		publisher.Close()
 		delete(s.publishers, c)
 	}
+	delete(s.last, c)
 	s.m.Unlock()
 }
 
@@ -80,11 +89,21 @@ func (s *statsCollector) unsubscribe(c *container.Container, ch chan interface{}
 		publisher.Evict(ch)
 		if publisher.Len() == 0 {
 			delete(s.publishers, c)
+			delete(s.last, c)
 		}
 	}
 	s.m.Unlock()
 }
 
+// lastStats returns the most recent sample published for the container, if
+// the container is currently being collected.
+func (s *statsCollector) lastStats(c *container.Container) (*types.StatsJSON, bool) {
+	s.m.Lock()
+	stats, exists := s.last[c]
+	s.m.Unlock()
+	return stats, exists
+}
+
 func (s *statsCollector) run() {
 	type publishersPair struct {
 		container *container.Container
@@ -126,6 +145,15 @@ func (s *statsCollector) run() {
 			// FIXME: move to containerd on Linux (not Windows)
 			stats.CPUStats.SystemUsage = systemUsage
 
+			s.m.Lock()
+			if _, exists := s.publishers[pair.container]; exists {
+				s.last[pair.container] = stats
+			}
+			s.m.Unlock()
+			if s.sampled != nil {
+				s.sampled(pair.container, stats)
+			}
+
 			pair.publisher.Publish(*stats)
 		}
 	}
@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/container"
 	"time"
 )
@@ -32,3 +33,9 @@ func (s *statsCollector) stopCollection(c *container.Container) {
 // unsubscribe removes a specific subscriber from receiving updates for a container's stats.
 func (s *statsCollector) unsubscribe(c *container.Container, ch chan interface{}) {
 }
+
+// lastStats returns the most recent sample published for the container, if
+// the container is currently being collected.
+func (s *statsCollector) lastStats(c *container.Container) (*types.StatsJSON, bool) {
+	return nil, false
+}
@@ -0,0 +1,64 @@
+// +build !windows
+
+package daemon
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+func TestSummarizeStats(t *testing.T) {
+	c := container.NewBaseContainer("abc123", "")
+	c.Name = "/web"
+
+	s := &types.StatsJSON{
+		Stats: types.Stats{
+			PreCPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{TotalUsage: 100},
+				SystemUsage: 1000,
+			},
+			CPUStats: types.CPUStats{
+				CPUUsage: types.CPUUsage{
+					TotalUsage:  200,
+					PercpuUsage: []uint64{100, 100},
+				},
+				SystemUsage: 2000,
+			},
+			MemoryStats: types.MemoryStats{Usage: 256, Limit: 1024},
+			BlkioStats: types.BlkioStats{
+				IoServiceBytesRecursive: []types.BlkioStatEntry{
+					{Op: "Read", Value: 10},
+					{Op: "Write", Value: 20},
+					{Op: "Read", Value: 5},
+				},
+			},
+			PidsStats: types.PidsStats{Current: 3},
+		},
+		Networks: map[string]types.NetworkStats{
+			"eth0": {RxBytes: 1, TxBytes: 2},
+			"eth1": {RxBytes: 3, TxBytes: 4},
+		},
+	}
+
+	summary := summarizeStats(c, s)
+	if summary.ID != "abc123" || summary.Name != "/web" {
+		t.Fatalf("unexpected container identity in summary: %+v", summary)
+	}
+	if summary.CPUPercentage != 20.0 {
+		t.Fatalf("expected 20%% CPU, got %v", summary.CPUPercentage)
+	}
+	if summary.MemoryPercentage != 25.0 {
+		t.Fatalf("expected 25%% memory, got %v", summary.MemoryPercentage)
+	}
+	if summary.BlockRead != 15 || summary.BlockWrite != 20 {
+		t.Fatalf("expected 15/20 block I/O, got %d/%d", summary.BlockRead, summary.BlockWrite)
+	}
+	if summary.NetworkRx != 4 || summary.NetworkTx != 6 {
+		t.Fatalf("expected 4/6 network I/O, got %d/%d", summary.NetworkRx, summary.NetworkTx)
+	}
+	if summary.PidsCurrent != 3 {
+		t.Fatalf("expected 3 pids, got %d", summary.PidsCurrent)
+	}
+}
@@ -37,10 +37,19 @@ func (daemon *Daemon) ContainerStop(name string, seconds int) error {
 // for the initial signal forever. If the container is not running Stop returns
 // immediately.
 func (daemon *Daemon) containerStop(container *container.Container, seconds int) error {
+	_, err := daemon.containerStopOrKill(container, seconds)
+	return err
+}
+
+// containerStopOrKill is containerStop, but also reports whether the
+// container had to be killed because it did not exit in time.
+func (daemon *Daemon) containerStopOrKill(container *container.Container, seconds int) (bool, error) {
 	if !container.IsRunning() {
-		return nil
+		return false, nil
 	}
 
+	var killed bool
+
 	daemon.stopHealthchecks(container)
 
 	stopSignal := container.StopSignal()
@@ -59,8 +68,9 @@ func (daemon *Daemon) containerStop(container *container.Container, seconds int)
 		if _, err := container.WaitStop(2 * time.Second); err != nil {
 			logrus.Infof("Container failed to stop after sending signal %d to the process, force killing", stopSignal)
 			if err := daemon.killPossiblyDeadProcess(container, 9); err != nil {
-				return err
+				return false, err
 			}
+			killed = true
 		}
 	}
 
@@ -68,6 +78,7 @@ func (daemon *Daemon) containerStop(container *container.Container, seconds int)
 	if _, err := container.WaitStop(time.Duration(seconds) * time.Second); err != nil {
 		logrus.Infof("Container %v failed to exit within %d seconds of signal %d - using the force", container.ID, seconds, stopSignal)
 		// 3. If it doesn't, then send SIGKILL
+		killed = true
 		if err := daemon.Kill(container); err != nil {
 			container.WaitStop(-1 * time.Second)
 			logrus.Warn(err) // Don't return error because we only care that container is stopped, not what function stopped it
@@ -75,5 +86,5 @@ func (daemon *Daemon) containerStop(container *container.Container, seconds int)
 	}
 
 	daemon.LogContainerEvent(container, "stop")
-	return nil
+	return killed, nil
 }
@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/libtrust"
+)
+
+// TrustKeyExport returns the trust key of the daemon in PEM format, encrypted
+// with the passphrase.
+func (daemon *Daemon) TrustKeyExport(passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.NewBadRequestError(fmt.Errorf("a passphrase is required to export the trust key"))
+	}
+
+	block, err := daemon.trustKey.PEMBlock()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte(passphrase), x509.PEMCipherAES256)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range block.Headers {
+		encrypted.Headers[k] = v
+	}
+	return pem.EncodeToMemory(encrypted), nil
+}
+
+// TrustKeyImport replaces the trust key of the daemon by the key in PEM
+// format, which is decrypted with the passphrase if it is encrypted. The key
+// is saved at the path of the trust key, and is used, with the ID of the
+// daemon derived from it, once the daemon is restarted.
+func (daemon *Daemon) TrustKeyImport(key []byte, passphrase string) error {
+	trustKey, err := decodeTrustKey(key, passphrase)
+	if err != nil {
+		return errors.NewBadRequestError(err)
+	}
+	return api.SaveTrustKey(daemon.configStore.TrustKeyPath, trustKey)
+}
+
+// decodeTrustKey decodes a libtrust key in PEM format, which is decrypted
+// with the passphrase if it is encrypted.
+func decodeTrustKey(key []byte, passphrase string) (libtrust.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found")
+	}
+	if x509.IsEncryptedPEMBlock(block) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("the key is encrypted, a passphrase is required")
+		}
+		der, err := x509.DecryptPEMBlock(block, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt the key: %v", err)
+		}
+		headers := make(map[string]string)
+		for k, v := range block.Headers {
+			if k != "Proc-Type" && k != "DEK-Info" {
+				headers[k] = v
+			}
+		}
+		block = &pem.Block{Type: block.Type, Headers: headers, Bytes: der}
+	}
+	return libtrust.UnmarshalPrivateKeyPEM(pem.EncodeToMemory(block))
+}
@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/libtrust"
+)
+
+func TestTrustKeyExportImport(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-trust-key-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	trustKey, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	trustKeyPath := filepath.Join(tmp, "key.json")
+	daemon := &Daemon{
+		trustKey:    trustKey,
+		configStore: &Config{CommonConfig: CommonConfig{TrustKeyPath: trustKeyPath}},
+	}
+
+	if _, err := daemon.TrustKeyExport(""); err == nil {
+		t.Fatal("expected an error exporting the key without a passphrase")
+	}
+	exported, err := daemon.TrustKeyExport("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(exported), "ENCRYPTED") {
+		t.Fatalf("expected an encrypted key, got:\n%s", exported)
+	}
+
+	for _, passphrase := range []string{"", "wrong"} {
+		if err := daemon.TrustKeyImport(exported, passphrase); err == nil {
+			t.Fatalf("expected an error importing the key with the passphrase %q", passphrase)
+		}
+	}
+	if err := daemon.TrustKeyImport(exported, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	imported, err := libtrust.LoadKeyFile(trustKeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.KeyID() != trustKey.KeyID() {
+		t.Fatalf("expected the key %s to be imported, got %s", trustKey.KeyID(), imported.KeyID())
+	}
+}
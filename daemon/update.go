@@ -1,14 +1,22 @@
 package daemon
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/runconfig"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/docker/libnetwork/etchosts"
 )
 
 // ContainerUpdate updates configuration of the container
-func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostConfig, validateHostname bool) (types.ContainerUpdateResponse, error) {
+func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostConfig, forceOomKillDisable, validateHostname bool) (types.ContainerUpdateResponse, error) {
 	var warnings []string
 
 	warnings, err := daemon.verifyContainerSettings(hostConfig, nil, true, validateHostname)
@@ -16,6 +24,10 @@ func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostCon
 		return types.ContainerUpdateResponse{Warnings: warnings}, err
 	}
 
+	if err := daemon.verifyOomKillDisableUpdate(name, hostConfig, forceOomKillDisable); err != nil {
+		return types.ContainerUpdateResponse{Warnings: warnings}, err
+	}
+
 	if err := daemon.update(name, hostConfig); err != nil {
 		return types.ContainerUpdateResponse{Warnings: warnings}, err
 	}
@@ -23,6 +35,139 @@ func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostCon
 	return types.ContainerUpdateResponse{Warnings: warnings}, nil
 }
 
+// verifyOomKillDisableUpdate checks, as on create, that the OOM killer is
+// only disabled for a container with a memory limit, either set by the
+// update or already set, unless it is forced.
+func (daemon *Daemon) verifyOomKillDisableUpdate(name string, hostConfig *container.HostConfig, force bool) error {
+	if hostConfig == nil || hostConfig.OomKillDisable == nil || !*hostConfig.OomKillDisable || hostConfig.Memory != 0 || force {
+		return nil
+	}
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	updated := *hostConfig
+	c.Lock()
+	updated.Memory = c.HostConfig.Memory
+	c.Unlock()
+	return verifyOomKillDisable(&updated, false)
+}
+
+// ContainerUpdateLabels adds and removes labels of the container. The change
+// is visible to ps/inspect filters immediately and persisted to disk.
+func (daemon *Daemon) ContainerUpdateLabels(name string, add map[string]string, remove []string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if container.RemovalInProgress || container.Dead {
+		return errCannotUpdate(container.ID, fmt.Errorf("Container is marked for removal and cannot be \"update\"."))
+	}
+
+	for _, k := range remove {
+		if _, ok := add[k]; ok {
+			return errCannotUpdate(container.ID, fmt.Errorf("label %q cannot be both added and removed", k))
+		}
+	}
+
+	if err := container.UpdateLabels(add, remove); err != nil {
+		return errCannotUpdate(container.ID, err)
+	}
+
+	attributes := map[string]string{}
+	if len(remove) > 0 {
+		attributes["labelsRemoved"] = strings.Join(remove, ",")
+	}
+	daemon.LogContainerEventWithAttributes(container, "update", attributes)
+
+	return nil
+}
+
+// ContainerUpdateExtraHosts adds and removes extra hosts entries of the
+// container. The change is persisted to disk, and applied to the hosts file of
+// the container if it is running.
+func (daemon *Daemon) ContainerUpdateExtraHosts(name string, add, remove []string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if container.RemovalInProgress || container.Dead {
+		return errCannotUpdate(container.ID, fmt.Errorf("Container is marked for removal and cannot be \"update\"."))
+	}
+	if container.HostConfig.NetworkMode.IsContainer() {
+		return errCannotUpdate(container.ID, runconfig.ErrConflictNetworkHosts)
+	}
+	for _, h := range add {
+		if _, err := runconfigopts.ValidateExtraHost(h); err != nil {
+			return errCannotUpdate(container.ID, err)
+		}
+	}
+
+	dropped, err := container.UpdateExtraHosts(add, remove)
+	if err != nil {
+		return errCannotUpdate(container.ID, err)
+	}
+
+	// A stopped container gets its hosts file with the new entries when it
+	// is started again.
+	if container.IsRunning() && container.HostsPath != "" {
+		if err := updateHostsFile(container.HostsPath, add, dropped); err != nil {
+			return errCannotUpdate(container.ID, err)
+		}
+	}
+
+	daemon.LogContainerEvent(container, "update")
+	return nil
+}
+
+// updateHostsFile removes the records of the extra hosts entries in dropped
+// from the hosts file at path, then appends the records of the entries in add.
+// Only the records written for extra hosts are removed, other entries with the
+// same name, such as localhost or the hostname of the container, are kept.
+// The new content is written to a temporary file which is renamed over path.
+func updateHostsFile(path string, add, dropped []string) error {
+	removed := make(map[string]int, len(dropped))
+	for _, h := range dropped {
+		removed[extraHostRecord(h)]++
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		line := s.Text()
+		if removed[line] > 0 {
+			removed[line]--
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	for _, h := range add {
+		buf.WriteString(extraHostRecord(h))
+		buf.WriteByte('\n')
+	}
+	return ioutils.AtomicWriteFile(path, buf.Bytes(), 0644)
+}
+
+// extraHostRecord returns the hosts file record of an extra hosts entry in the
+// host:ip form, as written by libnetwork.
+func extraHostRecord(h string) string {
+	parts := strings.SplitN(h, ":", 2)
+	var buf bytes.Buffer
+	etchosts.Record{Hosts: parts[0], IP: parts[1]}.WriteTo(&buf)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
 // ContainerUpdateCmdOnBuild updates Path and Args for the container with ID cID.
 func (daemon *Daemon) ContainerUpdateCmdOnBuild(cID string, cmd []string) error {
 	if len(cmd) == 0 {
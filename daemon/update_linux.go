@@ -3,6 +3,8 @@
 package daemon
 
 import (
+	"time"
+
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/libcontainerd"
 )
@@ -13,6 +15,11 @@ func toContainerdResources(resources container.Resources) libcontainerd.Resource
 	r.CpuShares = uint64(resources.CPUShares)
 	r.CpuPeriod = uint64(resources.CPUPeriod)
 	r.CpuQuota = uint64(resources.CPUQuota)
+	if resources.NanoCPUs != 0 {
+		// Same as the period and quota of the spec, see getCPUResources.
+		r.CpuPeriod = uint64(100 * time.Millisecond / time.Microsecond)
+		r.CpuQuota = uint64(resources.NanoCPUs) * r.CpuPeriod / 1e9
+	}
 	r.CpusetCpus = resources.CpusetCpus
 	r.CpusetMems = resources.CpusetMems
 	r.MemoryLimit = uint64(resources.Memory)
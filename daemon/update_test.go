@@ -0,0 +1,37 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateHostsFile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-update-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "hosts")
+	content := "127.0.0.1\tlocalhost\n172.17.0.2\tdrop\n# comment\n10.0.0.1\tkeep\n10.0.0.2\tchange\n10.0.0.3\tdrop\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := updateHostsFile(path, []string{"change:10.0.0.4", "added:10.0.0.5"}, []string{"change:10.0.0.2", "drop:10.0.0.3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The entry of the hostname of the container is not an extra host and
+	// is kept.
+	expected := "127.0.0.1\tlocalhost\n172.17.0.2\tdrop\n# comment\n10.0.0.1\tkeep\n10.0.0.4\tchange\n10.0.0.5\tadded\n"
+	if string(b) != expected {
+		t.Fatalf("Expected hosts file %q, got %q", expected, string(b))
+	}
+}
@@ -0,0 +1,32 @@
+package daemon
+
+import (
+	"github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerVerify compares the configuration of a running container with
+// the state of the kernel, such as its cgroup limits, mounts, namespaced
+// kernel parameters and the iptables rules of its published ports, and
+// reports the differences. Nothing is changed.
+func (daemon *Daemon) ContainerVerify(name string) (*types.ContainerVerifyReport, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+
+	if !container.Running {
+		return nil, errors.NewRequestConflictError(errNotRunning{container.ID})
+	}
+
+	drifts, err := daemon.verifyContainer(container)
+	if err != nil {
+		return nil, err
+	}
+	report := &types.ContainerVerifyReport{Drifts: []types.ContainerDrift{}}
+	report.Drifts = append(report.Drifts, drifts...)
+	return report, nil
+}
@@ -0,0 +1,380 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/parsers"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/libnetwork/iptables"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// cgroupValue is the value a cgroup file of a container is expected to
+// hold.
+type cgroupValue struct {
+	subsystem string
+	file      string
+	expected  string
+	// equal compares the expected value with the value read, they are
+	// compared as strings if it is not set.
+	equal func(expected, actual string) bool
+}
+
+func (daemon *Daemon) verifyContainer(c *container.Container) ([]types.ContainerDrift, error) {
+	pid := c.State.GetPID()
+	var drifts []types.ContainerDrift
+
+	// An unprivileged daemon does not set up the cgroups of the containers.
+	if !daemon.configStore.Rootless {
+		d, err := verifyCgroups(c.HostConfig.Resources, pid)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, d...)
+	}
+
+	d, err := verifyMounts(c, pid)
+	if err != nil {
+		return nil, err
+	}
+	drifts = append(drifts, d...)
+
+	d, err = verifySysctls(c.HostConfig.Sysctls, pid)
+	if err != nil {
+		return nil, err
+	}
+	drifts = append(drifts, d...)
+
+	if daemon.configStore.bridgeConfig.EnableIPTables {
+		d, err = verifyPortRules(c)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, d...)
+	}
+	return drifts, nil
+}
+
+// cgroupValues returns the values the cgroup files of a container with the
+// resources r are expected to hold. The limits which are not set are not
+// checked.
+func cgroupValues(r containertypes.Resources) []cgroupValue {
+	var values []cgroupValue
+	add := func(subsystem, file, expected string, equal func(expected, actual string) bool) {
+		values = append(values, cgroupValue{subsystem: subsystem, file: file, expected: expected, equal: equal})
+	}
+	// The kernel rounds the memory limits down to the page size.
+	pageSize := uint64(os.Getpagesize())
+	memoryLimit := func(v uint64) string {
+		return strconv.FormatUint(v/pageSize*pageSize, 10)
+	}
+
+	memory := getMemoryResources(r)
+	if memory.Limit != nil {
+		add("memory", "memory.limit_in_bytes", memoryLimit(*memory.Limit), nil)
+	}
+	if memory.Reservation != nil {
+		add("memory", "memory.soft_limit_in_bytes", memoryLimit(*memory.Reservation), nil)
+	}
+	// A swap of -1 is unlimited.
+	if memory.Swap != nil && r.MemorySwap > 0 {
+		add("memory", "memory.memsw.limit_in_bytes", memoryLimit(*memory.Swap), nil)
+	}
+	if memory.Kernel != nil && r.KernelMemory > 0 {
+		add("memory", "memory.kmem.limit_in_bytes", memoryLimit(*memory.Kernel), nil)
+	}
+
+	cpu := getCPUResources(r)
+	if cpu.Shares != nil {
+		add("cpu", "cpu.shares", strconv.FormatUint(*cpu.Shares, 10), nil)
+	}
+	if cpu.Period != nil {
+		add("cpu", "cpu.cfs_period_us", strconv.FormatUint(*cpu.Period, 10), nil)
+	}
+	if cpu.Quota != nil && r.CPUQuota >= 0 {
+		add("cpu", "cpu.cfs_quota_us", strconv.FormatUint(*cpu.Quota, 10), nil)
+	}
+	if cpu.Cpus != nil {
+		add("cpuset", "cpuset.cpus", *cpu.Cpus, equalUintList)
+	}
+	if cpu.Mems != nil {
+		add("cpuset", "cpuset.mems", *cpu.Mems, equalUintList)
+	}
+
+	if r.BlkioWeight > 0 {
+		add("blkio", "blkio.weight", strconv.FormatUint(uint64(r.BlkioWeight), 10), nil)
+	}
+	if r.PidsLimit > 0 {
+		add("pids", "pids.max", strconv.FormatInt(r.PidsLimit, 10), nil)
+	}
+	return values
+}
+
+// equalUintList compares two lists of integers such as "0-2,4", the kernel
+// formatting the cpusets its own way.
+func equalUintList(expected, actual string) bool {
+	e, err := parsers.ParseUintList(expected)
+	if err != nil {
+		return false
+	}
+	a, err := parsers.ParseUintList(actual)
+	if err != nil || len(a) != len(e) {
+		return false
+	}
+	for i := range e {
+		if !a[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyCgroups compares the cgroup files of the process pid with the
+// resources r of its container.
+func verifyCgroups(r containertypes.Resources, pid int) ([]types.ContainerDrift, error) {
+	paths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []types.ContainerDrift
+	for _, v := range cgroupValues(r) {
+		mnt, root, err := cgroups.FindCgroupMountpointAndRoot(v.subsystem)
+		if err != nil {
+			// the subsystem is not mounted, the limit cannot be set
+			continue
+		}
+		p, ok := paths[v.subsystem]
+		if !ok {
+			continue
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadFile(filepath.Join(mnt, rel, v.file))
+		if os.IsNotExist(err) {
+			// such as memory.memsw.limit_in_bytes without swap accounting
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		actual := strings.TrimSpace(string(data))
+		equal := actual == v.expected
+		if v.equal != nil {
+			equal = v.equal(v.expected, actual)
+		}
+		if !equal {
+			drifts = append(drifts, types.ContainerDrift{
+				Type:     types.DriftCgroup,
+				Resource: v.file,
+				Expected: v.expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// verifyMounts checks that the volumes, bind mounts and tmpfs mounts of the
+// container are mounted in the mount namespace of the process pid, read-only
+// if they are expected to be.
+func verifyMounts(c *container.Container, pid int) ([]types.ContainerDrift, error) {
+	infos, err := mount.PidMountInfo(pid)
+	if err != nil {
+		return nil, err
+	}
+	// the last mount on a mount point hides the previous ones
+	mounted := make(map[string]*mount.Info)
+	for _, i := range infos {
+		mounted[i.Mountpoint] = i
+	}
+
+	readOnly := make(map[string]bool)
+	for dest, mp := range c.MountPoints {
+		readOnly[filepath.Clean(dest)] = !mp.RW
+	}
+	for dest := range c.HostConfig.Tmpfs {
+		readOnly[filepath.Clean(dest)] = false
+	}
+	dests := make([]string, 0, len(readOnly))
+	for dest := range readOnly {
+		dests = append(dests, dest)
+	}
+	sort.Strings(dests)
+
+	var drifts []types.ContainerDrift
+	for _, dest := range dests {
+		i, ok := mounted[dest]
+		if !ok {
+			drifts = append(drifts, types.ContainerDrift{
+				Type:     types.DriftMount,
+				Resource: dest,
+				Expected: "mounted",
+				Actual:   "not mounted",
+			})
+			continue
+		}
+		expected, actual := "rw", "rw"
+		if readOnly[dest] {
+			expected = "ro"
+		}
+		for _, opt := range strings.Split(i.Opts, ",") {
+			if opt == "ro" {
+				actual = "ro"
+			}
+		}
+		if expected != actual {
+			drifts = append(drifts, types.ContainerDrift{
+				Type:     types.DriftMount,
+				Resource: dest,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// verifySysctls compares the namespaced kernel parameters of the process pid
+// with the sysctls of its container.
+func verifySysctls(sysctls map[string]string, pid int) ([]types.ContainerDrift, error) {
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var drifts []types.ContainerDrift
+	for _, key := range keys {
+		// The sysctls a container can set are in its network or IPC
+		// namespace.
+		ns, nstype := "ipc", syscall.CLONE_NEWIPC
+		if strings.HasPrefix(key, "net.") {
+			ns, nstype = "net", syscall.CLONE_NEWNET
+		}
+		actual, err := readSysctlIn(pid, ns, nstype, key)
+		if os.IsNotExist(err) {
+			actual = "unknown parameter"
+		} else if err != nil {
+			return nil, err
+		}
+
+		expected := strings.Join(strings.Fields(sysctls[key]), " ")
+		if expected != actual {
+			drifts = append(drifts, types.ContainerDrift{
+				Type:     types.DriftSysctl,
+				Resource: key,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// readSysctlIn reads the kernel parameter key in the namespace ns of the
+// process pid, which the kernel resolves from the namespace of the reading
+// thread.
+func readSysctlIn(pid int, ns string, nstype int, key string) (string, error) {
+	var data []byte
+	err := inNamespace(pid, ns, nstype, func() error {
+		var err error
+		data, err = ioutil.ReadFile(filepath.Join("/proc/sys", strings.Replace(key, ".", "/", -1)))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(string(data)), " "), nil
+}
+
+// verifyPortRules checks that the nat table has the DNAT rules of the
+// published ports of the container.
+func verifyPortRules(c *container.Container) ([]types.ContainerDrift, error) {
+	if c.NetworkSettings == nil || len(c.NetworkSettings.Ports) == 0 {
+		return nil, nil
+	}
+	ips := make(map[string]bool)
+	for _, ep := range c.NetworkSettings.Networks {
+		if ep.IPAddress != "" {
+			ips[ep.IPAddress] = true
+		}
+	}
+	if len(ips) == 0 {
+		return nil, nil
+	}
+
+	out, err := iptables.Raw("-t", "nat", "-S")
+	if err != nil {
+		return nil, err
+	}
+	rules := strings.Split(string(out), "\n")
+
+	ports := make([]nat.Port, 0, len(c.NetworkSettings.Ports))
+	for port := range c.NetworkSettings.Ports {
+		ports = append(ports, port)
+	}
+	nat.Sort(ports, func(i, j nat.Port) bool { return i < j })
+
+	var drifts []types.ContainerDrift
+	for _, port := range ports {
+		for _, binding := range c.NetworkSettings.Ports[port] {
+			if binding.HostPort == "" {
+				continue
+			}
+			if !hasDNATRule(rules, port.Proto(), binding.HostPort, ips, port.Port()) {
+				drifts = append(drifts, types.ContainerDrift{
+					Type:     types.DriftIptables,
+					Resource: net.JoinHostPort(binding.HostIP, binding.HostPort) + "->" + string(port),
+					Expected: "DNAT rule",
+					Actual:   "no rule",
+				})
+			}
+		}
+	}
+	return drifts, nil
+}
+
+// hasDNATRule returns whether one of the rules, as listed by iptables -S,
+// forwards the host port hostPort to the port of one of the ips.
+func hasDNATRule(rules []string, proto, hostPort string, ips map[string]bool, port string) bool {
+	for _, rule := range rules {
+		fields := strings.Fields(rule)
+		var ruleProto, dport, target, dest string
+		for i := 0; i+1 < len(fields); i++ {
+			switch fields[i] {
+			case "-p":
+				ruleProto = fields[i+1]
+			case "--dport":
+				dport = fields[i+1]
+			case "-j":
+				target = fields[i+1]
+			case "--to-destination":
+				dest = fields[i+1]
+			}
+		}
+		if target != "DNAT" || ruleProto != proto || dport != hostPort {
+			continue
+		}
+		ip, destPort, err := net.SplitHostPort(dest)
+		if err == nil && ips[ip] && destPort == port {
+			return true
+		}
+	}
+	return false
+}
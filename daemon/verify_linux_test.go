@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+)
+
+func TestCgroupValues(t *testing.T) {
+	pageSize := int64(os.Getpagesize())
+	values := cgroupValues(containertypes.Resources{
+		Memory:     100*pageSize + 1,
+		MemorySwap: -1,
+		NanoCPUs:   1500000000,
+		CpusetCpus: "0,1",
+		PidsLimit:  64,
+	})
+
+	expected := map[string]string{
+		"memory.limit_in_bytes": strconv.FormatInt(100*pageSize, 10),
+		"cpu.cfs_period_us":     "100000",
+		"cpu.cfs_quota_us":      "150000",
+		"cpuset.cpus":           "0,1",
+		"pids.max":              "64",
+	}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %d values, got %v", len(expected), values)
+	}
+	for _, v := range values {
+		if e, ok := expected[v.file]; !ok || e != v.expected {
+			t.Fatalf("expected %s to be %q, got %q", v.file, e, v.expected)
+		}
+	}
+}
+
+func TestEqualUintList(t *testing.T) {
+	if !equalUintList("0,1,2,4", "0-2,4") {
+		t.Fatal("expected 0,1,2,4 to equal 0-2,4")
+	}
+	if equalUintList("0,1", "0-2") {
+		t.Fatal("expected 0,1 to differ from 0-2")
+	}
+}
+
+func TestHasDNATRule(t *testing.T) {
+	rules := []string{
+		"-N DOCKER",
+		"-A DOCKER -i docker0 -j RETURN",
+		"-A DOCKER ! -i docker0 -p tcp -m tcp --dport 8080 -j DNAT --to-destination 172.17.0.2:80",
+	}
+	ips := map[string]bool{"172.17.0.2": true}
+
+	if !hasDNATRule(rules, "tcp", "8080", ips, "80") {
+		t.Fatal("expected the DNAT rule of 8080/tcp to be found")
+	}
+	if hasDNATRule(rules, "udp", "8080", ips, "80") {
+		t.Fatal("expected no DNAT rule for 8080/udp")
+	}
+	if hasDNATRule(rules, "tcp", "8080", map[string]bool{"172.17.0.3": true}, "80") {
+		t.Fatal("expected no DNAT rule to another container")
+	}
+}
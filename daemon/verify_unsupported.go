@@ -0,0 +1,15 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// verifyContainer is not supported on this platform.
+func (daemon *Daemon) verifyContainer(c *container.Container) ([]types.ContainerDrift, error) {
+	return nil, fmt.Errorf("verifying containers is not supported on this platform")
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
 )
 
 // ContainerWait stops processing until the given container is
@@ -20,6 +22,34 @@ func (daemon *Daemon) ContainerWait(name string, timeout time.Duration) (int, er
 	return container.WaitStop(timeout)
 }
 
+// ContainerWaitExit stops processing until the given container is stopped,
+// and returns its exit code along with how it exited: the signal which
+// killed it, if it ran out of memory, and the error of its start, if any.
+func (daemon *Daemon) ContainerWaitExit(name string) (*types.ContainerWaitResponse, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	exitCode, err := container.WaitStop(-1 * time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	container.Lock()
+	defer container.Unlock()
+	return &types.ContainerWaitResponse{
+		StatusCode: exitCode,
+		Exit: &types.ContainerExitStatus{
+			Signal:     exitSignal(exitCode),
+			OOMKilled:  container.State.OOMKilled,
+			Error:      container.State.Error(),
+			StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
+			FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
+		},
+	}, nil
+}
+
 // ContainerWaitWithContext returns a channel where exit code is sent
 // when container stops. Channel can be cancelled with a context.
 func (daemon *Daemon) ContainerWaitWithContext(ctx context.Context, name string) error {
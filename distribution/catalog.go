@@ -0,0 +1,63 @@
+package distribution
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/docker/distribution"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"golang.org/x/net/context"
+)
+
+// ListRepositories lists at most limit repositories of the catalog of the
+// registry hostname, in lexical order after the repository last.
+func ListRepositories(ctx context.Context, hostname, last string, limit int, config *ManifestConfig) (*types.RegistryRepositories, error) {
+	if limit < 1 || limit > registry.MaxCatalogLimit {
+		return nil, fmt.Errorf("the limit must be between 1 and %d", registry.MaxCatalogLimit)
+	}
+	index, err := config.RegistryService.ResolveIndex(hostname)
+	if err != nil {
+		return nil, err
+	}
+	if index.Official {
+		return nil, fmt.Errorf("the repositories of %s cannot be listed, use docker search", index.Name)
+	}
+	endpoints, err := config.RegistryService.LookupPullEndpoints(index.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories *types.RegistryRepositories
+	err = withV2Endpoint(index.Name, endpoints, func(endpoint registry.APIEndpoint) error {
+		reg, _, err := newV2Registry(ctx, endpoint, config.MetaHeaders, config.AuthConfig)
+		if err != nil {
+			return err
+		}
+
+		entries := make([]string, limit)
+		n, err := reg.Repositories(ctx, entries, last)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		repositories = &types.RegistryRepositories{Repositories: entries[:n]}
+		// the registry links to the next repositories if there are more
+		if err == nil && n > 0 {
+			repositories.Next = entries[n-1]
+		}
+		return nil
+	})
+	return repositories, err
+}
+
+// ListTags lists the tags of the repository ref in its registry.
+func ListTags(ctx context.Context, ref reference.Named, config *ManifestConfig) ([]string, error) {
+	var tags []string
+	err := withV2Repository(ctx, ref, config, false, func(repo distribution.Repository) error {
+		var err error
+		tags, err = repo.Tags(ctx).All(ctx)
+		return err
+	})
+	return tags, err
+}
@@ -0,0 +1,95 @@
+package distribution
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"golang.org/x/net/context"
+)
+
+// catalogRegistry serves the catalog and the tags of a registry with three
+// repositories.
+func catalogRegistry(w http.ResponseWriter, r *http.Request) {
+	repositories := []string{"alpine", "busybox", "debian"}
+	switch r.URL.Path {
+	case "/v2/":
+		w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+	case "/v2/_catalog":
+		last := r.URL.Query().Get("last")
+		n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+		var page []string
+		for _, repository := range repositories {
+			if repository > last {
+				page = append(page, repository)
+			}
+		}
+		if len(page) > n {
+			page = page[:n]
+			w.Header().Set("Link", fmt.Sprintf(`</v2/_catalog?last=%s&n=%d>; rel="next"`, page[n-1], n))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string][]string{"repositories": page})
+	case "/v2/busybox/tags/list":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": "busybox", "tags": []string{"1.25", "latest"}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestListRepositories(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(catalogRegistry))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ManifestConfig{
+		AuthConfig:      &types.AuthConfig{},
+		RegistryService: registry.NewService(registry.ServiceOptions{}),
+	}
+	ctx := context.Background()
+
+	repositories, err := ListRepositories(ctx, uri.Host, "", 2, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repositories.Repositories) != 2 || repositories.Repositories[1] != "busybox" || repositories.Next != "busybox" {
+		t.Fatalf("unexpected first repositories %v", repositories)
+	}
+	repositories, err = ListRepositories(ctx, uri.Host, repositories.Next, 2, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repositories.Repositories) != 1 || repositories.Repositories[0] != "debian" || repositories.Next != "" {
+		t.Fatalf("unexpected last repositories %v", repositories)
+	}
+
+	if _, err := ListRepositories(ctx, uri.Host, "", registry.MaxCatalogLimit+1, config); err == nil {
+		t.Fatal("expected an error for a limit above the maximum")
+	}
+	if _, err := ListRepositories(ctx, "docker.io", "", 2, config); err == nil {
+		t.Fatal("expected an error for the official registry")
+	}
+
+	ref, err := reference.ParseNamed(uri.Host + "/busybox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, err := ListTags(ctx, ref, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0] != "1.25" || tags[1] != "latest" {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+}
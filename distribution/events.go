@@ -0,0 +1,40 @@
+package distribution
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/docker/distribution/digest"
+)
+
+// logImageEvent logs an event of an image with the logger, if it is set.
+func logImageEvent(logger func(id, name, action string, attributes map[string]string), id, name, action string, attributes map[string]string) {
+	if logger == nil {
+		return
+	}
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	logger(id, name, action, attributes)
+}
+
+// finishedAttributes returns the attributes of the event of the end of a
+// pull or a push started at start, which failed if err is not nil.
+func finishedAttributes(start time.Time, err error) map[string]string {
+	attributes := map[string]string{
+		"duration": (time.Since(start) / time.Millisecond * time.Millisecond).String(),
+	}
+	if err != nil {
+		attributes["error"] = err.Error()
+	}
+	return attributes
+}
+
+// layerAttributes returns the attributes of the event of the completion of
+// the transfer of a layer.
+func layerAttributes(dgst digest.Digest, size int64) map[string]string {
+	return map[string]string{
+		"digest": dgst.String(),
+		"size":   strconv.FormatInt(size, 10),
+	}
+}
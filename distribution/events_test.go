@@ -0,0 +1,54 @@
+package distribution
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/digest"
+)
+
+func TestLogImageEvent(t *testing.T) {
+	// a nil logger is ignored
+	logImageEvent(nil, "busybox:latest", "busybox", "pull-started", nil)
+
+	var got map[string]string
+	logger := func(id, name, action string, attributes map[string]string) {
+		if id != "busybox:latest" || name != "busybox" || action != "pull-started" {
+			t.Fatalf("unexpected event %s %s %s", id, name, action)
+		}
+		got = attributes
+	}
+	logImageEvent(logger, "busybox:latest", "busybox", "pull-started", nil)
+	if got == nil {
+		t.Fatal("expected the attributes to be set")
+	}
+}
+
+func TestFinishedAttributes(t *testing.T) {
+	start := time.Now().Add(-1500 * time.Millisecond)
+	attributes := finishedAttributes(start, nil)
+	d, err := time.ParseDuration(attributes["duration"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d < 1500*time.Millisecond || d%time.Millisecond != 0 {
+		t.Fatalf("unexpected duration %s", d)
+	}
+	if _, ok := attributes["error"]; ok {
+		t.Fatal("expected no error attribute")
+	}
+
+	attributes = finishedAttributes(start, errors.New("unauthorized"))
+	if attributes["error"] != "unauthorized" {
+		t.Fatalf("expected the error attribute, got %q", attributes["error"])
+	}
+}
+
+func TestLayerAttributes(t *testing.T) {
+	dgst := digest.FromBytes([]byte("layer"))
+	attributes := layerAttributes(dgst, 1024)
+	if attributes["digest"] != dgst.String() || attributes["size"] != "1024" {
+		t.Fatalf("unexpected attributes %v", attributes)
+	}
+}
@@ -0,0 +1,226 @@
+package distribution
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"golang.org/x/net/context"
+)
+
+// ManifestConfig stores the configuration to inspect manifests, to push
+// manifest lists and to browse the catalog of a registry.
+type ManifestConfig struct {
+	// MetaHeaders stores HTTP headers with metadata about the image
+	MetaHeaders map[string][]string
+	// AuthConfig holds authentication credentials for authenticating with
+	// the registry.
+	AuthConfig *types.AuthConfig
+	// RegistryService is the registry service to use for TLS configuration
+	// and endpoint lookup.
+	RegistryService registry.Service
+}
+
+// InspectManifest returns the descriptor of the manifest ref refers to in
+// its registry, with the platform of its image, so that a manifest list can
+// reference it.
+func InspectManifest(ctx context.Context, ref reference.Named, config *ManifestConfig) (*types.ManifestDescriptor, error) {
+	var descriptor *types.ManifestDescriptor
+	err := withV2Repository(ctx, ref, config, false, func(repo distribution.Repository) error {
+		manSvc, err := repo.Manifests(ctx)
+		if err != nil {
+			return err
+		}
+
+		var manifest distribution.Manifest
+		if digested, ok := ref.(reference.Canonical); ok {
+			manifest, err = manSvc.Get(ctx, digested.Digest())
+		} else {
+			tag := reference.DefaultTag
+			if tagged, ok := ref.(reference.NamedTagged); ok {
+				tag = tagged.Tag()
+			}
+			manifest, err = manSvc.Get(ctx, "", distribution.WithTag(tag))
+		}
+		if err != nil {
+			return err
+		}
+
+		switch m := manifest.(type) {
+		case *schema2.DeserializedManifest:
+			descriptor, err = manifestDescriptor(ctx, repo, m)
+			return err
+		case *schema1.SignedManifest:
+			return fmt.Errorf("%s has a schema1 manifest, which cannot be referenced by a manifest list", ref.String())
+		case *manifestlist.DeserializedManifestList:
+			return fmt.Errorf("%s is a manifest list, which cannot be referenced by a manifest list", ref.String())
+		default:
+			return fmt.Errorf("%s has an unsupported manifest type", ref.String())
+		}
+	})
+	return descriptor, err
+}
+
+// manifestDescriptor returns the descriptor of a schema2 manifest, with the
+// platform of the image read from its configuration.
+func manifestDescriptor(ctx context.Context, repo distribution.Repository, m *schema2.DeserializedManifest) (*types.ManifestDescriptor, error) {
+	mediaType, payload, err := m.Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	configJSON, err := repo.Blobs(ctx).Get(ctx, m.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	var img image.Image
+	if err := json.Unmarshal(configJSON, &img); err != nil {
+		return nil, err
+	}
+
+	return &types.ManifestDescriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(payload).String(),
+		Size:      int64(len(payload)),
+		Platform: types.ManifestPlatform{
+			Architecture: img.Architecture,
+			OS:           img.OS,
+			OSVersion:    img.OSVersion,
+			OSFeatures:   img.OSFeatures,
+		},
+	}, nil
+}
+
+// manifestListDescriptors converts the descriptors of the manifests of a
+// manifest list, checking that their digest and platform are set.
+func manifestListDescriptors(manifests []types.ManifestDescriptor) ([]manifestlist.ManifestDescriptor, error) {
+	descriptors := make([]manifestlist.ManifestDescriptor, 0, len(manifests))
+	for _, m := range manifests {
+		dgst, err := digest.ParseDigest(m.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if m.Platform.Architecture == "" || m.Platform.OS == "" {
+			return nil, fmt.Errorf("the architecture and the OS of manifest %s must be set", dgst)
+		}
+		descriptors = append(descriptors, manifestlist.ManifestDescriptor{
+			Descriptor: distribution.Descriptor{
+				MediaType: m.MediaType,
+				Size:      m.Size,
+				Digest:    dgst,
+			},
+			Platform: manifestlist.PlatformSpec{
+				Architecture: m.Platform.Architecture,
+				OS:           m.Platform.OS,
+				OSVersion:    m.Platform.OSVersion,
+				OSFeatures:   m.Platform.OSFeatures,
+				Variant:      m.Platform.Variant,
+				Features:     m.Platform.Features,
+			},
+		})
+	}
+	return descriptors, nil
+}
+
+// PushManifestList pushes a manifest list referencing the manifests to ref,
+// and returns its digest. The manifests must have been pushed to the
+// repository of ref.
+func PushManifestList(ctx context.Context, ref reference.NamedTagged, manifests []types.ManifestDescriptor, config *ManifestConfig) (digest.Digest, error) {
+	if len(manifests) == 0 {
+		return "", fmt.Errorf("the manifest list %s references no manifest", ref.String())
+	}
+	descriptors, err := manifestListDescriptors(manifests)
+	if err != nil {
+		return "", err
+	}
+	mfstList, err := manifestlist.FromDescriptors(descriptors)
+	if err != nil {
+		return "", err
+	}
+
+	var dgst digest.Digest
+	err = withV2Repository(ctx, ref, config, true, func(repo distribution.Repository) error {
+		manSvc, err := repo.Manifests(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range descriptors {
+			exists, err := manSvc.Exists(ctx, m.Digest)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("manifest %s is not in the repository %s, it must be pushed there first", m.Digest, ref.Name())
+			}
+		}
+		dgst, err = manSvc.Put(ctx, mfstList, distribution.WithTag(ref.Tag()))
+		return err
+	})
+	return dgst, err
+}
+
+// withV2Repository calls fn with the repository of ref on the first v2
+// endpoint of its registry which can be reached. The repository is opened
+// for a push if push is set.
+func withV2Repository(ctx context.Context, ref reference.Named, config *ManifestConfig, push bool, fn func(repo distribution.Repository) error) error {
+	repoInfo, err := config.RegistryService.ResolveRepository(ref)
+	if err != nil {
+		return err
+	}
+	if err := ValidateRepoName(repoInfo.Name()); err != nil {
+		return err
+	}
+
+	actions := []string{"pull"}
+	lookup := config.RegistryService.LookupPullEndpoints
+	if push {
+		actions = []string{"push", "pull"}
+		lookup = config.RegistryService.LookupPushEndpoints
+	}
+	endpoints, err := lookup(repoInfo.Hostname())
+	if err != nil {
+		return err
+	}
+
+	return withV2Endpoint(repoInfo.FullName(), endpoints, func(endpoint registry.APIEndpoint) error {
+		repo, _, err := NewV2Repository(ctx, repoInfo, endpoint, config.MetaHeaders, config.AuthConfig, actions...)
+		if err != nil {
+			return err
+		}
+		return fn(repo)
+	})
+}
+
+// withV2Endpoint calls fn with the first v2 endpoint for which it does not
+// return a fallback error. name is the name of the resource fn accesses.
+func withV2Endpoint(name string, endpoints []registry.APIEndpoint, fn func(endpoint registry.APIEndpoint) error) error {
+	var lastErr error
+	for _, endpoint := range endpoints {
+		if endpoint.Version == registry.APIVersion1 {
+			continue
+		}
+		logrus.Debugf("Trying to open %s on %s", name, endpoint.URL)
+
+		err := fn(endpoint)
+		if fallbackErr, ok := err.(fallbackError); ok {
+			lastErr = fallbackErr.err
+			logrus.Errorf("Attempting next endpoint for %s after error: %v", name, lastErr)
+			continue
+		}
+		return err
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no v2 endpoints found for %s", name)
+	}
+	return lastErr
+}
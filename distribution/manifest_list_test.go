@@ -0,0 +1,37 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/docker/api/types"
+)
+
+func TestManifestListDescriptors(t *testing.T) {
+	dgst := digest.FromBytes([]byte("manifest"))
+	manifests := []types.ManifestDescriptor{
+		{
+			MediaType: schema2.MediaTypeManifest,
+			Digest:    dgst.String(),
+			Size:      8,
+			Platform:  types.ManifestPlatform{Architecture: "arm", OS: "linux", Variant: "v7"},
+		},
+	}
+	descriptors, err := manifestListDescriptors(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descriptors) != 1 || descriptors[0].Digest != dgst || descriptors[0].Platform.Variant != "v7" {
+		t.Fatalf("unexpected descriptors %v", descriptors)
+	}
+
+	manifests[0].Platform.OS = ""
+	if _, err := manifestListDescriptors(manifests); err == nil {
+		t.Fatal("expected an error for a manifest without an OS")
+	}
+	manifests[0].Digest = "invalid"
+	if _, err := manifestListDescriptors(manifests); err == nil {
+		t.Fatal("expected an error for an invalid digest")
+	}
+}
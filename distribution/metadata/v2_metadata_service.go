@@ -32,6 +32,9 @@ var _ V2MetadataService = &v2MetadataService{}
 type V2Metadata struct {
 	Digest           digest.Digest
 	SourceRepository string
+	// Compression is the compression of the blob, "zstd", or empty for
+	// gzip. It is not hashed in HMAC.
+	Compression string `json:",omitempty"`
 	// HMAC hashes above attributes with recent authconfig digest used as a key in order to determine matching
 	// metadata entries accompanied by the same credentials without actually exposing them.
 	HMAC string
@@ -0,0 +1,87 @@
+package distribution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution/digest"
+)
+
+// partialDownloadMaxAge is how long a partial download is kept once it is
+// no longer written to.
+const partialDownloadMaxAge = 24 * time.Hour
+
+// partialDownloads tracks the partial download files in use, so that a blob
+// which is downloaded by concurrent pulls is only written to its partial
+// download file by one of them.
+var partialDownloads = struct {
+	sync.Mutex
+	inUse map[string]bool
+}{inUse: make(map[string]bool)}
+
+// openPartialDownload opens the partial download file of the blob with the
+// given digest in dir, creating it if it does not exist. It returns nil if
+// the file is used by another download.
+func openPartialDownload(dir string, dgst digest.Digest) (*os.File, error) {
+	path := filepath.Join(dir, string(dgst.Algorithm())+"-"+dgst.Hex())
+
+	partialDownloads.Lock()
+	defer partialDownloads.Unlock()
+	if partialDownloads.inUse[path] {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	partialDownloads.inUse[path] = true
+	return f, nil
+}
+
+// closePartialDownload closes the partial download file. The file is kept
+// for the next pull unless remove is set or it is empty.
+func closePartialDownload(f *os.File, remove bool) error {
+	partialDownloads.Lock()
+	defer partialDownloads.Unlock()
+	delete(partialDownloads.inUse, f.Name())
+
+	if !remove {
+		if fi, err := f.Stat(); err == nil && fi.Size() != 0 {
+			return f.Close()
+		}
+	}
+	f.Close()
+	return os.RemoveAll(f.Name())
+}
+
+// RemoveStalePartialDownloads removes the partial downloads of dir which
+// were not written to for a day, such as the downloads of the pulls which
+// were not attempted again.
+func RemoveStalePartialDownloads(dir string) error {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	partialDownloads.Lock()
+	defer partialDownloads.Unlock()
+	for _, fi := range fis {
+		path := filepath.Join(dir, fi.Name())
+		if partialDownloads.inUse[path] || time.Since(fi.ModTime()) < partialDownloadMaxAge {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package distribution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/digest"
+)
+
+func TestPartialDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "partial-download-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dgst := digest.FromBytes([]byte("layer"))
+	f, err := openPartialDownload(dir, dgst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other, err := openPartialDownload(dir, dgst); err != nil || other != nil {
+		t.Fatalf("expected the partial download to be in use, got %v, %v", other, err)
+	}
+	if _, err := f.Write([]byte("lay")); err != nil {
+		t.Fatal(err)
+	}
+	if err := closePartialDownload(f, false); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err = openPartialDownload(dir, dgst)
+	if err != nil || f == nil {
+		t.Fatalf("expected the partial download to be kept, got %v, %v", f, err)
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "lay" {
+		t.Fatalf("expected the partial download to contain %q, got %q", "lay", data)
+	}
+	if err := closePartialDownload(f, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(f.Name()); !os.IsNotExist(err) {
+		t.Fatalf("expected the partial download to be removed, got %v", err)
+	}
+
+	// an empty partial download is not kept
+	f, err = openPartialDownload(dir, dgst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := closePartialDownload(f, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(f.Name()); !os.IsNotExist(err) {
+		t.Fatalf("expected the empty partial download to be removed, got %v", err)
+	}
+}
+
+func TestRemoveStalePartialDownloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "partial-download-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	stale := filepath.Join(dir, "sha256-stale")
+	recent := filepath.Join(dir, "sha256-recent")
+	for _, p := range []string{stale, recent} {
+		if err := ioutil.WriteFile(p, []byte("data"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * partialDownloadMaxAge)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveStalePartialDownloads(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected the stale partial download to be removed, got %v", err)
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveStalePartialDownloads(filepath.Join(dir, "missing")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,6 +2,7 @@ package distribution
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/digest"
@@ -29,8 +30,10 @@ type ImagePullConfig struct {
 	// RegistryService is the registry service to use for TLS configuration
 	// and endpoint lookup.
 	RegistryService registry.Service
-	// ImageEventLogger notifies events for a given image
-	ImageEventLogger func(id, name, action string)
+	// ImageEventLogger notifies events for a given image. Besides the pull
+	// event, the start and the end of the pull and the completion of the
+	// download of each layer are notified.
+	ImageEventLogger func(id, name, action string, attributes map[string]string)
 	// MetadataStore is the storage backend for distribution-specific
 	// metadata.
 	MetadataStore metadata.Store
@@ -40,6 +43,12 @@ type ImagePullConfig struct {
 	ReferenceStore reference.Store
 	// DownloadManager manages concurrent pulls.
 	DownloadManager *xfer.LayerDownloadManager
+	// PartialDownloadsDir is the directory where the layers are downloaded
+	// to, keyed by their digest, so that the download of a layer resumes
+	// if the pull fails and is attempted again. The layers are downloaded
+	// to temporary files which are removed once the pull fails if it is
+	// not set.
+	PartialDownloadsDir string
 }
 
 // Puller is an interface that abstracts pulling for different API versions.
@@ -77,7 +86,7 @@ func newPuller(endpoint registry.APIEndpoint, repoInfo *registry.RepositoryInfo,
 
 // Pull initiates a pull operation. image is the repository name to pull, and
 // tag may be either empty, or indicate a specific tag to pull.
-func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullConfig) error {
+func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullConfig) (err error) {
 	// Resolve the Repository name from fqn to RepositoryInfo
 	repoInfo, err := imagePullConfig.RegistryService.ResolveRepository(ref)
 	if err != nil {
@@ -89,6 +98,12 @@ func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullCo
 		return err
 	}
 
+	start := time.Now()
+	logImageEvent(imagePullConfig.ImageEventLogger, ref.String(), repoInfo.Name(), "pull-started", nil)
+	defer func() {
+		logImageEvent(imagePullConfig.ImageEventLogger, ref.String(), repoInfo.Name(), "pull-finished", finishedAttributes(start, err))
+	}()
+
 	endpoints, err := imagePullConfig.RegistryService.LookupPullEndpoints(repoInfo.Hostname())
 	if err != nil {
 		return err
@@ -171,7 +186,7 @@ func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullCo
 			return err
 		}
 
-		imagePullConfig.ImageEventLogger(ref.String(), repoInfo.Name(), "pull")
+		logImageEvent(imagePullConfig.ImageEventLogger, ref.String(), repoInfo.Name(), "pull", nil)
 		return nil
 	}
 
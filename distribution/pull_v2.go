@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"runtime"
@@ -138,6 +139,15 @@ type v2LayerDescriptor struct {
 	tmpFile           *os.File
 	verifier          digest.Verifier
 	src               distribution.Descriptor
+	// partialDir is the directory of the partial downloads, which are
+	// resumed by the next pull if the pull fails, if it is set.
+	partialDir string
+	// partial is set if tmpFile is the partial download of the layer.
+	partial bool
+	// downloadManager limits the rate of the download, if it is set.
+	downloadManager *xfer.LayerDownloadManager
+	// logEvent logs the events of the image being pulled.
+	logEvent func(action string, attributes map[string]string)
 }
 
 func (ld *v2LayerDescriptor) Key() string {
@@ -148,10 +158,51 @@ func (ld *v2LayerDescriptor) ID() string {
 	return stringid.TruncateID(ld.digest.String())
 }
 
+// Registry returns the name of the registry the layer is downloaded from.
+func (ld *v2LayerDescriptor) Registry() string {
+	return ld.repoInfo.Index.Name
+}
+
 func (ld *v2LayerDescriptor) DiffID() (layer.DiffID, error) {
 	return ld.V2MetadataService.GetDiffID(ld.digest)
 }
 
+var _ distribution.Describable = &v2LayerDescriptor{}
+
+// Descriptor returns the descriptor of a foreign layer, so that it is
+// recorded in the layer store and the layer is not pushed. It returns an
+// empty descriptor for the other layers.
+func (ld *v2LayerDescriptor) Descriptor() distribution.Descriptor {
+	if ld.src.MediaType == schema2.MediaTypeForeignLayer && len(ld.src.URLs) > 0 {
+		return ld.src
+	}
+	return distribution.Descriptor{}
+}
+
+// open opens the blob of the layer. A foreign layer is downloaded from the
+// first of its URLs which can be opened, and from the registry if none of
+// them can. The content is verified against the digest of the layer in
+// either case.
+func (ld *v2LayerDescriptor) open(ctx context.Context) (distribution.ReadSeekCloser, error) {
+	for _, u := range ld.src.URLs {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			logrus.Debugf("Ignoring foreign URL %v of %v: only http and https URLs are supported", u, ld.digest)
+			continue
+		}
+		logrus.Debugf("Pulling %v from foreign URL %v", ld.digest, u)
+		rsc := transport.NewHTTPReadSeeker(http.DefaultClient, u, nil)
+		if _, err := rsc.Seek(0, os.SEEK_SET); err != nil {
+			logrus.Debugf("Download for %v failed: %v", ld.digest, err)
+			rsc.Close()
+			continue
+		}
+		return rsc, nil
+	}
+
+	blobs := ld.repo.Blobs(ctx)
+	return blobs.Open(ctx, ld.digest)
+}
+
 func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progress.Output) (io.ReadCloser, int64, error) {
 	logrus.Debugf("pulling blob %q", ld.digest)
 
@@ -161,27 +212,25 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	)
 
 	if ld.tmpFile == nil {
-		ld.tmpFile, err = createDownloadFile()
+		ld.tmpFile, err = ld.openDownloadFile()
 		if err != nil {
 			return nil, 0, xfer.DoNotRetry{Err: err}
 		}
-	} else {
-		offset, err = ld.tmpFile.Seek(0, os.SEEK_END)
-		if err != nil {
-			logrus.Debugf("error seeking to end of download file: %v", err)
-			offset = 0
+	}
+	offset, err = ld.tmpFile.Seek(0, os.SEEK_END)
+	if err != nil {
+		logrus.Debugf("error seeking to end of download file: %v", err)
+		offset = 0
 
-			ld.tmpFile.Close()
-			if err := os.Remove(ld.tmpFile.Name()); err != nil {
-				logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
-			}
-			ld.tmpFile, err = createDownloadFile()
-			if err != nil {
-				return nil, 0, xfer.DoNotRetry{Err: err}
-			}
-		} else if offset != 0 {
-			logrus.Debugf("attempting to resume download of %q from %d bytes", ld.digest, offset)
+		ld.removeDownloadFile(ld.tmpFile, ld.partial)
+		ld.verifier = nil
+		ld.partial = false
+		ld.tmpFile, err = createDownloadFile()
+		if err != nil {
+			return nil, 0, xfer.DoNotRetry{Err: err}
 		}
+	} else if offset != 0 {
+		logrus.Debugf("attempting to resume download of %q from %d bytes", ld.digest, offset)
 	}
 
 	tmpFile := ld.tmpFile
@@ -228,7 +277,11 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 		}
 	}
 
-	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, layerDownload), progressOutput, size-offset, ld.ID(), "Downloading")
+	var body io.ReadCloser = ioutils.NewCancelReadCloser(ctx, layerDownload)
+	if ld.downloadManager != nil {
+		body = ld.downloadManager.RateLimitReader(ctx, ld.Registry(), body)
+	}
+	reader := progress.NewProgressReader(body, progressOutput, size-offset, ld.ID(), "Downloading")
 	defer reader.Close()
 
 	if ld.verifier == nil {
@@ -238,7 +291,7 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 		}
 	}
 
-	_, err = io.Copy(tmpFile, io.TeeReader(reader, ld.verifier))
+	copied, err := io.Copy(tmpFile, io.TeeReader(reader, ld.verifier))
 	if err != nil {
 		if err == transport.ErrWrongCodeForByteRange {
 			if err := ld.truncateDownloadFile(); err != nil {
@@ -264,19 +317,21 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 
 			return nil, 0, err
 		}
+		// Do not keep the data for the next pull.
+		ld.truncateDownloadFile()
 		return nil, 0, xfer.DoNotRetry{Err: err}
 	}
 
 	progress.Update(progressOutput, ld.ID(), "Download complete")
+	if ld.logEvent != nil {
+		ld.logEvent("layer-complete", layerAttributes(ld.digest, offset+copied))
+	}
 
 	logrus.Debugf("Downloaded %s to tempfile %s", ld.ID(), tmpFile.Name())
 
 	_, err = tmpFile.Seek(0, os.SEEK_SET)
 	if err != nil {
-		tmpFile.Close()
-		if err := os.Remove(tmpFile.Name()); err != nil {
-			logrus.Errorf("Failed to remove temp file: %s", tmpFile.Name())
-		}
+		ld.removeDownloadFile(tmpFile, ld.partial)
 		ld.tmpFile = nil
 		ld.verifier = nil
 		return nil, 0, xfer.DoNotRetry{Err: err}
@@ -285,24 +340,76 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	// hand off the temporary file to the download manager, so it will only
 	// be closed once
 	ld.tmpFile = nil
+	partial := ld.partial
 
 	return ioutils.NewReadCloserWrapper(tmpFile, func() error {
-		tmpFile.Close()
-		err := os.RemoveAll(tmpFile.Name())
-		if err != nil {
-			logrus.Errorf("Failed to remove temp file: %s", tmpFile.Name())
-		}
-		return err
+		return ld.removeDownloadFile(tmpFile, partial)
 	}), size, nil
 }
 
 func (ld *v2LayerDescriptor) Close() {
-	if ld.tmpFile != nil {
-		ld.tmpFile.Close()
-		if err := os.RemoveAll(ld.tmpFile.Name()); err != nil {
-			logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
+	if ld.tmpFile == nil {
+		return
+	}
+	if ld.partial {
+		// The partial download is kept, so that the next pull resumes it.
+		if err := closePartialDownload(ld.tmpFile, false); err != nil {
+			logrus.Errorf("Failed to remove partial download: %s", ld.tmpFile.Name())
+		}
+		return
+	}
+	ld.removeDownloadFile(ld.tmpFile, false)
+}
+
+// openDownloadFile opens the file the layer is downloaded to. It is the
+// partial download of the layer if the partial downloads are kept, in which
+// case the data of a previous pull is read to the verifier so that the
+// download resumes after it.
+func (ld *v2LayerDescriptor) openDownloadFile() (*os.File, error) {
+	ld.partial = false
+	if ld.partialDir == "" {
+		return createDownloadFile()
+	}
+
+	f, err := openPartialDownload(ld.partialDir, ld.digest)
+	if err != nil {
+		logrus.Warnf("Failed to open partial download of %s: %v", ld.digest, err)
+		return createDownloadFile()
+	}
+	if f == nil {
+		// the blob is downloaded to its partial download by another pull
+		return createDownloadFile()
+	}
+	verifier, err := digest.NewDigestVerifier(ld.digest)
+	if err != nil {
+		closePartialDownload(f, false)
+		return nil, err
+	}
+	if _, err := io.Copy(verifier, f); err != nil {
+		logrus.Warnf("Failed to read partial download of %s: %v", ld.digest, err)
+		closePartialDownload(f, true)
+		return createDownloadFile()
+	}
+	ld.partial = true
+	ld.verifier = verifier
+	return f, nil
+}
+
+// removeDownloadFile closes and removes the file the layer is downloaded to.
+func (ld *v2LayerDescriptor) removeDownloadFile(f *os.File, partial bool) error {
+	if partial {
+		err := closePartialDownload(f, true)
+		if err != nil {
+			logrus.Errorf("Failed to remove partial download: %s", f.Name())
 		}
+		return err
 	}
+	f.Close()
+	err := os.RemoveAll(f.Name())
+	if err != nil {
+		logrus.Errorf("Failed to remove temp file: %s", f.Name())
+	}
+	return err
 }
 
 func (ld *v2LayerDescriptor) truncateDownloadFile() error {
@@ -324,7 +431,11 @@ func (ld *v2LayerDescriptor) truncateDownloadFile() error {
 
 func (ld *v2LayerDescriptor) Registered(diffID layer.DiffID) {
 	// Cache mapping from this layer's DiffID to the blobsum
-	ld.V2MetadataService.Add(diffID, metadata.V2Metadata{Digest: ld.digest, SourceRepository: ld.repoInfo.FullName()})
+	meta := metadata.V2Metadata{Digest: ld.digest, SourceRepository: ld.repoInfo.FullName()}
+	if ld.src.MediaType == mediaTypeLayerZstd {
+		meta.Compression = "zstd"
+	}
+	ld.V2MetadataService.Add(diffID, meta)
 }
 
 func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdated bool, err error) {
@@ -424,6 +535,13 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdat
 	return true, nil
 }
 
+// eventLogger returns a function logging the events of the pull of ref.
+func (p *v2Puller) eventLogger(ref reference.Named) func(action string, attributes map[string]string) {
+	return func(action string, attributes map[string]string) {
+		logImageEvent(p.config.ImageEventLogger, ref.String(), p.repoInfo.Name(), action, attributes)
+	}
+}
+
 func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Named, unverifiedManifest *schema1.SignedManifest) (id digest.Digest, manifestDigest digest.Digest, err error) {
 	var verifiedManifest *schema1.Manifest
 	verifiedManifest, err = verifySchema1Manifest(unverifiedManifest, ref)
@@ -471,6 +589,9 @@ func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Named, unverif
 			repoInfo:          p.repoInfo,
 			repo:              p.repo,
 			V2MetadataService: p.V2MetadataService,
+			partialDir:        p.config.PartialDownloadsDir,
+			downloadManager:   p.config.DownloadManager,
+			logEvent:          p.eventLogger(ref),
 		}
 
 		descriptors = append(descriptors, layerDescriptor)
@@ -521,6 +642,9 @@ func (p *v2Puller) pullSchema2(ctx context.Context, ref reference.Named, mfst *s
 			repoInfo:          p.repoInfo,
 			V2MetadataService: p.V2MetadataService,
 			src:               d,
+			partialDir:        p.config.PartialDownloadsDir,
+			downloadManager:   p.config.DownloadManager,
+			logEvent:          p.eventLogger(ref),
 		}
 
 		descriptors = append(descriptors, layerDescriptor)
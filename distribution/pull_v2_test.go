@@ -1,16 +1,22 @@
 package distribution
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/docker/distribution"
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/docker/reference"
+	"golang.org/x/net/context"
 )
 
 // TestFixManifestLayers checks that fixManifestLayers removes a duplicate
@@ -181,3 +187,37 @@ func TestValidateManifest(t *testing.T) {
 		t.Fatal("expected validateManifest to fail with digest error")
 	}
 }
+
+func TestOpenForeignLayer(t *testing.T) {
+	content := []byte("foreign layer")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer ts.Close()
+
+	ld := &v2LayerDescriptor{
+		digest: digest.FromBytes(content),
+		src: distribution.Descriptor{
+			MediaType: schema2.MediaTypeForeignLayer,
+			Digest:    digest.FromBytes(content),
+			Size:      int64(len(content)),
+			URLs:      []string{"ftp://example.com/layer.tar", ts.URL},
+		},
+	}
+	if d := ld.Descriptor(); d.Digest != ld.digest {
+		t.Fatalf("expected the descriptor of the foreign layer, got %v", d)
+	}
+
+	rsc, err := ld.open(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rsc.Close()
+	b, err := ioutil.ReadAll(rsc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, content) {
+		t.Fatalf("unexpected content %q", b)
+	}
+}
@@ -2,9 +2,9 @@ package distribution
 
 import (
 	"bufio"
-	"compress/gzip"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
@@ -32,8 +33,10 @@ type ImagePushConfig struct {
 	// RegistryService is the registry service to use for TLS configuration
 	// and endpoint lookup.
 	RegistryService registry.Service
-	// ImageEventLogger notifies events for a given image
-	ImageEventLogger func(id, name, action string)
+	// ImageEventLogger notifies events for a given image. Besides the push
+	// event, the start and the end of the push and the completion of the
+	// upload of each layer are notified.
+	ImageEventLogger func(id, name, action string, attributes map[string]string)
 	// MetadataStore is the storage backend for distribution-specific
 	// metadata.
 	MetadataStore metadata.Store
@@ -48,6 +51,12 @@ type ImagePushConfig struct {
 	TrustKey libtrust.PrivateKey
 	// UploadManager dispatches uploads.
 	UploadManager *xfer.LayerUploadManager
+	// Compression is the compression of the layers uploaded, archive.Zstd
+	// or archive.Gzip, the default.
+	Compression archive.Compression
+	// CompressionLevel is the level of the compression of the layers
+	// uploaded, 0 being the default level of the compression.
+	CompressionLevel int
 }
 
 // Pusher is an interface that abstracts pushing for different API versions.
@@ -91,7 +100,7 @@ func NewPusher(ref reference.Named, endpoint registry.APIEndpoint, repoInfo *reg
 // Push initiates a push operation on ref.
 // ref is the specific variant of the image to be pushed.
 // If no tag is provided, all tags will be pushed.
-func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushConfig) error {
+func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushConfig) (err error) {
 	// FIXME: Allow to interrupt current push when new push of same image is done.
 
 	// Resolve the Repository name from fqn to RepositoryInfo
@@ -100,6 +109,12 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 		return err
 	}
 
+	start := time.Now()
+	logImageEvent(imagePushConfig.ImageEventLogger, ref.String(), repoInfo.Name(), "push-started", nil)
+	defer func() {
+		logImageEvent(imagePushConfig.ImageEventLogger, ref.String(), repoInfo.Name(), "push-finished", finishedAttributes(start, err))
+	}()
+
 	endpoints, err := imagePushConfig.RegistryService.LookupPushEndpoints(repoInfo.Hostname())
 	if err != nil {
 		return err
@@ -168,7 +183,7 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 			return err
 		}
 
-		imagePushConfig.ImageEventLogger(ref.String(), repoInfo.Name(), "push")
+		logImageEvent(imagePushConfig.ImageEventLogger, ref.String(), repoInfo.Name(), "push", nil)
 		return nil
 	}
 
@@ -178,8 +193,9 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 	return lastErr
 }
 
-// compress returns an io.ReadCloser which will supply a compressed version of
-// the provided Reader. The caller must close the ReadCloser after reading the
+// compress returns an io.ReadCloser which will supply a version of the
+// provided Reader compressed with zstd, or gzip for any other compression, at
+// the given level. The caller must close the ReadCloser after reading the
 // compressed data.
 //
 // Note that this function returns a reader instead of taking a writer as an
@@ -191,18 +207,22 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 // is finished. This allows the caller to make sure the goroutine finishes
 // before it releases any resources connected with the reader that was
 // passed in.
-func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
+func compress(in io.Reader, compression archive.Compression, level int) (io.ReadCloser, chan struct{}) {
 	compressionDone := make(chan struct{})
 
 	pipeReader, pipeWriter := io.Pipe()
 	// Use a bufio.Writer to avoid excessive chunking in HTTP request.
 	bufWriter := bufio.NewWriterSize(pipeWriter, compressionBufSize)
-	compressor := gzip.NewWriter(bufWriter)
 
 	go func() {
-		_, err := io.Copy(compressor, in)
+		compressor, err := newCompressor(bufWriter, compression, level)
 		if err == nil {
-			err = compressor.Close()
+			_, err = io.Copy(compressor, in)
+			// The compressor is closed even on error so that the zstd
+			// command exits.
+			if closeErr := compressor.Close(); err == nil {
+				err = closeErr
+			}
 		}
 		if err == nil {
 			err = bufWriter.Flush()
@@ -217,3 +237,12 @@ func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
 
 	return pipeReader, compressionDone
 }
+
+// newCompressor returns a writer compressing to w with zstd, or gzip for any
+// other compression, at the given level.
+func newCompressor(w io.Writer, compression archive.Compression, level int) (io.WriteCloser, error) {
+	if compression != archive.Zstd {
+		compression = archive.Gzip
+	}
+	return archive.CompressStreamLevel(w, compression, level)
+}
@@ -6,8 +6,10 @@ import (
 	"io"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/stringid"
@@ -34,6 +37,10 @@ const (
 	middleLayerMaximumSize = 10 * (1 << 20)  // 10MB
 )
 
+// mediaTypeLayerZstd is the media type of the layers compressed with zstd,
+// which schema2 has no media type of its own for.
+const mediaTypeLayerZstd = "application/vnd.oci.image.layer.v1.tar+zstd"
+
 // PushResult contains the tag, manifest digest, and manifest size from the
 // push. It's used to signal this information to the trust code in the client
 // so it can sign the manifest if necessary.
@@ -155,6 +162,11 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 		ref:               p.ref,
 		repo:              p.repo,
 		pushState:         &p.pushState,
+		compression:       p.config.Compression,
+		compressionLevel:  p.config.CompressionLevel,
+		logEvent: func(action string, attributes map[string]string) {
+			logImageEvent(p.config.ImageEventLogger, p.ref.String(), p.repoInfo.Name(), action, attributes)
+		},
 	}
 
 	// Loop bounds condition is to avoid pushing the base layer on Windows.
@@ -190,6 +202,11 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 			return err
 		}
 
+		if p.config.Compression == archive.Zstd {
+			// The layers of a schema1 manifest must be compressed with gzip.
+			return fmt.Errorf("failed to upload schema2 manifest: %v - the layers compressed with zstd cannot be pushed with a schema1 manifest", err)
+		}
+
 		logrus.Warnf("failed to upload schema2 manifest: %v - falling back to schema1", err)
 
 		manifestRef, err := distreference.WithTag(p.repo.Named(), ref.Tag())
@@ -256,6 +273,12 @@ type v2PushDescriptor struct {
 	remoteDescriptor  distribution.Descriptor
 	// a set of digests whose presence has been checked in a target repository
 	checkedDigests map[digest.Digest]struct{}
+	// compression and compressionLevel are the compression of the layer
+	// uploaded, zstd or gzip for any other compression.
+	compression      archive.Compression
+	compressionLevel int
+	// logEvent logs the events of the image being pushed.
+	logEvent func(action string, attributes map[string]string)
 }
 
 func (pd *v2PushDescriptor) Key() string {
@@ -270,6 +293,23 @@ func (pd *v2PushDescriptor) DiffID() layer.DiffID {
 	return pd.layer.DiffID()
 }
 
+// compressionName returns the compression of the layer as recorded in its v2
+// metadata, empty for gzip.
+func (pd *v2PushDescriptor) compressionName() string {
+	if pd.compression == archive.Zstd {
+		return "zstd"
+	}
+	return ""
+}
+
+// mediaType returns the media type of the layer compressed.
+func (pd *v2PushDescriptor) mediaType() string {
+	if pd.compression == archive.Zstd {
+		return mediaTypeLayerZstd
+	}
+	return schema2.MediaTypeLayer
+}
+
 func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.Output) (distribution.Descriptor, error) {
 	if fs, ok := pd.layer.(distribution.Describable); ok {
 		if d := fs.Descriptor(); len(d.URLs) > 0 {
@@ -294,6 +334,8 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 
 	// Do we have any metadata associated with this layer's DiffID?
 	v2Metadata, err := pd.v2MetadataService.GetMetadata(diffID)
+	// Only the blobs compressed the same way can be reused.
+	v2Metadata = filterV2MetadataByCompression(v2Metadata, pd.compressionName())
 	if err == nil {
 		// check for blob existence in the target repository if we have a mapping with it
 		descriptor, exists, err := pd.layerAlreadyExists(ctx, progressOutput, diffID, false, 1, v2Metadata)
@@ -348,7 +390,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 		case distribution.ErrBlobMounted:
 			progress.Updatef(progressOutput, pd.ID(), "Mounted from %s", err.From.Name())
 
-			err.Descriptor.MediaType = schema2.MediaTypeLayer
+			err.Descriptor.MediaType = pd.mediaType()
 
 			pd.pushState.Lock()
 			pd.pushState.confirmedV2 = true
@@ -359,6 +401,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 			if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 				Digest:           err.Descriptor.Digest,
 				SourceRepository: pd.repoInfo.FullName(),
+				Compression:      pd.compressionName(),
 			}); err != nil {
 				return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 			}
@@ -403,10 +446,25 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 	defer layerUpload.Close()
 
 	// upload the blob
+	start := time.Now()
 	desc, err := pd.uploadUsingSession(ctx, progressOutput, diffID, layerUpload)
 	if err != nil {
 		return desc, err
 	}
+	if pd.logEvent != nil {
+		attributes := layerAttributes(desc.Digest, desc.Size)
+		// The uploaded layers also report how well and how fast they
+		// were compressed.
+		attributes["compression"] = "gzip"
+		if pd.compression == archive.Zstd {
+			attributes["compression"] = "zstd"
+		}
+		if size, err := pd.layer.DiffSize(); err == nil {
+			attributes["uncompressedSize"] = strconv.FormatInt(size, 10)
+		}
+		attributes["duration"] = (time.Since(start) / time.Millisecond * time.Millisecond).String()
+		pd.logEvent("layer-complete", attributes)
+	}
 
 	return desc, nil
 }
@@ -434,7 +492,7 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	size, _ := pd.layer.DiffSize()
 
 	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, arch), progressOutput, size, pd.ID(), "Pushing")
-	compressedReader, compressionDone := compress(reader)
+	compressedReader, compressionDone := compress(reader, pd.compression, pd.compressionLevel)
 	defer func() {
 		reader.Close()
 		<-compressionDone
@@ -461,13 +519,14 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 		Digest:           pushDigest,
 		SourceRepository: pd.repoInfo.FullName(),
+		Compression:      pd.compressionName(),
 	}); err != nil {
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 	}
 
 	desc := distribution.Descriptor{
 		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
+		MediaType: pd.mediaType(),
 		Size:      nn,
 	}
 
@@ -535,11 +594,12 @@ func (pd *v2PushDescriptor) layerAlreadyExists(
 				if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 					Digest:           desc.Digest,
 					SourceRepository: pd.repoInfo.FullName(),
+					Compression:      pd.compressionName(),
 				}); err != nil {
 					return distribution.Descriptor{}, false, xfer.DoNotRetry{Err: err}
 				}
 			}
-			desc.MediaType = schema2.MediaTypeLayer
+			desc.MediaType = pd.mediaType()
 			exists = true
 			break
 		case distribution.ErrBlobUnknown:
@@ -591,6 +651,18 @@ func getMaxMountAndExistenceCheckAttempts(layer layer.Layer) (maxMountAttempts,
 	}
 }
 
+// filterV2MetadataByCompression returns the v2 metadata items of the blobs
+// with the given compression, empty for gzip.
+func filterV2MetadataByCompression(v2Metadata []metadata.V2Metadata, compression string) []metadata.V2Metadata {
+	filtered := []metadata.V2Metadata{}
+	for _, meta := range v2Metadata {
+		if meta.Compression == compression {
+			filtered = append(filtered, meta)
+		}
+	}
+	return filtered
+}
+
 // getRepositoryMountCandidates returns an array of v2 metadata items belonging to the given registry. The
 // array is sorted from youngest to oldest. If requireReigstryMatch is true, the resulting array will contain
 // only metadata entries having registry part of SourceRepository matching the part of repoInfo.
@@ -143,6 +143,26 @@ func TestGetRepositoryMountCandidates(t *testing.T) {
 	}
 }
 
+func TestFilterV2MetadataByCompression(t *testing.T) {
+	gzipped := metadata.V2Metadata{Digest: digest.Digest("sha256:1"), SourceRepository: "user/app"}
+	zstd := metadata.V2Metadata{Digest: digest.Digest("sha256:2"), SourceRepository: "user/app", Compression: "zstd"}
+	for _, tc := range []struct {
+		compression string
+		expected    []metadata.V2Metadata
+	}{
+		{"", []metadata.V2Metadata{gzipped}},
+		{"zstd", []metadata.V2Metadata{zstd}},
+	} {
+		filtered := filterV2MetadataByCompression([]metadata.V2Metadata{gzipped, zstd}, tc.compression)
+		if !reflect.DeepEqual(filtered, tc.expected) {
+			t.Errorf("[%q] unexpected metadata: %#+v != %#+v", tc.compression, filtered, tc.expected)
+		}
+	}
+	if filtered := filterV2MetadataByCompression(nil, ""); len(filtered) != 0 {
+		t.Errorf("unexpected metadata for no metadata: %#+v", filtered)
+	}
+}
+
 func TestLayerAlreadyExists(t *testing.T) {
 	for _, tc := range []struct {
 		name                   string
@@ -28,6 +28,60 @@ func NewV2Repository(ctx context.Context, repoInfo *registry.RepositoryInfo, end
 		repoName = repoInfo.RemoteName()
 	}
 
+	tr, foundVersion, err := newV2Transport(ctx, endpoint, metaHeaders, authConfig, auth.RepositoryScope{
+		Repository: repoName,
+		Actions:    actions,
+	})
+	if err != nil {
+		return nil, foundVersion, err
+	}
+
+	repoNameRef, err := distreference.ParseNamed(repoName)
+	if err != nil {
+		return nil, foundVersion, fallbackError{
+			err:         err,
+			confirmedV2: foundVersion,
+			transportOK: true,
+		}
+	}
+
+	repo, err = client.NewRepository(ctx, repoNameRef, endpoint.URL.String(), tr)
+	if err != nil {
+		err = fallbackError{
+			err:         err,
+			confirmedV2: foundVersion,
+			transportOK: true,
+		}
+	}
+	return
+}
+
+// newV2Registry returns a registry (v2 only) to list the catalog of its
+// repositories.
+func newV2Registry(ctx context.Context, endpoint registry.APIEndpoint, metaHeaders http.Header, authConfig *types.AuthConfig) (client.Registry, bool, error) {
+	tr, foundVersion, err := newV2Transport(ctx, endpoint, metaHeaders, authConfig, auth.RegistryScope{
+		Name:    "catalog",
+		Actions: []string{"*"},
+	})
+	if err != nil {
+		return nil, foundVersion, err
+	}
+
+	reg, err := client.NewRegistry(ctx, endpoint.URL.String(), tr)
+	if err != nil {
+		err = fallbackError{
+			err:         err,
+			confirmedV2: foundVersion,
+			transportOK: true,
+		}
+	}
+	return reg, foundVersion, err
+}
+
+// newV2Transport creates an HTTP transport to the endpoint providing timeout
+// settings and authentication support for the scope, and verifies the remote
+// API version.
+func newV2Transport(ctx context.Context, endpoint registry.APIEndpoint, metaHeaders http.Header, authConfig *types.AuthConfig, scope auth.Scope) (http.RoundTripper, bool, error) {
 	direct := &net.Dialer{
 		Timeout:   30 * time.Second,
 		KeepAlive: 30 * time.Second,
@@ -74,38 +128,14 @@ func NewV2Repository(ctx context.Context, repoInfo *registry.RepositoryInfo, end
 		tokenHandlerOptions := auth.TokenHandlerOptions{
 			Transport:   authTransport,
 			Credentials: creds,
-			Scopes: []auth.Scope{
-				auth.RepositoryScope{
-					Repository: repoName,
-					Actions:    actions,
-				},
-			},
-			ClientID: registry.AuthClientID,
+			Scopes:      []auth.Scope{scope},
+			ClientID:    registry.AuthClientID,
 		}
 		tokenHandler := auth.NewTokenHandlerWithOptions(tokenHandlerOptions)
 		basicHandler := auth.NewBasicHandler(creds)
 		modifiers = append(modifiers, auth.NewAuthorizer(challengeManager, tokenHandler, basicHandler))
 	}
-	tr := transport.NewTransport(base, modifiers...)
-
-	repoNameRef, err := distreference.ParseNamed(repoName)
-	if err != nil {
-		return nil, foundVersion, fallbackError{
-			err:         err,
-			confirmedV2: foundVersion,
-			transportOK: true,
-		}
-	}
-
-	repo, err = client.NewRepository(ctx, repoNameRef, endpoint.URL.String(), tr)
-	if err != nil {
-		err = fallbackError{
-			err:         err,
-			confirmedV2: foundVersion,
-			transportOK: true,
-		}
-	}
-	return
+	return transport.NewTransport(base, modifiers...), foundVersion, nil
 }
 
 type existingTokenHandler struct {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/Sirupsen/logrus"
@@ -14,9 +15,12 @@ import (
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
 )
 
-const maxDownloadAttempts = 5
+// defaultMaxDownloadAttempts is the default number of attempts of a layer
+// download before it fails.
+const defaultMaxDownloadAttempts = 5
 
 // LayerDownloadManager figures out which layers need to be downloaded, then
 // registers and downloads those, taking into account dependencies between
@@ -24,6 +28,21 @@ const maxDownloadAttempts = 5
 type LayerDownloadManager struct {
 	layerStore layer.Store
 	tm         TransferManager
+
+	mu                  sync.Mutex
+	maxDownloadAttempts int
+	rateLimiters        map[string]*rate.Limiter
+}
+
+// RegistryLimits are the limits of the layer downloads from a registry, on
+// top of the limits of all the downloads.
+type RegistryLimits struct {
+	// MaxConcurrentDownloads is the maximum number of concurrent layer
+	// downloads from the registry, or 0 for no limit.
+	MaxConcurrentDownloads int
+	// MaxDownloadRate is the maximum rate, in bytes per second, of all the
+	// layer downloads from the registry together, or 0 for no limit.
+	MaxDownloadRate int64
 }
 
 // SetConcurrency set the max concurrent downloads for each pull
@@ -31,11 +50,102 @@ func (ldm *LayerDownloadManager) SetConcurrency(concurrency int) {
 	ldm.tm.SetConcurrency(concurrency)
 }
 
+// SetMaxDownloadAttempts sets the number of attempts of a layer download
+// before it fails. It applies to the downloads which start afterwards.
+func (ldm *LayerDownloadManager) SetMaxDownloadAttempts(attempts int) {
+	ldm.mu.Lock()
+	ldm.maxDownloadAttempts = attempts
+	ldm.mu.Unlock()
+}
+
+// SetRegistryLimits sets the limits of the downloads from the registries,
+// by registry name. A download waiting for a registry at its concurrency
+// limit does not hold back the downloads from the other registries. The rate
+// limits apply to the downloads in progress.
+func (ldm *LayerDownloadManager) SetRegistryLimits(limits map[string]RegistryLimits) {
+	concurrency := make(map[string]int)
+	for name, l := range limits {
+		if l.MaxConcurrentDownloads != 0 {
+			concurrency[name] = l.MaxConcurrentDownloads
+		}
+	}
+	ldm.tm.SetGroupConcurrency(concurrency)
+
+	ldm.mu.Lock()
+	defer ldm.mu.Unlock()
+	if ldm.rateLimiters == nil {
+		ldm.rateLimiters = make(map[string]*rate.Limiter)
+	}
+	for name, limiter := range ldm.rateLimiters {
+		if _, ok := limits[name]; !ok {
+			limiter.SetLimit(rate.Inf)
+		}
+	}
+	for name, l := range limits {
+		limit := rate.Inf
+		if l.MaxDownloadRate != 0 {
+			limit = rate.Limit(l.MaxDownloadRate)
+		}
+		if limiter, ok := ldm.rateLimiters[name]; ok {
+			limiter.SetLimit(limit)
+		} else if limit != rate.Inf {
+			ldm.rateLimiters[name] = rate.NewLimiter(limit, rateLimitBurst)
+		}
+	}
+}
+
+// RateLimitReader returns a reader which limits the rate of rc to the
+// download rate limit of the registry, shared by all the downloads from it.
+// It returns rc if the registry does not have a rate limit.
+func (ldm *LayerDownloadManager) RateLimitReader(ctx context.Context, registry string, rc io.ReadCloser) io.ReadCloser {
+	ldm.mu.Lock()
+	limiter := ldm.rateLimiters[registry]
+	ldm.mu.Unlock()
+	if limiter == nil {
+		return rc
+	}
+	return &rateLimitedReader{ctx: ctx, rc: rc, limiter: limiter}
+}
+
+// rateLimitBurst is the maximum number of bytes read at once by a rate
+// limited reader.
+const rateLimitBurst = 32 * 1024
+
+type rateLimitedReader struct {
+	ctx     context.Context
+	rc      io.ReadCloser
+	limiter *rate.Limiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rateLimitBurst {
+		p = p[:rateLimitBurst]
+	}
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+func (r *rateLimitedReader) Close() error {
+	return r.rc.Close()
+}
+
+func (ldm *LayerDownloadManager) getMaxDownloadAttempts() int {
+	ldm.mu.Lock()
+	defer ldm.mu.Unlock()
+	return ldm.maxDownloadAttempts
+}
+
 // NewLayerDownloadManager returns a new LayerDownloadManager.
 func NewLayerDownloadManager(layerStore layer.Store, concurrencyLimit int) *LayerDownloadManager {
 	return &LayerDownloadManager{
-		layerStore: layerStore,
-		tm:         NewTransferManager(concurrencyLimit),
+		layerStore:          layerStore,
+		tm:                  NewTransferManager(concurrencyLimit),
+		maxDownloadAttempts: defaultMaxDownloadAttempts,
 	}
 }
 
@@ -81,6 +191,15 @@ type DownloadDescriptorWithRegistered interface {
 	Registered(diffID layer.DiffID)
 }
 
+// DownloadDescriptorWithRegistry is a DownloadDescriptor that downloads the
+// layer from a registry. The download counts against the concurrency limit
+// of the registry, set by SetRegistryLimits. This method is called if a
+// cast to DownloadDescriptorWithRegistry is successful.
+type DownloadDescriptorWithRegistry interface {
+	DownloadDescriptor
+	Registry() string
+}
+
 // Download is a blocking function which ensures the requested layers are
 // present in the layer store. It uses the string returned by the Key method to
 // deduplicate downloads. If a given layer is not already known to present in
@@ -146,7 +265,11 @@ func (ldm *LayerDownloadManager) Download(ctx context.Context, initialRootFS ima
 		} else {
 			xferFunc = ldm.makeDownloadFunc(descriptor, rootFS.ChainID(), nil)
 		}
-		topDownloadUncasted, watcher = ldm.tm.Transfer(transferKey, xferFunc, progressOutput)
+		var registry string
+		if withRegistry, ok := descriptor.(DownloadDescriptorWithRegistry); ok {
+			registry = withRegistry.Registry()
+		}
+		topDownloadUncasted, watcher = ldm.tm.TransferInGroup(registry, transferKey, xferFunc, progressOutput)
 		topDownload = topDownloadUncasted.(*downloadTransfer)
 		downloadsByKey[key] = topDownload
 	}
@@ -245,6 +368,8 @@ func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor,
 
 			defer descriptor.Close()
 
+			maxDownloadAttempts := ldm.getMaxDownloadAttempts()
+
 			for {
 				downloadReader, size, err = descriptor.Download(d.Transfer.Context(), progressOutput)
 				if err == nil {
@@ -261,7 +386,7 @@ func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor,
 				}
 
 				retries++
-				if _, isDNR := err.(DoNotRetry); isDNR || retries == maxDownloadAttempts {
+				if _, isDNR := err.(DoNotRetry); isDNR || retries >= maxDownloadAttempts {
 					logrus.Errorf("Download failed: %v", err)
 					d.err = err
 					return
@@ -349,3 +349,65 @@ func TestCancelledDownload(t *testing.T) {
 	close(progressChan)
 	<-progressDone
 }
+
+func TestMaxDownloadAttempts(t *testing.T) {
+	ldm := NewLayerDownloadManager(&mockLayerStore{make(map[layer.ChainID]*mockLayer)}, maxDownloadConcurrency)
+	ldm.SetMaxDownloadAttempts(1)
+
+	progressChan := make(chan progress.Progress)
+	progressDone := make(chan struct{})
+
+	go func() {
+		for range progressChan {
+		}
+		close(progressDone)
+	}()
+
+	descriptors := []DownloadDescriptor{
+		&mockDownloadDescriptor{
+			id:              "id1",
+			simulateRetries: 1,
+		},
+	}
+	_, _, err := ldm.Download(context.Background(), *image.NewRootFS(), descriptors, progress.ChanOutput(progressChan))
+	if err == nil || err.Error() != "simulating retry" {
+		t.Fatalf("expected the download to fail without a retry, got %v", err)
+	}
+
+	close(progressChan)
+	<-progressDone
+}
+
+func TestRegistryRateLimit(t *testing.T) {
+	ldm := NewLayerDownloadManager(&mockLayerStore{make(map[layer.ChainID]*mockLayer)}, maxDownloadConcurrency)
+	ldm.SetRegistryLimits(map[string]RegistryLimits{"registry.example.com": {MaxDownloadRate: 64 * 1024}})
+
+	rc := ioutil.NopCloser(bytes.NewReader(make([]byte, 96*1024)))
+	if r := ldm.RateLimitReader(context.Background(), "docker.io", rc); r != rc {
+		t.Fatal("expected the download from a registry without limits not to be limited")
+	}
+
+	start := time.Now()
+	n, err := io.Copy(ioutil.Discard, ldm.RateLimitReader(context.Background(), "registry.example.com", rc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 96*1024 {
+		t.Fatalf("expected to read %d bytes, got %d", 96*1024, n)
+	}
+	// the first 32KB are read at once, the next 64KB take a second
+	if elapsed := time.Since(start); elapsed < 800*time.Millisecond {
+		t.Fatalf("expected the download to be limited, it took %v", elapsed)
+	}
+
+	// the limits are removed by a reload
+	ldm.SetRegistryLimits(nil)
+	rc = ioutil.NopCloser(bytes.NewReader(make([]byte, 1024*1024)))
+	start = time.Now()
+	if _, err := io.Copy(ioutil.Discard, ldm.RateLimitReader(context.Background(), "registry.example.com", rc)); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the download not to be limited, it took %v", elapsed)
+	}
+}
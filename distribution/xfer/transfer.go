@@ -279,8 +279,21 @@ type TransferManager interface {
 	// so, it returns progress and error output from that transfer.
 	// Otherwise, it will call xferFunc to initiate the transfer.
 	Transfer(key string, xferFunc DoFunc, progressOutput progress.Output) (Transfer, *Watcher)
+	// TransferInGroup is like Transfer, but the transfer also counts
+	// against the concurrency limit of its group, if the group has one.
+	TransferInGroup(group, key string, xferFunc DoFunc, progressOutput progress.Output) (Transfer, *Watcher)
 	// SetConcurrency set the concurrencyLimit so that it is adjustable daemon reload
 	SetConcurrency(concurrency int)
+	// SetGroupConcurrency sets the concurrency limits of the groups of
+	// transfers. The transfers of the groups without a limit are only
+	// limited by the concurrencyLimit.
+	SetGroupConcurrency(limits map[string]int)
+}
+
+// waitingTransfer is a transfer waiting for a slot to start.
+type waitingTransfer struct {
+	start chan struct{}
+	group string
 }
 
 type transferManager struct {
@@ -289,7 +302,10 @@ type transferManager struct {
 	concurrencyLimit int
 	activeTransfers  int
 	transfers        map[string]Transfer
-	waitingTransfers []chan struct{}
+	waitingTransfers []waitingTransfer
+
+	groupLimits  map[string]int
+	activeGroups map[string]int
 }
 
 // NewTransferManager returns a new TransferManager.
@@ -297,6 +313,7 @@ func NewTransferManager(concurrencyLimit int) TransferManager {
 	return &transferManager{
 		concurrencyLimit: concurrencyLimit,
 		transfers:        make(map[string]Transfer),
+		activeGroups:     make(map[string]int),
 	}
 }
 
@@ -307,10 +324,52 @@ func (tm *transferManager) SetConcurrency(concurrency int) {
 	tm.mu.Unlock()
 }
 
+// SetGroupConcurrency sets the concurrency limits of the groups, and starts
+// the waiting transfers which are no longer held back by their group.
+func (tm *transferManager) SetGroupConcurrency(limits map[string]int) {
+	tm.mu.Lock()
+	tm.groupLimits = limits
+	tm.startWaiting()
+	tm.mu.Unlock()
+}
+
+// canStart returns whether a transfer of the group can start without
+// exceeding the concurrency limits.
+func (tm *transferManager) canStart(group string) bool {
+	if tm.concurrencyLimit != 0 && tm.activeTransfers >= tm.concurrencyLimit {
+		return false
+	}
+	limit, ok := tm.groupLimits[group]
+	return group == "" || !ok || limit == 0 || tm.activeGroups[group] < limit
+}
+
+// startWaiting starts the waiting transfers which can start, in order. A
+// transfer held back by the limit of its group does not hold back the
+// transfers of the other groups.
+func (tm *transferManager) startWaiting() {
+	waiting := tm.waitingTransfers[:0]
+	for _, w := range tm.waitingTransfers {
+		if !tm.canStart(w.group) {
+			waiting = append(waiting, w)
+			continue
+		}
+		close(w.start)
+		tm.activeTransfers++
+		tm.activeGroups[w.group]++
+	}
+	tm.waitingTransfers = waiting
+}
+
 // Transfer checks if a transfer matching the given key is in progress. If not,
 // it starts one by calling xferFunc. The caller supplies a channel which
 // receives progress output from the transfer.
 func (tm *transferManager) Transfer(key string, xferFunc DoFunc, progressOutput progress.Output) (Transfer, *Watcher) {
+	return tm.TransferInGroup("", key, xferFunc, progressOutput)
+}
+
+// TransferInGroup is like Transfer, but the transfer also counts against the
+// concurrency limit of its group.
+func (tm *transferManager) TransferInGroup(group, key string, xferFunc DoFunc, progressOutput progress.Output) (Transfer, *Watcher) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
@@ -346,11 +405,12 @@ func (tm *transferManager) Transfer(key string, xferFunc DoFunc, progressOutput
 	start := make(chan struct{})
 	inactive := make(chan struct{})
 
-	if tm.concurrencyLimit == 0 || tm.activeTransfers < tm.concurrencyLimit {
+	if tm.canStart(group) {
 		close(start)
 		tm.activeTransfers++
+		tm.activeGroups[group]++
 	} else {
-		tm.waitingTransfers = append(tm.waitingTransfers, start)
+		tm.waitingTransfers = append(tm.waitingTransfers, waitingTransfer{start: start, group: group})
 	}
 
 	masterProgressChan := make(chan progress.Progress)
@@ -365,13 +425,13 @@ func (tm *transferManager) Transfer(key string, xferFunc DoFunc, progressOutput
 			select {
 			case <-inactive:
 				tm.mu.Lock()
-				tm.inactivate(start)
+				tm.inactivate(start, group)
 				tm.mu.Unlock()
 				inactive = nil
 			case <-xfer.Done():
 				tm.mu.Lock()
 				if inactive != nil {
-					tm.inactivate(start)
+					tm.inactivate(start, group)
 				}
 				delete(tm.transfers, key)
 				tm.mu.Unlock()
@@ -384,18 +444,17 @@ func (tm *transferManager) Transfer(key string, xferFunc DoFunc, progressOutput
 	return xfer, watcher
 }
 
-func (tm *transferManager) inactivate(start chan struct{}) {
+func (tm *transferManager) inactivate(start chan struct{}, group string) {
 	// If the transfer was started, remove it from the activeTransfers
 	// count.
 	select {
 	case <-start:
-		// Start next transfer if any are waiting
-		if len(tm.waitingTransfers) != 0 {
-			close(tm.waitingTransfers[0])
-			tm.waitingTransfers = tm.waitingTransfers[1:]
-		} else {
-			tm.activeTransfers--
+		tm.activeTransfers--
+		if tm.activeGroups[group]--; tm.activeGroups[group] <= 0 {
+			delete(tm.activeGroups, group)
 		}
+		// Start next transfer if any are waiting
+		tm.startWaiting()
 	default:
 	}
 }
@@ -125,6 +125,76 @@ func TestConcurrencyLimit(t *testing.T) {
 	}
 }
 
+func TestGroupConcurrencyLimit(t *testing.T) {
+	var runningSlowJobs int32
+	release := make(chan struct{})
+
+	makeXferFunc := func(slow bool, started chan struct{}) DoFunc {
+		return func(progressChan chan<- progress.Progress, start <-chan struct{}, inactive chan<- struct{}) Transfer {
+			xfer := NewTransfer()
+			go func() {
+				<-start
+				close(started)
+				if slow {
+					if atomic.AddInt32(&runningSlowJobs, 1) > 1 {
+						t.Errorf("too many jobs of the group running")
+					}
+					<-release
+					atomic.AddInt32(&runningSlowJobs, -1)
+				}
+				close(progressChan)
+			}()
+			return xfer
+		}
+	}
+
+	tm := NewTransferManager(3)
+	tm.SetGroupConcurrency(map[string]int{"slow": 1})
+	progressChan := make(chan progress.Progress)
+	progressDone := make(chan struct{})
+
+	go func() {
+		for range progressChan {
+		}
+		close(progressDone)
+	}()
+
+	var (
+		xfers    []Transfer
+		watchers []*Watcher
+		started  []chan struct{}
+	)
+	// The transfers of the slow group wait for each other, but do not
+	// hold back the transfers queued after them.
+	for i, id := range []string{"slow1", "slow2", "slow3", "fast1", "fast2"} {
+		group := "slow"
+		if i >= 3 {
+			group = "fast"
+		}
+		c := make(chan struct{})
+		xfer, watcher := tm.TransferInGroup(group, id, makeXferFunc(group == "slow", c), progress.ChanOutput(progressChan))
+		xfers = append(xfers, xfer)
+		watchers = append(watchers, watcher)
+		started = append(started, c)
+	}
+
+	for _, c := range started[3:] {
+		select {
+		case <-c:
+		case <-time.After(5 * time.Second):
+			t.Fatal("transfer held back by the limit of another group")
+		}
+	}
+	close(release)
+
+	for i, xfer := range xfers {
+		<-xfer.Done()
+		xfer.Release(watchers[i])
+	}
+	close(progressChan)
+	<-progressDone
+}
+
 func TestInactiveJobs(t *testing.T) {
 	concurrencyLimit := 3
 	var runningJobs int32
@@ -92,6 +92,15 @@ func (img *Image) RunConfig() *container.Config {
 	return img.Config
 }
 
+// TopLayer returns the DiffID of the top layer of the image, or an empty
+// string if the image has no layers.
+func (img *Image) TopLayer() string {
+	if img.RootFS == nil || len(img.RootFS.DiffIDs) == 0 {
+		return ""
+	}
+	return img.RootFS.DiffIDs[len(img.RootFS.DiffIDs)-1].String()
+}
+
 // MarshalJSON serializes the image to JSON. It sorts the top-level keys so
 // that JSON that's been manipulated by a push/pull cycle with a legacy
 // registry won't end up with a different key order.
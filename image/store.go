@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/Sirupsen/logrus"
@@ -14,6 +16,7 @@ import (
 // Store is an interface for creating and accessing images
 type Store interface {
 	Create(config []byte) (ID, error)
+	CreateHeld(config []byte, holder string) (ID, error)
 	Get(id ID) (*Image, error)
 	Delete(id ID) ([]layer.Metadata, error)
 	Search(partialID string) (ID, error)
@@ -22,6 +25,9 @@ type Store interface {
 	Children(id ID) []ID
 	Map() map[ID]*Image
 	Heads() map[ID]*Image
+	Hold(id ID, holder string) error
+	Unhold(id ID, holder string)
+	Holders(id ID) []string
 }
 
 // LayerGetReleaser is a minimal interface for getting and releasing images.
@@ -33,6 +39,9 @@ type LayerGetReleaser interface {
 type imageMeta struct {
 	layer    layer.Layer
 	children map[ID]struct{}
+	// holders counts the holds of the image, which prevent its deletion,
+	// by holder.
+	holders map[string]int
 }
 
 type store struct {
@@ -104,6 +113,18 @@ func (is *store) restore() error {
 }
 
 func (is *store) Create(config []byte) (ID, error) {
+	return is.create(config, "")
+}
+
+// CreateHeld creates the image and holds it for the holder, which releases
+// it with Unhold. The image is held as it is created, so that it cannot be
+// deleted before the holder uses it.
+func (is *store) CreateHeld(config []byte, holder string) (ID, error) {
+	return is.create(config, holder)
+}
+
+// create creates the image, and holds it for holder if it is not empty.
+func (is *store) create(config []byte, holder string) (ID, error) {
 	var img Image
 	err := json.Unmarshal(config, &img)
 	if err != nil {
@@ -127,16 +148,19 @@ func (is *store) Create(config []byte) (ID, error) {
 		return "", errors.New("too many non-empty layers in History section")
 	}
 
+	// The configuration is written with the lock held, so that a concurrent
+	// deletion of the same image cannot remove it once it is written.
+	is.Lock()
+	defer is.Unlock()
+
 	dgst, err := is.fs.Set(config)
 	if err != nil {
 		return "", err
 	}
 	imageID := IDFromDigest(dgst)
 
-	is.Lock()
-	defer is.Unlock()
-
-	if _, exists := is.images[imageID]; exists {
+	if imageMeta, exists := is.images[imageID]; exists {
+		imageMeta.hold(holder)
 		return imageID, nil
 	}
 
@@ -146,6 +170,7 @@ func (is *store) Create(config []byte) (ID, error) {
 	if layerID != "" {
 		l, err = is.ls.Get(layerID)
 		if err != nil {
+			is.fs.Delete(dgst)
 			return "", err
 		}
 	}
@@ -158,8 +183,13 @@ func (is *store) Create(config []byte) (ID, error) {
 	is.images[imageID] = imageMeta
 	if err := is.digestSet.Add(imageID.Digest()); err != nil {
 		delete(is.images, imageID)
+		is.fs.Delete(dgst)
+		if l != nil {
+			is.ls.Release(l)
+		}
 		return "", err
 	}
+	imageMeta.hold(holder)
 
 	return imageID, nil
 }
@@ -208,6 +238,9 @@ func (is *store) Delete(id ID) ([]layer.Metadata, error) {
 	if imageMeta == nil {
 		return nil, fmt.Errorf("unrecognized image ID %s", id.String())
 	}
+	if len(imageMeta.holders) > 0 {
+		return nil, fmt.Errorf("image %s is in use by %s", id.String(), strings.Join(is.holders(id), ", "))
+	}
 	for id := range imageMeta.children {
 		is.fs.DeleteMetadata(id.Digest(), "parent")
 	}
@@ -266,6 +299,66 @@ func (is *store) children(id ID) []ID {
 	return ids
 }
 
+// Hold prevents the deletion of the image until the holder releases it with
+// Unhold. An image can be held several times, by several holders.
+func (is *store) Hold(id ID, holder string) error {
+	is.Lock()
+	defer is.Unlock()
+
+	imageMeta := is.images[id]
+	if imageMeta == nil {
+		return fmt.Errorf("unrecognized image ID %s", id.String())
+	}
+	imageMeta.hold(holder)
+	return nil
+}
+
+// hold adds a hold of the holder, if it is not empty, on the image. The
+// caller must hold the lock of the store.
+func (imageMeta *imageMeta) hold(holder string) {
+	if holder == "" {
+		return
+	}
+	if imageMeta.holders == nil {
+		imageMeta.holders = make(map[string]int)
+	}
+	imageMeta.holders[holder]++
+}
+
+// Unhold releases a hold of the holder on the image.
+func (is *store) Unhold(id ID, holder string) {
+	is.Lock()
+	defer is.Unlock()
+
+	imageMeta := is.images[id]
+	if imageMeta == nil || imageMeta.holders[holder] == 0 {
+		return
+	}
+	imageMeta.holders[holder]--
+	if imageMeta.holders[holder] == 0 {
+		delete(imageMeta.holders, holder)
+	}
+}
+
+// Holders returns the sorted holders of the image.
+func (is *store) Holders(id ID) []string {
+	is.Lock()
+	defer is.Unlock()
+
+	return is.holders(id)
+}
+
+func (is *store) holders(id ID) []string {
+	var holders []string
+	if is.images[id] != nil {
+		for holder := range is.images[id].holders {
+			holders = append(holders, holder)
+		}
+	}
+	sort.Strings(holders)
+	return holders
+}
+
 func (is *store) Heads() map[ID]*Image {
 	return is.imagesMap(false)
 }
@@ -3,6 +3,7 @@ package image
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/docker/distribution/digest"
@@ -289,6 +290,70 @@ func TestParentReset(t *testing.T) {
 
 }
 
+func TestHold(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "images-fs-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	fs, err := NewFSStoreBackend(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	is, err := NewImageStore(fs, &mockLayerGetReleaser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := is.Create([]byte(`{"comment": "abc", "rootfs": {"type": "layers"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := is.Hold(ID("sha256:0123"), "build a"); err == nil {
+		t.Fatal("expected an error for the hold of an unknown image")
+	}
+	for _, holder := range []string{"build b", "build a", "build a"} {
+		if err := is.Hold(id, holder); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if holders := is.Holders(id); len(holders) != 2 || holders[0] != "build a" || holders[1] != "build b" {
+		t.Fatalf("unexpected holders %v", holders)
+	}
+
+	is.Unhold(id, "build a")
+	is.Unhold(id, "build b")
+	if _, err := is.Delete(id); err == nil || !strings.Contains(err.Error(), "in use by build a") {
+		t.Fatalf("expected the held image not to be deleted, got %v", err)
+	}
+
+	is.Unhold(id, "build a")
+	if holders := is.Holders(id); len(holders) != 0 {
+		t.Fatalf("unexpected holders %v", holders)
+	}
+	if _, err := is.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+
+	// An image created held, or held again by its creation, cannot be
+	// deleted before it is released.
+	for i := 0; i < 2; i++ {
+		if id, err = is.CreateHeld([]byte(`{"comment": "abc", "rootfs": {"type": "layers"}}`), "build c"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	is.Unhold(id, "build c")
+	if _, err := is.Delete(id); err == nil || !strings.Contains(err.Error(), "in use by build c") {
+		t.Fatalf("expected the held image not to be deleted, got %v", err)
+	}
+	is.Unhold(id, "build c")
+	if _, err := is.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+}
+
 type mockLayerGetReleaser struct{}
 
 func (ls *mockLayerGetReleaser) Get(layer.ChainID) (layer.Layer, error) {
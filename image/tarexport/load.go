@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution"
@@ -30,7 +31,8 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 		progressOutput progress.Output
 	)
 	if !quiet {
-		progressOutput = sf.NewProgressOutput(outStream, false)
+		// The layers of several images are loaded at once.
+		progressOutput = sf.NewProgressOutput(&syncWriter{w: outStream}, false)
 	}
 	outStream = &streamformatter.StdoutFormatter{Writer: outStream, StreamFormatter: streamformatter.NewJSONStreamFormatter()}
 
@@ -66,7 +68,9 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	var imageIDsStr string
 	var imageRefCount int
 
-	for _, m := range manifest {
+	configs := make([][]byte, len(manifest))
+	imgs := make([]*image.Image, len(manifest))
+	for i, m := range manifest {
 		configPath, err := safePath(tmpDir, m.Config)
 		if err != nil {
 			return err
@@ -79,36 +83,27 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 		if err != nil {
 			return err
 		}
-		var rootFS image.RootFS
-		rootFS = *img.RootFS
-		rootFS.DiffIDs = nil
 
 		if expected, actual := len(m.Layers), len(img.RootFS.DiffIDs); expected != actual {
 			return fmt.Errorf("invalid manifest, layers length mismatch: expected %q, got %q", expected, actual)
 		}
+		configs[i] = config
+		imgs[i] = img
+	}
 
-		for i, diffID := range img.RootFS.DiffIDs {
-			layerPath, err := safePath(tmpDir, m.Layers[i])
-			if err != nil {
-				return err
-			}
-			r := rootFS
-			r.Append(diffID)
-			newLayer, err := l.ls.Get(r.ChainID())
-			if err != nil {
-				newLayer, err = l.loadLayer(layerPath, rootFS, diffID.String(), m.LayerSources[diffID], progressOutput)
-				if err != nil {
-					return err
-				}
-			}
-			defer layer.ReleaseAndLog(l.ls, newLayer)
-			if expected, actual := diffID, newLayer.DiffID(); expected != actual {
-				return fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
-			}
-			rootFS.Append(diffID)
-		}
+	loader := &layerLoader{
+		tarexporter:    l,
+		tmpDir:         tmpDir,
+		progressOutput: progressOutput,
+		loads:          make(map[layer.ChainID]*layerLoad),
+	}
+	defer loader.release()
+	if err := loader.loadImages(manifest, imgs); err != nil {
+		return err
+	}
 
-		imgID, err := l.is.Create(config)
+	for i, m := range manifest {
+		imgID, err := l.is.Create(configs[i])
 		if err != nil {
 			return err
 		}
@@ -148,6 +143,133 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	return nil
 }
 
+// layerLoader loads the layers of the images of an archive, each layer once
+// whatever the number of images sharing it, and holds a reference to them
+// until it is released.
+type layerLoader struct {
+	*tarexporter
+	tmpDir         string
+	progressOutput progress.Output
+
+	mu    sync.Mutex
+	loads map[layer.ChainID]*layerLoad
+}
+
+// layerLoad is the load of a layer, which is done once done is closed.
+type layerLoad struct {
+	done  chan struct{}
+	layer layer.Layer
+	err   error
+}
+
+// loadImages loads the layers of the images of the manifest of an archive,
+// those of up to the concurrency of the exporter images at once.
+func (ll *layerLoader) loadImages(manifest []manifestItem, imgs []*image.Image) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, ll.concurrency())
+	for i := range manifest {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			// Stop loading layers once one of them failed.
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(m manifestItem, img *image.Image) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := ll.loadImage(m, img); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(manifest[i], imgs[i])
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// loadImage loads the layers of an image of the archive, in order.
+func (ll *layerLoader) loadImage(m manifestItem, img *image.Image) error {
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+	for i, diffID := range img.RootFS.DiffIDs {
+		layerPath, err := safePath(ll.tmpDir, m.Layers[i])
+		if err != nil {
+			return err
+		}
+		parent := rootFS
+		rootFS.Append(diffID)
+		newLayer, err := ll.load(rootFS.ChainID(), func() (layer.Layer, error) {
+			if l, err := ll.ls.Get(rootFS.ChainID()); err == nil {
+				return l, nil
+			}
+			return ll.loadLayer(layerPath, parent, diffID.String(), m.LayerSources[diffID], ll.progressOutput)
+		})
+		if err != nil {
+			return err
+		}
+		if expected, actual := diffID, newLayer.DiffID(); expected != actual {
+			return fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
+		}
+	}
+	return nil
+}
+
+// load returns the layer of chainID loaded by loadFunc, which is only called
+// by the first of the images sharing the layer, the others waiting for it.
+func (ll *layerLoader) load(chainID layer.ChainID, loadFunc func() (layer.Layer, error)) (layer.Layer, error) {
+	ll.mu.Lock()
+	if ld, ok := ll.loads[chainID]; ok {
+		ll.mu.Unlock()
+		<-ld.done
+		return ld.layer, ld.err
+	}
+	ld := &layerLoad{done: make(chan struct{})}
+	ll.loads[chainID] = ld
+	ll.mu.Unlock()
+
+	ld.layer, ld.err = loadFunc()
+	close(ld.done)
+	return ld.layer, ld.err
+}
+
+// release releases the layers loaded.
+func (ll *layerLoader) release() {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	for _, ld := range ll.loads {
+		if ld.layer != nil {
+			layer.ReleaseAndLog(ll.ls, ld.layer)
+		}
+	}
+	ll.loads = nil
+}
+
+// syncWriter serializes the writes to w.
+type syncWriter struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func (s *syncWriter) Write(b []byte) (count int, err error) {
+	s.mu.Lock()
+	count, err = s.w.Write(b)
+	s.mu.Unlock()
+	return
+}
+
 func (l *tarexporter) setParentID(id, parentID image.ID) error {
 	img, err := l.is.Get(id)
 	if err != nil {
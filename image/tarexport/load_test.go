@@ -0,0 +1,43 @@
+package tarexport
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/docker/docker/layer"
+)
+
+func TestLayerLoaderLoadsLayersOnce(t *testing.T) {
+	ll := &layerLoader{
+		tarexporter: &tarexporter{},
+		loads:       make(map[layer.ChainID]*layerLoad),
+	}
+	loadErr := errors.New("load failed")
+
+	var calls int32
+	ready := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ready
+			_, err := ll.load(layer.ChainID("sha256:1"), func() (layer.Layer, error) {
+				atomic.AddInt32(&calls, 1)
+				return nil, loadErr
+			})
+			if err != loadErr {
+				t.Errorf("expected the error of the load, got %v", err)
+			}
+		}()
+	}
+	close(ready)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected the layer to be loaded once, got %d loads", calls)
+	}
+	ll.release()
+}
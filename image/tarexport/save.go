@@ -1,12 +1,14 @@
 package tarexport
 
 import (
+	"archive/tar"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/docker/distribution"
@@ -30,6 +32,24 @@ type saveSession struct {
 	images      map[image.ID]*imageDescriptor
 	savedLayers map[string]struct{}
 	diffIDPaths map[layer.DiffID]string // cache every diffID blob to avoid duplicates
+	// layerJobs are the layers to write to the archive, each one once
+	// whatever the number of images sharing it.
+	layerJobs []layerJob
+}
+
+// layerJob is a layer to write to the archive, in the directory of the v1
+// image it is the layer of.
+type layerJob struct {
+	layer       layer.Layer
+	outDir      string
+	createdTime time.Time
+}
+
+// layerResult is a layer ready to be written to the archive, unless err is
+// set.
+type layerResult struct {
+	job layerJob
+	err error
 }
 
 func (l *tarexporter) Save(names []string, outStream io.Writer) error {
@@ -119,6 +139,7 @@ func (l *tarexporter) parseNames(names []string) (map[image.ID]*imageDescriptor,
 func (s *saveSession) save(outStream io.Writer) error {
 	s.savedLayers = make(map[string]struct{})
 	s.diffIDPaths = make(map[layer.DiffID]string)
+	defer s.releaseLayers()
 
 	// get image json
 	tempDir, err := ioutil.TempDir("", "docker-export-")
@@ -208,16 +229,16 @@ func (s *saveSession) save(outStream io.Writer) error {
 		return err
 	}
 
-	fs, err := archive.Tar(tempDir, archive.Uncompressed)
-	if err != nil {
+	// The layers are streamed first, as soon as each one is ready, and the
+	// metadata of the images after them.
+	tw := tar.NewWriter(outStream)
+	if err := s.writeLayers(tw); err != nil {
 		return err
 	}
-	defer fs.Close()
-
-	if _, err := io.Copy(outStream, fs); err != nil {
+	if err := addTarFiles(tw, tempDir); err != nil {
 		return err
 	}
-	return nil
+	return tw.Close()
 }
 
 func (s *saveSession) saveImage(id image.ID) (map[layer.DiffID]distribution.Descriptor, error) {
@@ -306,46 +327,211 @@ func (s *saveSession) saveLayer(id layer.ChainID, legacyImg image.V1Image, creat
 	if err != nil {
 		return distribution.Descriptor{}, err
 	}
-	defer layer.ReleaseAndLog(s.ls, l)
+
+	var src distribution.Descriptor
+	if fs, ok := l.(distribution.Describable); ok {
+		src = fs.Descriptor()
+	}
 
 	if oldPath, exists := s.diffIDPaths[l.DiffID()]; exists {
+		layer.ReleaseAndLog(s.ls, l)
 		relPath, err := filepath.Rel(outDir, oldPath)
 		if err != nil {
 			return distribution.Descriptor{}, err
 		}
 		os.Symlink(relPath, layerPath)
 	} else {
+		// The layer is written by writeLayers, keeping the reference.
+		s.layerJobs = append(s.layerJobs, layerJob{
+			layer:       l,
+			outDir:      outDir,
+			createdTime: createdTime,
+		})
+		s.diffIDPaths[l.DiffID()] = layerPath
+	}
+	s.savedLayers[legacyImg.ID] = struct{}{}
 
-		tarFile, err := os.Create(layerPath)
-		if err != nil {
-			return distribution.Descriptor{}, err
+	return src, nil
+}
+
+// releaseLayers releases the layers which are still to write to the archive.
+func (s *saveSession) releaseLayers() {
+	for _, job := range s.layerJobs {
+		layer.ReleaseAndLog(s.ls, job.layer)
+	}
+	s.layerJobs = nil
+}
+
+// writeLayers writes the layers queued by saveLayer to the archive. Up to the
+// concurrency of the exporter, the layers are prepared at once in the
+// temporary directory of the save, and each one is written to the archive as
+// soon as it is ready, then removed from the temporary directory.
+func (s *saveSession) writeLayers(tw *tar.Writer) error {
+	jobs := s.layerJobs
+	s.layerJobs = nil
+	defer func() {
+		for _, job := range jobs {
+			layer.ReleaseAndLog(s.ls, job.layer)
 		}
-		defer tarFile.Close()
+	}()
+
+	jobCh := make(chan layerJob)
+	cancel := make(chan struct{})
+	go func() {
+		defer close(jobCh)
+		for _, job := range jobs {
+			select {
+			case jobCh <- job:
+			case <-cancel:
+				return
+			}
+		}
+	}()
+
+	results := make(chan layerResult)
+	var wg sync.WaitGroup
+	for i := 0; i < s.concurrency(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				results <- layerResult{job: job, err: s.prepareLayer(job)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-		arch, err := l.TarStream()
+	var err error
+	for r := range results {
 		if err != nil {
-			return distribution.Descriptor{}, err
+			// Only wait for the layers being prepared.
+			continue
+		}
+		if err = r.err; err == nil {
+			err = s.archiveLayer(tw, r.job)
+		}
+		if err != nil {
+			close(cancel)
 		}
-		defer arch.Close()
+	}
+	return err
+}
 
-		if _, err := io.Copy(tarFile, arch); err != nil {
-			return distribution.Descriptor{}, err
+// prepareLayer writes a layer to the temporary directory of the save.
+func (s *saveSession) prepareLayer(job layerJob) error {
+	tarFile, err := os.Create(filepath.Join(job.outDir, legacyLayerFileName))
+	if err != nil {
+		return err
+	}
+	defer tarFile.Close()
+
+	arch, err := job.layer.TarStream()
+	if err != nil {
+		return err
+	}
+	defer arch.Close()
+
+	if err := s.writeLayer(tarFile, arch); err != nil {
+		return err
+	}
+	if err := tarFile.Close(); err != nil {
+		return err
+	}
+
+	for _, fname := range []string{"", legacyVersionFileName, legacyConfigFileName, legacyLayerFileName} {
+		// todo: maybe save layer created timestamp?
+		if err := system.Chtimes(filepath.Join(job.outDir, fname), job.createdTime, job.createdTime); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		for _, fname := range []string{"", legacyVersionFileName, legacyConfigFileName, legacyLayerFileName} {
-			// todo: maybe save layer created timestamp?
-			if err := system.Chtimes(filepath.Join(outDir, fname), createdTime, createdTime); err != nil {
-				return distribution.Descriptor{}, err
-			}
+// archiveLayer writes a layer prepared in the temporary directory of the save
+// to the archive, and removes it from the temporary directory.
+func (s *saveSession) archiveLayer(tw *tar.Writer, job layerJob) error {
+	layerPath := filepath.Join(job.outDir, legacyLayerFileName)
+	name, err := filepath.Rel(s.outDir, layerPath)
+	if err != nil {
+		return err
+	}
+	if err := addTarFile(tw, layerPath, name); err != nil {
+		return err
+	}
+	if err := os.Remove(layerPath); err != nil {
+		return err
+	}
+	// The directory is archived later on, with the time of the image.
+	return system.Chtimes(job.outDir, job.createdTime, job.createdTime)
+}
+
+// addTarFiles writes the files of dir to the archive, named after their path
+// in dir.
+func addTarFiles(tw *tar.Writer, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == "." {
+			return err
 		}
+		return addTarFile(tw, path, name)
+	})
+}
 
-		s.diffIDPaths[l.DiffID()] = layerPath
+// addTarFile writes the file at path to the archive, named name.
+func addTarFile(tw *tar.Writer, path, name string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return err
 	}
-	s.savedLayers[legacyImg.ID] = struct{}{}
+	var link string
+	if fi.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	}
+	hdr, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(name)
+	if fi.IsDir() {
+		hdr.Name += "/"
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
 
-	var src distribution.Descriptor
-	if fs, ok := l.(distribution.Describable); ok {
-		src = fs.Descriptor()
+// writeLayer writes the tar stream of a layer to w, compressed with the
+// compression of the exporter if any.
+func (s *saveSession) writeLayer(w io.Writer, arch io.Reader) error {
+	if s.opts.Compression == archive.Uncompressed {
+		_, err := io.Copy(w, arch)
+		return err
 	}
-	return src, nil
+	compressor, err := archive.CompressStreamLevel(w, s.opts.Compression, s.opts.CompressionLevel)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(compressor, arch); err != nil {
+		compressor.Close()
+		return err
+	}
+	return compressor.Close()
 }
@@ -0,0 +1,71 @@
+package tarexport
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAddTarFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docker-tarexport-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "a"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "a", "json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../b/layer.tar", filepath.Join(dir, "a", "layer.tar")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	if err := addTarFiles(tw, dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	tr := tar.NewReader(buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		if hdr.Name == "a/layer.tar" && (hdr.Typeflag != tar.TypeSymlink || hdr.Linkname != "../b/layer.tar") {
+			t.Fatalf("expected a/layer.tar to be a symlink to ../b/layer.tar, got %#v", hdr)
+		}
+		if hdr.Name == "a/json" {
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != "{}" {
+				t.Fatalf("unexpected content of a/json: %q", content)
+			}
+		}
+	}
+	expected := []string{"a/", "a/json", "a/layer.tar", "manifest.json"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/docker/distribution"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/reference"
 )
 
@@ -28,6 +29,19 @@ type tarexporter struct {
 	ls             layer.Store
 	rs             reference.Store
 	loggerImgEvent LogImageEvent
+	opts           Options
+}
+
+// Options are the options of the saves and the loads of a tar exporter.
+type Options struct {
+	// Compression and CompressionLevel are the compression of the layers
+	// saved, which are saved uncompressed by default. The layers are loaded
+	// whatever their compression.
+	Compression      archive.Compression
+	CompressionLevel int
+	// Concurrency is the maximum number of layers a save writes at once,
+	// and of images a load loads the layers of at once, 1 by default.
+	Concurrency int
 }
 
 // LogImageEvent defines interface for event generation related to image tar(load and save) operations
@@ -45,3 +59,24 @@ func NewTarExporter(is image.Store, ls layer.Store, rs reference.Store, loggerIm
 		loggerImgEvent: loggerImgEvent,
 	}
 }
+
+// NewTarExporterWithOptions returns new ImageExporter for tar packages with
+// the given options
+func NewTarExporterWithOptions(is image.Store, ls layer.Store, rs reference.Store, loggerImgEvent LogImageEvent, opts Options) image.Exporter {
+	return &tarexporter{
+		is:             is,
+		ls:             ls,
+		rs:             rs,
+		loggerImgEvent: loggerImgEvent,
+		opts:           opts,
+	}
+}
+
+// concurrency returns the maximum number of layers a save writes at once, and
+// of images a load loads the layers of at once.
+func (l *tarexporter) concurrency() int {
+	if l.opts.Concurrency < 1 {
+		return 1
+	}
+	return l.opts.Concurrency
+}
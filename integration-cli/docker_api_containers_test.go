@@ -507,14 +507,49 @@ func (s *DockerSuite) TestContainerApiCreateEmptyConfig(c *check.C) {
 }
 
 func (s *DockerSuite) TestContainerApiCreateMultipleNetworksConfig(c *check.C) {
-	// Container creation must fail if client specified configurations for more than one network
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "network", "create", "net1")
+	dockerCmd(c, "network", "create", "net2")
+
+	// The container joins every network it has an endpoint config for, in
+	// addition to the network of its network mode
 	config := map[string]interface{}{
 		"Image": "busybox",
+		"Cmd":   []string{"top"},
+		"HostConfig": map[string]interface{}{
+			"NetworkMode": "net1",
+		},
+		"NetworkingConfig": networktypes.NetworkingConfig{
+			EndpointsConfig: map[string]*networktypes.EndpointSettings{
+				"net2": {Aliases: []string{"server_x"}},
+			},
+		},
+	}
+
+	status, body, err := sockRequest("POST", "/containers/create?name=multinet", config)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusCreated, check.Commentf(string(body)))
+	dockerCmd(c, "start", "multinet")
+
+	networks := inspectField(c, "multinet", "NetworkSettings.Networks")
+	c.Assert(networks, checker.Contains, "net1")
+	c.Assert(networks, checker.Contains, "net2")
+	aliases := inspectFieldJSON(c, "multinet", "NetworkSettings.Networks.net2.Aliases")
+	c.Assert(aliases, checker.Contains, "server_x")
+}
+
+func (s *DockerSuite) TestContainerApiCreateMultipleNetworksConfigHostMode(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	// Container creation must fail if the network mode does not allow other networks
+	config := map[string]interface{}{
+		"Image": "busybox",
+		"HostConfig": map[string]interface{}{
+			"NetworkMode": "host",
+		},
 		"NetworkingConfig": networktypes.NetworkingConfig{
 			EndpointsConfig: map[string]*networktypes.EndpointSettings{
 				"net1": {},
 				"net2": {},
-				"net3": {},
 			},
 		},
 	}
@@ -523,11 +558,7 @@ func (s *DockerSuite) TestContainerApiCreateMultipleNetworksConfig(c *check.C) {
 	c.Assert(err, checker.IsNil)
 	c.Assert(status, checker.Equals, http.StatusBadRequest)
 	msg := getErrorMessage(c, body)
-	// network name order in error message is not deterministic
-	c.Assert(msg, checker.Contains, "Container cannot be connected to network endpoints")
-	c.Assert(msg, checker.Contains, "net1")
-	c.Assert(msg, checker.Contains, "net2")
-	c.Assert(msg, checker.Contains, "net3")
+	c.Assert(msg, checker.Contains, "Container in network mode host cannot be connected to network endpoints: net1, net2")
 }
 
 func (s *DockerSuite) TestContainerApiCreateWithHostName(c *check.C) {
@@ -1278,7 +1309,7 @@ func (s *DockerSuite) TestPostContainersCreateWithWrongCpusetValues(c *check.C)
 	status, body, err := sockRequest("POST", "/containers/create?name="+name, c1)
 	c.Assert(err, checker.IsNil)
 	c.Assert(status, checker.Equals, http.StatusInternalServerError)
-	expected := "Invalid value 1-42,, for cpuset cpus"
+	expected := "Invalid value 1-42,, for cpuset cpus: expected a list of CPUs, such as 0-3 or 0,1"
 	c.Assert(getErrorMessage(c, body), checker.Equals, expected)
 
 	c2 := struct {
@@ -1289,7 +1320,7 @@ func (s *DockerSuite) TestPostContainersCreateWithWrongCpusetValues(c *check.C)
 	status, body, err = sockRequest("POST", "/containers/create?name="+name, c2)
 	c.Assert(err, checker.IsNil)
 	c.Assert(status, checker.Equals, http.StatusInternalServerError)
-	expected = "Invalid value 42-3,1-- for cpuset mems"
+	expected = "Invalid value 42-3,1-- for cpuset mems: expected a list of memory nodes, such as 0-3 or 0,1"
 	c.Assert(getErrorMessage(c, body), checker.Equals, expected)
 }
 
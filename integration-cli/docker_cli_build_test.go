@@ -4050,6 +4050,29 @@ func (s *DockerSuite) TestBuildFromGITWithContext(c *check.C) {
 	}
 }
 
+func (s *DockerSuite) TestBuildFromGITRecordsCommit(c *check.C) {
+	name := "testbuildfromgitrecordscommit"
+	git, err := newFakeGit("repo", map[string]string{
+		"Dockerfile": `FROM busybox
+					MAINTAINER docker`,
+	}, true)
+	if err != nil {
+		c.Fatal(err)
+	}
+	defer git.Close()
+
+	if _, err := buildImageFromPath(name, git.RepoURL, true); err != nil {
+		c.Fatal(err)
+	}
+	commit := inspectFieldMap(c, name, "Config.Labels", "com.docker.build.git-commit")
+	if !regexp.MustCompile("^[0-9a-f]{40}$").MatchString(commit) {
+		c.Fatalf("expected the commit of the git context to be recorded, got %q", commit)
+	}
+	if res := inspectFieldMap(c, name, "Config.Labels", "com.docker.build.version"); res != "<no value>" {
+		c.Fatalf("expected no build metadata without annotations, got %q", res)
+	}
+}
+
 func (s *DockerSuite) TestBuildFromGITwithF(c *check.C) {
 	name := "testbuildfromgitwithf"
 	git, err := newFakeGit("repo", map[string]string{
@@ -536,6 +536,22 @@ func (s *DockerSuite) TestCpToDot(c *check.C) {
 	c.Assert(string(content), checker.Equals, "lololol\n")
 }
 
+func (s *DockerSuite) TestCpBetweenContainers(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "/bin/sh", "-c", "echo lololol > /test && chown 1000:1000 /test")
+	srcID := strings.TrimSpace(out)
+	out, _ = dockerCmd(c, "wait", srcID)
+	c.Assert(strings.TrimSpace(out), checker.Equals, "0")
+
+	out, _ = dockerCmd(c, "create", "busybox", "/bin/sh", "-c", "cat /tmp/copied && stat -c %u:%g /tmp/copied")
+	dstID := strings.TrimSpace(out)
+
+	dockerCmd(c, "cp", srcID+":/test", dstID+":/tmp/copied")
+
+	out, _ = dockerCmd(c, "start", "-a", dstID)
+	c.Assert(out, checker.Equals, "lololol\n1000:1000\n")
+}
+
 func (s *DockerSuite) TestCpToStdout(c *check.C) {
 	out, _ := dockerCmd(c, "run", "-d", "busybox", "/bin/sh", "-c", "echo lololol > /test")
 
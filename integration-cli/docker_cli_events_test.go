@@ -205,11 +205,16 @@ func (s *DockerSuite) TestEventsImagePull(c *check.C) {
 		"--since", since, "--until", daemonUnixTime(c))
 
 	events := strings.Split(strings.TrimSpace(out), "\n")
-	event := strings.TrimSpace(events[len(events)-1])
-	matches := eventstestutils.ScanMap(event)
+	c.Assert(len(events), checker.GreaterOrEqualThan, 2, check.Commentf("out:\n%s", out))
+
+	// The pull event is followed by the pull-finished one.
+	matches := eventstestutils.ScanMap(strings.TrimSpace(events[len(events)-2]))
 	c.Assert(matches["id"], checker.Equals, "hello-world:latest")
 	c.Assert(matches["action"], checker.Equals, "pull")
 
+	matches = eventstestutils.ScanMap(strings.TrimSpace(events[len(events)-1]))
+	c.Assert(matches["id"], checker.Equals, "hello-world:latest")
+	c.Assert(matches["action"], checker.Equals, "pull-finished")
 }
 
 func (s *DockerSuite) TestEventsImageImport(c *check.C) {
@@ -429,7 +429,7 @@ func (s *DockerDaemonSuite) TestDaemonEvents(c *check.C) {
 	out, err = s.d.Cmd("events", "--since=0", "--until", daemonUnixTime(c))
 	c.Assert(err, checker.IsNil)
 
-	c.Assert(out, checker.Contains, fmt.Sprintf("daemon reload %s (cluster-advertise=, cluster-store=, cluster-store-opts={}, debug=true, default-runtime=runc, labels=[\"bar=foo\"], live-restore=false, max-concurrent-downloads=1, max-concurrent-uploads=5, name=%s, runtimes=runc:{docker-runc []})", daemonID, daemonName))
+	c.Assert(out, checker.Contains, fmt.Sprintf("daemon reload %s (autoheal=false, autoheal-threshold=1, build-from-policy=any, cluster-advertise=, cluster-store=, cluster-store-opts={}, debug=true, default-runtime=runc, default-ulimits=, dns=, dns-opts=, dns-search=, labels=[\"bar=foo\"], layer-depth-warning=100, live-restore=false, log-driver=json-file, log-opts={}, max-concurrent-downloads=1, max-concurrent-exports=3, max-concurrent-uploads=5, max-download-attempts=5, name=%s, registry-limits={}, runtimes=runc:{docker-runc []}, shutdown-timeout=15)", daemonID, daemonName))
 }
 
 func (s *DockerDaemonSuite) TestDaemonEventsWithFilters(c *check.C) {
@@ -1224,6 +1224,45 @@ func (s *DockerNetworkSuite) TestDockerNetworkConnectWithMac(c *check.C) {
 	c.Assert(strings.TrimSpace(mac2), checker.Not(checker.Equals), strings.TrimSpace(mac1))
 }
 
+func (s *DockerNetworkSuite) TestDockerNetworkInspectVerbose(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "network", "create", "--subnet=172.31.0.0/16", "test")
+	dockerCmd(c, "run", "-d", "--name=foo", "--net=test", "--net-alias=bar", "busybox", "top")
+	c.Assert(waitRun("foo"), checker.IsNil)
+
+	out, _ := dockerCmd(c, "network", "inspect", "test")
+	c.Assert(out, checker.Not(checker.Contains), "Diagnostics")
+
+	out, _ = dockerCmd(c, "network", "inspect", "--verbose", "--format", "{{json .Diagnostics}}", "test")
+	var diagnostics types.NetworkDiagnostics
+	c.Assert(json.Unmarshal([]byte(out), &diagnostics), checker.IsNil)
+
+	c.Assert(diagnostics.Endpoints, checker.HasLen, 1)
+	ep := diagnostics.Endpoints[0]
+	c.Assert(ep.InterfaceName, checker.Equals, "eth0")
+	c.Assert(ep.PeerName, checker.Not(checker.Equals), "")
+	c.Assert(ep.PeerIndex, checker.Not(checker.Equals), 0)
+
+	var masquerade bool
+	for _, rule := range diagnostics.IptablesRules {
+		if strings.Contains(rule, "172.31.0.0/16") && strings.Contains(rule, "MASQUERADE") {
+			masquerade = true
+		}
+	}
+	c.Assert(masquerade, checker.True, check.Commentf("%v", diagnostics.IptablesRules))
+
+	// The records hold the name of the container and its aliases, including
+	// its short ID
+	ip := strings.TrimSpace(inspectField(c, "foo", "NetworkSettings.Networks.test.IPAddress"))
+	records := make(map[string]string)
+	for _, r := range diagnostics.DNSRecords {
+		records[r.Name] = r.IPv4Address
+	}
+	c.Assert(records, checker.HasLen, 3)
+	c.Assert(records["foo"], checker.Equals, ip)
+	c.Assert(records["bar"], checker.Equals, ip)
+}
+
 func (s *DockerNetworkSuite) TestDockerNetworkInspectCreatedContainer(c *check.C) {
 	dockerCmd(c, "create", "--name", "test", "busybox")
 	networks := inspectField(c, "test", "NetworkSettings.Networks")
@@ -1241,6 +1280,29 @@ func (s *DockerNetworkSuite) TestDockerNetworkRestartWithMultipleNetworks(c *che
 	c.Assert(networks, checker.Contains, "test", check.Commentf("Should contain 'test' network"))
 }
 
+func (s *DockerNetworkSuite) TestDockerNetworkRestartPreservesConnectConfig(c *check.C) {
+	dockerCmd(c, "network", "create", "--subnet=172.29.0.0/16", "test")
+	dockerCmd(c, "run", "--name=foo", "-d", "busybox", "top")
+	c.Assert(waitRun("foo"), checker.IsNil)
+	dockerCmd(c, "network", "connect", "--ip", "172.29.55.44", "--alias", "bar", "test", "foo")
+
+	// The endpoint configuration of the connection made after the start is
+	// applied again when the container is restarted
+	for i := 0; i < 2; i++ {
+		dockerCmd(c, "restart", "foo")
+		c.Assert(waitRun("foo"), checker.IsNil)
+
+		ip := inspectField(c, "foo", "NetworkSettings.Networks.test.IPAddress")
+		c.Assert(ip, checker.Equals, "172.29.55.44")
+		aliases := inspectField(c, "foo", "NetworkSettings.Networks.test.Aliases")
+		c.Assert(aliases, checker.Contains, "bar")
+		bridge := inspectField(c, "foo", "NetworkSettings.Networks.bridge.IPAddress")
+		c.Assert(bridge, checker.Not(checker.Equals), "")
+	}
+
+	dockerCmd(c, "run", "--rm", "--net=test", "busybox", "ping", "-c", "1", "bar")
+}
+
 func (s *DockerNetworkSuite) TestDockerNetworkConnectDisconnectToStoppedContainer(c *check.C) {
 	dockerCmd(c, "network", "create", "test")
 	dockerCmd(c, "create", "--name=foo", "busybox", "top")
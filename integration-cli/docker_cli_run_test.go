@@ -2395,7 +2395,8 @@ func (s *DockerSuite) TestRunAllowPortRangeThroughExpose(c *check.C) {
 func (s *DockerSuite) TestRunExposePort(c *check.C) {
 	out, _, err := dockerCmdWithError("run", "--expose", "80000", "busybox")
 	c.Assert(err, checker.NotNil, check.Commentf("--expose with an invalid port should error out"))
-	c.Assert(out, checker.Contains, "invalid range format for --expose")
+	c.Assert(out, checker.Contains, "invalid value for --expose")
+	c.Assert(out, checker.Contains, "port 80000 is out of range")
 }
 
 func (s *DockerSuite) TestRunModeIpcHost(c *check.C) {
@@ -4009,7 +4010,7 @@ func (s *DockerSuite) TestRunWrongCpusetCpusFlagValue(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	out, exitCode, err := dockerCmdWithError("run", "--cpuset-cpus", "1-10,11--", "busybox", "true")
 	c.Assert(err, check.NotNil)
-	expected := "Error response from daemon: Invalid value 1-10,11-- for cpuset cpus.\n"
+	expected := "Error response from daemon: Invalid value 1-10,11-- for cpuset cpus: expected a list of CPUs, such as 0-3 or 0,1.\n"
 	if !(strings.Contains(out, expected) || exitCode == 125) {
 		c.Fatalf("Expected output to contain %q with exitCode 125, got out: %q exitCode: %v", expected, out, exitCode)
 	}
@@ -4020,7 +4021,7 @@ func (s *DockerSuite) TestRunWrongCpusetMemsFlagValue(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	out, exitCode, err := dockerCmdWithError("run", "--cpuset-mems", "1-42--", "busybox", "true")
 	c.Assert(err, check.NotNil)
-	expected := "Error response from daemon: Invalid value 1-42-- for cpuset mems.\n"
+	expected := "Error response from daemon: Invalid value 1-42-- for cpuset mems: expected a list of memory nodes, such as 0-3 or 0,1.\n"
 	if !(strings.Contains(out, expected) || exitCode == 125) {
 		c.Fatalf("Expected output to contain %q with exitCode 125, got out: %q exitCode: %v", expected, out, exitCode)
 	}
@@ -125,7 +125,14 @@ func (s *DockerSuite) TestSearchWithLimit(c *check.C) {
 	out, _, err = dockerCmdWithError("search", fmt.Sprintf("--limit=%d", limit), "docker")
 	c.Assert(err, checker.Not(checker.IsNil))
 
+	// Limits larger than a page of results are fetched in several pages.
 	limit = 200
 	out, _, err = dockerCmdWithError("search", fmt.Sprintf("--limit=%d", limit), "docker")
+	c.Assert(err, checker.IsNil)
+	outSlice = strings.Split(out, "\n")
+	c.Assert(len(outSlice), checker.LessOrEqualThan, limit+2) // 1 header, 1 carriage return
+
+	limit = 1001
+	out, _, err = dockerCmdWithError("search", fmt.Sprintf("--limit=%d", limit), "docker")
 	c.Assert(err, checker.Not(checker.IsNil))
 }
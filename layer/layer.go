@@ -189,6 +189,23 @@ type DescribableStore interface {
 	RegisterWithDescriptor(io.Reader, ChainID, distribution.Descriptor) (Layer, error)
 }
 
+// AuditableStore represents a layer store capable of auditing the
+// reference counts of its layers.
+type AuditableStore interface {
+	Audit() []AuditEntry
+}
+
+// AuditEntry is a layer found by an audit whose reference count does not
+// match the references retaining it, or which nothing retains.
+type AuditEntry struct {
+	ChainID ChainID
+	// ReferenceCount is the recorded reference count of the layer.
+	ReferenceCount int
+	// References is the number of references, child layers and mounts
+	// found retaining the layer.
+	References int
+}
+
 // MetadataTransaction represents functions for setting layer metadata
 // with a single transaction.
 type MetadataTransaction interface {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
 	"sync"
 
 	"github.com/Sirupsen/logrus"
@@ -241,6 +242,12 @@ func (ls *layerStore) Register(ts io.Reader, parent ChainID) (Layer, error) {
 	return ls.registerWithDescriptor(ts, parent, distribution.Descriptor{})
 }
 
+// RegisterWithDescriptor registers a layer like Register, and records the
+// descriptor of its foreign source, if it is not empty.
+func (ls *layerStore) RegisterWithDescriptor(ts io.Reader, parent ChainID, descriptor distribution.Descriptor) (Layer, error) {
+	return ls.registerWithDescriptor(ts, parent, descriptor)
+}
+
 func (ls *layerStore) registerWithDescriptor(ts io.Reader, parent ChainID, descriptor distribution.Descriptor) (Layer, error) {
 	// err is used to hold the error which will always trigger
 	// cleanup of creates sources but may not be an error returned
@@ -445,6 +452,48 @@ func (ls *layerStore) Release(l Layer) ([]Metadata, error) {
 	return ls.releaseLayer(layer)
 }
 
+// Audit compares the reference count of each layer with its references, its
+// child layers and the mounts it is the parent of, and returns the layers
+// whose count does not match or which nothing retains, sorted by chain ID.
+func (ls *layerStore) Audit() []AuditEntry {
+	ls.mountL.Lock()
+	defer ls.mountL.Unlock()
+	ls.layerL.Lock()
+	defer ls.layerL.Unlock()
+
+	found := make(map[*roLayer]int, len(ls.layerMap))
+	for _, l := range ls.layerMap {
+		found[l] += len(l.references)
+		if l.parent != nil {
+			found[l.parent]++
+		}
+	}
+	for _, m := range ls.mounts {
+		if m.parent != nil {
+			found[m.parent]++
+		}
+	}
+
+	var entries []AuditEntry
+	for chainID, l := range ls.layerMap {
+		if found[l] == 0 || found[l] != l.referenceCount {
+			entries = append(entries, AuditEntry{
+				ChainID:        chainID,
+				ReferenceCount: l.referenceCount,
+				References:     found[l],
+			})
+		}
+	}
+	sort.Sort(auditEntriesByChainID(entries))
+	return entries
+}
+
+type auditEntriesByChainID []AuditEntry
+
+func (e auditEntriesByChainID) Len() int           { return len(e) }
+func (e auditEntriesByChainID) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e auditEntriesByChainID) Less(i, j int) bool { return e[i].ChainID < e[j].ChainID }
+
 func (ls *layerStore) CreateRWLayer(name string, parent ChainID, mountLabel string, initFunc MountInit, storageOpt map[string]string) (RWLayer, error) {
 	ls.mountL.Lock()
 	defer ls.mountL.Unlock()
@@ -6,11 +6,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/docker/distribution"
 	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/daemon/graphdriver/vfs"
 	"github.com/docker/docker/pkg/archive"
@@ -768,3 +771,87 @@ func TestTarStreamVerification(t *testing.T) {
 		t.Fatalf("wrong error returned from tarstream: %q", err)
 	}
 }
+
+func TestRegisterWithDescriptor(t *testing.T) {
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	tar1, err := tarFromFiles(newTestFile("/etc/profile", []byte("# Base configuration"), 0644))
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptor := distribution.Descriptor{
+		MediaType: schema2.MediaTypeForeignLayer,
+		Size:      int64(len(tar1)),
+		Digest:    digest.FromBytes(tar1),
+		URLs:      []string{"https://example.com/layer.tar"},
+	}
+
+	layer1, err := ls.(DescribableStore).RegisterWithDescriptor(bytes.NewReader(tar1), "", descriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := layer1.(distribution.Describable).Descriptor(); !reflect.DeepEqual(d, descriptor) {
+		t.Fatalf("unexpected descriptor %v", d)
+	}
+
+	ls2, err := NewStoreFromGraphDriver(ls.(*layerStore).store, ls.(*layerStore).driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer1b, err := ls2.Get(layer1.ChainID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := layer1b.(distribution.Describable).Descriptor(); !reflect.DeepEqual(d, descriptor) {
+		t.Fatalf("expected the descriptor to be restored, got %v", d)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	layer1, err := createLayer(ls, "", initWithFiles(newTestFile("layer1.txt", []byte("layer 1 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer2, err := createLayer(ls, layer1.ChainID(), initWithFiles(newTestFile("layer2.txt", []byte("layer 2 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.Release(layer1); err != nil {
+		t.Fatal(err)
+	}
+	mount, err := ls.CreateRWLayer("container", layer2.ChainID(), "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ls.ReleaseRWLayer(mount)
+
+	if entries := ls.(AuditableStore).Audit(); len(entries) != 0 {
+		t.Fatalf("unexpected audit entries %v", entries)
+	}
+
+	ls.(*layerStore).layerMap[layer1.ChainID()].referenceCount++
+	entries := ls.(AuditableStore).Audit()
+	if len(entries) != 1 || entries[0].ChainID != layer1.ChainID() || entries[0].ReferenceCount != 2 || entries[0].References != 1 {
+		t.Fatalf("expected the miscounted layer to be reported, got %v", entries)
+	}
+	ls.(*layerStore).layerMap[layer1.ChainID()].referenceCount--
+
+	// Once restored, the layers are retained by nothing until they are
+	// referenced, except by the mount.
+	layer3, err := createLayer(ls, layer2.ChainID(), initWithFiles(newTestFile("layer3.txt", []byte("layer 3 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls2, err := NewStoreFromGraphDriver(ls.(*layerStore).store, ls.(*layerStore).driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = ls2.(AuditableStore).Audit()
+	if len(entries) != 1 || entries[0].ChainID != layer3.ChainID() || entries[0].ReferenceCount != 0 || entries[0].References != 0 {
+		t.Fatalf("expected the unreferenced layer to be reported, got %v", entries)
+	}
+}
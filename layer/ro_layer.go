@@ -51,6 +51,14 @@ func (rl *roLayer) DiffID() DiffID {
 	return rl.diffID
 }
 
+var _ distribution.Describable = &roLayer{}
+
+// Descriptor returns the descriptor of the foreign source of the layer, or
+// an empty descriptor if the layer has none.
+func (rl *roLayer) Descriptor() distribution.Descriptor {
+	return rl.descriptor
+}
+
 func (rl *roLayer) Parent() Layer {
 	if rl.parent == nil {
 		return nil
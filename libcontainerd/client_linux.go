@@ -605,6 +605,62 @@ func (clnt *client) Restore(containerID string, options ...CreateOption) error {
 	return clnt.setExited(containerID, uint32(255))
 }
 
+// resync reports the containers that containerd no longer runs as exited.
+// It is called once the connection to containerd is restored, as their exit
+// events may have been lost while containerd was unreachable.
+func (clnt *client) resync() {
+	clnt.mapMutex.RLock()
+	ids := make([]string, 0, len(clnt.containers))
+	for id := range clnt.containers {
+		ids = append(ids, id)
+	}
+	clnt.mapMutex.RUnlock()
+
+	for _, id := range ids {
+		clnt.lock(id)
+		checkedAt := time.Now()
+		ctr, err := clnt.getContainer(id)
+		if err != nil {
+			// the exit event was received in the meantime
+			clnt.unlock(id)
+			continue
+		}
+		cont, err := clnt.getContainerdContainer(id)
+		if err != nil && !strings.Contains(err.Error(), "container not found") {
+			clnt.unlock(id)
+			logrus.Errorf("libcontainerd: failed to resync container %s: %v", id, err)
+			continue
+		}
+		if err == nil && cont.Status != "Stopped" {
+			clnt.unlock(id)
+			continue
+		}
+
+		// The exit is reported as of the check, so that it is ignored if
+		// the container is restarted in the meantime, and only handled once
+		// if containerd replays it too.
+		exit := &containerd.Event{
+			Type:   StateExit,
+			Id:     id,
+			Pid:    InitFriendlyName,
+			Status: 255,
+		}
+		exit.Timestamp, _ = ptypes.TimestampProto(checkedAt)
+		tsp, err := ptypes.TimestampProto(clnt.remote.getLastEventTimestamp())
+		if err == nil {
+			if ev, err := clnt.getContainerLastEventSinceTime(id, tsp); err == nil && ev != nil && ev.Type == StateExit {
+				exit.Status = ev.Status
+			}
+		}
+		clnt.unlock(id)
+
+		logrus.Warnf("libcontainerd: container %s exited while containerd was unreachable", id)
+		if err := ctr.handleEvent(exit); err != nil {
+			logrus.Errorf("libcontainerd: error processing state change for %s: %v", id, err)
+		}
+	}
+}
+
 type exitNotifier struct {
 	id     string
 	client *client
@@ -679,3 +735,16 @@ func (clnt *client) ListCheckpoints(containerID string, checkpointDir string) (*
 	}
 	return (*Checkpoints)(resp), nil
 }
+
+func (clnt *client) GetServerVersion(ctx context.Context) (*ServerVersion, error) {
+	resp, err := clnt.remote.apiClient.GetServerVersion(ctx, &containerd.GetServerVersionRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &ServerVersion{
+		Major:    resp.Major,
+		Minor:    resp.Minor,
+		Patch:    resp.Patch,
+		Revision: resp.Revision,
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 	containerd "github.com/docker/containerd/api/grpc/types"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/restartmanager"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"golang.org/x/net/context"
 )
@@ -25,6 +26,10 @@ type container struct {
 	oom         bool
 	runtime     string
 	runtimeArgs []string
+	// exited is set once the exit of the init process of the current run
+	// is handled, so that the exit replayed by containerd and the one
+	// reported by resync are only handled once.
+	exited bool
 }
 
 type runtime struct {
@@ -131,14 +136,18 @@ func (ctr *container) start(checkpoint string, checkpointDir string) error {
 		return err
 	}
 
+	// The start time is taken before the request, as the exit of a short
+	// run may be reported before it returns.
+	startedAt := time.Now()
 	resp, err := ctr.client.remote.apiClient.CreateContainer(context.Background(), r)
 	if err != nil {
 		close(createChan)
 		ctr.closeFifos(iopipe)
 		return err
 	}
-	ctr.startedAt = time.Now()
+	ctr.startedAt = startedAt
 	ctr.systemPid = systemPid(resp.Container)
+	ctr.exited = false
 	close(createChan)
 
 	return ctr.client.backend.StateChanged(ctr.containerID, StateInfo{
@@ -164,6 +173,13 @@ func (ctr *container) handleEvent(e *containerd.Event) error {
 	defer ctr.client.unlock(ctr.containerID)
 	switch e.Type {
 	case StateExit, StatePause, StateResume, StateOOM:
+		if e.Type == StateExit && e.Pid == InitFriendlyName {
+			if ctr.exited || ctr.exitedBeforeStart(e) {
+				logrus.Debugf("libcontainerd: ignoring the exit of container %s, it was already handled", e.Id)
+				return nil
+			}
+			ctr.exited = true
+		}
 		var waitRestart chan error
 		st := StateInfo{
 			CommonStateInfo: CommonStateInfo{
@@ -246,6 +262,16 @@ func (ctr *container) handleEvent(e *containerd.Event) error {
 	return nil
 }
 
+// exitedBeforeStart returns whether an exit event is the one of a previous
+// run of the container, as replayed by containerd after a restart.
+func (ctr *container) exitedBeforeStart(e *containerd.Event) bool {
+	if e.Timestamp == nil || ctr.startedAt.IsZero() {
+		return false
+	}
+	t, err := ptypes.Timestamp(e.Timestamp)
+	return err == nil && t.Before(ctr.startedAt)
+}
+
 // discardFifos attempts to fully read the container fifos to unblock processes
 // that may be blocked on the writer side.
 func (ctr *container) discardFifos() {
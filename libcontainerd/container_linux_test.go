@@ -0,0 +1,86 @@
+package libcontainerd
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	containerd "github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/docker/pkg/locker"
+	"github.com/golang/protobuf/ptypes"
+)
+
+type exitRecorder struct {
+	sync.Mutex
+	exits []uint32
+	done  chan struct{}
+}
+
+func (r *exitRecorder) StateChanged(containerID string, state StateInfo) error {
+	r.Lock()
+	defer r.Unlock()
+	if state.State == StateExit {
+		r.exits = append(r.exits, state.ExitCode)
+		r.done <- struct{}{}
+	}
+	return nil
+}
+
+func (r *exitRecorder) AttachStreams(processFriendlyName string, io IOPipe) error {
+	return nil
+}
+
+func exitEvent(status uint32, t time.Time) *containerd.Event {
+	e := &containerd.Event{Type: StateExit, Id: "abc123", Pid: InitFriendlyName, Status: status}
+	e.Timestamp, _ = ptypes.TimestampProto(t)
+	return e
+}
+
+func TestHandleEventExitOnce(t *testing.T) {
+	backend := &exitRecorder{done: make(chan struct{}, 10)}
+	clnt := &client{
+		clientCommon: clientCommon{
+			backend:    backend,
+			containers: make(map[string]*container),
+			locker:     locker.New(),
+		},
+		exitNotifiers: make(map[string]*exitNotifier),
+	}
+	ctr := &container{containerCommon: containerCommon{
+		process: process{
+			processCommon: processCommon{client: clnt, containerID: "abc123", friendlyName: InitFriendlyName},
+			dir:           "/nonexistent",
+		},
+		startedAt: time.Now().Add(-time.Minute),
+	}}
+	clnt.appendContainer(ctr)
+
+	// An exit replayed by containerd for a previous run is ignored.
+	if err := ctr.handleEvent(exitEvent(1, time.Now().Add(-time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	// The exit replayed by containerd and the one reported by resync are
+	// only handled once.
+	if err := ctr.handleEvent(exitEvent(2, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctr.handleEvent(exitEvent(255, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-backend.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the exit to be handled")
+	}
+	select {
+	case <-backend.done:
+		t.Fatal("expected a single exit to be handled")
+	case <-time.After(100 * time.Millisecond):
+	}
+	backend.Lock()
+	defer backend.Unlock()
+	if len(backend.exits) != 1 || backend.exits[0] != 2 {
+		t.Fatalf("expected the exit of the current run to be handled once, got %v", backend.exits)
+	}
+}
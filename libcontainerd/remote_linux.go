@@ -135,7 +135,10 @@ func (r *remote) UpdateOptions(options ...RemoteOption) error {
 }
 
 func (r *remote) handleConnectionChange() {
-	var transientFailureCount = 0
+	var (
+		transientFailureCount = 0
+		connected             = true
+	)
 
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
@@ -147,10 +150,20 @@ func (r *remote) handleConnectionChange() {
 		_, err := healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
 		cancel()
 		if err == nil {
+			transientFailureCount = 0
+			if !connected {
+				connected = true
+				logrus.Info("libcontainerd: connection to containerd restored, resynchronizing containers")
+				go r.resync()
+			}
 			continue
 		}
 
 		logrus.Debugf("libcontainerd: containerd health check returned error: %v", err)
+		if connected && !r.closeManually {
+			connected = false
+			logrus.Warnf("libcontainerd: lost connection to containerd: %v", err)
+		}
 
 		if r.daemonPid != -1 {
 			if strings.Contains(err.Error(), "is closing") {
@@ -175,6 +188,20 @@ func (r *remote) handleConnectionChange() {
 	}
 }
 
+// resync reconciles the containers of all clients with the live state of
+// containerd, after the connection to containerd was lost and restored.
+// Containers that exited in the meantime are reported as exited.
+func (r *remote) resync() {
+	r.RLock()
+	clients := make([]*client, len(r.clients))
+	copy(clients, r.clients)
+	r.RUnlock()
+
+	for _, c := range clients {
+		c.resync()
+	}
+}
+
 func (r *remote) Cleanup() {
 	if r.daemonPid == -1 {
 		return
@@ -287,6 +314,20 @@ func (r *remote) startEventsMonitor() error {
 	return nil
 }
 
+// restartEventsMonitor subscribes again to the containerd events once the
+// event stream is broken, retrying until containerd is reachable. Events
+// missed in the meantime are replayed from the last event timestamp.
+func (r *remote) restartEventsMonitor() {
+	for !r.closeManually {
+		err := r.startEventsMonitor()
+		if err == nil {
+			return
+		}
+		logrus.Errorf("libcontainerd: failed to subscribe to containerd events: %v", err)
+		time.Sleep(connectionRetryDelay)
+	}
+}
+
 func (r *remote) handleEventStream(events containerd.API_EventsClient) {
 	for {
 		e, err := events.Recv()
@@ -297,7 +338,7 @@ func (r *remote) handleEventStream(events containerd.API_EventsClient) {
 				return
 			}
 			logrus.Errorf("libcontainerd: failed to receive event from containerd: %v", err)
-			go r.startEventsMonitor()
+			go r.restartEventsMonitor()
 			return
 		}
 
@@ -52,6 +52,15 @@ type Client interface {
 	CreateCheckpoint(containerID string, checkpointID string, checkpointDir string, exit bool) error
 	DeleteCheckpoint(containerID string, checkpointID string, checkpointDir string) error
 	ListCheckpoints(containerID string, checkpointDir string) (*Checkpoints, error)
+	GetServerVersion(ctx context.Context) (*ServerVersion, error)
+}
+
+// ServerVersion contains the version of the containerd server.
+type ServerVersion struct {
+	Major    uint32
+	Minor    uint32
+	Patch    uint32
+	Revision string
 }
 
 // CreateOption allows to configure parameters of container creation.
@@ -0,0 +1,13 @@
+package overlay
+
+import "syscall"
+
+// freeSpace returns the number of bytes available to unprivileged users on
+// the filesystem of path.
+func freeSpace(path string) (uint64, error) {
+	var buf syscall.Statfs_t
+	if err := syscall.Statfs(path, &buf); err != nil {
+		return 0, err
+	}
+	return buf.Bavail * uint64(buf.Bsize), nil
+}
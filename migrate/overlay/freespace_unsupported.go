@@ -0,0 +1,11 @@
+// +build !linux
+
+package overlay
+
+import "fmt"
+
+// freeSpace is not supported on platforms other than linux, where the
+// overlay storage drivers are not available.
+func freeSpace(path string) (uint64, error) {
+	return 0, fmt.Errorf("cannot migrate to %s on this platform", toDriver)
+}
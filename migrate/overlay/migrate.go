@@ -0,0 +1,423 @@
+// Package overlay migrates the images and the containers of the overlay
+// storage driver to the overlay2 storage driver.
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/directory"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/go-units"
+)
+
+const (
+	fromDriver        = "overlay"
+	toDriver          = "overlay2"
+	containersDirName = "containers"
+	configFileName    = "config.v2.json"
+)
+
+// Pending returns whether there are overlay images and no overlay2 images
+// yet in root, that is whether Migrate would migrate them.
+func Pending(root string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(root, "image", fromDriver)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(root, "image", toDriver)); !os.IsNotExist(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// Migrate copies the layers of the overlay storage driver in root to the
+// overlay2 storage driver, along with the metadata of the images and of the
+// containers, if there are overlay images and no overlay2 images yet. The
+// migration is refused while an overlay container is running or one of the
+// overlay layers is mounted, as the writes to the container after its layer
+// is copied would be lost. It fails, and the copied layers are removed, if
+// there is not enough free space for a copy of the layers or if any
+// container cannot be migrated. The overlay data is left in place, and can
+// be removed once the migrated images and containers are verified.
+func Migrate(root string, options []string, uidMaps, gidMaps []idtools.IDMap) error {
+	fromImageRoot := filepath.Join(root, "image", fromDriver)
+	toImageRoot := filepath.Join(root, "image", toDriver)
+	if pending, err := Pending(root); err != nil || !pending {
+		return err
+	}
+
+	containers, err := readContainers(root)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		if c.running {
+			return fmt.Errorf("container %s is running, stop it before migrating to %s", c.id, toDriver)
+		}
+	}
+	if err := checkMounts(filepath.Join(root, fromDriver)); err != nil {
+		return err
+	}
+	if err := checkFreeSpace(root, filepath.Join(root, fromDriver), fromImageRoot); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	logrus.Infof("Migrating the images and containers of the %s storage driver to %s", fromDriver, toDriver)
+
+	from, err := graphdriver.GetDriver(fromDriver, root, nil, uidMaps, gidMaps, nil)
+	if err != nil {
+		return err
+	}
+	defer from.Cleanup()
+	to, err := graphdriver.GetDriver(toDriver, root, options, uidMaps, gidMaps, nil)
+	if err != nil {
+		return err
+	}
+	defer to.Cleanup()
+
+	ms, err := layer.NewFSMetadataStore(filepath.Join(fromImageRoot, "layerdb"))
+	if err != nil {
+		return err
+	}
+	m := newMigration(from, to, ms)
+	if err := m.migrateLayers(); err != nil {
+		m.rollback()
+		return err
+	}
+
+	// The layers keep their cache IDs, so the metadata of the images is
+	// copied as is. It is renamed in place last, which marks the migration
+	// as complete.
+	tmpImageRoot := toImageRoot + "-migration"
+	if err := os.RemoveAll(tmpImageRoot); err != nil {
+		m.rollback()
+		return err
+	}
+	if err := archive.CopyWithTar(fromImageRoot, tmpImageRoot); err != nil {
+		os.RemoveAll(tmpImageRoot)
+		m.rollback()
+		return err
+	}
+	if err := os.Rename(tmpImageRoot, toImageRoot); err != nil {
+		os.RemoveAll(tmpImageRoot)
+		m.rollback()
+		return err
+	}
+
+	if err := migrateContainers(containers); err != nil {
+		if err := os.RemoveAll(toImageRoot); err != nil {
+			logrus.Errorf("Failed to remove the image metadata of the failed %s migration: %v", toDriver, err)
+		}
+		m.rollback()
+		return err
+	}
+
+	logrus.Infof("Migrated %d layers from %s to %s in %.2f seconds", len(m.created), fromDriver, toDriver, time.Since(start).Seconds())
+	return nil
+}
+
+// migration copies the layers referenced by a layer metadata store from a
+// storage driver to another.
+type migration struct {
+	from graphdriver.Driver
+	to   graphdriver.Driver
+	ms   layer.MetadataStore
+	// cacheIDs maps the migrated read-only layers to their cache IDs.
+	cacheIDs map[layer.ChainID]string
+	// created lists the layers created in the destination driver, in
+	// order of creation.
+	created []string
+}
+
+func newMigration(from, to graphdriver.Driver, ms layer.MetadataStore) *migration {
+	return &migration{
+		from:     from,
+		to:       to,
+		ms:       ms,
+		cacheIDs: make(map[layer.ChainID]string),
+	}
+}
+
+// migrateLayers copies the read-only layers, then the init and read-write
+// layers of the containers.
+func (m *migration) migrateLayers() error {
+	ids, mounts, err := m.ms.List()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, err := m.migrateLayer(id); err != nil {
+			return fmt.Errorf("failed to migrate layer %s: %v", id, err)
+		}
+	}
+	for _, mount := range mounts {
+		if err := m.migrateMount(mount); err != nil {
+			return fmt.Errorf("failed to migrate the layer of container %s: %v", mount, err)
+		}
+	}
+	return nil
+}
+
+// migrateLayer copies a read-only layer, after its parents, and returns its
+// cache ID.
+func (m *migration) migrateLayer(id layer.ChainID) (string, error) {
+	if cacheID, ok := m.cacheIDs[id]; ok {
+		return cacheID, nil
+	}
+
+	parent, err := m.ms.GetParent(id)
+	if err != nil {
+		return "", err
+	}
+	var parentCacheID string
+	if parent != "" {
+		if parentCacheID, err = m.migrateLayer(parent); err != nil {
+			return "", err
+		}
+	}
+
+	cacheID, err := m.ms.GetCacheID(id)
+	if err != nil {
+		return "", err
+	}
+	if err := m.to.Create(cacheID, parentCacheID, "", nil); err != nil {
+		return "", err
+	}
+	m.created = append(m.created, cacheID)
+	if err := m.copyLayer(cacheID, parentCacheID); err != nil {
+		return "", err
+	}
+	m.cacheIDs[id] = cacheID
+	return cacheID, nil
+}
+
+// migrateMount copies the init layer, if any, and the read-write layer of a
+// container.
+func (m *migration) migrateMount(mount string) error {
+	parent, err := m.ms.GetMountParent(mount)
+	if err != nil {
+		return err
+	}
+	var parentCacheID string
+	if parent != "" {
+		if parentCacheID, err = m.migrateLayer(parent); err != nil {
+			return err
+		}
+	}
+
+	initID, err := m.ms.GetInitID(mount)
+	if err != nil {
+		return err
+	}
+	if initID != "" {
+		if err := m.to.Create(initID, parentCacheID, "", nil); err != nil {
+			return err
+		}
+		m.created = append(m.created, initID)
+		if err := m.copyLayer(initID, parentCacheID); err != nil {
+			return err
+		}
+		parentCacheID = initID
+	}
+
+	mountID, err := m.ms.GetMountID(mount)
+	if err != nil {
+		return err
+	}
+	if err := m.to.CreateReadWrite(mountID, parentCacheID, "", nil); err != nil {
+		return err
+	}
+	m.created = append(m.created, mountID)
+	return m.copyLayer(mountID, parentCacheID)
+}
+
+// copyLayer applies the diff of a layer in the source driver to the same
+// layer in the destination driver.
+func (m *migration) copyLayer(id, parent string) error {
+	diff, err := m.from.Diff(id, parent)
+	if err != nil {
+		return err
+	}
+	defer diff.Close()
+	_, err = m.to.ApplyDiff(id, parent, diff)
+	return err
+}
+
+// rollback removes the layers created in the destination driver.
+func (m *migration) rollback() {
+	for i := len(m.created) - 1; i >= 0; i-- {
+		if err := m.to.Remove(m.created[i]); err != nil {
+			logrus.Errorf("Failed to remove layer %s of the failed %s migration: %v", m.created[i], toDriver, err)
+		}
+	}
+	m.created = nil
+}
+
+// checkFreeSpace returns an error if the filesystem of root has not enough
+// free space for a copy of the given directories.
+func checkFreeSpace(root string, dirs ...string) error {
+	var needed int64
+	for _, dir := range dirs {
+		size, err := directory.Size(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		needed += size
+	}
+	free, err := freeSpace(root)
+	if err != nil {
+		return err
+	}
+	if uint64(needed) > free {
+		return fmt.Errorf("not enough free space in %s to migrate to %s: %s needed, %s available", root, toDriver, units.BytesSize(float64(needed)), units.BytesSize(float64(free)))
+	}
+	return nil
+}
+
+// checkMounts returns an error if a layer of the storage driver in dir is
+// mounted, such as the layer of a container running while the daemon was
+// restarted with live restore enabled.
+func checkMounts(dir string) error {
+	mounts, err := mount.GetMounts()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if strings.HasPrefix(m.Mountpoint, dir+string(filepath.Separator)) {
+			return fmt.Errorf("%s is mounted, stop the containers using it before migrating to %s", m.Mountpoint, toDriver)
+		}
+	}
+	return nil
+}
+
+// containerConfig is the configuration file of an overlay container, with
+// its original and migrated content.
+type containerConfig struct {
+	id       string
+	running  bool
+	path     string
+	mode     os.FileMode
+	data     []byte
+	migrated []byte
+}
+
+// readContainers reads the configurations of the overlay containers in
+// root. It fails if the configuration of any container cannot be read, as
+// the container could not be migrated.
+func readContainers(root string) ([]*containerConfig, error) {
+	dir := filepath.Join(root, containersDirName)
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var containers []*containerConfig
+	for _, fi := range fileInfos {
+		if !fi.IsDir() {
+			continue
+		}
+		c, err := readContainer(filepath.Join(dir, fi.Name(), configFileName))
+		if err != nil {
+			return nil, fmt.Errorf("cannot migrate container %s to %s: %v", fi.Name(), toDriver, err)
+		}
+		if c != nil {
+			containers = append(containers, c)
+		}
+	}
+	return containers, nil
+}
+
+// readContainer reads the container configuration at configPath, and
+// returns it with its storage driver set to overlay2, or nil if the
+// container does not use overlay.
+func readContainer(configPath string) (*containerConfig, error) {
+	fi, err := os.Stat(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]*json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	var driver string
+	if raw := config["Driver"]; raw != nil {
+		if err := json.Unmarshal(*raw, &driver); err != nil {
+			return nil, err
+		}
+	}
+	if driver != fromDriver {
+		return nil, nil
+	}
+
+	var id string
+	if raw := config["ID"]; raw != nil {
+		if err := json.Unmarshal(*raw, &id); err != nil {
+			return nil, err
+		}
+	}
+	var state struct {
+		Running bool
+	}
+	if raw := config["State"]; raw != nil {
+		if err := json.Unmarshal(*raw, &state); err != nil {
+			return nil, err
+		}
+	}
+
+	raw := json.RawMessage(`"` + toDriver + `"`)
+	config["Driver"] = &raw
+	migrated, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return &containerConfig{
+		id:       id,
+		running:  state.Running,
+		path:     configPath,
+		mode:     fi.Mode(),
+		data:     data,
+		migrated: migrated,
+	}, nil
+}
+
+// migrateContainers switches the storage driver of the containers to
+// overlay2. If any container cannot be switched, the containers already
+// switched are restored to overlay.
+func migrateContainers(containers []*containerConfig) error {
+	for i, c := range containers {
+		if err := ioutils.AtomicWriteFile(c.path, c.migrated, c.mode); err != nil {
+			for _, done := range containers[:i] {
+				if err := ioutils.AtomicWriteFile(done.path, done.data, done.mode); err != nil {
+					logrus.Errorf("Failed to restore the %s storage driver of the container config %s: %v", fromDriver, done.path, err)
+				}
+			}
+			return fmt.Errorf("failed to migrate the container config %s to %s: %v", c.path, toDriver, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package overlay
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/graphdriver/vfs"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+func init() {
+	graphdriver.ApplyUncompressedLayer = archive.ApplyUncompressedLayer
+	vfs.CopyWithTar = archive.CopyWithTar
+
+	reexec.Init()
+}
+
+func newTestDriver(t *testing.T, home string) graphdriver.Driver {
+	d, err := vfs.Init(home, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func createTestLayer(t *testing.T, d graphdriver.Driver, id, parent string, files map[string]string) {
+	if err := d.Create(id, parent, "", nil); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := d.Get(id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Put(id)
+	for name, content := range files {
+		if content == "" {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				t.Fatal(err)
+			}
+		} else if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func addTestLayerMetadata(t *testing.T, ms layer.MetadataStore, id layer.ChainID, parent layer.ChainID, cacheID string) {
+	tx, err := ms.StartTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SetCacheID(cacheID); err != nil {
+		t.Fatal(err)
+	}
+	if parent != "" {
+		if err := tx.SetParent(parent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Commit(id); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkTestLayer(t *testing.T, d graphdriver.Driver, id string, files map[string]string) {
+	dir, err := d.Get(id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Put(id)
+	for name, content := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if content == "" {
+			if !os.IsNotExist(err) {
+				t.Fatalf("Expected %s to be removed from layer %s, got %v", name, id, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Fatalf("Expected %s of layer %s to be %q, got %q", name, id, content, data)
+		}
+	}
+}
+
+func TestMigrateLayers(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "migrate-overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	from := newTestDriver(t, filepath.Join(tmpdir, "from"))
+	to := newTestDriver(t, filepath.Join(tmpdir, "to"))
+	ms, err := layer.NewFSMetadataStore(filepath.Join(tmpdir, "layerdb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseID, childID := stringid.GenerateRandomID(), stringid.GenerateRandomID()
+	mountID := stringid.GenerateRandomID()
+	initID := mountID + "-init"
+	base := layer.ChainID(digest.FromBytes([]byte("base")))
+	child := layer.ChainID(digest.FromBytes([]byte("child")))
+
+	createTestLayer(t, from, baseID, "", map[string]string{"a": "base a", "b": "base b"})
+	createTestLayer(t, from, childID, baseID, map[string]string{"a": "", "c": "child c"})
+	createTestLayer(t, from, initID, childID, map[string]string{"d": "init d"})
+	createTestLayer(t, from, mountID, initID, map[string]string{"b": "container b"})
+
+	// The child layer is listed first, its parent must still be migrated
+	// before it.
+	addTestLayerMetadata(t, ms, child, base, childID)
+	addTestLayerMetadata(t, ms, base, "", baseID)
+	if err := ms.SetMountID("container", mountID); err != nil {
+		t.Fatal(err)
+	}
+	if err := ms.SetInitID("container", initID); err != nil {
+		t.Fatal(err)
+	}
+	if err := ms.SetMountParent("container", child); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newMigration(from, to, ms)
+	if err := m.migrateLayers(); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.created) != 4 {
+		t.Fatalf("Expected 4 layers to be created, got %v", m.created)
+	}
+
+	checkTestLayer(t, to, baseID, map[string]string{"a": "base a", "b": "base b"})
+	checkTestLayer(t, to, childID, map[string]string{"a": "", "b": "base b", "c": "child c"})
+	checkTestLayer(t, to, mountID, map[string]string{"a": "", "b": "container b", "c": "child c", "d": "init d"})
+
+	m.rollback()
+	for _, id := range []string{baseID, childID, initID, mountID} {
+		if to.Exists(id) {
+			t.Fatalf("Expected layer %s to be removed by the rollback", id)
+		}
+	}
+}
+
+func TestMigrateContainer(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "migrate-overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	for driver, expected := range map[string]string{
+		"overlay":  `{"Driver":"overlay2","ID":"c"}`,
+		"aufs":     `{"Driver":"aufs","ID":"c"}`,
+		"overlay2": `{"Driver":"overlay2","ID":"c"}`,
+	} {
+		configPath := filepath.Join(tmpdir, driver+".json")
+		if err := ioutil.WriteFile(configPath, []byte(`{"Driver":"`+driver+`","ID":"c"}`), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := readContainer(configPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != nil {
+			if err := migrateContainers([]*containerConfig{c}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		data, err := ioutil.ReadFile(configPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != expected {
+			t.Fatalf("Expected the %s container config to be %s, got %s", driver, expected, data)
+		}
+	}
+}
+
+func TestMigrateContainersRestore(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "migrate-overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	configPath := filepath.Join(tmpdir, "config.v2.json")
+	if err := ioutil.WriteFile(configPath, []byte(`{"Driver":"overlay","ID":"c"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := readContainer(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := &containerConfig{
+		path:     filepath.Join(tmpdir, "missing", "config.v2.json"),
+		mode:     0600,
+		migrated: []byte(`{"Driver":"overlay2","ID":"m"}`),
+	}
+	if err := migrateContainers([]*containerConfig{c, missing}); err == nil {
+		t.Fatal("Expected the migration of the containers to fail")
+	}
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"Driver":"overlay","ID":"c"}`; string(data) != expected {
+		t.Fatalf("Expected the container config to be restored to %s, got %s", expected, data)
+	}
+}
+
+func TestReadRunningContainer(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "migrate-overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	configPath := filepath.Join(tmpdir, "config.v2.json")
+	if err := ioutil.WriteFile(configPath, []byte(`{"Driver":"overlay","ID":"c","State":{"Running":true}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := readContainer(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.id != "c" || !c.running {
+		t.Fatalf("Expected container c to be running, got %s running %v", c.id, c.running)
+	}
+}
+
+func TestMigrateRunningContainer(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "migrate-overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	if err := os.MkdirAll(filepath.Join(tmpdir, "image", "overlay"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := Pending(tmpdir); err != nil || !pending {
+		t.Fatalf("Expected the migration to be pending, got %v, %v", pending, err)
+	}
+	containerDir := filepath.Join(tmpdir, "containers", "c")
+	if err := os.MkdirAll(containerDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	config := []byte(`{"Driver":"overlay","ID":"c","State":{"Running":true}}`)
+	if err := ioutil.WriteFile(filepath.Join(containerDir, "config.v2.json"), config, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Migrate(tmpdir, nil, nil, nil); err == nil || !strings.Contains(err.Error(), "is running") {
+		t.Fatalf("Expected the migration to be refused while container c is running, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpdir, "image", "overlay2")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no overlay2 images, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package opts
 
 import (
 	"fmt"
+	"math/big"
 	"net"
 	"regexp"
 	"strings"
@@ -319,3 +320,43 @@ func (o *FilterOpt) Type() string {
 func (o *FilterOpt) Value() filters.Args {
 	return o.filter
 }
+
+// NanoCPUs is a flag type for a fractional number of CPUs, stored in units
+// of 10^-9 CPUs.
+type NanoCPUs int64
+
+// String returns the number of CPUs with three decimals
+func (c *NanoCPUs) String() string {
+	return big.NewRat(c.Value(), 1e9).FloatString(3)
+}
+
+// Set sets the value of the opt by parsing a decimal number of CPUs
+func (c *NanoCPUs) Set(value string) error {
+	cpus, err := ParseCPUs(value)
+	*c = NanoCPUs(cpus)
+	return err
+}
+
+// Type returns the option type
+func (c *NanoCPUs) Type() string {
+	return "decimal"
+}
+
+// Value returns the value of this option in units of 10^-9 CPUs
+func (c *NanoCPUs) Value() int64 {
+	return int64(*c)
+}
+
+// ParseCPUs parses a decimal number of CPUs, such as "1.5", and returns it
+// in units of 10^-9 CPUs.
+func ParseCPUs(value string) (int64, error) {
+	cpu, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("Failed to parse %v as a rational number", value)
+	}
+	nano := cpu.Mul(cpu, big.NewRat(1e9, 1))
+	if !nano.IsInt() {
+		return 0, fmt.Errorf("value is too precise")
+	}
+	return nano.Num().Int64(), nil
+}
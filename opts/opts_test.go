@@ -230,3 +230,23 @@ func TestNamedMapOpts(t *testing.T) {
 		t.Errorf("expected map-size to be in the values, got %v", tmpMap)
 	}
 }
+
+func TestNanoCPUs(t *testing.T) {
+	var cpus NanoCPUs = 6100000000
+	if cpus.String() != "6.100" {
+		t.Fatalf("expected 6.100, got %s", cpus.String())
+	}
+
+	if err := cpus.Set("0.35"); err != nil {
+		t.Fatal(err)
+	}
+	if cpus.Value() != 350000000 {
+		t.Fatalf("expected 350000000, got %d", cpus.Value())
+	}
+
+	for _, value := range []string{"foo", "0.0000000001"} {
+		if err := cpus.Set(value); err == nil {
+			t.Fatalf("expected an error for %s", value)
+		}
+	}
+}
@@ -98,6 +98,8 @@ const (
 	Gzip
 	// Xz is xz compression algorithm.
 	Xz
+	// Zstd is zstd compression algorithm.
+	Zstd
 )
 
 const (
@@ -143,6 +145,7 @@ func DetectCompression(source []byte) Compression {
 		Bzip2: {0x42, 0x5A, 0x68},
 		Gzip:  {0x1F, 0x8B, 0x08},
 		Xz:    {0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00},
+		Zstd:  {0x28, 0xB5, 0x2F, 0xFD},
 	} {
 		if len(source) < len(m) {
 			logrus.Debug("Len too short")
@@ -161,6 +164,36 @@ func xzDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
 	return cmdStream(exec.Command(args[0], args[1:]...), archive)
 }
 
+// ZstdAvailable returns an error if the zstd command, which compresses and
+// decompresses the zstd archives, is not found.
+func ZstdAvailable() error {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		return fmt.Errorf("the zstd command is required for zstd compression: %v", err)
+	}
+	return nil
+}
+
+func zstdDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
+	if err := ZstdAvailable(); err != nil {
+		return nil, nil, err
+	}
+	args := []string{"zstd", "-d", "-c", "-q"}
+
+	return cmdStream(exec.Command(args[0], args[1:]...), archive)
+}
+
+func zstdCompress(dest io.Writer, level int) (io.WriteCloser, error) {
+	if err := ZstdAvailable(); err != nil {
+		return nil, err
+	}
+	args := []string{"zstd", "-c", "-q"}
+	if level != 0 {
+		args = append(args, fmt.Sprintf("-%d", level))
+	}
+
+	return cmdWriter(exec.Command(args[0], args[1:]...), dest)
+}
+
 // DecompressStream decompresses the archive and returns a ReaderCloser with the decompressed archive.
 func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 	p := pools.BufioReader32KPool
@@ -202,6 +235,16 @@ func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 			<-chdone
 			return readBufWrapper.Close()
 		}), nil
+	case Zstd:
+		zstdReader, chdone, err := zstdDecompress(buf)
+		if err != nil {
+			return nil, err
+		}
+		readBufWrapper := p.NewReadCloserWrapper(buf, zstdReader)
+		return ioutils.NewReadCloserWrapper(readBufWrapper, func() error {
+			<-chdone
+			return readBufWrapper.Close()
+		}), nil
 	default:
 		return nil, fmt.Errorf("Unsupported compression format %s", (&compression).Extension())
 	}
@@ -209,6 +252,17 @@ func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 
 // CompressStream compresseses the dest with specified compression algorithm.
 func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, error) {
+	return CompressStreamLevel(dest, compression, 0)
+}
+
+// CompressStreamLevel compresses the dest with specified compression algorithm
+// and level, 0 being the default level of the algorithm. Zstd compression runs
+// the zstd command, so the error of the command is returned when closing the
+// stream.
+func CompressStreamLevel(dest io.Writer, compression Compression, level int) (io.WriteCloser, error) {
+	if err := ValidateCompressionLevel(compression, level); err != nil {
+		return nil, err
+	}
 	p := pools.BufioWriter32KPool
 	buf := p.Get(dest)
 	switch compression {
@@ -216,9 +270,16 @@ func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, er
 		writeBufWrapper := p.NewWriteCloserWrapper(buf, buf)
 		return writeBufWrapper, nil
 	case Gzip:
-		gzWriter := gzip.NewWriter(dest)
-		writeBufWrapper := p.NewWriteCloserWrapper(buf, gzWriter)
-		return writeBufWrapper, nil
+		// The gzip writer is returned as is, so that the errors of the
+		// writes of its footer are returned when closing it.
+		p.Put(buf)
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(dest, level)
+	case Zstd:
+		p.Put(buf)
+		return zstdCompress(dest, level)
 	case Bzip2, Xz:
 		// archive/bzip2 does not support writing, and there is no xz support at all
 		// However, this is not a problem as docker only currently generates gzipped tars
@@ -239,10 +300,43 @@ func (compression *Compression) Extension() string {
 		return "tar.gz"
 	case Xz:
 		return "tar.xz"
+	case Zstd:
+		return "tar.zst"
 	}
 	return ""
 }
 
+// ParseCompression returns the compression algorithm named gzip or zstd, as
+// in the compression options of the layers of images.
+func ParseCompression(name string) (Compression, error) {
+	switch name {
+	case "gzip":
+		return Gzip, nil
+	case "zstd":
+		return Zstd, nil
+	}
+	return Uncompressed, fmt.Errorf("invalid compression %q, it must be gzip or zstd", name)
+}
+
+// ValidateCompressionLevel checks the level of a compression algorithm, 0
+// being its default level.
+func ValidateCompressionLevel(compression Compression, level int) error {
+	max := 0
+	switch compression {
+	case Gzip:
+		max = gzip.BestCompression
+	case Zstd:
+		max = 19
+	}
+	if level < 0 || level > max {
+		if max == 0 {
+			return fmt.Errorf("invalid compression level %d, the compression has no level", level)
+		}
+		return fmt.Errorf("invalid compression level %d, it must be between 1 and %d", level, max)
+	}
+	return nil
+}
+
 type tarWhiteoutConverter interface {
 	ConvertWrite(*tar.Header, string, os.FileInfo) error
 	ConvertRead(*tar.Header, string) (bool, error)
@@ -851,7 +945,7 @@ loop:
 // Untar reads a stream of bytes from `archive`, parses it as a tar archive,
 // and unpacks it into the directory at `dest`.
 // The archive may be compressed with one of the following algorithms:
-//  identity (uncompressed), gzip, bzip2, xz.
+//  identity (uncompressed), gzip, bzip2, xz, zstd.
 // FIXME: specify behavior when target path exists vs. doesn't exist.
 func Untar(tarArchive io.Reader, dest string, options *TarOptions) error {
 	return untarHandler(tarArchive, dest, options, true)
@@ -1103,6 +1197,32 @@ func cmdStream(cmd *exec.Cmd, input io.Reader) (io.ReadCloser, <-chan struct{},
 	return pipeR, chdone, nil
 }
 
+// cmdWriter executes a command, and returns its stdin as a stream written to
+// output by the command. Closing the stream waits for the command, and returns
+// an error, including anything written on stderr, if it doesn't complete
+// successfully.
+func cmdWriter(cmd *exec.Cmd, output io.Writer) (io.WriteCloser, error) {
+	cmd.Stdout = output
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return ioutils.NewWriteCloserWrapper(stdin, func() error {
+		stdin.Close()
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("%s: %s", err, errBuf.String())
+		}
+		return nil
+	}), nil
+}
+
 // NewTempArchive reads the content of src into a temporary file, and returns the contents
 // of that file as an archive. The archive can only be read once - as soon as reading completes,
 // the file will be deleted.
@@ -135,6 +135,78 @@ func TestDecompressStreamXz(t *testing.T) {
 	testDecompressStream(t, "xz", "xz -f")
 }
 
+func TestDecompressStreamZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd not installed")
+	}
+	testDecompressStream(t, "zst", "zstd -q -f")
+}
+
+func TestCompressStreamZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd not installed")
+	}
+	var dest bytes.Buffer
+	w, err := CompressStreamLevel(&dest, Zstd, 19)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("hello zstd")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if c := DetectCompression(dest.Bytes()); c != Zstd {
+		t.Fatalf("expected a zstd stream, got %s", (&c).Extension())
+	}
+
+	r, err := DecompressStream(&dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "hello zstd" {
+		t.Fatalf("expected 'hello zstd', got %q", content)
+	}
+}
+
+func TestCompressStreamLevelInvalid(t *testing.T) {
+	for _, c := range []struct {
+		compression Compression
+		level       int
+	}{
+		{Gzip, 10},
+		{Gzip, -1},
+		{Zstd, 20},
+		{Uncompressed, 1},
+	} {
+		if _, err := CompressStreamLevel(ioutil.Discard, c.compression, c.level); err == nil {
+			t.Fatalf("expected an error for the level %d of %s", c.level, (&c.compression).Extension())
+		}
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	for name, expected := range map[string]Compression{"gzip": Gzip, "zstd": Zstd} {
+		c, err := ParseCompression(name)
+		if err != nil || c != expected {
+			t.Fatalf("expected %s to be parsed as %s, got %s, %v", name, (&expected).Extension(), (&c).Extension(), err)
+		}
+	}
+	for _, name := range []string{"", "xz", "none"} {
+		if _, err := ParseCompression(name); err == nil {
+			t.Fatalf("expected an error for %q", name)
+		}
+	}
+}
+
 func TestCompressStreamXzUnsuported(t *testing.T) {
 	dest, err := os.Create(tmp + "dest")
 	if err != nil {
@@ -210,6 +282,13 @@ func TestExtensionXz(t *testing.T) {
 		t.Fatalf("The extension of a bzip2 archive should be 'tar.xz'")
 	}
 }
+func TestExtensionZstd(t *testing.T) {
+	compression := Zstd
+	output := compression.Extension()
+	if output != "tar.zst" {
+		t.Fatalf("The extension of a zstd archive should be 'tar.zst'")
+	}
+}
 
 func TestCmdStreamLargeStderr(t *testing.T) {
 	cmd := exec.Command("sh", "-c", "dd if=/dev/zero bs=1k count=1000 of=/dev/stderr; echo hello")
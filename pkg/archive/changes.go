@@ -390,6 +390,55 @@ func ChangesSize(newDir string, changes []Change) int64 {
 	return size
 }
 
+// ChangesUsage is the disk usage of changes.
+type ChangesUsage struct {
+	Size   int64 // Size is the size of the files added or modified
+	Inodes int64 // Inodes is the number of files and directories added or modified
+}
+
+// ChangesUsageByDir calculates the disk usage of the changes in newDir,
+// broken down by top-level directory. The files with several hard links are
+// only counted once. The changes at the root are reported under "/".
+func ChangesUsageByDir(newDir string, changes []Change) map[string]ChangesUsage {
+	var (
+		usage = make(map[string]ChangesUsage)
+		sf    = make(map[uint64]struct{})
+	)
+	for _, change := range changes {
+		if change.Kind != ChangeModify && change.Kind != ChangeAdd {
+			continue
+		}
+		file := filepath.Join(newDir, change.Path)
+		fileInfo, err := os.Lstat(file)
+		if err != nil {
+			logrus.Errorf("Can not stat %q: %s", file, err)
+			continue
+		}
+
+		dir := "/"
+		if parts := strings.SplitN(strings.TrimPrefix(filepath.ToSlash(change.Path), "/"), "/", 2); len(parts) == 2 {
+			dir += parts[0]
+		} else if fileInfo.IsDir() {
+			dir += parts[0]
+		}
+
+		u := usage[dir]
+		if hasHardlinks(fileInfo) {
+			inode := getIno(fileInfo)
+			if _, ok := sf[inode]; ok {
+				continue
+			}
+			sf[inode] = struct{}{}
+		}
+		u.Inodes++
+		if !fileInfo.IsDir() {
+			u.Size += fileInfo.Size()
+		}
+		usage[dir] = u
+	}
+	return usage
+}
+
 // ExportChanges produces an Archive from the provided changes, relative to dir.
 func ExportChanges(dir string, changes []Change, uidMaps, gidMaps []idtools.IDMap) (Archive, error) {
 	reader, writer := io.Pipe()
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"reflect"
 	"runtime"
 	"sort"
 	"testing"
@@ -563,3 +564,44 @@ func checkChanges(expectedChanges, changes []Change, t *testing.T) {
 		}
 	}
 }
+
+func TestChangesUsageByDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hardlinks on Windows")
+	}
+	dir, err := ioutil.TempDir("", "docker-test-changes-usage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(path.Join(dir, "var", "log"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "var", "log", "app.log"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(path.Join(dir, "var", "log", "app.log"), path.Join(dir, "var", "app.log")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "file"), make([]byte, 10), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := []Change{
+		{Path: "/var", Kind: ChangeModify},
+		{Path: "/var/log", Kind: ChangeAdd},
+		{Path: "/var/log/app.log", Kind: ChangeAdd},
+		{Path: "/var/app.log", Kind: ChangeAdd},
+		{Path: "/file", Kind: ChangeAdd},
+		{Path: "/etc/deleted", Kind: ChangeDelete},
+	}
+	usage := ChangesUsageByDir(dir, changes)
+	expected := map[string]ChangesUsage{
+		"/var": {Size: 100, Inodes: 3},
+		"/":    {Size: 10, Inodes: 1},
+	}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Fatalf("Expected %v, got %v", expected, usage)
+	}
+}
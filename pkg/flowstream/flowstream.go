@@ -0,0 +1,296 @@
+// Package flowstream multiplexes byte streams over a single connection.
+// Each stream has a flow control window: a side does not send more data on a
+// stream than the other side has room to buffer, and the window is updated
+// as the data is read. A slow reader therefore slows down the writer instead
+// of making the other side buffer data without bound.
+//
+// Each frame starts with an 8 bytes header:
+//
+//	type     uint8
+//	stream   uint8
+//	reserved uint16
+//	length   uint32, big endian
+//
+// followed by length bytes of payload. A data frame carries data of the
+// stream, a window frame carries the number of bytes, as a big endian
+// uint32, the receiver has read and the sender can send again, and a close
+// frame, without payload, marks the end of the data of the stream.
+package flowstream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Protocol is the protocol name used in the Upgrade header of HTTP
+// requests and responses to negotiate the use of flowstream.
+const Protocol = "flowstream"
+
+// The streams of an attach session: the input of the container, sent by the
+// client, and its output, sent by the daemon.
+const (
+	AttachStdinStream uint8 = iota
+	AttachOutputStream
+)
+
+const (
+	frameData byte = iota
+	frameWindow
+	frameClose
+
+	headerLen = 8
+
+	// MaxFrameSize is the maximum length of the payload of a frame.
+	MaxFrameSize = 32 * 1024
+	// InitialWindow is the number of bytes that can be sent on a stream
+	// before they are read by the other side.
+	InitialWindow = 256 * 1024
+)
+
+var (
+	// ErrClosed is returned when using a closed session.
+	ErrClosed = errors.New("flowstream: session closed")
+
+	errProtocol = errors.New("flowstream: protocol error")
+)
+
+// Session multiplexes streams over a connection.
+type Session struct {
+	conn io.ReadWriteCloser
+	wmu  sync.Mutex // serializes the writes of frames
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	streams map[uint8]*Stream
+	err     error
+}
+
+// NewSession returns a session over conn and starts reading its frames.
+// Both sides of the connection must use a session.
+func NewSession(conn io.ReadWriteCloser) *Session {
+	s := &Session{
+		conn:    conn,
+		streams: make(map[uint8]*Stream),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	go s.readFrames()
+	return s
+}
+
+// Stream returns the stream with the given id.
+func (s *Session) Stream(id uint8) *Stream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream(id)
+}
+
+// stream returns the stream with the given id, creating it if needed. The
+// caller must hold s.mu.
+func (s *Session) stream(id uint8) *Stream {
+	st, ok := s.streams[id]
+	if !ok {
+		st = &Stream{id: id, s: s, sendWindow: InitialWindow}
+		s.streams[id] = st
+	}
+	return st
+}
+
+// Close closes the session and its connection. The data received before
+// can still be read from the streams.
+func (s *Session) Close() error {
+	s.fail(ErrClosed)
+	return s.conn.Close()
+}
+
+// fail records the first error of the session and wakes up the streams.
+func (s *Session) fail(err error) {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.cond.Broadcast()
+	s.mu.Unlock()
+}
+
+func (s *Session) writeFrame(typ, id byte, payload []byte) error {
+	buf := make([]byte, headerLen+len(payload))
+	buf[0] = typ
+	buf[1] = id
+	binary.BigEndian.PutUint32(buf[4:headerLen], uint32(len(payload)))
+	copy(buf[headerLen:], payload)
+
+	s.wmu.Lock()
+	_, err := s.conn.Write(buf)
+	s.wmu.Unlock()
+	if err != nil {
+		s.fail(err)
+	}
+	return err
+}
+
+func (s *Session) readFrames() {
+	var hdr [headerLen]byte
+	for {
+		if _, err := io.ReadFull(s.conn, hdr[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = errProtocol
+			}
+			s.fail(err)
+			return
+		}
+		typ, id, length := hdr[0], hdr[1], binary.BigEndian.Uint32(hdr[4:])
+		if length > MaxFrameSize {
+			s.fail(errProtocol)
+			return
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(s.conn, payload); err != nil {
+			s.fail(errProtocol)
+			return
+		}
+
+		s.mu.Lock()
+		st := s.stream(id)
+		var err error
+		switch typ {
+		case frameData:
+			// the sender must not send more than the window
+			if st.buf.Len()+st.consumed+len(payload) > InitialWindow {
+				err = errProtocol
+				break
+			}
+			if !st.eof {
+				st.buf.Write(payload)
+			}
+		case frameWindow:
+			if len(payload) != 4 {
+				err = errProtocol
+				break
+			}
+			st.sendWindow += int(binary.BigEndian.Uint32(payload))
+		case frameClose:
+			st.eof = true
+		default:
+			err = errProtocol
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		if err != nil {
+			s.fail(err)
+			return
+		}
+	}
+}
+
+// Stream is a bidirectional stream of a session. A stream can be read and
+// written concurrently.
+type Stream struct {
+	id uint8
+	s  *Session
+
+	// protected by s.mu
+	buf         bytes.Buffer
+	consumed    int  // bytes read and not acknowledged with a window frame
+	eof         bool // the other side closed the stream for writing
+	sendWindow  int
+	writeClosed bool
+}
+
+// Read reads the data received on the stream. It returns io.EOF after the
+// other side closed the stream for writing.
+func (st *Stream) Read(p []byte) (int, error) {
+	s := st.s
+	s.mu.Lock()
+	for st.buf.Len() == 0 && !st.eof && s.err == nil {
+		s.cond.Wait()
+	}
+	if st.buf.Len() == 0 {
+		err := s.err
+		if st.eof {
+			err = io.EOF
+		}
+		s.mu.Unlock()
+		return 0, err
+	}
+	n, _ := st.buf.Read(p)
+	st.consumed += n
+
+	// Acknowledge the data read in batches, once half of the window is
+	// used, to limit the number of window frames.
+	var update uint32
+	if st.consumed >= InitialWindow/2 {
+		update = uint32(st.consumed)
+		st.consumed = 0
+	}
+	s.mu.Unlock()
+
+	if update > 0 {
+		var payload [4]byte
+		binary.BigEndian.PutUint32(payload[:], update)
+		// an error is reported by the next operations on the session
+		s.writeFrame(frameWindow, st.id, payload[:])
+	}
+	return n, nil
+}
+
+// Write sends p on the stream. It blocks while the window of the stream
+// is exhausted, that is until the other side reads the data sent before.
+func (st *Stream) Write(p []byte) (int, error) {
+	s := st.s
+	written := 0
+	for len(p) > 0 {
+		s.mu.Lock()
+		for st.sendWindow == 0 && !st.writeClosed && s.err == nil {
+			s.cond.Wait()
+		}
+		if s.err != nil {
+			err := s.err
+			s.mu.Unlock()
+			return written, err
+		}
+		if st.writeClosed {
+			s.mu.Unlock()
+			return written, io.ErrClosedPipe
+		}
+		n := len(p)
+		if n > st.sendWindow {
+			n = st.sendWindow
+		}
+		if n > MaxFrameSize {
+			n = MaxFrameSize
+		}
+		st.sendWindow -= n
+		s.mu.Unlock()
+
+		if err := s.writeFrame(frameData, st.id, p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// CloseWrite closes the stream for writing. The other side reads io.EOF
+// after the data sent before.
+func (st *Stream) CloseWrite() error {
+	s := st.s
+	s.mu.Lock()
+	if st.writeClosed {
+		s.mu.Unlock()
+		return nil
+	}
+	st.writeClosed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	return s.writeFrame(frameClose, st.id, nil)
+}
+
+// Close closes the stream for writing.
+func (st *Stream) Close() error {
+	return st.CloseWrite()
+}
@@ -0,0 +1,102 @@
+package flowstream
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+)
+
+func newSessions() (*Session, *Session) {
+	c1, c2 := net.Pipe()
+	return NewSession(c1), NewSession(c2)
+}
+
+func TestStreams(t *testing.T) {
+	client, server := newSessions()
+	defer client.Close()
+	defer server.Close()
+
+	out := bytes.Repeat([]byte("o"), 3*InitialWindow)
+	errOut := []byte("error")
+	go func() {
+		server.Stream(1).Write(out)
+		server.Stream(1).CloseWrite()
+	}()
+	go func() {
+		server.Stream(2).Write(errOut)
+		server.Stream(2).CloseWrite()
+	}()
+
+	b, err := ioutil.ReadAll(client.Stream(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, errOut) {
+		t.Fatalf("expected %q on stream 2, got %q", errOut, b)
+	}
+	b, err = ioutil.ReadAll(client.Stream(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, out) {
+		t.Fatalf("expected %d bytes on stream 1, got %d", len(out), len(b))
+	}
+}
+
+func TestWriteBlocksOnWindow(t *testing.T) {
+	client, server := newSessions()
+	defer client.Close()
+	defer server.Close()
+
+	written := make(chan int)
+	go func() {
+		n, _ := server.Stream(1).Write(make([]byte, InitialWindow+1))
+		written <- n
+	}()
+
+	select {
+	case n := <-written:
+		t.Fatalf("expected the write to block on the window, wrote %d bytes", n)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// reading half of the window sends a window update
+	if _, err := io.ReadFull(client.Stream(1), make([]byte, InitialWindow/2)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case n := <-written:
+		if n != InitialWindow+1 {
+			t.Fatalf("expected %d bytes written, got %d", InitialWindow+1, n)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the write to complete after the window update")
+	}
+}
+
+func TestSessionClose(t *testing.T) {
+	client, server := newSessions()
+
+	if _, err := server.Stream(1).Write([]byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	b := make([]byte, 4)
+	if _, err := io.ReadFull(client.Stream(1), b); err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("expected the data sent before closing, got %q", b)
+	}
+	if _, err := client.Stream(1).Read(b); err == nil {
+		t.Fatal("expected an error reading from a closed session")
+	}
+	if _, err := server.Stream(1).Write(b); err != ErrClosed {
+		t.Fatalf("expected %v writing to a closed session, got %v", ErrClosed, err)
+	}
+	client.Close()
+}
@@ -1,6 +1,7 @@
 package gitutils
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -14,45 +15,131 @@ import (
 	"github.com/docker/docker/pkg/urlutil"
 )
 
+// defaultTokenUsername is the username sent with the token of a clone over
+// HTTP(S) when none is given.
+const defaultTokenUsername = "x-access-token"
+
+// CloneOptions holds the credentials of the clone of a private repository.
+type CloneOptions struct {
+	// Username and Token authenticate the clone of a repository over
+	// HTTP(S). Username defaults to x-access-token.
+	Username string
+	Token    string
+	// SSHKey is the private key authenticating the clone of a repository
+	// over SSH. SSHKnownHosts holds the host keys of the servers that are
+	// trusted, in the format of the known_hosts file of OpenSSH; the host
+	// keys of the user running git are used if it is empty.
+	SSHKey        []byte
+	SSHKnownHosts []byte
+}
+
 // Clone clones a repository into a newly created directory which
 // will be under "docker-build-git"
 func Clone(remoteURL string) (string, error) {
+	root, _, err := CloneWithOptions(remoteURL, CloneOptions{})
+	return root, err
+}
+
+// CloneWithOptions clones a repository as Clone does, authenticating with
+// the credentials of opts. It also returns the SHA of the commit checked out.
+func CloneWithOptions(remoteURL string, opts CloneOptions) (string, string, error) {
 	if !urlutil.IsGitTransport(remoteURL) {
 		remoteURL = "https://" + remoteURL
 	}
 	root, err := ioutil.TempDir("", "docker-build-git")
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+
+	u, err := parseRemoteURL(remoteURL)
+	if err != nil {
+		os.RemoveAll(root)
+		return "", "", err
 	}
 
-	u, err := url.Parse(remoteURL)
+	a, err := newAuth(u, opts)
 	if err != nil {
-		return "", err
+		os.RemoveAll(root)
+		return "", "", err
 	}
+	defer a.cleanup()
 
 	fragment := u.Fragment
-	clone := cloneArgs(u, root)
+	clone := cloneArgs(u, root, a.authorization)
 
-	if output, err := git(clone...); err != nil {
-		return "", fmt.Errorf("Error trying to use git: %s (%s)", err, output)
+	output, err := gitWithEnv(a.env, clone...)
+	if err != nil && containsArg(clone, "--branch") {
+		// The ref may not be a branch or a tag, or the server may not
+		// support shallow clones: fall back to a full clone.
+		os.RemoveAll(root)
+		output, err = gitWithEnv(a.env, fullCloneArgs(clone)...)
+	}
+	if err != nil {
+		os.RemoveAll(root)
+		return "", "", fmt.Errorf("Error trying to use git: %s (%s)", err, output)
 	}
 
-	return checkoutGit(fragment, root)
+	ctxDir, err := checkoutGit(fragment, root)
+	if err != nil {
+		os.RemoveAll(root)
+		return "", "", err
+	}
+
+	output, err = gitWithinDir(root, "rev-parse", "HEAD")
+	if err != nil {
+		os.RemoveAll(root)
+		return "", "", fmt.Errorf("Error trying to use git: %s (%s)", err, output)
+	}
+	return ctxDir, strings.TrimSpace(string(output)), nil
 }
 
-func cloneArgs(remoteURL *url.URL, root string) []string {
+// parseRemoteURL parses the URL of a repository. The scp-like syntax of the
+// SSH remotes, git@host:path, is kept opaque as it is not a valid URL.
+func parseRemoteURL(remoteURL string) (*url.URL, error) {
+	if !strings.HasPrefix(remoteURL, "git@") {
+		return url.Parse(remoteURL)
+	}
+	u := &url.URL{Opaque: remoteURL}
+	if i := strings.Index(remoteURL, "#"); i >= 0 {
+		u.Opaque, u.Fragment = remoteURL[:i], remoteURL[i+1:]
+	}
+	return u, nil
+}
+
+// cloneArgs returns the arguments of the clone of the repository. The clone
+// is shallow unless the ref of the fragment is a commit, or the server is a
+// "dumb" HTTP server; the authorization is sent to check the latter.
+func cloneArgs(remoteURL *url.URL, root, authorization string) []string {
 	args := []string{"clone", "--recursive"}
-	shallow := len(remoteURL.Fragment) == 0
+	ref := strings.SplitN(remoteURL.Fragment, ":", 2)[0]
+	// A commit can only be checked out from a full clone.
+	shallow := !isCommitSHA(ref)
 
 	if shallow && strings.HasPrefix(remoteURL.Scheme, "http") {
-		res, err := http.Head(fmt.Sprintf("%s/info/refs?service=git-upload-pack", remoteURL))
-		if err != nil || res.Header.Get("Content-Type") != "application/x-git-upload-pack-advertisement" {
+		u := *remoteURL
+		u.Fragment = ""
+		req, err := http.NewRequest("HEAD", fmt.Sprintf("%s/info/refs?service=git-upload-pack", &u), nil)
+		if err != nil {
 			shallow = false
+		} else {
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil || res.Header.Get("Content-Type") != "application/x-git-upload-pack-advertisement" {
+				shallow = false
+			}
+			if err == nil {
+				res.Body.Close()
+			}
 		}
 	}
 
 	if shallow {
 		args = append(args, "--depth", "1")
+		if ref != "" {
+			args = append(args, "--branch", ref)
+		}
 	}
 
 	if remoteURL.Fragment != "" {
@@ -62,6 +149,118 @@ func cloneArgs(remoteURL *url.URL, root string) []string {
 	return append(args, remoteURL.String(), root)
 }
 
+// fullCloneArgs returns the arguments of a shallow clone without the options
+// making it shallow.
+func fullCloneArgs(args []string) []string {
+	var full []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--depth" || args[i] == "--branch" {
+			i++
+			continue
+		}
+		full = append(full, args[i])
+	}
+	return full
+}
+
+func containsArg(args []string, arg string) bool {
+	for _, a := range args {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommitSHA returns whether ref is the full SHA-1 of a commit.
+func isCommitSHA(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	for _, c := range ref {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// auth holds the configuration of git authenticating a clone.
+type auth struct {
+	env           []string // env is the environment of git, nil if it is inherited
+	authorization string // authorization is the header sent over HTTP(S)
+	dir           string // dir holds the SSH key and known hosts, if any
+}
+
+// newAuth returns the configuration of git authenticating the clone of the
+// repository at u with the credentials of opts. The token of a clone over
+// HTTP(S) is only sent to the host of the repository. It is set through the
+// environment of git rather than its arguments, which other users can read.
+func newAuth(u *url.URL, opts CloneOptions) (*auth, error) {
+	a := &auth{}
+	if opts.Token != "" && strings.HasPrefix(u.Scheme, "http") {
+		username := opts.Username
+		if username == "" {
+			username = defaultTokenUsername
+		}
+		a.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+opts.Token))
+		a.env = append(environWithoutGitConfig(),
+			"GIT_CONFIG_COUNT=1",
+			fmt.Sprintf("GIT_CONFIG_KEY_0=http.%s://%s/.extraheader", u.Scheme, u.Host),
+			"GIT_CONFIG_VALUE_0=Authorization: "+a.authorization,
+		)
+	}
+
+	if len(opts.SSHKey) == 0 {
+		return a, nil
+	}
+	dir, err := ioutil.TempDir("", "docker-build-git-ssh")
+	if err != nil {
+		return nil, err
+	}
+	a.dir = dir
+	keyPath := filepath.Join(dir, "id")
+	if err := ioutil.WriteFile(keyPath, opts.SSHKey, 0600); err != nil {
+		a.cleanup()
+		return nil, err
+	}
+	sshCommand := fmt.Sprintf("ssh -i '%s' -o IdentitiesOnly=yes", keyPath)
+	if len(opts.SSHKnownHosts) > 0 {
+		knownHostsPath := filepath.Join(dir, "known_hosts")
+		if err := ioutil.WriteFile(knownHostsPath, opts.SSHKnownHosts, 0600); err != nil {
+			a.cleanup()
+			return nil, err
+		}
+		sshCommand += fmt.Sprintf(" -o UserKnownHostsFile='%s' -o StrictHostKeyChecking=yes", knownHostsPath)
+	}
+	if a.env == nil {
+		a.env = os.Environ()
+	}
+	a.env = append(a.env, "GIT_SSH_COMMAND="+sshCommand)
+	return a, nil
+}
+
+// environWithoutGitConfig returns the environment of the current process
+// without the configuration of git it may set through GIT_CONFIG_COUNT,
+// which would conflict with the one of the clone.
+func environWithoutGitConfig() []string {
+	var env []string
+	for _, e := range os.Environ() {
+		if strings.HasPrefix(e, "GIT_CONFIG_COUNT=") || strings.HasPrefix(e, "GIT_CONFIG_KEY_") || strings.HasPrefix(e, "GIT_CONFIG_VALUE_") {
+			continue
+		}
+		env = append(env, e)
+	}
+	return env
+}
+
+// cleanup removes the SSH key and known hosts of the clone.
+func (a *auth) cleanup() {
+	if a.dir != "" {
+		os.RemoveAll(a.dir)
+	}
+}
+
 func checkoutGit(fragment, root string) (string, error) {
 	refAndDir := strings.SplitN(fragment, ":", 2)
 
@@ -96,5 +295,13 @@ func gitWithinDir(dir string, args ...string) ([]byte, error) {
 }
 
 func git(args ...string) ([]byte, error) {
-	return exec.Command("git", args...).CombinedOutput()
+	return gitWithEnv(nil, args...)
+}
+
+// gitWithEnv runs git with the environment env, or the environment of the
+// current process if env is nil.
+func gitWithEnv(env []string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Env = env
+	return cmd.CombinedOutput()
 }
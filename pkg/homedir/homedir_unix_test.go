@@ -0,0 +1,39 @@
+// +build !windows
+
+package homedir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetXDGDirs(t *testing.T) {
+	for _, key := range []string{"XDG_RUNTIME_DIR", "XDG_DATA_HOME", "XDG_CONFIG_HOME"} {
+		defer os.Setenv(key, os.Getenv(key))
+		os.Unsetenv(key)
+	}
+
+	if _, err := GetRuntimeDir(); err == nil {
+		t.Fatal("expected an error when XDG_RUNTIME_DIR is not set")
+	}
+	if dir, err := GetDataHome(); err != nil || dir != filepath.Join(Get(), ".local", "share") {
+		t.Fatalf("unexpected default data home %q: %v", dir, err)
+	}
+	if dir, err := GetConfigHome(); err != nil || dir != filepath.Join(Get(), ".config") {
+		t.Fatalf("unexpected default config home %q: %v", dir, err)
+	}
+
+	os.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	os.Setenv("XDG_DATA_HOME", "/data")
+	os.Setenv("XDG_CONFIG_HOME", "/config")
+	if dir, err := GetRuntimeDir(); err != nil || dir != "/run/user/1000" {
+		t.Fatalf("unexpected runtime dir %q: %v", dir, err)
+	}
+	if dir, err := GetDataHome(); err != nil || dir != "/data" {
+		t.Fatalf("unexpected data home %q: %v", dir, err)
+	}
+	if dir, err := GetConfigHome(); err != nil || dir != "/config" {
+		t.Fatalf("unexpected config home %q: %v", dir, err)
+	}
+}
@@ -0,0 +1,114 @@
+package listeners
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// newTCPListener creates a TCP listener on addr, which enables the TCP
+// keepalive of its connections with the period keepAlive if it is not 0,
+// and encapsulates it inside a TLS listener if tlsConfig is set.
+func newTCPListener(addr string, tlsConfig *tls.Config, keepAlive, writeTimeout time.Duration) (net.Listener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if keepAlive > 0 {
+		l = keepAliveListener{l.(*net.TCPListener), keepAlive}
+	}
+	l = wrapTCPListener(l, tlsConfig, writeTimeout)
+	return l, nil
+}
+
+// wrapTCPListener encapsulates a TCP listener inside a TLS listener if tlsConfig is
+// set. The write timeout is applied beneath the TLS layer, so that net/http
+// still gets the *tls.Conn of the connections, and with it the certificates
+// of the clients.
+func wrapTCPListener(l net.Listener, tlsConfig *tls.Config, writeTimeout time.Duration) net.Listener {
+	if writeTimeout > 0 {
+		l = newWriteTimeoutListener(l, writeTimeout)
+	}
+	if tlsConfig != nil {
+		tlsConfig.NextProtos = []string{"http/1.1"}
+		l = tls.NewListener(l, tlsConfig)
+	}
+	return l
+}
+
+// keepAliveListener enables the TCP keepalive of the connections it
+// accepts, so that the connections of the clients which went away are
+// eventually closed, even when they are hijacked.
+type keepAliveListener struct {
+	*net.TCPListener
+	period time.Duration
+}
+
+func (l keepAliveListener) Accept() (net.Conn, error) {
+	conn, err := l.AcceptTCP()
+	if err != nil {
+		return nil, err
+	}
+	conn.SetKeepAlive(true)
+	conn.SetKeepAlivePeriod(l.period)
+	return conn, nil
+}
+
+// WithWriteTimeout wraps a listener, so that the writes to its connections
+// fail if they block longer than the timeout, and the clients which stop
+// reading a response, such as the stream of docker events or logs, or the
+// hijacked connection of an attach or an exec, do not hang it forever. The
+// TCP listeners are returned as is, as Init applies the timeout to them
+// beneath their TLS layer.
+func WithWriteTimeout(l net.Listener, timeout time.Duration) net.Listener {
+	if timeout <= 0 || l.Addr().Network() == "tcp" {
+		return l
+	}
+	return newWriteTimeoutListener(l, timeout)
+}
+
+func newWriteTimeoutListener(l net.Listener, timeout time.Duration) net.Listener {
+	return writeTimeoutListener{l, timeout}
+}
+
+type writeTimeoutListener struct {
+	net.Listener
+	timeout time.Duration
+}
+
+func (l writeTimeoutListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	c := &writeTimeoutConn{conn, l.timeout}
+	// The hijacked connections close their write side only, if they can.
+	if _, ok := conn.(interface {
+		CloseWrite() error
+	}); ok {
+		return &writeTimeoutCloseWriteConn{c}, nil
+	}
+	return c, nil
+}
+
+// writeTimeoutConn is a connection whose writes fail if they block longer
+// than the timeout.
+type writeTimeoutConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *writeTimeoutConn) Write(b []byte) (int, error) {
+	c.Conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	return c.Conn.Write(b)
+}
+
+type writeTimeoutCloseWriteConn struct {
+	*writeTimeoutConn
+}
+
+func (c *writeTimeoutCloseWriteConn) CloseWrite() error {
+	return c.Conn.(interface {
+		CloseWrite() error
+	}).CloseWrite()
+}
@@ -4,15 +4,19 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/docker/go-connections/sockets"
 )
 
-// Init creates new listeners for the server.
-func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) (ls []net.Listener, err error) {
+// Init creates new listeners for the server. The TCP keepalive of the
+// connections of the tcp listeners has the period keepAlive, or is not
+// enabled if it is 0, and their writes fail if they block longer than
+// writeTimeout, unless it is 0.
+func Init(proto, addr, socketGroup string, tlsConfig *tls.Config, keepAlive, writeTimeout time.Duration) (ls []net.Listener, err error) {
 	switch proto {
 	case "tcp":
-		l, err := sockets.NewTCPSocket(addr, tlsConfig)
+		l, err := newTCPListener(addr, tlsConfig, keepAlive, writeTimeout)
 		if err != nil {
 			return nil, err
 		}
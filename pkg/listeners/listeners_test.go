@@ -0,0 +1,125 @@
+package listeners
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type acceptListener struct {
+	net.Listener
+	conn net.Conn
+}
+
+func (l acceptListener) Accept() (net.Conn, error) {
+	return l.conn, nil
+}
+
+func TestWriteTimeoutListener(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	l := newWriteTimeoutListener(acceptListener{conn: server}, 100*time.Millisecond)
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// The client does not read, so the write blocks until it times out.
+	errCh := make(chan error)
+	go func() {
+		_, err := conn.Write([]byte("output"))
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("expected the write to time out")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the write to time out within its timeout")
+	}
+}
+
+func selfSignedCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestTCPListenerWriteTimeoutKeepsTLS(t *testing.T) {
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{selfSignedCertificate(t)}}
+	l, err := newTCPListener("127.0.0.1:0", tlsConfig, 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})}
+	go srv.Serve(l)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + l.Addr().String() + "/_ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal("expected the request to hold the TLS state of its connection")
+	}
+}
+
+func TestWithWriteTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if _, ok := WithWriteTimeout(l, time.Minute).(writeTimeoutListener); ok {
+		t.Fatal("expected the tcp listener to be returned as is")
+	}
+
+	dir, err := ioutil.TempDir("", "listeners-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ul, err := net.Listen("unix", filepath.Join(dir, "docker.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ul.Close()
+	if _, ok := WithWriteTimeout(ul, time.Minute).(writeTimeoutListener); !ok {
+		t.Fatal("expected the unix socket listener to be wrapped")
+	}
+}
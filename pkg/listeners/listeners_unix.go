@@ -7,26 +7,30 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/coreos/go-systemd/activation"
 	"github.com/docker/go-connections/sockets"
 )
 
-// Init creates new listeners for the server.
+// Init creates new listeners for the server. The TCP keepalive of the
+// connections of the tcp listeners has the period keepAlive, or is not
+// enabled if it is 0, and their writes fail if they block longer than
+// writeTimeout, unless it is 0.
 // TODO: Clean up the fact that socketGroup and tlsConfig aren't always used.
-func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listener, error) {
+func Init(proto, addr, socketGroup string, tlsConfig *tls.Config, keepAlive, writeTimeout time.Duration) ([]net.Listener, error) {
 	ls := []net.Listener{}
 
 	switch proto {
 	case "fd":
-		fds, err := listenFD(addr, tlsConfig)
+		fds, err := listenFD(addr, tlsConfig, writeTimeout)
 		if err != nil {
 			return nil, err
 		}
 		ls = append(ls, fds...)
 	case "tcp":
-		l, err := sockets.NewTCPSocket(addr, tlsConfig)
+		l, err := newTCPListener(addr, tlsConfig, keepAlive, writeTimeout)
 		if err != nil {
 			return nil, err
 		}
@@ -46,20 +50,18 @@ func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listene
 
 // listenFD returns the specified socket activated files as a slice of
 // net.Listeners or all of the activated files if "*" is given.
-func listenFD(addr string, tlsConfig *tls.Config) ([]net.Listener, error) {
-	var (
-		err       error
-		listeners []net.Listener
-	)
+func listenFD(addr string, tlsConfig *tls.Config, writeTimeout time.Duration) ([]net.Listener, error) {
 	// socket activation
-	if tlsConfig != nil {
-		listeners, err = activation.TLSListeners(false, tlsConfig)
-	} else {
-		listeners, err = activation.Listeners(false)
-	}
+	listeners, err := activation.Listeners(false)
 	if err != nil {
 		return nil, err
 	}
+	for i, l := range listeners {
+		// Activate TLS only for TCP sockets
+		if l != nil && l.Addr().Network() == "tcp" {
+			listeners[i] = wrapTCPListener(l, tlsConfig, writeTimeout)
+		}
+	}
 
 	if len(listeners) == 0 {
 		return nil, fmt.Errorf("no sockets found via socket activation: make sure the service was started by systemd")
@@ -5,18 +5,21 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/Microsoft/go-winio"
-	"github.com/docker/go-connections/sockets"
 )
 
-// Init creates new listeners for the server.
-func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listener, error) {
+// Init creates new listeners for the server. The TCP keepalive of the
+// connections of the tcp listeners has the period keepAlive, or is not
+// enabled if it is 0, and their writes fail if they block longer than
+// writeTimeout, unless it is 0.
+func Init(proto, addr, socketGroup string, tlsConfig *tls.Config, keepAlive, writeTimeout time.Duration) ([]net.Listener, error) {
 	ls := []net.Listener{}
 
 	switch proto {
 	case "tcp":
-		l, err := sockets.NewTCPSocket(addr, tlsConfig)
+		l, err := newTCPListener(addr, tlsConfig, keepAlive, writeTimeout)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,123 @@
+package listeners
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// WithPeerCredentials wraps a unix socket listener, so that the remote
+// address of its connections holds the credentials of the peer process,
+// such as "pid=1234,uid=1000,gid=1000,groups=4:24:999", where groups lists
+// its supplementary groups, if it has any. Other listeners are returned as
+// is.
+func WithPeerCredentials(l net.Listener) net.Listener {
+	if _, ok := l.(*net.UnixListener); !ok {
+		return l
+	}
+	return peerCredListener{l}
+}
+
+type peerCredListener struct {
+	net.Listener
+}
+
+// Accept waits for and returns the next connection whose peer credentials
+// can be read. The other connections are closed, as the socket roles could
+// not be applied to them.
+func (l peerCredListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		uc, ok := conn.(*net.UnixConn)
+		if !ok {
+			conn.Close()
+			logrus.Warnf("Rejected a connection to %s: not a unix socket connection", l.Addr())
+			continue
+		}
+		cred, groups, err := peerCredentials(uc)
+		if err != nil {
+			uc.Close()
+			logrus.Warnf("Rejected a connection to %s: failed to get the credentials of the peer: %v", l.Addr(), err)
+			continue
+		}
+		return newPeerCredConn(uc, cred, groups), nil
+	}
+}
+
+func newPeerCredConn(uc *net.UnixConn, cred *syscall.Ucred, groups []uint32) net.Conn {
+	name := fmt.Sprintf("pid=%d,uid=%d,gid=%d", cred.Pid, cred.Uid, cred.Gid)
+	if len(groups) > 0 {
+		ids := make([]string, len(groups))
+		for i, g := range groups {
+			ids[i] = strconv.FormatUint(uint64(g), 10)
+		}
+		name += ",groups=" + strings.Join(ids, ":")
+	}
+	return &peerCredConn{
+		UnixConn: uc,
+		addr: &net.UnixAddr{
+			Name: name,
+			Net:  "unix",
+		},
+	}
+}
+
+// peerCredConn is a unix socket connection whose remote address holds the
+// credentials of the peer process.
+type peerCredConn struct {
+	*net.UnixConn
+	addr net.Addr
+}
+
+func (c *peerCredConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// PeerGroupsSupported returns whether the kernel reports the supplementary
+// groups of the peers of unix sockets, with SO_PEERGROUPS (Linux 4.13). The
+// remote addresses of the connections have no groups otherwise.
+func PeerGroupsSupported() bool {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		return false
+	}
+	defer syscall.Close(fds[0])
+	defer syscall.Close(fds[1])
+	_, err = peerGroups(fds[0])
+	return err == nil
+}
+
+// peerCredentials returns the credentials and the supplementary groups of
+// the peer of the connection. Both are recorded by the kernel when the peer
+// connects, so they cannot be those of another process reusing its pid.
+func peerCredentials(conn *net.UnixConn) (*syscall.Ucred, []uint32, error) {
+	f, err := conn.File()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	fd := int(f.Fd())
+	// The duplicated descriptor shares the file status flags of the
+	// connection, which File switches to blocking mode.
+	defer syscall.SetNonblock(fd, true)
+	cred, err := syscall.GetsockoptUcred(fd, syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	if err != nil {
+		return nil, nil, err
+	}
+	groups, err := peerGroups(fd)
+	if err == syscall.ENOPROTOOPT {
+		// The kernel does not report the groups, see PeerGroupsSupported.
+		return cred, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return cred, groups, nil
+}
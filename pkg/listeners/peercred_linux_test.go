@@ -0,0 +1,64 @@
+package listeners
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWithPeerCredentials(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "listeners-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	l, err := net.Listen("unix", filepath.Join(tmp, "sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l = WithPeerCredentials(l)
+	defer l.Close()
+
+	client, err := net.Dial("unix", filepath.Join(tmp, "sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	expected := fmt.Sprintf("pid=%d,uid=%d,gid=%d", os.Getpid(), os.Getuid(), os.Getgid())
+	groups, err := os.Getgroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) > 0 && PeerGroupsSupported() {
+		var ids []string
+		for _, g := range groups {
+			ids = append(ids, strconv.Itoa(g))
+		}
+		expected += ",groups=" + strings.Join(ids, ":")
+	}
+	if addr := conn.RemoteAddr().String(); addr != expected {
+		t.Fatalf("expected the remote address to be %s, got %s", expected, addr)
+	}
+
+	// the connection must still be usable once its credentials are read
+	if _, err := client.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 4)
+	if _, err := conn.Read(buf); err != nil || string(buf) != "ping" {
+		t.Fatalf("expected to read ping, got %q, %v", buf, err)
+	}
+}
@@ -0,0 +1,17 @@
+// +build !linux
+
+package listeners
+
+import "net"
+
+// WithPeerCredentials returns the listener as is, the credentials of the
+// peers of unix sockets are not supported on this platform.
+func WithPeerCredentials(l net.Listener) net.Listener {
+	return l
+}
+
+// PeerGroupsSupported returns false, the credentials of the peers of unix
+// sockets are not supported on this platform.
+func PeerGroupsSupported() bool {
+	return false
+}
@@ -0,0 +1,30 @@
+// +build linux,!386
+
+package listeners
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// soPeerGroups is SO_PEERGROUPS, which the syscall package does not define.
+const soPeerGroups = 59
+
+// peerGroups returns the supplementary groups of the peer of the unix socket
+// fd, which the kernel recorded when the peer connected.
+func peerGroups(fd int) ([]uint32, error) {
+	groups := make([]uint32, 32)
+	for {
+		n := uint32(len(groups) * 4)
+		_, _, errno := syscall.Syscall6(syscall.SYS_GETSOCKOPT, uintptr(fd), syscall.SOL_SOCKET, soPeerGroups, uintptr(unsafe.Pointer(&groups[0])), uintptr(unsafe.Pointer(&n)), 0)
+		if errno == syscall.ERANGE && int(n/4) > len(groups) {
+			// n holds the size the groups need.
+			groups = make([]uint32, n/4)
+			continue
+		}
+		if errno != 0 {
+			return nil, errno
+		}
+		return groups[:n/4], nil
+	}
+}
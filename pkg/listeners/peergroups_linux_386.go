@@ -0,0 +1,9 @@
+package listeners
+
+import "syscall"
+
+// peerGroups is not implemented on 386, where getsockopt goes through
+// socketcall.
+func peerGroups(fd int) ([]uint32, error) {
+	return nil, syscall.ENOPROTOOPT
+}
@@ -0,0 +1,109 @@
+// Package rootless provides helpers for running the daemon in rootless mode,
+// as an unprivileged user, in the namespaces created by RootlessKit.
+//
+// RootlessKit runs the daemon in a user namespace, where the unprivileged
+// user is mapped to root, and in a network namespace connected to the host
+// by a usermode network stack such as slirp4netns. The ports published in
+// this network namespace are forwarded from the host by RootlessKit, through
+// its API socket.
+package rootless
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/go-connections/sockets"
+)
+
+// stateDirEnv is the environment variable set by RootlessKit to its state
+// directory, which holds its API socket.
+const stateDirEnv = "ROOTLESSKIT_STATE_DIR"
+
+// RunningWithRootlessKit returns whether the process runs in the namespaces
+// created by RootlessKit.
+func RunningWithRootlessKit() bool {
+	return os.Getenv(stateDirEnv) != ""
+}
+
+// PortSpec is a port forwarded from the host to the network namespace of
+// the daemon.
+type PortSpec struct {
+	Proto      string `json:"proto"`
+	ParentIP   string `json:"parentIP,omitempty"`
+	ParentPort int    `json:"parentPort"`
+	ChildPort  int    `json:"childPort"`
+}
+
+// PortClient forwards ports from the host with the API of RootlessKit.
+type PortClient struct {
+	client *http.Client
+}
+
+// NewPortClient returns a client of the API socket of RootlessKit.
+func NewPortClient() (*PortClient, error) {
+	stateDir := os.Getenv(stateDirEnv)
+	if stateDir == "" {
+		return nil, fmt.Errorf("%s is not set, the daemon does not run in RootlessKit", stateDirEnv)
+	}
+	return newPortClient(filepath.Join(stateDir, "api.sock"))
+}
+
+func newPortClient(socket string) (*PortClient, error) {
+	tr := &http.Transport{}
+	if err := sockets.ConfigureTransport(tr, "unix", socket); err != nil {
+		return nil, err
+	}
+	return &PortClient{client: &http.Client{Transport: tr}}, nil
+}
+
+// AddPort forwards the port from the host, and returns the ID of the
+// forwarding.
+func (c *PortClient) AddPort(spec PortSpec) (int, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.client.Post("http://rootlesskit/v1/ports", "application/json", bytes.NewReader(b))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return 0, err
+	}
+
+	var status struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return 0, err
+	}
+	return status.ID, nil
+}
+
+// RemovePort stops the forwarding of a port.
+func (c *PortClient) RemovePort(id int) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("http://rootlesskit/v1/ports/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	b, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("rootlesskit: %s: %s", resp.Status, bytes.TrimSpace(b))
+}
@@ -0,0 +1,91 @@
+package rootless
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPortClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootless-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "api.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	ports := make(map[string]PortSpec)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/ports", func(w http.ResponseWriter, r *http.Request) {
+		var spec PortSpec
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ports["1"] = spec
+		w.Write([]byte(`{"id":1}`))
+	})
+	mux.HandleFunc("/v1/ports/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/ports/")
+		if _, ok := ports[id]; r.Method != "DELETE" || !ok {
+			http.Error(w, "no such port", http.StatusNotFound)
+			return
+		}
+		delete(ports, id)
+	})
+	go http.Serve(l, mux)
+
+	c, err := newPortClient(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := PortSpec{Proto: "tcp", ParentIP: "127.0.0.1", ParentPort: 8080, ChildPort: 8080}
+	id, err := c.AddPort(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 || ports["1"] != spec {
+		t.Fatalf("unexpected forwarded port %d: %+v", id, ports)
+	}
+
+	if err := c.RemovePort(id); err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 0 {
+		t.Fatalf("expected the port to be removed, got %+v", ports)
+	}
+	if err := c.RemovePort(id); err == nil || !strings.Contains(err.Error(), "no such port") {
+		t.Fatalf("expected an error for an unknown port, got %v", err)
+	}
+}
+
+func TestNewPortClient(t *testing.T) {
+	defer os.Setenv(stateDirEnv, os.Getenv(stateDirEnv))
+
+	os.Unsetenv(stateDirEnv)
+	if RunningWithRootlessKit() {
+		t.Fatal("expected not to run with RootlessKit")
+	}
+	if _, err := NewPortClient(); err == nil {
+		t.Fatal("expected an error outside of RootlessKit")
+	}
+
+	os.Setenv(stateDirEnv, "/run/user/1000/rootlesskit")
+	if !RunningWithRootlessKit() {
+		t.Fatal("expected to run with RootlessKit")
+	}
+	if _, err := NewPortClient(); err != nil {
+		t.Fatal(err)
+	}
+}
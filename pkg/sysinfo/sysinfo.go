@@ -1,6 +1,10 @@
 package sysinfo
 
-import "github.com/docker/docker/pkg/parsers"
+import (
+	"sort"
+
+	"github.com/docker/docker/pkg/parsers"
+)
 
 // SysInfo stores information about which features a kernel supports.
 // TODO Windows: Factor out platform specific capabilities.
@@ -27,6 +31,9 @@ type SysInfo struct {
 
 	// Whether the cgroup has the mountpoint of "devices" or not
 	CgroupDevicesEnabled bool
+
+	// Whether the kernel supports cgroup namespaces or not
+	CgroupNamespaces bool
 }
 
 type cgroupMemInfo struct {
@@ -110,21 +117,45 @@ func (c cgroupCpusetInfo) IsCpusetMemsAvailable(provided string) (bool, error) {
 	return isCpusetListAvailable(provided, c.Mems)
 }
 
+// UnavailableCpusetCpus returns the provided cpus that are not in cgroup's
+// cpuset.cpus set, in increasing order.
+// If error is not nil a parsing error occurred.
+func (c cgroupCpusetInfo) UnavailableCpusetCpus(provided string) ([]int, error) {
+	return unavailableCpusetList(provided, c.Cpus)
+}
+
+// UnavailableCpusetMems returns the provided memory nodes that are not in
+// cgroup's cpuset.mems set, in increasing order.
+// If error is not nil a parsing error occurred.
+func (c cgroupCpusetInfo) UnavailableCpusetMems(provided string) ([]int, error) {
+	return unavailableCpusetList(provided, c.Mems)
+}
+
 func isCpusetListAvailable(provided, available string) (bool, error) {
-	parsedProvided, err := parsers.ParseUintList(provided)
+	unavailable, err := unavailableCpusetList(provided, available)
 	if err != nil {
 		return false, err
 	}
+	return len(unavailable) == 0, nil
+}
+
+func unavailableCpusetList(provided, available string) ([]int, error) {
+	parsedProvided, err := parsers.ParseUintList(provided)
+	if err != nil {
+		return nil, err
+	}
 	parsedAvailable, err := parsers.ParseUintList(available)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	var unavailable []int
 	for k := range parsedProvided {
 		if !parsedAvailable[k] {
-			return false, nil
+			unavailable = append(unavailable, k)
 		}
 	}
-	return true, nil
+	sort.Ints(unavailable)
+	return unavailable, nil
 }
 
 // Returns bit count of 1, used by NumCPU
@@ -54,6 +54,12 @@ func New(quiet bool) *SysInfo {
 	sysInfo.BridgeNFCallIPTablesDisabled = !readProcBool("/proc/sys/net/bridge/bridge-nf-call-iptables")
 	sysInfo.BridgeNFCallIP6TablesDisabled = !readProcBool("/proc/sys/net/bridge/bridge-nf-call-ip6tables")
 
+	// Check if cgroup namespaces are supported, via CONFIG_CGROUPS and
+	// kernel 4.6 or newer.
+	if _, err := os.Stat("/proc/self/ns/cgroup"); err == nil {
+		sysInfo.CgroupNamespaces = true
+	}
+
 	// Check if AppArmor is supported.
 	if _, err := os.Stat("/sys/kernel/security/apparmor"); !os.IsNotExist(err) {
 		sysInfo.AppArmor = true
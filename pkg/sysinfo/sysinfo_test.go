@@ -1,6 +1,9 @@
 package sysinfo
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestIsCpusetListAvailable(t *testing.T) {
 	cases := []struct {
@@ -24,3 +27,28 @@ func TestIsCpusetListAvailable(t *testing.T) {
 		}
 	}
 }
+
+func TestUnavailableCpusetList(t *testing.T) {
+	cases := []struct {
+		provided    string
+		available   string
+		unavailable []int
+	}{
+		{"1", "0-4", nil},
+		{"", "0-7", nil},
+		{"6-9,1", "0-7", []int{8, 9}},
+		{"1,41-42", "43,45", []int{1, 41, 42}},
+	}
+	for _, c := range cases {
+		unavailable, err := unavailableCpusetList(c.provided, c.available)
+		if err != nil {
+			t.Fatalf("%s, %s: unexpected error: %v", c.provided, c.available, err)
+		}
+		if !reflect.DeepEqual(unavailable, c.unavailable) {
+			t.Fatalf("%s, %s: expected %v, got %v", c.provided, c.available, c.unavailable, unavailable)
+		}
+	}
+	if _, err := unavailableCpusetList("1--42", "0-7"); err == nil {
+		t.Fatal("expected an error for an invalid list")
+	}
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"bytes"
 	"syscall"
 	"unsafe"
 )
@@ -61,3 +62,39 @@ func Lsetxattr(path string, attr string, data []byte, flags int) error {
 	}
 	return nil
 }
+
+// Llistxattr returns the names of the extended attributes associated with
+// the given path in the file system.
+func Llistxattr(path string) ([]string, error) {
+	pathBytes, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		sz, _, errno := syscall.Syscall(syscall.SYS_LLISTXATTR, uintptr(unsafe.Pointer(pathBytes)), 0, 0)
+		if errno != 0 {
+			return nil, errno
+		}
+		if sz == 0 {
+			return nil, nil
+		}
+		dest := make([]byte, sz)
+		sz, _, errno = syscall.Syscall(syscall.SYS_LLISTXATTR, uintptr(unsafe.Pointer(pathBytes)), uintptr(unsafe.Pointer(&dest[0])), uintptr(len(dest)))
+		if errno == syscall.ERANGE {
+			// An attribute was added since the size was read.
+			continue
+		}
+		if errno != 0 {
+			return nil, errno
+		}
+
+		var attrs []string
+		for _, name := range bytes.Split(dest[:sz], []byte{0}) {
+			if len(name) > 0 {
+				attrs = append(attrs, string(name))
+			}
+		}
+		return attrs, nil
+	}
+}
@@ -29,6 +29,7 @@ type Association struct {
 type Store interface {
 	References(id digest.Digest) []Named
 	ReferencesByName(ref Named) []Association
+	Associations() []Association
 	AddTag(ref Named, id digest.Digest, force bool) error
 	AddDigest(ref Canonical, id digest.Digest, force bool) error
 	Delete(ref Named) (bool, error)
@@ -249,6 +250,28 @@ func (store *store) ReferencesByName(ref Named) []Association {
 	return associations
 }
 
+// Associations returns all the references of the store, sorted.
+func (store *store) Associations() []Association {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	var associations []Association
+	for _, repository := range store.Repositories {
+		for refStr, refID := range repository {
+			ref, err := ParseNamed(refStr)
+			if err != nil {
+				// Should never happen
+				continue
+			}
+			associations = append(associations, Association{Ref: ref, ID: refID})
+		}
+	}
+
+	sort.Sort(lexicalAssociations(associations))
+
+	return associations
+}
+
 func (store *store) save() error {
 	// Store the json
 	jsonData, err := json.Marshal(store)
@@ -292,6 +292,18 @@ func TestAddDeleteGet(t *testing.T) {
 		t.Fatalf("unexpected reference: %v", associations[2].Ref.String())
 	}
 
+	// Check Associations
+	all := store.Associations()
+	if len(all) != 6 {
+		t.Fatal("unexpected number of associations")
+	}
+	if all[0].Ref.String() != ref3.String() || all[0].ID != testImageID1 {
+		t.Fatalf("unexpected reference: %v", all[0].Ref.String())
+	}
+	if all[5].Ref.String() != "username/repo:latest" {
+		t.Fatalf("unexpected reference: %v", all[5].Ref.String())
+	}
+
 	// Delete should return ErrDoesNotExist for a nonexistent repo
 	if _, err = store.Delete(nonExistRepo); err != ErrDoesNotExist {
 		t.Fatal("Expected ErrDoesNotExist from Delete")
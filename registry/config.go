@@ -21,6 +21,10 @@ type ServiceOptions struct {
 	// V2Only controls access to legacy registries.  If it is set to true via the
 	// command line flag the daemon will not attempt to contact v1 legacy registries
 	V2Only bool `json:"disable-legacy-registry,omitempty"`
+
+	// Limits are the limits of the layer downloads from the registries,
+	// by registry name.
+	Limits map[string]Limits `json:"registry-limits,omitempty"`
 }
 
 // serviceConfig holds daemon configuration for the registry service.
@@ -76,6 +80,7 @@ func (options *ServiceOptions) InstallCliFlags(flags *pflag.FlagSet) {
 
 	flags.Var(mirrors, "registry-mirror", "Preferred Docker registry mirror")
 	flags.Var(insecureRegistries, "insecure-registry", "Enable insecure registry communication")
+	flags.Var(NewNamedLimitsOpt("registry-limits", &options.Limits), "registry-limit", "Limit the layer downloads from a registry")
 
 	options.installCliPlatformFlags(flags)
 }
@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+)
+
+// Limits are the limits of the layer downloads from a registry.
+type Limits struct {
+	// MaxConcurrentDownloads is the maximum number of concurrent layer
+	// downloads from the registry, across all the pulls.
+	MaxConcurrentDownloads int `json:"max-concurrent-downloads,omitempty"`
+	// MaxDownloadRate is the maximum rate of the layer downloads from the
+	// registry, in bytes per second, such as "10mb".
+	MaxDownloadRate string `json:"max-download-rate,omitempty"`
+}
+
+// DownloadRate returns the maximum download rate in bytes per second, or 0
+// if it is not limited.
+func (l Limits) DownloadRate() (int64, error) {
+	if l.MaxDownloadRate == "" {
+		return 0, nil
+	}
+	r, err := units.RAMInBytes(l.MaxDownloadRate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max download rate %q: %v", l.MaxDownloadRate, err)
+	}
+	if r <= 0 {
+		return 0, fmt.Errorf("invalid max download rate %q: it must be positive", l.MaxDownloadRate)
+	}
+	return r, nil
+}
+
+// ValidateLimits validates the limits of the registries, and returns them
+// keyed by the normalized names of the registries.
+func ValidateLimits(limits map[string]Limits) (map[string]Limits, error) {
+	validated := make(map[string]Limits, len(limits))
+	for name, l := range limits {
+		normalized, err := ValidateIndexName(name)
+		if err != nil {
+			return nil, err
+		}
+		if l.MaxConcurrentDownloads < 0 {
+			return nil, fmt.Errorf("invalid max concurrent downloads for registry %s: %d", name, l.MaxConcurrentDownloads)
+		}
+		if _, err := l.DownloadRate(); err != nil {
+			return nil, fmt.Errorf("%v for registry %s", err, name)
+		}
+		validated[normalized] = l
+	}
+	return validated, nil
+}
+
+// LimitsOpt is the option of the limits of the registries, in the form
+// registry=max-concurrent-downloads=2,max-download-rate=10mb.
+type LimitsOpt struct {
+	name   string
+	values *map[string]Limits
+}
+
+// NewNamedLimitsOpt creates a new LimitsOpt.
+func NewNamedLimitsOpt(name string, ref *map[string]Limits) *LimitsOpt {
+	if *ref == nil {
+		*ref = make(map[string]Limits)
+	}
+	return &LimitsOpt{name: name, values: ref}
+}
+
+// Name returns the name of the option in the configuration.
+func (o *LimitsOpt) Name() string {
+	return o.name
+}
+
+// Set parses and adds the limits of a registry.
+func (o *LimitsOpt) Set(val string) error {
+	parts := strings.SplitN(val, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid registry limit: %s", val)
+	}
+	name, err := ValidateIndexName(parts[0])
+	if err != nil {
+		return err
+	}
+
+	var l Limits
+	for _, field := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid registry limit: %s", val)
+		}
+		switch kv[0] {
+		case "max-concurrent-downloads":
+			n, err := strconv.Atoi(kv[1])
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid max concurrent downloads for registry %s: %s", name, kv[1])
+			}
+			l.MaxConcurrentDownloads = n
+		case "max-download-rate":
+			l.MaxDownloadRate = kv[1]
+			if _, err := l.DownloadRate(); err != nil {
+				return fmt.Errorf("%v for registry %s", err, name)
+			}
+		default:
+			return fmt.Errorf("unknown registry limit %s", kv[0])
+		}
+	}
+	(*o.values)[name] = l
+	return nil
+}
+
+// String returns the names of the registries with limits.
+func (o *LimitsOpt) String() string {
+	var out []string
+	for name := range *o.values {
+		out = append(out, name)
+	}
+	return fmt.Sprintf("%v", out)
+}
+
+// Type returns the type of the option.
+func (o *LimitsOpt) Type() string {
+	return "registry-limit"
+}
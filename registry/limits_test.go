@@ -0,0 +1,47 @@
+package registry
+
+import "testing"
+
+func TestLimitsOpt(t *testing.T) {
+	var limits map[string]Limits
+	opt := NewNamedLimitsOpt("registry-limits", &limits)
+
+	if err := opt.Set("index.docker.io=max-concurrent-downloads=2,max-download-rate=10mb"); err != nil {
+		t.Fatal(err)
+	}
+	l, ok := limits["docker.io"]
+	if !ok {
+		t.Fatalf("expected the limits of docker.io, got %v", limits)
+	}
+	if l.MaxConcurrentDownloads != 2 {
+		t.Fatalf("expected 2 concurrent downloads, got %d", l.MaxConcurrentDownloads)
+	}
+	if rate, err := l.DownloadRate(); err != nil || rate != 10*1024*1024 {
+		t.Fatalf("expected a download rate of 10mb, got %d, %v", rate, err)
+	}
+
+	for _, val := range []string{
+		"registry.example.com",
+		"registry.example.com=",
+		"registry.example.com=max-concurrent-downloads=-1",
+		"registry.example.com=max-download-rate=fast",
+		"registry.example.com=max-uploads=1",
+	} {
+		if err := opt.Set(val); err == nil {
+			t.Fatalf("expected an error for %q", val)
+		}
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	limits, err := ValidateLimits(map[string]Limits{"index.docker.io": {MaxDownloadRate: "1mb"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := limits["docker.io"]; !ok {
+		t.Fatalf("expected the limits of docker.io, got %v", limits)
+	}
+	if _, err := ValidateLimits(map[string]Limits{"registry.example.com": {MaxDownloadRate: "0"}}); err == nil {
+		t.Fatal("expected an error for a download rate of 0")
+	}
+}
@@ -445,7 +445,15 @@ func handlerAuth(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, "OK", 200)
 }
 
+// lastSearchQuery is the query of the last search of the mock registry.
+var lastSearchQuery url.Values
+
 func handlerSearch(w http.ResponseWriter, r *http.Request) {
+	lastSearchQuery = r.URL.Query()
+	if lastSearchQuery.Get("q") == "paginated" {
+		handlerPaginatedSearch(w, r)
+		return
+	}
 	result := &registrytypes.SearchResults{
 		Query:      "fakequery",
 		NumResults: 1,
@@ -454,6 +462,26 @@ func handlerSearch(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, result, 200)
 }
 
+// handlerPaginatedSearch returns a page of 250 results.
+func handlerPaginatedSearch(w http.ResponseWriter, r *http.Request) {
+	const total = 250
+	n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	var results []registrytypes.SearchResult
+	for i := (page - 1) * n; i < page*n && i < total; i++ {
+		results = append(results, registrytypes.SearchResult{Name: fmt.Sprintf("image-%d", i)})
+	}
+	writeResponse(w, map[string]interface{}{
+		"query":       "paginated",
+		"num_results": total,
+		"num_pages":   (total + n - 1) / n,
+		"results":     results,
+	}, 200)
+}
+
 func TestPing(t *testing.T) {
 	res, err := http.Get(makeURL("/v1/_ping"))
 	if err != nil {
@@ -730,7 +730,7 @@ func TestPushImageJSONIndex(t *testing.T) {
 
 func TestSearchRepositories(t *testing.T) {
 	r := spawnTestRegistrySession(t)
-	results, err := r.SearchRepositories("fakequery", 25)
+	results, err := r.SearchRepositories("fakequery", 25, SearchFilters{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -742,6 +742,34 @@ func TestSearchRepositories(t *testing.T) {
 	assertEqual(t, results.Results[0].StarCount, 42, "Expected 'fakeimage' to have 42 stars")
 }
 
+func TestSearchRepositoriesPagination(t *testing.T) {
+	r := spawnTestRegistrySession(t)
+	isOfficial := true
+	results, err := r.SearchRepositories("paginated", 150, SearchFilters{IsOfficial: &isOfficial, Stars: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, results.NumResults, 150, "Expected 150 search results")
+	assertEqual(t, results.Results[149].Name, "image-149", "Expected the results of the second page")
+	assertEqual(t, lastSearchQuery.Get("page"), "2", "Expected the second page to be fetched")
+	assertEqual(t, lastSearchQuery.Get("is_official"), "true", "Expected the is-official filter to be passed")
+	assertEqual(t, lastSearchQuery.Get("stars"), "3", "Expected the stars filter to be passed")
+	if lastSearchQuery.Get("is_automated") != "" {
+		t.Fatal("Expected no is-automated filter")
+	}
+
+	// the index does not paginate the results of other queries
+	results, err = r.SearchRepositories("fakequery", 150, SearchFilters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, results.NumResults, 1, "Expected 1 search results")
+
+	if _, err := r.SearchRepositories("paginated", MaxSearchLimit+1, SearchFilters{}); err == nil {
+		t.Fatal("Expected an error for a limit above the maximum")
+	}
+}
+
 func TestTrustedLocation(t *testing.T) {
 	for _, url := range []string{"http://example.com", "https://example.com:7777", "http://docker.io", "http://test.docker.com", "https://fakedocker.com"} {
 		req, _ := http.NewRequest("GET", url, nil)
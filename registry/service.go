@@ -19,6 +19,12 @@ import (
 const (
 	// DefaultSearchLimit is the default value for maximum number of returned search results.
 	DefaultSearchLimit = 25
+	// MaxSearchLimit is the maximum number of returned search results.
+	MaxSearchLimit = 1000
+	// DefaultCatalogLimit is the default value for maximum number of listed repositories of a registry.
+	DefaultCatalogLimit = 100
+	// MaxCatalogLimit is the maximum number of listed repositories of a registry.
+	MaxCatalogLimit = 1000
 )
 
 // Service is the interface defining what a registry service should implement.
@@ -28,7 +34,7 @@ type Service interface {
 	LookupPushEndpoints(hostname string) (endpoints []APIEndpoint, err error)
 	ResolveRepository(name reference.Named) (*RepositoryInfo, error)
 	ResolveIndex(name string) (*registrytypes.IndexInfo, error)
-	Search(ctx context.Context, term string, limit int, authConfig *types.AuthConfig, userAgent string, headers map[string][]string) (*registrytypes.SearchResults, error)
+	Search(ctx context.Context, term string, limit int, searchFilters SearchFilters, authConfig *types.AuthConfig, userAgent string, headers map[string][]string) (*registrytypes.SearchResults, error)
 	ServiceConfig() *registrytypes.ServiceConfig
 	TLSConfig(hostname string) (*tls.Config, error)
 }
@@ -114,7 +120,7 @@ func splitReposSearchTerm(reposName string) (string, string) {
 
 // Search queries the public registry for images matching the specified
 // search terms, and returns the results.
-func (s *DefaultService) Search(ctx context.Context, term string, limit int, authConfig *types.AuthConfig, userAgent string, headers map[string][]string) (*registrytypes.SearchResults, error) {
+func (s *DefaultService) Search(ctx context.Context, term string, limit int, searchFilters SearchFilters, authConfig *types.AuthConfig, userAgent string, headers map[string][]string) (*registrytypes.SearchResults, error) {
 	// TODO Use ctx when searching for repositories
 	if err := validateNoScheme(term); err != nil {
 		return nil, err
@@ -178,9 +184,9 @@ func (s *DefaultService) Search(ctx context.Context, term string, limit int, aut
 			localName = strings.SplitN(localName, "/", 2)[1]
 		}
 
-		return r.SearchRepositories(localName, limit)
+		return r.SearchRepositories(localName, limit, searchFilters)
 	}
-	return r.SearchRepositories(remoteName, limit)
+	return r.SearchRepositories(remoteName, limit, searchFilters)
 }
 
 // ResolveRepository splits a repository name into its components
@@ -730,13 +730,76 @@ func shouldRedirect(response *http.Response) bool {
 	return response.StatusCode >= 300 && response.StatusCode < 400
 }
 
-// SearchRepositories performs a search against the remote repository
-func (r *Session) SearchRepositories(term string, limit int) (*registrytypes.SearchResults, error) {
-	if limit < 1 || limit > 100 {
-		return nil, fmt.Errorf("Limit %d is outside the range of [1, 100]", limit)
+// searchPageSize is the maximum number of results of a page of a search.
+const searchPageSize = 100
+
+// searchPage is a page of the results of a search.
+type searchPage struct {
+	registrytypes.SearchResults
+	// NumPages is the number of pages of the results, if the index
+	// reports it.
+	NumPages int `json:"num_pages"`
+}
+
+// SearchRepositories performs a search against the remote repository. The
+// filters are passed to the index, which may ignore them. The results are
+// fetched in several pages if the limit is larger than the size of a page.
+func (r *Session) SearchRepositories(term string, limit int, searchFilters SearchFilters) (*registrytypes.SearchResults, error) {
+	if limit < 1 || limit > MaxSearchLimit {
+		return nil, fmt.Errorf("Limit %d is outside the range of [1, %d]", limit, MaxSearchLimit)
 	}
 	logrus.Debugf("Index server: %s", r.indexEndpoint)
-	u := r.indexEndpoint.String() + "search?q=" + url.QueryEscape(term) + "&n=" + url.QueryEscape(fmt.Sprintf("%d", limit))
+
+	pageSize := limit
+	if pageSize > searchPageSize {
+		pageSize = searchPageSize
+	}
+	result := &registrytypes.SearchResults{Query: term}
+	seen := make(map[string]struct{})
+	for page := 1; len(result.Results) < limit; page++ {
+		p, err := r.searchPage(term, pageSize, page, searchFilters)
+		if err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			result.Query = p.Query
+		}
+		// An index which does not paginate returns the first page again,
+		// which adds no result.
+		added := 0
+		for _, res := range p.Results {
+			if _, ok := seen[res.Name]; ok || len(result.Results) == limit {
+				continue
+			}
+			seen[res.Name] = struct{}{}
+			result.Results = append(result.Results, res)
+			added++
+		}
+		if added == 0 || len(p.Results) < pageSize || (p.NumPages > 0 && page >= p.NumPages) {
+			break
+		}
+	}
+	result.NumResults = len(result.Results)
+	return result, nil
+}
+
+func (r *Session) searchPage(term string, pageSize, page int, searchFilters SearchFilters) (*searchPage, error) {
+	query := url.Values{}
+	query.Set("q", term)
+	query.Set("n", strconv.Itoa(pageSize))
+	if page > 1 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if searchFilters.IsAutomated != nil {
+		query.Set("is_automated", strconv.FormatBool(*searchFilters.IsAutomated))
+	}
+	if searchFilters.IsOfficial != nil {
+		query.Set("is_official", strconv.FormatBool(*searchFilters.IsOfficial))
+	}
+	if searchFilters.Stars > 0 {
+		query.Set("stars", strconv.Itoa(searchFilters.Stars))
+	}
+	u := r.indexEndpoint.String() + "search?" + query.Encode()
 
 	req, err := http.NewRequest("GET", u, nil)
 	if err != nil {
@@ -752,7 +815,7 @@ func (r *Session) SearchRepositories(term string, limit int) (*registrytypes.Sea
 	if res.StatusCode != 200 {
 		return nil, httputils.NewHTTPRequestError(fmt.Sprintf("Unexpected status code %d", res.StatusCode), res)
 	}
-	result := new(registrytypes.SearchResults)
+	result := new(searchPage)
 	return result, json.NewDecoder(res.Body).Decode(result)
 }
 
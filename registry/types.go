@@ -5,6 +5,20 @@ import (
 	"github.com/docker/docker/reference"
 )
 
+// SearchFilters are the filters of a search which are passed to the index,
+// so that it filters the results before they are paginated. An index which
+// does not support them returns unfiltered results.
+type SearchFilters struct {
+	// IsAutomated only matches automated builds if it is true, and the
+	// other images if it is false.
+	IsAutomated *bool
+	// IsOfficial only matches official images if it is true, and the
+	// other images if it is false.
+	IsOfficial *bool
+	// Stars is the minimum number of stars of the results.
+	Stars int
+}
+
 // RepositoryData tracks the image list, list of endpoints, and list of tokens
 // for a repository
 type RepositoryData struct {
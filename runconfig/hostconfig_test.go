@@ -100,6 +100,39 @@ func TestIpcModeTest(t *testing.T) {
 	}
 }
 
+func TestIpcModeSharing(t *testing.T) {
+	ipcModes := map[container.IpcMode][]bool{
+		// private, private mode, shareable, none, empty, valid
+		"":               {true, false, false, false, true, true},
+		"private":        {true, true, false, false, false, true},
+		"shareable":      {true, false, true, false, false, true},
+		"none":           {true, false, false, true, false, true},
+		"host":           {false, false, false, false, false, true},
+		"container:name": {false, false, false, false, false, true},
+		"privat":         {true, false, false, false, false, false},
+	}
+	for ipcMode, state := range ipcModes {
+		if ipcMode.IsPrivate() != state[0] {
+			t.Fatalf("IpcMode.IsPrivate for %v should have been %v but was %v", ipcMode, state[0], ipcMode.IsPrivate())
+		}
+		if ipcMode.IsPrivateMode() != state[1] {
+			t.Fatalf("IpcMode.IsPrivateMode for %v should have been %v but was %v", ipcMode, state[1], ipcMode.IsPrivateMode())
+		}
+		if ipcMode.IsShareable() != state[2] {
+			t.Fatalf("IpcMode.IsShareable for %v should have been %v but was %v", ipcMode, state[2], ipcMode.IsShareable())
+		}
+		if ipcMode.IsNone() != state[3] {
+			t.Fatalf("IpcMode.IsNone for %v should have been %v but was %v", ipcMode, state[3], ipcMode.IsNone())
+		}
+		if ipcMode.IsEmpty() != state[4] {
+			t.Fatalf("IpcMode.IsEmpty for %v should have been %v but was %v", ipcMode, state[4], ipcMode.IsEmpty())
+		}
+		if ipcMode.Valid() != state[5] {
+			t.Fatalf("IpcMode.Valid for %v should have been %v but was %v", ipcMode, state[5], ipcMode.Valid())
+		}
+	}
+}
+
 func TestUTSModeTest(t *testing.T) {
 	utsModes := map[container.UTSMode][]bool{
 		// private, host, valid
@@ -163,6 +196,31 @@ func TestPidModeTest(t *testing.T) {
 	}
 }
 
+func TestCgroupnsModeTest(t *testing.T) {
+	cgroupnsModes := map[container.CgroupnsMode][]bool{
+		// private, host, empty, valid
+		"":                {false, false, true, true},
+		"something:weird": {false, false, false, false},
+		"host":            {false, true, false, true},
+		"host:name":       {false, false, false, false},
+		"private":         {true, false, false, true},
+	}
+	for cgroupnsMode, state := range cgroupnsModes {
+		if cgroupnsMode.IsPrivate() != state[0] {
+			t.Fatalf("CgroupnsMode.IsPrivate for %v should have been %v but was %v", cgroupnsMode, state[0], cgroupnsMode.IsPrivate())
+		}
+		if cgroupnsMode.IsHost() != state[1] {
+			t.Fatalf("CgroupnsMode.IsHost for %v should have been %v but was %v", cgroupnsMode, state[1], cgroupnsMode.IsHost())
+		}
+		if cgroupnsMode.IsEmpty() != state[2] {
+			t.Fatalf("CgroupnsMode.IsEmpty for %v should have been %v but was %v", cgroupnsMode, state[2], cgroupnsMode.IsEmpty())
+		}
+		if cgroupnsMode.Valid() != state[3] {
+			t.Fatalf("CgroupnsMode.Valid for %v should have been %v but was %v", cgroupnsMode, state[3], cgroupnsMode.Valid())
+		}
+	}
+}
+
 func TestRestartPolicy(t *testing.T) {
 	restartPolicies := map[container.RestartPolicy][]bool{
 		// none, always, failure
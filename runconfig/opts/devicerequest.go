@@ -0,0 +1,89 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// DeviceRequestOpt is a flag type for the requests of devices from device
+// driver plugins, in the form driver=<name>[,count=<n>|all][,device=<id>]
+// [,<option>=<value>].
+type DeviceRequestOpt struct {
+	values []container.DeviceRequest
+}
+
+// Set parses a device request and adds it to the list
+func (o *DeviceRequestOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	req := container.DeviceRequest{}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		key, value := strings.ToLower(parts[0]), parts[1]
+		switch key {
+		case "driver":
+			req.Driver = value
+		case "count":
+			if value == "all" {
+				req.Count = -1
+				continue
+			}
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return fmt.Errorf("invalid count '%s', must be a positive number or all", value)
+			}
+			req.Count = count
+		case "device":
+			req.DeviceIDs = append(req.DeviceIDs, value)
+		default:
+			if req.Options == nil {
+				req.Options = make(map[string]string)
+			}
+			req.Options[parts[0]] = value
+		}
+	}
+
+	if req.Driver == "" {
+		return fmt.Errorf("driver is required")
+	}
+	if req.Count != 0 && len(req.DeviceIDs) > 0 {
+		return fmt.Errorf("count and device cannot both be set")
+	}
+	if req.Count == 0 && len(req.DeviceIDs) == 0 {
+		req.Count = 1
+	}
+
+	o.values = append(o.values, req)
+	return nil
+}
+
+// Type returns the option type
+func (o *DeviceRequestOpt) Type() string {
+	return "device-request"
+}
+
+// String returns a string repr of this option
+func (o *DeviceRequestOpt) String() string {
+	requests := []string{}
+	for _, req := range o.values {
+		requests = append(requests, fmt.Sprintf("%s:%d%v", req.Driver, req.Count, req.DeviceIDs))
+	}
+	return strings.Join(requests, ", ")
+}
+
+// Value returns the device requests
+func (o *DeviceRequestOpt) Value() []container.DeviceRequest {
+	return o.values
+}
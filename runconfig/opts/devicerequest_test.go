@@ -0,0 +1,42 @@
+package opts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestDeviceRequestOpt(t *testing.T) {
+	valid := map[string]container.DeviceRequest{
+		"driver=nvidia":                      {Driver: "nvidia", Count: 1},
+		"driver=nvidia,count=2":              {Driver: "nvidia", Count: 2},
+		"driver=nvidia,count=all":            {Driver: "nvidia", Count: -1},
+		"driver=nvidia,device=0,device=2":    {Driver: "nvidia", DeviceIDs: []string{"0", "2"}},
+		`driver=fpga,"device=a,b",Mode=fast`: {Driver: "fpga", DeviceIDs: []string{"a,b"}, Options: map[string]string{"Mode": "fast"}},
+	}
+	for value, expected := range valid {
+		var opt DeviceRequestOpt
+		if err := opt.Set(value); err != nil {
+			t.Fatalf("%s: unexpected error: %v", value, err)
+		}
+		if len(opt.Value()) != 1 || !reflect.DeepEqual(opt.Value()[0], expected) {
+			t.Fatalf("%s: expected %+v, got %+v", value, expected, opt.Value())
+		}
+	}
+
+	invalid := map[string]string{
+		"count=2":                        "driver is required",
+		"driver=nvidia,count=0":          "invalid count",
+		"driver=nvidia,count=some":       "invalid count",
+		"driver=nvidia,count=2,device=0": "cannot both be set",
+		"driver=nvidia,device":           "must be a key=value pair",
+	}
+	for value, expected := range invalid {
+		var opt DeviceRequestOpt
+		if err := opt.Set(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected an error containing %q, got %v", value, expected, err)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	networktypes "github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
@@ -26,14 +27,17 @@ type ContainerOptions struct {
 	attach            opts.ListOpts
 	volumes           opts.ListOpts
 	tmpfs             opts.ListOpts
+	rwPaths           opts.ListOpts
 	blkioWeightDevice WeightdeviceOpt
 	deviceReadBps     ThrottledeviceOpt
 	deviceWriteBps    ThrottledeviceOpt
 	links             opts.ListOpts
+	dependsOn         opts.ListOpts
 	aliases           opts.ListOpts
 	linkLocalIPs      opts.ListOpts
 	deviceReadIOps    ThrottledeviceOpt
 	deviceWriteIOps   ThrottledeviceOpt
+	deviceRequests    DeviceRequestOpt
 	env               opts.ListOpts
 	labels            opts.ListOpts
 	devices           opts.ListOpts
@@ -47,6 +51,7 @@ type ContainerOptions struct {
 	extraHosts        opts.ListOpts
 	volumesFrom       opts.ListOpts
 	envFile           opts.ListOpts
+	envFileIDs        opts.ListOpts
 	capAdd            opts.ListOpts
 	capDrop           opts.ListOpts
 	groupAdd          opts.ListOpts
@@ -62,6 +67,7 @@ type ContainerOptions struct {
 	stdin             bool
 	tty               bool
 	oomKillDisable    bool
+	oomKillForce      bool
 	oomScoreAdj       int
 	containerIDFile   string
 	entrypoint        string
@@ -74,6 +80,7 @@ type ContainerOptions struct {
 	workingDir        string
 	cpuShares         int64
 	cpuPercent        int64
+	cpus              opts.NanoCPUs
 	cpuPeriod         int64
 	cpuQuota          int64
 	cpusetCpus        string
@@ -82,11 +89,12 @@ type ContainerOptions struct {
 	ioMaxBandwidth    string
 	ioMaxIOps         uint64
 	swappiness        int64
-	netMode           string
+	networks          opts.ListOpts
 	macAddress        string
 	ipv4Address       string
 	ipv6Address       string
 	ipcMode           string
+	cgroupnsMode      string
 	pidsLimit         int64
 	restartPolicy     string
 	readonlyRootfs    bool
@@ -96,6 +104,8 @@ type ContainerOptions struct {
 	stopSignal        string
 	isolation         string
 	shmSize           string
+	coreDumps         string
+	coreDumpMaxSize   string
 	noHealthcheck     bool
 	healthCmd         string
 	healthInterval    time.Duration
@@ -103,6 +113,7 @@ type ContainerOptions struct {
 	healthRetries     int
 	runtime           string
 	autoRemove        bool
+	maxRuntime        time.Duration
 	init              bool
 	initPath          string
 
@@ -128,6 +139,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 		devices:           opts.NewListOpts(ValidateDevice),
 		env:               opts.NewListOpts(ValidateEnv),
 		envFile:           opts.NewListOpts(nil),
+		envFileIDs:        opts.NewListOpts(nil),
 		expose:            opts.NewListOpts(nil),
 		extraHosts:        opts.NewListOpts(ValidateExtraHost),
 		groupAdd:          opts.NewListOpts(nil),
@@ -135,12 +147,15 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 		labelsFile:        opts.NewListOpts(nil),
 		linkLocalIPs:      opts.NewListOpts(nil),
 		links:             opts.NewListOpts(ValidateLink),
+		dependsOn:         opts.NewListOpts(ValidateDependsOn),
 		loggingOpts:       opts.NewListOpts(nil),
+		networks:          opts.NewListOpts(nil),
 		publish:           opts.NewListOpts(nil),
 		securityOpt:       opts.NewListOpts(nil),
 		storageOpt:        opts.NewListOpts(nil),
 		sysctls:           opts.NewMapOpts(nil, opts.ValidateSysctl),
 		tmpfs:             opts.NewListOpts(nil),
+		rwPaths:           opts.NewListOpts(nil),
 		ulimits:           NewUlimitOpt(nil),
 		volumes:           opts.NewListOpts(nil),
 		volumesFrom:       opts.NewListOpts(nil),
@@ -151,6 +166,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.Var(&copts.devices, "device", "Add a host device to the container")
 	flags.VarP(&copts.env, "env", "e", "Set environment variables")
 	flags.Var(&copts.envFile, "env-file", "Read in a file of environment variables")
+	flags.Var(&copts.envFileIDs, "env-file-id", "Set the environment variables of an environment file stored by the daemon")
 	flags.StringVar(&copts.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image")
 	flags.Var(&copts.groupAdd, "group-add", "Add additional groups to join")
 	flags.StringVarP(&copts.hostname, "hostname", "h", "", "Container host name")
@@ -158,7 +174,9 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.VarP(&copts.labels, "label", "l", "Set meta data on a container")
 	flags.Var(&copts.labelsFile, "label-file", "Read in a line delimited file of labels")
 	flags.BoolVar(&copts.readonlyRootfs, "read-only", false, "Mount the container's root filesystem as read only")
+	flags.Var(&copts.rwPaths, "rw-path", "Writable path over the read-only root filesystem")
 	flags.StringVar(&copts.restartPolicy, "restart", "no", "Restart policy to apply when a container exits")
+	flags.Var(&copts.dependsOn, "depends-on", "Container to start before this one (name[:started|healthy])")
 	flags.StringVar(&copts.stopSignal, "stop-signal", signal.DefaultStopSignal, fmt.Sprintf("Signal to stop a container, %v by default", signal.DefaultStopSignal))
 	flags.Var(copts.sysctls, "sysctl", "Sysctl options")
 	flags.BoolVarP(&copts.tty, "tty", "t", false, "Allocate a pseudo-TTY")
@@ -166,6 +184,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.StringVarP(&copts.user, "user", "u", "", "Username or UID (format: <name|uid>[:<group|gid>])")
 	flags.StringVarP(&copts.workingDir, "workdir", "w", "", "Working directory inside the container")
 	flags.BoolVar(&copts.autoRemove, "rm", false, "Automatically remove the container when it exits")
+	flags.DurationVar(&copts.maxRuntime, "timeout", 0, "Stop the container once it has run for this duration (0 to let it run)")
 
 	// Security
 	flags.Var(&copts.capAdd, "cap-add", "Add Linux capabilities")
@@ -188,8 +207,10 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.VarP(&copts.publish, "publish", "p", "Publish a container's port(s) to the host")
 	flags.BoolVarP(&copts.publishAll, "publish-all", "P", false, "Publish all exposed ports to random ports")
 	// We allow for both "--net" and "--network", although the latter is the recommended way.
-	flags.StringVar(&copts.netMode, "net", "default", "Connect a container to a network")
-	flags.StringVar(&copts.netMode, "network", "default", "Connect a container to a network")
+	// The first network is the network mode of the container, which is also
+	// connected to the others before it starts.
+	flags.Var(&copts.networks, "net", "Connect a container to a network")
+	flags.Var(&copts.networks, "network", "Connect a container to a network")
 	flags.MarkHidden("net")
 	// We allow for both "--net-alias" and "--network-alias", although the latter is the recommended way.
 	flags.Var(&copts.aliases, "net-alias", "Add network-scoped alias for the container")
@@ -221,11 +242,13 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.Int64Var(&copts.cpuPercent, "cpu-percent", 0, "CPU percent (Windows only)")
 	flags.Int64Var(&copts.cpuPeriod, "cpu-period", 0, "Limit CPU CFS (Completely Fair Scheduler) period")
 	flags.Int64Var(&copts.cpuQuota, "cpu-quota", 0, "Limit CPU CFS (Completely Fair Scheduler) quota")
+	flags.Var(&copts.cpus, "cpus", "Number of CPUs")
 	flags.Int64VarP(&copts.cpuShares, "cpu-shares", "c", 0, "CPU shares (relative weight)")
 	flags.Var(&copts.deviceReadBps, "device-read-bps", "Limit read rate (bytes per second) from a device")
 	flags.Var(&copts.deviceReadIOps, "device-read-iops", "Limit read rate (IO per second) from a device")
 	flags.Var(&copts.deviceWriteBps, "device-write-bps", "Limit write rate (bytes per second) to a device")
 	flags.Var(&copts.deviceWriteIOps, "device-write-iops", "Limit write rate (IO per second) to a device")
+	flags.Var(&copts.deviceRequests, "device-request", "Request devices from a device driver plugin (driver=<name>[,count=<n>|all][,device=<id>])")
 	flags.StringVar(&copts.ioMaxBandwidth, "io-maxbandwidth", "", "Maximum IO bandwidth limit for the system drive (Windows only)")
 	flags.Uint64Var(&copts.ioMaxIOps, "io-maxiops", 0, "Maximum IOps limit for the system drive (Windows only)")
 	flags.StringVar(&copts.kernelMemory, "kernel-memory", "", "Kernel memory limit")
@@ -234,11 +257,15 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.StringVar(&copts.memorySwap, "memory-swap", "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flags.Int64Var(&copts.swappiness, "memory-swappiness", -1, "Tune container memory swappiness (0 to 100)")
 	flags.BoolVar(&copts.oomKillDisable, "oom-kill-disable", false, "Disable OOM Killer")
+	flags.BoolVar(&copts.oomKillForce, "oom-kill-disable-force", false, "Allow --oom-kill-disable without a memory limit")
 	flags.IntVar(&copts.oomScoreAdj, "oom-score-adj", 0, "Tune host's OOM preferences (-1000 to 1000)")
 	flags.Int64Var(&copts.pidsLimit, "pids-limit", 0, "Tune container pids limit (set -1 for unlimited)")
 
 	// Low-level execution (cgroups, namespaces, ...)
 	flags.StringVar(&copts.cgroupParent, "cgroup-parent", "", "Optional parent cgroup for the container")
+	flags.StringVar(&copts.cgroupnsMode, "cgroupns", "", "Cgroup namespace to use (host|private)")
+	flags.StringVar(&copts.coreDumps, "core-dumps", "", "Handling of the core dumps of the processes (kernel|capture|discard)")
+	flags.StringVar(&copts.coreDumpMaxSize, "core-dump-max-size", "", "Maximum size of a core dump")
 	flags.StringVar(&copts.ipcMode, "ipc", "", "IPC namespace to use")
 	flags.StringVar(&copts.isolation, "isolation", "", "Container isolation technology")
 	flags.StringVar(&copts.pidMode, "pid", "", "PID namespace to use")
@@ -251,6 +278,14 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	return copts
 }
 
+// CreateOptions returns the options of the request creating the container,
+// which are not stored with its configuration.
+func (copts *ContainerOptions) CreateOptions() types.ContainerCreateOptions {
+	return types.ContainerCreateOptions{
+		ForceOomKillDisable: copts.oomKillDisable && copts.oomKillForce,
+	}
+}
+
 // Parse parses the args for the specified command and generates a Config,
 // a HostConfig and returns them with the specified command.
 // If the specified args are not valid, it will return an error.
@@ -270,6 +305,16 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 	if copts.stdin {
 		attachStdin = true
 	}
+
+	networks := copts.networks.GetAll()
+	if len(networks) == 0 {
+		networks = []string{"default"}
+	}
+	netMode := networks[0]
+	if mode := container.NetworkMode(netMode); len(networks) > 1 && (mode.IsHost() || mode.IsNone() || mode.IsContainer()) {
+		return nil, nil, nil, fmt.Errorf("Conflicting options: a container in network mode %s cannot be connected to other networks", netMode)
+	}
+
 	// If -a is not set, attach to stdout and stderr
 	if copts.attach.Len() == 0 {
 		attachStdout = true
@@ -314,6 +359,14 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		}
 	}
 
+	if copts.oomKillDisable && memory == 0 && !copts.oomKillForce {
+		return nil, nil, nil, fmt.Errorf("--oom-kill-disable requires a memory limit to be set with -m/--memory, or --oom-kill-disable-force")
+	}
+
+	if copts.oomScoreAdj < -1000 || copts.oomScoreAdj > 1000 {
+		return nil, nil, nil, fmt.Errorf("invalid value: %d. Valid oom score adj range is -1000-1000", copts.oomScoreAdj)
+	}
+
 	swappiness := copts.swappiness
 	if swappiness != -1 && (swappiness < 0 || swappiness > 100) {
 		return nil, nil, nil, fmt.Errorf("invalid value: %d. Valid memory swappiness range is 0-100", swappiness)
@@ -327,6 +380,14 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		}
 	}
 
+	var coreDumpMaxSize int64
+	if copts.coreDumpMaxSize != "" {
+		coreDumpMaxSize, err = units.RAMInBytes(copts.coreDumpMaxSize)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	// TODO FIXME units.RAMInBytes should have a uint64 version
 	var maxIOBandwidth int64
 	if copts.ioMaxBandwidth != "" {
@@ -350,6 +411,10 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		}
 	}
 
+	if copts.rwPaths.Len() > 0 && !copts.readonlyRootfs {
+		return nil, nil, nil, fmt.Errorf("--rw-path requires --read-only")
+	}
+
 	// Can't evaluate options passed into --tmpfs until we actually mount
 	tmpfs := make(map[string]string)
 	for _, t := range copts.tmpfs.GetAll() {
@@ -390,18 +455,11 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 			return nil, nil, nil, fmt.Errorf("invalid port format for --expose: %s", e)
 		}
 		//support two formats for expose, original format <portnum>/[<proto>] or <startport-endport>/[<proto>]
-		proto, port := nat.SplitProtoPort(e)
-		//parse the start and end port and create a sequence of ports to expose
-		//if expose a port, the start and end port are the same
-		start, end, err := nat.ParsePortRange(port)
+		exposed, err := ParseExposedPorts(e)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("invalid range format for --expose: %s, error: %s", e, err)
+			return nil, nil, nil, fmt.Errorf("invalid value for --expose: %v", err)
 		}
-		for i := start; i <= end; i++ {
-			p, err := nat.NewPort(proto, strconv.FormatUint(i, 10))
-			if err != nil {
-				return nil, nil, nil, err
-			}
+		for _, p := range exposed {
 			if _, exists := ports[p]; !exists {
 				ports[p] = struct{}{}
 			}
@@ -435,6 +493,16 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		return nil, nil, nil, fmt.Errorf("--ipc: invalid IPC mode")
 	}
 
+	cgroupnsMode := container.CgroupnsMode(copts.cgroupnsMode)
+	if !cgroupnsMode.Valid() {
+		return nil, nil, nil, fmt.Errorf("--cgroupns: invalid CGROUP mode")
+	}
+
+	coreDumps := container.CoreDumpMode(copts.coreDumps)
+	if !coreDumps.Valid() {
+		return nil, nil, nil, fmt.Errorf("--core-dumps: invalid core dump mode %q, must be one of kernel, capture or discard", copts.coreDumps)
+	}
+
 	pidMode := container.PidMode(copts.pidMode)
 	if !pidMode.Valid() {
 		return nil, nil, nil, fmt.Errorf("--pid: invalid PID mode")
@@ -503,6 +571,10 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		}
 	}
 
+	if copts.maxRuntime < 0 {
+		return nil, nil, nil, fmt.Errorf("--timeout cannot be negative")
+	}
+
 	resources := container.Resources{
 		CgroupParent:         copts.cgroupParent,
 		Memory:               memory,
@@ -517,6 +589,7 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		CpusetCpus:           copts.cpusetCpus,
 		CpusetMems:           copts.cpusetMems,
 		CPUQuota:             copts.cpuQuota,
+		NanoCPUs:             copts.cpus.Value(),
 		PidsLimit:            copts.pidsLimit,
 		BlkioWeight:          copts.blkioWeight,
 		BlkioWeightDevice:    copts.blkioWeightDevice.GetList(),
@@ -524,6 +597,7 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		BlkioDeviceWriteBps:  copts.deviceWriteBps.GetList(),
 		BlkioDeviceReadIOps:  copts.deviceReadIOps.GetList(),
 		BlkioDeviceWriteIOps: copts.deviceWriteIOps.GetList(),
+		DeviceRequests:       copts.deviceRequests.Value(),
 		IOMaximumIOps:        copts.ioMaxIOps,
 		IOMaximumBandwidth:   uint64(maxIOBandwidth),
 		Ulimits:              copts.ulimits.GetList(),
@@ -562,9 +636,12 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		ContainerIDFile: copts.containerIDFile,
 		OomScoreAdj:     copts.oomScoreAdj,
 		AutoRemove:      copts.autoRemove,
+		MaxRuntime:      copts.maxRuntime,
+		EnvFileIDs:      copts.envFileIDs.GetAll(),
 		Privileged:      copts.privileged,
 		PortBindings:    portBindings,
 		Links:           copts.links.GetAll(),
+		DependsOn:       copts.dependsOn.GetAll(),
 		PublishAllPorts: copts.publishAll,
 		// Make sure the dns fields are never nil.
 		// New containers don't ever have those fields nil,
@@ -576,8 +653,9 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		DNSOptions:     copts.dnsOptions.GetAllOrEmpty(),
 		ExtraHosts:     copts.extraHosts.GetAll(),
 		VolumesFrom:    copts.volumesFrom.GetAll(),
-		NetworkMode:    container.NetworkMode(copts.netMode),
+		NetworkMode:    container.NetworkMode(netMode),
 		IpcMode:        ipcMode,
+		CgroupnsMode:   cgroupnsMode,
 		PidMode:        pidMode,
 		UTSMode:        utsMode,
 		UsernsMode:     usernsMode,
@@ -594,9 +672,13 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		ShmSize:        shmSize,
 		Resources:      resources,
 		Tmpfs:          tmpfs,
+		RwPaths:        copts.rwPaths.GetAll(),
 		Sysctls:        copts.sysctls.GetAll(),
 		Runtime:        copts.runtime,
+		InitPath:       copts.initPath,
 	}
+	hostConfig.CoreDumps = coreDumps
+	hostConfig.CoreDumpMaxSize = coreDumpMaxSize
 
 	// only set this value if the user provided the flag, else it should default to nil
 	if flags.Changed("init") {
@@ -647,6 +729,12 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		networkingConfig.EndpointsConfig[string(hostConfig.NetworkMode)] = epConfig
 	}
 
+	for _, n := range networks[1:] {
+		if _, ok := networkingConfig.EndpointsConfig[n]; !ok && n != netMode {
+			networkingConfig.EndpointsConfig[n] = &networktypes.EndpointSettings{}
+		}
+	}
+
 	return config, hostConfig, networkingConfig, nil
 }
 
@@ -823,6 +911,46 @@ func ValidateLink(val string) (string, error) {
 	return val, nil
 }
 
+const (
+	// DependencyStarted is the condition of a dependency that is met once
+	// the container is running. It is the default condition.
+	DependencyStarted = "started"
+	// DependencyHealthy is the condition of a dependency that is met once
+	// the container is running and healthy.
+	DependencyHealthy = "healthy"
+)
+
+// ParseDependsOn parses and validates the specified string as a dependency
+// format (name[:condition])
+func ParseDependsOn(val string) (string, string, error) {
+	if val == "" {
+		return "", "", fmt.Errorf("empty string specified for depends-on")
+	}
+	arr := strings.Split(val, ":")
+	if len(arr) > 2 || arr[0] == "" || arr[0] == "/" {
+		return "", "", fmt.Errorf("bad format for depends-on: %s", val)
+	}
+	name := strings.TrimPrefix(arr[0], "/")
+	if len(arr) == 1 {
+		return name, DependencyStarted, nil
+	}
+	switch arr[1] {
+	case DependencyStarted, DependencyHealthy:
+		return name, arr[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid condition for depends-on: %s (must be %s or %s)", arr[1], DependencyStarted, DependencyHealthy)
+	}
+}
+
+// ValidateDependsOn validates that the specified string has a valid dependency
+// format (name[:condition]).
+func ValidateDependsOn(val string) (string, error) {
+	if _, _, err := ParseDependsOn(val); err != nil {
+		return val, err
+	}
+	return val, nil
+}
+
 // ValidDeviceMode checks if the mode for device is valid or not.
 // Valid mode is a composition of r (read), w (write), and m (mknod).
 func ValidDeviceMode(mode string) bool {
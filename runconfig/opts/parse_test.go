@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -389,6 +390,70 @@ func TestParseWithMemorySwap(t *testing.T) {
 	}
 }
 
+func TestParseWithCPUs(t *testing.T) {
+	if _, _, _, err := parseRun([]string{"--cpus=one", "img", "cmd"}); err == nil || !strings.Contains(err.Error(), "Failed to parse one as a rational number") {
+		t.Fatalf("Expected an error for an invalid number of CPUs, got %v", err)
+	}
+	if _, hostconfig := mustParse(t, "--cpus=1.5"); hostconfig.NanoCPUs != 1500000000 {
+		t.Fatalf("Expected the config to have '1500000000' as NanoCPUs, got '%v'", hostconfig.NanoCPUs)
+	}
+}
+
+func TestParseOomSettings(t *testing.T) {
+	if _, _, _, err := parseRun([]string{"--oom-kill-disable", "img", "cmd"}); err == nil || !strings.Contains(err.Error(), "requires a memory limit") {
+		t.Fatalf("Expected an error for --oom-kill-disable without a memory limit, got %v", err)
+	}
+	if _, hostconfig := mustParse(t, "--oom-kill-disable -m 64m"); hostconfig.OomKillDisable == nil || !*hostconfig.OomKillDisable {
+		t.Fatalf("Expected the config to have OomKillDisable set, got %v", hostconfig.OomKillDisable)
+	}
+	if _, hostconfig := mustParse(t, "--oom-kill-disable --oom-kill-disable-force"); hostconfig.OomKillDisable == nil || !*hostconfig.OomKillDisable {
+		t.Fatalf("Expected the config to have OomKillDisable set, got %v", hostconfig.OomKillDisable)
+	}
+	for _, invalid := range []string{"--oom-score-adj=-1001", "--oom-score-adj=1001"} {
+		if _, _, _, err := parseRun([]string{invalid, "img", "cmd"}); err == nil || !strings.Contains(err.Error(), "Valid oom score adj range") {
+			t.Fatalf("Expected an error for %s, got %v", invalid, err)
+		}
+	}
+	if _, hostconfig := mustParse(t, "--oom-score-adj=-500"); hostconfig.OomScoreAdj != -500 {
+		t.Fatalf("Expected the config to have -500 as OomScoreAdj, got %v", hostconfig.OomScoreAdj)
+	}
+}
+
+func TestParseInit(t *testing.T) {
+	if _, hostconfig := mustParse(t, ""); hostconfig.Init != nil || hostconfig.InitPath != "" {
+		t.Fatalf("Expected no init settings by default, got %v and %q", hostconfig.Init, hostconfig.InitPath)
+	}
+	_, hostconfig := mustParse(t, "--init --init-path=/usr/local/bin/tini")
+	if hostconfig.Init == nil || !*hostconfig.Init {
+		t.Fatalf("Expected the config to have Init set, got %v", hostconfig.Init)
+	}
+	if hostconfig.InitPath != "/usr/local/bin/tini" {
+		t.Fatalf("Expected the config to have /usr/local/bin/tini as InitPath, got %q", hostconfig.InitPath)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	if _, hostconfig := mustParse(t, ""); hostconfig.MaxRuntime != 0 {
+		t.Fatalf("Expected no maximum runtime by default, got %s", hostconfig.MaxRuntime)
+	}
+	if _, hostconfig := mustParse(t, "--timeout=1h30m"); hostconfig.MaxRuntime != 90*time.Minute {
+		t.Fatalf("Expected a maximum runtime of 1h30m0s, got %s", hostconfig.MaxRuntime)
+	}
+	if _, _, err := parse(t, "--timeout=-1s"); err == nil || err.Error() != "--timeout cannot be negative" {
+		t.Fatalf("Expected an error for a negative timeout, got %v", err)
+	}
+}
+
+func TestParseEnvFileIDs(t *testing.T) {
+	if _, hostconfig := mustParse(t, ""); len(hostconfig.EnvFileIDs) != 0 {
+		t.Fatalf("Expected no environment files by default, got %v", hostconfig.EnvFileIDs)
+	}
+	_, hostconfig := mustParse(t, "--env-file-id=db --env-file-id=0123456789ab")
+	if len(hostconfig.EnvFileIDs) != 2 || hostconfig.EnvFileIDs[0] != "db" || hostconfig.EnvFileIDs[1] != "0123456789ab" {
+		t.Fatalf("Expected the environment files db and 0123456789ab, got %v", hostconfig.EnvFileIDs)
+	}
+}
+
 func TestParseHostname(t *testing.T) {
 	validHostnames := map[string]string{
 		"hostname":    "hostname",
@@ -412,16 +477,54 @@ func TestParseHostname(t *testing.T) {
 	}
 }
 
+func TestParseRwPaths(t *testing.T) {
+	_, hostconfig := mustParse(t, "--read-only --rw-path /tmp --rw-path /var/run")
+	if !reflect.DeepEqual(hostconfig.RwPaths, []string{"/tmp", "/var/run"}) {
+		t.Fatalf("Expected RwPaths [/tmp /var/run], got %v", hostconfig.RwPaths)
+	}
+	if _, _, err := parse(t, "--rw-path /tmp"); err == nil || err.Error() != "--rw-path requires --read-only" {
+		t.Fatalf("Expected an error for --rw-path without --read-only, got %v", err)
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	_, hostconfig, nwconfig, err := parseRun(strings.Split("--network net1 --network-alias web --network net2 --network net3 ubuntu bash", " "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hostconfig.NetworkMode != "net1" {
+		t.Fatalf("Expected the network mode to be net1, got %s", hostconfig.NetworkMode)
+	}
+	if len(nwconfig.EndpointsConfig) != 3 {
+		t.Fatalf("Expected the endpoint configs of 3 networks, got %v", nwconfig.EndpointsConfig)
+	}
+	if aliases := nwconfig.EndpointsConfig["net1"].Aliases; !reflect.DeepEqual(aliases, []string{"web"}) {
+		t.Fatalf("Expected the alias web on net1, got %v", aliases)
+	}
+	if nwconfig.EndpointsConfig["net2"] == nil || nwconfig.EndpointsConfig["net3"] == nil {
+		t.Fatalf("Expected the endpoint configs of net2 and net3, got %v", nwconfig.EndpointsConfig)
+	}
+
+	if _, hostconfig := mustParse(t, ""); hostconfig.NetworkMode != "default" {
+		t.Fatalf("Expected the default network mode, got %s", hostconfig.NetworkMode)
+	}
+	if _, _, err := parse(t, "--network host --network net1"); err == nil {
+		t.Fatal("Expected an error for the host network mode with other networks")
+	}
+}
+
 func TestParseWithExpose(t *testing.T) {
 	invalids := map[string]string{
 		":":                   "invalid port format for --expose: :",
 		"8080:9090":           "invalid port format for --expose: 8080:9090",
-		"/tcp":                "invalid range format for --expose: /tcp, error: Empty string specified for ports.",
-		"/udp":                "invalid range format for --expose: /udp, error: Empty string specified for ports.",
-		"NaN/tcp":             `invalid range format for --expose: NaN/tcp, error: strconv.ParseUint: parsing "NaN": invalid syntax`,
-		"NaN-NaN/tcp":         `invalid range format for --expose: NaN-NaN/tcp, error: strconv.ParseUint: parsing "NaN": invalid syntax`,
-		"8080-NaN/tcp":        `invalid range format for --expose: 8080-NaN/tcp, error: strconv.ParseUint: parsing "NaN": invalid syntax`,
-		"1234567890-8080/tcp": `invalid range format for --expose: 1234567890-8080/tcp, error: strconv.ParseUint: parsing "1234567890": value out of range`,
+		"/tcp":                `invalid value for --expose: invalid port "/tcp": missing port number`,
+		"/udp":                `invalid value for --expose: invalid port "/udp": missing port number`,
+		"NaN/tcp":             `invalid value for --expose: invalid port "NaN/tcp": "NaN" is not a port number`,
+		"NaN-NaN/tcp":         `invalid value for --expose: invalid port "NaN-NaN/tcp": "NaN" is not a port number`,
+		"8080-NaN/tcp":        `invalid value for --expose: invalid port "8080-NaN/tcp": "NaN" is not a port number`,
+		"1234567890-8080/tcp": `invalid value for --expose: invalid port "1234567890-8080/tcp": port 1234567890 is out of range, must be between 0 and 65535`,
+		"8005-8000/udp":       `invalid value for --expose: invalid port range "8005-8000/udp": start port 8005 is greater than end port 8000`,
+		"8000-/tcp":           `invalid value for --expose: invalid port range "8000-/tcp": a range must have a start and an end port`,
 	}
 	valids := map[string][]nat.Port{
 		"8080/tcp":      {"8080/tcp"},
@@ -516,6 +619,15 @@ func TestParseModes(t *testing.T) {
 	if !hostconfig.IpcMode.Valid() {
 		t.Fatalf("Expected a valid IpcMode, got %v", hostconfig.IpcMode)
 	}
+	for _, mode := range []string{"private", "shareable", "none"} {
+		_, hostconfig, _, err := parseRun([]string{"--ipc=" + mode, "img", "cmd"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(hostconfig.IpcMode) != mode || !hostconfig.IpcMode.Valid() {
+			t.Fatalf("Expected a valid %s IpcMode, got %v", mode, hostconfig.IpcMode)
+		}
+	}
 	// pid ko
 	if _, _, _, err := parseRun([]string{"--pid=container:", "img", "cmd"}); err == nil || err.Error() != "--pid: invalid PID mode" {
 		t.Fatalf("Expected an error with message '--pid: invalid PID mode', got %v", err)
@@ -540,6 +652,18 @@ func TestParseModes(t *testing.T) {
 	if !hostconfig.UTSMode.Valid() {
 		t.Fatalf("Expected a valid UTSMode, got %v", hostconfig.UTSMode)
 	}
+	// cgroupns ko
+	if _, _, _, err := parseRun([]string{"--cgroupns=container:", "img", "cmd"}); err == nil || err.Error() != "--cgroupns: invalid CGROUP mode" {
+		t.Fatalf("Expected an error with message '--cgroupns: invalid CGROUP mode', got %v", err)
+	}
+	// cgroupns ok
+	_, hostconfig, _, err = parseRun([]string{"--cgroupns=private", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hostconfig.CgroupnsMode.IsPrivate() {
+		t.Fatalf("Expected a private CgroupnsMode, got %v", hostconfig.CgroupnsMode)
+	}
 	// shm-size ko
 	if _, _, _, err = parseRun([]string{"--shm-size=a128m", "img", "cmd"}); err == nil || err.Error() != "invalid size: 'a128m'" {
 		t.Fatalf("Expected an error with message 'invalid size: a128m', got %v", err)
@@ -763,6 +887,39 @@ func TestParseLink(t *testing.T) {
 	}
 }
 
+func TestParseDependsOn(t *testing.T) {
+	valid := map[string][2]string{
+		"db":         {"db", DependencyStarted},
+		"/db":        {"db", DependencyStarted},
+		"db:started": {"db", DependencyStarted},
+		"db:healthy": {"db", DependencyHealthy},
+	}
+	invalid := map[string]string{
+		"":           "empty string specified for depends-on",
+		":healthy":   "bad format for depends-on: :healthy",
+		"db:up:now":  "bad format for depends-on: db:up:now",
+		"db:stopped": "invalid condition for depends-on: stopped",
+	}
+
+	for dependency, expected := range valid {
+		name, condition, err := ParseDependsOn(dependency)
+		if err != nil {
+			t.Fatalf("ParseDependsOn(`%q`) should succeed: error %q", dependency, err)
+		}
+		if name != expected[0] || condition != expected[1] {
+			t.Fatalf("ParseDependsOn(`%q`) should return %v, got %s and %s", dependency, expected, name, condition)
+		}
+	}
+
+	for dependency, expectedError := range invalid {
+		if _, err := ValidateDependsOn(dependency); err == nil {
+			t.Fatalf("ValidateDependsOn(`%q`) should have failed validation", dependency)
+		} else if !strings.Contains(err.Error(), expectedError) {
+			t.Fatalf("ValidateDependsOn(`%q`) error should contain %q, got %q", dependency, expectedError, err)
+		}
+	}
+}
+
 func TestValidateDevice(t *testing.T) {
 	valid := []string{
 		"/home",
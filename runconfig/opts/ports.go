@@ -0,0 +1,64 @@
+package opts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// ParseExposedPorts parses a port to expose, in the format
+// <port>[/<proto>] or <startport-endport>[/<proto>], and returns the
+// ports it contains. The protocol defaults to tcp.
+func ParseExposedPorts(spec string) ([]nat.Port, error) {
+	rawPort, proto := spec, "tcp"
+	if i := strings.Index(spec, "/"); i >= 0 && i < len(spec)-1 {
+		rawPort, proto = spec[:i], spec[i+1:]
+	} else if i >= 0 {
+		rawPort = spec[:i]
+	}
+	if rawPort == "" {
+		return nil, fmt.Errorf("invalid port %q: missing port number", spec)
+	}
+
+	startPort, endPort := rawPort, rawPort
+	if i := strings.Index(rawPort, "-"); i >= 0 {
+		startPort, endPort = rawPort[:i], rawPort[i+1:]
+		if startPort == "" || endPort == "" {
+			return nil, fmt.Errorf("invalid port range %q: a range must have a start and an end port", spec)
+		}
+	}
+	start, err := parsePortNumber(spec, startPort)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parsePortNumber(spec, endPort)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, fmt.Errorf("invalid port range %q: start port %d is greater than end port %d", spec, start, end)
+	}
+
+	ports := make([]nat.Port, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		port, err := nat.NewPort(proto, strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+func parsePortNumber(spec, port string) (int, error) {
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q: %q is not a port number", spec, port)
+	}
+	if n < 0 || n > 65535 {
+		return 0, fmt.Errorf("invalid port %q: port %d is out of range, must be between 0 and 65535", spec, n)
+	}
+	return n, nil
+}
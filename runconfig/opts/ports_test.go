@@ -0,0 +1,41 @@
+package opts
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+)
+
+func TestParseExposedPorts(t *testing.T) {
+	valids := map[string][]nat.Port{
+		"80":            {"80/tcp"},
+		"80/":           {"80/tcp"},
+		"53/udp":        {"53/udp"},
+		"8000-8002/udp": {"8000/udp", "8001/udp", "8002/udp"},
+		"8000-8000":     {"8000/tcp"},
+	}
+	for spec, expected := range valids {
+		ports, err := ParseExposedPorts(spec)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", spec, err)
+		}
+		if !reflect.DeepEqual(ports, expected) {
+			t.Fatalf("expected %v for %s, got %v", expected, spec, ports)
+		}
+	}
+
+	invalids := map[string]string{
+		"":          `invalid port "": missing port number`,
+		"65536":     `invalid port "65536": port 65536 is out of range, must be between 0 and 65535`,
+		"-8000":     `invalid port range "-8000": a range must have a start and an end port`,
+		"9-8/udp":   `invalid port range "9-8/udp": start port 9 is greater than end port 8`,
+		"80-a":      `invalid port "80-a": "a" is not a port number`,
+		"1-2-3/tcp": `invalid port "1-2-3/tcp": "2-3" is not a port number`,
+	}
+	for spec, expected := range invalids {
+		if _, err := ParseExposedPorts(spec); err == nil || err.Error() != expected {
+			t.Fatalf("expected error %q for %s, got %v", expected, spec, err)
+		}
+	}
+}
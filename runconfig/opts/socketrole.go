@@ -0,0 +1,107 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+// SocketRoleOpt defines a map of the roles of the clients of the unix
+// sockets, set in the form name=<name>,uid=<uid>|gid=<gid>,allow=<rule>.
+type SocketRoleOpt struct {
+	name   string
+	values *map[string]types.SocketRole
+}
+
+// NewNamedSocketRoleOpt creates a new SocketRoleOpt
+func NewNamedSocketRoleOpt(name string, ref *map[string]types.SocketRole) *SocketRoleOpt {
+	if ref == nil {
+		ref = &map[string]types.SocketRole{}
+	}
+	return &SocketRoleOpt{name: name, values: ref}
+}
+
+// Name returns the name of the SocketRoleOpt in the configuration.
+func (o *SocketRoleOpt) Name() string {
+	return o.name
+}
+
+// Set validates and adds a role to the map of socket roles
+func (o *SocketRoleOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	role := types.SocketRole{}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		key, value := strings.ToLower(parts[0]), parts[1]
+		switch key {
+		case "name":
+			name = value
+		case "uid", "gid":
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid %s '%s'", key, value)
+			}
+			if key == "uid" {
+				role.UIDs = append(role.UIDs, uint32(id))
+			} else {
+				role.GIDs = append(role.GIDs, uint32(id))
+			}
+		case "allow":
+			role.Allow = append(role.Allow, value)
+		default:
+			return fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(role.UIDs) == 0 && len(role.GIDs) == 0 {
+		return fmt.Errorf("socket role '%s' must have a uid or a gid", name)
+	}
+	if _, ok := (*o.values)[name]; ok {
+		return fmt.Errorf("socket role '%s' was already defined", name)
+	}
+
+	(*o.values)[name] = role
+	return nil
+}
+
+// String returns the names of the socket roles as a string.
+func (o *SocketRoleOpt) String() string {
+	var out []string
+	for k := range *o.values {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+
+	return fmt.Sprintf("%v", out)
+}
+
+// GetMap returns the map of socket roles
+func (o *SocketRoleOpt) GetMap() map[string]types.SocketRole {
+	if o.values != nil {
+		return *o.values
+	}
+
+	return map[string]types.SocketRole{}
+}
+
+// Type returns the type of the option
+func (o *SocketRoleOpt) Type() string {
+	return "socket-role"
+}
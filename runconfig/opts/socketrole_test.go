@@ -0,0 +1,46 @@
+package opts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestSocketRoleOpt(t *testing.T) {
+	roles := make(map[string]types.SocketRole)
+	opt := NewNamedSocketRoleOpt("socket-roles", &roles)
+	if err := opt.Set("name=monitoring,gid=1001,uid=1000,uid=1002,allow=GET /"); err != nil {
+		t.Fatal(err)
+	}
+	if err := opt.Set(`name=ops,gid=1003,allow=GET /,"allow=POST /containers/*/restart"`); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]types.SocketRole{
+		"monitoring": {UIDs: []uint32{1000, 1002}, GIDs: []uint32{1001}, Allow: []string{"GET /"}},
+		"ops":        {GIDs: []uint32{1003}, Allow: []string{"GET /", "POST /containers/*/restart"}},
+	}
+	if !reflect.DeepEqual(opt.GetMap(), expected) {
+		t.Fatalf("expected %+v, got %+v", expected, opt.GetMap())
+	}
+	if opt.String() != "[monitoring ops]" {
+		t.Fatalf("unexpected string %s", opt.String())
+	}
+
+	invalid := map[string]string{
+		"gid=1001,allow=GET /":               "name is required",
+		"name=other,allow=GET /":             "must have a uid or a gid",
+		"name=other,uid=-1":                  "invalid uid",
+		"name=other,gid=group":               "invalid gid",
+		"name=other,gid=1001,deny=GET /":     "unexpected key",
+		"name=other,gid":                     "must be a key=value pair",
+		"name=monitoring,gid=1001,allow=GET": "already defined",
+	}
+	for value, expected := range invalid {
+		if err := opt.Set(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%s: expected an error containing %q, got %v", value, expected, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package runconfig
+
+// Scrollback selects the recent output replayed to a client attaching to
+// the standard output.
+type Scrollback int
+
+const (
+	// ScrollbackNone replays no output.
+	ScrollbackNone Scrollback = iota
+	// ScrollbackAll replays all the output kept in the scrollback.
+	ScrollbackAll
+	// ScrollbackSinceDetach replays the output kept in the scrollback that
+	// was written since a client last detached.
+	ScrollbackSinceDetach
+)
+
+// scrollback is a ring buffer keeping the last bytes written to a stream.
+type scrollback struct {
+	buf   []byte
+	start int   // index of the oldest byte in buf
+	size  int   // number of bytes kept
+	total int64 // number of bytes written since the scrollback was created
+}
+
+func newScrollback(size int) *scrollback {
+	return &scrollback{buf: make([]byte, size)}
+}
+
+func (s *scrollback) Write(p []byte) (int, error) {
+	n := len(p)
+	s.total += int64(n)
+	if n >= len(s.buf) {
+		copy(s.buf, p[n-len(s.buf):])
+		s.start, s.size = 0, len(s.buf)
+		return n, nil
+	}
+	end := (s.start + s.size) % len(s.buf)
+	copied := copy(s.buf[end:], p)
+	copy(s.buf, p[copied:])
+	s.size += n
+	if s.size > len(s.buf) {
+		s.start = (s.start + s.size - len(s.buf)) % len(s.buf)
+		s.size = len(s.buf)
+	}
+	return n, nil
+}
+
+// since returns the bytes kept that were written after the first offset
+// bytes.
+func (s *scrollback) since(offset int64) []byte {
+	n := s.size
+	if written := s.total - offset; written < int64(n) {
+		if written <= 0 {
+			return nil
+		}
+		n = int(written)
+	}
+	out := make([]byte, n)
+	first := (s.start + s.size - n) % len(s.buf)
+	copied := copy(out, s.buf[first:])
+	if copied < n {
+		copy(out[copied:], s.buf)
+	}
+	return out
+}
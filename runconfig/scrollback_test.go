@@ -0,0 +1,57 @@
+package runconfig
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestScrollback(t *testing.T) {
+	s := newScrollback(8)
+	s.Write([]byte("abc"))
+	if b := s.since(0); string(b) != "abc" {
+		t.Fatalf("expected abc, got %q", b)
+	}
+	s.Write([]byte("defghij"))
+	if b := s.since(0); string(b) != "cdefghij" {
+		t.Fatalf("expected the last 8 bytes cdefghij, got %q", b)
+	}
+	if b := s.since(7); string(b) != "hij" {
+		t.Fatalf("expected the bytes written after offset 7 hij, got %q", b)
+	}
+	if b := s.since(10); len(b) != 0 {
+		t.Fatalf("expected no bytes after offset 10, got %q", b)
+	}
+	s.Write([]byte("0123456789"))
+	if b := s.since(0); string(b) != "23456789" {
+		t.Fatalf("expected 23456789, got %q", b)
+	}
+}
+
+func TestStdoutPipeWithScrollback(t *testing.T) {
+	for _, c := range []struct {
+		mode     Scrollback
+		expected string
+	}{
+		{ScrollbackNone, "live"},
+		{ScrollbackAll, "before after live"},
+		{ScrollbackSinceDetach, "after live"},
+	} {
+		streamConfig := NewStreamConfig()
+		streamConfig.EnableScrollback(1024)
+		streamConfig.Stdout().Write([]byte("before "))
+		streamConfig.MarkDetached()
+		streamConfig.Stdout().Write([]byte("after "))
+
+		pipe := streamConfig.StdoutPipeWithScrollback(c.mode)
+		streamConfig.Stdout().Write([]byte("live"))
+		streamConfig.CloseStreams()
+
+		b, err := ioutil.ReadAll(pipe)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != c.expected {
+			t.Fatalf("expected %q for mode %d, got %q", c.expected, c.mode, b)
+		}
+	}
+}
@@ -19,13 +19,20 @@ import (
 // which can be used to retrieve the standard output (and error) generated
 // by the container's active process. The output (and error) are actually
 // copied and delivered to all StdoutPipe and StderrPipe consumers, using
-// a kind of "broadcaster".
+// a kind of "broadcaster". When the scrollback is enabled, the last bytes of
+// the output are also kept, to be replayed to new StdoutPipe consumers.
 type StreamConfig struct {
 	sync.WaitGroup
 	stdout    *broadcaster.Unbuffered
 	stderr    *broadcaster.Unbuffered
 	stdin     io.ReadCloser
 	stdinPipe io.WriteCloser
+
+	// mu protects the scrollback, and serializes the writes to stdout
+	// with the creation of the pipes replaying the scrollback.
+	mu           sync.Mutex
+	scrollback   *scrollback
+	detachOffset int64
 }
 
 // NewStreamConfig creates a stream config and initializes
@@ -37,9 +44,44 @@ func NewStreamConfig() *StreamConfig {
 	}
 }
 
-// Stdout returns the standard output in the configuration.
-func (streamConfig *StreamConfig) Stdout() *broadcaster.Unbuffered {
-	return streamConfig.stdout
+// Stdout returns the writer of the standard output in the configuration.
+func (streamConfig *StreamConfig) Stdout() io.Writer {
+	return stdoutWriter{streamConfig}
+}
+
+// stdoutWriter writes the standard output to the scrollback, if enabled,
+// and to the broadcaster.
+type stdoutWriter struct {
+	streamConfig *StreamConfig
+}
+
+func (w stdoutWriter) Write(p []byte) (int, error) {
+	w.streamConfig.mu.Lock()
+	defer w.streamConfig.mu.Unlock()
+	if w.streamConfig.scrollback != nil {
+		w.streamConfig.scrollback.Write(p)
+	}
+	return w.streamConfig.stdout.Write(p)
+}
+
+// EnableScrollback keeps the last size bytes of the standard output. It
+// has no effect if the scrollback is already enabled.
+func (streamConfig *StreamConfig) EnableScrollback(size int) {
+	streamConfig.mu.Lock()
+	if streamConfig.scrollback == nil && size > 0 {
+		streamConfig.scrollback = newScrollback(size)
+	}
+	streamConfig.mu.Unlock()
+}
+
+// MarkDetached records that a client detached from the standard output,
+// for the clients replaying the output written since then.
+func (streamConfig *StreamConfig) MarkDetached() {
+	streamConfig.mu.Lock()
+	if streamConfig.scrollback != nil {
+		streamConfig.detachOffset = streamConfig.scrollback.total
+	}
+	streamConfig.mu.Unlock()
 }
 
 // Stderr returns the standard error in the configuration.
@@ -65,6 +107,24 @@ func (streamConfig *StreamConfig) StdoutPipe() io.ReadCloser {
 	return bytesPipe
 }
 
+// StdoutPipeWithScrollback creates a new io.ReadCloser like StdoutPipe,
+// which starts with the output of the scrollback selected by mode.
+func (streamConfig *StreamConfig) StdoutPipeWithScrollback(mode Scrollback) io.ReadCloser {
+	streamConfig.mu.Lock()
+	defer streamConfig.mu.Unlock()
+
+	bytesPipe := ioutils.NewBytesPipe()
+	if s := streamConfig.scrollback; s != nil && mode != ScrollbackNone {
+		var offset int64
+		if mode == ScrollbackSinceDetach {
+			offset = streamConfig.detachOffset
+		}
+		bytesPipe.Write(s.since(offset))
+	}
+	streamConfig.stdout.Add(bytesPipe)
+	return bytesPipe
+}
+
 // StderrPipe creates a new io.ReadCloser with an empty bytes pipe.
 // It adds this new err pipe to the Stderr broadcaster.
 func (streamConfig *StreamConfig) StderrPipe() io.ReadCloser {
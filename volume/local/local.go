@@ -287,6 +287,9 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// mountErr is the error of the last mount or unmount of the volume, if
+	// it failed
+	mountErr error
 }
 
 // Name returns the name of the given Volume.
@@ -311,9 +314,11 @@ func (v *localVolume) Mount(id string) (string, error) {
 	if v.opts != nil {
 		if !v.active.mounted {
 			if err := v.mount(); err != nil {
+				v.mountErr = err
 				return "", err
 			}
 			v.active.mounted = true
+			v.mountErr = nil
 		}
 		v.active.count++
 	}
@@ -324,14 +329,16 @@ func (v *localVolume) Mount(id string) (string, error) {
 func (v *localVolume) Unmount(id string) error {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.opts != nil && v.active.count > 0 {
 		v.active.count--
 		if v.active.count == 0 {
 			if err := mount.Unmount(v.path); err != nil {
 				v.active.count++
-				return errors.Wrapf(err, "error while unmounting volume path '%s'", v.path)
+				v.mountErr = errors.Wrapf(err, "error while unmounting volume path '%s'", v.path)
+				return v.mountErr
 			}
 			v.active.mounted = false
+			v.mountErr = nil
 		}
 	}
 	return nil
@@ -346,6 +353,19 @@ func validateOpts(opts map[string]string) error {
 	return nil
 }
 
+// Status returns the state of the mount of a volume created with mount
+// options, and the error of its last mount or unmount if it failed.
 func (v *localVolume) Status() map[string]interface{} {
-	return nil
+	v.m.Lock()
+	defer v.m.Unlock()
+	if v.opts == nil {
+		return nil
+	}
+	status := map[string]interface{}{
+		"Mounted": v.active.mounted,
+	}
+	if v.mountErr != nil {
+		status["Error"] = v.mountErr.Error()
+	}
+	return status
 }
@@ -7,6 +7,7 @@ package local
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
 	"strings"
 
@@ -23,6 +24,9 @@ var (
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
 	}
+
+	// lookupIP resolves the hosts of the remote filesystems
+	lookupIP = net.LookupIP
 )
 
 type optsConfig struct {
@@ -64,6 +68,13 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+	host, err := v.opts.remoteHost()
+	if err != nil {
+		return validationError{err}
+	}
+	if host == "" && v.opts.isRemote() && !hasAddrOpt(v.opts.MountOpts) {
+		return validationError{fmt.Errorf("missing addr in the o option of a %s volume whose device has no host", v.opts.MountType)}
+	}
 	return nil
 }
 
@@ -71,6 +82,113 @@ func (v *localVolume) mount() error {
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
 	}
-	err := mount.Mount(v.opts.MountDevice, v.path, v.opts.MountType, v.opts.MountOpts)
+	mountOpts, err := v.opts.mountOpts()
+	if err != nil {
+		return errors.Wrapf(err, "error while mounting volume with options: %s", v.opts)
+	}
+	err = mount.Mount(v.opts.MountDevice, v.path, v.opts.MountType, mountOpts)
 	return errors.Wrapf(err, "error while mounting volume with options: %s", v.opts)
 }
+
+// isRemote returns whether the volume mounts an nfs or cifs filesystem.
+func (o *optsConfig) isRemote() bool {
+	switch o.MountType {
+	case "nfs", "nfs4", "cifs":
+		return true
+	}
+	return false
+}
+
+// remoteHost returns the host of the device of an nfs volume, in the
+// host:/export form, or of a cifs volume, in the //host/share form. The host
+// of an nfs device can be empty when the address is given in the options.
+func (o *optsConfig) remoteHost() (string, error) {
+	switch o.MountType {
+	case "nfs", "nfs4":
+		i := strings.Index(o.MountDevice, ":/")
+		if i < 0 {
+			return "", fmt.Errorf("invalid device %q for a %s volume, must be in the host:/export form", o.MountDevice, o.MountType)
+		}
+		return o.MountDevice[:i], nil
+	case "cifs":
+		share := strings.TrimPrefix(o.MountDevice, "//")
+		if i := strings.Index(share, "/"); share != o.MountDevice && i > 0 {
+			return share[:i], nil
+		}
+		return "", fmt.Errorf("invalid device %q for a cifs volume, must be in the //host/share form", o.MountDevice)
+	}
+	return "", nil
+}
+
+// mountOpts returns the options of the mount of the volume. The kernel does
+// not resolve host names, which is done by the mount helpers of nfs and cifs,
+// so the host of the addr option of a remote filesystem is resolved here, and
+// the addr option is set from the host of the device when it is missing.
+func (o *optsConfig) mountOpts() (string, error) {
+	if !o.isRemote() {
+		return o.MountOpts, nil
+	}
+	host, err := o.remoteHost()
+	if err != nil {
+		return "", err
+	}
+
+	var opts []string
+	hasAddr := false
+	for _, opt := range strings.Split(o.MountOpts, ",") {
+		if opt == "" {
+			continue
+		}
+		if kv := strings.SplitN(opt, "=", 2); len(kv) == 2 && isAddrOpt(kv[0]) {
+			addr, err := resolveAddr(kv[1])
+			if err != nil {
+				return "", err
+			}
+			opt = kv[0] + "=" + addr
+			hasAddr = true
+		}
+		opts = append(opts, opt)
+	}
+	if !hasAddr {
+		if host == "" {
+			return "", fmt.Errorf("missing addr in the o option of a %s volume whose device has no host", o.MountType)
+		}
+		addr, err := resolveAddr(host)
+		if err != nil {
+			return "", err
+		}
+		opts = append(opts, "addr="+addr)
+	}
+	return strings.Join(opts, ","), nil
+}
+
+// isAddrOpt returns whether key is the option of the address of the server of
+// a remote filesystem, addr, or ip for cifs.
+func isAddrOpt(key string) bool {
+	return key == "addr" || key == "ip"
+}
+
+// hasAddrOpt returns whether the mount options set the address of the server.
+func hasAddrOpt(mountOpts string) bool {
+	for _, opt := range strings.Split(mountOpts, ",") {
+		if kv := strings.SplitN(opt, "=", 2); len(kv) == 2 && isAddrOpt(kv[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAddr returns the IP address of host.
+func resolveAddr(host string) (string, error) {
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		return ip.String(), nil
+	}
+	ips, err := lookupIP(host)
+	if err != nil {
+		return "", errors.Wrapf(err, "error resolving the address of %s", host)
+	}
+	if len(ips) == 0 {
+		return "", fmt.Errorf("no address found for %s", host)
+	}
+	return ips[0].String(), nil
+}
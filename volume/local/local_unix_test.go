@@ -0,0 +1,79 @@
+// +build linux freebsd solaris
+
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+)
+
+func TestMountOptsRemote(t *testing.T) {
+	defer func(orig func(string) ([]net.IP, error)) { lookupIP = orig }(lookupIP)
+	lookupIP = func(host string) ([]net.IP, error) {
+		if host == "nas" {
+			return []net.IP{net.ParseIP("10.0.0.5")}, nil
+		}
+		return nil, fmt.Errorf("unknown host %s", host)
+	}
+
+	cases := []struct {
+		opts     optsConfig
+		expected string
+	}{
+		{optsConfig{MountType: "nfs", MountDevice: ":/export", MountOpts: "addr=10.0.0.5,rw"}, "addr=10.0.0.5,rw"},
+		{optsConfig{MountType: "nfs", MountDevice: ":/export", MountOpts: "addr=nas,rw"}, "addr=10.0.0.5,rw"},
+		{optsConfig{MountType: "nfs4", MountDevice: "nas:/export", MountOpts: "rw"}, "rw,addr=10.0.0.5"},
+		{optsConfig{MountType: "cifs", MountDevice: "//nas/share", MountOpts: "username=u"}, "username=u,addr=10.0.0.5"},
+		{optsConfig{MountType: "cifs", MountDevice: "//nas/share", MountOpts: "ip=10.0.0.6"}, "ip=10.0.0.6"},
+		{optsConfig{MountType: "tmpfs", MountDevice: "tmpfs", MountOpts: "size=1m"}, "size=1m"},
+	}
+	for _, c := range cases {
+		opts, err := c.opts.mountOpts()
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", &c.opts, err)
+		}
+		if opts != c.expected {
+			t.Fatalf("expected %q for %s, got %q", c.expected, &c.opts, opts)
+		}
+	}
+
+	if _, err := (&optsConfig{MountType: "nfs", MountDevice: "unknown:/export"}).mountOpts(); err == nil {
+		t.Fatal("expected an error for an unknown host")
+	}
+}
+
+func TestCreateRemoteValidation(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalids := []map[string]string{
+		{"type": "nfs", "device": "/export"},
+		{"type": "nfs", "device": ":/export", "o": "rw"},
+		{"type": "cifs", "device": "nas/share"},
+		{"type": "cifs", "device": "//nas"},
+	}
+	for _, opts := range invalids {
+		if _, err := r.Create("remote", opts); err == nil {
+			t.Fatalf("expected an error for %v", opts)
+		}
+	}
+
+	v, err := r.Create("remote", map[string]string{"type": "nfs", "device": ":/export", "o": "addr=10.0.0.5,rw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := v.Status(); status["Mounted"] != false || status["Error"] != nil {
+		t.Fatalf("expected an unmounted volume without error, got %v", status)
+	}
+}
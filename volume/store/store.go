@@ -139,9 +139,17 @@ func (s *VolumeStore) List() ([]volume.Volume, []string, error) {
 		return nil, nil, &OpErr{Err: err, Op: "list"}
 	}
 	var out []volume.Volume
+	// volumes of global drivers can be returned more than once, for example
+	// by a driver that lists the volumes of all the nodes of a cluster
+	listed := make(map[string]bool)
 
 	for _, v := range vols {
 		name := normaliseVolumeName(v.Name())
+		key := v.DriverName() + "/" + name
+		if listed[key] {
+			continue
+		}
+		listed[key] = true
 
 		s.locks.Lock(name)
 		storedV, exists := s.getNamed(name)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	pluginstore "github.com/docker/docker/plugin/store"
+	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
 	volumetestutils "github.com/docker/docker/volume/testutils"
 )
@@ -114,6 +115,46 @@ func TestList(t *testing.T) {
 	}
 }
 
+// globalDriver is a global driver that returns each of its volumes twice,
+// like a driver listing the volumes of all the nodes of a cluster.
+type globalDriver struct {
+	volume.Driver
+}
+
+func (d globalDriver) List() ([]volume.Volume, error) {
+	ls, err := d.Driver.List()
+	return append(ls, ls...), err
+}
+
+func (globalDriver) Scope() string {
+	return volume.GlobalScope
+}
+
+func TestListGlobalVolumesOnce(t *testing.T) {
+	volumedrivers.Register(globalDriver{volumetestutils.NewFakeDriver("global")}, "global")
+	defer volumedrivers.Unregister("global")
+
+	s, err := New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create("test", "global", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ls, _, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 1 {
+		t.Fatalf("expected 1 volume, got: %d", len(ls))
+	}
+	v, ok := ls[0].(volume.ScopedVolume)
+	if !ok || v.Scope() != volume.GlobalScope {
+		t.Fatalf("expected a volume with the global scope, got %v", ls[0])
+	}
+}
+
 func TestFilterByDriver(t *testing.T) {
 	volumedrivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
 	volumedrivers.Register(volumetestutils.NewFakeDriver("noop"), "noop")
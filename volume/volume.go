@@ -80,6 +80,18 @@ type ScopedVolume interface {
 	Volume
 }
 
+// The results of the copy of the image data into a volume, recorded on the
+// mount point when the container is created.
+const (
+	// CopyResultCopied means the image data was copied into the volume.
+	CopyResultCopied = "copied"
+	// CopyResultDisabled means the copy was disabled by the nocopy option.
+	CopyResultDisabled = "disabled"
+	// CopyResultSkipped means nothing was copied, because the volume was not
+	// empty or the image has no data at the destination.
+	CopyResultSkipped = "skipped"
+)
+
 // MountPoint is the intersection point between a volume and a container. It
 // specifies which volume is to be used and where inside a container it should
 // be mounted.
@@ -95,6 +107,11 @@ type MountPoint struct {
 	// Note Mode is not used on Windows
 	Mode string `json:"Relabel,omitempty"` // Originally field was `Relabel`"
 
+	// SELinuxLabel is the SELinux label applied to the source of the mount
+	// point by its relabel mode, z or Z, when it was set up. It is empty if
+	// the source was not relabeled.
+	SELinuxLabel string `json:",omitempty"`
+
 	// Note Propagation is not used on Windows
 	Propagation mounttypes.Propagation `json:",omitempty"` // Mount propagation string
 
@@ -102,6 +119,10 @@ type MountPoint struct {
 	// Use a pointer here so we can tell if the user set this value explicitly
 	// This allows us to error out when the user explicitly enabled copy but we can't copy due to the volume being populated
 	CopyData bool `json:"-"`
+	// CopyResult records what happened to the image data at the destination
+	// of a volume when the container was created, see the CopyResult
+	// constants. It is empty for bind mounts.
+	CopyResult string `json:",omitempty"`
 	// ID is the opaque ID used to pass to the volume driver.
 	// This should be set by calls to `Mount` and unset by calls to `Unmount`
 	ID   string `json:",omitempty"`
@@ -109,8 +130,28 @@ type MountPoint struct {
 }
 
 // Setup sets up a mount point by either mounting the volume if it is
-// configured, or creating the source directory if supplied.
-func (m *MountPoint) Setup(mountLabel string, rootUID, rootGID int) (string, error) {
+// configured, or creating the source directory if supplied. The source is
+// relabeled if the mode of the mount point is z, with the label shared by
+// all the containers, or Z, with the private label of the container.
+func (m *MountPoint) Setup(mountLabel string, rootUID, rootGID int) (path string, err error) {
+	defer func() {
+		if err != nil || !label.RelabelNeeded(m.Mode) {
+			return
+		}
+		shared := label.IsShared(m.Mode)
+		if err = label.Relabel(path, mountLabel, shared); err != nil {
+			if err == syscall.ENOTSUP {
+				// the filesystem of the source does not support labels
+				err = nil
+				return
+			}
+			path = ""
+			err = errors.Wrapf(err, "error setting label on mount source '%s'", m.Source)
+			return
+		}
+		m.SELinuxLabel = appliedLabel(mountLabel, shared)
+	}()
+
 	if m.Volume != nil {
 		if m.ID == "" {
 			m.ID = stringid.GenerateNonCryptoID()
@@ -133,14 +174,24 @@ func (m *MountPoint) Setup(mountLabel string, rootUID, rootGID int) (string, err
 			}
 		}
 	}
-	if label.RelabelNeeded(m.Mode) {
-		if err := label.Relabel(m.Source, mountLabel, label.IsShared(m.Mode)); err != nil {
-			return "", errors.Wrapf(err, "error setting label on mount source '%s'", m.Source)
-		}
-	}
 	return m.Source, nil
 }
 
+// appliedLabel returns the label set by label.Relabel with the mount label
+// of a container. The label shared by all the containers is the mount label
+// without its categories, with the s0 level.
+func appliedLabel(mountLabel string, shared bool) string {
+	if mountLabel == "" || !shared {
+		return mountLabel
+	}
+	c := strings.SplitN(mountLabel, ":", 4)
+	if len(c) < 4 {
+		return mountLabel
+	}
+	c[3] = "s0"
+	return strings.Join(c, ":")
+}
+
 // Path returns the path of a volume in a mount point.
 func (m *MountPoint) Path() string {
 	if m.Volume != nil {
@@ -267,3 +267,21 @@ func TestParseMountSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestAppliedLabel(t *testing.T) {
+	cases := []struct {
+		mountLabel string
+		shared     bool
+		expected   string
+	}{
+		{"", true, ""},
+		{"system_u:object_r:svirt_sandbox_file_t:s0:c100,c200", false, "system_u:object_r:svirt_sandbox_file_t:s0:c100,c200"},
+		{"system_u:object_r:svirt_sandbox_file_t:s0:c100,c200", true, "system_u:object_r:svirt_sandbox_file_t:s0"},
+		{"system_u:object_r:svirt_sandbox_file_t", true, "system_u:object_r:svirt_sandbox_file_t"},
+	}
+	for _, c := range cases {
+		if l := appliedLabel(c.mountLabel, c.shared); l != c.expected {
+			t.Fatalf("Expected label %q for %q (shared: %v), got %q", c.expected, c.mountLabel, c.shared, l)
+		}
+	}
+}